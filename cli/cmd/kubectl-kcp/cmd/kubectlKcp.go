@@ -27,9 +27,13 @@ import (
 	"k8s.io/component-base/version"
 	"k8s.io/klog/v2"
 
+	apibindingcmd "github.com/kcp-dev/kcp/cli/pkg/apibinding/cmd"
 	bindcmd "github.com/kcp-dev/kcp/cli/pkg/bind/cmd"
 	claimscmd "github.com/kcp-dev/kcp/cli/pkg/claims/cmd"
 	crdcmd "github.com/kcp-dev/kcp/cli/pkg/crd/cmd"
+	shardcmd "github.com/kcp-dev/kcp/cli/pkg/shard/cmd"
+	tokencmd "github.com/kcp-dev/kcp/cli/pkg/token/cmd"
+	whoamicmd "github.com/kcp-dev/kcp/cli/pkg/whoami/cmd"
 	workspacecmd "github.com/kcp-dev/kcp/cli/pkg/workspace/cmd"
 	"github.com/kcp-dev/kcp/sdk/cmd/help"
 )
@@ -80,5 +84,17 @@ func KubectlKcpCommand() *cobra.Command {
 	claimsCmd := claimscmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
 	root.AddCommand(claimsCmd)
 
+	apibindingCmd := apibindingcmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	root.AddCommand(apibindingCmd)
+
+	shardCmd := shardcmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	root.AddCommand(shardCmd)
+
+	whoamiCmd := whoamicmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	root.AddCommand(whoamiCmd)
+
+	tokenCmd := tokencmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	root.AddCommand(tokenCmd)
+
 	return root
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/cli/pkg/claims/plugin"
+)
+
+var (
+	apibindingClaimsExample = `
+	# Shows the permission claims of the APIBinding "cert-manager" and how they differ from what its APIExport requests.
+	%[1]s apibinding claims cert-manager
+
+	# Accepts all permission claims the APIExport currently requests and removes stale ones.
+	%[1]s apibinding claims cert-manager --accept-all
+	`
+)
+
+// New returns a cobra.Command for APIBinding related actions.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cliName := "kubectl"
+	if pflag.CommandLine.Name() == "kubectl-kcp" {
+		cliName = "kubectl kcp"
+	}
+
+	cmd := &cobra.Command{
+		Use:              "apibinding",
+		Short:            "Operations related to APIBindings",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	claimsOpts := plugin.NewAPIBindingClaimsOptions(streams)
+	claimsCmd := &cobra.Command{
+		Use:          "claims <apibinding_name>",
+		Short:        "Show the permission claims of an APIBinding compared with its APIExport",
+		Example:      fmt.Sprintf(apibindingClaimsExample, cliName),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := claimsOpts.Complete(args); err != nil {
+				return err
+			}
+			if err := claimsOpts.Validate(); err != nil {
+				return err
+			}
+			return claimsOpts.Run(cmd.Context())
+		},
+	}
+	claimsOpts.BindFlags(claimsCmd)
+
+	cmd.AddCommand(claimsCmd)
+	return cmd
+}
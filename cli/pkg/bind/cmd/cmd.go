@@ -24,12 +24,16 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/kcp-dev/kcp/cli/pkg/bind/plugin"
+	workspaceplugin "github.com/kcp-dev/kcp/cli/pkg/workspace/plugin"
 )
 
 var (
 	bindExampleUses = `
 	# Create an APIBinding named "my-binding" that binds to the APIExport "my-export" in the "root:my-service" workspace.
 	%[1]s bind apiexport root:my-service:my-export --name my-binding
+
+	# Bind to the APIExport "my-export", accepting its permission claim for configmaps and rejecting all others.
+	%[1]s bind apiexport root:my-service:my-export --accept-permission-claim configmaps --no-prompt
 	`
 )
 
@@ -63,6 +67,7 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 		},
 	}
 	bindOpts.BindFlags(bindCmd)
+	bindCmd.ValidArgsFunction = workspaceplugin.NewWorkspaceCompleter(bindOpts.Options).AbsolutePathsCompletion()
 
 	cmd.AddCommand(bindCmd)
 	return cmd
@@ -20,12 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/spf13/cobra"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -47,6 +49,14 @@ type BindOptions struct {
 	APIBindingName string
 	// BindWaitTimeout is how long to wait for the APIBinding to be created and successful.
 	BindWaitTimeout time.Duration
+	// AcceptedPermissionClaims are the `resource.group` of the permission claims to accept.
+	AcceptedPermissionClaims []string
+	// RejectedPermissionClaims are the `resource.group` of the permission claims to reject.
+	RejectedPermissionClaims []string
+	// AcceptAllPermissionClaims accepts all permission claims of the APIExport.
+	AcceptAllPermissionClaims bool
+	// NoPrompt rejects the permission claims not accepted by flags instead of prompting for them.
+	NoPrompt bool
 }
 
 // NewBindOptions returns new BindOptions.
@@ -62,6 +72,10 @@ func (b *BindOptions) BindFlags(cmd *cobra.Command) {
 
 	cmd.Flags().StringVar(&b.APIBindingName, "name", b.APIBindingName, "Name of the APIBinding to create.")
 	cmd.Flags().DurationVar(&b.BindWaitTimeout, "timeout", time.Second*30, "Duration to wait for APIBinding to be created successfully.")
+	cmd.Flags().StringSliceVar(&b.AcceptedPermissionClaims, "accept-permission-claim", b.AcceptedPermissionClaims, "Permission claim to accept, as resource.group (or resource for the core group). Can be repeated.")
+	cmd.Flags().StringSliceVar(&b.RejectedPermissionClaims, "reject-permission-claim", b.RejectedPermissionClaims, "Permission claim to reject, as resource.group (or resource for the core group). Can be repeated.")
+	cmd.Flags().BoolVar(&b.AcceptAllPermissionClaims, "accept-all-permission-claims", b.AcceptAllPermissionClaims, "Accept all permission claims of the APIExport.")
+	cmd.Flags().BoolVar(&b.NoPrompt, "no-prompt", b.NoPrompt, "Reject permission claims not accepted by flags instead of prompting for them.")
 }
 
 // Complete ensures all fields are initialized.
@@ -89,6 +103,10 @@ func (b *BindOptions) Validate() error {
 		return fmt.Errorf("fully qualified reference to workspace where APIExport exists is required. The format is `<logical-cluster-name>:<apiexport>` or `<full>:<path>:<to>:<apiexport>`")
 	}
 
+	if err := b.validateClaimFlags(); err != nil {
+		return err
+	}
+
 	return b.Options.Validate()
 }
 
@@ -131,6 +149,27 @@ func (b *BindOptions) Run(ctx context.Context) error {
 		return err
 	}
 
+	// binding only requires the bind verb, so the APIExport might not be readable. Claims
+	// cannot be accepted then.
+	export, err := kcpclient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, apiExportName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsForbidden(err):
+		if len(b.AcceptedPermissionClaims) > 0 || b.AcceptAllPermissionClaims {
+			return fmt.Errorf("cannot accept permission claims of APIExport %s: %w", b.APIExportRef, err)
+		}
+		if _, err := fmt.Fprintf(b.ErrOut, "Warning: cannot read APIExport %s, binding without accepting permission claims.\n", b.APIExportRef); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		claims, err := b.decideClaims(export.Spec.PermissionClaims, b.In, b.Out)
+		if err != nil {
+			return err
+		}
+		binding.Spec.PermissionClaims = claims
+	}
+
 	createdBinding, err := kcpclient.Cluster(currentClusterName).ApisV1alpha1().APIBindings().Create(ctx, binding, metav1.CreateOptions{})
 	if err != nil {
 		return err
@@ -143,7 +182,7 @@ func (b *BindOptions) Run(ctx context.Context) error {
 	// wait for phase to be bound
 	if createdBinding.Status.Phase != apisv1alpha1.APIBindingPhaseBound {
 		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*500, b.BindWaitTimeout, true, func(ctx context.Context) (done bool, err error) {
-			createdBinding, err := kcpclient.Cluster(currentClusterName).ApisV1alpha1().APIBindings().Get(ctx, binding.Name, metav1.GetOptions{})
+			createdBinding, err = kcpclient.Cluster(currentClusterName).ApisV1alpha1().APIBindings().Get(ctx, binding.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, err
 			}
@@ -160,6 +199,26 @@ func (b *BindOptions) Run(ctx context.Context) error {
 		return err
 	}
 
+	return printBoundResources(b.Out, createdBinding)
+}
+
+// printBoundResources prints the resources that became available through the binding.
+func printBoundResources(out io.Writer, binding *apisv1alpha1.APIBinding) error {
+	if len(binding.Status.BoundResources) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(out, "The following resources are now available:"); err != nil {
+		return err
+	}
+	for _, r := range binding.Status.BoundResources {
+		resource := r.Resource
+		if r.Group != "" {
+			resource += "." + r.Group
+		}
+		if _, err := fmt.Fprintf(out, "  %s\n", resource); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
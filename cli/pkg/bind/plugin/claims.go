@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// claimKey returns the `resource.group` (or just `resource` for the core group) a
+// permission claim is referenced by on the command line.
+func claimKey(claim apisv1alpha1.PermissionClaim) string {
+	if claim.Group == "" {
+		return claim.Resource
+	}
+	return claim.Resource + "." + claim.Group
+}
+
+// validateClaimFlags checks that no permission claim is both accepted and rejected.
+func (b *BindOptions) validateClaimFlags() error {
+	if b.AcceptAllPermissionClaims && len(b.RejectedPermissionClaims) > 0 {
+		return fmt.Errorf("--accept-all-permission-claims and --reject-permission-claim are mutually exclusive")
+	}
+	if both := sets.New[string](b.AcceptedPermissionClaims...).Intersection(sets.New[string](b.RejectedPermissionClaims...)); both.Len() > 0 {
+		return fmt.Errorf("permission claims cannot be both accepted and rejected: %s", strings.Join(sets.List(both), ", "))
+	}
+	return nil
+}
+
+// decideClaims returns the given permission claims of an APIExport as accepted or rejected.
+// Claims not decided by the command line flags are prompted for on in, unless prompting is
+// disabled in which case they are rejected.
+func (b *BindOptions) decideClaims(claims []apisv1alpha1.PermissionClaim, in io.Reader, out io.Writer) ([]apisv1alpha1.AcceptablePermissionClaim, error) {
+	accepted := sets.New[string](b.AcceptedPermissionClaims...)
+	rejected := sets.New[string](b.RejectedPermissionClaims...)
+	known := sets.New[string]()
+	for _, claim := range claims {
+		known.Insert(claimKey(claim))
+	}
+	if unknown := accepted.Union(rejected).Difference(known); unknown.Len() > 0 {
+		return nil, fmt.Errorf("APIExport has no permission claims for %s", strings.Join(sets.List(unknown), ", "))
+	}
+
+	reader := bufio.NewReader(in)
+	result := make([]apisv1alpha1.AcceptablePermissionClaim, 0, len(claims))
+	for _, claim := range claims {
+		key := claimKey(claim)
+		var state apisv1alpha1.AcceptablePermissionClaimState
+		switch {
+		case accepted.Has(key) || b.AcceptAllPermissionClaims:
+			state = apisv1alpha1.ClaimAccepted
+		case rejected.Has(key) || b.NoPrompt:
+			state = apisv1alpha1.ClaimRejected
+		default:
+			ok, err := promptClaim(reader, out, claim)
+			if err != nil {
+				return nil, err
+			}
+			state = apisv1alpha1.ClaimRejected
+			if ok {
+				state = apisv1alpha1.ClaimAccepted
+			}
+		}
+		result = append(result, apisv1alpha1.AcceptablePermissionClaim{PermissionClaim: claim, State: state})
+	}
+	return result, nil
+}
+
+// promptClaim asks whether to accept the given claim. Anything but yes rejects it.
+func promptClaim(reader *bufio.Reader, out io.Writer, claim apisv1alpha1.PermissionClaim) (bool, error) {
+	scope := "all objects"
+	if !claim.All {
+		selectors := make([]string, 0, len(claim.ResourceSelector))
+		for _, s := range claim.ResourceSelector {
+			switch {
+			case s.Namespace == "":
+				selectors = append(selectors, s.Name)
+			case s.Name == "":
+				selectors = append(selectors, s.Namespace+"/*")
+			default:
+				selectors = append(selectors, s.Namespace+"/"+s.Name)
+			}
+		}
+		scope = strings.Join(selectors, ", ")
+	}
+	if _, err := fmt.Fprintf(out, "Accept permission claim for %s (%s)? [y/N]: ", claimKey(claim), scope); err != nil {
+		return false, err
+	}
+	answer, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	if err == io.EOF {
+		// e.g. stdin is not a terminal
+		fmt.Fprintln(out) //nolint:errcheck
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
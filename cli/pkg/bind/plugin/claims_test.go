@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestDecideClaims(t *testing.T) {
+	configMaps := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}
+	widgets := apisv1alpha1.PermissionClaim{
+		GroupResource:    apisv1alpha1.GroupResource{Group: "example.io", Resource: "widgets"},
+		ResourceSelector: []apisv1alpha1.ResourceSelector{{Namespace: "default"}},
+		IdentityHash:     "hash",
+	}
+	claims := []apisv1alpha1.PermissionClaim{configMaps, widgets}
+
+	tests := []struct {
+		name    string
+		options BindOptions
+		input   string
+		want    []apisv1alpha1.AcceptablePermissionClaimState
+		prompts int
+		wantErr string
+	}{
+		{
+			name:    "accepted and rejected by flags",
+			options: BindOptions{AcceptedPermissionClaims: []string{"configmaps"}, RejectedPermissionClaims: []string{"widgets.example.io"}},
+			want:    []apisv1alpha1.AcceptablePermissionClaimState{apisv1alpha1.ClaimAccepted, apisv1alpha1.ClaimRejected},
+		},
+		{
+			name:    "accept all",
+			options: BindOptions{AcceptAllPermissionClaims: true},
+			want:    []apisv1alpha1.AcceptablePermissionClaimState{apisv1alpha1.ClaimAccepted, apisv1alpha1.ClaimAccepted},
+		},
+		{
+			name:    "prompted",
+			input:   "yes\nn\n",
+			want:    []apisv1alpha1.AcceptablePermissionClaimState{apisv1alpha1.ClaimAccepted, apisv1alpha1.ClaimRejected},
+			prompts: 2,
+		},
+		{
+			name:    "prompted for undecided claims only",
+			options: BindOptions{RejectedPermissionClaims: []string{"configmaps"}},
+			input:   "y\n",
+			want:    []apisv1alpha1.AcceptablePermissionClaimState{apisv1alpha1.ClaimRejected, apisv1alpha1.ClaimAccepted},
+			prompts: 1,
+		},
+		{
+			name:    "end of input rejects",
+			want:    []apisv1alpha1.AcceptablePermissionClaimState{apisv1alpha1.ClaimRejected, apisv1alpha1.ClaimRejected},
+			prompts: 2,
+		},
+		{
+			name:    "no prompt",
+			options: BindOptions{NoPrompt: true, AcceptedPermissionClaims: []string{"widgets.example.io"}},
+			want:    []apisv1alpha1.AcceptablePermissionClaimState{apisv1alpha1.ClaimRejected, apisv1alpha1.ClaimAccepted},
+		},
+		{
+			name:    "unknown claim",
+			options: BindOptions{AcceptedPermissionClaims: []string{"secrets"}},
+			wantErr: "APIExport has no permission claims for secrets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			got, err := tt.options.decideClaims(claims, strings.NewReader(tt.input), out)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(claims))
+			for i := range got {
+				require.Equal(t, claims[i], got[i].PermissionClaim)
+				require.Equal(t, tt.want[i], got[i].State, "claim %s", claimKey(claims[i]))
+			}
+			require.Equal(t, tt.prompts, strings.Count(out.String(), "Accept permission claim"))
+		})
+	}
+}
+
+func TestValidateClaimFlags(t *testing.T) {
+	require.NoError(t, (&BindOptions{AcceptedPermissionClaims: []string{"a"}, RejectedPermissionClaims: []string{"b"}}).validateClaimFlags())
+	require.Error(t, (&BindOptions{AcceptedPermissionClaims: []string{"a"}, RejectedPermissionClaims: []string{"a"}}).validateClaimFlags())
+	require.Error(t, (&BindOptions{AcceptAllPermissionClaims: true, RejectedPermissionClaims: []string{"a"}}).validateClaimFlags())
+}
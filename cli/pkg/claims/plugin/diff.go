@@ -0,0 +1,221 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	apiv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// claimState is the state of a permission claim of an APIBinding with respect to its APIExport.
+type claimState string
+
+const (
+	// claimStateStale is a claim of the APIBinding that the APIExport no longer requests as is.
+	claimStateStale claimState = "Stale"
+	// claimStatePending is a claim requested by the APIExport that the APIBinding neither accepts nor rejects.
+	claimStatePending claimState = "Pending"
+)
+
+// claimDiff is a permission claim of an APIBinding compared with the claims requested by the APIExport.
+type claimDiff struct {
+	Claim apiv1alpha1.PermissionClaim
+	// State is Accepted or Rejected for claims of the APIBinding requested as is by the APIExport,
+	// and Stale or Pending otherwise.
+	State claimState
+	// Change describes the difference to the claim requested by the APIExport, if any.
+	Change string
+}
+
+// diffClaims compares the claims of an APIBinding with the claims requested by its APIExport.
+// Claims of the APIBinding come first, in their order, followed by the pending claims.
+func diffClaims(bound []apiv1alpha1.AcceptablePermissionClaim, requested []apiv1alpha1.PermissionClaim) []claimDiff {
+	diffs := make([]claimDiff, 0, len(bound)+len(requested))
+	for _, claim := range bound {
+		diff := claimDiff{Claim: claim.PermissionClaim, State: claimState(claim.State)}
+		match, found := findClaim(requested, claim.PermissionClaim)
+		switch {
+		case !found:
+			diff.State, diff.Change = claimStateStale, "- no longer requested"
+		case !sameScope(match, claim.PermissionClaim):
+			diff.State, diff.Change = claimStateStale, "~ requested objects changed"
+		}
+		diffs = append(diffs, diff)
+	}
+	for _, claim := range requested {
+		if _, found := findAcceptableClaim(bound, claim); !found {
+			diffs = append(diffs, claimDiff{Claim: claim, State: claimStatePending, Change: "+ newly requested"})
+		}
+	}
+	return diffs
+}
+
+func findClaim(claims []apiv1alpha1.PermissionClaim, claim apiv1alpha1.PermissionClaim) (apiv1alpha1.PermissionClaim, bool) {
+	for _, c := range claims {
+		if c.Equal(claim) {
+			return c, true
+		}
+	}
+	return apiv1alpha1.PermissionClaim{}, false
+}
+
+func findAcceptableClaim(claims []apiv1alpha1.AcceptablePermissionClaim, claim apiv1alpha1.PermissionClaim) (apiv1alpha1.AcceptablePermissionClaim, bool) {
+	for _, c := range claims {
+		if c.PermissionClaim.Equal(claim) {
+			return c, true
+		}
+	}
+	return apiv1alpha1.AcceptablePermissionClaim{}, false
+}
+
+// sameScope returns whether both claims claim the same objects.
+func sameScope(a, b apiv1alpha1.PermissionClaim) bool {
+	return a.All == b.All && apiequality.Semantic.DeepEqual(a.ResourceSelector, b.ResourceSelector)
+}
+
+// APIBindingClaimsOptions contains the options for inspecting the permission claims of an
+// APIBinding against its APIExport.
+type APIBindingClaimsOptions struct {
+	*base.Options
+
+	// APIBindingName is the name of the APIBinding to inspect.
+	APIBindingName string
+	// AcceptAll accepts all claims currently requested by the APIExport and drops stale claims.
+	AcceptAll bool
+}
+
+// NewAPIBindingClaimsOptions returns new APIBindingClaimsOptions.
+func NewAPIBindingClaimsOptions(streams genericclioptions.IOStreams) *APIBindingClaimsOptions {
+	return &APIBindingClaimsOptions{
+		Options: base.NewOptions(streams),
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *APIBindingClaimsOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().BoolVar(&o.AcceptAll, "accept-all", o.AcceptAll, "Accept all permission claims currently requested by the APIExport and remove stale ones.")
+}
+
+// Complete ensures all fields are initialized.
+func (o *APIBindingClaimsOptions) Complete(args []string) error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		o.APIBindingName = args[0]
+	}
+	return nil
+}
+
+// Validate validates the APIBindingClaimsOptions are complete and usable.
+func (o *APIBindingClaimsOptions) Validate() error {
+	if o.APIBindingName == "" {
+		return errors.New("the name of the APIBinding is required as an argument")
+	}
+	return o.Options.Validate()
+}
+
+// Run prints the permission claims of the APIBinding and their difference to the claims requested
+// by the APIExport, and accepts all of them if requested.
+func (o *APIBindingClaimsOptions) Run(ctx context.Context) error {
+	cfg, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	_, currentClusterName, err := pluginhelpers.ParseClusterURL(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("current URL %q does not point to workspace", cfg.Host)
+	}
+	kcpClusterClient, err := newKCPClusterClient(o.ClientConfig)
+	if err != nil {
+		return fmt.Errorf("error while creating kcp client %w", err)
+	}
+
+	binding, err := kcpClusterClient.Cluster(currentClusterName).ApisV1alpha1().APIBindings().Get(ctx, o.APIBindingName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error finding apibinding: %w", err)
+	}
+
+	// Prefer what the APIExport requests right now. As consumers often may only bind, but not
+	// read the APIExport, fall back to what the APIBinding controller observed last.
+	requested := binding.Status.ExportPermissionClaims
+	if ref := binding.Spec.Reference.Export; ref != nil {
+		exportPath := logicalcluster.NewPath(ref.Path)
+		if exportPath.Empty() {
+			exportPath = currentClusterName
+		}
+		export, err := kcpClusterClient.Cluster(exportPath).ApisV1alpha1().APIExports().Get(ctx, ref.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsForbidden(err) || apierrors.IsNotFound(err):
+			fmt.Fprintf(o.ErrOut, "Warning: cannot read APIExport %s, comparing with the claims last observed on the APIBinding.\n", exportPath.Join(ref.Name)) //nolint:errcheck
+		case err != nil:
+			return err
+		default:
+			requested = export.Spec.PermissionClaims
+		}
+	}
+
+	diffs := diffClaims(binding.Spec.PermissionClaims, requested)
+	if err := printClaimDiffs(o.Out, diffs); err != nil {
+		return err
+	}
+	if !o.AcceptAll {
+		return nil
+	}
+
+	binding = binding.DeepCopy()
+	binding.Spec.PermissionClaims = make([]apiv1alpha1.AcceptablePermissionClaim, 0, len(requested))
+	for _, claim := range requested {
+		binding.Spec.PermissionClaims = append(binding.Spec.PermissionClaims, apiv1alpha1.AcceptablePermissionClaim{PermissionClaim: claim, State: apiv1alpha1.ClaimAccepted})
+	}
+	if _, err := kcpClusterClient.Cluster(currentClusterName).ApisV1alpha1().APIBindings().Update(ctx, binding, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error accepting permission claims of apibinding %s: %w", binding.Name, err)
+	}
+	_, err = fmt.Fprintf(o.Out, "Accepted all %d permission claims of apibinding %s.\n", len(requested), binding.Name)
+	return err
+}
+
+func printClaimDiffs(w io.Writer, diffs []claimDiff) error {
+	out := printers.GetNewTabWriter(w)
+	defer out.Flush()
+
+	if _, err := fmt.Fprintln(out, "CLAIM\tSTATE\tCHANGE"); err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		if _, err := fmt.Fprintf(out, "%s\t%s\t%s\n", diff.Claim.String(), diff.State, diff.Change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestDiffClaims(t *testing.T) {
+	claim := func(resource string, namespaces ...string) apiv1alpha1.PermissionClaim {
+		c := apiv1alpha1.PermissionClaim{GroupResource: apiv1alpha1.GroupResource{Resource: resource}}
+		if len(namespaces) == 0 {
+			c.All = true
+		}
+		for _, ns := range namespaces {
+			c.ResourceSelector = append(c.ResourceSelector, apiv1alpha1.ResourceSelector{Namespace: ns})
+		}
+		return c
+	}
+	bound := func(c apiv1alpha1.PermissionClaim, state apiv1alpha1.AcceptablePermissionClaimState) apiv1alpha1.AcceptablePermissionClaim {
+		return apiv1alpha1.AcceptablePermissionClaim{PermissionClaim: c, State: state}
+	}
+
+	got := diffClaims(
+		[]apiv1alpha1.AcceptablePermissionClaim{
+			bound(claim("configmaps"), apiv1alpha1.ClaimAccepted),
+			bound(claim("secrets"), apiv1alpha1.ClaimRejected),
+			bound(claim("services", "default"), apiv1alpha1.ClaimAccepted),
+			bound(claim("pods"), apiv1alpha1.ClaimAccepted),
+		},
+		[]apiv1alpha1.PermissionClaim{
+			claim("configmaps"),
+			claim("secrets"),
+			claim("services", "default", "kube-system"),
+			claim("events"),
+		},
+	)
+
+	require.Equal(t, []claimDiff{
+		{Claim: claim("configmaps"), State: "Accepted"},
+		{Claim: claim("secrets"), State: "Rejected"},
+		{Claim: claim("services", "default"), State: claimStateStale, Change: "~ requested objects changed"},
+		{Claim: claim("pods"), State: claimStateStale, Change: "- no longer requested"},
+		{Claim: claim("events"), State: claimStatePending, Change: "+ newly requested"},
+	}, got)
+}
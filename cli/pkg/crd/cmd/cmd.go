@@ -34,6 +34,10 @@ var (
 
 	# Convert a CRD from STDIN
 	kubectl get crd foo -o yaml | %[1]s crd snapshot -f - --prefix today > output.yaml
+
+	# Convert the CRDs in a yaml file to APIResourceSchemas in the current workspace, and export them
+	# with the APIExport my-export, creating it if necessary.
+	%[1]s crd convert-and-export my-export -f crds.yaml
 `
 )
 
@@ -71,7 +75,34 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 
 	snapshotOptions.BindFlags(snapshotCommand)
 
+	convertAndExportOptions := plugin.NewConvertAndExportOptions(streams)
+
+	convertAndExportCommand := &cobra.Command{
+		Use:          "convert-and-export <apiexport-name> -f FILE [--prefix PREFIX]",
+		Short:        "Convert CRDs to APIResourceSchemas and export them with an APIExport in the current workspace",
+		Example:      fmt.Sprintf(crdExample, "kubectl kcp"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return c.Help()
+			}
+
+			if err := convertAndExportOptions.Complete(args); err != nil {
+				return err
+			}
+
+			if err := convertAndExportOptions.Validate(); err != nil {
+				return err
+			}
+
+			return convertAndExportOptions.Run(c.Context())
+		},
+	}
+
+	convertAndExportOptions.BindFlags(convertAndExportCommand)
+
 	cmd.AddCommand(snapshotCommand)
+	cmd.AddCommand(convertAndExportCommand)
 
 	return cmd
 }
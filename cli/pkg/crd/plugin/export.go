@@ -0,0 +1,285 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// ConvertAndExportOptions contains options for converting CRDs to APIResourceSchemas and
+// exporting them with an APIExport in the current workspace.
+type ConvertAndExportOptions struct {
+	*base.Options
+
+	// APIExportName is the name of the APIExport to create or update.
+	APIExportName string
+	// Filenames are the files containing the CRDs, or - for stdin.
+	Filenames []string
+	// Prefix is the prefix of the APIResourceSchema names. If empty, a hash of the schema is used,
+	// such that unchanged CRDs map to the same APIResourceSchema.
+	Prefix string
+
+	kcpClusterClient kcpclientset.ClusterInterface
+	currentCluster   logicalcluster.Path
+}
+
+// NewConvertAndExportOptions provides an instance of ConvertAndExportOptions with default values.
+func NewConvertAndExportOptions(streams genericclioptions.IOStreams) *ConvertAndExportOptions {
+	return &ConvertAndExportOptions{
+		Options: base.NewOptions(streams),
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *ConvertAndExportOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().StringSliceVarP(&o.Filenames, "filename", "f", o.Filenames, "Paths to files containing the CRDs to export, or - for stdin")
+	cmd.Flags().StringVar(&o.Prefix, "prefix", o.Prefix, "Prefix to use for the APIResourceSchemas' names, before <resource>.<group>. Defaults to a hash of the schema")
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *ConvertAndExportOptions) Complete(args []string) error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		o.APIExportName = args[0]
+	}
+
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	_, currentCluster, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current URL %q does not point to workspace", config.Host)
+	}
+	o.currentCluster = currentCluster
+
+	o.kcpClusterClient, err = newKCPClusterClient(o.ClientConfig)
+	return err
+}
+
+// Validate validates the ConvertAndExportOptions are complete and usable.
+func (o *ConvertAndExportOptions) Validate() error {
+	var errs []error
+
+	if err := o.Options.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if o.APIExportName == "" {
+		errs = append(errs, errors.New("the name of the APIExport is required as an argument"))
+	}
+
+	if len(o.Filenames) == 0 {
+		errs = append(errs, fmt.Errorf("--filename is required"))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// Run converts the CRDs to APIResourceSchemas, creates those that do not exist yet, points the
+// APIExport to them and prints how to bind it.
+func (o *ConvertAndExportOptions) Run(ctx context.Context) error {
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	for _, filename := range o.Filenames {
+		in, closeIn, err := openInput(filename, o.In)
+		if err != nil {
+			return err
+		}
+		read, err := readCRDs(in)
+		closeIn()
+		if err != nil {
+			return fmt.Errorf("error reading CRDs from %s: %w", filename, err)
+		}
+		crds = append(crds, read...)
+	}
+	if len(crds) == 0 {
+		return errors.New("no CRDs found")
+	}
+
+	schemas := make([]*apisv1alpha1.APIResourceSchema, 0, len(crds))
+	for _, crd := range crds {
+		crd, warnings := withoutCaveats(crd)
+		for _, warning := range warnings {
+			fmt.Fprintf(o.ErrOut, "Warning: %s\n", warning) //nolint:errcheck
+		}
+		schema, err := convertCRD(crd, o.Prefix)
+		if err != nil {
+			return fmt.Errorf("error converting CRD %s: %w", crd.Name, err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	apis := o.kcpClusterClient.Cluster(o.currentCluster).ApisV1alpha1()
+	for _, schema := range schemas {
+		_, err := apis.APIResourceSchemas().Create(ctx, schema, metav1.CreateOptions{})
+		switch {
+		case apierrors.IsAlreadyExists(err):
+			existing, err := apis.APIResourceSchemas().Get(ctx, schema.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if !apiequality.Semantic.DeepEqual(existing.Spec, schema.Spec) {
+				return fmt.Errorf("apiresourceschema %s exists with a different schema; APIResourceSchemas are immutable, choose another --prefix", schema.Name)
+			}
+			fmt.Fprintf(o.Out, "apiresourceschema %s unchanged\n", schema.Name) //nolint:errcheck
+		case err != nil:
+			return fmt.Errorf("error creating apiresourceschema %s: %w", schema.Name, err)
+		default:
+			fmt.Fprintf(o.Out, "apiresourceschema %s created\n", schema.Name) //nolint:errcheck
+		}
+	}
+
+	export, err := apis.APIExports().Get(ctx, o.APIExportName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		export = &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{Name: o.APIExportName},
+			Spec:       apisv1alpha1.APIExportSpec{LatestResourceSchemas: latestResourceSchemas(nil, schemas)},
+		}
+		if _, err := apis.APIExports().Create(ctx, export, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error creating apiexport %s: %w", o.APIExportName, err)
+		}
+		fmt.Fprintf(o.Out, "apiexport %s created\n", o.APIExportName) //nolint:errcheck
+	case err != nil:
+		return fmt.Errorf("error getting apiexport %s: %w", o.APIExportName, err)
+	default:
+		latest := latestResourceSchemas(export.Spec.LatestResourceSchemas, schemas)
+		if apiequality.Semantic.DeepEqual(latest, export.Spec.LatestResourceSchemas) {
+			fmt.Fprintf(o.Out, "apiexport %s unchanged\n", o.APIExportName) //nolint:errcheck
+			break
+		}
+		export = export.DeepCopy()
+		export.Spec.LatestResourceSchemas = latest
+		if _, err := apis.APIExports().Update(ctx, export, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error updating apiexport %s: %w", o.APIExportName, err)
+		}
+		fmt.Fprintf(o.Out, "apiexport %s updated\n", o.APIExportName) //nolint:errcheck
+	}
+
+	_, err = fmt.Fprintf(o.Out, "\nTo consume the exported APIs, run in any workspace:\n\n  kubectl kcp bind apiexport %s\n", o.currentCluster.Join(o.APIExportName))
+	return err
+}
+
+// withoutCaveats returns the CRD with the parts kcp does not support removed, and warnings
+// about the behaviour that differs from a CRD.
+func withoutCaveats(crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, []string) {
+	var warnings []string
+
+	if crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter {
+		crd = crd.DeepCopy()
+		crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter}
+		warnings = append(warnings, fmt.Sprintf("CRD %s uses a conversion webhook, which kcp does not call. Create an APIConversion with the name of the APIResourceSchema to convert between versions.", crd.Name))
+	}
+
+	if crd.Spec.PreserveUnknownFields {
+		warnings = append(warnings, fmt.Sprintf("CRD %s preserves unknown fields, objects are not pruned.", crd.Name))
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Schema != nil && version.Schema.OpenAPIV3Schema != nil && ptrTrue(version.Schema.OpenAPIV3Schema.XPreserveUnknownFields) {
+			warnings = append(warnings, fmt.Sprintf("version %s of CRD %s sets x-kubernetes-preserve-unknown-fields on its root, objects are not pruned.", version.Name, crd.Name))
+		}
+	}
+
+	return crd, warnings
+}
+
+func ptrTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// convertCRD converts the CRD to an APIResourceSchema. Without prefix, a hash of the schema is
+// used as prefix.
+func convertCRD(crd *apiextensionsv1.CustomResourceDefinition, prefix string) (*apisv1alpha1.APIResourceSchema, error) {
+	if prefix != "" {
+		return apisv1alpha1.CRDToAPIResourceSchema(crd, prefix)
+	}
+
+	schema, err := apisv1alpha1.CRDToAPIResourceSchema(crd, "v0")
+	if err != nil {
+		return nil, err
+	}
+	spec, err := json.Marshal(schema.Spec)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(spec)
+	schema.Name = "v" + hex.EncodeToString(sum[:])[:8] + "." + crd.Name
+	return schema, nil
+}
+
+// latestResourceSchemas replaces the schemas of the same resources as the given schemas
+// in latest, and appends the others.
+func latestResourceSchemas(latest []string, schemas []*apisv1alpha1.APIResourceSchema) []string {
+	result := append([]string(nil), latest...)
+	for _, schema := range schemas {
+		resource := schema.Spec.Names.Plural + "." + schema.Spec.Group
+		replaced := false
+		for i, name := range result {
+			if _, r, ok := strings.Cut(name, "."); ok && r == resource {
+				result[i], replaced = schema.Name, true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, schema.Name)
+		}
+	}
+	return result
+}
+
+func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.ClusterInterface, error) {
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clusterConfig := rest.CopyConfig(config)
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = ""
+	clusterConfig.Host = u.String()
+	clusterConfig.UserAgent = rest.DefaultKubernetesUserAgent()
+	return kcpclientset.NewForConfig(clusterConfig)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+)
+
+var widgetsCRDYaml = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.io
+spec:
+  group: example.io
+  names:
+    kind: Widget
+    listKind: WidgetList
+    plural: widgets
+    singular: widget
+  scope: Namespaced
+  conversion:
+    strategy: Webhook
+    webhook:
+      conversionReviewVersions: ["v1"]
+      clientConfig:
+        service:
+          name: widget-conversion
+          namespace: default
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+  - name: v1beta1
+    served: true
+    storage: false
+    schema:
+      openAPIV3Schema:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+`
+
+func TestConvertAndExport(t *testing.T) {
+	cluster := logicalcluster.NewPath("root:org:ws")
+	client := kcpfakeclient.NewSimpleClientset(&apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-export",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster.String()},
+		},
+		Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"old.widgets.example.io", "old.gadgets.example.io"}},
+	})
+
+	run := func() (string, string) {
+		streams, stdin, stdout, stderr := genericclioptions.NewTestIOStreams()
+		stdin.WriteString(widgetsCRDYaml) //nolint:errcheck
+		opts := NewConvertAndExportOptions(streams)
+		opts.APIExportName = "my-export"
+		opts.Filenames = []string{"-"}
+		opts.kcpClusterClient = client
+		opts.currentCluster = cluster
+		require.NoError(t, opts.Run(context.Background()))
+		return stdout.String(), stderr.String()
+	}
+
+	out, warnings := run()
+	require.Contains(t, warnings, "CRD widgets.example.io uses a conversion webhook")
+	require.Contains(t, warnings, "version v1 of CRD widgets.example.io sets x-kubernetes-preserve-unknown-fields")
+	require.Contains(t, out, "apiexport my-export updated\n")
+	require.Contains(t, out, "kubectl kcp bind apiexport root:org:ws:my-export\n")
+
+	export, err := client.Cluster(cluster).ApisV1alpha1().APIExports().Get(context.Background(), "my-export", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, export.Spec.LatestResourceSchemas, 2)
+	schemaName := export.Spec.LatestResourceSchemas[0]
+	require.True(t, strings.HasSuffix(schemaName, ".widgets.example.io"), schemaName)
+	require.NotEqual(t, "old.widgets.example.io", schemaName)
+	require.Equal(t, "old.gadgets.example.io", export.Spec.LatestResourceSchemas[1])
+
+	schema, err := client.Cluster(cluster).ApisV1alpha1().APIResourceSchemas().Get(context.Background(), schemaName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, apisv1alpha1.ConversionStrategyType("None"), schema.Spec.Conversion.Strategy)
+	require.Len(t, schema.Spec.Versions, 2)
+
+	// an unchanged CRD maps to the same schema.
+	out, _ = run()
+	require.Contains(t, out, "apiresourceschema "+schemaName+" unchanged\n")
+	require.Contains(t, out, "apiexport my-export unchanged\n")
+}
+
+func TestLatestResourceSchemas(t *testing.T) {
+	schema := func(name, plural, group string) *apisv1alpha1.APIResourceSchema {
+		s := &apisv1alpha1.APIResourceSchema{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		s.Spec.Group = group
+		s.Spec.Names.Plural = plural
+		return s
+	}
+
+	got := latestResourceSchemas(
+		[]string{"v1.widgets.example.io", "v1.gadgets.example.io"},
+		[]*apisv1alpha1.APIResourceSchema{schema("v2.widgets.example.io", "widgets", "example.io"), schema("v1.things.example.io", "things", "example.io")},
+	)
+	require.Equal(t, []string{"v2.widgets.example.io", "v1.gadgets.example.io", "v1.things.example.io"}, got)
+}
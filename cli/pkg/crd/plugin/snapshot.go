@@ -89,19 +89,15 @@ func (o *SnapshotOptions) Validate() error {
 }
 
 func (o *SnapshotOptions) Run() error {
-	var in io.Reader
-
-	if o.Filename == "-" {
-		in = o.In
-	} else {
-		f, err := os.Open(o.Filename)
-		if err != nil {
-			return fmt.Errorf("error opening %s: %w", o.Filename, err)
-		}
-
-		defer f.Close()
+	in, closeIn, err := openInput(o.Filename, o.In)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
 
-		in = f
+	crds, err := readCRDs(in)
+	if err != nil {
+		return err
 	}
 
 	scheme := runtime.NewScheme()
@@ -131,44 +127,69 @@ func (o *SnapshotOptions) Run() error {
 
 	encoder := codecs.EncoderForVersion(info.Serializer, apisv1alpha1.SchemeGroupVersion)
 
-	d := kubeyaml.NewYAMLReader(bufio.NewReader(in))
+	for _, crd := range crds {
+		apiResourceSchema, err := apisv1alpha1.CRDToAPIResourceSchema(crd, o.Prefix)
+		if err != nil {
+			return fmt.Errorf("error converting CRD: %w", err)
+		}
+
+		out, err := runtime.Encode(encoder, apiResourceSchema)
+		if err != nil {
+			return fmt.Errorf("error converting CRD to an APIResourceSchema: %w", err)
+		}
+
+		fmt.Fprintln(o.Out, string(out))
+		fmt.Fprintln(o.Out, "---")
+	}
+
+	return nil
+}
 
+// openInput opens the named file, or returns stdin for "-".
+func openInput(filename string, stdin io.Reader) (io.Reader, func(), error) {
+	if filename == "-" {
+		return stdin, func() {}, nil
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening %s: %w", filename, err)
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// readCRDs decodes all CRDs of a multi-document YAML stream.
+func readCRDs(in io.Reader) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	scheme := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDecoder(apiextensionsv1.SchemeGroupVersion)
+
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	d := kubeyaml.NewYAMLReader(bufio.NewReader(in))
 	for {
 		doc, err := d.Read()
 		if errors.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if len(bytes.TrimSpace(doc)) == 0 {
 			continue
 		}
 
-		decoded, _, err := codecs.UniversalDecoder(apiextensionsv1.SchemeGroupVersion).Decode(doc, nil, nil)
+		decoded, _, err := decoder.Decode(doc, nil, nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		crd, ok := decoded.(*apiextensionsv1.CustomResourceDefinition)
 		if !ok {
-			return fmt.Errorf("unexpected type for CRD %T", decoded)
-		}
-
-		apiResourceSchema, err := apisv1alpha1.CRDToAPIResourceSchema(crd, o.Prefix)
-		if err != nil {
-			return fmt.Errorf("error converting CRD: %w", err)
-		}
-
-		out, err := runtime.Encode(encoder, apiResourceSchema)
-		if err != nil {
-			return fmt.Errorf("error converting CRD to an APIResourceSchema: %w", err)
+			return nil, fmt.Errorf("unexpected type for CRD %T", decoded)
 		}
-
-		fmt.Fprintln(o.Out, string(out))
-		fmt.Fprintln(o.Out, "---")
+		crds = append(crds, crd)
 	}
-
-	return nil
+	return crds, nil
 }
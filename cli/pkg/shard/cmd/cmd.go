@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/cli/pkg/shard/plugin"
+)
+
+var (
+	shardExample = `
+	# List all shards with their readiness, scheduling state and usage.
+	%[1]s shard list
+
+	# Stop scheduling new workspaces to the shard "shard-2".
+	%[1]s shard cordon shard-2
+
+	# Migrate all workspaces away from the shard "shard-2" and wait until it is empty.
+	%[1]s shard drain shard-2 --timeout 1h
+
+	# Schedule workspaces to the shard "shard-2" again, stopping a drain.
+	%[1]s shard uncordon shard-2
+
+	# Show the URLs, usage, drain progress and conditions of the shard "shard-2".
+	%[1]s shard status shard-2
+	`
+)
+
+// New returns a cobra.Command for shard administration.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cliName := "kubectl"
+	if pflag.CommandLine.Name() == "kubectl-kcp" {
+		cliName = "kubectl kcp"
+	}
+
+	cmd := &cobra.Command{
+		Use:              "shard",
+		Short:            "Operations related to administering shards",
+		Example:          fmt.Sprintf(shardExample, cliName),
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	listOpts := plugin.NewListOptions(streams)
+	listCmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the shards",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := listOpts.Complete(); err != nil {
+				return err
+			}
+			if err := listOpts.Validate(); err != nil {
+				return err
+			}
+			return listOpts.Run(cmd.Context())
+		},
+	}
+	listOpts.BindFlags(listCmd)
+	cmd.AddCommand(listCmd)
+
+	for _, cordon := range []bool{true, false} {
+		use, short := "cordon <shard>", "Stop scheduling new logical clusters to the shard"
+		if !cordon {
+			use, short = "uncordon <shard>", "Schedule new logical clusters to the shard again, stopping a drain"
+		}
+		cordonOpts := plugin.NewCordonOptions(streams, cordon)
+		cordonCmd := &cobra.Command{
+			Use:          use,
+			Short:        short,
+			Args:         cobra.ExactArgs(1),
+			SilenceUsage: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if err := cordonOpts.Complete(args); err != nil {
+					return err
+				}
+				if err := cordonOpts.Validate(); err != nil {
+					return err
+				}
+				return cordonOpts.Run(cmd.Context())
+			},
+		}
+		cordonOpts.BindFlags(cordonCmd)
+		cmd.AddCommand(cordonCmd)
+	}
+
+	drainOpts := plugin.NewDrainOptions(streams)
+	drainCmd := &cobra.Command{
+		Use:          "drain <shard>",
+		Short:        "Migrate all workspaces away from the shard",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := drainOpts.Complete(args); err != nil {
+				return err
+			}
+			if err := drainOpts.Validate(); err != nil {
+				return err
+			}
+			return drainOpts.Run(cmd.Context())
+		},
+	}
+	drainOpts.BindFlags(drainCmd)
+	cmd.AddCommand(drainCmd)
+
+	statusOpts := plugin.NewStatusOptions(streams)
+	statusCmd := &cobra.Command{
+		Use:          "status <shard>",
+		Short:        "Show the status of the shard",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := statusOpts.Complete(args); err != nil {
+				return err
+			}
+			if err := statusOpts.Validate(); err != nil {
+				return err
+			}
+			return statusOpts.Run(cmd.Context())
+		},
+	}
+	statusOpts.BindFlags(statusCmd)
+	cmd.AddCommand(statusCmd)
+
+	return cmd
+}
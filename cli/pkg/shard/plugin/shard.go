@@ -0,0 +1,393 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/core/v1alpha1"
+)
+
+// ShardOptions contains the options common to all shard commands. Shards are managed
+// through the Shard objects in the root workspace.
+type ShardOptions struct {
+	*base.Options
+
+	kcpClusterClient kcpclientset.ClusterInterface
+	// for testing
+	now func() time.Time
+}
+
+// NewShardOptions returns new ShardOptions.
+func NewShardOptions(streams genericclioptions.IOStreams) *ShardOptions {
+	return &ShardOptions{
+		Options: base.NewOptions(streams),
+		now:     time.Now,
+	}
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *ShardOptions) Complete() error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	kcpClusterClient, err := newKCPClusterClient(o.ClientConfig)
+	if err != nil {
+		return err
+	}
+	o.kcpClusterClient = kcpClusterClient
+	return nil
+}
+
+func (o *ShardOptions) shards() corev1alpha1client.ShardInterface {
+	return o.kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards()
+}
+
+// ListOptions contains the options for listing shards.
+type ListOptions struct {
+	*ShardOptions
+}
+
+// NewListOptions returns new ListOptions.
+func NewListOptions(streams genericclioptions.IOStreams) *ListOptions {
+	return &ListOptions{ShardOptions: NewShardOptions(streams)}
+}
+
+// Run lists the shards with their scheduling state and usage.
+func (o *ListOptions) Run(ctx context.Context) error {
+	shards, err := o.shards().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list shards: %w", err)
+	}
+
+	out := printers.GetNewTabWriter(o.Out)
+	defer out.Flush()
+
+	if _, err := fmt.Fprintln(out, "NAME\tREADY\tSCHEDULING\tLOGICAL CLUSTERS\tQPS HEADROOM\tURL\tAGE"); err != nil {
+		return err
+	}
+	for i := range shards.Items {
+		shard := &shards.Items[i]
+		logicalClusters, headroom := "<unknown>", "<unknown>"
+		if usage := shard.Status.Usage; usage != nil {
+			logicalClusters = strconv.FormatInt(usage.LogicalClusters, 10)
+			if usage.QPSHeadroom != nil {
+				headroom = strconv.FormatInt(*usage.QPSHeadroom, 10)
+			}
+		}
+		if _, err := fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			shard.Name,
+			readiness(shard),
+			scheduling(shard),
+			logicalClusters,
+			headroom,
+			shard.Spec.BaseURL,
+			duration.HumanDuration(o.now().Sub(shard.CreationTimestamp.Time)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CordonOptions contains the options for cordoning or uncordoning a shard.
+type CordonOptions struct {
+	*ShardOptions
+
+	// Name is the name of the shard.
+	Name string
+	// Cordon is true to cordon the shard, and false to uncordon it. Uncordoning also stops a drain.
+	Cordon bool
+}
+
+// NewCordonOptions returns new CordonOptions.
+func NewCordonOptions(streams genericclioptions.IOStreams, cordon bool) *CordonOptions {
+	return &CordonOptions{ShardOptions: NewShardOptions(streams), Cordon: cordon}
+}
+
+// Complete ensures all fields are initialized.
+func (o *CordonOptions) Complete(args []string) error {
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+	return o.ShardOptions.Complete()
+}
+
+// Validate validates the CordonOptions are complete and usable.
+func (o *CordonOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("shard name is required")
+	}
+	return o.Options.Validate()
+}
+
+// Run cordons or uncordons the shard.
+func (o *CordonOptions) Run(ctx context.Context) error {
+	spec := fmt.Sprintf(`{"spec":{"cordoned":%t}}`, o.Cordon)
+	verb := "cordoned"
+	if !o.Cordon {
+		spec = `{"spec":{"cordoned":false,"drain":false}}`
+		verb = "uncordoned"
+	}
+	if _, err := o.shards().Patch(ctx, o.Name, types.MergePatchType, []byte(spec), metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to update shard %q: %w", o.Name, err)
+	}
+	_, err := fmt.Fprintf(o.Out, "shard %s %s\n", o.Name, verb)
+	return err
+}
+
+// DrainOptions contains the options for draining a shard.
+type DrainOptions struct {
+	*ShardOptions
+
+	// Name is the name of the shard.
+	Name string
+	// Wait waits until all logical clusters are migrated away, reporting progress.
+	Wait bool
+	// Timeout is how long to wait for the drain to complete. Zero means no limit.
+	Timeout time.Duration
+	// PollInterval is how often the progress is checked.
+	PollInterval time.Duration
+}
+
+// NewDrainOptions returns new DrainOptions.
+func NewDrainOptions(streams genericclioptions.IOStreams) *DrainOptions {
+	return &DrainOptions{
+		ShardOptions: NewShardOptions(streams),
+		Wait:         true,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *DrainOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().BoolVar(&o.Wait, "wait", o.Wait, "Wait until all logical clusters are migrated away from the shard")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "How long to wait for the drain to complete. 0 waits forever.")
+}
+
+// Complete ensures all fields are initialized.
+func (o *DrainOptions) Complete(args []string) error {
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+	return o.ShardOptions.Complete()
+}
+
+// Validate validates the DrainOptions are complete and usable.
+func (o *DrainOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("shard name is required")
+	}
+	if o.Timeout < 0 {
+		return errors.New("--timeout must not be negative")
+	}
+	return o.Options.Validate()
+}
+
+// Run starts draining the shard and waits for it to complete if requested.
+func (o *DrainOptions) Run(ctx context.Context) error {
+	if _, err := o.shards().Patch(ctx, o.Name, types.MergePatchType, []byte(`{"spec":{"drain":true}}`), metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to drain shard %q: %w", o.Name, err)
+	}
+	if _, err := fmt.Fprintf(o.Out, "shard %s draining\n", o.Name); err != nil {
+		return err
+	}
+	if !o.Wait {
+		return nil
+	}
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	lastRemaining := int64(-1)
+	err := wait.PollUntilContextCancel(ctx, o.PollInterval, true, func(ctx context.Context) (bool, error) {
+		shard, err := o.shards().Get(ctx, o.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !shard.Spec.Drain {
+			return false, fmt.Errorf("drain of shard %q was stopped", o.Name)
+		}
+		if conditions.IsTrue(shard, corev1alpha1.ShardDrained) {
+			return true, nil
+		}
+		if status := shard.Status.Drain; status != nil && status.RemainingLogicalClusters != lastRemaining {
+			lastRemaining = status.RemainingLogicalClusters
+			if _, err := fmt.Fprintf(o.Out, "%d logical clusters remaining\n", lastRemaining); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for shard %q to drain, the drain continues in the background", o.Name)
+	} else if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.Out, "shard %s drained\n", o.Name)
+	return err
+}
+
+// StatusOptions contains the options for showing the status of a shard.
+type StatusOptions struct {
+	*ShardOptions
+
+	// Name is the name of the shard.
+	Name string
+}
+
+// NewStatusOptions returns new StatusOptions.
+func NewStatusOptions(streams genericclioptions.IOStreams) *StatusOptions {
+	return &StatusOptions{ShardOptions: NewShardOptions(streams)}
+}
+
+// Complete ensures all fields are initialized.
+func (o *StatusOptions) Complete(args []string) error {
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+	return o.ShardOptions.Complete()
+}
+
+// Validate validates the StatusOptions are complete and usable.
+func (o *StatusOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("shard name is required")
+	}
+	return o.Options.Validate()
+}
+
+// Run prints the URLs, scheduling state, usage, drain progress and conditions of the shard.
+func (o *StatusOptions) Run(ctx context.Context) error {
+	shard, err := o.shards().Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get shard %q: %w", o.Name, err)
+	}
+	return printStatus(o.Out, shard, o.now())
+}
+
+func printStatus(w io.Writer, shard *corev1alpha1.Shard, now time.Time) error {
+	out := printers.GetNewTabWriter(w)
+	defer out.Flush()
+
+	lines := [][2]string{
+		{"Name", shard.Name},
+		{"Base URL", shard.Spec.BaseURL},
+		{"External URL", shard.Spec.ExternalURL},
+		{"Virtual Workspace URL", shard.Spec.VirtualWorkspaceURL},
+		{"Ready", readiness(shard)},
+		{"Scheduling", scheduling(shard)},
+	}
+	if usage := shard.Status.Usage; usage != nil {
+		lines = append(lines,
+			[2]string{"Logical Clusters", strconv.FormatInt(usage.LogicalClusters, 10)},
+			[2]string{"Usage Updated", duration.HumanDuration(now.Sub(usage.LastUpdateTime.Time)) + " ago"},
+		)
+		if usage.StorageSizeBytes != nil {
+			lines = append(lines, [2]string{"Storage Size", strconv.FormatInt(*usage.StorageSizeBytes, 10) + " bytes"})
+		}
+		if usage.QPSHeadroom != nil {
+			lines = append(lines, [2]string{"QPS Headroom", strconv.FormatInt(*usage.QPSHeadroom, 10)})
+		}
+	}
+	if drain := shard.Status.Drain; drain != nil {
+		lines = append(lines, [2]string{"Drain Remaining", strconv.FormatInt(drain.RemainingLogicalClusters, 10) + " logical clusters"})
+	}
+	for _, line := range lines {
+		if line[1] == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "%s:\t%s\n", line[0], line[1]); err != nil {
+			return err
+		}
+	}
+
+	if len(shard.Status.Conditions) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(out, "Conditions:\n  TYPE\tSTATUS\tREASON\tMESSAGE"); err != nil {
+		return err
+	}
+	for _, c := range shard.Status.Conditions {
+		if _, err := fmt.Fprintf(out, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readiness returns the status of the Ready condition of the shard.
+func readiness(shard *corev1alpha1.Shard) string {
+	if c := conditions.Get(shard, conditionsv1alpha1.ReadyCondition); c != nil {
+		return string(c.Status)
+	}
+	return "Unknown"
+}
+
+// scheduling returns whether new logical clusters can be scheduled to the shard.
+func scheduling(shard *corev1alpha1.Shard) string {
+	switch {
+	case shard.Spec.Drain:
+		return "Draining"
+	case shard.Spec.Cordoned:
+		return "Cordoned"
+	}
+	return "Schedulable"
+}
+
+func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.ClusterInterface, error) {
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clusterConfig := rest.CopyConfig(config)
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = ""
+	clusterConfig.Host = u.String()
+	clusterConfig.UserAgent = rest.DefaultKubernetesUserAgent()
+	return kcpclientset.NewForConfig(clusterConfig)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+)
+
+func newShard(name string, mutate func(*corev1alpha1.Shard)) *corev1alpha1.Shard {
+	shard := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Annotations:       map[string]string{logicalcluster.AnnotationKey: core.RootCluster.String()},
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		Spec: corev1alpha1.ShardSpec{BaseURL: "https://" + name + ":6443"},
+	}
+	if mutate != nil {
+		mutate(shard)
+	}
+	return shard
+}
+
+func newTestShardOptions(t *testing.T, objs ...runtime.Object) (*ShardOptions, *kcpfakeclient.ClusterClientset, *bytes.Buffer) {
+	t.Helper()
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	client := kcpfakeclient.NewSimpleClientset(objs...)
+	opts := NewShardOptions(streams)
+	opts.kcpClusterClient = client
+	opts.now = func() time.Time { return time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC) }
+	return opts, client, out
+}
+
+func TestList(t *testing.T) {
+	headroom := int64(120)
+	opts, _, out := newTestShardOptions(t,
+		newShard("root", func(s *corev1alpha1.Shard) {
+			s.Status.Conditions = conditionsv1alpha1.Conditions{{Type: conditionsv1alpha1.ReadyCondition, Status: corev1.ConditionTrue}}
+			s.Status.Usage = &corev1alpha1.ShardUsage{LogicalClusters: 42, QPSHeadroom: &headroom}
+		}),
+		newShard("beta", func(s *corev1alpha1.Shard) { s.Spec.Drain = true }),
+	)
+
+	require.NoError(t, (&ListOptions{ShardOptions: opts}).Run(context.Background()))
+	require.Regexp(t, `root\s+True\s+Schedulable\s+42\s+120\s+https://root:6443\s+2d`, out.String())
+	require.Regexp(t, `beta\s+Unknown\s+Draining\s+<unknown>\s+<unknown>`, out.String())
+}
+
+func TestCordon(t *testing.T) {
+	opts, client, _ := newTestShardOptions(t, newShard("beta", func(s *corev1alpha1.Shard) { s.Spec.Drain = true }))
+	shards := client.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards()
+
+	require.NoError(t, (&CordonOptions{ShardOptions: opts, Name: "beta", Cordon: true}).Run(context.Background()))
+	shard, err := shards.Get(context.Background(), "beta", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, shard.Spec.Cordoned)
+	require.True(t, shard.Spec.Drain, "cordoning must not stop a drain")
+
+	require.NoError(t, (&CordonOptions{ShardOptions: opts, Name: "beta"}).Run(context.Background()))
+	shard, err = shards.Get(context.Background(), "beta", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, shard.Spec.Cordoned)
+	require.False(t, shard.Spec.Drain)
+}
+
+func TestDrain(t *testing.T) {
+	opts, client, out := newTestShardOptions(t, newShard("beta", nil))
+
+	// report progress on every get, until no logical cluster is left.
+	remaining := int64(3)
+	client.PrependReactor("get", "shards", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		shard := newShard("beta", func(s *corev1alpha1.Shard) { s.Spec.Drain = true })
+		if remaining == 0 {
+			shard.Status.Conditions = conditionsv1alpha1.Conditions{{Type: corev1alpha1.ShardDrained, Status: corev1.ConditionTrue}}
+		} else {
+			shard.Status.Drain = &corev1alpha1.ShardDrainStatus{RemainingLogicalClusters: remaining}
+			remaining--
+		}
+		return true, shard, nil
+	})
+
+	drain := &DrainOptions{ShardOptions: opts, Name: "beta", Wait: true, PollInterval: time.Millisecond}
+	require.NoError(t, drain.Run(context.Background()))
+	require.Equal(t, "shard beta draining\n"+
+		"3 logical clusters remaining\n"+
+		"2 logical clusters remaining\n"+
+		"1 logical clusters remaining\n"+
+		"shard beta drained\n", out.String())
+
+	var patched bool
+	for _, action := range client.Actions() {
+		if patch, ok := action.(kcptesting.PatchAction); ok {
+			require.Equal(t, `{"spec":{"drain":true}}`, string(patch.GetPatch()))
+			patched = true
+		}
+	}
+	require.True(t, patched)
+}
+
+func TestDrainStopped(t *testing.T) {
+	opts, client, _ := newTestShardOptions(t, newShard("beta", nil))
+	client.PrependReactor("get", "shards", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		return true, newShard("beta", nil), nil
+	})
+
+	drain := &DrainOptions{ShardOptions: opts, Name: "beta", Wait: true, PollInterval: time.Millisecond}
+	require.ErrorContains(t, drain.Run(context.Background()), "was stopped")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/cli/pkg/token/plugin"
+)
+
+var (
+	tokenExample = `
+	# Create a token that can read everything in root:org:ws and below for two hours, and print a kubeconfig using it.
+	%[1]s token create --workspace root:org:ws --role view --duration 2h > ci-kubeconfig.yaml
+
+	# Create a token for the current workspace and print only the token.
+	%[1]s token create --role edit -o token
+	`
+)
+
+// New returns a cobra.Command for workspace access tokens.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cliName := "kubectl"
+	if pflag.CommandLine.Name() == "kubectl-kcp" {
+		cliName = "kubectl kcp"
+	}
+
+	cmd := &cobra.Command{
+		Use:              "token",
+		Short:            "Manage workspace access tokens",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	createOpts := plugin.NewCreateTokenOptions(streams)
+	createCmd := &cobra.Command{
+		Use:          "create [--workspace <path>] [--role view|edit|admin] [--duration <duration>]",
+		Short:        "Create a short-lived token restricted to a workspace and its descendants",
+		Example:      fmt.Sprintf(tokenExample, cliName),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := createOpts.Complete(); err != nil {
+				return err
+			}
+			if err := createOpts.Validate(); err != nil {
+				return err
+			}
+			return createOpts.Run(cmd.Context())
+		},
+	}
+	createOpts.BindFlags(createCmd)
+
+	cmd.AddCommand(createCmd)
+	return cmd
+}
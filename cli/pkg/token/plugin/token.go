@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+const (
+	// OutputKubeconfig prints a kubeconfig using the token.
+	OutputKubeconfig = "kubeconfig"
+	// OutputToken prints only the token.
+	OutputToken = "token"
+)
+
+// discoveryRule allows the discovery requests every client does first.
+var discoveryRule = tenancyv1alpha1.WorkspaceAccessTokenRule{
+	Verbs:           []string{"get"},
+	NonResourceURLs: []string{"/api", "/api/*", "/apis", "/apis/*", "/version"},
+}
+
+// roleRules are the rules of the tokens for the supported roles, similar to the
+// user-facing cluster roles of Kubernetes.
+var roleRules = map[string][]tenancyv1alpha1.WorkspaceAccessTokenRule{
+	"view": {
+		{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		discoveryRule,
+	},
+	"edit": {
+		{Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		discoveryRule,
+	},
+	"admin": {
+		{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		{Verbs: []string{"*"}, NonResourceURLs: []string{"*"}},
+	},
+}
+
+// CreateTokenOptions contains the options for creating a workspace access token.
+type CreateTokenOptions struct {
+	*base.Options
+
+	// Workspace is the absolute path of the workspace the token is restricted to. Defaults to
+	// the current workspace.
+	Workspace string
+	// Role is one of view, edit or admin.
+	Role string
+	// Duration is the requested lifetime of the token. It is capped by the server.
+	Duration time.Duration
+	// Output is kubeconfig or token.
+	Output string
+
+	kcpClusterClient kcpclientset.ClusterInterface
+}
+
+// NewCreateTokenOptions returns new CreateTokenOptions.
+func NewCreateTokenOptions(streams genericclioptions.IOStreams) *CreateTokenOptions {
+	return &CreateTokenOptions{
+		Options:  base.NewOptions(streams),
+		Role:     "view",
+		Duration: time.Hour,
+		Output:   OutputKubeconfig,
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *CreateTokenOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "Absolute path of the workspace the token is restricted to, e.g. root:org:ws. Defaults to the current workspace.")
+	cmd.Flags().StringVar(&o.Role, "role", o.Role, "Role of the token in the workspace and its descendants: view, edit or admin. The token never allows more than the current user may do.")
+	cmd.Flags().DurationVar(&o.Duration, "duration", o.Duration, "Requested lifetime of the token, capped by the server.")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "Output format: kubeconfig or token.")
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *CreateTokenOptions) Complete() error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	kcpClusterClient, err := newKCPClusterClient(o.ClientConfig)
+	if err != nil {
+		return err
+	}
+	o.kcpClusterClient = kcpClusterClient
+	return nil
+}
+
+// Validate validates the CreateTokenOptions are complete and usable.
+func (o *CreateTokenOptions) Validate() error {
+	if _, found := roleRules[o.Role]; !found {
+		return fmt.Errorf("invalid --role %q, must be one of view, edit or admin", o.Role)
+	}
+	if o.Duration < time.Second {
+		return errors.New("--duration must be at least 1s")
+	}
+	if o.Output != OutputKubeconfig && o.Output != OutputToken {
+		return fmt.Errorf("invalid --output %q, must be kubeconfig or token", o.Output)
+	}
+	if o.Workspace != "" && !logicalcluster.NewPath(strings.TrimPrefix(o.Workspace, ":")).IsValid() {
+		return fmt.Errorf("invalid workspace path %q", o.Workspace)
+	}
+	return o.Options.Validate()
+}
+
+// Run creates the token through the workspaceaccesstokens virtual workspace and prints it.
+func (o *CreateTokenOptions) Run(ctx context.Context) error {
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	baseURL, current, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current URL %q does not point to workspace", config.Host)
+	}
+	workspace := current
+	if o.Workspace != "" {
+		workspace = logicalcluster.NewPath(strings.TrimPrefix(o.Workspace, ":"))
+	}
+
+	// the virtual workspace is addressed by logical cluster name, not by path.
+	lc, err := o.kcpClusterClient.Cluster(workspace).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to resolve workspace %s: %w", workspace, err)
+	}
+
+	expirationSeconds := int64(o.Duration.Seconds())
+	token, err := createWorkspaceAccessToken(ctx, config, baseURL, logicalcluster.From(lc), &tenancyv1alpha1.WorkspaceAccessToken{
+		TypeMeta: metav1.TypeMeta{APIVersion: tenancyv1alpha1.SchemeGroupVersion.String(), Kind: "WorkspaceAccessToken"},
+		Spec: tenancyv1alpha1.WorkspaceAccessTokenSpec{
+			Rules:             roleRules[o.Role],
+			ExpirationSeconds: &expirationSeconds,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create token for workspace %s: %w", workspace, err)
+	}
+	if _, err := fmt.Fprintf(o.ErrOut, "Created %s token for workspace %s, expiring at %s.\n", o.Role, workspace, token.Status.ExpirationTimestamp.UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	if o.Output == OutputToken {
+		_, err := fmt.Fprintln(o.Out, token.Status.Token)
+		return err
+	}
+
+	if err := rest.LoadTLSFiles(config); err != nil {
+		return err
+	}
+	server := *baseURL
+	server.Path = workspace.RequestPath()
+	name := workspace.String()
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   server.String(),
+		CertificateAuthorityData: config.CAData,
+		InsecureSkipTLSVerify:    config.Insecure,
+		TLSServerName:            config.ServerName,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token.Status.Token}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	kubeconfig.CurrentContext = name
+	data, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	_, err = o.Out.Write(data)
+	return err
+}
+
+// createWorkspaceAccessToken posts the token request to the workspaceaccesstokens virtual
+// workspace of the given server, which is usually the front-proxy.
+func createWorkspaceAccessToken(ctx context.Context, config *rest.Config, baseURL *url.URL, cluster logicalcluster.Name, token *tenancyv1alpha1.WorkspaceAccessToken) (*tenancyv1alpha1.WorkspaceAccessToken, error) {
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(token)
+	if err != nil {
+		return nil, err
+	}
+	u := strings.TrimSuffix(baseURL.String(), "/") + "/services/workspaceaccesstokens" + cluster.Path().RequestPath() + "/apis/tenancy.kcp.io/v1alpha1/workspaceaccesstokens"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var status metav1.Status
+		if err := json.Unmarshal(data, &status); err == nil && status.Kind == "Status" {
+			return nil, &apierrors.StatusError{ErrStatus: status}
+		}
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	var created tenancyv1alpha1.WorkspaceAccessToken
+	if err := json.Unmarshal(data, &created); err != nil {
+		return nil, err
+	}
+	if created.Status.Token == "" {
+		return nil, errors.New("no token returned")
+	}
+	return &created, nil
+}
+
+func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.ClusterInterface, error) {
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clusterConfig := rest.CopyConfig(config)
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = ""
+	clusterConfig.Host = u.String()
+	clusterConfig.UserAgent = rest.DefaultKubernetesUserAgent()
+	return kcpclientset.NewForConfig(clusterConfig)
+}
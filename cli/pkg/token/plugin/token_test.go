@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+)
+
+func TestCreateToken(t *testing.T) {
+	var got tenancyv1alpha1.WorkspaceAccessToken
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/services/workspaceaccesstokens/clusters/abc123/apis/tenancy.kcp.io/v1alpha1/workspaceaccesstokens" {
+			http.NotFound(w, req)
+			return
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&got))
+		got.Status = tenancyv1alpha1.WorkspaceAccessTokenStatus{
+			Token:               "secret",
+			ExpirationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)),
+		}
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(t, json.NewEncoder(w).Encode(&got))
+	}))
+	defer server.Close()
+
+	client := kcpfakeclient.NewSimpleClientset()
+	client.PrependReactor("get", "logicalclusters", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		require.Equal(t, logicalcluster.NewPath("root:org:ws"), action.GetCluster())
+		return true, &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{
+			Name:        corev1alpha1.LogicalClusterName,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "abc123"},
+		}}, nil
+	})
+
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	opts := NewCreateTokenOptions(streams)
+	opts.Workspace = "root:org:ws"
+	opts.Duration = 2 * time.Hour
+	opts.kcpClusterClient = client
+	opts.ClientConfig = clientcmd.NewDefaultClientConfig(clientcmdapi.Config{CurrentContext: "test",
+		Contexts:  map[string]*clientcmdapi.Context{"test": {Cluster: "test", AuthInfo: "test"}},
+		Clusters:  map[string]*clientcmdapi.Cluster{"test": {Server: server.URL + "/clusters/root:org"}},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "user"}},
+	}, nil)
+	require.NoError(t, opts.Validate())
+	require.NoError(t, opts.Run(context.Background()))
+
+	require.Equal(t, roleRules["view"], got.Spec.Rules)
+	require.Equal(t, int64(7200), *got.Spec.ExpirationSeconds)
+	require.Equal(t, "Created view token for workspace root:org:ws, expiring at 2024-01-01T02:00:00Z.\n", errOut.String())
+
+	kubeconfig, err := clientcmd.Load(out.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "root:org:ws", kubeconfig.CurrentContext)
+	require.Equal(t, server.URL+"/clusters/root:org:ws", kubeconfig.Clusters["root:org:ws"].Server)
+	require.Equal(t, "secret", kubeconfig.AuthInfos["root:org:ws"].Token)
+}
+
+func TestCreateTokenValidate(t *testing.T) {
+	opts := NewCreateTokenOptions(genericclioptions.NewTestIOStreamsDiscard())
+	require.NoError(t, opts.Validate())
+
+	opts.Role = "owner"
+	require.Error(t, opts.Validate())
+
+	opts.Role, opts.Output = "admin", "json"
+	require.Error(t, opts.Validate())
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/cli/pkg/whoami/plugin"
+)
+
+var (
+	whoamiExample = `
+	# Shows the user name, groups and extras as seen by the current workspace.
+	%[1]s whoami
+
+	# Additionally shows whether the user can administer, edit or view each API group of the current workspace.
+	%[1]s whoami --access
+	`
+)
+
+// New returns a cobra.Command showing the current user.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cliName := "kubectl"
+	if pflag.CommandLine.Name() == "kubectl-kcp" {
+		cliName = "kubectl kcp"
+	}
+
+	opts := plugin.NewWhoAmIOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "whoami",
+		Short:        "Show the current user as authenticated by the current workspace",
+		Example:      fmt.Sprintf(whoamiExample, cliName),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Complete(); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return opts.Run(cmd.Context())
+		},
+	}
+	opts.BindFlags(cmd)
+
+	return cmd
+}
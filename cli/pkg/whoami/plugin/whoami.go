@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+)
+
+// accessReviewConcurrency is the number of access reviews sent in parallel.
+const accessReviewConcurrency = 10
+
+// accessLevel is the access to all resources of an API group.
+type accessLevel string
+
+const (
+	accessAdmin accessLevel = "admin"
+	accessEdit  accessLevel = "edit"
+	accessView  accessLevel = "view"
+	accessNone  accessLevel = "none"
+)
+
+var (
+	// accessLevelVerbs are the verbs that have to be allowed on all resources of an API group
+	// for an access level, from the most to the least privileged.
+	accessLevelVerbs = []struct {
+		level accessLevel
+		verbs []string
+	}{
+		{accessAdmin, []string{"*"}},
+		{accessEdit, []string{"create", "update", "patch", "delete"}},
+		{accessView, []string{"get", "list", "watch"}},
+	}
+)
+
+// WhoAmIOptions contains the options for showing the current user.
+type WhoAmIOptions struct {
+	*base.Options
+
+	// Access lists the access of the user to the API groups of the current workspace.
+	Access bool
+
+	kubeClient kubernetes.Interface
+}
+
+// NewWhoAmIOptions returns new WhoAmIOptions.
+func NewWhoAmIOptions(streams genericclioptions.IOStreams) *WhoAmIOptions {
+	return &WhoAmIOptions{
+		Options: base.NewOptions(streams),
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *WhoAmIOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().BoolVar(&o.Access, "access", o.Access, "List the access (admin, edit, view) to every API group of the current workspace")
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *WhoAmIOptions) Complete() error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	config = rest.CopyConfig(config)
+	config.UserAgent = rest.DefaultKubernetesUserAgent()
+	if o.kubeClient, err = kubernetes.NewForConfig(config); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run prints the user as authenticated by the current workspace, and its access if requested.
+func (o *WhoAmIOptions) Run(ctx context.Context) error {
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	review, err := o.kubeClient.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review the current user: %w", err)
+	}
+	if err := printUser(o.Out, config.Host, review.Status.UserInfo); err != nil {
+		return err
+	}
+	if !o.Access {
+		return nil
+	}
+
+	groups, err := o.kubeClient.Discovery().ServerGroups()
+	if err != nil {
+		return fmt.Errorf("failed to discover API groups: %w", err)
+	}
+	names := make([]string, 0, len(groups.Groups))
+	for _, g := range groups.Groups {
+		names = append(names, g.Name)
+	}
+	access, err := o.groupAccess(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(o.Out); err != nil {
+		return err
+	}
+	out := printers.GetNewTabWriter(o.Out)
+	defer out.Flush()
+	if _, err := fmt.Fprintln(out, "API GROUP\tACCESS"); err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		display := name
+		if display == "" {
+			display = "core"
+		}
+		if _, err := fmt.Fprintf(out, "%s\t%s\n", display, access[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupAccess returns the access level of the user to every given API group. All access
+// reviews are sent in parallel, accessReviewConcurrency at a time.
+func (o *WhoAmIOptions) groupAccess(ctx context.Context, groups []string) (map[string]accessLevel, error) {
+	type check struct {
+		group, verb string
+	}
+	var checks []check
+	for _, group := range groups {
+		for _, level := range accessLevelVerbs {
+			for _, verb := range level.verbs {
+				checks = append(checks, check{group: group, verb: verb})
+			}
+		}
+	}
+
+	var (
+		lock     sync.Mutex
+		allowed  = map[check]bool{}
+		firstErr error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, accessReviewConcurrency)
+	)
+	for _, c := range checks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(c check) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			review, err := o.kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{Group: c.group, Resource: "*", Verb: c.verb},
+				},
+			}, metav1.CreateOptions{})
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to review access to API group %q: %w", c.group, err)
+				}
+				return
+			}
+			allowed[c] = review.Status.Allowed
+		}(c)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	access := make(map[string]accessLevel, len(groups))
+	for _, group := range groups {
+		access[group] = accessNone
+	levels:
+		for _, level := range accessLevelVerbs {
+			for _, verb := range level.verbs {
+				if !allowed[check{group: group, verb: verb}] {
+					continue levels
+				}
+			}
+			access[group] = level.level
+			break
+		}
+	}
+	return access, nil
+}
+
+func printUser(w io.Writer, host string, user authenticationv1.UserInfo) error {
+	out := printers.GetNewTabWriter(w)
+	defer out.Flush()
+
+	workspace := host
+	if _, clusterName, err := pluginhelpers.ParseClusterURL(host); err == nil {
+		workspace = clusterName.String()
+	}
+	lines := [][2]string{
+		{"Workspace", workspace},
+		{"Username", user.Username},
+		{"UID", user.UID},
+		{"Groups", strings.Join(user.Groups, ", ")},
+	}
+	keys := make([]string, 0, len(user.Extra))
+	for key := range user.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, [2]string{"Extra: " + key, strings.Join(user.Extra[key], ", ")})
+	}
+	for _, line := range lines {
+		if line[1] == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "%s:\t%s\n", line[0], line[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestWhoAmI(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, &authenticationv1.SelfSubjectReview{Status: authenticationv1.SelfSubjectReviewStatus{UserInfo: authenticationv1.UserInfo{
+			Username: "alice",
+			Groups:   []string{"team-a", "system:authenticated"},
+			Extra:    map[string]authenticationv1.ExtraValue{"scopes": {"cluster:abc"}},
+		}}}, nil
+	})
+	// alice administers apps, may edit and view core resources, and only view rbac.
+	allowed := map[string]map[string]bool{
+		"":                          {"create": true, "update": true, "patch": true, "delete": true, "get": true, "list": true, "watch": true},
+		"apps":                      {"*": true, "create": true, "update": true, "patch": true, "delete": true, "get": true, "list": true, "watch": true},
+		"rbac.authorization.k8s.io": {"get": true, "list": true, "watch": true},
+	}
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = allowed[attrs.Group][attrs.Verb]
+		return true, review, nil
+	})
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1"},
+		{GroupVersion: "apps/v1"},
+		{GroupVersion: "rbac.authorization.k8s.io/v1"},
+		{GroupVersion: "tenancy.kcp.io/v1alpha1"},
+	}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	opts := NewWhoAmIOptions(streams)
+	opts.Access = true
+	opts.kubeClient = client
+	opts.ClientConfig = clientcmd.NewDefaultClientConfig(clientcmdapi.Config{
+		CurrentContext: "test",
+		Contexts:       map[string]*clientcmdapi.Context{"test": {Cluster: "test", AuthInfo: "test"}},
+		Clusters:       map[string]*clientcmdapi.Cluster{"test": {Server: "https://test/clusters/root:org"}},
+		AuthInfos:      map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+	}, nil)
+
+	require.NoError(t, opts.Run(context.Background()))
+	require.Regexp(t, `Workspace:\s+root:org\n`, out.String())
+	require.Regexp(t, `Username:\s+alice\n`, out.String())
+	require.Regexp(t, `Groups:\s+team-a, system:authenticated\n`, out.String())
+	require.Regexp(t, `Extra: scopes:\s+cluster:abc\n`, out.String())
+	require.Regexp(t, `apps\s+admin\n`, out.String())
+	require.Regexp(t, `core\s+edit\n`, out.String())
+	require.Regexp(t, `rbac.authorization.k8s.io\s+view\n`, out.String())
+	require.Regexp(t, `tenancy.kcp.io\s+none\n`, out.String())
+}
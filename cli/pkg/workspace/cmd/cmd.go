@@ -57,17 +57,32 @@ var (
 	# enter the previous workspace
 	%[1]s workspace -
 
+	# pick one of the recently used workspaces
+	%[1]s workspace --recent
+
 	# go to your home workspace
 	%[1]s workspace
 
 	# create a workspace and immediately enter it
 	%[1]s workspace create my-workspace --enter
 
+	# create a workspace, wait for its initializers and bind an APIExport in it
+	%[1]s workspace create my-workspace --wait-for-initializers --bind root:my-service:my-export
+
 	# create a context with the current workspace, e.g. root:default:my-workspace
 	%[1]s workspace create-context
 
 	# create a context with the current workspace, named context-name
 	%[1]s workspace create-context context-name
+
+	# export the current workspace and all its child workspaces to a tarball
+	%[1]s workspace export backup.tar --recursive
+
+	# export the current workspace as an OCI image layout
+	%[1]s workspace export backup.oci.tar --format=oci
+
+	# import an export into the current workspace
+	%[1]s workspace import backup.tar
 `
 )
 
@@ -82,7 +97,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 
 	cmd := &cobra.Command{
 		Aliases:          []string{"ws", "workspaces"},
-		Use:              "workspace [create|create-context|use|current|<workspace>|..|.|-|~|<root:absolute:workspace>]",
+		Use:              "workspace [create|create-context|use|current|tree|export|import|<workspace>|..|.|-|~|<root:absolute:workspace>]",
 		Short:            "Manages KCP workspaces",
 		Example:          fmt.Sprintf(workspaceExample, cliName),
 		SilenceUsage:     true,
@@ -101,6 +116,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 		},
 	}
 	cmdOpts.BindFlags(cmd)
+	cmd.ValidArgsFunction = plugin.NewWorkspaceCompleter(cmdOpts.Options).WorkspacePathsCompletion()
 
 	if v := version.Get().String(); len(v) == 0 {
 		cmd.Version = "<unknown>"
@@ -115,7 +131,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 		Short:        "Uses the given workspace as the current workspace. Using - means previous workspace, .. means parent workspace, . mean current, ~ means home workspace",
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
-			if len(args) != 1 {
+			if len(args) > 1 || (len(args) == 0 && !useWorkspaceOpts.Recent) {
 				return c.Help()
 			}
 			if err := useWorkspaceOpts.Complete(args); err != nil {
@@ -128,6 +144,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 		},
 	}
 	useWorkspaceOpts.BindFlags(useCmd)
+	useCmd.ValidArgsFunction = plugin.NewWorkspaceCompleter(useWorkspaceOpts.Options).WorkspacePathsCompletion()
 
 	currentWorkspaceOpts := plugin.NewCurrentWorkspaceOptions(streams)
 	currentCmd := &cobra.Command{
@@ -179,8 +196,8 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 	treeCmdOpts := plugin.NewTreeOptions(streams)
 	treeCmd := &cobra.Command{
 		Use:          "tree",
-		Short:        "Print the current workspace tree.",
-		Example:      "kcp workspace tree",
+		Short:        "Print the current workspace tree with types, phases and shards.",
+		Example:      "kcp workspace tree --depth 2",
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if len(args) != 0 {
@@ -197,11 +214,55 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 	}
 	treeCmdOpts.BindFlags(treeCmd)
 
+	exportOpts := plugin.NewExportOptions(streams)
+	exportCmd := &cobra.Command{
+		Use:          "export <file>|- [--recursive] [--format=tar|oci]",
+		Short:        "Export all objects of the current workspace, optionally with its child workspaces, to an archive",
+		Example:      "kcp workspace export backup.tar --recursive",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return c.Help()
+			}
+			if err := exportOpts.Complete(args); err != nil {
+				return err
+			}
+			if err := exportOpts.Validate(); err != nil {
+				return err
+			}
+			return exportOpts.Run(c.Context())
+		},
+	}
+	exportOpts.BindFlags(exportCmd)
+
+	importOpts := plugin.NewImportOptions(streams)
+	importCmd := &cobra.Command{
+		Use:          "import <file>|-",
+		Short:        "Import an archive created by export into the current workspace",
+		Example:      "kcp workspace import backup.tar",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return c.Help()
+			}
+			if err := importOpts.Complete(args); err != nil {
+				return err
+			}
+			if err := importOpts.Validate(); err != nil {
+				return err
+			}
+			return importOpts.Run(c.Context())
+		},
+	}
+	importOpts.BindFlags(importCmd)
+
 	cmd.AddCommand(useCmd)
 	cmd.AddCommand(treeCmd)
 	cmd.AddCommand(currentCmd)
 	cmd.AddCommand(createCmd)
 	cmd.AddCommand(createContextCmd)
+	cmd.AddCommand(exportCmd)
+	cmd.AddCommand(importCmd)
 	return cmd, nil
 }
 
@@ -211,7 +272,7 @@ func NewCreate(prefix string, deprecation string, streams genericclioptions.IOSt
 	cmd := &cobra.Command{
 		Use:          "create",
 		Short:        "Creates a new workspace",
-		Example:      prefix + " <workspace name> [--type=<type>] [--enter [--ignore-not-ready]] --ignore-existing",
+		Example:      prefix + " <workspace name> [--type=<type>] [--bind=<workspace_path:apiexport-name>] [--wait-for-initializers] [--enter [--ignore-not-ready]] --ignore-existing",
 		Deprecated:   deprecation,
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(1),
@@ -229,6 +290,18 @@ func NewCreate(prefix string, deprecation string, streams genericclioptions.IOSt
 		},
 	}
 	createWorkspaceOpts.BindFlags(cmd)
+	if err := cmd.RegisterFlagCompletionFunc("bind", plugin.NewWorkspaceCompleter(createWorkspaceOpts.Options).AbsolutePathsCompletion()); err != nil {
+		return nil, err
+	}
+	if err := cmd.RegisterFlagCompletionFunc("type", func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		types, err := createWorkspaceOpts.CompleteTypes(c.Context(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return types, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		return nil, err
+	}
 
 	return cmd, nil
 }
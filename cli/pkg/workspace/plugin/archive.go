@@ -0,0 +1,419 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ArchiveFormat is the file format of a workspace export.
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatTar is a tarball with one JSON file per object.
+	ArchiveFormatTar ArchiveFormat = "tar"
+	// ArchiveFormatOCI is an OCI image layout, as a tarball, holding an artifact with the
+	// tarball of ArchiveFormatTar as its only layer. It can be pushed to a registry with
+	// tools like oras or skopeo.
+	ArchiveFormatOCI ArchiveFormat = "oci"
+	// ArchiveFormatDir is a directory with the same layout as the tarball of ArchiveFormatTar.
+	// It is meant for reviewing and editing exports, or keeping them in git.
+	ArchiveFormatDir ArchiveFormat = "dir"
+
+	// The root directory of a tarball that objects are stored in.
+	archiveObjectsDir = "workspaces"
+	// The directory of a workspace in the tarball below which child workspaces are stored.
+	archiveChildrenDir = "children"
+	// The file in the root of the tarball holding the archiveMetadata.
+	archiveMetadataFile = "metadata.json"
+
+	ociArtifactType  = "application/vnd.kcp.workspace.v1"
+	ociLayerType     = "application/vnd.kcp.workspace.layer.v1.tar"
+	ociEmptyType     = "application/vnd.oci.empty.v1+json"
+	ociManifestType  = "application/vnd.oci.image.manifest.v1+json"
+	ociIndexType     = "application/vnd.oci.image.index.v1+json"
+	ociLayoutFile    = "oci-layout"
+	ociIndexFile     = "index.json"
+	ociRefAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// archiveMetadata describes an export as a whole.
+type archiveMetadata struct {
+	// Workspace is the path of the exported workspace. Paths to it and its children in the
+	// objects are remapped to the workspace the archive is imported into.
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// archivedObject is an object of an exported workspace.
+type archivedObject struct {
+	// Workspace is the path of the workspace of the object relative to the exported
+	// workspace, empty for the exported workspace itself.
+	Workspace logicalcluster.Path
+	// Resource is the group and resource of the object.
+	Resource schema.GroupResource
+	// Object is the object without any server-set fields.
+	Object *unstructured.Unstructured
+}
+
+// entryName returns the name of file of the object inside the tarball, e.g.
+// "workspaces/children/team/namespaces/default/configmaps/settings.json".
+func (o *archivedObject) entryName() string {
+	elems := []string{archiveObjectsDir}
+	if !o.Workspace.Empty() {
+		for _, name := range strings.Split(o.Workspace.String(), ":") {
+			elems = append(elems, archiveChildrenDir, name)
+		}
+	}
+	if ns := o.Object.GetNamespace(); ns != "" {
+		elems = append(elems, "namespaces", ns)
+	} else {
+		elems = append(elems, "cluster")
+	}
+	elems = append(elems, o.Resource.String(), o.Object.GetName()+".json")
+	return path.Join(elems...)
+}
+
+// parseEntryName is the inverse of entryName, returning the workspace and resource of
+// the file in the tarball.
+func parseEntryName(name string) (logicalcluster.Path, schema.GroupResource, error) {
+	elems := strings.Split(path.Clean(name), "/")
+	if len(elems) < 4 || elems[0] != archiveObjectsDir || !strings.HasSuffix(name, ".json") {
+		return logicalcluster.Path{}, schema.GroupResource{}, fmt.Errorf("unexpected file %q in archive", name)
+	}
+	elems = elems[1:]
+
+	ws := logicalcluster.Path{}
+	for len(elems) > 2 && elems[0] == archiveChildrenDir {
+		ws = ws.Join(elems[1])
+		elems = elems[2:]
+	}
+
+	switch {
+	case len(elems) == 3 && elems[0] == "cluster":
+		return ws, schema.ParseGroupResource(elems[1]), nil
+	case len(elems) == 4 && elems[0] == "namespaces":
+		return ws, schema.ParseGroupResource(elems[2]), nil
+	}
+	return logicalcluster.Path{}, schema.GroupResource{}, fmt.Errorf("unexpected file %q in archive", name)
+}
+
+// writeArchive writes the given objects to w in the given format. ArchiveFormatDir is
+// written with writeArchiveDir instead.
+func writeArchive(w io.Writer, format ArchiveFormat, meta archiveMetadata, objects []archivedObject, now time.Time) error {
+	switch format {
+	case ArchiveFormatTar:
+		return writeObjectsTar(w, meta, objects, now)
+	case ArchiveFormatOCI:
+		var layer bytes.Buffer
+		if err := writeObjectsTar(&layer, meta, objects, now); err != nil {
+			return err
+		}
+		return writeOCILayout(w, layer.Bytes(), now)
+	default:
+		return fmt.Errorf("unknown archive format %q", format)
+	}
+}
+
+func writeObjectsTar(w io.Writer, meta archiveMetadata, objects []archivedObject, now time.Time) error {
+	tw := tar.NewWriter(w)
+	bs, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, archiveMetadataFile, bs, now); err != nil {
+		return err
+	}
+	for i := range objects {
+		bs, err := objects[i].Object.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err := writeTarFile(tw, objects[i].entryName(), bs, now); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+type ociDescriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	ArtifactType  string          `json:"artifactType,omitempty"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+func writeOCILayout(w io.Writer, layer []byte, now time.Time) error {
+	tw := tar.NewWriter(w)
+
+	writeBlob := func(mediaType string, bs []byte) (ociDescriptor, error) {
+		sum := sha256.Sum256(bs)
+		digest := hex.EncodeToString(sum[:])
+		if err := writeTarFile(tw, path.Join("blobs", "sha256", digest), bs, now); err != nil {
+			return ociDescriptor{}, err
+		}
+		return ociDescriptor{MediaType: mediaType, Digest: "sha256:" + digest, Size: int64(len(bs))}, nil
+	}
+
+	if err := writeTarFile(tw, ociLayoutFile, []byte(`{"imageLayoutVersion":"1.0.0"}`), now); err != nil {
+		return err
+	}
+	config, err := writeBlob(ociEmptyType, []byte("{}"))
+	if err != nil {
+		return err
+	}
+	layerDesc, err := writeBlob(ociLayerType, layer)
+	if err != nil {
+		return err
+	}
+	manifest, err := json.Marshal(ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestType,
+		ArtifactType:  ociArtifactType,
+		Config:        config,
+		Layers:        []ociDescriptor{layerDesc},
+	})
+	if err != nil {
+		return err
+	}
+	manifestDesc, err := writeBlob(ociManifestType, manifest)
+	if err != nil {
+		return err
+	}
+	manifestDesc.ArtifactType = ociArtifactType
+	manifestDesc.Annotations = map[string]string{ociRefAnnotation: "latest"}
+	index, err := json.Marshal(ociIndex{SchemaVersion: 2, MediaType: ociIndexType, Manifests: []ociDescriptor{manifestDesc}})
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, ociIndexFile, index, now); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, bs []byte, now time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(bs)),
+		ModTime:  now,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(bs)
+	return err
+}
+
+// writeArchiveDir writes the given objects to the given directory in ArchiveFormatDir,
+// creating it if necessary.
+func writeArchiveDir(dir string, meta archiveMetadata, objects []archivedObject) error {
+	write := func(name string, bs []byte) error {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(name, bs, 0o644)
+	}
+
+	bs, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := write(archiveMetadataFile, bs); err != nil {
+		return err
+	}
+	for i := range objects {
+		bs, err := json.MarshalIndent(objects[i].Object.Object, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := write(objects[i].entryName(), append(bs, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readArchive reads the objects from an archive written by writeArchive, detecting
+// the format automatically.
+func readArchive(r io.Reader) (archiveMetadata, []archivedObject, error) {
+	files, err := readTarFiles(r)
+	if err != nil {
+		return archiveMetadata{}, nil, err
+	}
+
+	if _, found := files[ociLayoutFile]; found {
+		layer, err := ociLayer(files)
+		if err != nil {
+			return archiveMetadata{}, nil, err
+		}
+		if files, err = readTarFiles(bytes.NewReader(layer)); err != nil {
+			return archiveMetadata{}, nil, err
+		}
+	}
+
+	return archiveFromFiles(files)
+}
+
+// readArchiveDir reads the objects from a directory written by writeArchiveDir.
+func readArchiveDir(dir string) (archiveMetadata, []archivedObject, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		bs, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = bs
+		return nil
+	})
+	if err != nil {
+		return archiveMetadata{}, nil, err
+	}
+	return archiveFromFiles(files)
+}
+
+func archiveFromFiles(files map[string][]byte) (archiveMetadata, []archivedObject, error) {
+	var meta archiveMetadata
+	if bs, found := files[archiveMetadataFile]; found {
+		if err := json.Unmarshal(bs, &meta); err != nil {
+			return archiveMetadata{}, nil, fmt.Errorf("failed to decode %q: %w", archiveMetadataFile, err)
+		}
+		delete(files, archiveMetadataFile)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	objects := make([]archivedObject, 0, len(names))
+	for _, name := range names {
+		ws, gr, err := parseEntryName(name)
+		if err != nil {
+			return archiveMetadata{}, nil, err
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(files[name]); err != nil {
+			return archiveMetadata{}, nil, fmt.Errorf("failed to decode %q: %w", name, err)
+		}
+		objects = append(objects, archivedObject{Workspace: ws, Resource: gr, Object: obj})
+	}
+	return meta, objects, nil
+}
+
+func ociLayer(files map[string][]byte) ([]byte, error) {
+	blob := func(desc ociDescriptor) ([]byte, error) {
+		bs, found := files[path.Join("blobs", strings.Replace(desc.Digest, ":", "/", 1))]
+		if !found {
+			return nil, fmt.Errorf("blob %s not found in OCI layout", desc.Digest)
+		}
+		return bs, nil
+	}
+
+	var index ociIndex
+	if err := json.Unmarshal(files[ociIndexFile], &index); err != nil {
+		return nil, fmt.Errorf("failed to decode OCI index: %w", err)
+	}
+	for _, desc := range index.Manifests {
+		if desc.MediaType != ociManifestType {
+			continue
+		}
+		bs, err := blob(desc)
+		if err != nil {
+			return nil, err
+		}
+		var manifest ociManifest
+		if err := json.Unmarshal(bs, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to decode OCI manifest: %w", err)
+		}
+		if manifest.ArtifactType != ociArtifactType {
+			continue
+		}
+		for _, layer := range manifest.Layers {
+			if layer.MediaType == ociLayerType {
+				return blob(layer)
+			}
+		}
+	}
+	return nil, errors.New("no workspace artifact found in OCI layout")
+}
+
+func readTarFiles(r io.Reader) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		bs, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(hdr.Name)] = bs
+	}
+}
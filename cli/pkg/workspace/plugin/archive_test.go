@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	objects := []archivedObject{
+		{
+			Resource: schema.GroupResource{Resource: "configmaps"},
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "settings", "namespace": "default"},
+				"data":       map[string]interface{}{"tier": "gold"},
+			}},
+		},
+		{
+			Resource: workspacesResource,
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "tenancy.kcp.io/v1alpha1",
+				"kind":       "Workspace",
+				"metadata":   map[string]interface{}{"name": "team"},
+			}},
+		},
+		{
+			Workspace: logicalcluster.NewPath("team:sub"),
+			Resource:  schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "ClusterRole",
+				"metadata":   map[string]interface{}{"name": "viewer"},
+			}},
+		},
+	}
+
+	meta := archiveMetadata{Workspace: "root:org"}
+
+	for _, format := range []ArchiveFormat{ArchiveFormatTar, ArchiveFormatOCI} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeArchive(&buf, format, meta, objects, time.Now()))
+
+			gotMeta, got, err := readArchive(&buf)
+			require.NoError(t, err)
+			require.Equal(t, meta, gotMeta)
+			// entries are read in lexical order
+			require.Equal(t, []archivedObject{objects[2], objects[1], objects[0]}, got)
+		})
+	}
+
+	t.Run(string(ArchiveFormatDir), func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "export")
+		require.NoError(t, writeArchiveDir(dir, meta, objects))
+		require.FileExists(t, filepath.Join(dir, "workspaces", "namespaces", "default", "configmaps", "settings.json"))
+
+		gotMeta, got, err := readArchiveDir(dir)
+		require.NoError(t, err)
+		require.Equal(t, meta, gotMeta)
+		require.Equal(t, []archivedObject{objects[2], objects[1], objects[0]}, got)
+	})
+}
+
+func TestParseEntryName(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		wantWorkspace string
+		wantResource  schema.GroupResource
+		wantErr       bool
+	}{
+		{name: "workspaces/cluster/namespaces/team.json", wantResource: schema.GroupResource{Resource: "namespaces"}},
+		{name: "workspaces/namespaces/team/foo.json", wantErr: true},
+		{name: "workspaces/namespaces/team/deployments.apps/foo.json", wantResource: schema.GroupResource{Group: "apps", Resource: "deployments"}},
+		{name: "workspaces/children/a/children/b/cluster/workspaces.tenancy.kcp.io/c.json", wantWorkspace: "a:b", wantResource: workspacesResource},
+		{name: "workspaces/children/a/foo.json", wantErr: true},
+		{name: "other/cluster/namespaces/team.json", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, gr, err := parseEntryName(tt.name)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantWorkspace, ws.String())
+			require.Equal(t, tt.wantResource, gr)
+		})
+	}
+}
+
+func TestSanitizeForExport(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tenancy.kcp.io/v1alpha1",
+		"kind":       "Workspace",
+		"metadata": map[string]interface{}{
+			"name":              "team",
+			"uid":               "uid",
+			"resourceVersion":   "42",
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"annotations": map[string]interface{}{
+				"kcp.io/cluster": "abc",
+				"kcp.io/path":    "root:org",
+				"foo":            "bar",
+			},
+			"labels": map[string]interface{}{"foo": "bar"},
+		},
+		"spec": map[string]interface{}{
+			"cluster": "def",
+			"URL":     "https://kcp.example.com/clusters/def",
+			"type":    map[string]interface{}{"name": "universal", "path": "root"},
+		},
+		"status": map[string]interface{}{"phase": "Ready"},
+	}}
+
+	got := sanitizeForExport(workspacesResource, obj)
+	require.Equal(t, map[string]interface{}{
+		"apiVersion": "tenancy.kcp.io/v1alpha1",
+		"kind":       "Workspace",
+		"metadata": map[string]interface{}{
+			"name":        "team",
+			"annotations": map[string]interface{}{"foo": "bar"},
+			"labels":      map[string]interface{}{"foo": "bar"},
+		},
+		"spec": map[string]interface{}{
+			"type": map[string]interface{}{"name": "universal", "path": "root"},
+		},
+	}, got.Object)
+	require.Contains(t, obj.Object, "status", "the input must not be mutated")
+}
+
+func TestSortedForImport(t *testing.T) {
+	object := func(group, resource string) archivedObject {
+		return archivedObject{Resource: schema.GroupResource{Group: group, Resource: resource}}
+	}
+	got := sortedForImport([]archivedObject{
+		object("tenancy.kcp.io", "workspaces"),
+		object("", "configmaps"),
+		object("apis.kcp.io", "apibindings"),
+		object("", "namespaces"),
+		object("rbac.authorization.k8s.io", "roles"),
+	})
+	require.Equal(t, []archivedObject{
+		object("", "namespaces"),
+		object("apis.kcp.io", "apibindings"),
+		object("", "configmaps"),
+		object("rbac.authorization.k8s.io", "roles"),
+		object("tenancy.kcp.io", "workspaces"),
+	}, got)
+}
+
+func TestRemapWorkspacePaths(t *testing.T) {
+	from, to := logicalcluster.NewPath("root:org"), logicalcluster.NewPath("root:copy")
+
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"reference": map[string]interface{}{"export": map[string]interface{}{"path": "root:org:team", "name": "widgets"}}},
+	}}
+	remapWorkspacePaths(schema.GroupResource{Group: "apis.kcp.io", Resource: "apibindings"}, binding, from, to)
+	path, _, _ := unstructured.NestedString(binding.Object, "spec", "reference", "export", "path")
+	require.Equal(t, "root:copy:team", path)
+
+	outside := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"type": map[string]interface{}{"path": "root:organization", "name": "universal"}},
+	}}
+	remapWorkspacePaths(workspacesResource, outside, from, to)
+	path, _, _ = unstructured.NestedString(outside.Object, "spec", "type", "path")
+	require.Equal(t, "root:organization", path, "paths outside of the exported workspace must not change")
+
+	wst := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"extend": map[string]interface{}{"with": []interface{}{
+				map[string]interface{}{"name": "base", "path": "root:org"},
+				map[string]interface{}{"name": "universal", "path": "root"},
+			}},
+		},
+	}}
+	remapWorkspacePaths(schema.GroupResource{Group: "tenancy.kcp.io", Resource: "workspacetypes"}, wst, from, to)
+	with, _, _ := unstructured.NestedSlice(wst.Object, "spec", "extend", "with")
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "base", "path": "root:copy"},
+		map[string]interface{}{"name": "universal", "path": "root"},
+	}, with)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/homedir"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// DefaultCompletionCacheTTL is how long the child workspaces listed for shell completion are reused.
+const DefaultCompletionCacheTTL = 30 * time.Second
+
+// WorkspaceCompleter completes partial workspace paths for shell completion by listing the child
+// workspaces on the current server. The listed children are cached on disk, as every key stroke
+// of the user starts a new completion process.
+type WorkspaceCompleter struct {
+	*base.Options
+
+	// CacheDir is the directory of the cache. Empty disables caching.
+	CacheDir string
+	// CacheTTL is how long cached children are used.
+	CacheTTL time.Duration
+
+	kcpClusterClient kcpclientset.ClusterInterface
+	host             string
+	currentCluster   logicalcluster.Path
+
+	// for testing
+	now func() time.Time
+}
+
+// NewWorkspaceCompleter returns a WorkspaceCompleter caching in ~/.kube/cache/kcp-workspaces.
+// The options are usually those of the command to complete, such that its kubeconfig flags apply.
+func NewWorkspaceCompleter(options *base.Options) *WorkspaceCompleter {
+	return &WorkspaceCompleter{
+		Options:  options,
+		CacheDir: filepath.Join(homedir.HomeDir(), ".kube", "cache", "kcp-workspaces"),
+		CacheTTL: DefaultCompletionCacheTTL,
+		now:      time.Now,
+	}
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (c *WorkspaceCompleter) Complete() error {
+	if err := c.Options.Complete(); err != nil {
+		return err
+	}
+
+	config, err := c.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	u, currentCluster, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return err
+	}
+	u.Path = ""
+	c.host = u.String()
+	c.currentCluster = currentCluster
+
+	c.kcpClusterClient, err = newKCPClusterClient(c.ClientConfig)
+	return err
+}
+
+// WorkspacePathsCompletion returns a cobra completion func for the first argument with CompleteWorkspacePaths.
+func (c *WorkspaceCompleter) WorkspacePathsCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return c.completion(c.CompleteWorkspacePaths)
+}
+
+// AbsolutePathsCompletion returns a cobra completion func for the first argument with CompleteAbsolutePaths.
+func (c *WorkspaceCompleter) AbsolutePathsCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return c.completion(c.CompleteAbsolutePaths)
+}
+
+func (c *WorkspaceCompleter) completion(complete func(context.Context, string) ([]string, error)) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if c.kcpClusterClient == nil {
+			if err := c.Complete(); err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+		}
+		paths, err := complete(cmd.Context(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		// no space, such that the user can continue with a child workspace.
+		return paths, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// CompleteWorkspacePaths completes a path as accepted by "kubectl ws", i.e. relative to the
+// current workspace, or absolute with a leading colon.
+func (c *WorkspaceCompleter) CompleteWorkspacePaths(ctx context.Context, toComplete string) ([]string, error) {
+	if strings.HasPrefix(toComplete, "~") || strings.HasPrefix(toComplete, "-") {
+		return nil, nil
+	}
+
+	prefix, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, ":"); i >= 0 {
+		prefix, partial = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	var parent logicalcluster.Path
+	switch {
+	case prefix == ":":
+		return matching([]string{core.RootCluster.String()}, ":", partial), nil
+	case strings.HasPrefix(prefix, ":"):
+		parent = logicalcluster.NewPath(strings.Trim(prefix, ":"))
+	case prefix == "":
+		parent = c.currentCluster
+	default:
+		resolved, err := resolveDots(c.currentCluster.Join(strings.TrimSuffix(prefix, ":")).String())
+		if err != nil {
+			return nil, nil //nolint:nilerr // nothing to complete above the root
+		}
+		parent = resolved
+	}
+
+	children, err := c.children(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	if prefix == "" && !parent.Empty() && parent != core.RootCluster.Path() {
+		children = append(children, "..")
+	}
+	return matching(children, prefix, partial), nil
+}
+
+// CompleteAbsolutePaths completes an absolute path without leading colon, as accepted by
+// "kubectl kcp bind apiexport".
+func (c *WorkspaceCompleter) CompleteAbsolutePaths(ctx context.Context, toComplete string) ([]string, error) {
+	i := strings.LastIndex(toComplete, ":")
+	if i < 0 {
+		return matching([]string{core.RootCluster.String()}, "", toComplete), nil
+	}
+	children, err := c.children(ctx, logicalcluster.NewPath(toComplete[:i]))
+	if err != nil {
+		return nil, err
+	}
+	return matching(children, toComplete[:i+1], toComplete[i+1:]), nil
+}
+
+func matching(names []string, prefix, partial string) []string {
+	var ret []string
+	for _, name := range names {
+		if strings.HasPrefix(name, partial) {
+			ret = append(ret, prefix+name)
+		}
+	}
+	return ret
+}
+
+// cachedChildren is the cache file content of the children of a workspace.
+type cachedChildren struct {
+	Children []string `json:"children"`
+}
+
+// children returns the names of the child workspaces of the given workspace, from the cache if it is fresh.
+func (c *WorkspaceCompleter) children(ctx context.Context, parent logicalcluster.Path) ([]string, error) {
+	if !parent.IsValid() {
+		return nil, nil
+	}
+
+	var cacheFile string
+	if c.CacheDir != "" {
+		sum := sha256.Sum256([]byte(c.host + "/clusters/" + parent.String()))
+		cacheFile = filepath.Join(c.CacheDir, hex.EncodeToString(sum[:16])+".json")
+		if info, err := os.Stat(cacheFile); err == nil && c.now().Sub(info.ModTime()) < c.CacheTTL {
+			if data, err := os.ReadFile(cacheFile); err == nil {
+				var cached cachedChildren
+				if err := json.Unmarshal(data, &cached); err == nil {
+					return cached.Children, nil
+				}
+			}
+		}
+	}
+
+	list, err := c.kcpClusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	children := make([]string, 0, len(list.Items))
+	for _, ws := range list.Items {
+		children = append(children, ws.Name)
+	}
+	sort.Strings(children)
+
+	// caching is best effort, completion works without.
+	if cacheFile != "" {
+		if data, err := json.Marshal(cachedChildren{Children: children}); err == nil {
+			if err := os.MkdirAll(c.CacheDir, 0o750); err == nil {
+				_ = os.WriteFile(cacheFile, data, 0o600)
+			}
+		}
+	}
+	return children, nil
+}
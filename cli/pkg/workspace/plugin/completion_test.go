@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+)
+
+func newTestWorkspace(cluster, name string) *tenancyv1alpha1.Workspace {
+	return &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+	}}
+}
+
+func newTestWorkspaceCompleter(t *testing.T, client *kcpfakeclient.ClusterClientset) *WorkspaceCompleter {
+	t.Helper()
+	c := NewWorkspaceCompleter(base.NewOptions(genericclioptions.NewTestIOStreamsDiscard()))
+	c.CacheDir = ""
+	c.kcpClusterClient = client
+	c.host = "https://test"
+	c.currentCluster = logicalcluster.NewPath("root:foo")
+	return c
+}
+
+func TestCompleteWorkspacePaths(t *testing.T) {
+	client := kcpfakeclient.NewSimpleClientset(
+		newTestWorkspace("root", "foo"),
+		newTestWorkspace("root", "other"),
+		newTestWorkspace("root:foo", "bar"),
+		newTestWorkspace("root:foo", "baz"),
+		newTestWorkspace("root:foo:bar", "nested"),
+	)
+	c := newTestWorkspaceCompleter(t, client)
+
+	tests := map[string][]string{
+		"":          {"bar", "baz", ".."},
+		"b":         {"bar", "baz"},
+		"bar:":      {"bar:nested"},
+		"..:o":      {"..:other"},
+		":":         {":root"},
+		":root:":    {":root:foo", ":root:other"},
+		":root:foo": {":root:foo"},
+		"~":         nil,
+		"-":         nil,
+		"..:..:..:": nil,
+	}
+	for toComplete, expected := range tests {
+		got, err := c.CompleteWorkspacePaths(context.Background(), toComplete)
+		require.NoError(t, err, toComplete)
+		require.Equal(t, expected, got, toComplete)
+	}
+
+	got, err := c.CompleteAbsolutePaths(context.Background(), "ro")
+	require.NoError(t, err)
+	require.Equal(t, []string{"root"}, got)
+	got, err = c.CompleteAbsolutePaths(context.Background(), "root:foo:b")
+	require.NoError(t, err)
+	require.Equal(t, []string{"root:foo:bar", "root:foo:baz"}, got)
+}
+
+func TestCompleteWorkspacePathsCache(t *testing.T) {
+	client := kcpfakeclient.NewSimpleClientset(newTestWorkspace("root:foo", "bar"))
+	c := newTestWorkspaceCompleter(t, client)
+	c.CacheDir = t.TempDir()
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	got, err := c.CompleteWorkspacePaths(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", ".."}, got)
+
+	err = client.Tracker().Cluster(logicalcluster.NewPath("root:foo")).Create(tenancyv1alpha1.SchemeGroupVersion.WithResource("workspaces"), newTestWorkspace("root:foo", "baz"), "")
+	require.NoError(t, err)
+
+	got, err = c.CompleteWorkspacePaths(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", ".."}, got, "expected the cached children")
+
+	now = now.Add(2 * DefaultCompletionCacheTTL)
+	got, err = c.CompleteWorkspacePaths(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", "baz", ".."}, got)
+}
@@ -19,7 +19,9 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -36,6 +38,7 @@ import (
 
 	"github.com/kcp-dev/kcp/cli/pkg/base"
 	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
@@ -58,6 +61,15 @@ type CreateWorkspaceOptions struct {
 	ReadyWaitTimeout time.Duration
 	// LocationSelector is the location selector to use when creating the workspace to select a matching shard.
 	LocationSelector string
+	// PollInterval is the interval to check for readiness. Defaults to 500ms.
+	PollInterval time.Duration
+	// TTL is the time after which the workspace is deleted automatically. Zero means never.
+	TTL time.Duration
+	// BindAPIExports are the APIExports, each as <workspace_path:apiexport-name>, to bind in the
+	// workspace once it is ready.
+	BindAPIExports []string
+	// WaitForInitializers waits without timeout until all initializers are done, showing their progress.
+	WaitForInitializers bool
 
 	kcpClusterClient kcpclientset.ClusterInterface
 
@@ -98,6 +110,14 @@ func (o *CreateWorkspaceOptions) Validate() error {
 	if _, err := metav1.ParseToLabelSelector(o.LocationSelector); err != nil {
 		return fmt.Errorf("invalid location selector: %w", err)
 	}
+	if o.TTL < 0 {
+		return fmt.Errorf("--ttl must not be negative")
+	}
+	for _, ref := range o.BindAPIExports {
+		if path, name := logicalcluster.NewPath(ref).Split(); path.Empty() || name == "" {
+			return fmt.Errorf("--bind %q must be of the format <workspace_path:apiexport-name>", ref)
+		}
+	}
 
 	return o.Options.Validate()
 }
@@ -109,6 +129,9 @@ func (o *CreateWorkspaceOptions) BindFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&o.EnterAfterCreate, "enter", o.EnterAfterCreate, "Immediately enter the created workspace")
 	cmd.Flags().BoolVar(&o.IgnoreExisting, "ignore-existing", o.IgnoreExisting, "Ignore if the workspace already exists. Requires none or absolute type path.")
 	cmd.Flags().StringVar(&o.LocationSelector, "location-selector", o.LocationSelector, "A label selector to select the scheduling location of the created workspace.")
+	cmd.Flags().DurationVar(&o.TTL, "ttl", o.TTL, "Delete the created workspace automatically after the given duration, e.g. 48h.")
+	cmd.Flags().StringArrayVar(&o.BindAPIExports, "bind", o.BindAPIExports, "Bind the given APIExport, as <workspace_path:apiexport-name>, once the workspace is ready. Can be repeated. Permission claims are not accepted.")
+	cmd.Flags().BoolVar(&o.WaitForInitializers, "wait-for-initializers", o.WaitForInitializers, "Wait without timeout until all initializers of the workspace are done, showing their progress.")
 }
 
 // Run creates a workspace.
@@ -163,6 +186,10 @@ func (o *CreateWorkspaceOptions) Run(ctx context.Context) error {
 		}
 	}
 
+	if o.TTL > 0 {
+		ws.Spec.TTL = &metav1.Duration{Duration: o.TTL}
+	}
+
 	preExisting := false
 	ws, err = o.kcpClusterClient.Cluster(currentClusterName).TenancyV1alpha1().Workspaces().Create(ctx, ws, metav1.CreateOptions{})
 	if apierrors.IsAlreadyExists(err) && o.IgnoreExisting {
@@ -189,7 +216,7 @@ func (o *CreateWorkspaceOptions) Run(ctx context.Context) error {
 				return err
 			}
 		}
-	} else if ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && o.ReadyWaitTimeout > 0 {
+	} else if ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && (o.ReadyWaitTimeout > 0 || o.WaitForInitializers) {
 		if _, err := fmt.Fprintf(o.Out, "%s created. Waiting for it to be ready...\n", workspaceReference); err != nil {
 			return err
 		}
@@ -212,7 +239,14 @@ func (o *CreateWorkspaceOptions) Run(ctx context.Context) error {
 
 	// wait for being ready
 	if ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
-		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*500, o.ReadyWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		waitCtx, cancel := ctx, func() {}
+		var spinner *progressSpinner
+		if o.WaitForInitializers {
+			spinner = newProgressSpinner(o.ErrOut)
+		} else {
+			waitCtx, cancel = context.WithTimeout(ctx, o.ReadyWaitTimeout)
+		}
+		err := wait.PollUntilContextCancel(waitCtx, o.pollInterval(), true, func(ctx context.Context) (bool, error) {
 			ws, err = o.kcpClusterClient.Cluster(currentClusterName).TenancyV1alpha1().Workspaces().Get(ctx, ws.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, err
@@ -220,8 +254,16 @@ func (o *CreateWorkspaceOptions) Run(ctx context.Context) error {
 			if ws.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
 				return true, nil
 			}
+			if spinner != nil {
+				spinner.update(initializersProgress(ws))
+			}
 			return false, nil
-		}); err != nil {
+		})
+		cancel()
+		if spinner != nil {
+			spinner.stop()
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -229,6 +271,10 @@ func (o *CreateWorkspaceOptions) Run(ctx context.Context) error {
 		return err
 	}
 
+	if err := o.bindAPIExports(ctx, currentClusterName.Join(ws.Name)); err != nil {
+		return err
+	}
+
 	if o.EnterAfterCreate {
 		useOptions := NewUseWorkspaceOptions(o.IOStreams)
 		useOptions.Name = ws.Name
@@ -248,6 +294,147 @@ func (o *CreateWorkspaceOptions) Run(ctx context.Context) error {
 	return nil
 }
 
+// CompleteTypes returns the workspace types usable in the current workspace that start with
+// toComplete: the types of the current workspace by name, and those of its ancestors by
+// absolute reference.
+func (o *CreateWorkspaceOptions) CompleteTypes(ctx context.Context, toComplete string) ([]string, error) {
+	if o.kcpClusterClient == nil {
+		if err := o.Complete(nil); err != nil {
+			return nil, err
+		}
+	}
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	_, currentClusterName, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("current URL %q does not point to a workspace", config.Host)
+	}
+
+	var types []string
+	for path, relative := currentClusterName, true; !path.Empty(); path, relative = parentPath(path), false {
+		list, err := o.kcpClusterClient.Cluster(path).TenancyV1alpha1().WorkspaceTypes().List(ctx, metav1.ListOptions{})
+		if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, wt := range list.Items {
+			name := path.Join(wt.Name).String()
+			if relative {
+				name = wt.Name
+			}
+			if strings.HasPrefix(name, toComplete) {
+				types = append(types, name)
+			}
+		}
+	}
+	return types, nil
+}
+
+func parentPath(path logicalcluster.Path) logicalcluster.Path {
+	parent, _ := path.Parent()
+	return parent
+}
+
+// bindAPIExports creates an APIBinding for every APIExport to bind in the given workspace, and
+// waits for them to be bound.
+func (o *CreateWorkspaceOptions) bindAPIExports(ctx context.Context, workspace logicalcluster.Path) error {
+	bindings := o.kcpClusterClient.Cluster(workspace).ApisV1alpha1().APIBindings()
+	for _, ref := range o.BindAPIExports {
+		path, name := logicalcluster.NewPath(ref).Split()
+		binding := &apisv1alpha1.APIBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{
+					Export: &apisv1alpha1.ExportBindingReference{Path: path.String(), Name: name},
+				},
+			},
+		}
+		if _, err := bindings.Create(ctx, binding, metav1.CreateOptions{}); apierrors.IsAlreadyExists(err) && o.IgnoreExisting {
+			if _, err := fmt.Fprintf(o.Out, "apibinding %s already exists.\n", name); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return fmt.Errorf("error binding %s: %w", ref, err)
+		}
+	}
+
+	for _, ref := range o.BindAPIExports {
+		_, name := logicalcluster.NewPath(ref).Split()
+		if err := wait.PollUntilContextTimeout(ctx, o.pollInterval(), o.ReadyWaitTimeout, true, func(ctx context.Context) (bool, error) {
+			binding, err := bindings.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			return binding.Status.Phase == apisv1alpha1.APIBindingPhaseBound, nil
+		}); err != nil {
+			return fmt.Errorf("could not bind %s: %w", ref, err)
+		}
+		if _, err := fmt.Fprintf(o.Out, "apibinding %s bound.\n", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *CreateWorkspaceOptions) pollInterval() time.Duration {
+	if o.PollInterval > 0 {
+		return o.PollInterval
+	}
+	return time.Millisecond * 500
+}
+
+// initializersProgress describes the initializers a workspace waits for.
+func initializersProgress(ws *tenancyv1alpha1.Workspace) string {
+	if len(ws.Status.Initializers) == 0 {
+		return fmt.Sprintf("Workspace %q is %s", ws.Name, ws.Status.Phase)
+	}
+	initializers := make([]string, 0, len(ws.Status.Initializers))
+	for _, initializer := range ws.Status.Initializers {
+		initializers = append(initializers, string(initializer))
+	}
+	return fmt.Sprintf("Workspace %q waits for %d initializers: %s", ws.Name, len(initializers), strings.Join(initializers, ", "))
+}
+
+// progressSpinner shows a progress message behind a spinner on a terminal. On other writers,
+// it prints every changed message on a new line.
+type progressSpinner struct {
+	out      io.Writer
+	terminal bool
+	frame    int
+	last     string
+}
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+func newProgressSpinner(out io.Writer) *progressSpinner {
+	s := &progressSpinner{out: out}
+	if f, ok := out.(*os.File); ok {
+		if info, err := f.Stat(); err == nil {
+			s.terminal = info.Mode()&os.ModeCharDevice != 0
+		}
+	}
+	return s
+}
+
+func (s *progressSpinner) update(msg string) {
+	if s.terminal {
+		fmt.Fprintf(s.out, "\r%s %s\x1b[K", spinnerFrames[s.frame%len(spinnerFrames)], msg) //nolint:errcheck
+		s.frame++
+	} else if msg != s.last {
+		fmt.Fprintln(s.out, msg) //nolint:errcheck
+	}
+	s.last = msg
+}
+
+func (s *progressSpinner) stop() {
+	if s.terminal && s.last != "" {
+		fmt.Fprint(s.out, "\r\x1b[K") //nolint:errcheck
+	}
+}
+
 func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.ClusterInterface, error) {
 	config, err := clientConfig.ClientConfig()
 	if err != nil {
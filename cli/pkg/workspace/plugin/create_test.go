@@ -35,6 +35,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
@@ -261,3 +262,83 @@ func TestCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateWaitForInitializersAndBind(t *testing.T) {
+	currentClusterName := logicalcluster.NewPath("root:foo")
+	client := kcpfakeclient.NewSimpleClientset()
+
+	// the workspace becomes ready after its initializers are done one by one, one on every get.
+	initializers := []corev1alpha1.LogicalClusterInitializer{"system:apibindings", "root:universal", "root:foo:bar"}
+	client.PrependReactor("get", "workspaces", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		ws := &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+			Status:     tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseInitializing, Initializers: initializers},
+		}
+		if len(initializers) == 0 {
+			ws.Status.Phase = corev1alpha1.LogicalClusterPhaseReady
+		} else {
+			initializers = initializers[1:]
+		}
+		return true, ws, nil
+	})
+	client.PrependReactor("create", "apibindings", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		binding := action.(kcptesting.CreateAction).GetObject().(*apisv1alpha1.APIBinding)
+		binding.Status.Phase = apisv1alpha1.APIBindingPhaseBound
+		return true, binding, client.Tracker().Cluster(action.GetCluster()).Create(apisv1alpha1.SchemeGroupVersion.WithResource("apibindings"), binding, "")
+	})
+
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	opts := NewCreateWorkspaceOptions(streams)
+	opts.Name = "bar"
+	opts.WaitForInitializers = true
+	opts.BindAPIExports = []string{"root:my-service:my-export"}
+	opts.PollInterval = time.Millisecond
+	opts.kcpClusterClient = client
+	opts.ClientConfig = clientcmd.NewDefaultClientConfig(clientcmdapi.Config{CurrentContext: "test",
+		Contexts:  map[string]*clientcmdapi.Context{"test": {Cluster: "test", AuthInfo: "test"}},
+		Clusters:  map[string]*clientcmdapi.Cluster{"test": {Server: "https://test/clusters/root:foo"}},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+	}, nil)
+	require.NoError(t, opts.Validate())
+	require.NoError(t, opts.Run(context.Background()))
+
+	require.Equal(t, `Workspace "bar" waits for 2 initializers: root:universal, root:foo:bar
+Workspace "bar" waits for 1 initializers: root:foo:bar
+`, errOut.String())
+	require.Contains(t, out.String(), "is ready to use.\napibinding my-export bound.\n")
+
+	binding, err := client.Cluster(currentClusterName.Join("bar")).ApisV1alpha1().APIBindings().Get(context.Background(), "my-export", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, &apisv1alpha1.ExportBindingReference{Path: "root:my-service", Name: "my-export"}, binding.Spec.Reference.Export)
+}
+
+func TestCompleteTypes(t *testing.T) {
+	workspaceType := func(cluster, name string) *tenancyv1alpha1.WorkspaceType {
+		return &tenancyv1alpha1.WorkspaceType{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+		}}
+	}
+	client := kcpfakeclient.NewSimpleClientset(
+		workspaceType("root", "universal"),
+		workspaceType("root", "organization"),
+		workspaceType("root:foo", "team"),
+		workspaceType("root:other", "unrelated"),
+	)
+
+	opts := NewCreateWorkspaceOptions(genericclioptions.NewTestIOStreamsDiscard())
+	opts.kcpClusterClient = client
+	opts.ClientConfig = clientcmd.NewDefaultClientConfig(clientcmdapi.Config{CurrentContext: "test",
+		Contexts:  map[string]*clientcmdapi.Context{"test": {Cluster: "test", AuthInfo: "test"}},
+		Clusters:  map[string]*clientcmdapi.Cluster{"test": {Server: "https://test/clusters/root:foo"}},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+	}, nil)
+
+	types, err := opts.CompleteTypes(context.Background(), "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"team", "root:universal", "root:organization"}, types)
+
+	types, err = opts.CompleteTypes(context.Background(), "root:u")
+	require.NoError(t, err)
+	require.Equal(t, []string{"root:universal"}, types)
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+var (
+	workspacesResource = tenancyv1alpha1.Resource("workspaces")
+
+	// exportSkippedResources are resources that are never exported as they are
+	// owned by the system or are meaningless in another workspace.
+	exportSkippedResources = sets.New[schema.GroupResource](
+		schema.GroupResource{Resource: "events"},
+		schema.GroupResource{Group: "events.k8s.io", Resource: "events"},
+		corev1alpha1.Resource("logicalclusters"),
+	)
+)
+
+// ExportOptions contains options for exporting a workspace.
+type ExportOptions struct {
+	*base.Options
+
+	// File is the file to write the archive to, or "-" for stdout. It is a directory
+	// for ArchiveFormatDir.
+	File string
+	// Format is the format of the archive.
+	Format string
+	// Recursive includes the child workspaces and their objects.
+	Recursive bool
+	// APIGroups are the API groups whose objects are exported, "core" for the core group.
+	// All groups are exported if empty. Workspaces are exported if Recursive anyway.
+	APIGroups []string
+
+	kcpClusterClient     kcpclientset.ClusterInterface
+	dynamicClusterClient kcpdynamic.ClusterInterface
+}
+
+// NewExportOptions returns a new ExportOptions.
+func NewExportOptions(streams genericclioptions.IOStreams) *ExportOptions {
+	return &ExportOptions{
+		Options: base.NewOptions(streams),
+
+		Format: string(ArchiveFormatTar),
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *ExportOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().StringVar(&o.Format, "format", o.Format, "The format of the archive, either 'tar', 'oci' for an OCI image layout, or 'dir' for a directory")
+	cmd.Flags().BoolVarP(&o.Recursive, "recursive", "r", o.Recursive, "Include child workspaces and their objects")
+	cmd.Flags().StringSliceVar(&o.APIGroups, "api-group", o.APIGroups, "Only export objects of these API groups, 'core' for the core group. Can be repeated.")
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *ExportOptions) Complete(args []string) error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		o.File = args[0]
+	}
+
+	var err error
+	if o.kcpClusterClient, err = newKCPClusterClient(o.ClientConfig); err != nil {
+		return err
+	}
+	if o.dynamicClusterClient, err = newDynamicClusterClient(o.ClientConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate validates the ExportOptions are complete and usable.
+func (o *ExportOptions) Validate() error {
+	if o.File == "" {
+		return errors.New("file is required")
+	}
+	switch ArchiveFormat(o.Format) {
+	case ArchiveFormatTar, ArchiveFormatOCI:
+	case ArchiveFormatDir:
+		if o.File == "-" {
+			return errors.New("the 'dir' format cannot be written to stdout")
+		}
+	default:
+		return fmt.Errorf("unknown format %q, must be 'tar', 'oci' or 'dir'", o.Format)
+	}
+
+	return o.Options.Validate()
+}
+
+// Run exports the current workspace.
+func (o *ExportOptions) Run(ctx context.Context) error {
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	_, current, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current config context URL %q does not point to workspace", config.Host)
+	}
+
+	objects, err := o.collect(ctx, current, logicalcluster.Path{})
+	if err != nil {
+		return err
+	}
+	meta := archiveMetadata{Workspace: current.String()}
+
+	if ArchiveFormat(o.Format) == ArchiveFormatDir {
+		if err := writeArchiveDir(o.File, meta, objects); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(o.Out, "Exported %d objects of workspace %q to %s.\n", len(objects), current, o.File)
+		return err
+	}
+
+	var w io.Writer = o.Out
+	if o.File != "-" {
+		f, err := os.Create(o.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := writeArchive(w, ArchiveFormat(o.Format), meta, objects, time.Now()); err != nil {
+		return err
+	}
+
+	if o.File != "-" {
+		_, err = fmt.Fprintf(o.Out, "Exported %d objects of workspace %q to %s.\n", len(objects), current, o.File)
+	}
+	return err
+}
+
+// collect returns the exportable objects of the given workspace, and of its children if
+// recursive.
+func (o *ExportOptions) collect(ctx context.Context, cluster, relative logicalcluster.Path) ([]archivedObject, error) {
+	resources, err := o.kcpClusterClient.Cluster(cluster).Discovery().ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover resources of workspace %q: %w", cluster, err)
+	}
+
+	groups := sets.New[string](o.APIGroups...)
+	if groups.Has("core") {
+		groups.Insert("")
+	}
+
+	var objects []archivedObject
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range list.APIResources {
+			gr := gv.WithResource(r.Name).GroupResource()
+			if !sets.New[string](r.Verbs...).HasAll("list", "create") || exportSkippedResources.Has(gr) {
+				continue
+			}
+			if gr == workspacesResource && !o.Recursive {
+				continue
+			}
+			if groups.Len() > 0 && !groups.Has(gr.Group) && gr != workspacesResource {
+				continue
+			}
+
+			items, err := o.dynamicClusterClient.Cluster(cluster).Resource(gv.WithResource(r.Name)).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s in workspace %q: %w", gr, cluster, err)
+			}
+			for i := range items.Items {
+				obj := &items.Items[i]
+				if !exportable(obj) {
+					continue
+				}
+				objects = append(objects, archivedObject{Workspace: relative, Resource: gr, Object: sanitizeForExport(gr, obj)})
+
+				if gr != workspacesResource {
+					continue
+				}
+				ws := &tenancyv1alpha1.Workspace{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, ws); err != nil {
+					return nil, err
+				}
+				if ws.Spec.Mount != nil {
+					fmt.Fprintf(o.ErrOut, "Skipping objects of mounted workspace %q.\n", cluster.Join(ws.Name))
+					continue
+				}
+				if ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+					return nil, fmt.Errorf("workspace %q is not ready", cluster.Join(ws.Name))
+				}
+				children, err := o.collect(ctx, cluster.Join(ws.Name), relative.Join(ws.Name))
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, children...)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// exportable returns false for objects that are deleted or recreated by a controller anyway.
+func exportable(obj *unstructured.Unstructured) bool {
+	if obj.GetDeletionTimestamp() != nil {
+		return false
+	}
+	return metav1.GetControllerOf(obj) == nil
+}
+
+// sanitizeForExport removes all fields from the object that are set by the server or
+// that are specific to the logical cluster of the object.
+func sanitizeForExport(gr schema.GroupResource, obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink", "ownerReferences"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, logicalcluster.AnnotationKey)
+	delete(annotations, core.LogicalClusterPathAnnotationKey)
+	obj.SetAnnotations(annotations)
+
+	if gr == workspacesResource {
+		unstructured.RemoveNestedField(obj.Object, "spec", "cluster")
+		unstructured.RemoveNestedField(obj.Object, "spec", "URL")
+	}
+
+	return obj
+}
+
+func newDynamicClusterClient(clientConfig clientcmd.ClientConfig) (kcpdynamic.ClusterInterface, error) {
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clusterConfig := rest.CopyConfig(config)
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = ""
+	clusterConfig.Host = u.String()
+	clusterConfig.UserAgent = rest.DefaultKubernetesUserAgent()
+	return kcpdynamic.NewForConfig(clusterConfig)
+}
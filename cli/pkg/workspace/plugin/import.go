@@ -0,0 +1,302 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/cli/pkg/base"
+	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// importFirstResources are imported before all other resources, in this order, because
+// other objects depend on them.
+var importFirstResources = []schema.GroupResource{
+	{Resource: "namespaces"},
+	{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+	{Group: "apis.kcp.io", Resource: "apiresourceschemas"},
+	{Group: "apis.kcp.io", Resource: "apiexports"},
+	{Group: "apis.kcp.io", Resource: "apibindings"},
+}
+
+// ImportOptions contains options for importing a workspace export.
+type ImportOptions struct {
+	*base.Options
+
+	// File is the file to read the archive from, or "-" for stdin. It can also be a directory
+	// written with the 'dir' format.
+	File string
+	// ReadyWaitTimeout is how long to wait for APIs to be served and workspaces to be ready.
+	ReadyWaitTimeout time.Duration
+
+	In io.Reader
+
+	kcpClusterClient     kcpclientset.ClusterInterface
+	dynamicClusterClient kcpdynamic.ClusterInterface
+}
+
+// NewImportOptions returns a new ImportOptions.
+func NewImportOptions(streams genericclioptions.IOStreams) *ImportOptions {
+	return &ImportOptions{
+		Options: base.NewOptions(streams),
+
+		ReadyWaitTimeout: time.Minute,
+		In:               streams.In,
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *ImportOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().DurationVar(&o.ReadyWaitTimeout, "ready-wait-timeout", o.ReadyWaitTimeout, "How long to wait for APIs to be served and workspaces to become ready")
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *ImportOptions) Complete(args []string) error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		o.File = args[0]
+	}
+
+	var err error
+	if o.kcpClusterClient, err = newKCPClusterClient(o.ClientConfig); err != nil {
+		return err
+	}
+	if o.dynamicClusterClient, err = newDynamicClusterClient(o.ClientConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate validates the ImportOptions are complete and usable.
+func (o *ImportOptions) Validate() error {
+	if o.File == "" {
+		return errors.New("file is required")
+	}
+
+	return o.Options.Validate()
+}
+
+// Run imports an archive into the current workspace.
+func (o *ImportOptions) Run(ctx context.Context) error {
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	_, current, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current config context URL %q does not point to workspace", config.Host)
+	}
+
+	meta, objects, err := o.read()
+	if err != nil {
+		return err
+	}
+	if meta.Workspace != "" {
+		from := logicalcluster.NewPath(meta.Workspace)
+		for _, obj := range objects {
+			remapWorkspacePaths(obj.Resource, obj.Object, from, current)
+		}
+	}
+
+	byWorkspace := map[string][]archivedObject{}
+	for _, obj := range objects {
+		byWorkspace[obj.Workspace.String()] = append(byWorkspace[obj.Workspace.String()], obj)
+	}
+	workspaces := make([]logicalcluster.Path, 0, len(byWorkspace))
+	for ws := range byWorkspace {
+		workspaces = append(workspaces, logicalcluster.NewPath(ws))
+	}
+	// parents go first, as their Workspace objects create the children.
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].String() < workspaces[j].String()
+	})
+
+	for _, ws := range workspaces {
+		cluster := current
+		if !ws.Empty() {
+			cluster = current.Join(ws.String())
+			if err := o.waitForWorkspace(ctx, cluster); err != nil {
+				return err
+			}
+		}
+		if err := o.importObjects(ctx, cluster, sortedForImport(byWorkspace[ws.String()])); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(o.Out, "Imported %d objects into workspace %q.\n", len(byWorkspace[ws.String()]), cluster); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// read reads the archive from stdin, a file or a directory.
+func (o *ImportOptions) read() (archiveMetadata, []archivedObject, error) {
+	if o.File == "-" {
+		return readArchive(o.In)
+	}
+	if info, err := os.Stat(o.File); err != nil {
+		return archiveMetadata{}, nil, err
+	} else if info.IsDir() {
+		return readArchiveDir(o.File)
+	}
+	f, err := os.Open(o.File)
+	if err != nil {
+		return archiveMetadata{}, nil, err
+	}
+	defer f.Close()
+	return readArchive(f)
+}
+
+// remappedPathFields are the fields holding workspace paths, by resource.
+var remappedPathFields = map[schema.GroupResource][][]string{
+	{Group: "apis.kcp.io", Resource: "apibindings"}: {{"spec", "reference", "export", "path"}},
+	workspacesResource:                                          {{"spec", "type", "path"}},
+	tenancyv1alpha1.Resource("workspacetypes"):                  {{"spec", "defaultChildWorkspaceType", "path"}},
+	{Group: "apis.kcp.io", Resource: "apiexportendpointslices"}: {{"spec", "export", "path"}},
+}
+
+// remapWorkspacePaths rewrites the paths in the object that point to the exported workspace
+// or one of its children, such that they point into the workspace imported into.
+func remapWorkspacePaths(gr schema.GroupResource, obj *unstructured.Unstructured, from, to logicalcluster.Path) {
+	remap := func(value string) string {
+		switch {
+		case value == from.String():
+			return to.String()
+		case strings.HasPrefix(value, from.String()+":"):
+			return to.Join(strings.TrimPrefix(value, from.String()+":")).String()
+		}
+		return value
+	}
+
+	for _, field := range remappedPathFields[gr] {
+		if value, found, _ := unstructured.NestedString(obj.Object, field...); found && value != "" {
+			_ = unstructured.SetNestedField(obj.Object, remap(value), field...)
+		}
+	}
+	if gr == tenancyv1alpha1.Resource("workspacetypes") {
+		for _, field := range [][]string{{"spec", "extend", "with"}, {"spec", "limitAllowedChildren", "types"}, {"spec", "limitAllowedParents", "types"}} {
+			refs, found, _ := unstructured.NestedSlice(obj.Object, field...)
+			if !found {
+				continue
+			}
+			for _, ref := range refs {
+				if m, ok := ref.(map[string]interface{}); ok {
+					if value, ok := m["path"].(string); ok && value != "" {
+						m["path"] = remap(value)
+					}
+				}
+			}
+			_ = unstructured.SetNestedSlice(obj.Object, refs, field...)
+		}
+	}
+}
+
+// importObjects creates the given objects, skipping those that exist already. Objects
+// whose API is not served yet, e.g. because the APIBinding is not bound yet, are retried
+// until ReadyWaitTimeout.
+func (o *ImportOptions) importObjects(ctx context.Context, cluster logicalcluster.Path, objects []archivedObject) error {
+	pending := objects
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, time.Second, o.ReadyWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		var retry []archivedObject
+		for _, obj := range pending {
+			gvr := obj.Resource.WithVersion(obj.Object.GroupVersionKind().Version)
+			_, err := o.dynamicClusterClient.Cluster(cluster).Resource(gvr).Namespace(obj.Object.GetNamespace()).Create(ctx, obj.Object, metav1.CreateOptions{})
+			switch {
+			case err == nil, apierrors.IsAlreadyExists(err):
+			case apierrors.IsNotFound(err):
+				lastErr = fmt.Errorf("failed to create %s %s: %w", obj.Resource, objectName(obj), err)
+				retry = append(retry, obj)
+			default:
+				return false, fmt.Errorf("failed to create %s %s in workspace %q: %w", obj.Resource, objectName(obj), cluster, err)
+			}
+		}
+		pending = retry
+		return len(pending) == 0, nil
+	})
+	if err != nil && lastErr != nil && wait.Interrupted(err) {
+		return fmt.Errorf("failed to import %d objects into workspace %q: %w", len(pending), cluster, lastErr)
+	}
+	return err
+}
+
+// waitForWorkspace waits until the given workspace is ready.
+func (o *ImportOptions) waitForWorkspace(ctx context.Context, cluster logicalcluster.Path) error {
+	parent, name := cluster.Split()
+	return wait.PollUntilContextTimeout(ctx, time.Millisecond*500, o.ReadyWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		ws, err := o.kcpClusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get workspace %q: %w", cluster, err)
+		}
+		return ws.Status.Phase == corev1alpha1.LogicalClusterPhaseReady, nil
+	})
+}
+
+// sortedForImport sorts the objects by the order in which they have to be created.
+func sortedForImport(objects []archivedObject) []archivedObject {
+	rank := func(gr schema.GroupResource) int {
+		for i, first := range importFirstResources {
+			if gr == first {
+				return i
+			}
+		}
+		if gr == workspacesResource {
+			return len(importFirstResources) + 1
+		}
+		return len(importFirstResources)
+	}
+
+	sorted := append([]archivedObject(nil), objects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i].Resource) < rank(sorted[j].Resource)
+	})
+	return sorted
+}
+
+func objectName(obj archivedObject) string {
+	if ns := obj.Object.GetNamespace(); ns != "" {
+		return ns + "/" + obj.Object.GetName()
+	}
+	return obj.Object.GetName()
+}
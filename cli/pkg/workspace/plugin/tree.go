@@ -18,7 +18,10 @@ package plugin
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -28,19 +31,39 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
 
 	"github.com/kcp-dev/kcp/cli/pkg/base"
 	pluginhelpers "github.com/kcp-dev/kcp/cli/pkg/helpers"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 )
 
+// workspaceIndexPath is the path the front-proxy serves its workspace index on.
+const workspaceIndexPath = "/workspaceindex"
+
+// errIndexUnavailable is returned if the workspace index cannot be read, e.g. because
+// the user is not in an allowed group, or the server is not a front-proxy.
+var errIndexUnavailable = errors.New("workspace index unavailable")
+
 // TreeOptions contains options for displaying the workspace tree.
 type TreeOptions struct {
 	*base.Options
 
 	Full bool
+	// Depth is the number of levels below the current workspace to print. Zero means all.
+	Depth int
 
 	kcpClusterClient kcpclientset.ClusterInterface
+	// getIndex returns the entries of the workspace index of the front-proxy.
+	getIndex func(ctx context.Context) ([]indexEntry, error)
+}
+
+// indexEntry is an entry of the workspace index of the front-proxy.
+type indexEntry struct {
+	Path    string `json:"path"`
+	Cluster string `json:"cluster,omitempty"`
+	Shard   string `json:"shard,omitempty"`
 }
 
 // NewTreeOptions returns a new TreeOptions.
@@ -54,6 +77,15 @@ func NewTreeOptions(streams genericclioptions.IOStreams) *TreeOptions {
 func (o *TreeOptions) BindFlags(cmd *cobra.Command) {
 	o.Options.BindFlags(cmd)
 	cmd.Flags().BoolVarP(&o.Full, "full", "f", o.Full, "Show full workspace names")
+	cmd.Flags().IntVar(&o.Depth, "depth", o.Depth, "Number of levels below the current workspace to show. 0 shows all levels.")
+}
+
+// Validate validates the TreeOptions are complete and usable.
+func (o *TreeOptions) Validate() error {
+	if o.Depth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+	return o.Options.Validate()
 }
 
 // Complete ensures all dynamically populated fields are initialized.
@@ -68,10 +100,20 @@ func (o *TreeOptions) Complete() error {
 	}
 	o.kcpClusterClient = kcpClusterClient
 
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	o.getIndex = func(ctx context.Context) ([]indexEntry, error) {
+		return getWorkspaceIndex(ctx, config)
+	}
+
 	return nil
 }
 
-// Run outputs the current workspace.
+// Run outputs the workspace tree below the current workspace. The tree is read from the
+// workspace index of the front-proxy with a single wildcard list of workspaces if the user
+// may read it, and workspace by workspace otherwise.
 func (o *TreeOptions) Run(ctx context.Context) error {
 	config, err := o.ClientConfig.ClientConfig()
 	if err != nil {
@@ -89,16 +131,28 @@ func (o *TreeOptions) Run(ctx context.Context) error {
 	if !o.Full {
 		name = name[strings.LastIndex(name, ":")+1:]
 	}
-	branch := tree.AddBranch(name)
-	if err := o.populateBranch(ctx, branch, current, name); err != nil {
+
+	entries, err := o.getIndex(ctx)
+	switch {
+	case errors.Is(err, errIndexUnavailable):
+		branch := tree.AddBranch(name)
+		if err := o.populateBranch(ctx, branch, current, name, 1); err != nil {
+			return err
+		}
+	case err != nil:
 		return err
+	default:
+		o.populateFromIndex(ctx, tree, current, name, entries)
 	}
 
-	fmt.Println(tree.String())
+	fmt.Fprintln(o.Out, tree.String())
 	return nil
 }
 
-func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, parent logicalcluster.Path, parentName string) error {
+func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, parent logicalcluster.Path, parentName string, level int) error {
+	if o.Depth > 0 && level > o.Depth {
+		return nil
+	}
 	results, err := o.kcpClusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -107,7 +161,8 @@ func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, p
 		return err
 	}
 
-	for _, workspace := range results.Items {
+	for i := range results.Items {
+		workspace := &results.Items[i]
 		_, current, err := pluginhelpers.ParseClusterURL(workspace.Spec.URL)
 		if err != nil {
 			return fmt.Errorf("current config context URL %q does not point to workspace", workspace.Spec.URL)
@@ -118,10 +173,111 @@ func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, p
 		if o.Full {
 			name = parentName + ":" + name
 		}
-		branch := tree.AddBranch(name)
-		if err := o.populateBranch(ctx, branch, current, name); err != nil {
+		branch := tree.AddBranch(name + details(workspace, ""))
+		if err := o.populateBranch(ctx, branch, current, name, level+1); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// populateFromIndex adds the workspaces below the current one in the index to the tree,
+// with their types and phases if the workspaces can be listed across all workspaces.
+func (o *TreeOptions) populateFromIndex(ctx context.Context, tree treeprint.Tree, current logicalcluster.Path, name string, entries []indexEntry) {
+	workspaces := map[string]*tenancyv1alpha1.Workspace{}
+	if list, err := o.kcpClusterClient.TenancyV1alpha1().Workspaces().List(ctx, metav1.ListOptions{}); err == nil {
+		for i := range list.Items {
+			if cluster := list.Items[i].Spec.Cluster; cluster != "" {
+				workspaces[cluster] = &list.Items[i]
+			}
+		}
+	}
+
+	branches := map[string]treeprint.Tree{}
+	names := map[string]string{}
+	prefix := current.String() + ":"
+	for _, entry := range entries {
+		// entries are sorted by path, i.e. parents come before their children.
+		if entry.Path == current.String() {
+			branches[entry.Path] = tree.AddBranch(name + details(nil, entry.Shard))
+			names[entry.Path] = name
+			continue
+		}
+		if !strings.HasPrefix(entry.Path, prefix) {
+			continue
+		}
+		if o.Depth > 0 && strings.Count(strings.TrimPrefix(entry.Path, prefix), ":") >= o.Depth {
+			continue
+		}
+		parentPath, base := entry.Path[:strings.LastIndex(entry.Path, ":")], entry.Path[strings.LastIndex(entry.Path, ":")+1:]
+		parent, ok := branches[parentPath]
+		if !ok {
+			continue
+		}
+		childName := base
+		if o.Full {
+			childName = names[parentPath] + ":" + base
+		}
+		branches[entry.Path] = parent.AddBranch(childName + details(workspaces[entry.Cluster], entry.Shard))
+		names[entry.Path] = childName
+	}
+	if _, ok := branches[current.String()]; !ok {
+		tree.AddBranch(name)
+	}
+}
+
+// details returns the type, phase and shard of a workspace, as far as known.
+func details(workspace *tenancyv1alpha1.Workspace, shard string) string {
+	var parts []string
+	if workspace != nil {
+		if workspace.Spec.Type.Name != "" {
+			parts = append(parts, string(workspace.Spec.Type.Name))
+		}
+		if workspace.Status.Phase != "" {
+			parts = append(parts, string(workspace.Status.Phase))
+		}
+	}
+	if shard != "" {
+		parts = append(parts, "shard "+shard)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// getWorkspaceIndex reads the workspace index of the front-proxy.
+func getWorkspaceIndex(ctx context.Context, config *rest.Config) ([]indexEntry, error) {
+	base, _, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base.String(), "/")+workspaceIndexPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return nil, errIndexUnavailable
+	default:
+		return nil, fmt.Errorf("failed to read workspace index: %s", resp.Status)
+	}
+	var list struct {
+		Entries []indexEntry `json:"entries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		// e.g. a shard serving something else on the path
+		return nil, errIndexUnavailable
+	}
+	return list.Entries, nil
+}
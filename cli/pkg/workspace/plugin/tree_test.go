@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/treeprint"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+)
+
+func TestPopulateFromIndex(t *testing.T) {
+	entries := []indexEntry{
+		{Path: "root", Cluster: "root", Shard: "root"},
+		{Path: "root:org", Cluster: "c1", Shard: "root"},
+		{Path: "root:org:team", Cluster: "c2", Shard: "beta"},
+		{Path: "root:org:team:project", Cluster: "c3", Shard: "beta"},
+		{Path: "root:other", Cluster: "c4", Shard: "root"},
+	}
+	workspaces := []runtime.Object{
+		workspace("root", "org", "c1", "organization", corev1alpha1.LogicalClusterPhaseReady),
+		workspace("c1", "team", "c2", "team", corev1alpha1.LogicalClusterPhaseInitializing),
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		full    bool
+		depth   int
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "all levels",
+			current: "root:org",
+			want:    []string{"org (shard root)", "team (team, Initializing, shard beta)", "project (shard beta)"},
+			notWant: []string{"other"},
+		},
+		{
+			name:    "full names",
+			current: "root:org",
+			full:    true,
+			want:    []string{"root:org (shard root)", "root:org:team (team, Initializing, shard beta)", "root:org:team:project (shard beta)"},
+		},
+		{
+			name:    "depth",
+			current: "root:org",
+			depth:   1,
+			want:    []string{"team"},
+			notWant: []string{"project"},
+		},
+		{
+			name:    "root",
+			current: "root",
+			want:    []string{"org (organization, Ready, shard root)", "other (shard root)", "project"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &TreeOptions{
+				Full:             tt.full,
+				Depth:            tt.depth,
+				kcpClusterClient: kcpfakeclient.NewSimpleClientset(workspaces...),
+			}
+			current := logicalcluster.NewPath(tt.current)
+			name := tt.current
+			if !tt.full {
+				name = name[strings.LastIndex(name, ":")+1:]
+			}
+
+			tree := treeprint.New()
+			o.populateFromIndex(context.Background(), tree, current, name, entries)
+			got := tree.String()
+			for _, s := range tt.want {
+				require.Contains(t, got, s)
+			}
+			for _, s := range tt.notWant {
+				require.NotContains(t, got, s)
+			}
+		})
+	}
+}
+
+func workspace(cluster, name, logicalCluster string, typeName tenancyv1alpha1.WorkspaceTypeName, phase corev1alpha1.LogicalClusterPhaseType) *tenancyv1alpha1.Workspace {
+	return &tenancyv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+		},
+		Spec: tenancyv1alpha1.WorkspaceSpec{
+			Type:    tenancyv1alpha1.WorkspaceTypeReference{Name: typeName, Path: "root"},
+			Cluster: logicalCluster,
+		},
+		Status: tenancyv1alpha1.WorkspaceStatus{Phase: phase},
+	}
+}
@@ -17,13 +17,16 @@ limitations under the License.
 package plugin
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -31,6 +34,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -46,8 +50,20 @@ import (
 const (
 	kcpPreviousWorkspaceContextKey string = "workspace.kcp.io/previous"
 	kcpCurrentWorkspaceContextKey  string = "workspace.kcp.io/current"
+
+	// kcpWorkspaceHistoryExtensionKey is the kubeconfig preferences extension holding the
+	// recently used workspaces.
+	kcpWorkspaceHistoryExtensionKey string = "workspace.kcp.io/history"
+	// maxWorkspaceHistory is the number of recently used workspaces that are kept.
+	maxWorkspaceHistory = 10
 )
 
+// workspaceHistory is the content of the workspace history kubeconfig extension.
+type workspaceHistory struct {
+	// URLs are the URLs of the recently used workspaces, most recent first.
+	URLs []string `json:"urls"`
+}
+
 // UseWorkspaceOptions contains options for manipulating or showing the current workspace.
 type UseWorkspaceOptions struct {
 	*base.Options
@@ -56,6 +72,8 @@ type UseWorkspaceOptions struct {
 	Name string
 	// ShortWorkspaceOutput indicates only the workspace name should be printed.
 	ShortWorkspaceOutput bool
+	// Recent lets the user pick one of the recently used workspaces.
+	Recent bool
 
 	kcpClusterClient kcpclientset.ClusterInterface
 	startingConfig   *clientcmdapi.Config
@@ -106,6 +124,9 @@ func (o *UseWorkspaceOptions) Complete(args []string) error {
 
 // Validate validates the UseWorkspaceOptions are complete and usable.
 func (o *UseWorkspaceOptions) Validate() error {
+	if o.Recent && o.Name != "" {
+		return errors.New("--recent cannot be used with a workspace argument")
+	}
 	return o.Options.Validate()
 }
 
@@ -113,6 +134,7 @@ func (o *UseWorkspaceOptions) Validate() error {
 func (o *UseWorkspaceOptions) BindFlags(cmd *cobra.Command) {
 	o.Options.BindFlags(cmd)
 	cmd.Flags().BoolVar(&o.ShortWorkspaceOutput, "short", o.ShortWorkspaceOutput, "Print only the name of the workspace, e.g. for integration into the shell prompt")
+	cmd.Flags().BoolVar(&o.Recent, "recent", o.Recent, "Pick one of the recently used workspaces")
 }
 
 // Run executes the "use workspace" logic based on the supplied options.
@@ -130,7 +152,7 @@ func (o *UseWorkspaceOptions) Run(ctx context.Context) (err error) {
 		name = ":" + name
 		fmt.Fprintf(o.ErrOut, "Note: Using 'root:' to define an absolute path is no longer supported. Instead, use ':root' to specify an absolute path.\n")
 	}
-	if name == "" {
+	if name == "" && !o.Recent {
 		defer func() {
 			if err == nil {
 				_, err = fmt.Fprintf(o.ErrOut, "Note: 'kubectl ws' now matches 'cd' semantics: go to home workspace. 'kubectl ws -' to go back. 'kubectl ws .' to print current workspace.\n")
@@ -151,6 +173,12 @@ func (o *UseWorkspaceOptions) Run(ctx context.Context) (err error) {
 	}
 
 	switch {
+	case o.Recent:
+		u, err := o.pickRecent()
+		if err != nil {
+			return err
+		}
+		return o.commitConfig(ctx, currentContext, u, nil)
 	case name == "-":
 		newServerHost, err := o.swapContexts(ctx, currentContext)
 		if err != nil {
@@ -294,11 +322,14 @@ func (o *UseWorkspaceOptions) swapContexts(ctx context.Context, currentContext *
 
 	newKubeConfig.CurrentContext = kcpCurrentWorkspaceContextKey
 
-	if err := o.modifyConfig(o.ClientConfig.ConfigAccess(), newKubeConfig); err != nil {
+	newServerHost := newKubeConfig.Clusters[newKubeConfig.Contexts[kcpCurrentWorkspaceContextKey].Cluster].Server
+	if err := recordWorkspaceHistory(newKubeConfig, newServerHost); err != nil {
 		return "", err
 	}
 
-	newServerHost := newKubeConfig.Clusters[newKubeConfig.Contexts[kcpCurrentWorkspaceContextKey].Cluster].Server
+	if err := o.modifyConfig(o.ClientConfig.ConfigAccess(), newKubeConfig); err != nil {
+		return "", err
+	}
 
 	bindings, err := o.getAPIBindings(ctx, o.kcpClusterClient, newServerHost)
 	if err != nil {
@@ -338,6 +369,10 @@ func (o *UseWorkspaceOptions) commitConfig(ctx context.Context, currentContext *
 
 	newKubeConfig.CurrentContext = kcpCurrentWorkspaceContextKey
 
+	if err := recordWorkspaceHistory(newKubeConfig, u.String()); err != nil {
+		return err
+	}
+
 	if err := o.modifyConfig(o.ClientConfig.ConfigAccess(), newKubeConfig); err != nil {
 		return err
 	}
@@ -355,6 +390,92 @@ func (o *UseWorkspaceOptions) commitConfig(ctx context.Context, currentContext *
 	return printCurrentWorkspace(o.Out, u.String(), shortWorkspaceOutput(o.ShortWorkspaceOutput), workspaceType)
 }
 
+// pickRecent lists the recently used workspaces other than the current one and reads the
+// choice of the user.
+func (o *UseWorkspaceOptions) pickRecent() (*url.URL, error) {
+	history, err := readWorkspaceHistory(o.startingConfig)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]string, 0, len(history.URLs))
+	for _, u := range history.URLs {
+		if u != cfg.Host {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("no recently used workspaces found in kubeconfig")
+	}
+
+	for i, u := range candidates {
+		_, clusterName, _ := pluginhelpers.ParseClusterURL(u)
+		if _, err := fmt.Fprintf(o.ErrOut, "%2d) %s\n", i+1, clusterName); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := fmt.Fprintf(o.ErrOut, "Select workspace [1-%d]: ", len(candidates)); err != nil {
+		return nil, err
+	}
+	answer, err := bufio.NewReader(o.In).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || i < 1 || i > len(candidates) {
+		return nil, fmt.Errorf("invalid selection %q", strings.TrimSpace(answer))
+	}
+	return url.Parse(candidates[i-1])
+}
+
+// readWorkspaceHistory returns the recently used workspaces stored in the kubeconfig.
+func readWorkspaceHistory(config *clientcmdapi.Config) (*workspaceHistory, error) {
+	history := &workspaceHistory{}
+	ext, found := config.Preferences.Extensions[kcpWorkspaceHistoryExtensionKey]
+	if !found {
+		return history, nil
+	}
+	unknown, ok := ext.(*runtime.Unknown)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T of kubeconfig extension %q", ext, kcpWorkspaceHistoryExtensionKey)
+	}
+	if err := json.Unmarshal(unknown.Raw, history); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig extension %q: %w", kcpWorkspaceHistoryExtensionKey, err)
+	}
+	return history, nil
+}
+
+// recordWorkspaceHistory adds the given workspace URL to the front of the history in the kubeconfig.
+// URLs not pointing to a workspace are not recorded.
+func recordWorkspaceHistory(config *clientcmdapi.Config, u string) error {
+	if _, _, err := pluginhelpers.ParseClusterURL(u); err != nil {
+		return nil
+	}
+	history, err := readWorkspaceHistory(config)
+	if err != nil {
+		// start over rather than failing navigation on a broken history.
+		history = &workspaceHistory{}
+	}
+	urls := []string{u}
+	for _, existing := range history.URLs {
+		if existing != u && len(urls) < maxWorkspaceHistory {
+			urls = append(urls, existing)
+		}
+	}
+	raw, err := json.Marshal(workspaceHistory{URLs: urls})
+	if err != nil {
+		return err
+	}
+	if config.Preferences.Extensions == nil {
+		config.Preferences.Extensions = map[string]runtime.Object{}
+	}
+	config.Preferences.Extensions[kcpWorkspaceHistoryExtensionKey] = &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON}
+	return nil
+}
+
 func (o *UseWorkspaceOptions) homePath(ctx context.Context) (logicalcluster.Path, error) {
 	homeWorkspace, err := o.kcpClusterClient.Cluster(core.RootCluster.Path()).TenancyV1alpha1().Workspaces().Get(ctx, "~", metav1.GetOptions{})
 	if err != nil {
@@ -18,6 +18,7 @@ package plugin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -108,7 +109,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			wantStdout:  []string{"Current workspace is 'root:foo:bar'"},
 		},
@@ -121,7 +122,7 @@ func TestUse(t *testing.T) {
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
 			short:       true,
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			wantStdout:  []string{"root:foo:bar"},
 		},
@@ -139,7 +140,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "root:foo:bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			wantStdout:  []string{"Current workspace is 'root:foo:bar'"},
 		},
@@ -149,7 +150,7 @@ func TestUse(t *testing.T) {
 			getWorkspaceErrors: map[logicalcluster.Path]error{logicalcluster.NewPath("root:foo"): errors.NewForbidden(schema.GroupResource{}, "bar", fmt.Errorf("not allowed"))},
 			discovery:          discoveryFor("root:foo:bar"),
 			param:              "root:foo:bar",
-			expected:           NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:           NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination:        "root:foo:bar",
 			wantStdout:         []string{"Current workspace is 'root:foo:bar'"},
 		},
@@ -196,7 +197,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo").Build(),
 			discovery:   discoveryFor("system:admin"),
 			param:       ":system:admin",
-			expected:    NewKubeconfig().WithKcpCurrent("system:admin").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("system:admin").WithKcpPrevious("root:foo").WithHistory("system:admin").Build(),
 			destination: "system:admin",
 			wantStdout:  []string{"Current workspace is 'system:admin'"},
 		},
@@ -205,7 +206,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo").Build(),
 			discovery:   discoveryFor("root"),
 			param:       "root",
-			expected:    NewKubeconfig().WithKcpCurrent("root").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root").WithKcpPrevious("root:foo").WithHistory("root").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root'"},
 		},
@@ -223,7 +224,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo"),
 			param:       "..",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo").WithKcpPrevious("root:foo:bar").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo").WithKcpPrevious("root:foo:bar").WithHistory("root:foo").Build(),
 			destination: "root:foo",
 			wantStdout:  []string{"Current workspace is 'root:foo'"},
 		},
@@ -238,7 +239,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo").Build(),
 			discovery:   discoveryFor("root"),
 			param:       "..",
-			expected:    NewKubeconfig().WithKcpCurrent("root").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root").WithKcpPrevious("root:foo").WithHistory("root").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root'"},
 		},
@@ -247,7 +248,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").Build(),
 			discovery:   discoveryFor("root:foo"),
 			param:       "../..",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo").WithKcpPrevious("root:foo:bar:baz").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo").WithKcpPrevious("root:foo:bar:baz").WithHistory("root:foo").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root:foo'"},
 		},
@@ -256,7 +257,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").Build(),
 			discovery:   discoveryFor("root:foo"),
 			param:       "..:..",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo").WithKcpPrevious("root:foo:bar:baz").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo").WithKcpPrevious("root:foo:bar:baz").WithHistory("root:foo").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root:foo'"},
 		},
@@ -272,7 +273,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo").Build(),
 			discovery:   discoveryFor("root:foo:bar:baz"),
 			param:       "bar:baz",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").WithHistory("root:foo:bar:baz").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root:foo:bar:baz'"},
 		},
@@ -281,7 +282,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo").Build(),
 			discovery:   discoveryFor("root:foo:bar:baz"),
 			param:       "bar:..:bar:baz:..:baz",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").WithHistory("root:foo:bar:baz").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root:foo:bar:baz'"},
 		},
@@ -290,7 +291,7 @@ func TestUse(t *testing.T) {
 			config:      *NewKubeconfig().WithKcpCurrent("root:foo").Build(),
 			discovery:   discoveryFor("root:foo:bar:baz"),
 			param:       ".:bar:.:.:baz:.",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").WithHistory("root:foo:bar:baz").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root:foo:bar:baz'"},
 		},
@@ -301,7 +302,7 @@ func TestUse(t *testing.T) {
 				logicalcluster.Name("root:foo"): {"bar"},
 			},
 			param:       "-",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			wantStdout:  []string{"Current workspace is 'root:foo:bar'"},
 		},
@@ -402,7 +403,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:users:ab:cd:user-name"),
 			param:       "~",
-			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").WithHistory(homeWorkspace.String()).Build(),
 			destination: homeWorkspace.String(),
 			wantStdout:  []string{fmt.Sprintf("Current workspace is '%s'", homeWorkspace.String())},
 		},
@@ -414,7 +415,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:users:ab:cd:user-name:bar:baz"),
 			param:       "~/bar/baz",
-			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String() + ":bar:baz").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String() + ":bar:baz").WithKcpPrevious("root:foo").WithHistory(homeWorkspace.String() + ":bar:baz").Build(),
 			destination: homeWorkspace.String(),
 			wantStdout:  []string{fmt.Sprintf("Current workspace is '%s'", homeWorkspace.String()+":bar:baz")},
 		},
@@ -426,7 +427,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:users:ab:cd"),
 			param:       "~/..",
-			expected:    NewKubeconfig().WithKcpCurrent("root:users:ab:cd").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:users:ab:cd").WithKcpPrevious("root:foo").WithHistory("root:users:ab:cd").Build(),
 			destination: homeWorkspace.String(),
 			wantStdout:  []string{fmt.Sprintf("Current workspace is 'root:users:ab:cd'")},
 		},
@@ -438,7 +439,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:users:ab:cd:user-name"),
 			param:       "/home/sts",
-			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").WithHistory(homeWorkspace.String()).Build(),
 			destination: homeWorkspace.String(),
 			wantStdout:  []string{fmt.Sprintf("Current workspace is '%s'", homeWorkspace.String())},
 		},
@@ -450,7 +451,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:users:ab:cd:user-name"),
 			param:       "",
-			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").WithHistory(homeWorkspace.String()).Build(),
 			destination: homeWorkspace.String(),
 			wantStderr: []string{
 				"Note: 'kubectl ws' now matches 'cd' semantics: go to home workspace. 'kubectl ws -' to go back. 'kubectl ws .' to print current workspace.",
@@ -465,7 +466,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -483,7 +484,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -505,7 +506,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:users:ab:cd:user-name"),
 			param:       "~",
-			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent(homeWorkspace.String()).WithKcpPrevious("root:foo").WithHistory(homeWorkspace.String()).Build(),
 			destination: homeWorkspace.String(),
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -527,7 +528,7 @@ func TestUse(t *testing.T) {
 				logicalcluster.Name("root:foo"): {"bar"},
 			},
 			param:       "-",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -550,7 +551,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -570,7 +571,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -590,7 +591,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -613,7 +614,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -635,7 +636,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -659,7 +660,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -683,7 +684,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar"),
 			param:       "bar",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar").WithKcpPrevious("root:foo").WithHistory("root:foo:bar").Build(),
 			destination: "root:foo:bar",
 			apiBindings: []apisv1alpha1.APIBinding{
 				newBindingBuilder("a").
@@ -706,7 +707,7 @@ func TestUse(t *testing.T) {
 			},
 			discovery:   discoveryFor("root:foo:bar:baz"),
 			param:       "bar:baz",
-			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root:foo:bar:baz").WithKcpPrevious("root:foo").WithHistory("root:foo:bar:baz").Build(),
 			destination: "root:foo:bar:baz",
 			wantStdout: []string{
 				"Current workspace is 'root:foo:bar:baz'"},
@@ -718,7 +719,7 @@ func TestUse(t *testing.T) {
 				logicalcluster.Name("root:foo"): {"bar"},
 			},
 			param:       ":",
-			expected:    NewKubeconfig().WithKcpCurrent("root").WithKcpPrevious("root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("root").WithKcpPrevious("root:foo").WithHistory("root").Build(),
 			destination: "root",
 			wantStdout:  []string{"Current workspace is 'root'"},
 		},
@@ -729,7 +730,7 @@ func TestUse(t *testing.T) {
 				logicalcluster.Name("root:foo"): {"bar"},
 			},
 			param:       ":",
-			expected:    NewKubeconfig().WithKcpCurrent("my-root").WithKcpPrevious("my-root:foo").Build(),
+			expected:    NewKubeconfig().WithKcpCurrent("my-root").WithKcpPrevious("my-root:foo").WithHistory("my-root").Build(),
 			destination: "my-root",
 			wantStdout:  []string{"Current workspace is 'my-root'"},
 		},
@@ -867,6 +868,86 @@ func TestUse(t *testing.T) {
 	}
 }
 
+func TestRecordWorkspaceHistory(t *testing.T) {
+	config := NewKubeconfig().WithHistory("root:a", "root:b", "root:c").Build()
+
+	require.NoError(t, recordWorkspaceHistory(config, "https://test/clusters/root:b"))
+	history, err := readWorkspaceHistory(config)
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://test/clusters/root:b", "https://test/clusters/root:a", "https://test/clusters/root:c"}, history.URLs, "existing entry should move to the front")
+
+	require.NoError(t, recordWorkspaceHistory(config, "https://other/"))
+	history, err = readWorkspaceHistory(config)
+	require.NoError(t, err)
+	require.Len(t, history.URLs, 3, "URLs not pointing to a workspace should not be recorded")
+
+	for i := 0; i < 2*maxWorkspaceHistory; i++ {
+		require.NoError(t, recordWorkspaceHistory(config, fmt.Sprintf("https://test/clusters/root:ws%d", i)))
+	}
+	history, err = readWorkspaceHistory(config)
+	require.NoError(t, err)
+	require.Len(t, history.URLs, maxWorkspaceHistory)
+	require.Equal(t, fmt.Sprintf("https://test/clusters/root:ws%d", 2*maxWorkspaceHistory-1), history.URLs[0])
+}
+
+func TestUseRecent(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *clientcmdapi.Config
+		input    string
+		expected *clientcmdapi.Config
+		wantErr  string
+	}{
+		{
+			name:     "pick second",
+			config:   NewKubeconfig().WithKcpCurrent("root:a").WithHistory("root:a", "root:b", "root:c").Build(),
+			input:    "2\n",
+			expected: NewKubeconfig().WithKcpCurrent("root:c").WithKcpPrevious("root:a").WithHistory("root:c", "root:a", "root:b").Build(),
+		},
+		{
+			name:    "invalid choice",
+			config:  NewKubeconfig().WithKcpCurrent("root:a").WithHistory("root:a", "root:b").Build(),
+			input:   "3\n",
+			wantErr: "invalid selection",
+		},
+		{
+			name:    "no history",
+			config:  NewKubeconfig().WithKcpCurrent("root:a").WithHistory("root:a").Build(),
+			wantErr: "no recently used workspaces found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *clientcmdapi.Config
+			streams, stdin, _, stderr := genericclioptions.NewTestIOStreams()
+			stdin.WriteString(tt.input)
+			opts := NewUseWorkspaceOptions(streams)
+			opts.Recent = true
+			opts.modifyConfig = func(configAccess clientcmd.ConfigAccess, config *clientcmdapi.Config) error {
+				got = config
+				return nil
+			}
+			opts.getAPIBindings = func(ctx context.Context, kcpClusterClient kcpclientset.ClusterInterface, host string) ([]apisv1alpha1.APIBinding, error) {
+				return nil, nil
+			}
+			opts.kcpClusterClient = kcpfakeclient.NewSimpleClientset()
+			opts.ClientConfig = clientcmd.NewDefaultClientConfig(*tt.config.DeepCopy(), nil)
+			opts.startingConfig = tt.config
+
+			err := opts.Run(context.Background())
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, stderr.String(), " 1) root:b")
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("unexpected config, diff (expected, got): %s", cmp.Diff(tt.expected, got))
+			}
+		})
+	}
+}
+
 type kubeconfigBuilder struct {
 	config clientcmdapi.Config
 }
@@ -908,6 +989,21 @@ func (b *kubeconfigBuilder) WithKcpPrevious(pth string) *kubeconfigBuilder {
 	return b
 }
 
+func (b *kubeconfigBuilder) WithHistory(pths ...string) *kubeconfigBuilder {
+	urls := make([]string, 0, len(pths))
+	for _, pth := range pths {
+		urls = append(urls, "https://test/clusters/"+pth)
+	}
+	raw, err := json.Marshal(workspaceHistory{URLs: urls})
+	if err != nil {
+		panic(err)
+	}
+	b.config.Preferences.Extensions = map[string]runtime.Object{
+		kcpWorkspaceHistoryExtensionKey: &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON},
+	}
+	return b
+}
+
 func (b *kubeconfigBuilder) Build() *clientcmdapi.Config {
 	return &b.config
 }
@@ -370,3 +370,32 @@ func writeExternalLogicalClusterAdminKubeConfig(hostIP, workDirPath string) erro
 
 	return clientcmd.WriteToFile(kubeConfig, filepath.Join(workDirPath, ".kcp/external-logical-cluster-admin.kubeconfig"))
 }
+
+func writeServiceAccountTokenValidatorKubeConfig(hostIP, workDirPath string) error {
+	// The service accounts of other shards are looked up through the front-proxy
+	baseHost := fmt.Sprintf("https://%s", net.JoinHostPort(hostIP, "6443"))
+
+	var kubeConfig clientcmdapi.Config
+	kubeConfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
+		"serviceaccount-token-validator": {
+			ClientKey:         filepath.Join(workDirPath, ".kcp/serviceaccount-token-validator.key"),
+			ClientCertificate: filepath.Join(workDirPath, ".kcp/serviceaccount-token-validator.crt"),
+		},
+	}
+	kubeConfig.Clusters = map[string]*clientcmdapi.Cluster{
+		"base": {
+			Server:               baseHost,
+			CertificateAuthority: filepath.Join(workDirPath, ".kcp/serving-ca.crt"),
+		},
+	}
+	kubeConfig.Contexts = map[string]*clientcmdapi.Context{
+		"base": {Cluster: "base", AuthInfo: "serviceaccount-token-validator"},
+	}
+	kubeConfig.CurrentContext = "base"
+
+	if err := clientcmdapi.FlattenConfig(&kubeConfig); err != nil {
+		return err
+	}
+
+	return clientcmd.WriteToFile(kubeConfig, filepath.Join(workDirPath, ".kcp/serviceaccount-token-validator.kubeconfig"))
+}
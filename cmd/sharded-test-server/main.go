@@ -152,6 +152,20 @@ func start(proxyFlags, shardFlags []string, logDirPath, workDirPath string, numb
 		return fmt.Errorf("failed to create external-logical-cluster-admin client cert: %w", err)
 	}
 
+	// client cert for serviceaccount-token-validator
+	_, _, err = clientCA.EnsureClientCertificate(
+		filepath.Join(workDirPath, ".kcp/serviceaccount-token-validator.crt"),
+		filepath.Join(workDirPath, ".kcp/serviceaccount-token-validator.key"),
+		&kuser.DefaultInfo{
+			Name:   "serviceaccount-token-validator",
+			Groups: []string{bootstrap.SystemKcpServiceAccountTokenValidator},
+		},
+		365,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create serviceaccount-token-validator client cert: %w", err)
+	}
+
 	// TODO:(p0lyn0mial): in the future we need a separate group valid only for the proxy
 	// so that it can make wildcard requests against shards
 	// for now we will use the privileged system group to bypass the authz stack
@@ -220,6 +234,10 @@ func start(proxyFlags, shardFlags []string, logDirPath, workDirPath string, numb
 		return err
 	}
 
+	if err := writeServiceAccountTokenValidatorKubeConfig(hostIP.String(), workDirPath); err != nil {
+		return err
+	}
+
 	// start shards
 	var shards []*shard.Shard
 	for i := 0; i < numberOfShards; i++ {
@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"time"
 
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	kcpkubernetesclient "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/spf13/cobra"
@@ -100,6 +101,14 @@ func Run(ctx context.Context, o *options.Options) error {
 	if err != nil {
 		return err
 	}
+	cacheKubeClusterClient, err := kcpkubernetesclient.NewForConfig(cacheConfig)
+	if err != nil {
+		return err
+	}
+	cacheDynamicClient, err := kcpdynamic.NewForConfig(cacheConfig)
+	if err != nil {
+		return err
+	}
 
 	// Don't throttle
 	nonIdentityConfig.QPS = -1
@@ -142,6 +151,7 @@ func Run(ctx context.Context, o *options.Options) error {
 	}
 	wildcardKcpInformers := kcpinformers.NewSharedInformerFactory(kcpClusterClient, 10*time.Minute)
 	cacheKcpInformers := kcpinformers.NewSharedInformerFactory(cacheKcpClusterClient, 10*time.Minute)
+	cacheKubeInformers := kcpkubernetesinformers.NewSharedInformerFactory(cacheKubeClusterClient, 10*time.Minute)
 
 	if o.ProfilerAddress != "" {
 		//nolint:errcheck,gosec
@@ -178,7 +188,11 @@ func Run(ctx context.Context, o *options.Options) error {
 		return o.ShardExternalURL
 	}
 
-	rootAPIServerConfig.Extra.VirtualWorkspaces, err = o.CoreVirtualWorkspaces.NewVirtualWorkspaces(identityConfig, o.RootPathPrefix, sharedExternalURLGetter, wildcardKubeInformers, wildcardKcpInformers, cacheKcpInformers)
+	rootAPIServerConfig.Extra.VirtualWorkspaces, err = o.CoreVirtualWorkspaces.NewVirtualWorkspaces(identityConfig, o.RootPathPrefix, sharedExternalURLGetter, wildcardKubeInformers, cacheKubeInformers, wildcardKcpInformers, cacheKcpInformers, cacheDynamicClient)
+	if err != nil {
+		return err
+	}
+	rootAPIServerConfig.Extra.FlowControl, err = o.CoreVirtualWorkspaces.NewFlowControlConfig()
 	if err != nil {
 		return err
 	}
@@ -200,10 +214,12 @@ func Run(ctx context.Context, o *options.Options) error {
 	wildcardKubeInformers.Start(ctx.Done())
 	wildcardKcpInformers.Start(ctx.Done())
 	cacheKcpInformers.Start(ctx.Done())
+	cacheKubeInformers.Start(ctx.Done())
 
 	wildcardKubeInformers.WaitForCacheSync(ctx.Done())
 	wildcardKcpInformers.WaitForCacheSync(ctx.Done())
 	cacheKcpInformers.WaitForCacheSync(ctx.Done())
+	cacheKubeInformers.WaitForCacheSync(ctx.Done())
 
 	logger.Info("Starting virtual workspace apiserver on ", "externalAddress", rootAPIServerConfig.Generic.ExternalAddress, "version", version.Get().String())
 	return preparedRootAPIServer.Run(ctx.Done())
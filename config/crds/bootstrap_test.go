@@ -19,8 +19,12 @@ package crds
 import (
 	"context"
 	"embed"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	apiextensionsv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -57,3 +61,21 @@ func TestCreateFromFS(t *testing.T) {
 		})
 	}
 }
+
+func TestSDKValidationCRDsUpToDate(t *testing.T) {
+	files, err := filepath.Glob("*.kcp.io_*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	copies, err := filepath.Glob("../../sdk/apis/validation/crds/*.yaml")
+	require.NoError(t, err)
+	require.Len(t, copies, len(files), "run hack/update-codegen-crds.sh")
+
+	for _, f := range files {
+		want, err := os.ReadFile(f)
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join("../../sdk/apis/validation/crds", f))
+		require.NoError(t, err, "run hack/update-codegen-crds.sh")
+		require.Equal(t, string(want), string(got), "%s is outdated in the sdk, run hack/update-codegen-crds.sh", f)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
+)
+
+// LocalWorkspaceLabelKey is the label holding the shard name on the shard-local
+// workspaces in the root workspace.
+const LocalWorkspaceLabelKey = "core.kcp.io/shard-local"
+
+// LocalWorkspaceName returns the name of the shard-local workspace with the given name
+// of a shard, in the root workspace.
+func LocalWorkspaceName(name, shardName string) string {
+	return name + "-" + shardName
+}
+
+// BootstrapLocalWorkspaces creates the shard-local workspaces of the given shard in the
+// root workspace, scheduled onto the shard through their location. Existing workspaces
+// are left untouched, so this can be called every time a shard starts.
+func BootstrapLocalWorkspaces(ctx context.Context, rootKcpClient kcpclient.Interface, shardName string, names []string) error {
+	logger := klog.FromContext(ctx)
+	for _, name := range names {
+		ws := &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   LocalWorkspaceName(name, shardName),
+				Labels: map[string]string{LocalWorkspaceLabelKey: shardName},
+			},
+			Spec: tenancyv1alpha1.WorkspaceSpec{
+				Type: tenancyv1alpha1.WorkspaceTypeReference{
+					Name: "universal",
+					Path: "root",
+				},
+				Location: &tenancyv1alpha1.WorkspaceLocation{
+					// shards are labeled with their name on creation
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": shardName}},
+				},
+			},
+		}
+		_, err := rootKcpClient.TenancyV1alpha1().Workspaces().Create(ctx, ws, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to create shard-local workspace %q: %w", ws.Name, err)
+		}
+		logger.Info("Created shard-local workspace", "workspace", ws.Name)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/fake"
+)
+
+func TestBootstrapLocalWorkspaces(t *testing.T) {
+	existing := &tenancyv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-beta"},
+		Spec:       tenancyv1alpha1.WorkspaceSpec{Type: tenancyv1alpha1.WorkspaceTypeReference{Name: "custom"}},
+	}
+	client := kcpfakeclient.NewSimpleClientset(existing)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		err := BootstrapLocalWorkspaces(ctx, client, "beta", []string{"system", "compute"})
+		require.NoError(t, err)
+	}
+
+	ws, err := client.TenancyV1alpha1().Workspaces().Get(ctx, "compute-beta", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "beta", ws.Labels[LocalWorkspaceLabelKey])
+	require.Equal(t, map[string]string{"name": "beta"}, ws.Spec.Location.Selector.MatchLabels)
+
+	ws, err = client.TenancyV1alpha1().Workspaces().Get(ctx, "system-beta", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, existing, ws, "existing workspaces must not be changed")
+}
@@ -42,6 +42,7 @@ chmod +x "${CODEGEN_PKG}"/generate-internal-groups.sh
   github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1 \
+  github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2 \
   github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1 \
   k8s.io/apimachinery/pkg/apis/meta/v1 \
@@ -55,6 +56,7 @@ chmod +x "${CODEGEN_PKG}"/generate-internal-groups.sh
   --input github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1 \
   --input github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1 \
   --input github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1 \
+  --input github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2 \
   --input github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1 \
   --input-base="" \
   --apply-configuration-package=github.com/kcp-dev/kcp/sdk/client/applyconfiguration \
@@ -62,7 +64,7 @@ chmod +x "${CODEGEN_PKG}"/generate-internal-groups.sh
 
 bash "${CODEGEN_PKG}"/kube_codegen.sh "deepcopy" \
   github.com/kcp-dev/kcp/sdk/client github.com/kcp-dev/kcp/sdk/apis \
-  "core:v1alpha1 tenancy:v1alpha1 apis:v1alpha1 topology:v1alpha1" \
+  "core:v1alpha1 tenancy:v1alpha1 apis:v1alpha1,v1alpha2 topology:v1alpha1" \
   --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate/boilerplate.generatego.txt \
   --output-base "${SCRIPT_ROOT}" \
   --trim-path-prefix github.com/kcp-dev/kcp
@@ -125,6 +127,7 @@ go install "${OPENAPI_PKG}"/cmd/openapi-gen
   github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1 \
+  github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2 \
   github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1 \
   k8s.io/apimachinery/pkg/apis/meta/v1 \
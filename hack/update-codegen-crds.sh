@@ -49,6 +49,10 @@ done
   ${KCP_APIGEN_GEN} --input-dir "${REPO_ROOT}"/config/crds --output-dir "${REPO_ROOT}"/config/root-phase0
 )
 
+# Copy the kcp CRDs into the sdk for offline validation and defaulting.
+rm -f "${REPO_ROOT}"/sdk/apis/validation/crds/*.yaml
+cp "${REPO_ROOT}"/config/crds/*.kcp.io_*.yaml "${REPO_ROOT}"/sdk/apis/validation/crds/
+
 
 # Tests CRDs
 
@@ -22,13 +22,14 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/authentication/user"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
 	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
 	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
 	"github.com/kcp-dev/kcp/pkg/indexers"
@@ -49,12 +51,32 @@ const (
 	PluginName = "apis.kcp.io/APIBinding"
 )
 
+// Config configures the plugin in the --admission-control-config-file.
+type Config struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// AllowedExportPaths are the workspaces, including the workspaces below them, whose APIExports
+	// may be bound, in addition to the workspace of the APIBinding and root. If empty, APIExports
+	// of all workspaces may be bound.
+	AllowedExportPaths []string `json:"allowedExportPaths,omitempty"`
+}
+
 func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
-		func(_ io.Reader) (admission.Interface, error) {
+		func(config io.Reader) (admission.Interface, error) {
+			var c Config
+			if err := helpers.DecodeConfig(PluginName, config, &c); err != nil {
+				return nil, err
+			}
+			for _, path := range c.AllowedExportPaths {
+				if !logicalcluster.NewPath(path).IsValid() {
+					return nil, fmt.Errorf("invalid %s admission plugin configuration: allowedExportPaths must be workspace paths, got %q", PluginName, path)
+				}
+			}
 			p := &apiBindingAdmission{
-				Handler:          admission.NewHandler(admission.Create, admission.Update),
-				createAuthorizer: delegated.NewDelegatedAuthorizer,
+				Handler:            admission.NewHandler(admission.Create, admission.Update),
+				createAuthorizer:   delegated.NewDelegatedAuthorizer,
+				allowedExportPaths: c.AllowedExportPaths,
 			}
 			p.getAPIExport = func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 				return indexers.ByPathAndNameWithFallback[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), p.apiExportIndexer, p.cacheAPIExportIndexer, path, name)
@@ -74,6 +96,8 @@ type apiBindingAdmission struct {
 
 	deepSARClient    kcpkubernetesclientset.ClusterInterface
 	createAuthorizer delegated.DelegatedAuthorizerFactory
+
+	allowedExportPaths []string
 }
 
 // Ensure that the required admission interfaces are implemented.
@@ -101,7 +125,7 @@ func (o *apiBindingAdmission) Admit(ctx context.Context, a admission.Attributes,
 	}
 
 	apiBinding := &apisv1alpha1.APIBinding{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, apiBinding); err != nil {
+	if err := helpers.DecodeUnstructured(u, apiBinding); err != nil {
 		return fmt.Errorf("failed to convert unstructured to APIBinding: %w", err)
 	}
 
@@ -117,7 +141,7 @@ func (o *apiBindingAdmission) Admit(ctx context.Context, a admission.Attributes,
 		}
 
 		oldAPIBinding = &apisv1alpha1.APIBinding{}
-		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, oldAPIBinding); err != nil {
+		if err := helpers.DecodeUnstructured(u, oldAPIBinding); err != nil {
 			return fmt.Errorf("failed to convert unstructured to APIBinding: %w", err)
 		}
 	}
@@ -161,14 +185,8 @@ func (o *apiBindingAdmission) Admit(ctx context.Context, a admission.Attributes,
 		)
 	}
 
-	// write back
-	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(apiBinding)
-	if err != nil {
-		return err
-	}
-	u.Object = raw
-
-	return nil
+	// write back in the version of the request
+	return helpers.EncodeUnstructured(apiBinding, u)
 }
 
 // Validate validates the creation and updating of APIBinding resources. It also performs a SubjectAccessReview
@@ -189,7 +207,7 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 	}
 
 	apiBinding := &apisv1alpha1.APIBinding{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, apiBinding); err != nil {
+	if err := helpers.DecodeUnstructured(u, apiBinding); err != nil {
 		return fmt.Errorf("failed to convert unstructured to APIBinding: %w", err)
 	}
 
@@ -205,7 +223,7 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 			return fmt.Errorf("unexpected type %T", a.GetOldObject())
 		}
 		oldAPIBinding = &apisv1alpha1.APIBinding{}
-		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, oldAPIBinding); err != nil {
+		if err := helpers.DecodeUnstructured(u, oldAPIBinding); err != nil {
 			return fmt.Errorf("failed to convert unstructured to APIBinding: %w", err)
 		}
 
@@ -228,6 +246,10 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 		forbidden := admission.NewForbidden(a, fmt.Errorf("unable to %s APIBinding: no permission to bind to export %s", action,
 			logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path).Join(apiBinding.Spec.Reference.Export.Name).String()))
 
+		if !o.exportPathAllowed(apiBinding.Spec.Reference.Export.Path) {
+			return admission.NewForbidden(a, fmt.Errorf("unable to %s APIBinding: APIExports may only be bound from %s", action, strings.Join(o.allowedExportPaths, ", ")))
+		}
+
 		// get cluster name of export
 		var exportClusterName logicalcluster.Name
 		if apiBinding.Spec.Reference.Export.Path == "" {
@@ -262,6 +284,19 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 	return nil
 }
 
+// exportPathAllowed returns true if APIExports of the given workspace path may be bound.
+func (o *apiBindingAdmission) exportPathAllowed(path string) bool {
+	if len(o.allowedExportPaths) == 0 || path == "" || path == core.RootCluster.String() {
+		return true
+	}
+	for _, allowed := range o.allowedExportPaths {
+		if path == allowed || strings.HasPrefix(path, allowed+":") {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *apiBindingAdmission) checkAPIExportAccess(ctx context.Context, user user.Info, apiExportClusterName logicalcluster.Name, apiExportName string) error {
 	logger := klog.FromContext(ctx)
 	authz, err := o.createAuthorizer(apiExportClusterName, o.deepSARClient, delegated.Options{})
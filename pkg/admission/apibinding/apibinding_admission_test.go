@@ -205,11 +205,12 @@ func TestAdmit(t *testing.T) {
 
 func TestValidate(t *testing.T) {
 	tests := []struct {
-		name           string
-		attr           admission.Attributes
-		authzDecision  authorizer.Decision
-		authzError     error
-		expectedErrors []string
+		name               string
+		attr               admission.Attributes
+		authzDecision      authorizer.Decision
+		authzError         error
+		allowedExportPaths []string
+		expectedErrors     []string
 	}{
 		{
 			name: "Create: fails without reference",
@@ -257,6 +258,34 @@ func TestValidate(t *testing.T) {
 			),
 			authzDecision: authorizer.DecisionAllow,
 		},
+		{
+			name: "Create: reference below an allowed export path passes when authorized",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).APIBinding,
+			),
+			allowedExportPaths: []string{"root:providers", "root:org"},
+			authzDecision:      authorizer.DecisionAllow,
+		},
+		{
+			name: "Create: root reference passes outside of the allowed export paths",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root:someExport")).APIBinding,
+			),
+			allowedExportPaths: []string{"root:providers"},
+			authzDecision:      authorizer.DecisionAllow,
+		},
+		{
+			name: "Create: reference outside of the allowed export paths fails",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:aunt"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-aunt:someExport")).APIBinding,
+			),
+			allowedExportPaths: []string{"root:au", "root:providers"},
+			authzDecision:      authorizer.DecisionAllow,
+			expectedErrors:     []string{"APIExports may only be bound from root:au, root:providers"},
+		},
 		{
 			name: "Create: complete workspace reference fails with no authorization decision",
 			attr: createAttr(
@@ -440,6 +469,7 @@ func TestValidate(t *testing.T) {
 						tc.authzError,
 					}, nil
 				},
+				allowedExportPaths: tc.allowedExportPaths,
 				getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 					switch path.Join(name).String() {
 					case "root:org:workspaceName:someExport", "root-org-workspaceName:someExport":
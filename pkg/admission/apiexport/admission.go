@@ -22,10 +22,10 @@ import (
 	"io"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
 	builtinapiexport "github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas/builtin"
 	"github.com/kcp-dev/kcp/sdk/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
@@ -75,7 +75,7 @@ func (e *APIExportAdmission) Validate(ctx context.Context, a admission.Attribute
 		return fmt.Errorf("unexpected type %T", a.GetObject())
 	}
 	ae := &apisv1alpha1.APIExport{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ae); err != nil {
+	if err := helpers.DecodeUnstructured(u, ae); err != nil {
 		return fmt.Errorf("failed to convert unstructured to APIExport: %w", err)
 	}
 
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DecodeConfig decodes the configuration of an admission plugin, as passed from the
+// --admission-control-config-file, into the given struct. Without configuration, it is
+// left unchanged.
+func DecodeConfig(pluginName string, config io.Reader, into interface{}) error {
+	if config == nil {
+		return nil
+	}
+	data, err := io.ReadAll(config)
+	if err != nil {
+		return fmt.Errorf("failed to read %s admission plugin configuration: %w", pluginName, err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := yaml.UnmarshalStrict(data, into); err != nil {
+		return fmt.Errorf("failed to decode %s admission plugin configuration: %w", pluginName, err)
+	}
+	return nil
+}
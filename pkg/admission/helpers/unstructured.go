@@ -44,3 +44,60 @@ func ToUnstructuredOrDie(obj runtime.Object) *unstructured.Unstructured {
 
 	return u
 }
+
+// DecodeUnstructured decodes u into the typed object into. If u is of another version than
+// into, it is converted with the conversion functions registered in the kcp scheme.
+func DecodeUnstructured(u *unstructured.Unstructured, into runtime.Object) error {
+	gvk := u.GroupVersionKind()
+	kinds, _, err := kcpscheme.Scheme.ObjectKinds(into)
+	if err != nil {
+		return err
+	}
+	if gvk.Empty() || gvk == kinds[0] {
+		return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, into)
+	}
+
+	obj, err := kcpscheme.Scheme.New(gvk)
+	if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return err
+	}
+	return kcpscheme.Scheme.Convert(obj, into, nil)
+}
+
+// EncodeUnstructured writes the typed object obj into u, keeping the version of u. If obj
+// is of another version, it is converted with the conversion functions registered in the
+// kcp scheme.
+func EncodeUnstructured(obj runtime.Object, u *unstructured.Unstructured) error {
+	gvk := u.GroupVersionKind()
+	kinds, _, err := kcpscheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	if gvk.Empty() || gvk == kinds[0] {
+		raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		u.Object = raw
+		return nil
+	}
+
+	converted, err := kcpscheme.Scheme.New(gvk)
+	if err != nil {
+		return err
+	}
+	if err := kcpscheme.Scheme.Convert(obj, converted, nil); err != nil {
+		return err
+	}
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(converted)
+	if err != nil {
+		return err
+	}
+	u.Object = raw
+	u.SetGroupVersionKind(gvk)
+
+	return nil
+}
@@ -109,6 +109,7 @@ func (o *plugin) Admit(ctx context.Context, a admission.Attributes, _ admission.
 		}
 
 		logicalCluster.Status.Initializers = logicalCluster.Spec.Initializers
+		logicalCluster.Status.Terminators = logicalCluster.Spec.Terminators
 
 		return updateUnstructured(u, logicalCluster)
 	}
@@ -179,6 +180,27 @@ func (o *plugin) Validate(ctx context.Context, a admission.Attributes, _ admissi
 			}
 		}
 
+		oldTerminatorsSpec := toSet(old.Spec.Terminators)
+		newTerminatorsSpec := toSet(logicalCluster.Spec.Terminators)
+		oldTerminatorsStatus := toSet(old.Status.Terminators)
+		newTerminatorsStatus := toSet(logicalCluster.Status.Terminators)
+
+		if !oldTerminatorsSpec.Equal(newTerminatorsSpec) {
+			return admission.NewForbidden(a, fmt.Errorf("spec.terminators is immutable"))
+		}
+
+		if transitioningToInitializing && !newTerminatorsSpec.Equal(newTerminatorsStatus) {
+			return admission.NewForbidden(a, fmt.Errorf("status.terminators do not equal spec.terminators"))
+		}
+
+		if !transitioningToInitializing && !oldTerminatorsStatus.IsSuperset(newTerminatorsStatus) {
+			return admission.NewForbidden(a, fmt.Errorf("status.terminators must not grow"))
+		}
+
+		if old.DeletionTimestamp.IsZero() && !oldTerminatorsStatus.Equal(newTerminatorsStatus) && !transitioningToInitializing {
+			return admission.NewForbidden(a, fmt.Errorf("status.terminators can only be removed after deletion started"))
+		}
+
 		if phaseOrdinal[old.Status.Phase] > phaseOrdinal[logicalCluster.Status.Phase] {
 			return admission.NewForbidden(a, fmt.Errorf("cannot transition from %q to %q", old.Status.Phase, logicalCluster.Status.Phase))
 		}
@@ -216,10 +238,10 @@ func (o *plugin) SetKcpInformers(local, global kcpinformers.SharedInformerFactor
 	o.logicalClusterLister = local.Core().V1alpha1().LogicalClusters().Lister()
 }
 
-func toSet(initializers []corev1alpha1.LogicalClusterInitializer) sets.Set[string] {
+func toSet[T ~string](items []T) sets.Set[string] {
 	ret := sets.New[string]()
-	for _, initializer := range initializers {
-		ret.Insert(string(initializer))
+	for _, item := range items {
+		ret.Insert(string(item))
 	}
 	return ret
 }
@@ -99,6 +99,22 @@ func TestAdmit(t *testing.T) {
 				Initializers: []corev1alpha1.LogicalClusterInitializer{"a", "b"},
 			}).LogicalCluster,
 		},
+		{
+			name:        "adds terminators during transition to initializing",
+			clusterName: "root:org:ws",
+			a: updateAttr(
+				newLogicalCluster("root:org:ws:test").withType("root:org", "foo").withTerminators("a").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseInitializing,
+				}).LogicalCluster,
+				newLogicalCluster("root:org:ws:test").withType("root:org", "foo").withTerminators("a").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseScheduling,
+				}).LogicalCluster,
+			),
+			expectedObj: newLogicalCluster("root:org:ws:test").withType("root:org", "foo").withTerminators("a").withStatus(corev1alpha1.LogicalClusterStatus{
+				Phase:       corev1alpha1.LogicalClusterPhaseInitializing,
+				Terminators: []corev1alpha1.LogicalClusterTerminator{"a"},
+			}).LogicalCluster,
+		},
 		{
 			name:        "does not add initializer during transition to initializing when spec has none",
 			clusterName: "root:org:ws",
@@ -301,6 +317,76 @@ func TestValidate(t *testing.T) {
 				}).LogicalCluster,
 			),
 		},
+		{
+			name:        "fails if spec.terminators is changed",
+			clusterName: "root:org:ws",
+			attr: updateAttr(
+				newLogicalCluster("root:org:ws").withTerminators("a", "b").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+				}).LogicalCluster,
+				newLogicalCluster("root:org:ws").withTerminators("a").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+				}).LogicalCluster,
+			),
+			wantErr: "spec.terminators is immutable",
+		},
+		{
+			name:        "spec and status terminators must match when switching to initializing",
+			clusterName: "root:org:ws",
+			attr: updateAttr(
+				newLogicalCluster("root:org:ws").withTerminators("a").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseInitializing,
+				}).LogicalCluster,
+				newLogicalCluster("root:org:ws").withTerminators("a").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseScheduling,
+				}).LogicalCluster,
+			),
+			wantErr: "status.terminators do not equal spec.terminators",
+		},
+		{
+			name:        "fails if status.terminators is growing",
+			clusterName: "root:org:ws",
+			attr: updateAttr(
+				newLogicalCluster("root:org:ws").deleting().withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase:       corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{"a", "b"},
+				}).LogicalCluster,
+				newLogicalCluster("root:org:ws").deleting().withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase:       corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{"a"},
+				}).LogicalCluster,
+			),
+			wantErr: "status.terminators must not grow",
+		},
+		{
+			name:        "fails if status.terminators is shrinking before deletion",
+			clusterName: "root:org:ws",
+			attr: updateAttr(
+				newLogicalCluster("root:org:ws").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase:       corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{"a"},
+				}).LogicalCluster,
+				newLogicalCluster("root:org:ws").withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase:       corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{"a", "b"},
+				}).LogicalCluster,
+			),
+			wantErr: "status.terminators can only be removed after deletion started",
+		},
+		{
+			name:        "passes if status.terminators is shrinking when deleting",
+			clusterName: "root:org:ws",
+			attr: updateAttr(
+				newLogicalCluster("root:org:ws").deleting().withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase:       corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{"a"},
+				}).LogicalCluster,
+				newLogicalCluster("root:org:ws").deleting().withStatus(corev1alpha1.LogicalClusterStatus{
+					Phase:       corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{"a", "b"},
+				}).LogicalCluster,
+			),
+		},
 		{
 			name:        "fails to move phase backwards",
 			clusterName: "root:org:ws",
@@ -421,6 +507,17 @@ func (b thisWsBuilder) withInitializers(initializers ...corev1alpha1.LogicalClus
 	return b
 }
 
+func (b thisWsBuilder) withTerminators(terminators ...corev1alpha1.LogicalClusterTerminator) thisWsBuilder {
+	b.Spec.Terminators = terminators
+	return b
+}
+
+func (b thisWsBuilder) deleting() thisWsBuilder {
+	now := metav1.Now()
+	b.DeletionTimestamp = &now
+	return b
+}
+
 func (b thisWsBuilder) directlyDeletable() thisWsBuilder {
 	b.Spec.DirectlyDeletable = true
 	return b
@@ -100,30 +100,11 @@ func (p *Plugin) Admit(ctx context.Context, attr admission.Attributes, o admissi
 	}
 	clusterName := cluster.Name
 
-	var config io.Reader
-	if len(p.config) > 0 {
-		config = bytes.NewReader(p.config)
-	}
-
-	hookSource, err := p.getHookSource(clusterName, attr.GetResource().GroupResource())
+	sourceClusters, err := p.getSourceClustersForGroupResource(clusterName, attr.GetResource().GroupResource())
 	if err != nil {
 		return err
 	}
 
-	plugin, err := mutating.NewMutatingWebhook(config)
-	if err != nil {
-		return fmt.Errorf("error creating mutating admission webhook: %w", err)
-	}
-
-	plugin.SetExternalKubeClientSet(p.kubeClusterClient.Cluster(clusterName.Path()))
-	plugin.SetNamespaceInformer(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
-	plugin.SetHookSource(hookSource)
-	plugin.SetReadyFuncFromKCP(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
-
-	if err := plugin.ValidateInitialization(); err != nil {
-		return fmt.Errorf("error validating MutatingWebhook initialization: %w", err)
-	}
-
 	// Add cluster annotation on create
 	if attr.GetOperation() == admission.Create {
 		u, ok := attr.GetObject().(metav1.Object)
@@ -135,43 +116,73 @@ func (p *Plugin) Admit(ctx context.Context, attr admission.Attributes, o admissi
 		}
 	}
 
-	return plugin.Admit(ctx, attr, o)
-}
+	for _, sourceCluster := range sourceClusters {
+		var config io.Reader
+		if len(p.config) > 0 {
+			config = bytes.NewReader(p.config)
+		}
 
-func (p *Plugin) getHookSource(clusterName logicalcluster.Name, groupResource schema.GroupResource) (generic.Source, error) {
-	clusterNameForGroupResource, err := p.getSourceClusterForGroupResource(clusterName, groupResource)
-	if err != nil {
-		return nil, err
+		plugin, err := mutating.NewMutatingWebhook(config)
+		if err != nil {
+			return fmt.Errorf("error creating mutating admission webhook: %w", err)
+		}
+
+		plugin.SetExternalKubeClientSet(p.kubeClusterClient.Cluster(clusterName.Path()))
+		plugin.SetNamespaceInformer(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
+		plugin.SetHookSource(p.getHookSource(sourceCluster))
+		plugin.SetServiceResolver(validatingwebhook.NewWorkspaceServiceResolver(p.kubeClusterClient, sourceCluster))
+		plugin.SetAuthenticationInfoResolverWrapper(validatingwebhook.RestrictServiceDialing)
+		plugin.SetReadyFuncFromKCP(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
+
+		if err := plugin.ValidateInitialization(); err != nil {
+			return fmt.Errorf("error validating MutatingWebhook initialization: %w", err)
+		}
+
+		if err := plugin.Admit(ctx, attr, o); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+func (p *Plugin) getHookSource(sourceCluster logicalcluster.Name) generic.Source {
 	p.managerLock.Lock()
 	defer p.managerLock.Unlock()
-	if _, ok := p.managersCache[clusterNameForGroupResource]; !ok {
-		p.managersCache[clusterNameForGroupResource] = configuration.NewMutatingWebhookConfigurationManagerForInformer(
-			p.globalKubeSharedInformerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Cluster(clusterNameForGroupResource),
+	if _, ok := p.managersCache[sourceCluster]; !ok {
+		p.managersCache[sourceCluster] = configuration.NewMutatingWebhookConfigurationManagerForInformer(
+			p.globalKubeSharedInformerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Cluster(sourceCluster),
 		)
 	}
 
-	return p.managersCache[clusterNameForGroupResource], nil
+	return p.managersCache[sourceCluster]
 }
 
-func (p *Plugin) getSourceClusterForGroupResource(clusterName logicalcluster.Name, groupResource schema.GroupResource) (logicalcluster.Name, error) {
+// getSourceClustersForGroupResource returns the logical clusters whose webhook configurations
+// apply to the given resource, in the order they are called: the APIExport cluster if the
+// resource is bound, such that the workspace-local webhooks see the object as defaulted by the
+// API provider, and the cluster of the request itself.
+func (p *Plugin) getSourceClustersForGroupResource(clusterName logicalcluster.Name, groupResource schema.GroupResource) ([]logicalcluster.Name, error) {
 	objs, err := p.getAPIBindings(clusterName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	for _, apiBinding := range objs {
 		for _, br := range apiBinding.Status.BoundResources {
 			if br.Group == groupResource.Group && br.Resource == groupResource.Resource {
 				// GroupResource comes from an APIBinding/APIExport
-				return logicalcluster.Name(apiBinding.Status.APIExportClusterName), nil
+				exportClusterName := logicalcluster.Name(apiBinding.Status.APIExportClusterName)
+				if exportClusterName == clusterName {
+					return []logicalcluster.Name{clusterName}, nil
+				}
+				return []logicalcluster.Name{exportClusterName, clusterName}, nil
 			}
 		}
 	}
 
 	// GroupResource is local to this cluster
-	return clusterName, nil
+	return []logicalcluster.Name{clusterName}, nil
 }
 
 func (p *Plugin) ValidateInitialization() error {
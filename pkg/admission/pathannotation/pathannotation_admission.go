@@ -70,6 +70,9 @@ type pathAnnotationPlugin struct {
 var pathAnnotationResources = sets.New[string](
 	apisv1alpha1.Resource("apiexports").String(),
 	tenancyv1alpha1.Resource("workspacetypes").String(),
+	tenancyv1alpha1.Resource("workspacequotas").String(),
+	tenancyv1alpha1.Resource("auditsinks").String(),
+	tenancyv1alpha1.Resource("referencegrants").String(),
 )
 
 // Ensure that the required admission interfaces are implemented.
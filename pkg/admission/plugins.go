@@ -54,6 +54,7 @@ import (
 	workspacenamespacelifecycle "github.com/kcp-dev/kcp/pkg/admission/namespacelifecycle"
 	"github.com/kcp-dev/kcp/pkg/admission/pathannotation"
 	"github.com/kcp-dev/kcp/pkg/admission/permissionclaims"
+	"github.com/kcp-dev/kcp/pkg/admission/referencegrant"
 	"github.com/kcp-dev/kcp/pkg/admission/reservedcrdannotations"
 	"github.com/kcp-dev/kcp/pkg/admission/reservedcrdgroups"
 	"github.com/kcp-dev/kcp/pkg/admission/reservedmetadata"
@@ -62,8 +63,11 @@ import (
 	kcpvalidatingadmissionpolicy "github.com/kcp-dev/kcp/pkg/admission/validatingadmissionpolicy"
 	kcpvalidatingwebhook "github.com/kcp-dev/kcp/pkg/admission/validatingwebhook"
 	"github.com/kcp-dev/kcp/pkg/admission/workspace"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacequota"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacerolebinding"
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetype"
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypelimits"
 )
 
 // AllOrderedPlugins is the list of all the plugins in order.
@@ -75,6 +79,10 @@ var AllOrderedPlugins = beforeWebhooks(kubeapiserveroptions.AllOrderedPlugins,
 	shard.PluginName,
 	workspacetype.PluginName,
 	workspacetypeexists.PluginName,
+	workspacetypelimits.PluginName,
+	workspacequota.PluginName,
+	referencegrant.PluginName,
+	workspacerolebinding.PluginName,
 	logicalcluster.PluginName,
 	apiexport.PluginName,
 	apibinding.PluginName,
@@ -112,6 +120,10 @@ func RegisterAllKcpAdmissionPlugins(plugins *admission.Plugins) {
 	shard.Register(plugins)
 	workspacetype.Register(plugins)
 	workspacetypeexists.Register(plugins)
+	workspacetypelimits.Register(plugins)
+	workspacequota.Register(plugins)
+	referencegrant.Register(plugins)
+	workspacerolebinding.Register(plugins)
 	logicalcluster.Register(plugins)
 	apiresourceschema.Register(plugins)
 	apiexport.Register(plugins)
@@ -144,6 +156,10 @@ var defaultOnPluginsInKcp = sets.New[string](
 	shard.PluginName,
 	workspacetype.PluginName,
 	workspacetypeexists.PluginName,
+	workspacetypelimits.PluginName,
+	workspacequota.PluginName,
+	referencegrant.PluginName,
+	workspacerolebinding.PluginName,
 	logicalcluster.PluginName,
 	apiresourceschema.PluginName,
 	apiexport.PluginName,
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package referencegrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+const (
+	PluginName = "tenancy.kcp.io/ReferenceGrant"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			plugin := &referenceGrant{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}
+			plugin.getGrants = func(path logicalcluster.Path) ([]*tenancyv1alpha1.ReferenceGrant, error) {
+				return indexers.ByIndexWithFallback[*tenancyv1alpha1.ReferenceGrant](plugin.grantIndexer, plugin.globalGrantIndexer, indexers.ByLogicalClusterPath, path.String())
+			}
+			return plugin, nil
+		})
+}
+
+// referenceGrant rejects objects of any resource referencing objects in other
+// workspaces through the references annotation, unless the referenced workspace
+// has a ReferenceGrant allowing the reference.
+type referenceGrant struct {
+	*admission.Handler
+
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getGrants         func(path logicalcluster.Path) ([]*tenancyv1alpha1.ReferenceGrant, error)
+
+	grantIndexer       cache.Indexer
+	globalGrantIndexer cache.Indexer
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&referenceGrant{})
+	_ = admission.InitializationValidator(&referenceGrant{})
+	_ = kcpinitializers.WantsKcpInformers(&referenceGrant{})
+)
+
+// Validate rejects objects with references that are not granted.
+func (o *referenceGrant) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetSubresource() != "" || a.GetObject() == nil {
+		return nil
+	}
+	obj, err := meta.Accessor(a.GetObject())
+	//nolint:nilerr
+	if err != nil {
+		// the object has no metadata, hence no references.
+		return nil
+	}
+	value := obj.GetAnnotations()[tenancyv1alpha1.ExperimentalReferencesAnnotationKey]
+	if value == "" {
+		return nil
+	}
+	if a.GetOperation() == admission.Update && a.GetOldObject() != nil {
+		if old, err := meta.Accessor(a.GetOldObject()); err == nil && old.GetAnnotations()[tenancyv1alpha1.ExperimentalReferencesAnnotationKey] == value {
+			return nil
+		}
+	}
+
+	var refs []tenancyv1alpha1.WorkspaceObjectReference
+	if err := json.Unmarshal([]byte(value), &refs); err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("invalid %s annotation: %w", tenancyv1alpha1.ExperimentalReferencesAnnotationKey, err))
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	if !o.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	path := clusterName.Path()
+	if logicalCluster, err := o.getLogicalCluster(clusterName); err != nil && !apierrors.IsNotFound(err) {
+		return apierrors.NewInternalError(err)
+	} else if err == nil {
+		if p := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; p != "" {
+			path = logicalcluster.NewPath(p)
+		}
+	}
+
+	for i, ref := range refs {
+		refPath := logicalcluster.NewPath(ref.Path)
+		if !refPath.IsValid() || ref.Resource == "" || ref.Name == "" {
+			return admission.NewForbidden(a, fmt.Errorf("%s annotation: reference %d must have a valid path, a resource and a name", tenancyv1alpha1.ExperimentalReferencesAnnotationKey, i))
+		}
+		if refPath.Equal(path) {
+			continue
+		}
+		grants, err := o.getGrants(refPath)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if !Allowed(grants, path, a.GetResource().GroupResource(), ref) {
+			return admission.NewForbidden(a, fmt.Errorf("reference to %s %s in workspace %s is not allowed by a ReferenceGrant", ref.GroupResource, qualifiedName(ref.Namespace, ref.Name), ref.Path))
+		}
+	}
+
+	return nil
+}
+
+// Allowed returns whether one of the given grants allows objects of the given resource in
+// the given workspace to reference the given object.
+func Allowed(grants []*tenancyv1alpha1.ReferenceGrant, from logicalcluster.Path, fromResource schema.GroupResource, ref tenancyv1alpha1.WorkspaceObjectReference) bool {
+	for _, grant := range grants {
+		fromMatches := false
+		for _, f := range grant.Spec.From {
+			if logicalcluster.NewPath(f.Path).Equal(from) && f.Group == fromResource.Group && f.Resource == fromResource.Resource {
+				fromMatches = true
+				break
+			}
+		}
+		if !fromMatches {
+			continue
+		}
+		for _, t := range grant.Spec.To {
+			if t.GroupResource == ref.GroupResource && (t.Name == "" || t.Name == ref.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func qualifiedName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func (o *referenceGrant) ValidateInitialization() error {
+	if o.getLogicalCluster == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	if o.grantIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a ReferenceGrant indexer")
+	}
+	if o.globalGrantIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a global ReferenceGrant indexer")
+	}
+	return nil
+}
+
+func (o *referenceGrant) SetKcpInformers(local, global kcpinformers.SharedInformerFactory) {
+	localGrantsReady := local.Tenancy().V1alpha1().ReferenceGrants().Informer().HasSynced
+	globalGrantsReady := global.Tenancy().V1alpha1().ReferenceGrants().Informer().HasSynced
+	logicalClustersReady := local.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return localGrantsReady() && globalGrantsReady() && logicalClustersReady()
+	})
+
+	logicalClusterLister := local.Core().V1alpha1().LogicalClusters().Lister()
+	o.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	}
+
+	o.grantIndexer = local.Tenancy().V1alpha1().ReferenceGrants().Informer().GetIndexer()
+	o.globalGrantIndexer = global.Tenancy().V1alpha1().ReferenceGrants().Informer().GetIndexer()
+
+	indexers.AddIfNotPresentOrDie(o.grantIndexer, cache.Indexers{
+		indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+	})
+	indexers.AddIfNotPresentOrDie(o.globalGrantIndexer, cache.Indexers{
+		indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+	})
+}
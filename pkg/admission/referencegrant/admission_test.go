@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package referencegrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func newConfigMap(references string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	if references != "" {
+		cm.Annotations = map[string]string{tenancyv1alpha1.ExperimentalReferencesAnnotationKey: references}
+	}
+	return cm
+}
+
+func attr(op admission.Operation, obj, old runtime.Object) admission.Attributes {
+	return admission.NewAttributesRecord(
+		obj,
+		old,
+		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		"default",
+		"test",
+		corev1.SchemeGroupVersion.WithResource("configmaps"),
+		"",
+		op,
+		nil,
+		false,
+		nil,
+	)
+}
+
+func newGrant(from tenancyv1alpha1.ReferenceGrantFrom, to tenancyv1alpha1.ReferenceGrantTo) *tenancyv1alpha1.ReferenceGrant {
+	return &tenancyv1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+		Spec: tenancyv1alpha1.ReferenceGrantSpec{
+			From: []tenancyv1alpha1.ReferenceGrantFrom{from},
+			To:   []tenancyv1alpha1.ReferenceGrantTo{to},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secretRef := `[{"path":"root:org:shared","resource":"secrets","namespace":"default","name":"creds"}]`
+	fromTeam := tenancyv1alpha1.ReferenceGrantFrom{Path: "root:org:team", GroupResource: tenancyv1alpha1.GroupResource{Resource: "configmaps"}}
+	toSecrets := tenancyv1alpha1.ReferenceGrantTo{GroupResource: tenancyv1alpha1.GroupResource{Resource: "secrets"}}
+
+	tests := []struct {
+		name   string
+		a      admission.Attributes
+		grants map[string][]*tenancyv1alpha1.ReferenceGrant
+
+		wantErr string
+	}{
+		{
+			name: "no references",
+			a:    attr(admission.Create, newConfigMap(""), nil),
+		},
+		{
+			name:    "reference without grant",
+			a:       attr(admission.Create, newConfigMap(secretRef), nil),
+			wantErr: "reference to secrets default/creds in workspace root:org:shared is not allowed by a ReferenceGrant",
+		},
+		{
+			name:   "granted reference",
+			a:      attr(admission.Create, newConfigMap(secretRef), nil),
+			grants: map[string][]*tenancyv1alpha1.ReferenceGrant{"root:org:shared": {newGrant(fromTeam, toSecrets)}},
+		},
+		{
+			name: "grant for another workspace",
+			a:    attr(admission.Create, newConfigMap(secretRef), nil),
+			grants: map[string][]*tenancyv1alpha1.ReferenceGrant{"root:org:shared": {newGrant(
+				tenancyv1alpha1.ReferenceGrantFrom{Path: "root:org:other", GroupResource: tenancyv1alpha1.GroupResource{Resource: "configmaps"}},
+				toSecrets,
+			)}},
+			wantErr: "is not allowed by a ReferenceGrant",
+		},
+		{
+			name: "grant for another name",
+			a:    attr(admission.Create, newConfigMap(secretRef), nil),
+			grants: map[string][]*tenancyv1alpha1.ReferenceGrant{"root:org:shared": {newGrant(
+				fromTeam,
+				tenancyv1alpha1.ReferenceGrantTo{GroupResource: tenancyv1alpha1.GroupResource{Resource: "secrets"}, Name: "other"},
+			)}},
+			wantErr: "is not allowed by a ReferenceGrant",
+		},
+		{
+			name: "reference in the same workspace",
+			a:    attr(admission.Create, newConfigMap(`[{"path":"root:org:team","resource":"secrets","name":"creds"}]`), nil),
+		},
+		{
+			name: "unchanged references on update",
+			a:    attr(admission.Update, newConfigMap(secretRef), newConfigMap(secretRef)),
+		},
+		{
+			name:    "changed references on update",
+			a:       attr(admission.Update, newConfigMap(secretRef), newConfigMap("")),
+			wantErr: "is not allowed by a ReferenceGrant",
+		},
+		{
+			name:    "invalid annotation",
+			a:       attr(admission.Create, newConfigMap(`{}`), nil),
+			wantErr: "invalid experimental.tenancy.kcp.io/references annotation",
+		},
+		{
+			name:    "reference without name",
+			a:       attr(admission.Create, newConfigMap(`[{"path":"root:org:shared","resource":"secrets"}]`), nil),
+			wantErr: "reference 0 must have a valid path, a resource and a name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &referenceGrant{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        corev1alpha1.LogicalClusterName,
+							Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: "root:org:team"},
+						},
+					}, nil
+				},
+				getGrants: func(path logicalcluster.Path) ([]*tenancyv1alpha1.ReferenceGrant, error) {
+					return tt.grants[path.String()], nil
+				},
+			}
+			o.SetReadyFunc(func() bool { return true })
+
+			ctx := genericapirequest.WithCluster(context.Background(), genericapirequest.Cluster{Name: "team"})
+			err := o.Validate(ctx, tt.a, nil)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+			}
+		})
+	}
+}
@@ -20,11 +20,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
 
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 )
 
@@ -34,17 +37,32 @@ const (
 	PluginName = "tenancy.kcp.io/Shard"
 )
 
+// Config configures the plugin in the --admission-control-config-file.
+type Config struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// RequireHTTPS rejects shards whose URLs are not https.
+	RequireHTTPS bool `json:"requireHTTPS,omitempty"`
+}
+
 func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
-		func(_ io.Reader) (admission.Interface, error) {
+		func(config io.Reader) (admission.Interface, error) {
+			var c Config
+			if err := helpers.DecodeConfig(PluginName, config, &c); err != nil {
+				return nil, err
+			}
 			return &shard{
 				Handler: admission.NewHandler(admission.Create, admission.Update),
+				config:  c,
 			}, nil
 		})
 }
 
 type shard struct {
 	*admission.Handler
+
+	config Config
 }
 
 // Ensure that the required admission interfaces are implemented.
@@ -73,6 +91,18 @@ func (o *shard) Admit(_ context.Context, a admission.Attributes, _ admission.Obj
 		wShard.Spec.VirtualWorkspaceURL = wShard.Spec.BaseURL
 	}
 
+	if o.config.RequireHTTPS {
+		for _, f := range []struct{ path, value string }{
+			{"spec.baseURL", wShard.Spec.BaseURL},
+			{"spec.externalURL", wShard.Spec.ExternalURL},
+			{"spec.virtualWorkspaceURL", wShard.Spec.VirtualWorkspaceURL},
+		} {
+			if u, err := url.Parse(f.value); err != nil || u.Scheme != "https" {
+				return admission.NewForbidden(a, fmt.Errorf("%s must be an https URL, got %q", f.path, f.value))
+			}
+		}
+	}
+
 	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(wShard)
 	if err != nil {
 		return err
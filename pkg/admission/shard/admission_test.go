@@ -18,6 +18,7 @@ package shard
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -173,3 +174,21 @@ func TestAdmit(t *testing.T) {
 		}
 	}
 }
+
+func TestAdmitRequireHTTPS(t *testing.T) {
+	plugins := admission.NewPlugins()
+	Register(plugins)
+	plugin, err := plugins.InitPlugin(PluginName, strings.NewReader("apiVersion: v1\nkind: ShardConfiguration\nrequireHTTPS: true\n"), admission.PluginInitializers{})
+	require.NoError(t, err)
+	o := plugin.(admission.MutationInterface)
+
+	ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+	err = o.Admit(ctx, createAttr(newShard().baseURL("https://base").externalURL("https://external").Shard), nil)
+	require.NoError(t, err)
+
+	err = o.Admit(ctx, createAttr(newShard().baseURL("https://base").externalURL("http://external").Shard), nil)
+	require.ErrorContains(t, err, `spec.externalURL must be an https URL, got "http://external"`)
+
+	_, err = plugins.InitPlugin(PluginName, strings.NewReader("requireHTTP: true\n"), admission.PluginInitializers{})
+	require.ErrorContains(t, err, "failed to decode")
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatingadmissionpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/generic"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	// InheritLabelKey on a ValidatingAdmissionPolicyBinding with value "true" makes the binding
+	// apply to all descendant workspaces of its workspace as well.
+	InheritLabelKey = "admission.kcp.io/inherit"
+
+	// maxInheritanceDepth bounds the walk up the workspace hierarchy.
+	maxInheritanceDepth = 32
+)
+
+// inheritingPolicySource adds the hooks of the inheritable bindings of the ancestor workspaces
+// to the hooks of a logical cluster.
+type inheritingPolicySource struct {
+	generic.Source[validating.PolicyHook]
+
+	inherited func() []generic.Source[validating.PolicyHook]
+}
+
+func (s *inheritingPolicySource) Hooks() []validating.PolicyHook {
+	hooks := s.Source.Hooks()
+	for _, source := range s.inherited() {
+		hooks = append(hooks, source.Hooks()...)
+	}
+	return hooks
+}
+
+func (s *inheritingPolicySource) HasSynced() bool {
+	if !s.Source.HasSynced() {
+		return false
+	}
+	for _, source := range s.inherited() {
+		if !source.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// inheritableBindingsSource only returns the bindings carrying the inherit label.
+type inheritableBindingsSource struct {
+	generic.Source[validating.PolicyHook]
+	stop func()
+}
+
+func (s *inheritableBindingsSource) Hooks() []validating.PolicyHook {
+	var hooks []validating.PolicyHook
+	for _, hook := range s.Source.Hooks() {
+		var bindings []*validating.PolicyBinding
+		for _, binding := range hook.Bindings {
+			if binding.Labels[InheritLabelKey] == "true" {
+				bindings = append(bindings, binding)
+			}
+		}
+		if len(bindings) == 0 {
+			continue
+		}
+		hook.Bindings = bindings
+		hooks = append(hooks, hook)
+	}
+	return hooks
+}
+
+// ancestors returns the logical clusters of the parent workspaces of the given logical cluster,
+// starting with the direct parent. Parents that are not known yet end the walk.
+func (k *KubeValidatingAdmissionPolicy) ancestors(clusterName logicalcluster.Name) []logicalcluster.Name {
+	var ancestors []logicalcluster.Name
+	for range maxInheritanceDepth {
+		logicalCluster, err := k.getLogicalCluster(clusterName)
+		if err != nil || logicalCluster.Spec.Owner == nil || logicalCluster.Spec.Owner.Cluster == "" {
+			break
+		}
+		clusterName = logicalcluster.Name(logicalCluster.Spec.Owner.Cluster)
+		ancestors = append(ancestors, clusterName)
+	}
+	return ancestors
+}
+
+// getInheritedSources returns the sources of the inheritable bindings of the ancestors of the
+// given logical cluster, starting them on first use.
+func (k *KubeValidatingAdmissionPolicy) getInheritedSources(clusterName logicalcluster.Name) []generic.Source[validating.PolicyHook] {
+	ancestors := k.ancestors(clusterName)
+	if len(ancestors) == 0 {
+		return nil
+	}
+
+	sources := make([]generic.Source[validating.PolicyHook], 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		k.inheritedLock.RLock()
+		source, ok := k.inherited[ancestor]
+		k.inheritedLock.RUnlock()
+		if !ok {
+			k.inheritedLock.Lock()
+			if source, ok = k.inherited[ancestor]; !ok {
+				source = k.newInheritableBindingsSource(ancestor)
+				k.inherited[ancestor] = source
+			}
+			k.inheritedLock.Unlock()
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+func (k *KubeValidatingAdmissionPolicy) newInheritableBindingsSource(clusterName logicalcluster.Name) *inheritableBindingsSource {
+	// Set up a context that is cancelable and that is bounded by k.serverDone
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-k.serverDone:
+			cancel()
+		}
+	}()
+
+	discoveryClient := memory.NewMemCacheClient(k.kubeClusterClient.Cluster(clusterName.Path()).Discovery())
+	source := &inheritableBindingsSource{
+		Source: k.newPolicySource(clusterName, restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)),
+		stop:   cancel,
+	}
+	go func() {
+		if err := source.Run(ctx); err != nil {
+			utilruntime.HandleError(fmt.Errorf("inherited policy source of logical cluster %s stopped: %w", clusterName, err))
+		}
+	}()
+	return source
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatingadmissionpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/generic"
+	"k8s.io/apiserver/pkg/admission/plugin/policy/validating"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+type fakeSource struct {
+	hooks  []validating.PolicyHook
+	synced bool
+}
+
+func (s *fakeSource) Hooks() []validating.PolicyHook { return s.hooks }
+func (s *fakeSource) Run(ctx context.Context) error  { return nil }
+func (s *fakeSource) HasSynced() bool                { return s.synced }
+
+func binding(name string, inherit bool) *admissionregistrationv1.ValidatingAdmissionPolicyBinding {
+	b := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if inherit {
+		b.Labels = map[string]string{InheritLabelKey: "true"}
+	}
+	return b
+}
+
+func policy(name string) *admissionregistrationv1.ValidatingAdmissionPolicy {
+	return &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func TestInheritableBindingsSource(t *testing.T) {
+	source := &inheritableBindingsSource{Source: &fakeSource{hooks: []validating.PolicyHook{
+		{Policy: policy("mixed"), Bindings: []*admissionregistrationv1.ValidatingAdmissionPolicyBinding{binding("local", false), binding("inherited", true)}},
+		{Policy: policy("local-only"), Bindings: []*admissionregistrationv1.ValidatingAdmissionPolicyBinding{binding("local", false)}},
+		{Policy: policy("unbound")},
+	}}}
+
+	hooks := source.Hooks()
+	require.Len(t, hooks, 1)
+	require.Equal(t, "mixed", hooks[0].Policy.Name)
+	require.Len(t, hooks[0].Bindings, 1)
+	require.Equal(t, "inherited", hooks[0].Bindings[0].Name)
+}
+
+func TestInheritingPolicySource(t *testing.T) {
+	local := &fakeSource{hooks: []validating.PolicyHook{{Policy: policy("local")}}, synced: true}
+	parent := &fakeSource{hooks: []validating.PolicyHook{{Policy: policy("parent")}}}
+	source := &inheritingPolicySource{
+		Source: local,
+		inherited: func() []generic.Source[validating.PolicyHook] {
+			return []generic.Source[validating.PolicyHook]{parent}
+		},
+	}
+
+	require.False(t, source.HasSynced(), "expected not to be synced before the inherited sources")
+	parent.synced = true
+	require.True(t, source.HasSynced())
+
+	hooks := source.Hooks()
+	require.Len(t, hooks, 2)
+	require.Equal(t, "local", hooks[0].Policy.Name)
+	require.Equal(t, "parent", hooks[1].Policy.Name)
+}
+
+func TestAncestors(t *testing.T) {
+	owned := func(parent string) *corev1alpha1.LogicalCluster {
+		return &corev1alpha1.LogicalCluster{Spec: corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{Cluster: parent}}}
+	}
+	logicalClusters := map[logicalcluster.Name]*corev1alpha1.LogicalCluster{
+		"team":   owned("org"),
+		"org":    owned("root"),
+		"root":   {},
+		"orphan": owned("unknown"),
+		"loop":   owned("loop"),
+	}
+	k := &KubeValidatingAdmissionPolicy{
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			if logicalCluster, ok := logicalClusters[clusterName]; ok {
+				return logicalCluster, nil
+			}
+			return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+		},
+	}
+
+	require.Equal(t, []logicalcluster.Name{"org", "root"}, k.ancestors("team"))
+	require.Empty(t, k.ancestors("root"))
+	require.Equal(t, []logicalcluster.Name{"unknown"}, k.ancestors("orphan"))
+	require.Len(t, k.ancestors("loop"), maxInheritanceDepth)
+}
@@ -26,6 +26,7 @@ import (
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/admission/initializer"
@@ -43,6 +44,7 @@ import (
 
 	"github.com/kcp-dev/kcp/pkg/admission/initializers"
 	"github.com/kcp-dev/kcp/pkg/admission/kubequota"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
 )
@@ -61,6 +63,7 @@ func NewKubeValidatingAdmissionPolicy() *KubeValidatingAdmissionPolicy {
 	return &KubeValidatingAdmissionPolicy{
 		Handler:   admission.NewHandler(admission.Connect, admission.Create, admission.Delete, admission.Update),
 		delegates: make(map[logicalcluster.Name]*stoppableValidatingAdmissionPolicy),
+		inherited: make(map[logicalcluster.Name]*inheritableBindingsSource),
 	}
 }
 
@@ -76,10 +79,15 @@ type KubeValidatingAdmissionPolicy struct {
 	serverDone                      <-chan struct{}
 	featureGates                    featuregate.FeatureGate
 	authorizer                      authorizer.Authorizer
+	getLogicalCluster               func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
 
 	lock      sync.RWMutex
 	delegates map[logicalcluster.Name]*stoppableValidatingAdmissionPolicy
 
+	// inherited are the sources of the inheritable bindings of workspaces with descendants.
+	inheritedLock sync.RWMutex
+	inherited     map[logicalcluster.Name]*inheritableBindingsSource
+
 	logicalClusterDeletionMonitorStarter sync.Once
 }
 
@@ -98,6 +106,15 @@ func (k *KubeValidatingAdmissionPolicy) SetKubeClusterClient(kubeClusterClient k
 
 func (k *KubeValidatingAdmissionPolicy) SetKcpInformers(local, global kcpinformers.SharedInformerFactory) {
 	k.logicalClusterInformer = local.Core().V1alpha1().LogicalClusters()
+	localLogicalClusters := local.Core().V1alpha1().LogicalClusters().Lister()
+	globalLogicalClusters := global.Core().V1alpha1().LogicalClusters().Lister()
+	k.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		logicalCluster, err := localLogicalClusters.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		if apierrors.IsNotFound(err) {
+			return globalLogicalClusters.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		}
+		return logicalCluster, err
+	}
 }
 
 func (k *KubeValidatingAdmissionPolicy) SetKubeInformers(local, global kcpkubernetesinformers.SharedInformerFactory) {
@@ -194,17 +211,12 @@ func (k *KubeValidatingAdmissionPolicy) getOrCreateDelegate(clusterName logicalc
 	plugin.SetAuthorizer(k.authorizer)
 	plugin.SetClusterName(clusterName)
 	plugin.SetSourceFactory(func(_ informers.SharedInformerFactory, client kubernetes.Interface, dynamicClient dynamic.Interface, restMapper meta.RESTMapper, clusterName logicalcluster.Name) generic.Source[validating.PolicyHook] {
-		return generic.NewPolicySource(
-			k.globalKubeSharedInformerFactory.Admissionregistration().V1().ValidatingAdmissionPolicies().Informer().Cluster(clusterName),
-			k.globalKubeSharedInformerFactory.Admissionregistration().V1().ValidatingAdmissionPolicyBindings().Informer().Cluster(clusterName),
-			validating.NewValidatingAdmissionPolicyAccessor,
-			validating.NewValidatingAdmissionPolicyBindingAccessor,
-			validating.CompilePolicy,
-			nil,
-			dynamicClient,
-			restMapper,
-			clusterName,
-		)
+		return &inheritingPolicySource{
+			Source: k.newPolicySource(clusterName, restMapper),
+			inherited: func() []generic.Source[validating.PolicyHook] {
+				return k.getInheritedSources(clusterName)
+			},
+		}
 	})
 
 	if err := plugin.ValidateInitialization(); err != nil {
@@ -217,6 +229,20 @@ func (k *KubeValidatingAdmissionPolicy) getOrCreateDelegate(clusterName logicalc
 	return delegate, nil
 }
 
+func (k *KubeValidatingAdmissionPolicy) newPolicySource(clusterName logicalcluster.Name, restMapper meta.RESTMapper) generic.Source[validating.PolicyHook] {
+	return generic.NewPolicySource(
+		k.globalKubeSharedInformerFactory.Admissionregistration().V1().ValidatingAdmissionPolicies().Informer().Cluster(clusterName),
+		k.globalKubeSharedInformerFactory.Admissionregistration().V1().ValidatingAdmissionPolicyBindings().Informer().Cluster(clusterName),
+		validating.NewValidatingAdmissionPolicyAccessor,
+		validating.NewValidatingAdmissionPolicyBindingAccessor,
+		validating.CompilePolicy,
+		nil,
+		k.dynamicClusterClient.Cluster(clusterName.Path()),
+		restMapper,
+		clusterName,
+	)
+}
+
 func (k *KubeValidatingAdmissionPolicy) logicalClusterDeleted(clusterName logicalcluster.Name) {
 	k.lock.Lock()
 	defer k.lock.Unlock()
@@ -225,6 +251,13 @@ func (k *KubeValidatingAdmissionPolicy) logicalClusterDeleted(clusterName logica
 
 	logger := klog.Background().WithValues("clusterName", clusterName)
 
+	k.inheritedLock.Lock()
+	if inherited := k.inherited[clusterName]; inherited != nil {
+		delete(k.inherited, clusterName)
+		inherited.stop()
+	}
+	k.inheritedLock.Unlock()
+
 	if delegate == nil {
 		logger.V(3).Info("received event to stop validating admission policy for logical cluster, but it wasn't in the map")
 		return
@@ -99,30 +99,11 @@ func (p *Plugin) Validate(ctx context.Context, attr admission.Attributes, o admi
 	}
 	clusterName := cluster.Name
 
-	var config io.Reader
-	if len(p.config) > 0 {
-		config = bytes.NewReader(p.config)
-	}
-
-	hookSource, err := p.getHookSource(clusterName, attr.GetResource().GroupResource())
+	sourceClusters, err := p.getSourceClustersForGroupResource(clusterName, attr.GetResource().GroupResource())
 	if err != nil {
 		return err
 	}
 
-	plugin, err := validating.NewValidatingAdmissionWebhook(config)
-	if err != nil {
-		return fmt.Errorf("error creating validating admission webhook: %w", err)
-	}
-
-	plugin.SetExternalKubeClientSet(p.kubeClusterClient.Cluster(clusterName.Path()))
-	plugin.SetNamespaceInformer(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
-	plugin.SetHookSource(hookSource)
-	plugin.SetReadyFuncFromKCP(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
-
-	if err := plugin.ValidateInitialization(); err != nil {
-		return fmt.Errorf("error validating ValidatingAdmissionWebhook initialization: %w", err)
-	}
-
 	// Add cluster annotation on create
 	if attr.GetOperation() == admission.Create {
 		u, ok := attr.GetObject().(metav1.Object)
@@ -134,43 +115,72 @@ func (p *Plugin) Validate(ctx context.Context, attr admission.Attributes, o admi
 		}
 	}
 
-	return plugin.Validate(ctx, attr, o)
-}
+	for _, sourceCluster := range sourceClusters {
+		var config io.Reader
+		if len(p.config) > 0 {
+			config = bytes.NewReader(p.config)
+		}
 
-func (p *Plugin) getHookSource(clusterName logicalcluster.Name, groupResource schema.GroupResource) (generic.Source, error) {
-	clusterNameForGroupResource, err := p.getSourceClusterForGroupResource(clusterName, groupResource)
-	if err != nil {
-		return nil, err
+		plugin, err := validating.NewValidatingAdmissionWebhook(config)
+		if err != nil {
+			return fmt.Errorf("error creating validating admission webhook: %w", err)
+		}
+
+		plugin.SetExternalKubeClientSet(p.kubeClusterClient.Cluster(clusterName.Path()))
+		plugin.SetNamespaceInformer(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
+		plugin.SetHookSource(p.getHookSource(sourceCluster))
+		plugin.SetServiceResolver(NewWorkspaceServiceResolver(p.kubeClusterClient, sourceCluster))
+		plugin.SetAuthenticationInfoResolverWrapper(RestrictServiceDialing)
+		plugin.SetReadyFuncFromKCP(p.localKubeSharedInformerFactory.Core().V1().Namespaces().Cluster(clusterName))
+
+		if err := plugin.ValidateInitialization(); err != nil {
+			return fmt.Errorf("error validating ValidatingAdmissionWebhook initialization: %w", err)
+		}
+
+		if err := plugin.Validate(ctx, attr, o); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+func (p *Plugin) getHookSource(sourceCluster logicalcluster.Name) generic.Source {
 	p.managerLock.Lock()
 	defer p.managerLock.Unlock()
-	if _, ok := p.managersCache[clusterNameForGroupResource]; !ok {
-		p.managersCache[clusterNameForGroupResource] = configuration.NewValidatingWebhookConfigurationManagerForInformer(
-			p.globalKubeSharedInformerFactory.Admissionregistration().V1().ValidatingWebhookConfigurations().Cluster(clusterNameForGroupResource),
+	if _, ok := p.managersCache[sourceCluster]; !ok {
+		p.managersCache[sourceCluster] = configuration.NewValidatingWebhookConfigurationManagerForInformer(
+			p.globalKubeSharedInformerFactory.Admissionregistration().V1().ValidatingWebhookConfigurations().Cluster(sourceCluster),
 		)
 	}
 
-	return p.managersCache[clusterNameForGroupResource], nil
+	return p.managersCache[sourceCluster]
 }
 
-func (p *Plugin) getSourceClusterForGroupResource(clusterName logicalcluster.Name, groupResource schema.GroupResource) (logicalcluster.Name, error) {
+// getSourceClustersForGroupResource returns the logical clusters whose webhook configurations
+// apply to the given resource: the APIExport cluster if the resource is bound, and the cluster
+// of the request itself.
+func (p *Plugin) getSourceClustersForGroupResource(clusterName logicalcluster.Name, groupResource schema.GroupResource) ([]logicalcluster.Name, error) {
 	objs, err := p.getAPIBindings(clusterName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	for _, apiBinding := range objs {
 		for _, br := range apiBinding.Status.BoundResources {
 			if br.Group == groupResource.Group && br.Resource == groupResource.Resource {
 				// GroupResource comes from an APIBinding/APIExport
-				return logicalcluster.Name(apiBinding.Status.APIExportClusterName), nil
+				exportClusterName := logicalcluster.Name(apiBinding.Status.APIExportClusterName)
+				if exportClusterName == clusterName {
+					return []logicalcluster.Name{clusterName}, nil
+				}
+				return []logicalcluster.Name{exportClusterName, clusterName}, nil
 			}
 		}
 	}
 
 	// GroupResource is local to this cluster
-	return clusterName, nil
+	return []logicalcluster.Name{clusterName}, nil
 }
 
 func (p *Plugin) ValidateInitialization() error {
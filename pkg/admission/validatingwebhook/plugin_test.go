@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatingwebhook
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestGetSourceClustersForGroupResource(t *testing.T) {
+	binding := func(exportCluster string) *apisv1alpha1.APIBinding {
+		return &apisv1alpha1.APIBinding{Status: apisv1alpha1.APIBindingStatus{
+			APIExportClusterName: exportCluster,
+			BoundResources:       []apisv1alpha1.BoundAPIResource{{Group: "example.io", Resource: "widgets"}},
+		}}
+	}
+	tests := map[string]struct {
+		bindings []*apisv1alpha1.APIBinding
+		want     []logicalcluster.Name
+	}{
+		"local resource": {
+			want: []logicalcluster.Name{"consumer"},
+		},
+		"bound resource": {
+			bindings: []*apisv1alpha1.APIBinding{binding("provider")},
+			want:     []logicalcluster.Name{"provider", "consumer"},
+		},
+		"resource bound from the same cluster": {
+			bindings: []*apisv1alpha1.APIBinding{binding("consumer")},
+			want:     []logicalcluster.Name{"consumer"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &Plugin{getAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+				return tt.bindings, nil
+			}}
+			got, err := p.getSourceClustersForGroupResource("consumer", schema.GroupResource{Group: "example.io", Resource: "widgets"})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
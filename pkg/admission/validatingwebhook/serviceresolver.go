@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatingwebhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	webhookutil "k8s.io/apiserver/pkg/util/webhook"
+	"k8s.io/client-go/rest"
+)
+
+// serviceLookupTimeout bounds the lookup of a webhook Service. The webhook admission does
+// not pass the request context to the resolver.
+const serviceLookupTimeout = 5 * time.Second
+
+// NewWorkspaceServiceResolver returns a resolver for the Services referenced by the webhook
+// configurations of the given logical cluster. Only Services of type ExternalName in that
+// logical cluster are resolved, to their external name, using the target port of the matching
+// service port if there is one. External names pointing to loopback, link-local, private or
+// cluster-internal targets are rejected, as are all other Services: kcp must not be turned into
+// a proxy into the network of the cluster hosting it by users of a workspace. The addresses
+// external names resolve to are checked when connecting, see RestrictServiceDialing.
+//
+// Services are read live instead of from an informer: they are not served in every logical
+// cluster, and a wildcard informer would never sync.
+func NewWorkspaceServiceResolver(client kcpkubernetesclientset.ClusterInterface, clusterName logicalcluster.Name) webhookutil.ServiceResolver {
+	return &workspaceServiceResolver{
+		getService: func(namespace, name string) (*corev1.Service, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), serviceLookupTimeout)
+			defer cancel()
+			return client.Cluster(clusterName.Path()).CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+	}
+}
+
+type workspaceServiceResolver struct {
+	getService func(namespace, name string) (*corev1.Service, error)
+}
+
+func (r *workspaceServiceResolver) ResolveEndpoint(namespace, name string, port int32) (*url.URL, error) {
+	svc, err := r.getService(namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("webhook service %s/%s not found", namespace, name)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get webhook service %s/%s: %w", namespace, name, err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeExternalName {
+		return nil, fmt.Errorf("webhook service %s/%s must be of type ExternalName, but is of type %q", namespace, name, svc.Spec.Type)
+	}
+	if svc.Spec.ExternalName == "" {
+		return nil, fmt.Errorf("webhook service %s/%s of type ExternalName has no external name", namespace, name)
+	}
+	if err := validateExternalName(svc.Spec.ExternalName); err != nil {
+		return nil, fmt.Errorf("webhook service %s/%s: %w", namespace, name, err)
+	}
+
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port && p.TargetPort.IntVal != 0 {
+			port = p.TargetPort.IntVal
+			break
+		}
+	}
+	return &url.URL{Scheme: "https", Host: net.JoinHostPort(svc.Spec.ExternalName, strconv.Itoa(int(port)))}, nil
+}
+
+// validateExternalName rejects external names that would make kcp call itself or the network
+// of the cluster hosting it.
+func validateExternalName(externalName string) error {
+	if ip := net.ParseIP(externalName); ip != nil {
+		if isInternalIP(ip) {
+			return fmt.Errorf("external name %q is not a public address", externalName)
+		}
+		return nil
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(externalName, "."))
+	switch {
+	case !strings.Contains(host, "."):
+		// single labels are resolved through the search domains of the cluster
+		return fmt.Errorf("external name %q must be a fully qualified domain name", externalName)
+	case host == "localhost" || strings.HasSuffix(host, ".localhost"):
+		return fmt.Errorf("external name %q must not point to localhost", externalName)
+	case strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, ".cluster.local"):
+		return fmt.Errorf("external name %q must not point to a cluster-internal service", externalName)
+	}
+	return nil
+}
+
+// RestrictServiceDialing wraps the resolver of the webhook clients such that the clients of
+// service-based webhooks do not connect to loopback, link-local or private addresses. The
+// addresses are checked after name resolution, i.e. an external name resolving to such an
+// address is rejected as well. URL-based webhooks are not restricted.
+func RestrictServiceDialing(delegate webhookutil.AuthenticationInfoResolver) webhookutil.AuthenticationInfoResolver {
+	return &restrictedServiceDialResolver{AuthenticationInfoResolver: delegate}
+}
+
+type restrictedServiceDialResolver struct {
+	webhookutil.AuthenticationInfoResolver
+}
+
+func (r *restrictedServiceDialResolver) ClientConfigForService(serviceName, serviceNamespace string, servicePort int) (*rest.Config, error) {
+	config, err := r.AuthenticationInfoResolver.ClientConfigForService(serviceName, serviceNamespace, servicePort)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: controlServiceDial}
+	config.Dial = dialer.DialContext
+	return config, nil
+}
+
+// controlServiceDial rejects connections to internal addresses. It is called with the resolved
+// address of every connection attempt.
+func controlServiceDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isInternalIP(ip) {
+		return fmt.Errorf("webhook service address %s is not a public address", address)
+	}
+	return nil
+}
+
+// isInternalIP returns whether the IP is a loopback, link-local, unspecified or private address.
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsPrivate()
+}
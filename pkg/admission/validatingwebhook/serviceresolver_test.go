@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatingwebhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+)
+
+func TestWorkspaceServiceResolver(t *testing.T) {
+	tests := map[string]struct {
+		service *corev1.Service
+		port    int32
+		want    string
+		wantErr bool
+	}{
+		"missing service": {
+			port:    443,
+			wantErr: true,
+		},
+		"ClusterIP service": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "webhook.example.com"}},
+			port:    443,
+			want:    "https://webhook.example.com:443",
+		},
+		"ExternalName service with target port": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:         corev1.ServiceTypeExternalName,
+				ExternalName: "webhook.example.com",
+				Ports: []corev1.ServicePort{
+					{Port: 80, TargetPort: intstr.FromInt32(8080)},
+					{Port: 443, TargetPort: intstr.FromInt32(8443)},
+				},
+			}},
+			port: 443,
+			want: "https://webhook.example.com:8443",
+		},
+		"ExternalName service without external name": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with public IP": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "203.0.113.10"}},
+			port:    443,
+			want:    "https://203.0.113.10:443",
+		},
+		"ExternalName service with loopback IP": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "127.0.0.1"}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with IPv6 loopback IP": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "::1"}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with link-local IP": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "169.254.169.254"}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with private IP": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "10.96.0.1"}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with localhost": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "localhost"}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with cluster-internal service": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "kubernetes.default.svc"}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with fully qualified cluster-internal service": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "kubernetes.default.svc.cluster.local."}},
+			port:    443,
+			wantErr: true,
+		},
+		"ExternalName service with single label": {
+			service: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "kubernetes"}},
+			port:    443,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &workspaceServiceResolver{
+				getService: func(namespace, name string) (*corev1.Service, error) {
+					require.Equal(t, "ns", namespace)
+					require.Equal(t, "webhook", name)
+					if tt.service == nil {
+						return nil, apierrors.NewNotFound(corev1.Resource("services"), name)
+					}
+					return tt.service, nil
+				},
+			}
+			got, err := r.ResolveEndpoint("ns", "webhook", tt.port)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRestrictServiceDialing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	t.Cleanup(server.Close)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	resolver := RestrictServiceDialing(&fakeAuthenticationInfoResolver{})
+
+	t.Log("Service-based webhooks must not connect to hostnames resolving to internal addresses")
+	config, err := resolver.ClientConfigForService("webhook", "ns", 443)
+	require.NoError(t, err)
+	require.NotNil(t, config.Dial)
+	_, err = config.Dial(context.Background(), "tcp", net.JoinHostPort("localhost", port))
+	require.ErrorContains(t, err, "is not a public address")
+
+	t.Log("URL-based webhooks are not restricted")
+	config, err = resolver.ClientConfigFor(net.JoinHostPort("localhost", port))
+	require.NoError(t, err)
+	require.Nil(t, config.Dial)
+}
+
+func TestControlServiceDial(t *testing.T) {
+	for address, wantErr := range map[string]bool{
+		"203.0.113.10:443":      false,
+		"[2001:db8::1]:443":     false,
+		"127.0.0.1:443":         true,
+		"[::1]:443":             true,
+		"10.96.0.1:443":         true,
+		"192.168.1.1:443":       true,
+		"169.254.169.254:80":    true,
+		"[fe80::1]:443":         true,
+		"0.0.0.0:443":           true,
+		"[fd00::1]:443":         true,
+		"webhook.example.com:1": true,
+	} {
+		t.Run(address, func(t *testing.T) {
+			err := controlServiceDial("tcp", address, nil)
+			if wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+type fakeAuthenticationInfoResolver struct{}
+
+func (r *fakeAuthenticationInfoResolver) ClientConfigFor(hostPort string) (*rest.Config, error) {
+	return &rest.Config{Host: hostPort}, nil
+}
+
+func (r *fakeAuthenticationInfoResolver) ClientConfigForService(serviceName, serviceNamespace string, servicePort int) (*rest.Config, error) {
+	return &rest.Config{}, nil
+}
@@ -22,10 +22,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
 
 	authenticationv1 "k8s.io/api/authentication/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -34,8 +38,10 @@ import (
 	kuser "k8s.io/apiserver/pkg/authentication/user"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
 	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
 	"github.com/kcp-dev/kcp/pkg/authorization"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
@@ -48,11 +54,34 @@ const (
 	PluginName = "tenancy.kcp.io/Workspace"
 )
 
+// systemAnnotationKeys are the annotations on workspaces that only system privileged users can set or change.
+var systemAnnotationKeys = []string{
+	tenancyv1alpha1.ExperimentalWorkspaceRestoreAnnotationKey,
+	tenancyv1alpha1.ExperimentalWorkspaceRestoredFromAnnotationKey,
+	tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey,
+	tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey,
+	tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey,
+}
+
+// Config configures the plugin in the --admission-control-config-file.
+type Config struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// PrivilegedGroups are treated like system:masters, i.e. their members may set spec.cluster,
+	// spec.URL and the system annotations, and they are not recorded as workspace owners.
+	PrivilegedGroups []string `json:"privilegedGroups,omitempty"`
+}
+
 func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
-		func(_ io.Reader) (admission.Interface, error) {
+		func(config io.Reader) (admission.Interface, error) {
+			var c Config
+			if err := helpers.DecodeConfig(PluginName, config, &c); err != nil {
+				return nil, err
+			}
 			return &workspace{
-				Handler: admission.NewHandler(admission.Create, admission.Update),
+				Handler:          admission.NewHandler(admission.Create, admission.Update),
+				privilegedGroups: sets.New[string](c.PrivilegedGroups...).Insert(kuser.SystemPrivilegedGroup),
 			}, nil
 		})
 }
@@ -60,6 +89,8 @@ func Register(plugins *admission.Plugins) {
 type workspace struct {
 	*admission.Handler
 
+	privilegedGroups sets.Set[string]
+
 	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
 }
 
@@ -92,7 +123,7 @@ func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admissi
 	}
 
 	if a.GetOperation() == admission.Create {
-		isSystemPrivileged := sets.New[string](a.GetUserInfo().GetGroups()...).Has(kuser.SystemPrivilegedGroup)
+		isSystemPrivileged := o.isSystemPrivileged(a.GetUserInfo())
 
 		// create owner anntoation
 		if !isSystemPrivileged {
@@ -130,6 +161,7 @@ func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admissi
 // - the workspace only does a valid phase transition
 // - has a valid type and it is not mutated
 // - the cluster is not removed
+// - the ttl is positive
 // - the user is recorded in annotations on create
 // - the required groups match with the LogicalCluster.
 func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
@@ -151,7 +183,11 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 		return fmt.Errorf("failed to convert unstructured to Workspace: %w", err)
 	}
 
-	isSystemPrivileged := sets.New[string](a.GetUserInfo().GetGroups()...).Has(kuser.SystemPrivilegedGroup)
+	isSystemPrivileged := o.isSystemPrivileged(a.GetUserInfo())
+
+	if ws.Spec.TTL != nil && ws.Spec.TTL.Duration <= 0 {
+		return admission.NewForbidden(a, errors.New("spec.ttl must be positive"))
+	}
 
 	switch a.GetOperation() {
 	case admission.Update:
@@ -173,6 +209,16 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 		if old.Spec.URL != ws.Spec.URL && !isSystemPrivileged {
 			return admission.NewForbidden(a, errors.New("spec.URL can only be changed by system privileged users"))
 		}
+		for _, key := range systemAnnotationKeys {
+			if old.Annotations[key] != ws.Annotations[key] && !isSystemPrivileged {
+				return admission.NewForbidden(a, fmt.Errorf("%s annotation can only be changed by system privileged users", key))
+			}
+		}
+		if moveTo, found := ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey]; found && moveTo != old.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey] {
+			if err := o.validateMoveTo(clusterName, ws, moveTo); err != nil {
+				return admission.NewForbidden(a, err)
+			}
+		}
 
 		if errs := validation.ValidateImmutableField(ws.Spec.Type, old.Spec.Type, field.NewPath("spec", "type")); len(errs) > 0 {
 			return admission.NewForbidden(a, errs.ToAggregate())
@@ -198,6 +244,11 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 		if ws.Spec.URL != "" && !isSystemPrivileged {
 			return admission.NewForbidden(a, errors.New("spec.URL can only be set by system privileged users"))
 		}
+		for _, key := range systemAnnotationKeys {
+			if _, found := ws.Annotations[key]; found && !isSystemPrivileged {
+				return admission.NewForbidden(a, fmt.Errorf("%s annotation can only be set by system privileged users", key))
+			}
+		}
 
 		if !isSystemPrivileged {
 			userInfo, err := WorkspaceOwnerAnnotationValue(a.GetUserInfo())
@@ -228,6 +279,39 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 	return nil
 }
 
+func (o *workspace) isSystemPrivileged(user kuser.Info) bool {
+	if o.privilegedGroups == nil {
+		return sets.New[string](user.GetGroups()...).Has(kuser.SystemPrivilegedGroup)
+	}
+	return o.privilegedGroups.HasAny(user.GetGroups()...)
+}
+
+// validateMoveTo checks that the given move-to annotation value is a valid target
+// path for the workspace, i.e. it has a parent and it is not inside the workspace itself.
+func (o *workspace) validateMoveTo(clusterName logicalcluster.Name, ws *tenancyv1alpha1.Workspace, moveTo string) error {
+	target := logicalcluster.NewPath(moveTo)
+	if !target.IsValid() {
+		return fmt.Errorf("%s annotation must be a valid workspace path, got %q", tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey, moveTo)
+	}
+	if parent, ok := target.Parent(); !ok || parent.Empty() || parent.Equal(target) {
+		return fmt.Errorf("%s annotation must be a workspace path with a parent, got %q", tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey, moveTo)
+	}
+
+	logicalCluster, err := o.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	if err != nil {
+		return err
+	}
+	current := clusterName.Path()
+	if p := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; p != "" {
+		current = logicalcluster.NewPath(p)
+	}
+	current = current.Join(ws.Name)
+	if target.Equal(current) || strings.HasPrefix(target.String(), current.String()+":") {
+		return fmt.Errorf("workspace %s cannot be moved to %s", current, target)
+	}
+	return nil
+}
+
 func (o *workspace) ValidateInitialization() error {
 	if o.logicalClusterLister == nil {
 		return fmt.Errorf(PluginName + " plugin needs an LogicalCluster lister")
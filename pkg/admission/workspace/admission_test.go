@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/admission"
 	kuser "k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -518,6 +519,154 @@ func TestValidate(t *testing.T) {
 				}),
 			expectedErrors: []string{"spec.URL can only be changed by system privileged users"},
 		},
+		{
+			name: "rejects creation with restored-from annotation when unprivileged user",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: createAttr(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner":         "{}",
+						"experimental.tenancy.kcp.io/restored-from": "some-uid",
+					},
+				},
+			}),
+			expectedErrors: []string{"experimental.tenancy.kcp.io/restored-from annotation can only be set by system privileged users"},
+		},
+		{
+			name: "rejects non-positive ttl",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: createAttr(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{TTL: &metav1.Duration{}},
+			}),
+			expectedErrors: []string{"spec.ttl must be positive"},
+		},
+		{
+			name: "rejects restoring from unprivileged users",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttr(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner":   "{}",
+						"experimental.tenancy.kcp.io/restore": "true",
+					},
+				},
+			},
+				&tenancyv1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+					},
+				}),
+			expectedErrors: []string{"experimental.tenancy.kcp.io/restore annotation can only be changed by system privileged users"},
+		},
+		{
+			name: "rejects moving from unprivileged users",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner":   "{}",
+						"experimental.tenancy.kcp.io/move-to": "root:other:test",
+					},
+				},
+			},
+				&tenancyv1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+					},
+				}, &kuser.DefaultInfo{}),
+			expectedErrors: []string{"experimental.tenancy.kcp.io/move-to annotation can only be changed by system privileged users"},
+		},
+		{
+			name: "rejects moving into the workspace itself",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner":   "{}",
+						"experimental.tenancy.kcp.io/move-to": "root:org:test:sub",
+					},
+				},
+			},
+				&tenancyv1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+					},
+				}, &kuser.DefaultInfo{
+					Name:   "admin",
+					Groups: []string{kuser.SystemPrivilegedGroup},
+				}),
+			expectedErrors: []string{"workspace root:org:test cannot be moved to root:org:test:sub"},
+		},
+		{
+			name: "rejects moving to a path without parent",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner":   "{}",
+						"experimental.tenancy.kcp.io/move-to": "root",
+					},
+				},
+			},
+				&tenancyv1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+					},
+				}, &kuser.DefaultInfo{
+					Name:   "admin",
+					Groups: []string{kuser.SystemPrivilegedGroup},
+				}),
+			expectedErrors: []string{"experimental.tenancy.kcp.io/move-to annotation must be a workspace path with a parent"},
+		},
+		{
+			name: "allows moving to another parent by privileged users",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner":   "{}",
+						"experimental.tenancy.kcp.io/move-to": "root:other:renamed",
+					},
+				},
+			},
+				&tenancyv1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+					},
+				}, &kuser.DefaultInfo{
+					Name:   "admin",
+					Groups: []string{kuser.SystemPrivilegedGroup},
+				}),
+		},
 		{
 			name: "rejects transition to ready directly when invalid",
 			logicalClusters: []*corev1alpha1.LogicalCluster{
@@ -812,3 +961,22 @@ func (l fakeLogicalClusterLister) GetWithContext(ctx context.Context, name strin
 	}
 	return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspace"), name)
 }
+
+func TestValidatePrivilegedGroups(t *testing.T) {
+	o := &workspace{
+		Handler:              admission.NewHandler(admission.Create, admission.Update),
+		logicalClusterLister: fakeLogicalClusterClusterLister{newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster},
+		privilegedGroups:     sets.New[string](kuser.SystemPrivilegedGroup, "operators"),
+	}
+	ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+	ws := &tenancyv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       tenancyv1alpha1.WorkspaceSpec{Cluster: "somecluster", URL: "https://kcp.bigcorp.com/clusters/somecluster"},
+	}
+
+	err := o.Validate(ctx, createAttrWithUser(ws, &kuser.DefaultInfo{Name: "alice", Groups: []string{"operators"}}), nil)
+	require.NoError(t, err, "members of privileged groups can set spec.cluster")
+
+	err = o.Validate(ctx, createAttrWithUser(ws, &kuser.DefaultInfo{Name: "bob", Groups: []string{"developers"}}), nil)
+	require.ErrorContains(t, err, "spec.Cluster can only be set by system privileged users")
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+const (
+	PluginName = "tenancy.kcp.io/WorkspaceQuota"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			plugin := &workspaceQuota{
+				Handler: admission.NewHandler(admission.Create),
+			}
+			plugin.getQuotas = func(path logicalcluster.Path) ([]*tenancyv1alpha1.WorkspaceQuota, error) {
+				return indexers.ByIndexWithFallback[*tenancyv1alpha1.WorkspaceQuota](plugin.quotaIndexer, plugin.globalQuotaIndexer, indexers.ByLogicalClusterPath, path.String())
+			}
+			return plugin, nil
+		})
+}
+
+// workspaceQuota rejects the creation of workspaces, APIBindings and
+// CustomResourceDefinitions if that would exceed a WorkspaceQuota in a parent
+// workspace. The usage is taken from the quota status, which is maintained
+// by the workspacequota controller.
+type workspaceQuota struct {
+	*admission.Handler
+
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getQuotas         func(path logicalcluster.Path) ([]*tenancyv1alpha1.WorkspaceQuota, error)
+
+	quotaIndexer       cache.Indexer
+	globalQuotaIndexer cache.Indexer
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&workspaceQuota{})
+	_ = admission.InitializationValidator(&workspaceQuota{})
+	_ = kcpinitializers.WantsKcpInformers(&workspaceQuota{})
+)
+
+// Validate rejects the creation of objects exceeding a WorkspaceQuota.
+func (o *workspaceQuota) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	// workspaces are counted for quotas in the same workspace, everything
+	// else only for quotas in parent workspaces.
+	var includeSelf bool
+	var counter func(*tenancyv1alpha1.WorkspaceQuotaResources) *int64
+	switch a.GetResource().GroupResource() {
+	case tenancyv1alpha1.Resource("workspaces"):
+		includeSelf = true
+		counter = func(r *tenancyv1alpha1.WorkspaceQuotaResources) *int64 { return r.Workspaces }
+	case apisv1alpha1.Resource("apibindings"):
+		counter = func(r *tenancyv1alpha1.WorkspaceQuotaResources) *int64 { return r.APIBindings }
+	case apiextensionsv1.Resource("customresourcedefinitions"):
+		counter = func(r *tenancyv1alpha1.WorkspaceQuotaResources) *int64 { return r.CustomResourceDefinitions }
+	default:
+		return nil
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	if !o.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	logicalCluster, err := o.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		// system logical clusters have no LogicalCluster, and are not subject to quotas.
+		return nil
+	} else if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	path := logicalcluster.NewPath(logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey])
+	if path.Empty() {
+		path = clusterName.Path()
+	}
+	if !includeSelf {
+		var ok bool
+		if path, ok = path.Parent(); !ok {
+			return nil
+		}
+	}
+
+	for {
+		quotas, err := o.getQuotas(path)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		for _, quota := range quotas {
+			hard := counter(&quota.Spec.Hard)
+			if hard == nil {
+				continue
+			}
+			var used int64
+			if u := counter(&quota.Status.Used); u != nil {
+				used = *u
+			}
+			if used+1 > *hard {
+				return admission.NewForbidden(a, fmt.Errorf("exceeded workspace quota %s in workspace %s: %s limited to %d", quota.Name, path, a.GetResource().GroupResource(), *hard))
+			}
+		}
+
+		parent, ok := path.Parent()
+		if !ok || parent.Equal(path) {
+			return nil
+		}
+		path = parent
+	}
+}
+
+func (o *workspaceQuota) ValidateInitialization() error {
+	if o.getLogicalCluster == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	if o.quotaIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a WorkspaceQuota indexer")
+	}
+	if o.globalQuotaIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a global WorkspaceQuota indexer")
+	}
+	return nil
+}
+
+func (o *workspaceQuota) SetKcpInformers(local, global kcpinformers.SharedInformerFactory) {
+	localQuotasReady := local.Tenancy().V1alpha1().WorkspaceQuotas().Informer().HasSynced
+	globalQuotasReady := global.Tenancy().V1alpha1().WorkspaceQuotas().Informer().HasSynced
+	logicalClustersReady := local.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return localQuotasReady() && globalQuotasReady() && logicalClustersReady()
+	})
+
+	logicalClusterLister := local.Core().V1alpha1().LogicalClusters().Lister()
+	o.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	}
+
+	o.quotaIndexer = local.Tenancy().V1alpha1().WorkspaceQuotas().Informer().GetIndexer()
+	o.globalQuotaIndexer = global.Tenancy().V1alpha1().WorkspaceQuotas().Informer().GetIndexer()
+
+	indexers.AddIfNotPresentOrDie(o.quotaIndexer, cache.Indexers{
+		indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+	})
+	indexers.AddIfNotPresentOrDie(o.globalQuotaIndexer, cache.Indexers{
+		indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+	})
+}
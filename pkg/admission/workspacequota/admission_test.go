@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/utils/ptr"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func createAttr(resource schema.GroupVersionResource, kind string) admission.Attributes {
+	return admission.NewAttributesRecord(
+		nil,
+		nil,
+		resource.GroupVersion().WithKind(kind),
+		"",
+		"test",
+		resource,
+		"",
+		admission.Create,
+		&metav1.CreateOptions{},
+		false,
+		nil,
+	)
+}
+
+func newQuota(hard, used tenancyv1alpha1.WorkspaceQuotaResources) *tenancyv1alpha1.WorkspaceQuota {
+	return &tenancyv1alpha1.WorkspaceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec:       tenancyv1alpha1.WorkspaceQuotaSpec{Hard: hard},
+		Status:     tenancyv1alpha1.WorkspaceQuotaStatus{Used: used},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	workspaces := tenancyv1alpha1.SchemeGroupVersion.WithResource("workspaces")
+	apiBindings := apisv1alpha1.SchemeGroupVersion.WithResource("apibindings")
+
+	tests := []struct {
+		name   string
+		a      admission.Attributes
+		quotas map[string][]*tenancyv1alpha1.WorkspaceQuota
+
+		wantErr bool
+	}{
+		{
+			name: "no quotas",
+			a:    createAttr(workspaces, "Workspace"),
+		},
+		{
+			name: "workspace within quota of parent",
+			a:    createAttr(workspaces, "Workspace"),
+			quotas: map[string][]*tenancyv1alpha1.WorkspaceQuota{
+				"root:org": {newQuota(tenancyv1alpha1.WorkspaceQuotaResources{Workspaces: ptr.To[int64](3)}, tenancyv1alpha1.WorkspaceQuotaResources{Workspaces: ptr.To[int64](2)})},
+			},
+		},
+		{
+			name: "workspace exceeding quota of grandparent",
+			a:    createAttr(workspaces, "Workspace"),
+			quotas: map[string][]*tenancyv1alpha1.WorkspaceQuota{
+				"root:org": {newQuota(tenancyv1alpha1.WorkspaceQuotaResources{Workspaces: ptr.To[int64](3)}, tenancyv1alpha1.WorkspaceQuotaResources{Workspaces: ptr.To[int64](3)})},
+			},
+			wantErr: true,
+		},
+		{
+			name: "workspace exceeding quota in the same workspace",
+			a:    createAttr(workspaces, "Workspace"),
+			quotas: map[string][]*tenancyv1alpha1.WorkspaceQuota{
+				"root:org:team": {newQuota(tenancyv1alpha1.WorkspaceQuotaResources{Workspaces: ptr.To[int64](0)}, tenancyv1alpha1.WorkspaceQuotaResources{})},
+			},
+			wantErr: true,
+		},
+		{
+			name: "apibinding exceeding quota of parent",
+			a:    createAttr(apiBindings, "APIBinding"),
+			quotas: map[string][]*tenancyv1alpha1.WorkspaceQuota{
+				"root:org": {newQuota(tenancyv1alpha1.WorkspaceQuotaResources{APIBindings: ptr.To[int64](1)}, tenancyv1alpha1.WorkspaceQuotaResources{APIBindings: ptr.To[int64](1)})},
+			},
+			wantErr: true,
+		},
+		{
+			name: "apibinding not limited by quota in the same workspace",
+			a:    createAttr(apiBindings, "APIBinding"),
+			quotas: map[string][]*tenancyv1alpha1.WorkspaceQuota{
+				"root:org:team": {newQuota(tenancyv1alpha1.WorkspaceQuotaResources{APIBindings: ptr.To[int64](0)}, tenancyv1alpha1.WorkspaceQuotaResources{})},
+			},
+		},
+		{
+			name: "apibinding not limited by workspace quota",
+			a:    createAttr(apiBindings, "APIBinding"),
+			quotas: map[string][]*tenancyv1alpha1.WorkspaceQuota{
+				"root:org": {newQuota(tenancyv1alpha1.WorkspaceQuotaResources{Workspaces: ptr.To[int64](0)}, tenancyv1alpha1.WorkspaceQuotaResources{})},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &workspaceQuota{
+				Handler: admission.NewHandler(admission.Create),
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					if clusterName != "team" {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+					}
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: corev1alpha1.LogicalClusterName,
+							Annotations: map[string]string{
+								core.LogicalClusterPathAnnotationKey: "root:org:team",
+							},
+						},
+					}, nil
+				},
+				getQuotas: func(path logicalcluster.Path) ([]*tenancyv1alpha1.WorkspaceQuota, error) {
+					return tt.quotas[path.String()], nil
+				},
+			}
+			o.SetReadyFunc(func() bool { return true })
+
+			ctx := genericapirequest.WithCluster(context.Background(), genericapirequest.Cluster{Name: "team"})
+			err := o.Validate(ctx, tt.a, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+const (
+	PluginName = "tenancy.kcp.io/WorkspaceRoleBinding"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &workspaceRoleBinding{
+				Handler:          admission.NewHandler(admission.Create, admission.Update),
+				createAuthorizer: delegated.NewDelegatedAuthorizer,
+			}, nil
+		})
+}
+
+// workspaceRoleBinding rejects WorkspaceRoleBindings of cluster roles the requesting user
+// may not bind. The ClusterRoleBindings materialized by the workspace membership controller
+// are created with the privileges of the controller, hence the bind check of RBAC would be
+// bypassed otherwise.
+type workspaceRoleBinding struct {
+	*admission.Handler
+
+	deepSARClient    kcpkubernetesclientset.ClusterInterface
+	createAuthorizer delegated.DelegatedAuthorizerFactory
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&workspaceRoleBinding{})
+	_ = admission.InitializationValidator(&workspaceRoleBinding{})
+	_ = kcpinitializers.WantsDeepSARClient(&workspaceRoleBinding{})
+)
+
+// Validate checks that the user may bind the cluster role of a new WorkspaceRoleBinding, or
+// of one whose role changes.
+func (o *workspaceRoleBinding) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetResource().GroupResource() != tenancyv1alpha1.Resource("workspacerolebindings") || a.GetSubresource() != "" {
+		return nil
+	}
+
+	binding, err := toWorkspaceRoleBinding(a.GetObject())
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if a.GetOperation() == admission.Update {
+		old, err := toWorkspaceRoleBinding(a.GetOldObject())
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if old.Spec.RoleRef == binding.Spec.RoleRef {
+			return nil
+		}
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	authz, err := o.createAuthorizer(clusterName, o.deepSARClient, delegated.Options{})
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to determine access to cluster role %q: %w", binding.Spec.RoleRef.Name, err))
+	}
+	bindAttr := authorizer.AttributesRecord{
+		User:            a.GetUserInfo(),
+		Verb:            "bind",
+		APIGroup:        rbacv1.GroupName,
+		APIVersion:      rbacv1.SchemeGroupVersion.Version,
+		Resource:        "clusterroles",
+		Name:            binding.Spec.RoleRef.Name,
+		ResourceRequest: true,
+	}
+	if decision, _, err := authz.Authorize(ctx, bindAttr); err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to determine access to cluster role %q: %w", binding.Spec.RoleRef.Name, err))
+	} else if decision != authorizer.DecisionAllow {
+		return admission.NewForbidden(a, fmt.Errorf("unable to bind cluster role %q: missing verb='bind' permission on clusterroles", binding.Spec.RoleRef.Name))
+	}
+
+	return nil
+}
+
+func (o *workspaceRoleBinding) ValidateInitialization() error {
+	if o.deepSARClient == nil {
+		return fmt.Errorf(PluginName + " plugin needs a deep SAR client")
+	}
+	return nil
+}
+
+func (o *workspaceRoleBinding) SetDeepSARClient(client kcpkubernetesclientset.ClusterInterface) {
+	o.deepSARClient = client
+}
+
+func toWorkspaceRoleBinding(obj runtime.Object) (*tenancyv1alpha1.WorkspaceRoleBinding, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", obj)
+	}
+	binding := &tenancyv1alpha1.WorkspaceRoleBinding{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, binding); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to WorkspaceRoleBinding: %w", err)
+	}
+	return binding, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func newBinding(role string) *tenancyv1alpha1.WorkspaceRoleBinding {
+	return &tenancyv1alpha1.WorkspaceRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+		Spec: tenancyv1alpha1.WorkspaceRoleBindingSpec{
+			RoleRef: tenancyv1alpha1.WorkspaceRoleRef{Name: role},
+			Groups:  []string{"platform"},
+		},
+	}
+}
+
+func attr(obj, old *tenancyv1alpha1.WorkspaceRoleBinding, subresource string) admission.Attributes {
+	op := admission.Create
+	var oldObj runtime.Object
+	if old != nil {
+		op = admission.Update
+		oldObj = helpers.ToUnstructuredOrDie(old)
+	}
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(obj),
+		oldObj,
+		tenancyv1alpha1.Kind("WorkspaceRoleBinding").WithVersion("v1alpha1"),
+		"",
+		obj.Name,
+		tenancyv1alpha1.Resource("workspacerolebindings").WithVersion("v1alpha1"),
+		subresource,
+		op,
+		&metav1.CreateOptions{},
+		false,
+		&user.DefaultInfo{Name: "alice"},
+	)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		attr          admission.Attributes
+		authzDecision authorizer.Decision
+		authzError    error
+		wantChecked   string
+		wantErr       bool
+	}{
+		{
+			name:          "create with bind permission",
+			attr:          attr(newBinding("admin"), nil, ""),
+			authzDecision: authorizer.DecisionAllow,
+			wantChecked:   "admin",
+		},
+		{
+			name:          "create without bind permission",
+			attr:          attr(newBinding("admin"), nil, ""),
+			authzDecision: authorizer.DecisionNoOpinion,
+			wantChecked:   "admin",
+			wantErr:       true,
+		},
+		{
+			name:        "authorizer error",
+			attr:        attr(newBinding("admin"), nil, ""),
+			authzError:  errors.New("authorizer error"),
+			wantChecked: "admin",
+			wantErr:     true,
+		},
+		{
+			name:          "update of role checks new role",
+			attr:          attr(newBinding("admin"), newBinding("view"), ""),
+			authzDecision: authorizer.DecisionNoOpinion,
+			wantChecked:   "admin",
+			wantErr:       true,
+		},
+		{
+			name:          "update of members is not checked",
+			attr:          attr(newBinding("admin"), newBinding("admin"), ""),
+			authzDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:          "status is not checked",
+			attr:          attr(newBinding("admin"), nil, "status"),
+			authzDecision: authorizer.DecisionNoOpinion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checked string
+			o := &workspaceRoleBinding{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+				createAuthorizer: func(clusterName logicalcluster.Name, client kcpkubernetesclientset.ClusterInterface, opts delegated.Options) (authorizer.Authorizer, error) {
+					return authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+						if a.GetVerb() != "bind" || a.GetResource() != "clusterroles" || a.GetUser().GetName() != "alice" {
+							t.Errorf("unexpected authorization attributes: %#v", a)
+						}
+						checked = a.GetName()
+						return tt.authzDecision, "reason", tt.authzError
+					}), nil
+				},
+			}
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org:ws"})
+			if err := o.Validate(ctx, tt.attr, nil); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if checked != tt.wantChecked {
+				t.Errorf("checked cluster role %q, expected %q", checked, tt.wantChecked)
+			}
+		})
+	}
+}
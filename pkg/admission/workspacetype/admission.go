@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -33,6 +35,9 @@ import (
 
 // Validate WorkspaceTypes creation and updates for
 //  - "organization" type is only created in root workspace.
+//  - overrides are only set when extending other types.
+//  - only built-in resources are disabled.
+//  - the child name pattern is a valid regular expression.
 
 const (
 	PluginName = "tenancy.kcp.io/WorkspaceType"
@@ -81,6 +86,24 @@ func (o *workspacetype) Validate(ctx context.Context, a admission.Attributes, _
 		return admission.NewForbidden(a, fmt.Errorf(".spec.defaultChildWorkspaceType.path must be set"))
 	}
 
+	if len(wt.Spec.Extend.Overrides) > 0 && len(wt.Spec.Extend.With) == 0 {
+		return admission.NewForbidden(a, fmt.Errorf(".spec.extend.overrides requires .spec.extend.with to be set"))
+	}
+
+	if wt.Spec.Limits != nil {
+		for i, r := range wt.Spec.Limits.DisabledResources {
+			if r.Group == "kcp.io" || strings.HasSuffix(r.Group, ".kcp.io") {
+				return admission.NewForbidden(a, fmt.Errorf(".spec.limits.disabledResources[%d]: kcp resources cannot be disabled", i))
+			}
+		}
+	}
+
+	if wt.Spec.ChildNamePolicy != nil && wt.Spec.ChildNamePolicy.Pattern != "" {
+		if _, err := regexp.Compile(wt.Spec.ChildNamePolicy.Pattern); err != nil {
+			return admission.NewForbidden(a, fmt.Errorf(".spec.childNamePolicy.pattern is invalid: %w", err))
+		}
+	}
+
 	if wt.Spec.LimitAllowedChildren != nil {
 		for i, t := range wt.Spec.LimitAllowedChildren.Types {
 			if t.Path == "" {
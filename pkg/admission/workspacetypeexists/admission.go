@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
@@ -321,6 +322,9 @@ func (o *workspacetypeExists) Validate(ctx context.Context, a admission.Attribut
 		if err := validateAllowedChildren(parentAliases, wtAliases, thisTypePath, wTypeString); err != nil {
 			return admission.NewForbidden(a, err)
 		}
+		if err := validateChildName(parentAliases, thisTypePath, ws.Name); err != nil {
+			return admission.NewForbidden(a, err)
+		}
 	}
 
 	return nil
@@ -462,9 +466,59 @@ func (r *transitiveTypeResolver) resolve(wt *tenancyv1alpha1.WorkspaceType, seen
 	return ret, nil
 }
 
+// WithoutOverridden filters the given aliases of a WorkspaceType, as returned by
+// TransitiveTypeResolver.Resolve with the type itself last, down to those whose value
+// of the given field apply, i.e. it drops the types that are only extended through
+// types overriding the field.
+func WithoutOverridden(aliases []*tenancyv1alpha1.WorkspaceType, field tenancyv1alpha1.WorkspaceTypeField) []*tenancyv1alpha1.WorkspaceType {
+	overridden := false
+	for _, alias := range aliases {
+		for _, f := range alias.Spec.Extend.Overrides {
+			overridden = overridden || f == field
+		}
+	}
+	if !overridden {
+		return aliases
+	}
+
+	byName := make(map[string]*tenancyv1alpha1.WorkspaceType, len(aliases))
+	for _, alias := range aliases {
+		byName[canonicalPathFrom(alias).Join(alias.Name).String()] = alias
+	}
+
+	applies := sets.New[string]()
+	var visit func(wt *tenancyv1alpha1.WorkspaceType)
+	visit = func(wt *tenancyv1alpha1.WorkspaceType) {
+		qualifiedName := canonicalPathFrom(wt).Join(wt.Name).String()
+		if applies.Has(qualifiedName) {
+			return
+		}
+		applies.Insert(qualifiedName)
+		for _, overridden := range wt.Spec.Extend.Overrides {
+			if overridden == field {
+				return
+			}
+		}
+		for _, baseTypeRef := range wt.Spec.Extend.With {
+			if baseType, found := byName[logicalcluster.NewPath(baseTypeRef.Path).Join(tenancyv1alpha1.ObjectName(baseTypeRef.Name)).String()]; found {
+				visit(baseType)
+			}
+		}
+	}
+	visit(aliases[len(aliases)-1])
+
+	ret := make([]*tenancyv1alpha1.WorkspaceType, 0, len(aliases))
+	for _, alias := range aliases {
+		if applies.Has(canonicalPathFrom(alias).Join(alias.Name).String()) {
+			ret = append(ret, alias)
+		}
+	}
+	return ret
+}
+
 func validateAllowedParents(parentAliases, childAliases []*tenancyv1alpha1.WorkspaceType, parentType, childType logicalcluster.Path) error {
 	var errs []error
-	for _, childAlias := range childAliases {
+	for _, childAlias := range WithoutOverridden(childAliases, tenancyv1alpha1.WorkspaceTypeFieldLimitAllowedParents) {
 		if childAlias.Spec.LimitAllowedParents == nil {
 			continue
 		}
@@ -505,7 +559,7 @@ func validateAllowedParents(parentAliases, childAliases []*tenancyv1alpha1.Works
 
 func validateAllowedChildren(parentAliases, childAliases []*tenancyv1alpha1.WorkspaceType, parentType, childType logicalcluster.Path) error {
 	var errs []error
-	for _, parentAlias := range parentAliases {
+	for _, parentAlias := range WithoutOverridden(parentAliases, tenancyv1alpha1.WorkspaceTypeFieldLimitAllowedChildren) {
 		if parentAlias.Spec.LimitAllowedChildren == nil {
 			continue
 		}
@@ -544,6 +598,37 @@ func validateAllowedChildren(parentAliases, childAliases []*tenancyv1alpha1.Work
 	return utilerrors.NewAggregate(errs)
 }
 
+func validateChildName(parentAliases []*tenancyv1alpha1.WorkspaceType, parentType logicalcluster.Path, name string) error {
+	var errs []error
+	for _, parentAlias := range WithoutOverridden(parentAliases, tenancyv1alpha1.WorkspaceTypeFieldChildNamePolicy) {
+		policy := parentAlias.Spec.ChildNamePolicy
+		if policy == nil {
+			continue
+		}
+
+		extending := ""
+		if qualifiedParent := canonicalPathFrom(parentAlias).Join(string(tenancyv1alpha1.TypeName(parentAlias.Name))); qualifiedParent != parentType {
+			extending = fmt.Sprintf(" extends %s, which", qualifiedParent)
+		}
+
+		if policy.Pattern != "" {
+			re, err := regexp.Compile("^(?:" + policy.Pattern + ")$")
+			if err != nil {
+				errs = append(errs, fmt.Errorf("workspace type %s%s has an invalid child name pattern %q: %w", parentType, extending, policy.Pattern, err))
+			} else if !re.MatchString(name) {
+				errs = append(errs, fmt.Errorf("workspace type %s%s only allows child workspace names matching %q", parentType, extending, policy.Pattern))
+			}
+		}
+		for _, prefix := range policy.ReservedPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				errs = append(errs, fmt.Errorf("workspace type %s%s reserves child workspace names starting with %q", parentType, extending, prefix))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
 func allOfTheFormerExistInTheLater(objectAliases []*tenancyv1alpha1.WorkspaceType, allowedTypes []tenancyv1alpha1.WorkspaceTypeReference) bool {
 	allowedAliasSet := sets.New[string]()
 	for _, allowed := range allowedTypes {
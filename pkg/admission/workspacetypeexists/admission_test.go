@@ -788,6 +788,132 @@ func TestValidateAllowedChildren(t *testing.T) {
 	}
 }
 
+func TestValidateChildName(t *testing.T) {
+	tests := []struct {
+		name          string
+		parentAliases []*tenancyv1alpha1.WorkspaceType
+		childName     string
+		wantErr       string
+	}{
+		{
+			name:          "no policy",
+			parentAliases: []*tenancyv1alpha1.WorkspaceType{newType("root:a").WorkspaceType},
+			childName:     "anything",
+		},
+		{
+			name:          "matching pattern",
+			parentAliases: []*tenancyv1alpha1.WorkspaceType{newType("root:a").withChildNamePolicy("team-[a-z]+").WorkspaceType},
+			childName:     "team-abc",
+		},
+		{
+			name:          "pattern must match the whole name",
+			parentAliases: []*tenancyv1alpha1.WorkspaceType{newType("root:a").withChildNamePolicy("team-[a-z]+").WorkspaceType},
+			childName:     "my-team-abc",
+			wantErr:       `workspace type root:a only allows child workspace names matching "team-[a-z]+"`,
+		},
+		{
+			name:          "reserved prefix",
+			parentAliases: []*tenancyv1alpha1.WorkspaceType{newType("root:a").withChildNamePolicy("", "kcp-", "system-").WorkspaceType},
+			childName:     "system-foo",
+			wantErr:       `workspace type root:a reserves child workspace names starting with "system-"`,
+		},
+		{
+			name: "policy of extended type",
+			parentAliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").withChildNamePolicy("team-[a-z]+").WorkspaceType,
+				newType("root:a").extending("root:b").WorkspaceType,
+			},
+			childName: "foo",
+			wantErr:   `workspace type root:a extends root:b, which only allows child workspace names matching "team-[a-z]+"`,
+		},
+		{
+			name: "overridden policy of extended type",
+			parentAliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").withChildNamePolicy("team-[a-z]+").WorkspaceType,
+				newType("root:a").extending("root:b").overriding(tenancyv1alpha1.WorkspaceTypeFieldChildNamePolicy).WorkspaceType,
+			},
+			childName: "foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChildName(tt.parentAliases, logicalcluster.NewPath("root:a"), tt.childName)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithoutOverridden(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases []*tenancyv1alpha1.WorkspaceType
+		want    []string
+	}{
+		{
+			name: "no aliases",
+		},
+		{
+			name: "no overrides",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").extending("root:c").WorkspaceType,
+				newType("root:c").WorkspaceType,
+				newType("root:a").extending("root:b").WorkspaceType,
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name: "leaf overrides",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").extending("root:c").WorkspaceType,
+				newType("root:c").WorkspaceType,
+				newType("root:a").extending("root:b").overriding(tenancyv1alpha1.WorkspaceTypeFieldDefaultAPIBindings).WorkspaceType,
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "intermediate overrides",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").extending("root:c").overriding(tenancyv1alpha1.WorkspaceTypeFieldDefaultAPIBindings).WorkspaceType,
+				newType("root:c").WorkspaceType,
+				newType("root:a").extending("root:b").WorkspaceType,
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name: "other field overridden",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").extending("root:c").overriding(tenancyv1alpha1.WorkspaceTypeFieldInitializers).WorkspaceType,
+				newType("root:c").WorkspaceType,
+				newType("root:a").extending("root:b").WorkspaceType,
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name: "diamond with one path overriding",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").extending("root:d").overriding(tenancyv1alpha1.WorkspaceTypeFieldDefaultAPIBindings).WorkspaceType,
+				newType("root:d").WorkspaceType,
+				newType("root:c").extending("root:d").WorkspaceType,
+				newType("root:a").extending("root:b").extending("root:c").WorkspaceType,
+			},
+			want: []string{"b", "d", "c", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, wt := range WithoutOverridden(tt.aliases, tenancyv1alpha1.WorkspaceTypeFieldDefaultAPIBindings) {
+				got = append(got, wt.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 type builder struct {
 	*tenancyv1alpha1.WorkspaceType
 }
@@ -811,6 +937,11 @@ func (b builder) extending(qualifiedName string) builder {
 	return b
 }
 
+func (b builder) overriding(field tenancyv1alpha1.WorkspaceTypeField) builder {
+	b.Spec.Extend.Overrides = append(b.Spec.Extend.Overrides, field)
+	return b
+}
+
 func (b builder) allowingParent(qualifiedName string) builder {
 	path, name := logicalcluster.NewPath(qualifiedName).Split()
 	if b.Spec.LimitAllowedParents == nil {
@@ -852,6 +983,14 @@ func (b builder) disallowingParent() builder {
 	return b
 }
 
+func (b builder) withChildNamePolicy(pattern string, reservedPrefixes ...string) builder {
+	b.WorkspaceType.Spec.ChildNamePolicy = &tenancyv1alpha1.WorkspaceNamePolicy{
+		Pattern:          pattern,
+		ReservedPrefixes: reservedPrefixes,
+	}
+	return b
+}
+
 func (b builder) withAdditionalLabel(labels map[string]string) builder {
 	b.WorkspaceType.Spec.AdditionalWorkspaceLabels = labels
 	return b
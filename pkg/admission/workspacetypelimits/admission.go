@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetypelimits
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+const (
+	PluginName = "tenancy.kcp.io/WorkspaceTypeLimits"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			plugin := &workspaceTypeLimits{
+				Handler: admission.NewHandler(admission.Create),
+			}
+			plugin.getType = func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+				return indexers.ByPathAndNameWithFallback[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), plugin.typeIndexer, plugin.globalTypeIndexer, path, name)
+			}
+			plugin.transitiveTypeResolver = workspacetypeexists.NewTransitiveTypeResolver(plugin.getType)
+			return plugin, nil
+		})
+}
+
+// workspaceTypeLimits rejects the creation of objects of resources that are
+// disabled by the limits of the workspace type of the logical cluster, or by
+// the limits of the types it extends.
+type workspaceTypeLimits struct {
+	*admission.Handler
+
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getType           func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+
+	typeIndexer       cache.Indexer
+	globalTypeIndexer cache.Indexer
+
+	transitiveTypeResolver workspacetypeexists.TransitiveTypeResolver
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&workspaceTypeLimits{})
+	_ = admission.InitializationValidator(&workspaceTypeLimits{})
+	_ = kcpinitializers.WantsKcpInformers(&workspaceTypeLimits{})
+)
+
+// Validate rejects the creation of objects of disabled resources.
+func (o *workspaceTypeLimits) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetSubresource() != "" {
+		return nil
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	if !o.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	logicalCluster, err := o.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		// system logical clusters have no LogicalCluster, and no type.
+		return nil
+	} else if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	typeAnnotation, found := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterTypeAnnotationKey]
+	if !found {
+		return nil
+	}
+	wtWorkspace, wtName := logicalcluster.NewPath(typeAnnotation).Split()
+	if wtWorkspace.Empty() {
+		return nil
+	}
+	wt, err := o.getType(wtWorkspace, wtName)
+	if apierrors.IsNotFound(err) {
+		// the type was deleted after the workspace was created. Nothing to enforce.
+		return nil
+	} else if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	aliases, err := o.transitiveTypeResolver.Resolve(wt)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+
+	gr := a.GetResource().GroupResource()
+	for _, alias := range workspacetypeexists.WithoutOverridden(aliases, tenancyv1alpha1.WorkspaceTypeFieldLimits) {
+		if alias.Spec.Limits == nil {
+			continue
+		}
+		for _, disabled := range alias.Spec.Limits.DisabledResources {
+			if disabled.Group == gr.Group && disabled.Resource == gr.Resource {
+				return admission.NewForbidden(a, fmt.Errorf("%s are disabled in workspaces of type %s", gr, wtWorkspace.Join(wtName)))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *workspaceTypeLimits) ValidateInitialization() error {
+	if o.getLogicalCluster == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	if o.typeIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a WorkspaceType indexer")
+	}
+	if o.globalTypeIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a global WorkspaceType indexer")
+	}
+	return nil
+}
+
+func (o *workspaceTypeLimits) SetKcpInformers(local, global kcpinformers.SharedInformerFactory) {
+	localTypesReady := local.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced
+	globalTypesReady := global.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced
+	logicalClustersReady := local.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return localTypesReady() && globalTypesReady() && logicalClustersReady()
+	})
+
+	logicalClusterLister := local.Core().V1alpha1().LogicalClusters().Lister()
+	o.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	}
+
+	o.typeIndexer = local.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer()
+	o.globalTypeIndexer = global.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer()
+
+	indexers.AddIfNotPresentOrDie(o.typeIndexer, cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+	indexers.AddIfNotPresentOrDie(o.globalTypeIndexer, cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+}
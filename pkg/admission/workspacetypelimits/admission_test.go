@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetypelimits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func createAttr(resource schema.GroupVersionResource, subresource string) admission.Attributes {
+	return admission.NewAttributesRecord(
+		nil,
+		nil,
+		resource.GroupVersion().WithKind("Test"),
+		"default",
+		"test",
+		resource,
+		subresource,
+		admission.Create,
+		&metav1.CreateOptions{},
+		false,
+		nil,
+	)
+}
+
+func newType(path, name string, disabled []tenancyv1alpha1.GroupResource, extends ...string) *tenancyv1alpha1.WorkspaceType {
+	wt := &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: path},
+		},
+	}
+	if disabled != nil {
+		wt.Spec.Limits = &tenancyv1alpha1.WorkspaceTypeLimits{DisabledResources: disabled}
+	}
+	for _, e := range extends {
+		p, n := logicalcluster.NewPath(e).Split()
+		wt.Spec.Extend.With = append(wt.Spec.Extend.With, tenancyv1alpha1.WorkspaceTypeReference{Path: p.String(), Name: tenancyv1alpha1.WorkspaceTypeName(n)})
+	}
+	return wt
+}
+
+func TestValidate(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	services := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	overriding := newType("root:org", "overriding", []tenancyv1alpha1.GroupResource{{Resource: "services"}}, "root:base")
+	overriding.Spec.Extend.Overrides = []tenancyv1alpha1.WorkspaceTypeField{tenancyv1alpha1.WorkspaceTypeFieldLimits}
+	types := []*tenancyv1alpha1.WorkspaceType{
+		newType("root", "base", []tenancyv1alpha1.GroupResource{{Group: "apps", Resource: "deployments"}}),
+		newType("root", "unlimited", nil),
+		newType("root:org", "extending", []tenancyv1alpha1.GroupResource{{Resource: "services"}}, "root:base"),
+		overriding,
+	}
+
+	tests := []struct {
+		name        string
+		a           admission.Attributes
+		clusterType string
+
+		wantErr bool
+	}{
+		{
+			name:        "type without limits",
+			a:           createAttr(deployments, ""),
+			clusterType: "root:unlimited",
+		},
+		{
+			name:        "disabled resource",
+			a:           createAttr(deployments, ""),
+			clusterType: "root:base",
+			wantErr:     true,
+		},
+		{
+			name:        "resource not disabled",
+			a:           createAttr(configMaps, ""),
+			clusterType: "root:base",
+		},
+		{
+			name:        "subresource of disabled resource",
+			a:           createAttr(deployments, "scale"),
+			clusterType: "root:base",
+		},
+		{
+			name:        "resource disabled by extending type",
+			a:           createAttr(services, ""),
+			clusterType: "root:org:extending",
+			wantErr:     true,
+		},
+		{
+			name:        "resource disabled by extended type",
+			a:           createAttr(deployments, ""),
+			clusterType: "root:org:extending",
+			wantErr:     true,
+		},
+		{
+			name:        "limits of extended type overridden",
+			a:           createAttr(deployments, ""),
+			clusterType: "root:org:overriding",
+		},
+		{
+			name:        "resource disabled by overriding type",
+			a:           createAttr(services, ""),
+			clusterType: "root:org:overriding",
+			wantErr:     true,
+		},
+		{
+			name: "system logical cluster",
+			a:    createAttr(deployments, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getType := func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+				for _, wt := range types {
+					if wt.Annotations[core.LogicalClusterPathAnnotationKey] == path.String() && wt.Name == name {
+						return wt, nil
+					}
+				}
+				return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetypes"), name)
+			}
+			o := &workspaceTypeLimits{
+				Handler: admission.NewHandler(admission.Create),
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					if tt.clusterType == "" {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+					}
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: corev1alpha1.LogicalClusterName,
+							Annotations: map[string]string{
+								tenancyv1alpha1.LogicalClusterTypeAnnotationKey: tt.clusterType,
+							},
+						},
+					}, nil
+				},
+				getType:                getType,
+				transitiveTypeResolver: workspacetypeexists.NewTransitiveTypeResolver(getType),
+			}
+			o.SetReadyFunc(func() bool { return true })
+
+			ctx := genericapirequest.WithCluster(context.Background(), genericapirequest.Cluster{Name: "team"})
+			err := o.Validate(ctx, tt.a, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
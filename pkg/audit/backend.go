@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	pluginlog "k8s.io/apiserver/plugin/pkg/audit/log"
+)
+
+// backend sends the events of the global audit policy to the global backend, and the
+// events of workspace audit policies to the backend of the workspace.
+type backend struct {
+	delegate kaudit.Backend
+	global   kaudit.PolicyRuleEvaluator
+
+	getPolicy           func(clusterName logicalcluster.Name) kaudit.PolicyRuleEvaluator
+	newWorkspaceBackend func(clusterName logicalcluster.Name) (kaudit.Backend, error)
+
+	lock              sync.Mutex
+	stopCh            <-chan struct{}
+	workspaceBackends map[logicalcluster.Name]kaudit.Backend
+}
+
+var _ kaudit.Backend = &backend{}
+
+func (b *backend) ProcessEvents(events ...*auditinternal.Event) bool {
+	success := true
+	global := make([]*auditinternal.Event, 0, len(events))
+	for _, ev := range events {
+		attrs := attributesFrom(ev)
+		if filtered := filterEvent(ev, evaluate(b.global, attrs)); filtered != nil {
+			global = append(global, filtered)
+		}
+
+		clusterName := logicalcluster.Name(ev.Annotations[WorkspaceAnnotationKey])
+		if clusterName.Empty() {
+			continue
+		}
+		workspacePolicy := b.getPolicy(clusterName)
+		if workspacePolicy == nil {
+			continue
+		}
+		filtered := filterEvent(ev, workspacePolicy.EvaluatePolicyRule(attrs))
+		if filtered == nil {
+			continue
+		}
+		workspaceBackend, err := b.workspaceBackend(clusterName)
+		if err != nil {
+			utilruntime.HandleError(err)
+			success = false
+			continue
+		}
+		success = workspaceBackend.ProcessEvents(filtered) && success
+	}
+
+	if b.delegate != nil && len(global) > 0 {
+		success = b.delegate.ProcessEvents(global...) && success
+	}
+	return success
+}
+
+func (b *backend) workspaceBackend(clusterName logicalcluster.Name) (kaudit.Backend, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if workspaceBackend, found := b.workspaceBackends[clusterName]; found {
+		return workspaceBackend, nil
+	}
+	workspaceBackend, err := b.newWorkspaceBackend(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit backend for logical cluster %q: %w", clusterName, err)
+	}
+	if err := workspaceBackend.Run(b.stopCh); err != nil {
+		return nil, fmt.Errorf("failed to run audit backend for logical cluster %q: %w", clusterName, err)
+	}
+	b.workspaceBackends[clusterName] = workspaceBackend
+	return workspaceBackend, nil
+}
+
+func (b *backend) Run(stopCh <-chan struct{}) error {
+	b.lock.Lock()
+	b.stopCh = stopCh
+	b.lock.Unlock()
+
+	if b.delegate != nil {
+		return b.delegate.Run(stopCh)
+	}
+	return nil
+}
+
+func (b *backend) Shutdown() {
+	if b.delegate != nil {
+		b.delegate.Shutdown()
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, workspaceBackend := range b.workspaceBackends {
+		workspaceBackend.Shutdown()
+	}
+}
+
+func (b *backend) String() string {
+	if b.delegate != nil {
+		return fmt.Sprintf("workspaces<%s>", b.delegate)
+	}
+	return "workspaces"
+}
+
+// fileBackend writes events to a log file.
+type fileBackend struct {
+	kaudit.Backend
+
+	file *os.File
+}
+
+func newFileBackend(dir string, clusterName logicalcluster.Name) (kaudit.Backend, error) {
+	return openFileBackend(filepath.Join(dir, clusterName.String()+".log"))
+}
+
+// openFileBackend appends the events to the given file, creating it and its directory if needed.
+func openFileBackend(path string) (kaudit.Backend, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileBackend{
+		Backend: pluginlog.NewBackend(f, pluginlog.FormatJson, auditv1.SchemeGroupVersion),
+		file:    f,
+	}, nil
+}
+
+func (b *fileBackend) Shutdown() {
+	b.Backend.Shutdown()
+	if err := b.file.Close(); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/audit/policy"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+type fakeBackend struct {
+	events []*auditinternal.Event
+}
+
+func (b *fakeBackend) ProcessEvents(events ...*auditinternal.Event) bool {
+	b.events = append(b.events, events...)
+	return true
+}
+func (b *fakeBackend) Run(<-chan struct{}) error { return nil }
+func (b *fakeBackend) Shutdown()                 {}
+func (b *fakeBackend) String() string            { return "fake" }
+
+var writesPolicy = &tenancyv1alpha1.AuditPolicy{
+	Rules: []tenancyv1alpha1.AuditPolicyRule{
+		{Level: tenancyv1alpha1.AuditLevelNone, Resources: []tenancyv1alpha1.AuditGroupResources{{Resources: []string{"events"}}}},
+		{Level: tenancyv1alpha1.AuditLevelRequestResponse, Verbs: []string{"create", "update", "patch", "delete"}},
+	},
+}
+
+func TestPolicyRuleEvaluator(t *testing.T) {
+	create := &authorizer.AttributesRecord{Verb: "create", ResourceRequest: true, Resource: "configmaps"}
+	get := &authorizer.AttributesRecord{Verb: "get", ResourceRequest: true, Resource: "configmaps"}
+	createEvent := &authorizer.AttributesRecord{Verb: "create", ResourceRequest: true, Resource: "events"}
+
+	global := policy.NewFakePolicyRuleEvaluator(auditinternal.LevelMetadata, []auditinternal.Stage{auditinternal.StageRequestReceived})
+
+	tests := []struct {
+		name     string
+		global   kaudit.PolicyRuleEvaluator
+		policies []*tenancyv1alpha1.AuditPolicy
+		attrs    authorizer.Attributes
+		want     kaudit.RequestAuditConfig
+	}{
+		{
+			name:  "no policies",
+			attrs: create,
+			want:  kaudit.RequestAuditConfig{Level: auditinternal.LevelNone},
+		},
+		{
+			name:   "global policy only",
+			global: global,
+			attrs:  create,
+			want:   kaudit.RequestAuditConfig{Level: auditinternal.LevelMetadata, OmitStages: []auditinternal.Stage{auditinternal.StageRequestReceived}},
+		},
+		{
+			name:     "workspace policy raises the level",
+			global:   global,
+			policies: []*tenancyv1alpha1.AuditPolicy{writesPolicy},
+			attrs:    create,
+			want:     kaudit.RequestAuditConfig{Level: auditinternal.LevelRequestResponse},
+		},
+		{
+			name:     "workspace policy without global policy",
+			policies: []*tenancyv1alpha1.AuditPolicy{writesPolicy},
+			attrs:    create,
+			want:     kaudit.RequestAuditConfig{Level: auditinternal.LevelRequestResponse},
+		},
+		{
+			name:     "workspace policy not matching",
+			global:   global,
+			policies: []*tenancyv1alpha1.AuditPolicy{writesPolicy},
+			attrs:    get,
+			want:     kaudit.RequestAuditConfig{Level: auditinternal.LevelMetadata, OmitStages: []auditinternal.Stage{auditinternal.StageRequestReceived}},
+		},
+		{
+			name:     "workspace policy excluding the request",
+			policies: []*tenancyv1alpha1.AuditPolicy{writesPolicy},
+			attrs:    createEvent,
+			want:     kaudit.RequestAuditConfig{Level: auditinternal.LevelNone},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &policyRuleEvaluator{
+				global: tt.global,
+				listPolicies: func() []kaudit.PolicyRuleEvaluator {
+					var ret []kaudit.PolicyRuleEvaluator
+					for _, p := range tt.policies {
+						ret = append(ret, NewPolicyRuleEvaluator(p))
+					}
+					return ret
+				},
+			}
+			require.Equal(t, tt.want, e.EvaluatePolicyRule(tt.attrs))
+		})
+	}
+}
+
+func TestBackend(t *testing.T) {
+	newEvent := func(cluster, verb string, stage auditinternal.Stage) *auditinternal.Event {
+		return &auditinternal.Event{
+			Level:          auditinternal.LevelRequestResponse,
+			Stage:          stage,
+			RequestURI:     "/clusters/" + cluster + "/api/v1/namespaces/default/configmaps",
+			Verb:           verb,
+			User:           authenticationv1.UserInfo{Username: "user"},
+			ObjectRef:      &auditinternal.ObjectReference{Resource: "configmaps", Namespace: "default", APIVersion: "v1"},
+			RequestObject:  &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap"}`)},
+			ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap"}`)},
+			Annotations:    map[string]string{WorkspaceAnnotationKey: cluster},
+		}
+	}
+
+	global := &fakeBackend{}
+	workspaces := map[logicalcluster.Name]*fakeBackend{}
+	b := &backend{
+		delegate: global,
+		global:   policy.NewFakePolicyRuleEvaluator(auditinternal.LevelMetadata, []auditinternal.Stage{auditinternal.StageRequestReceived}),
+		getPolicy: func(clusterName logicalcluster.Name) kaudit.PolicyRuleEvaluator {
+			if clusterName == "compliance" {
+				return NewPolicyRuleEvaluator(writesPolicy)
+			}
+			return nil
+		},
+		newWorkspaceBackend: func(clusterName logicalcluster.Name) (kaudit.Backend, error) {
+			workspaces[clusterName] = &fakeBackend{}
+			return workspaces[clusterName], nil
+		},
+		workspaceBackends: map[logicalcluster.Name]kaudit.Backend{},
+	}
+
+	require.True(t, b.ProcessEvents(
+		newEvent("compliance", "create", auditinternal.StageRequestReceived),
+		newEvent("compliance", "create", auditinternal.StageResponseComplete),
+		newEvent("compliance", "get", auditinternal.StageResponseComplete),
+		newEvent("other", "create", auditinternal.StageResponseComplete),
+	))
+
+	require.Len(t, global.events, 3, "the global policy omits the RequestReceived stage")
+	for _, ev := range global.events {
+		require.Equal(t, auditinternal.LevelMetadata, ev.Level)
+		require.Nil(t, ev.RequestObject)
+		require.Nil(t, ev.ResponseObject)
+	}
+
+	require.Len(t, workspaces, 1)
+	require.Len(t, workspaces["compliance"].events, 2, "only the writes are logged for the workspace")
+	for _, ev := range workspaces["compliance"].events {
+		require.Equal(t, "create", ev.Verb)
+		require.Equal(t, auditinternal.LevelRequestResponse, ev.Level)
+		require.NotNil(t, ev.RequestObject)
+		require.NotNil(t, ev.ResponseObject)
+	}
+}
+
+func TestAttributesFrom(t *testing.T) {
+	attrs := attributesFrom(&auditinternal.Event{
+		Verb:       "get",
+		RequestURI: "/clusters/abc/healthz?verbose=true",
+		User:       authenticationv1.UserInfo{Username: "user", Groups: []string{"group"}},
+	})
+	require.False(t, attrs.IsResourceRequest())
+	require.Equal(t, "/healthz", attrs.GetPath())
+	require.Equal(t, "user", attrs.GetUser().GetName())
+	require.Equal(t, []string{"group"}, attrs.GetUser().GetGroups())
+
+	attrs = attributesFrom(&auditinternal.Event{
+		Verb:       "create",
+		RequestURI: "/clusters/abc/apis/apps/v1/namespaces/default/deployments",
+		ObjectRef:  &auditinternal.ObjectReference{APIGroup: "apps", APIVersion: "v1", Resource: "deployments", Namespace: "default"},
+	})
+	require.True(t, attrs.IsResourceRequest())
+	require.Equal(t, "apps", attrs.GetAPIGroup())
+	require.Equal(t, "deployments", attrs.GetResource())
+	require.Equal(t, "default", attrs.GetNamespace())
+}
+
+func TestWithoutManagedFields(t *testing.T) {
+	got := withoutManagedFields(&runtime.Unknown{Raw: []byte(`{"metadata":{"name":"foo","managedFields":[{}]},"items":[{"metadata":{"managedFields":[{}]}}]}`)})
+	require.JSONEq(t, `{"metadata":{"name":"foo"},"items":[{"metadata":{}}]}`, string(got.Raw))
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/audit/policy"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// policyRuleEvaluator evaluates the global audit policy together with the audit policies
+// of all workspaces. The workspace of a request is not known at this point, hence the
+// highest level of all policies applies, and the backend drops what was not asked for
+// per workspace.
+type policyRuleEvaluator struct {
+	global kaudit.PolicyRuleEvaluator
+
+	listPolicies func() []kaudit.PolicyRuleEvaluator
+}
+
+func (e *policyRuleEvaluator) EvaluatePolicyRule(attrs authorizer.Attributes) kaudit.RequestAuditConfig {
+	rac := evaluate(e.global, attrs)
+	for _, p := range e.listPolicies() {
+		workspaceRac := p.EvaluatePolicyRule(attrs)
+		if workspaceRac.Level == auditinternal.LevelNone {
+			continue
+		}
+		// workspace policies do not omit anything. The backend omits stages
+		// and managed fields per policy.
+		level := rac.Level
+		if level.Less(workspaceRac.Level) {
+			level = workspaceRac.Level
+		}
+		rac = kaudit.RequestAuditConfig{Level: level}
+	}
+	return rac
+}
+
+func evaluate(evaluator kaudit.PolicyRuleEvaluator, attrs authorizer.Attributes) kaudit.RequestAuditConfig {
+	if evaluator == nil {
+		return kaudit.RequestAuditConfig{Level: auditinternal.LevelNone}
+	}
+	return evaluator.EvaluatePolicyRule(attrs)
+}
+
+// NewPolicyRuleEvaluator returns the evaluator of the given workspace audit policy.
+func NewPolicyRuleEvaluator(p *tenancyv1alpha1.AuditPolicy) kaudit.PolicyRuleEvaluator {
+	internal := &auditinternal.Policy{}
+	for _, r := range p.Rules {
+		rule := auditinternal.PolicyRule{
+			Level:           auditinternal.Level(r.Level),
+			Users:           r.Users,
+			UserGroups:      r.UserGroups,
+			Verbs:           r.Verbs,
+			Namespaces:      r.Namespaces,
+			NonResourceURLs: r.NonResourceURLs,
+		}
+		for _, gr := range r.Resources {
+			rule.Resources = append(rule.Resources, auditinternal.GroupResources{
+				Group:         gr.Group,
+				Resources:     gr.Resources,
+				ResourceNames: gr.ResourceNames,
+			})
+		}
+		internal.Rules = append(internal.Rules, rule)
+	}
+	return policy.NewPolicyRuleEvaluator(internal)
+}
+
+// attributesFrom reconstructs the attributes the audit policy was evaluated with from the event.
+func attributesFrom(ev *auditinternal.Event) authorizer.Attributes {
+	attrs := &authorizer.AttributesRecord{
+		User: &user.DefaultInfo{
+			Name:   ev.User.Username,
+			UID:    ev.User.UID,
+			Groups: ev.User.Groups,
+		},
+		Verb: ev.Verb,
+	}
+	if ref := ev.ObjectRef; ref != nil {
+		attrs.ResourceRequest = true
+		attrs.Namespace = ref.Namespace
+		attrs.Name = ref.Name
+		attrs.APIGroup = ref.APIGroup
+		attrs.APIVersion = ref.APIVersion
+		attrs.Resource = ref.Resource
+		attrs.Subresource = ref.Subresource
+		return attrs
+	}
+
+	path, _, _ := strings.Cut(ev.RequestURI, "?")
+	if rest, found := strings.CutPrefix(path, "/clusters/"); found {
+		_, path, _ = strings.Cut(rest, "/")
+		path = "/" + path
+	}
+	attrs.Path = path
+	return attrs
+}
+
+// filterEvent returns the event as it would have been recorded with the given audit config,
+// or nil if it would not have been recorded.
+func filterEvent(ev *auditinternal.Event, rac kaudit.RequestAuditConfig) *auditinternal.Event {
+	if rac.Level == auditinternal.LevelNone {
+		return nil
+	}
+	for _, stage := range rac.OmitStages {
+		if ev.Stage == stage {
+			return nil
+		}
+	}
+	if rac.Level.GreaterOrEqual(ev.Level) && !rac.OmitManagedFields {
+		return ev
+	}
+
+	ev = ev.DeepCopy()
+	if rac.Level.Less(ev.Level) {
+		ev.Level = rac.Level
+	}
+	if ev.Level.Less(auditinternal.LevelRequest) {
+		ev.RequestObject = nil
+	}
+	if ev.Level.Less(auditinternal.LevelRequestResponse) {
+		ev.ResponseObject = nil
+	}
+	if rac.OmitManagedFields {
+		ev.RequestObject = withoutManagedFields(ev.RequestObject)
+		ev.ResponseObject = withoutManagedFields(ev.ResponseObject)
+	}
+	return ev
+}
+
+// withoutManagedFields removes the managed fields from the JSON encoded object or list.
+func withoutManagedFields(obj *runtime.Unknown) *runtime.Unknown {
+	if obj == nil || (obj.ContentType != "" && obj.ContentType != runtime.ContentTypeJSON) {
+		return obj
+	}
+	var u map[string]interface{}
+	if err := json.Unmarshal(obj.Raw, &u); err != nil {
+		return obj
+	}
+	unstructured.RemoveNestedField(u, "metadata", "managedFields")
+	if items, ok := u["items"].([]interface{}); ok {
+		for _, item := range items {
+			if item, ok := item.(map[string]interface{}); ok {
+				unstructured.RemoveNestedField(item, "metadata", "managedFields")
+			}
+		}
+	}
+	bs, err := json.Marshal(u)
+	if err != nil {
+		return obj
+	}
+	return &runtime.Unknown{Raw: bs, ContentType: obj.ContentType}
+}
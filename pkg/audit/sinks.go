@@ -0,0 +1,346 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/util/webhook"
+	pluginbuffered "k8s.io/apiserver/plugin/pkg/audit/buffered"
+	pluginwebhook "k8s.io/apiserver/plugin/pkg/audit/webhook"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// defaultSinkPolicy records all requests at the Metadata level, for sinks without a policy.
+var defaultSinkPolicy = &tenancyv1alpha1.AuditPolicy{
+	Rules: []tenancyv1alpha1.AuditPolicyRule{{Level: tenancyv1alpha1.AuditLevelMetadata}},
+}
+
+// webhookSinkBatchConfig batches the events sent to webhook sinks, like the audit webhook
+// of the server does by default.
+var webhookSinkBatchConfig = pluginbuffered.BatchConfig{
+	BufferSize:     10000,
+	MaxBatchSize:   400,
+	MaxBatchWait:   30 * time.Second,
+	ThrottleEnable: true,
+	ThrottleQPS:    10,
+	ThrottleBurst:  15,
+	AsyncDelegate:  true,
+}
+
+// WithOrganizationSinks wraps the audit policy evaluator and backend of the server, both of
+// which may be nil, to additionally send the events of requests to an organization and its
+// workspaces to the AuditSinks of the organization. File sinks are written below
+// dir/<logical cluster of the organization>, and are ignored if dir is empty. Webhook sinks
+// are ignored unless webhooks is true.
+func WithOrganizationSinks(evaluator kaudit.PolicyRuleEvaluator, delegate kaudit.Backend, dir string, webhooks bool, local, global kcpinformers.SharedInformerFactory) (kaudit.PolicyRuleEvaluator, kaudit.Backend) {
+	logicalClusterIndexer := local.Core().V1alpha1().LogicalClusters().Informer().GetIndexer()
+	sinkInformer := global.Tenancy().V1alpha1().AuditSinks().Informer()
+	sinkLister := global.Tenancy().V1alpha1().AuditSinks().Lister()
+
+	indexers.AddIfNotPresentOrDie(sinkInformer.GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+	})
+
+	enabled := func(sink *tenancyv1alpha1.AuditSink) bool {
+		return (sink.Spec.File != nil && dir != "") || (sink.Spec.Webhook != nil && webhooks)
+	}
+	s := &organizationSinks{
+		delegate: delegate,
+		global:   evaluator,
+		getOrganization: func(clusterName logicalcluster.Name) logicalcluster.Path {
+			obj, found, err := logicalClusterIndexer.GetByKey(kcpcache.ToClusterAwareKey(clusterName.String(), "", corev1alpha1.LogicalClusterName))
+			if err != nil {
+				utilruntime.HandleError(err)
+				return logicalcluster.Path{}
+			} else if !found {
+				return logicalcluster.Path{}
+			}
+			return organizationOf(logicalcluster.NewPath(obj.(*corev1alpha1.LogicalCluster).Annotations[core.LogicalClusterPathAnnotationKey]))
+		},
+		listSinks: func() []*tenancyv1alpha1.AuditSink {
+			sinks, err := sinkLister.List(labels.Everything())
+			if err != nil {
+				utilruntime.HandleError(err)
+			}
+			return filterSinks(sinks, func(sink *tenancyv1alpha1.AuditSink) bool {
+				return enabled(sink) && !organizationOf(logicalcluster.NewPath(sink.Annotations[core.LogicalClusterPathAnnotationKey])).Empty()
+			})
+		},
+		listOrganizationSinks: func(org logicalcluster.Path) []*tenancyv1alpha1.AuditSink {
+			sinks, err := indexers.ByIndex[*tenancyv1alpha1.AuditSink](sinkInformer.GetIndexer(), indexers.ByLogicalClusterPath, org.String())
+			if err != nil {
+				utilruntime.HandleError(err)
+			}
+			return filterSinks(sinks, enabled)
+		},
+		newSinkBackend: func(sink *tenancyv1alpha1.AuditSink) (kaudit.Backend, error) {
+			if sink.Spec.File != nil {
+				return newFileSinkBackend(dir, sink)
+			}
+			return newWebhookSinkBackend(sink)
+		},
+		evaluators: map[string]cachedEvaluator{},
+		backends:   map[string]*runningSink{},
+	}
+	_, _ = sinkInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if sink, ok := obj.(*tenancyv1alpha1.AuditSink); ok {
+				s.forget(sinkKey(sink))
+			}
+		},
+	})
+
+	return &policyRuleEvaluator{
+		global:       evaluator,
+		listPolicies: s.listEvaluators,
+	}, s
+}
+
+// organizationSinks sends the events of the global audit policy to the global backend, and
+// the events of requests to an organization and its workspaces to the sinks of the organization,
+// filtered by the policy of each sink. Sinks are run until they change or are deleted.
+type organizationSinks struct {
+	delegate kaudit.Backend
+	global   kaudit.PolicyRuleEvaluator
+
+	// getOrganization returns the path of the organization of the logical cluster, or an
+	// empty path if it is not in an organization.
+	getOrganization       func(clusterName logicalcluster.Name) logicalcluster.Path
+	listSinks             func() []*tenancyv1alpha1.AuditSink
+	listOrganizationSinks func(org logicalcluster.Path) []*tenancyv1alpha1.AuditSink
+	newSinkBackend        func(sink *tenancyv1alpha1.AuditSink) (kaudit.Backend, error)
+
+	lock       sync.RWMutex
+	evaluators map[string]cachedEvaluator
+	backends   map[string]*runningSink
+}
+
+var _ kaudit.Backend = &organizationSinks{}
+
+// runningSink is the backend of one version of a sink. It runs until stop is closed.
+type runningSink struct {
+	version string
+	backend kaudit.Backend
+	stop    chan struct{}
+}
+
+func (r *runningSink) shutdown() {
+	close(r.stop)
+	r.backend.Shutdown()
+}
+
+// listEvaluators returns the evaluators of the policies of all sinks.
+func (s *organizationSinks) listEvaluators() []kaudit.PolicyRuleEvaluator {
+	sinks := s.listSinks()
+	ret := make([]kaudit.PolicyRuleEvaluator, 0, len(sinks))
+	for _, sink := range sinks {
+		ret = append(ret, s.evaluator(sink))
+	}
+	return ret
+}
+
+func (s *organizationSinks) evaluator(sink *tenancyv1alpha1.AuditSink) kaudit.PolicyRuleEvaluator {
+	key := sinkKey(sink)
+	s.lock.RLock()
+	c, found := s.evaluators[key]
+	s.lock.RUnlock()
+	if found && c.version == sink.ResourceVersion {
+		return c.evaluator
+	}
+
+	p := sink.Spec.Policy
+	if p == nil {
+		p = defaultSinkPolicy
+	}
+	c = cachedEvaluator{version: sink.ResourceVersion, evaluator: NewPolicyRuleEvaluator(p)}
+	s.lock.Lock()
+	s.evaluators[key] = c
+	s.lock.Unlock()
+	return c.evaluator
+}
+
+func (s *organizationSinks) ProcessEvents(events ...*auditinternal.Event) bool {
+	success := true
+	global := make([]*auditinternal.Event, 0, len(events))
+	for _, ev := range events {
+		attrs := attributesFrom(ev)
+		if filtered := filterEvent(ev, evaluate(s.global, attrs)); filtered != nil {
+			global = append(global, filtered)
+		}
+
+		clusterName := logicalcluster.Name(ev.Annotations[WorkspaceAnnotationKey])
+		if clusterName.Empty() {
+			continue
+		}
+		org := s.getOrganization(clusterName)
+		if org.Empty() {
+			continue
+		}
+		for _, sink := range s.listOrganizationSinks(org) {
+			filtered := filterEvent(ev, s.evaluator(sink).EvaluatePolicyRule(attrs))
+			if filtered == nil {
+				continue
+			}
+			sinkBackend, err := s.sinkBackend(sink)
+			if err != nil {
+				utilruntime.HandleError(err)
+				success = false
+				continue
+			}
+			success = sinkBackend.ProcessEvents(filtered) && success
+		}
+	}
+
+	if s.delegate != nil && len(global) > 0 {
+		success = s.delegate.ProcessEvents(global...) && success
+	}
+	return success
+}
+
+// sinkBackend returns the running backend of the current version of the sink, replacing
+// the backend of a previous version.
+func (s *organizationSinks) sinkBackend(sink *tenancyv1alpha1.AuditSink) (kaudit.Backend, error) {
+	key := sinkKey(sink)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	previous, found := s.backends[key]
+	if found && previous.version == sink.ResourceVersion {
+		return previous.backend, nil
+	}
+	if found {
+		delete(s.backends, key)
+		go previous.shutdown() // flushing must not block the request
+	}
+
+	sinkBackend, err := s.newSinkBackend(sink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backend of AuditSink %s: %w", key, err)
+	}
+	running := &runningSink{version: sink.ResourceVersion, backend: sinkBackend, stop: make(chan struct{})}
+	if err := sinkBackend.Run(running.stop); err != nil {
+		return nil, fmt.Errorf("failed to run backend of AuditSink %s: %w", key, err)
+	}
+	s.backends[key] = running
+	return sinkBackend, nil
+}
+
+// forget drops the evaluator of the sink and shuts down its backend.
+func (s *organizationSinks) forget(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.evaluators, key)
+	if running, found := s.backends[key]; found {
+		delete(s.backends, key)
+		go running.shutdown()
+	}
+}
+
+func (s *organizationSinks) Run(stopCh <-chan struct{}) error {
+	if s.delegate != nil {
+		return s.delegate.Run(stopCh)
+	}
+	return nil
+}
+
+func (s *organizationSinks) Shutdown() {
+	if s.delegate != nil {
+		s.delegate.Shutdown()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, running := range s.backends {
+		running.shutdown()
+		delete(s.backends, key)
+	}
+}
+
+func (s *organizationSinks) String() string {
+	if s.delegate != nil {
+		return fmt.Sprintf("organizations<%s>", s.delegate)
+	}
+	return "organizations"
+}
+
+func newFileSinkBackend(dir string, sink *tenancyv1alpha1.AuditSink) (kaudit.Backend, error) {
+	if !filepath.IsLocal(sink.Spec.File.Path) {
+		return nil, fmt.Errorf("path %q is not local", sink.Spec.File.Path)
+	}
+	return openFileBackend(filepath.Join(dir, logicalcluster.From(sink).String(), sink.Spec.File.Path))
+}
+
+func newWebhookSinkBackend(sink *tenancyv1alpha1.AuditSink) (kaudit.Backend, error) {
+	backoff := webhook.DefaultRetryBackoffWithInitialDelay(pluginwebhook.DefaultInitialBackoffDelay)
+	config := &rest.Config{
+		Host:            sink.Spec.Webhook.URL,
+		TLSClientConfig: rest.TLSClientConfig{CAData: sink.Spec.Webhook.CABundle},
+	}
+	w, err := webhook.NewGenericWebhook(kaudit.Scheme, kaudit.Codecs, config, []schema.GroupVersion{auditv1.SchemeGroupVersion}, backoff)
+	if err != nil {
+		return nil, err
+	}
+	return pluginbuffered.NewBackend(pluginwebhook.NewDynamicBackend(w.RestClient, backoff), webhookSinkBatchConfig), nil
+}
+
+// organizationOf returns the path of the top-level organization workspace of the given path,
+// e.g. root:org for root:org:team, or an empty path for the root workspace.
+func organizationOf(path logicalcluster.Path) logicalcluster.Path {
+	segments := strings.SplitN(path.String(), ":", 3)
+	if len(segments) < 2 || segments[0] != core.RootCluster.String() {
+		return logicalcluster.Path{}
+	}
+	return logicalcluster.NewPath(segments[0] + ":" + segments[1])
+}
+
+func filterSinks(sinks []*tenancyv1alpha1.AuditSink, include func(*tenancyv1alpha1.AuditSink) bool) []*tenancyv1alpha1.AuditSink {
+	ret := sinks[:0]
+	for _, sink := range sinks {
+		if include(sink) {
+			ret = append(ret, sink)
+		}
+	}
+	return ret
+}
+
+func sinkKey(sink *tenancyv1alpha1.AuditSink) string {
+	return logicalcluster.From(sink).String() + "|" + sink.Name
+}
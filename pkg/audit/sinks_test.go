@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/audit/policy"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestOrganizationSinks(t *testing.T) {
+	newEvent := func(cluster, verb string) *auditinternal.Event {
+		return &auditinternal.Event{
+			Level:          auditinternal.LevelRequestResponse,
+			Stage:          auditinternal.StageResponseComplete,
+			RequestURI:     "/clusters/" + cluster + "/api/v1/namespaces/default/configmaps",
+			Verb:           verb,
+			User:           authenticationv1.UserInfo{Username: "user"},
+			ObjectRef:      &auditinternal.ObjectReference{Resource: "configmaps", Namespace: "default", APIVersion: "v1"},
+			RequestObject:  &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap"}`)},
+			ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap"}`)},
+			Annotations:    map[string]string{WorkspaceAnnotationKey: cluster},
+		}
+	}
+	newSink := func(cluster, name, version string, p *tenancyv1alpha1.AuditPolicy) *tenancyv1alpha1.AuditSink {
+		return &tenancyv1alpha1.AuditSink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				ResourceVersion: version,
+				Annotations:     map[string]string{logicalcluster.AnnotationKey: cluster},
+			},
+			Spec: tenancyv1alpha1.AuditSinkSpec{Policy: p},
+		}
+	}
+
+	sinks := map[logicalcluster.Path][]*tenancyv1alpha1.AuditSink{
+		logicalcluster.NewPath("root:acme"): {
+			newSink("acme", "all", "1", nil),
+			newSink("acme", "writes", "1", writesPolicy),
+		},
+		logicalcluster.NewPath("root:other"): {
+			newSink("other", "all", "1", nil),
+		},
+	}
+	organizations := map[logicalcluster.Name]logicalcluster.Path{
+		"acme":      logicalcluster.NewPath("root:acme"),
+		"acme-team": logicalcluster.NewPath("root:acme"),
+		"other":     logicalcluster.NewPath("root:other"),
+	}
+
+	global := &fakeBackend{}
+	backends := map[string]*fakeBackend{}
+	created := 0
+	s := &organizationSinks{
+		delegate: global,
+		global:   policy.NewFakePolicyRuleEvaluator(auditinternal.LevelMetadata, nil),
+		getOrganization: func(clusterName logicalcluster.Name) logicalcluster.Path {
+			return organizations[clusterName]
+		},
+		listOrganizationSinks: func(org logicalcluster.Path) []*tenancyv1alpha1.AuditSink {
+			return sinks[org]
+		},
+		newSinkBackend: func(sink *tenancyv1alpha1.AuditSink) (kaudit.Backend, error) {
+			created++
+			backends[sinkKey(sink)] = &fakeBackend{}
+			return backends[sinkKey(sink)], nil
+		},
+		evaluators: map[string]cachedEvaluator{},
+		backends:   map[string]*runningSink{},
+	}
+
+	require.True(t, s.ProcessEvents(
+		newEvent("acme", "create"),
+		newEvent("acme-team", "get"),
+		newEvent("other", "create"),
+		newEvent("root", "create"),
+	))
+
+	require.Len(t, global.events, 4)
+	require.Len(t, backends, 3)
+
+	acmeAll := backends["acme|all"].events
+	require.Len(t, acmeAll, 2, "all requests to the organization and its workspaces are recorded")
+	for _, ev := range acmeAll {
+		require.Equal(t, auditinternal.LevelMetadata, ev.Level, "sinks without a policy record at the Metadata level")
+		require.Nil(t, ev.RequestObject)
+	}
+
+	acmeWrites := backends["acme|writes"].events
+	require.Len(t, acmeWrites, 1, "only the writes are recorded")
+	require.Equal(t, "create", acmeWrites[0].Verb)
+	require.Equal(t, auditinternal.LevelRequestResponse, acmeWrites[0].Level)
+
+	require.Len(t, backends["other|all"].events, 1, "organizations only receive their own events")
+	require.Equal(t, "other", backends["other|all"].events[0].Annotations[WorkspaceAnnotationKey])
+
+	require.True(t, s.ProcessEvents(newEvent("acme", "create")))
+	require.Equal(t, 3, created, "backends are reused")
+
+	sinks[logicalcluster.NewPath("root:acme")][0] = newSink("acme", "all", "2", nil)
+	require.True(t, s.ProcessEvents(newEvent("acme", "create")))
+	require.Equal(t, 4, created, "the backend is replaced when the sink changes")
+	require.Len(t, backends["acme|all"].events, 1)
+}
+
+func TestOrganizationOf(t *testing.T) {
+	for path, want := range map[string]string{
+		"":                  "",
+		"root":              "",
+		"root:acme":         "root:acme",
+		"root:acme:team":    "root:acme",
+		"root:acme:team:ws": "root:acme",
+		"system:admin":      "",
+	} {
+		require.Equal(t, want, organizationOf(logicalcluster.NewPath(path)).String(), "path %q", path)
+	}
+}
+
+func TestNewFileSinkBackend(t *testing.T) {
+	dir := t.TempDir()
+	sink := &tenancyv1alpha1.AuditSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "file",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "acme"},
+		},
+		Spec: tenancyv1alpha1.AuditSinkSpec{File: &tenancyv1alpha1.AuditSinkFile{Path: "../escape.log"}},
+	}
+	_, err := newFileSinkBackend(dir, sink)
+	require.Error(t, err)
+
+	sink.Spec.File.Path = "audit/acme.log"
+	b, err := newFileSinkBackend(dir, sink)
+	require.NoError(t, err)
+	b.Shutdown()
+	require.FileExists(t, dir+"/acme/audit/acme.log")
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// WorkspaceAnnotationKey is the annotation of audit events holding the logical cluster
+// of the request.
+const WorkspaceAnnotationKey = "tenancy.kcp.io/workspace"
+
+const byAuditPolicy = "kcp-audit-byAuditPolicy"
+
+// WithWorkspacePolicies wraps the audit policy evaluator and backend of the server, both of
+// which may be nil, to additionally log requests to workspaces with an audit policy, or of a
+// type with one, to a log file per logical cluster in dir.
+func WithWorkspacePolicies(evaluator kaudit.PolicyRuleEvaluator, delegate kaudit.Backend, dir string, local, global kcpinformers.SharedInformerFactory) (kaudit.PolicyRuleEvaluator, kaudit.Backend) {
+	logicalClusterInformer := local.Core().V1alpha1().LogicalClusters().Informer()
+	typeInformer := global.Tenancy().V1alpha1().WorkspaceTypes().Informer()
+
+	indexers.AddIfNotPresentOrDie(logicalClusterInformer.GetIndexer(), cache.Indexers{
+		byAuditPolicy: indexLogicalClusterByAuditPolicy,
+	})
+	indexers.AddIfNotPresentOrDie(typeInformer.GetIndexer(), cache.Indexers{
+		byAuditPolicy:                        indexWorkspaceTypeByAuditPolicy,
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	policies := &workspacePolicies{
+		logicalClusterIndexer: logicalClusterInformer.GetIndexer(),
+		typeIndexer:           typeInformer.GetIndexer(),
+		evaluators:            map[string]cachedEvaluator{},
+	}
+	_, _ = logicalClusterInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if logicalCluster, ok := obj.(*corev1alpha1.LogicalCluster); ok {
+				policies.forget(logicalClusterKey(logicalcluster.From(logicalCluster)), combinedKey(logicalcluster.From(logicalCluster)))
+			}
+		},
+	})
+
+	return &policyRuleEvaluator{
+		global:       evaluator,
+		listPolicies: policies.list,
+	}, &backend{
+		delegate:  delegate,
+		global:    evaluator,
+		getPolicy: policies.get,
+		newWorkspaceBackend: func(clusterName logicalcluster.Name) (kaudit.Backend, error) {
+			return newFileBackend(dir, clusterName)
+		},
+		workspaceBackends: map[logicalcluster.Name]kaudit.Backend{},
+	}
+}
+
+// workspacePolicies provides the evaluators of the audit policies of logical clusters and of
+// workspace types. Evaluators are cached until the object changes.
+type workspacePolicies struct {
+	logicalClusterIndexer cache.Indexer
+	typeIndexer           cache.Indexer
+
+	lock       sync.RWMutex
+	evaluators map[string]cachedEvaluator
+}
+
+type cachedEvaluator struct {
+	version   string
+	evaluator kaudit.PolicyRuleEvaluator
+}
+
+// list returns the evaluators of all audit policies on this shard.
+func (p *workspacePolicies) list() []kaudit.PolicyRuleEvaluator {
+	var ret []kaudit.PolicyRuleEvaluator
+	logicalClusters, err := p.logicalClusterIndexer.ByIndex(byAuditPolicy, "true")
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+	for _, obj := range logicalClusters {
+		logicalCluster := obj.(*corev1alpha1.LogicalCluster)
+		if evaluator := p.cached(logicalClusterKey(logicalcluster.From(logicalCluster)), logicalCluster.ResourceVersion, func() kaudit.PolicyRuleEvaluator {
+			return evaluatorFor(logicalClusterPolicy(logicalCluster))
+		}); evaluator != nil {
+			ret = append(ret, evaluator)
+		}
+	}
+	types, err := p.typeIndexer.ByIndex(byAuditPolicy, "true")
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+	for _, obj := range types {
+		wt := obj.(*tenancyv1alpha1.WorkspaceType)
+		if evaluator := p.cached(typeKey(wt), wt.ResourceVersion, func() kaudit.PolicyRuleEvaluator {
+			return evaluatorFor(wt.Spec.AuditPolicy)
+		}); evaluator != nil {
+			ret = append(ret, evaluator)
+		}
+	}
+	return ret
+}
+
+// get returns the evaluator of the rules of the audit policy of the given logical cluster,
+// followed by the rules of the audit policy of its type, or nil if there are none.
+func (p *workspacePolicies) get(clusterName logicalcluster.Name) kaudit.PolicyRuleEvaluator {
+	obj, found, err := p.logicalClusterIndexer.GetByKey(kcpcache.ToClusterAwareKey(clusterName.String(), "", corev1alpha1.LogicalClusterName))
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	} else if !found {
+		return nil
+	}
+	logicalCluster := obj.(*corev1alpha1.LogicalCluster)
+
+	var wt *tenancyv1alpha1.WorkspaceType
+	if typePath, wtName := logicalcluster.NewPath(logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterTypeAnnotationKey]).Split(); !typePath.Empty() {
+		wt, _ = indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), p.typeIndexer, typePath, wtName)
+	}
+	if wt != nil && wt.Spec.AuditPolicy == nil {
+		wt = nil
+	}
+	if wt == nil && logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterAuditPolicyAnnotationKey] == "" {
+		return nil
+	}
+
+	version := logicalCluster.ResourceVersion
+	if wt != nil {
+		version += "/" + string(wt.UID) + "/" + wt.ResourceVersion
+	}
+	return p.cached(combinedKey(clusterName), version, func() kaudit.PolicyRuleEvaluator {
+		combined := &tenancyv1alpha1.AuditPolicy{}
+		if workspacePolicy := logicalClusterPolicy(logicalCluster); workspacePolicy != nil {
+			combined.Rules = append(combined.Rules, workspacePolicy.Rules...)
+		}
+		if wt != nil {
+			combined.Rules = append(combined.Rules, wt.Spec.AuditPolicy.Rules...)
+		}
+		return evaluatorFor(combined)
+	})
+}
+
+func (p *workspacePolicies) cached(key, version string, build func() kaudit.PolicyRuleEvaluator) kaudit.PolicyRuleEvaluator {
+	p.lock.RLock()
+	c, found := p.evaluators[key]
+	p.lock.RUnlock()
+	if found && c.version == version {
+		return c.evaluator
+	}
+
+	c = cachedEvaluator{version: version, evaluator: build()}
+	p.lock.Lock()
+	p.evaluators[key] = c
+	p.lock.Unlock()
+	return c.evaluator
+}
+
+func (p *workspacePolicies) forget(keys ...string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, key := range keys {
+		delete(p.evaluators, key)
+	}
+}
+
+func evaluatorFor(p *tenancyv1alpha1.AuditPolicy) kaudit.PolicyRuleEvaluator {
+	if p == nil || len(p.Rules) == 0 {
+		return nil
+	}
+	return NewPolicyRuleEvaluator(p)
+}
+
+// logicalClusterPolicy returns the audit policy of the workspace of the logical cluster, or nil
+// if there is none or it cannot be decoded.
+func logicalClusterPolicy(logicalCluster *corev1alpha1.LogicalCluster) *tenancyv1alpha1.AuditPolicy {
+	value, found := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterAuditPolicyAnnotationKey]
+	if !found {
+		return nil
+	}
+	var p tenancyv1alpha1.AuditPolicy
+	if err := json.Unmarshal([]byte(value), &p); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to decode audit policy of logical cluster %q: %w", logicalcluster.From(logicalCluster), err))
+		return nil
+	}
+	return &p
+}
+
+func logicalClusterKey(clusterName logicalcluster.Name) string {
+	return "logicalcluster/" + clusterName.String()
+}
+
+func combinedKey(clusterName logicalcluster.Name) string {
+	return "combined/" + clusterName.String()
+}
+
+func typeKey(wt *tenancyv1alpha1.WorkspaceType) string {
+	return "workspacetype/" + string(wt.UID)
+}
+
+func indexLogicalClusterByAuditPolicy(obj interface{}) ([]string, error) {
+	logicalCluster, ok := obj.(*corev1alpha1.LogicalCluster)
+	if !ok {
+		return nil, fmt.Errorf("obj is supposed to be a LogicalCluster, but is %T", obj)
+	}
+	if _, found := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterAuditPolicyAnnotationKey]; found {
+		return []string{"true"}, nil
+	}
+	return nil, nil
+}
+
+func indexWorkspaceTypeByAuditPolicy(obj interface{}) ([]string, error) {
+	wt, ok := obj.(*tenancyv1alpha1.WorkspaceType)
+	if !ok {
+		return nil, fmt.Errorf("obj is supposed to be a WorkspaceType, but is %T", obj)
+	}
+	if wt.Spec.AuditPolicy != nil {
+		return []string{"true"}, nil
+	}
+	return nil, nil
+}
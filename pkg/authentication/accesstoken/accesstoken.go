@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/group"
+	"k8s.io/apiserver/pkg/authentication/request/bearertoken"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/kubernetes/pkg/serviceaccount"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+const (
+	// Issuer is the issuer of workspace access tokens.
+	Issuer = "kcp.io/workspace-access-token"
+
+	// WorkspaceExtraKey is the user extra holding the path of the workspace the user
+	// of a workspace access token is restricted to, including its descendants.
+	WorkspaceExtraKey = "authentication.kcp.io/workspace-access-token-workspace"
+	// RulesExtraKey is the user extra holding the JSON encoded rules of a workspace
+	// access token, one per value.
+	RulesExtraKey = "authentication.kcp.io/workspace-access-token-rules"
+)
+
+type privateClaims struct {
+	Kcp kcpClaims `json:"kcp.io"`
+}
+
+type kcpClaims struct {
+	UID       string                                     `json:"uid,omitempty"`
+	Groups    []string                                   `json:"groups,omitempty"`
+	Extra     map[string][]string                        `json:"extra,omitempty"`
+	Workspace string                                     `json:"workspace"`
+	Rules     []tenancyv1alpha1.WorkspaceAccessTokenRule `json:"rules"`
+}
+
+// Generator mints workspace access tokens.
+type Generator struct {
+	generator serviceaccount.TokenGenerator
+	now       func() time.Time
+}
+
+// NewGenerator returns a generator signing tokens with the private key in the given file.
+func NewGenerator(signingKeyFile string) (*Generator, error) {
+	key, err := keyutil.PrivateKeyFromFile(signingKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace access token signing key file %q: %w", signingKeyFile, err)
+	}
+	generator, err := serviceaccount.JWTTokenGenerator(Issuer, key)
+	if err != nil {
+		return nil, err
+	}
+	return &Generator{generator: generator, now: time.Now}, nil
+}
+
+// Generate returns a token of the given user restricted to the workspace with the given path,
+// its descendants and the given rules.
+func (g *Generator) Generate(u user.Info, workspace logicalcluster.Path, rules []tenancyv1alpha1.WorkspaceAccessTokenRule, expiration time.Duration) (string, time.Time, error) {
+	if IsRestricted(u) {
+		return "", time.Time{}, errors.New("workspace access tokens cannot be created with workspace access tokens")
+	}
+
+	now := g.now()
+	expiry := now.Add(expiration)
+	token, err := g.generator.GenerateToken(&jwt.Claims{
+		Subject:   u.GetName(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(expiry),
+	}, &privateClaims{Kcp: kcpClaims{
+		UID:       u.GetUID(),
+		Groups:    u.GetGroups(),
+		Extra:     u.GetExtra(),
+		Workspace: workspace.String(),
+		Rules:     rules,
+	}})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiry, nil
+}
+
+// NewAuthenticator returns an authenticator of workspace access tokens signed by any of the
+// keys in the given files. The authenticated user is the one who created the token, with the
+// restrictions of the token in its extras.
+func NewAuthenticator(keyFiles []string) (authenticator.Request, error) {
+	var keys []interface{}
+	for _, keyFile := range keyFiles {
+		publicKeys, err := keyutil.PublicKeysFromFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workspace access token key file %q: %w", keyFile, err)
+		}
+		keys = append(keys, publicKeys...)
+	}
+	return group.NewAuthenticatedGroupAdder(bearertoken.New(&tokenAuthenticator{keys: keys, now: time.Now})), nil
+}
+
+type tokenAuthenticator struct {
+	keys []interface{}
+	now  func() time.Time
+}
+
+func (a *tokenAuthenticator) AuthenticateToken(ctx context.Context, tokenData string) (*authenticator.Response, bool, error) {
+	tok, err := jwt.ParseSigned(tokenData)
+	if err != nil {
+		return nil, false, nil // not a JWT
+	}
+	public := &jwt.Claims{}
+	if err := tok.UnsafeClaimsWithoutVerification(public); err != nil || public.Issuer != Issuer {
+		return nil, false, nil // some other JWT, e.g. of a service account
+	}
+
+	private := &privateClaims{}
+	verified := false
+	for _, key := range a.keys {
+		if err := tok.Claims(key, public, private); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, false, errors.New("workspace access token signature is invalid")
+	}
+	if err := public.ValidateWithLeeway(jwt.Expected{Issuer: Issuer, Time: a.now()}, 0); err != nil {
+		return nil, false, fmt.Errorf("workspace access token is invalid: %w", err)
+	}
+	if private.Kcp.Workspace == "" || len(private.Kcp.Rules) == 0 {
+		return nil, false, errors.New("workspace access token has no restrictions")
+	}
+
+	extra := map[string][]string{}
+	for k, v := range private.Kcp.Extra {
+		extra[k] = v
+	}
+	extra[WorkspaceExtraKey] = []string{private.Kcp.Workspace}
+	for _, rule := range private.Kcp.Rules {
+		bs, err := json.Marshal(rule)
+		if err != nil {
+			return nil, false, err
+		}
+		extra[RulesExtraKey] = append(extra[RulesExtraKey], string(bs))
+	}
+
+	return &authenticator.Response{
+		User: &user.DefaultInfo{
+			Name:   public.Subject,
+			UID:    private.Kcp.UID,
+			Groups: private.Kcp.Groups,
+			Extra:  extra,
+		},
+	}, true, nil
+}
+
+// IsRestricted returns whether the user authenticated with a workspace access token.
+func IsRestricted(u user.Info) bool {
+	_, found := u.GetExtra()[WorkspaceExtraKey]
+	return found
+}
+
+// Restrictions returns the workspace paths and the rules the user is restricted to.
+func Restrictions(u user.Info) (workspaces []logicalcluster.Path, rules []rbacv1.PolicyRule, err error) {
+	for _, workspace := range u.GetExtra()[WorkspaceExtraKey] {
+		workspaces = append(workspaces, logicalcluster.NewPath(workspace))
+	}
+	for _, value := range u.GetExtra()[RulesExtraKey] {
+		var rule tenancyv1alpha1.WorkspaceAccessTokenRule
+		if err := json.Unmarshal([]byte(value), &rule); err != nil {
+			return nil, nil, fmt.Errorf("invalid workspace access token rule %q: %w", value, err)
+		}
+		rules = append(rules, rbacv1.PolicyRule{
+			Verbs:           rule.Verbs,
+			APIGroups:       rule.APIGroups,
+			Resources:       rule.Resources,
+			ResourceNames:   rule.ResourceNames,
+			NonResourceURLs: rule.NonResourceURLs,
+		})
+	}
+	return workspaces, rules, nil
+}
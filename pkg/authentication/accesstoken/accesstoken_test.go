@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesstoken
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func writeKey(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+	return path
+}
+
+func authenticate(t *testing.T, keyFile, token string) (user.Info, bool, error) {
+	t.Helper()
+	auth, err := NewAuthenticator([]string{keyFile})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, ok, err := auth.AuthenticateRequest(req)
+	if !ok {
+		return nil, ok, err
+	}
+	return resp.User, ok, err
+}
+
+func TestGenerateAndAuthenticate(t *testing.T) {
+	keyFile := writeKey(t)
+	generator, err := NewGenerator(keyFile)
+	require.NoError(t, err)
+
+	minter := &user.DefaultInfo{
+		Name:   "alice",
+		UID:    "123",
+		Groups: []string{"ci"},
+		Extra:  map[string][]string{"team": {"platform"}},
+	}
+	rules := []tenancyv1alpha1.WorkspaceAccessTokenRule{
+		{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"configmaps"}},
+	}
+	token, expiry, err := generator.Generate(minter, logicalcluster.NewPath("root:org"), rules, time.Hour)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Hour), expiry, time.Minute)
+
+	u, ok, err := authenticate(t, keyFile, token)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "alice", u.GetName())
+	require.Equal(t, "123", u.GetUID())
+	require.ElementsMatch(t, []string{"ci", user.AllAuthenticated}, u.GetGroups())
+	require.Equal(t, []string{"platform"}, u.GetExtra()["team"])
+	require.True(t, IsRestricted(u))
+
+	workspaces, policyRules, err := Restrictions(u)
+	require.NoError(t, err)
+	require.Equal(t, []logicalcluster.Path{logicalcluster.NewPath("root:org")}, workspaces)
+	require.Equal(t, []rbacv1.PolicyRule{{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"configmaps"}}}, policyRules)
+
+	_, _, err = generator.Generate(u, logicalcluster.NewPath("root"), rules, time.Hour)
+	require.Error(t, err, "restricted users must not create tokens")
+}
+
+func TestAuthenticateInvalid(t *testing.T) {
+	keyFile := writeKey(t)
+	generator, err := NewGenerator(keyFile)
+	require.NoError(t, err)
+	rules := []tenancyv1alpha1.WorkspaceAccessTokenRule{{Verbs: []string{"get"}, Resources: []string{"*"}}}
+
+	t.Run("not a JWT", func(t *testing.T) {
+		_, ok, _ := authenticate(t, keyFile, "some-static-token")
+		require.False(t, ok)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		generator.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+		defer func() { generator.now = time.Now }()
+		token, _, err := generator.Generate(&user.DefaultInfo{Name: "alice"}, logicalcluster.NewPath("root"), rules, time.Hour)
+		require.NoError(t, err)
+		_, ok, err := authenticate(t, keyFile, token)
+		require.Error(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		token, _, err := generator.Generate(&user.DefaultInfo{Name: "alice"}, logicalcluster.NewPath("root"), rules, time.Hour)
+		require.NoError(t, err)
+		_, ok, err := authenticate(t, writeKey(t), token)
+		require.Error(t, err)
+		require.False(t, ok)
+	})
+}
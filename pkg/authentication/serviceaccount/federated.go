@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	kcpcorev1client "github.com/kcp-dev/client-go/kubernetes/typed/core/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/group"
+	"k8s.io/apiserver/pkg/authentication/request/bearertoken"
+	tokencache "k8s.io/apiserver/pkg/authentication/token/cache"
+	tokenunion "k8s.io/apiserver/pkg/authentication/token/union"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/kubernetes/pkg/serviceaccount"
+)
+
+// FederatedTokenCacheTTL is the time a successfully authenticated service account token
+// is cached, to avoid looking up the service account on another shard for every request.
+const FederatedTokenCacheTTL = 10 * time.Second
+
+// federatedLookupTimeout bounds the lookup of a service account, secret or pod on another shard.
+// The getter interface of the token validators does not pass the context of the request.
+const federatedLookupTimeout = 5 * time.Second
+
+// ShardClientFunc returns a client for the shard serving the given logical cluster, or for
+// a front-proxy routing to it.
+type ShardClientFunc func(clusterName logicalcluster.Name) (kcpkubernetesclientset.ClusterInterface, error)
+
+// NewFederatedClusterGetter returns a getter looking up service accounts, secrets and pods
+// live on the shard of their logical cluster. Unlike the informer based getter of the shard,
+// this works for service accounts of logical clusters on any shard.
+func NewFederatedClusterGetter(shardClient ShardClientFunc) serviceaccount.ServiceAccountTokenClusterGetter {
+	return &federatedClusterGetter{shardClient: shardClient}
+}
+
+type federatedClusterGetter struct {
+	shardClient ShardClientFunc
+}
+
+func (g *federatedClusterGetter) Cluster(clusterName logicalcluster.Name) serviceaccount.ServiceAccountTokenGetter {
+	return &federatedGetter{clusterName: clusterName, shardClient: g.shardClient}
+}
+
+type federatedGetter struct {
+	clusterName logicalcluster.Name
+	shardClient ShardClientFunc
+}
+
+func (g *federatedGetter) client(resource, name string) (kcpcorev1client.CoreV1ClusterInterface, error) {
+	client, err := g.shardClient(g.clusterName)
+	if err != nil {
+		// unknown logical clusters cannot have valid service accounts
+		return nil, apierrors.NewNotFound(corev1.Resource(resource), name)
+	}
+	return client.CoreV1(), nil
+}
+
+func (g *federatedGetter) GetServiceAccount(namespace, name string) (*corev1.ServiceAccount, error) {
+	client, err := g.client("serviceaccounts", name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), federatedLookupTimeout)
+	defer cancel()
+	return client.ServiceAccounts().Cluster(g.clusterName.Path()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (g *federatedGetter) GetPod(namespace, name string) (*corev1.Pod, error) {
+	client, err := g.client("pods", name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), federatedLookupTimeout)
+	defer cancel()
+	return client.Pods().Cluster(g.clusterName.Path()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (g *federatedGetter) GetSecret(namespace, name string) (*corev1.Secret, error) {
+	client, err := g.client("secrets", name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), federatedLookupTimeout)
+	defer cancel()
+	return client.Secrets().Cluster(g.clusterName.Path()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (g *federatedGetter) GetNode(name string) (*corev1.Node, error) {
+	// kcp has no nodes, hence no tokens can be bound to them.
+	return nil, apierrors.NewNotFound(corev1.Resource("nodes"), name)
+}
+
+// NewFederatedAuthenticator returns an authenticator for legacy and bound service account
+// tokens signed with any of the keys in the given files, looking up the service accounts with
+// the given getter. Together with a federated getter, this authenticates the service accounts
+// of all shards sharing the same signing keys. Successful authentications are cached for
+// FederatedTokenCacheTTL.
+func NewFederatedAuthenticator(issuers, keyFiles []string, apiAudiences authenticator.Audiences, getter serviceaccount.ServiceAccountTokenClusterGetter, secretsWriter kcpcorev1client.SecretClusterInterface) (authenticator.Request, error) {
+	var keys []interface{}
+	for _, keyFile := range keyFiles {
+		publicKeys, err := keyutil.PublicKeysFromFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account key file %q: %w", keyFile, err)
+		}
+		keys = append(keys, publicKeys...)
+	}
+
+	legacyValidator, err := serviceaccount.NewLegacyValidator(true, getter, secretsWriter)
+	if err != nil {
+		return nil, err
+	}
+	tokenAuthenticator := tokenunion.New(
+		serviceaccount.JWTTokenAuthenticator([]string{serviceaccount.LegacyIssuer}, keys, apiAudiences, legacyValidator),
+		serviceaccount.JWTTokenAuthenticator(issuers, keys, apiAudiences, serviceaccount.NewValidator(getter)),
+	)
+	tokenAuthenticator = tokencache.New(tokenAuthenticator, false, FederatedTokenCacheTTL, 0)
+
+	return group.NewAuthenticatedGroupAdder(bearertoken.New(tokenAuthenticator)), nil
+}
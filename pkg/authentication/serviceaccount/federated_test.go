@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	kcpfakekubernetesclientset "github.com/kcp-dev/client-go/kubernetes/fake"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiserverserviceaccount "k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/serviceaccount"
+)
+
+const testIssuer = "https://kcp.default.svc"
+
+func TestFederatedAuthenticator(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "sa.pub")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+	generator, err := serviceaccount.JWTTokenGenerator(testIssuer, key)
+	require.NoError(t, err)
+
+	remote := kcpfakekubernetesclientset.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "controller",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "remote"},
+		},
+	})
+	getter := NewFederatedClusterGetter(func(clusterName logicalcluster.Name) (kcpkubernetesclientset.ClusterInterface, error) {
+		if clusterName != "remote" {
+			return nil, fmt.Errorf("logical cluster %q not found", clusterName)
+		}
+		return remote, nil
+	})
+	authn, err := NewFederatedAuthenticator([]string{testIssuer}, []string{keyFile}, []string{testIssuer}, getter, remote.CoreV1().Secrets())
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		cluster  string
+		name     string
+		uid      string
+		wantUser string
+	}{
+		"service account on another shard": {cluster: "remote", name: "controller", uid: "uid", wantUser: "system:serviceaccount:default:controller"},
+		"deleted service account":          {cluster: "remote", name: "deleted", uid: "uid"},
+		"recreated service account":        {cluster: "remote", name: "controller", uid: "other"},
+		"unknown logical cluster":          {cluster: "unknown", name: "controller", uid: "uid"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sa := core.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+				Name:        tt.name,
+				Namespace:   "default",
+				UID:         types.UID(tt.uid),
+				Annotations: map[string]string{logicalcluster.AnnotationKey: tt.cluster},
+			}}
+			claims, private, err := serviceaccount.Claims(sa, nil, nil, nil, 3600, 0, []string{testIssuer})
+			require.NoError(t, err)
+			token, err := generator.GenerateToken(claims, private)
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, ok, err := authn.AuthenticateRequest(req)
+			if tt.wantUser == "" {
+				require.False(t, ok)
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, tt.wantUser, resp.User.GetName())
+			require.Contains(t, resp.User.GetGroups(), user.AllAuthenticated)
+			require.Equal(t, []string{"remote"}, resp.User.GetExtra()[apiserverserviceaccount.ClusterNameKey])
+		})
+	}
+}
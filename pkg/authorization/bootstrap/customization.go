@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	rbacrest "k8s.io/kubernetes/pkg/registry/rbac/rest"
+	"sigs.k8s.io/yaml"
+)
+
+// PolicyCustomization extends or overrides the compiled-in bootstrap policy. ClusterRoles
+// and ClusterRoleBindings with the name of a compiled-in one replace it, others are added.
+type PolicyCustomization struct {
+	ClusterRoles        []rbacv1.ClusterRole        `json:"clusterRoles,omitempty"`
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding `json:"clusterRoleBindings,omitempty"`
+}
+
+// LoadPolicyCustomization reads a bootstrap policy customization from the given file.
+func LoadPolicyCustomization(path string) (*PolicyCustomization, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bootstrap policy %q: %w", path, err)
+	}
+	var c PolicyCustomization
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bootstrap policy %q: %w", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid bootstrap policy %q: %w", path, err)
+	}
+	return &c, nil
+}
+
+func (c *PolicyCustomization) validate() error {
+	roles := sets.New[string]()
+	for _, role := range c.ClusterRoles {
+		if role.Name == "" || roles.Has(role.Name) {
+			return fmt.Errorf("clusterRole names must be unique and not empty, got %q", role.Name)
+		}
+		roles.Insert(role.Name)
+	}
+	bindings := sets.New[string]()
+	for _, binding := range c.ClusterRoleBindings {
+		if binding.Name == "" || bindings.Has(binding.Name) {
+			return fmt.Errorf("clusterRoleBinding names must be unique and not empty, got %q", binding.Name)
+		}
+		bindings.Insert(binding.Name)
+		if binding.RoleRef.APIGroup != rbacv1.GroupName || binding.RoleRef.Kind != "ClusterRole" || binding.RoleRef.Name == "" {
+			return fmt.Errorf("clusterRoleBinding %q must reference a ClusterRole", binding.Name)
+		}
+	}
+	return nil
+}
+
+// CustomizedPolicy returns the bootstrap policy with the given customization applied. The
+// customization may be nil.
+func CustomizedPolicy(c *PolicyCustomization) *rbacrest.PolicyData {
+	policy := Policy()
+	if c == nil {
+		return policy
+	}
+	for _, role := range c.ClusterRoles {
+		policy.ClusterRoles = replaceOrAppend(policy.ClusterRoles, role, func(r rbacv1.ClusterRole) string { return r.Name })
+	}
+	for _, binding := range c.ClusterRoleBindings {
+		policy.ClusterRoleBindings = replaceOrAppend(policy.ClusterRoleBindings, binding, func(b rbacv1.ClusterRoleBinding) string { return b.Name })
+	}
+	return policy
+}
+
+func replaceOrAppend[T any](objs []T, obj T, name func(T) string) []T {
+	for i := range objs {
+		if name(objs[i]) == name(obj) {
+			objs[i] = obj
+			return objs
+		}
+	}
+	return append(objs, obj)
+}
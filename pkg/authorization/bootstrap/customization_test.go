@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestLoadPolicyCustomization(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "policy.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	c, err := LoadPolicyCustomization(write(`
+clusterRoles:
+- metadata:
+    name: system:kcp:tenancy:reader
+  rules:
+  - apiGroups: ["tenancy.kcp.io"]
+    resources: ["workspaces"]
+    verbs: ["get"]
+- metadata:
+    name: system:kcp:auditor
+  rules:
+  - nonResourceURLs: ["/metrics"]
+    verbs: ["get"]
+clusterRoleBindings:
+- metadata:
+    name: system:kcp:auditor
+  roleRef:
+    apiGroup: rbac.authorization.k8s.io
+    kind: ClusterRole
+    name: system:kcp:auditor
+  subjects:
+  - apiGroup: rbac.authorization.k8s.io
+    kind: Group
+    name: auditors
+`))
+	require.NoError(t, err)
+
+	policy := CustomizedPolicy(c)
+	require.Len(t, policy.ClusterRoles, len(clusterRoles())+1, "overridden roles are replaced, new ones added")
+	require.Len(t, policy.ClusterRoleBindings, len(clusterRoleBindings())+1)
+	for _, role := range policy.ClusterRoles {
+		if role.Name == "system:kcp:tenancy:reader" {
+			require.Equal(t, []rbacv1.PolicyRule{{APIGroups: []string{"tenancy.kcp.io"}, Resources: []string{"workspaces"}, Verbs: []string{"get"}}}, role.Rules)
+		}
+	}
+	require.Equal(t, Policy(), CustomizedPolicy(nil))
+
+	_, err = LoadPolicyCustomization(write(`
+clusterRoleBindings:
+- metadata:
+    name: foo
+  roleRef:
+    kind: Role
+    name: foo
+`))
+	require.ErrorContains(t, err, "must reference a ClusterRole")
+
+	_, err = LoadPolicyCustomization(write(`
+clusterRoles:
+- metadata:
+    name: foo
+- metadata:
+    name: foo
+`))
+	require.ErrorContains(t, err, "must be unique")
+}
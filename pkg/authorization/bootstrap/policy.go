@@ -19,6 +19,7 @@ package bootstrap
 import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
 	rbacv1helpers "k8s.io/kubernetes/pkg/apis/rbac/v1"
 	rbacrest "k8s.io/kubernetes/pkg/registry/rbac/rest"
 	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac/bootstrappolicy"
@@ -42,8 +43,14 @@ const (
 	// SystemExternalLogicalClusterAdmin is a group used by the workspace controllers to manage LogicalCluster
 	// resources after creation, using a subset of permissions allowed for the internal logical-cluster-admin.
 	SystemExternalLogicalClusterAdmin = "system:kcp:external-logical-cluster-admin"
+	// SystemKcpServiceAccountTokenValidator is a group used by shards to look up the service accounts, secrets
+	// and pods of service account tokens issued by other shards, through the external address (e.g. the front-proxy).
+	SystemKcpServiceAccountTokenValidator = "system:kcp:serviceaccount-token-validator"
 	// SystemKcpWorkspaceAccessGroup is a group that gives a user system:authenticated access to a workspace.
 	SystemKcpWorkspaceAccessGroup = "system:kcp:workspace:access"
+	// SystemKcpVirtualWorkspaceDiscovery is the role allowing every user with access to a workspace to discover the
+	// virtual workspace URLs available to them.
+	SystemKcpVirtualWorkspaceDiscovery = "system:kcp:virtual-workspace-discovery"
 )
 
 // ClusterRoleBindings return default rolebindings to the default roles.
@@ -53,6 +60,8 @@ func clusterRoleBindings() []rbacv1.ClusterRoleBinding {
 		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemKcpWorkspaceBootstrapper).Groups(SystemKcpWorkspaceBootstrapper, "apis.kcp.io:binding:"+SystemKcpWorkspaceBootstrapper).BindingOrDie(), SystemKcpWorkspaceBootstrapper),
 		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemLogicalClusterAdmin).Groups(SystemLogicalClusterAdmin).BindingOrDie(), SystemLogicalClusterAdmin),
 		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemExternalLogicalClusterAdmin).Groups(SystemExternalLogicalClusterAdmin).BindingOrDie(), SystemExternalLogicalClusterAdmin),
+		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemKcpServiceAccountTokenValidator).Groups(SystemKcpServiceAccountTokenValidator).BindingOrDie(), SystemKcpServiceAccountTokenValidator),
+		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemKcpVirtualWorkspaceDiscovery).Groups(user.AllAuthenticated).BindingOrDie(), SystemKcpVirtualWorkspaceDiscovery),
 	}
 }
 
@@ -88,12 +97,27 @@ func clusterRoles() []rbacv1.ClusterRole {
 				rbacv1helpers.NewRule("access").URLs("/").RuleOrDie(),
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: SystemKcpServiceAccountTokenValidator},
+			Rules: []rbacv1.PolicyRule{
+				rbacv1helpers.NewRule("get").Groups("").Resources("serviceaccounts", "pods", "secrets").RuleOrDie(),
+				// legacy tokens are marked as used or invalid by labels on their secret
+				rbacv1helpers.NewRule("patch").Groups("").Resources("secrets").RuleOrDie(),
+				rbacv1helpers.NewRule("access").URLs("/").RuleOrDie(),
+			},
+		},
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: SystemKcpWorkspaceAccessGroup},
 			Rules: []rbacv1.PolicyRule{
 				rbacv1helpers.NewRule("access").URLs("/").RuleOrDie(),
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: SystemKcpVirtualWorkspaceDiscovery},
+			Rules: []rbacv1.PolicyRule{
+				rbacv1helpers.NewRule("get").URLs("/virtualworkspaces").RuleOrDie(),
+			},
+		},
 	}
 }
 
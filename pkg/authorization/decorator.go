@@ -18,6 +18,7 @@ package authorization
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -41,6 +42,40 @@ const (
 	auditReason   = "reason"
 )
 
+// AuditDetail is the verbosity of the audit annotations of authorizer decisions.
+type AuditDetail string
+
+const (
+	// AuditDetailNone records no audit annotations of authorizer decisions.
+	AuditDetailNone AuditDetail = "None"
+	// AuditDetailDecision records the decision of every authorizer.
+	AuditDetailDecision AuditDetail = "Decision"
+	// AuditDetailReason records the decision and the reason of every authorizer.
+	AuditDetailReason AuditDetail = "Reason"
+	// AuditDetailStructured records the decision and the reason of every authorizer,
+	// and in addition a JSON annotation with the decision, reason, error and the
+	// authorized attributes.
+	AuditDetailStructured AuditDetail = "Structured"
+)
+
+// AuditDetails are the supported audit details.
+var AuditDetails = []AuditDetail{AuditDetailNone, AuditDetailDecision, AuditDetailReason, AuditDetailStructured}
+
+// auditAnnotation is the structured audit annotation of an authorizer decision.
+type auditAnnotation struct {
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	Verb        string `json:"verb,omitempty"`
+	APIGroup    string `json:"apiGroup,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Path        string `json:"path,omitempty"`
+}
+
 type Decorator struct {
 	target authorizer.Authorizer
 	key    string
@@ -70,12 +105,8 @@ func (d *Decorator) AddAuditLogging() *Decorator {
 			auditReasonMsg = fmt.Sprintf("reason: %v, error: %v", reason, err)
 		}
 
-		if domain := ctx.Value(auditDomainKey); domain != nil && domain != "" {
-			kaudit.AddAuditAnnotations(
-				ctx,
-				fmt.Sprintf("%s/%s-%s", domain, d.key, auditDecision), decisionString(dec),
-				fmt.Sprintf("%s/%s-%s", domain, d.key, auditReason), auditReasonMsg,
-			)
+		if logging, ok := ctx.Value(auditDomainKey).(auditLogging); ok && logging.domain != "" {
+			d.addAuditAnnotations(ctx, logging, attr, dec, reason, auditReasonMsg, err)
 		}
 
 		if dec != authorizer.DecisionAllow {
@@ -90,6 +121,46 @@ func (d *Decorator) AddAuditLogging() *Decorator {
 	return d
 }
 
+// addAuditAnnotations records the decision of the target authorizer according to the audit detail.
+func (d *Decorator) addAuditAnnotations(ctx context.Context, logging auditLogging, attr authorizer.Attributes, dec authorizer.Decision, reason, auditReasonMsg string, err error) {
+	prefix := fmt.Sprintf("%s/%s", logging.domain, d.key)
+
+	switch logging.detail {
+	case AuditDetailNone:
+	case AuditDetailDecision:
+		kaudit.AddAuditAnnotation(ctx, prefix+"-"+auditDecision, decisionString(dec))
+	default:
+		kaudit.AddAuditAnnotations(
+			ctx,
+			prefix+"-"+auditDecision, decisionString(dec),
+			prefix+"-"+auditReason, auditReasonMsg,
+		)
+	}
+	if logging.detail != AuditDetailStructured {
+		return
+	}
+
+	annotation := auditAnnotation{
+		Decision:    decisionString(dec),
+		Reason:      reason,
+		Verb:        attr.GetVerb(),
+		APIGroup:    attr.GetAPIGroup(),
+		Resource:    attr.GetResource(),
+		Subresource: attr.GetSubresource(),
+		Namespace:   attr.GetNamespace(),
+		Name:        attr.GetName(),
+	}
+	if !attr.IsResourceRequest() {
+		annotation.Path = attr.GetPath()
+	}
+	if err != nil {
+		annotation.Error = err.Error()
+	}
+	if bs, err := json.Marshal(annotation); err == nil {
+		kaudit.AddAuditAnnotation(ctx, prefix, string(bs))
+	}
+}
+
 // AddAnonymization anonymizes authorization decisions,
 // returning "access granted" reason in case of an allow decision and "access denied" reason otherwise to the next decoration.
 // Previous decorations are not anonymized.
@@ -156,18 +227,23 @@ const (
 	auditDomainKey auditLoggingDomainType = iota
 )
 
-// WithAuditLogging stores the given domain in the context to be used when logging
+type auditLogging struct {
+	domain string
+	detail AuditDetail
+}
+
+// WithAuditLogging stores the given domain and detail in the context to be used when logging
 // audit events in the given authorizer chain. The annotations will have the format
 // <prefix>.<domain>/<key>. If that context is not set, audit logging is skipped.
 // Note that this is only respected by authorizers that have been decorated using Decorator.AddAuditLogging.
-func WithAuditLogging(annotationDomain string, delegate authorizer.Authorizer) authorizer.Authorizer {
+func WithAuditLogging(annotationDomain string, detail AuditDetail, delegate authorizer.Authorizer) authorizer.Authorizer {
 	return authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
-		ctx = context.WithValue(ctx, auditDomainKey, annotationDomain)
+		ctx = context.WithValue(ctx, auditDomainKey, auditLogging{domain: annotationDomain, detail: detail})
 		return delegate.Authorize(ctx, a)
 	})
 }
 
-func WithSubjectAccessReviewAuditAnnotations(handler http.Handler) http.Handler {
+func WithSubjectAccessReviewAuditAnnotations(handler http.Handler, detail AuditDetail) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ri, ok := genericapirequest.RequestInfoFrom(r.Context())
 		if !ok {
@@ -179,7 +255,7 @@ func WithSubjectAccessReviewAuditAnnotations(handler http.Handler) http.Handler
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), auditDomainKey, "sar.auth.kcp.io")
+		ctx := context.WithValue(r.Context(), auditDomainKey, auditLogging{domain: "sar.auth.kcp.io", detail: detail})
 		r = r.WithContext(ctx)
 
 		handler.ServeHTTP(w, r)
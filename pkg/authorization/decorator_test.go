@@ -49,7 +49,7 @@ func TestDecorator(t *testing.T) {
 		wantReason   string
 	}{
 		"topAllows": {
-			authz: WithAuditLogging("domain", NewDecorator("top", alwaysAllow).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top", alwaysAllow).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 
 			wantDecision: authorizer.DecisionAllow,
 			wantReason:   "top: access granted",
@@ -68,7 +68,7 @@ func TestDecorator(t *testing.T) {
 			wantAudit: nil,
 		},
 		"topAllowsWithoutReasonAnnotation": {
-			authz: WithAuditLogging("domain", NewDecorator("top", alwaysAllow).AddAuditLogging().AddAnonymization()),
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top", alwaysAllow).AddAuditLogging().AddAnonymization()),
 
 			wantDecision: authorizer.DecisionAllow,
 			wantReason:   "access granted",
@@ -79,7 +79,7 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topAllowsWithoutReasonAnnotationWithoutAnonymization": {
-			authz: WithAuditLogging("domain", NewDecorator("top", alwaysAllow).AddAuditLogging()),
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top", alwaysAllow).AddAuditLogging()),
 
 			wantDecision: authorizer.DecisionAllow,
 			wantReason:   "unanonymized allow",
@@ -90,15 +90,45 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topAllowsWithoutReasonAnnotationWithoutAnonymizationWithoutAuditLogging": {
-			authz: WithAuditLogging("domain", NewDecorator("top", alwaysAllow)),
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top", alwaysAllow)),
 
 			wantDecision: authorizer.DecisionAllow,
 			wantReason:   "unanonymized allow",
 
 			wantAudit: nil,
 		},
+		"topDeniesWithoutAuditDetail": {
+			authz: WithAuditLogging("domain", AuditDetailNone, NewDecorator("top", alwaysDeny).AddAuditLogging().AddAnonymization()),
+
+			wantDecision: authorizer.DecisionDeny,
+			wantReason:   "access denied",
+
+			wantAudit: nil,
+		},
+		"topDeniesWithDecisionAuditDetail": {
+			authz: WithAuditLogging("domain", AuditDetailDecision, NewDecorator("top", alwaysDeny).AddAuditLogging().AddAnonymization()),
+
+			wantDecision: authorizer.DecisionDeny,
+			wantReason:   "access denied",
+
+			wantAudit: map[string]string{
+				"domain/top-decision": "Denied",
+			},
+		},
+		"topErrorsWithStructuredAuditDetail": {
+			authz: WithAuditLogging("domain", AuditDetailStructured, NewDecorator("top", alwaysError).AddAuditLogging().AddAnonymization()),
+
+			wantDecision: authorizer.DecisionNoOpinion,
+			wantReason:   "access denied",
+
+			wantAudit: map[string]string{
+				"domain/top-decision": "NoOpinion",
+				"domain/top-reason":   "reason: unanonymized failure, error: unanonymized error",
+				"domain/top":          `{"decision":"NoOpinion","reason":"unanonymized failure","error":"unanonymized error"}`,
+			},
+		},
 		"topDelegatesToAllow": {
-			authz: WithAuditLogging("domain", NewDecorator("top",
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top",
 				DelegateAuthorization("top-to-bottom",
 					NewDecorator("bottom", alwaysAllow).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 			).AddAuditLogging().AddAnonymization()),
@@ -115,7 +145,7 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topDelegatesToDeny": {
-			authz: WithAuditLogging("domain", NewDecorator("top",
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top",
 				DelegateAuthorization("top-to-bottom",
 					NewDecorator("bottom", alwaysDeny).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 			).AddAuditLogging().AddAnonymization()),
@@ -132,7 +162,7 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topDelegatesToDelegateDelegatesToDeny": {
-			authz: WithAuditLogging("domain", NewDecorator("top",
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top",
 				DelegateAuthorization("top-to-middle", NewDecorator("middle",
 					DelegateAuthorization("middle-to-bottom", NewDecorator("bottom", alwaysDeny).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 				).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
@@ -153,7 +183,7 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topDelegatesToDelegateDelegatesToAllow": {
-			authz: WithAuditLogging("domain", NewDecorator("top",
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top",
 				DelegateAuthorization("top-to-middle", NewDecorator("middle",
 					DelegateAuthorization("middle-to-bottom", NewDecorator("bottom", alwaysAllow).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 				).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
@@ -174,7 +204,7 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topDelegatesToDelegateDelegatesToNoOpinion": {
-			authz: WithAuditLogging("domain", NewDecorator("top",
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top",
 				DelegateAuthorization("top-to-middle", NewDecorator("middle",
 					DelegateAuthorization("middle-to-bottom", NewDecorator("bottom", alwaysNoOpinion).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 				).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
@@ -195,7 +225,7 @@ func TestDecorator(t *testing.T) {
 			},
 		},
 		"topDelegatesToDelegateDelegatesToError": {
-			authz: WithAuditLogging("domain", NewDecorator("top",
+			authz: WithAuditLogging("domain", AuditDetailReason, NewDecorator("top",
 				DelegateAuthorization("top-to-middle", NewDecorator("middle",
 					DelegateAuthorization("middle-to-bottom", NewDecorator("bottom", alwaysError).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
 				).AddAuditLogging().AddAnonymization().AddReasonAnnotation()),
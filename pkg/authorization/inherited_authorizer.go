@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	kcprbacv1listers "github.com/kcp-dev/client-go/listers/rbac/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	controlplaneapiserver "k8s.io/kubernetes/pkg/controlplane/apiserver"
+	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
+
+	rbacwrapper "github.com/kcp-dev/kcp/pkg/virtual/framework/wrappers/rbac"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	// InheritLabelKey on a ClusterRoleBinding with value "true" makes the binding apply to all
+	// descendant workspaces of its workspace as well.
+	InheritLabelKey = "authorization.kcp.io/inherit"
+
+	// maxInheritanceDepth bounds the walk up the workspace hierarchy.
+	maxInheritanceDepth = 32
+)
+
+// NewInheritedAuthorizer returns an authorizer and rule resolver for the ClusterRoleBindings
+// labeled for inheritance in the ancestor workspaces of the requested workspace. The bindings
+// and their ClusterRoles are resolved in the workspace of the binding.
+func NewInheritedAuthorizer(localKubeInformers, globalKubeInformers kcpkubernetesinformers.SharedInformerFactory, localLogicalClusterLister, globalLogicalClusterLister corev1alpha1listers.LogicalClusterClusterLister) (authorizer.Authorizer, authorizer.RuleResolver) {
+	// listers are saved in the struct here to ensure that informers are instantiated early and we do not encounter race conditions with starting them.
+	a := &InheritedAuthorizer{
+		localClusterRoleLister:         localKubeInformers.Rbac().V1().ClusterRoles().Lister(),
+		localClusterRoleBindingLister:  localKubeInformers.Rbac().V1().ClusterRoleBindings().Lister(),
+		globalClusterRoleLister:        globalKubeInformers.Rbac().V1().ClusterRoles().Lister(),
+		globalClusterRoleBindingLister: globalKubeInformers.Rbac().V1().ClusterRoleBindings().Lister(),
+
+		getLogicalCluster: func(logicalCluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			obj, err := localLogicalClusterLister.Cluster(logicalCluster).Get(corev1alpha1.LogicalClusterName)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			} else if errors.IsNotFound(err) {
+				return globalLogicalClusterLister.Cluster(logicalCluster).Get(corev1alpha1.LogicalClusterName)
+			}
+			return obj, nil
+		},
+	}
+
+	return a, a
+}
+
+type InheritedAuthorizer struct {
+	localClusterRoleLister         kcprbacv1listers.ClusterRoleClusterLister
+	localClusterRoleBindingLister  kcprbacv1listers.ClusterRoleBindingClusterLister
+	globalClusterRoleLister        kcprbacv1listers.ClusterRoleClusterLister
+	globalClusterRoleBindingLister kcprbacv1listers.ClusterRoleBindingClusterLister
+
+	getLogicalCluster func(logicalCluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+}
+
+func (a *InheritedAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorized authorizer.Decision, reason string, err error) {
+	cluster := genericapirequest.ClusterFrom(ctx)
+	if cluster == nil || cluster.Name.Empty() {
+		return authorizer.DecisionNoOpinion, "empty cluster name", nil
+	}
+
+	ancestors, err := a.ancestors(cluster.Name)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("error getting ancestors of cluster %q: %w", cluster.Name, err)
+	}
+	for _, ancestor := range ancestors {
+		dec, reason, err := a.newAuthorizer(ancestor).Authorize(ctx, attr)
+		if err != nil {
+			return authorizer.DecisionNoOpinion, "", fmt.Errorf("error authorizing inherited policy of cluster %q: %w", ancestor, err)
+		}
+		if dec == authorizer.DecisionAllow {
+			return dec, fmt.Sprintf("inherited cluster %q policy: %v", ancestor, reason), nil
+		}
+	}
+
+	return authorizer.DecisionNoOpinion, "no inherited policy allows the request", nil
+}
+
+func (a *InheritedAuthorizer) RulesFor(ctx context.Context, user user.Info, namespace string) ([]authorizer.ResourceRuleInfo, []authorizer.NonResourceRuleInfo, bool, error) {
+	cluster := genericapirequest.ClusterFrom(ctx)
+	if cluster == nil || cluster.Name.Empty() {
+		return nil, nil, false, fmt.Errorf("empty cluster name")
+	}
+
+	ancestors, err := a.ancestors(cluster.Name)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	var (
+		resourceRules    []authorizer.ResourceRuleInfo
+		nonResourceRules []authorizer.NonResourceRuleInfo
+		incomplete       bool
+	)
+	for _, ancestor := range ancestors {
+		r, nr, inc, err := a.newAuthorizer(ancestor).RulesFor(ctx, user, namespace)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		resourceRules = append(resourceRules, r...)
+		nonResourceRules = append(nonResourceRules, nr...)
+		incomplete = incomplete || inc
+	}
+	return resourceRules, nonResourceRules, incomplete, nil
+}
+
+// ancestors returns the logical clusters of the parent workspaces of the given logical cluster,
+// starting with the direct parent. The chain is walked through the listers on every call, as
+// workspaces can be moved to another parent.
+func (a *InheritedAuthorizer) ancestors(clusterName logicalcluster.Name) ([]logicalcluster.Name, error) {
+	var ancestors []logicalcluster.Name
+	current := clusterName
+	for range maxInheritanceDepth {
+		logicalCluster, err := a.getLogicalCluster(current)
+		if errors.IsNotFound(err) {
+			// not known (yet), e.g. not replicated to the cache.
+			return ancestors, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if logicalCluster.Spec.Owner == nil || logicalCluster.Spec.Owner.Cluster == "" {
+			break
+		}
+		current = logicalcluster.Name(logicalCluster.Spec.Owner.Cluster)
+		ancestors = append(ancestors, current)
+	}
+
+	return ancestors, nil
+}
+
+func (a *InheritedAuthorizer) newAuthorizer(clusterName logicalcluster.Name) *rbac.RBACAuthorizer {
+	return rbac.New(
+		&rbac.RoleGetter{Lister: rbacwrapper.NewMergedRoleLister()},
+		&rbac.RoleBindingLister{Lister: rbacwrapper.NewMergedRoleBindingLister()},
+		&rbac.ClusterRoleGetter{Lister: rbacwrapper.NewMergedClusterRoleLister(
+			a.localClusterRoleLister.Cluster(clusterName),
+			a.globalClusterRoleLister.Cluster(clusterName),
+			a.localClusterRoleLister.Cluster(controlplaneapiserver.LocalAdminCluster),
+		)},
+		&rbac.ClusterRoleBindingLister{Lister: &inheritedClusterRoleBindingLister{
+			ClusterRoleBindingLister: rbacwrapper.NewMergedClusterRoleBindingLister(
+				a.localClusterRoleBindingLister.Cluster(clusterName),
+				a.globalClusterRoleBindingLister.Cluster(clusterName),
+			),
+		}},
+	)
+}
+
+var inheritRequirement, _ = labels.NewRequirement(InheritLabelKey, selection.Equals, []string{"true"})
+
+// inheritedClusterRoleBindingLister only lists the ClusterRoleBindings labeled for inheritance.
+type inheritedClusterRoleBindingLister struct {
+	rbaclisters.ClusterRoleBindingLister
+}
+
+func (l *inheritedClusterRoleBindingLister) List(selector labels.Selector) ([]*rbacv1.ClusterRoleBinding, error) {
+	return l.ClusterRoleBindingLister.List(selector.Add(*inheritRequirement))
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	kcpfakeclient "github.com/kcp-dev/client-go/kubernetes/fake"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+func TestInheritedAuthorizer(t *testing.T) {
+	clusterRole := func(cluster string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin", Annotations: map[string]string{logicalcluster.AnnotationKey: cluster}},
+			Rules:      []rbacv1.PolicyRule{{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}}},
+		}
+	}
+	clusterRoleBinding := func(cluster, name, user string, inherit bool) *rbacv1.ClusterRoleBinding {
+		crb := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{logicalcluster.AnnotationKey: cluster}},
+			Subjects:   []rbacv1.Subject{{Kind: "User", APIGroup: rbacv1.GroupName, Name: user}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+		}
+		if inherit {
+			crb.Labels = map[string]string{InheritLabelKey: "true"}
+		}
+		return crb
+	}
+
+	tests := map[string]struct {
+		user         string
+		cluster      string
+		wantDecision authorizer.Decision
+	}{
+		"inherited from parent": {
+			user:         "org-admin",
+			cluster:      "team",
+			wantDecision: authorizer.DecisionAllow,
+		},
+		"inherited from grandparent": {
+			user:         "org-admin",
+			cluster:      "app",
+			wantDecision: authorizer.DecisionAllow,
+		},
+		"not labeled for inheritance": {
+			user:         "org-local-admin",
+			cluster:      "team",
+			wantDecision: authorizer.DecisionNoOpinion,
+		},
+		"not applied to the workspace of the binding itself": {
+			user:         "org-admin",
+			cluster:      "org",
+			wantDecision: authorizer.DecisionNoOpinion,
+		},
+		"not inherited upwards": {
+			user:         "team-admin",
+			cluster:      "org",
+			wantDecision: authorizer.DecisionNoOpinion,
+		},
+		"inherited from parent on another shard": {
+			user:         "remote-admin",
+			cluster:      "team",
+			wantDecision: authorizer.DecisionAllow,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			localKubeClient := kcpfakeclient.NewSimpleClientset([]runtime.Object{
+				clusterRole("org"),
+				clusterRoleBinding("org", "org-admin", "org-admin", true),
+				clusterRoleBinding("org", "org-local-admin", "org-local-admin", false),
+				clusterRole("team"),
+				clusterRoleBinding("team", "team-admin", "team-admin", true),
+			}...)
+			globalKubeClient := kcpfakeclient.NewSimpleClientset([]runtime.Object{
+				clusterRole("remote"),
+				clusterRoleBinding("remote", "remote-admin", "remote-admin", true),
+			}...)
+			local := kcpkubernetesinformers.NewSharedInformerFactory(localKubeClient, controller.NoResyncPeriodFunc())
+			global := kcpkubernetesinformers.NewSharedInformerFactory(globalKubeClient, controller.NoResyncPeriodFunc())
+
+			localIndexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+			globalIndexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+			for _, lc := range []struct {
+				indexer cache.Indexer
+				name    string
+				parent  string
+			}{
+				{localIndexer, "root", ""},
+				{localIndexer, "org", "root"},
+				{localIndexer, "team", "org"},
+				{localIndexer, "app", "team"},
+				{globalIndexer, "remote", "root"},
+			} {
+				obj := &corev1alpha1.LogicalCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: lc.name}},
+				}
+				if lc.parent != "" {
+					obj.Spec.Owner = &corev1alpha1.LogicalClusterOwner{Cluster: lc.parent}
+				}
+				require.NoError(t, lc.indexer.Add(obj))
+			}
+			// team is a child of org, and app a grandchild. For the remote test, make team a child of remote.
+			if tt.user == "remote-admin" {
+				require.NoError(t, localIndexer.Update(&corev1alpha1.LogicalCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "team"}},
+					Spec:       corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{Cluster: "remote"}},
+				}))
+			}
+
+			a, _ := NewInheritedAuthorizer(local, global, corev1alpha1listers.NewLogicalClusterClusterLister(localIndexer), corev1alpha1listers.NewLogicalClusterClusterLister(globalIndexer))
+
+			var syncs []cache.InformerSynced
+			for _, inf := range []cache.SharedIndexInformer{
+				local.Rbac().V1().ClusterRoles().Informer(),
+				local.Rbac().V1().ClusterRoleBindings().Informer(),
+				global.Rbac().V1().ClusterRoles().Informer(),
+				global.Rbac().V1().ClusterRoleBindings().Informer(),
+			} {
+				go inf.Run(ctx.Done())
+				syncs = append(syncs, inf.HasSynced)
+			}
+			cache.WaitForCacheSync(ctx.Done(), syncs...)
+
+			ctx = request.WithCluster(ctx, request.Cluster{Name: logicalcluster.Name(tt.cluster)})
+			dec, reason, err := a.Authorize(ctx, authorizer.AttributesRecord{
+				User:            newUser(tt.user),
+				Verb:            "get",
+				Resource:        "configmaps",
+				ResourceRequest: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDecision, dec, "reason: %s", reason)
+		})
+	}
+}
+
+func TestInheritedAuthorizerWorkspaceMove(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kubeClient := kcpfakeclient.NewSimpleClientset([]runtime.Object{
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin", Annotations: map[string]string{logicalcluster.AnnotationKey: "org"}},
+			Rules:      []rbacv1.PolicyRule{{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "org-admin", Labels: map[string]string{InheritLabelKey: "true"}, Annotations: map[string]string{logicalcluster.AnnotationKey: "org"}},
+			Subjects:   []rbacv1.Subject{{Kind: "User", APIGroup: rbacv1.GroupName, Name: "org-admin"}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+		},
+	}...)
+	local := kcpkubernetesinformers.NewSharedInformerFactory(kubeClient, controller.NoResyncPeriodFunc())
+	global := kcpkubernetesinformers.NewSharedInformerFactory(kcpfakeclient.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+
+	logicalCluster := func(name, parent string) *corev1alpha1.LogicalCluster {
+		return &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: name}},
+			Spec:       corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{Cluster: parent}},
+		}
+	}
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}},
+	}))
+	require.NoError(t, indexer.Add(logicalCluster("org", "root")))
+	require.NoError(t, indexer.Add(logicalCluster("other", "root")))
+	require.NoError(t, indexer.Add(logicalCluster("team", "org")))
+
+	lister := corev1alpha1listers.NewLogicalClusterClusterLister(indexer)
+	a, _ := NewInheritedAuthorizer(local, global, lister, corev1alpha1listers.NewLogicalClusterClusterLister(cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})))
+
+	local.Start(ctx.Done())
+	global.Start(ctx.Done())
+	local.WaitForCacheSync(ctx.Done())
+	global.WaitForCacheSync(ctx.Done())
+
+	authorize := func() authorizer.Decision {
+		t.Helper()
+		dec, _, err := a.Authorize(request.WithCluster(ctx, request.Cluster{Name: "team"}), authorizer.AttributesRecord{
+			User:            newUser("org-admin"),
+			Verb:            "get",
+			Resource:        "configmaps",
+			ResourceRequest: true,
+		})
+		require.NoError(t, err)
+		return dec
+	}
+
+	require.Equal(t, authorizer.DecisionAllow, authorize(), "expected binding of the parent to apply")
+
+	// move team from org to other
+	require.NoError(t, indexer.Update(logicalCluster("team", "other")))
+	require.Equal(t, authorizer.DecisionNoOpinion, authorize(), "expected binding of the old parent not to apply after the move")
+}
+
+func TestInheritedAuthorizerAncestors(t *testing.T) {
+	logicalClusters := map[logicalcluster.Name]*corev1alpha1.LogicalCluster{
+		"root": {},
+		"org":  {Spec: corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{Cluster: "root"}}},
+		"team": {Spec: corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{Cluster: "org"}}},
+	}
+	a := &InheritedAuthorizer{
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			if logicalCluster, ok := logicalClusters[clusterName]; ok {
+				return logicalCluster, nil
+			}
+			return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+		},
+	}
+
+	ancestors, err := a.ancestors("team")
+	require.NoError(t, err)
+	require.Equal(t, []logicalcluster.Name{"org", "root"}, ancestors)
+
+	ancestors, err = a.ancestors("orphan")
+	require.NoError(t, err)
+	require.Empty(t, ancestors)
+}
@@ -105,6 +105,10 @@ func (a *requiredGroupsAuthorizer) Authorize(ctx context.Context, attr authorize
 		if sets.New[string](attr.GetUser().GetGroups()...).Has(bootstrap.SystemExternalLogicalClusterAdmin) {
 			return DelegateAuthorization("external logical cluster admin access", a.delegate).Authorize(ctx, attr)
 		}
+		// service accounts of the logical cluster are authenticated on other shards too
+		if sets.New[string](attr.GetUser().GetGroups()...).Has(bootstrap.SystemKcpServiceAccountTokenValidator) {
+			return DelegateAuthorization("service account token validator access", a.delegate).Authorize(ctx, attr)
+		}
 
 		// check required groups
 		value, found := logicalCluster.Annotations[RequiredGroupsAnnotationKey]
@@ -63,6 +63,19 @@ func TestRequiredGroupsAuthorizer(t *testing.T) {
 			wantDecision:       authorizer.DecisionAllow,
 			wantReason:         "delegating due to external logical cluster admin access",
 		},
+		"system:kcp:serviceaccount-token-validator can always pass": {
+			requestedWorkspace: "root:ready",
+			requestingUser:     newUser("serviceaccount-token-validator", "system:kcp:serviceaccount-token-validator"),
+			wantDecision:       authorizer.DecisionAllow,
+			logicalCluster: &v1alpha1.LogicalCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Annotations: map[string]string{
+						"authorization.kcp.io/required-groups": "special-group",
+					},
+				},
+			},
+			wantReason: "delegating due to service account token validator access",
+		},
 		"service account from other cluster is granted access": {
 			requestedWorkspace: "root:ready",
 			requestingUser:     newServiceAccountWithCluster("sa", "anotherws"),
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook provides an authorizer calling an external authorization webhook, e.g. OPA,
+// with the SubjectAccessReview API, passing the logical cluster of the request.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
+	utilwebhook "k8s.io/apiserver/pkg/util/webhook"
+	"k8s.io/apiserver/plugin/pkg/authorizer/webhook"
+	webhookmetrics "k8s.io/apiserver/plugin/pkg/authorizer/webhook/metrics"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	// ClusterNameExtraKey is the user extra of the SubjectAccessReviews sent to the webhook
+	// holding the logical cluster of the request, or "*" for wildcard requests.
+	ClusterNameExtraKey = "authorization.kcp.io/cluster-name"
+	// ClusterPathExtraKey is the user extra of the SubjectAccessReviews sent to the webhook
+	// holding the workspace path of the request, if known.
+	ClusterPathExtraKey = "authorization.kcp.io/cluster-path"
+)
+
+const (
+	// FailurePolicyNoOpinion ignores the webhook if it cannot be called.
+	FailurePolicyNoOpinion = "NoOpinion"
+	// FailurePolicyDeny denies requests if the webhook cannot be called.
+	FailurePolicyDeny = "Deny"
+)
+
+var (
+	failurePolicies = []string{FailurePolicyNoOpinion, FailurePolicyDeny}
+	versions        = []string{"v1", "v1beta1"}
+)
+
+// Options configure the authorization webhook.
+type Options struct {
+	// ConfigFile is the kubeconfig of the webhook. If empty, no webhook is called.
+	ConfigFile string
+	// Version is the version of the SubjectAccessReview API of the webhook.
+	Version string
+	// CacheAuthorizedTTL and CacheUnauthorizedTTL are the durations the decisions are cached.
+	CacheAuthorizedTTL   time.Duration
+	CacheUnauthorizedTTL time.Duration
+	// FailurePolicy is the decision if the webhook cannot be called, one of NoOpinion and Deny.
+	FailurePolicy string
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Version:              "v1",
+		CacheAuthorizedTTL:   5 * time.Minute,
+		CacheUnauthorizedTTL: 30 * time.Second,
+		FailurePolicy:        FailurePolicyNoOpinion,
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	if o == nil {
+		return
+	}
+
+	fs.StringVar(&o.ConfigFile, "authorization-webhook-config-file", o.ConfigFile,
+		"File with the kubeconfig of an external authorization webhook, called with SubjectAccessReviews after the kcp authorizers. "+
+			"The logical cluster and the workspace path of the request are passed in the user extras "+ClusterNameExtraKey+" and "+ClusterPathExtraKey+". "+
+			"If unset, no webhook is called.")
+	fs.StringVar(&o.Version, "authorization-webhook-version", o.Version,
+		fmt.Sprintf("The version of the authorization.k8s.io SubjectAccessReview API of the authorization webhook. One of %v.", versions))
+	fs.DurationVar(&o.CacheAuthorizedTTL, "authorization-webhook-cache-authorized-ttl", o.CacheAuthorizedTTL,
+		"The duration to cache 'authorized' responses of the authorization webhook.")
+	fs.DurationVar(&o.CacheUnauthorizedTTL, "authorization-webhook-cache-unauthorized-ttl", o.CacheUnauthorizedTTL,
+		"The duration to cache 'unauthorized' responses of the authorization webhook.")
+	fs.StringVar(&o.FailurePolicy, "authorization-webhook-failure-policy", o.FailurePolicy,
+		fmt.Sprintf("The decision of the authorization webhook if it cannot be called. One of %v.", failurePolicies))
+}
+
+func (o *Options) Validate() []error {
+	if o == nil {
+		return nil
+	}
+
+	var errs []error
+	if !slices.Contains(versions, o.Version) {
+		errs = append(errs, fmt.Errorf("--authorization-webhook-version must be one of %v", versions))
+	}
+	if !slices.Contains(failurePolicies, o.FailurePolicy) {
+		errs = append(errs, fmt.Errorf("--authorization-webhook-failure-policy must be one of %v", failurePolicies))
+	}
+	if o.CacheAuthorizedTTL < 0 || o.CacheUnauthorizedTTL < 0 {
+		errs = append(errs, fmt.Errorf("--authorization-webhook-cache-authorized-ttl and --authorization-webhook-cache-unauthorized-ttl must not be negative"))
+	}
+	return errs
+}
+
+// Enabled returns true if a webhook is configured.
+func (o *Options) Enabled() bool {
+	return o != nil && o.ConfigFile != ""
+}
+
+// NewAuthorizer returns the authorizer of the webhook. The extras returned by clusterExtra for
+// the request are added to the user extra, and are part of the cache key of the decisions.
+func (o *Options) NewAuthorizer(clusterExtra func(ctx context.Context) map[string]string) (authorizer.Authorizer, error) {
+	config, err := utilwebhook.LoadKubeconfig(o.ConfigFile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load authorization webhook config: %w", err)
+	}
+	decisionOnError := authorizer.DecisionNoOpinion
+	if o.FailurePolicy == FailurePolicyDeny {
+		decisionOnError = authorizer.DecisionDeny
+	}
+	w, err := webhook.New(config, o.Version, o.CacheAuthorizedTTL, o.CacheUnauthorizedTTL, *genericoptions.DefaultAuthWebhookRetryBackoff(), decisionOnError, nil, "kcp", webhookmetrics.NoopAuthorizerMetrics{})
+	if err != nil {
+		return nil, err
+	}
+	return WithClusterExtra(w, clusterExtra), nil
+}
+
+// WithClusterExtra returns an authorizer adding the extras returned by clusterExtra to the
+// user of the request before calling the delegate.
+func WithClusterExtra(delegate authorizer.Authorizer, clusterExtra func(ctx context.Context) map[string]string) authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+		extra := clusterExtra(ctx)
+		if len(extra) == 0 {
+			return delegate.Authorize(ctx, attr)
+		}
+
+		u := &user.DefaultInfo{Extra: map[string][]string{}}
+		if orig := attr.GetUser(); orig != nil {
+			u.Name, u.UID, u.Groups = orig.GetName(), orig.GetUID(), orig.GetGroups()
+			for k, v := range orig.GetExtra() {
+				u.Extra[k] = v
+			}
+		}
+		for k, v := range extra {
+			u.Extra[k] = []string{v}
+		}
+		return delegate.Authorize(ctx, withUser{Attributes: attr, user: u})
+	})
+}
+
+type withUser struct {
+	authorizer.Attributes
+	user user.Info
+}
+
+func (a withUser) GetUser() user.Info {
+	return a.user
+}
+
+// ShardClusterExtra returns the logical cluster of the request and, if known, its workspace
+// path, looking up the LogicalCluster locally first, then in the cache server.
+func ShardClusterExtra(local, global corev1alpha1listers.LogicalClusterClusterLister) func(ctx context.Context) map[string]string {
+	return func(ctx context.Context) map[string]string {
+		cluster := genericapirequest.ClusterFrom(ctx)
+		if cluster == nil {
+			return nil
+		}
+		if cluster.Wildcard {
+			return map[string]string{ClusterNameExtraKey: logicalcluster.Wildcard.String()}
+		}
+		if cluster.Name.Empty() {
+			return nil
+		}
+
+		extra := map[string]string{ClusterNameExtraKey: cluster.Name.String()}
+		logicalCluster, err := local.Cluster(cluster.Name).Get(corev1alpha1.LogicalClusterName)
+		if apierrors.IsNotFound(err) {
+			logicalCluster, err = global.Cluster(cluster.Name).Get(corev1alpha1.LogicalClusterName)
+		}
+		if err == nil {
+			if path, found := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; found {
+				extra[ClusterPathExtraKey] = path
+			}
+		}
+		return extra
+	}
+}
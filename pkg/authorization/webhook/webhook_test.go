@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+func TestWithClusterExtra(t *testing.T) {
+	var got user.Info
+	delegate := authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+		got = attr.GetUser()
+		return authorizer.DecisionAllow, "", nil
+	})
+	orig := &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"team"}, Extra: map[string][]string{"scopes": {"a"}}}
+	attr := authorizer.AttributesRecord{User: orig, Verb: "get"}
+
+	authz := WithClusterExtra(delegate, func(ctx context.Context) map[string]string {
+		return map[string]string{ClusterNameExtraKey: "abc", ClusterPathExtraKey: "root:org"}
+	})
+	_, _, err := authz.Authorize(context.Background(), attr)
+	require.NoError(t, err)
+	require.Equal(t, &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"team"}, Extra: map[string][]string{
+		"scopes":            {"a"},
+		ClusterNameExtraKey: {"abc"},
+		ClusterPathExtraKey: {"root:org"},
+	}}, got)
+	require.Equal(t, map[string][]string{"scopes": {"a"}}, orig.Extra, "the original user must not be changed")
+
+	authz = WithClusterExtra(delegate, func(ctx context.Context) map[string]string { return nil })
+	_, _, err = authz.Authorize(context.Background(), attr)
+	require.NoError(t, err)
+	require.Same(t, orig, got)
+}
+
+func TestValidate(t *testing.T) {
+	o := NewOptions()
+	require.Empty(t, o.Validate())
+	require.False(t, o.Enabled())
+
+	o.Version, o.FailurePolicy = "v2", "Allow"
+	require.Len(t, o.Validate(), 2)
+}
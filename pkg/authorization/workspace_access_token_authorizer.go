@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	rbacauthorizer "k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
+
+	"github.com/kcp-dev/kcp/pkg/authentication/accesstoken"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+// NewWorkspaceAccessTokenAuthorizer returns an authorizer that denies requests of users
+// authenticated with a workspace access token outside of the workspace subtree and the rules
+// of the token. Everything else is delegated, i.e. the user needs permissions in addition.
+func NewWorkspaceAccessTokenAuthorizer(local, global corev1alpha1listers.LogicalClusterClusterLister, delegate authorizer.Authorizer) authorizer.Authorizer {
+	return &workspaceAccessTokenAuthorizer{
+		getLogicalCluster: func(logicalCluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			obj, err := local.Cluster(logicalCluster).Get(corev1alpha1.LogicalClusterName)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			} else if errors.IsNotFound(err) {
+				return global.Cluster(logicalCluster).Get(corev1alpha1.LogicalClusterName)
+			}
+			return obj, nil
+		},
+		delegate: delegate,
+	}
+}
+
+type workspaceAccessTokenAuthorizer struct {
+	getLogicalCluster func(logicalCluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	delegate          authorizer.Authorizer
+}
+
+func (a *workspaceAccessTokenAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorized authorizer.Decision, reason string, err error) {
+	if attr.GetUser() == nil || !accesstoken.IsRestricted(attr.GetUser()) {
+		return DelegateAuthorization("no workspace access token", a.delegate).Authorize(ctx, attr)
+	}
+
+	workspaces, rules, err := accesstoken.Restrictions(attr.GetUser())
+	if err != nil {
+		return authorizer.DecisionDeny, "invalid workspace access token", nil
+	}
+	if attr.GetVerb() == "impersonate" {
+		// impersonated users would not be restricted anymore
+		return authorizer.DecisionDeny, "impersonation is not permitted with workspace access tokens", nil
+	}
+
+	cluster := genericapirequest.ClusterFrom(ctx)
+	if cluster == nil || cluster.Name.Empty() || cluster.Wildcard {
+		return authorizer.DecisionDeny, "workspace access tokens are restricted to a workspace", nil
+	}
+	path := cluster.Name.Path()
+	logicalCluster, err := a.getLogicalCluster(cluster.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return authorizer.DecisionNoOpinion, "", err
+	} else if err == nil {
+		if value, found := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; found {
+			path = logicalcluster.NewPath(value)
+		}
+	}
+	for _, workspace := range workspaces {
+		if !path.HasPrefix(workspace) && workspace != cluster.Name.Path() {
+			return authorizer.DecisionDeny, fmt.Sprintf("workspace access token is restricted to workspace %s and its descendants", workspace), nil
+		}
+	}
+
+	if !rbacauthorizer.RulesAllow(attr, rules...) {
+		return authorizer.DecisionDeny, "workspace access token does not permit the request", nil
+	}
+
+	return DelegateAuthorization("workspace access token permits the request", a.delegate).Authorize(ctx, attr)
+}
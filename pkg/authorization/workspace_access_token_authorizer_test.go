@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/authentication/accesstoken"
+	"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestWorkspaceAccessTokenAuthorizer(t *testing.T) {
+	newTokenUser := func(workspace string) *user.DefaultInfo {
+		return &user.DefaultInfo{
+			Name: "ci",
+			Extra: map[string][]string{
+				accesstoken.WorkspaceExtraKey: {workspace},
+				accesstoken.RulesExtraKey: {
+					`{"verbs":["get","list"],"apiGroups":[""],"resources":["configmaps"]}`,
+					`{"verbs":["get"],"nonResourceURLs":["/api","/api/*"]}`,
+				},
+			},
+		}
+	}
+	getConfigMaps := authorizer.AttributesRecord{Verb: "list", Resource: "configmaps", ResourceRequest: true}
+
+	for name, tt := range map[string]struct {
+		requestedWorkspace string
+		path               string
+		requestingUser     *user.DefaultInfo
+		attr               authorizer.AttributesRecord
+		wantDecision       authorizer.Decision
+		wantReason         string
+	}{
+		"user without token is delegated": {
+			requestedWorkspace: "abc",
+			path:               "root:other",
+			requestingUser:     newUser("user"),
+			attr:               getConfigMaps,
+			wantDecision:       authorizer.DecisionAllow,
+			wantReason:         "delegating due to no workspace access token",
+		},
+		"token in workspace": {
+			requestedWorkspace: "abc",
+			path:               "root:org",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               getConfigMaps,
+			wantDecision:       authorizer.DecisionAllow,
+			wantReason:         "delegating due to workspace access token permits the request",
+		},
+		"token in descendant workspace": {
+			requestedWorkspace: "abc",
+			path:               "root:org:team:ws",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               getConfigMaps,
+			wantDecision:       authorizer.DecisionAllow,
+			wantReason:         "delegating due to workspace access token permits the request",
+		},
+		"token in sibling workspace with common prefix": {
+			requestedWorkspace: "abc",
+			path:               "root:organization",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               getConfigMaps,
+			wantDecision:       authorizer.DecisionDeny,
+			wantReason:         "workspace access token is restricted to workspace root:org and its descendants",
+		},
+		"token in parent workspace": {
+			requestedWorkspace: "abc",
+			path:               "root",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               getConfigMaps,
+			wantDecision:       authorizer.DecisionDeny,
+			wantReason:         "workspace access token is restricted to workspace root:org and its descendants",
+		},
+		"token without workspace": {
+			requestingUser: newTokenUser("root:org"),
+			attr:           getConfigMaps,
+			wantDecision:   authorizer.DecisionDeny,
+			wantReason:     "workspace access tokens are restricted to a workspace",
+		},
+		"token for other resource": {
+			requestedWorkspace: "abc",
+			path:               "root:org",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               authorizer.AttributesRecord{Verb: "get", Resource: "secrets", ResourceRequest: true},
+			wantDecision:       authorizer.DecisionDeny,
+			wantReason:         "workspace access token does not permit the request",
+		},
+		"token for other verb": {
+			requestedWorkspace: "abc",
+			path:               "root:org",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               authorizer.AttributesRecord{Verb: "delete", Resource: "configmaps", ResourceRequest: true},
+			wantDecision:       authorizer.DecisionDeny,
+			wantReason:         "workspace access token does not permit the request",
+		},
+		"token for discovery": {
+			requestedWorkspace: "abc",
+			path:               "root:org",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               authorizer.AttributesRecord{Verb: "get", Path: "/api/v1"},
+			wantDecision:       authorizer.DecisionAllow,
+			wantReason:         "delegating due to workspace access token permits the request",
+		},
+		"token for impersonation": {
+			requestedWorkspace: "abc",
+			path:               "root:org",
+			requestingUser:     newTokenUser("root:org"),
+			attr:               authorizer.AttributesRecord{Verb: "impersonate", Resource: "users", ResourceRequest: true},
+			wantDecision:       authorizer.DecisionDeny,
+			wantReason:         "impersonation is not permitted with workspace access tokens",
+		},
+		"token for logical cluster by name": {
+			requestedWorkspace: "abc",
+			requestingUser:     newTokenUser("abc"),
+			attr:               getConfigMaps,
+			wantDecision:       authorizer.DecisionAllow,
+			wantReason:         "delegating due to workspace access token permits the request",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.requestedWorkspace != "" {
+				ctx = request.WithCluster(ctx, request.Cluster{
+					Name: logicalcluster.Name(tt.requestedWorkspace),
+				})
+			}
+
+			tt.attr.User = tt.requestingUser
+			authz := workspaceAccessTokenAuthorizer{
+				getLogicalCluster: func(logicalCluster logicalcluster.Name) (*v1alpha1.LogicalCluster, error) {
+					if tt.path == "" {
+						return nil, apierrors.NewNotFound(v1alpha1.Resource("logicalclusters"), "cluster")
+					}
+					return &v1alpha1.LogicalCluster{
+						ObjectMeta: v1.ObjectMeta{
+							Annotations: map[string]string{"kcp.io/path": tt.path},
+						},
+					}, nil
+				},
+				delegate: &recordingAuthorizer{decision: authorizer.DecisionAllow, reason: "allowed"},
+			}
+
+			gotDecision, gotReason, err := authz.Authorize(ctx, tt.attr)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if gotReason != tt.wantReason {
+				t.Errorf("want reason %q, got %q", tt.wantReason, gotReason)
+			}
+			if gotDecision != tt.wantDecision {
+				t.Errorf("want decision %v, got %v", tt.wantDecision, gotDecision)
+			}
+		})
+	}
+}
@@ -44,7 +44,7 @@ const (
 	WorkspaceAccessNotPermittedReason = "workspace access not permitted"
 )
 
-func NewWorkspaceContentAuthorizer(localInformers, globalInformers kcpkubernetesinformers.SharedInformerFactory, localLogicalClusterLister, globalLogicalClusterLister corev1alpha1listers.LogicalClusterClusterLister, delegate authorizer.Authorizer) authorizer.Authorizer {
+func NewWorkspaceContentAuthorizer(localInformers, globalInformers kcpkubernetesinformers.SharedInformerFactory, localLogicalClusterLister, globalLogicalClusterLister corev1alpha1listers.LogicalClusterClusterLister, inherited, delegate authorizer.Authorizer) authorizer.Authorizer {
 	return &workspaceContentAuthorizer{
 		localClusterRoleLister:        localInformers.Rbac().V1().ClusterRoles().Lister(),
 		localClusterRoleBindingLister: localInformers.Rbac().V1().ClusterRoleBindings().Lister(),
@@ -62,7 +62,8 @@ func NewWorkspaceContentAuthorizer(localInformers, globalInformers kcpkubernetes
 			return obj, nil
 		},
 
-		delegate: delegate,
+		inherited: inherited,
+		delegate:  delegate,
 	}
 }
 
@@ -75,7 +76,9 @@ type workspaceContentAuthorizer struct {
 
 	getLogicalCluster func(logicalCluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
 
-	delegate authorizer.Authorizer
+	// inherited optionally authorizes access through bindings inherited from parent workspaces.
+	inherited authorizer.Authorizer
+	delegate  authorizer.Authorizer
 }
 
 func (a *workspaceContentAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
@@ -165,6 +168,11 @@ func (a *workspaceContentAuthorizer) Authorize(ctx context.Context, attr authori
 		if err != nil {
 			return authorizer.DecisionNoOpinion, fmt.Sprintf("errors from workspace content authorizer: %v", err), err
 		}
+		if dec != authorizer.DecisionAllow && a.inherited != nil {
+			if dec, _, err = a.inherited.Authorize(ctx, workspaceAttr); err != nil {
+				return authorizer.DecisionNoOpinion, fmt.Sprintf("errors from workspace content authorizer: %v", err), err
+			}
+		}
 		if dec != authorizer.DecisionAllow {
 			return dec, "no verb=access permission on /", nil
 		}
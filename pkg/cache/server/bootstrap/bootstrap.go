@@ -50,7 +50,11 @@ func Bootstrap(ctx context.Context, apiExtensionsClusterClient kcpapiextensionsc
 		{"apis.kcp.io", "apiexports"},
 		{"core.kcp.io", "logicalclusters"},
 		{"core.kcp.io", "shards"},
+		{"core.kcp.io", "shardbackups"},
 		{"tenancy.kcp.io", "workspacetypes"},
+		{"tenancy.kcp.io", "workspacequotas"},
+		{"tenancy.kcp.io", "auditsinks"},
+		{"tenancy.kcp.io", "referencegrants"},
 		{"rbac.authorization.k8s.io", "roles"},
 		{"rbac.authorization.k8s.io", "clusterroles"},
 		{"rbac.authorization.k8s.io", "rolebindings"},
@@ -41,6 +41,8 @@ import (
 
 type Config struct {
 	*embed.Config
+
+	Defrag DefragConfig
 }
 
 func NewConfig(o options.CompletedOptions, enableWatchCache bool) (*Config, error) {
@@ -109,8 +111,18 @@ func NewConfig(o options.CompletedOptions, enableWatchCache bool) (*Config, erro
 		cfg.QuotaBackendBytes = o.QuotaBackendBytes
 	}
 
+	if o.AutoCompactionRetention > 0 {
+		cfg.AutoCompactionMode = embed.CompactorModePeriodic
+		cfg.AutoCompactionRetention = o.AutoCompactionRetention.String()
+	}
+
 	return &Config{
 		Config: cfg,
+		Defrag: DefragConfig{
+			Interval:       o.DefragInterval,
+			MinDBSizeBytes: o.DefragMinDBSizeBytes,
+			MinFreePercent: o.DefragMinFreePercent,
+		},
 	}, nil
 }
 
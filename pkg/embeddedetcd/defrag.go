@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package embeddedetcd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+)
+
+// DefragConfig configures the periodic defragmentation of the embedded etcd database.
+// Compaction only marks the pages of old revisions as free, defragmentation rewrites the
+// database to give them back to the file system.
+type DefragConfig struct {
+	// Interval is the interval to check the database size. Zero disables defragmentation.
+	Interval time.Duration
+	// MinDBSizeBytes is the size below which the database is never defragmented.
+	MinDBSizeBytes int64
+	// MinFreePercent is the percentage of the database that must be free to defragment.
+	MinFreePercent int
+}
+
+// backend is the part of the etcd backend used for defragmentation.
+type backend interface {
+	Size() int64
+	SizeInUse() int64
+	Defrag() error
+}
+
+type defragmenter struct {
+	config  DefragConfig
+	backend backend
+}
+
+func newDefragmenter(config DefragConfig, backend backend) *defragmenter {
+	return &defragmenter{config: config, backend: backend}
+}
+
+func (d *defragmenter) run(ctx context.Context) {
+	logger := klog.FromContext(ctx).WithName("embedded-etcd-defrag")
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.maybeDefrag(logger)
+		}
+	}
+}
+
+// maybeDefrag defragments the database if it is big enough and has enough free pages. It
+// returns whether it defragmented.
+func (d *defragmenter) maybeDefrag(logger klog.Logger) bool {
+	size, inUse := d.backend.Size(), d.backend.SizeInUse()
+	dbSize.Set(float64(size))
+	dbSizeInUse.Set(float64(inUse))
+
+	if size < d.config.MinDBSizeBytes || size == 0 || (size-inUse)*100/size < int64(d.config.MinFreePercent) {
+		return false
+	}
+
+	logger.Info("Defragmenting embedded etcd database", "size", size, "sizeInUse", inUse)
+	start := time.Now()
+	err := d.backend.Defrag()
+	defragDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		defragmentations.WithLabelValues("failure").Inc()
+		logger.Error(err, "Failed to defragment embedded etcd database")
+		return false
+	}
+	defragmentations.WithLabelValues("success").Inc()
+	dbSize.Set(float64(d.backend.Size()))
+	dbSizeInUse.Set(float64(d.backend.SizeInUse()))
+	logger.Info("Defragmented embedded etcd database", "size", d.backend.Size(), "duration", time.Since(start))
+	return true
+}
+
+var (
+	dbSize = compbasemetrics.NewGauge(
+		&compbasemetrics.GaugeOpts{
+			Name:           "embedded_etcd_db_size_bytes",
+			Help:           "Size of the embedded etcd database file, as of the last defragmentation check.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+
+	dbSizeInUse = compbasemetrics.NewGauge(
+		&compbasemetrics.GaugeOpts{
+			Name:           "embedded_etcd_db_size_in_use_bytes",
+			Help:           "Size of the embedded etcd database in use, as of the last defragmentation check.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+
+	defragmentations = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "embedded_etcd_defragmentations_total",
+			Help:           "Number of defragmentations of the embedded etcd database, by result.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+
+	defragDuration = compbasemetrics.NewHistogram(
+		&compbasemetrics.HistogramOpts{
+			Name:           "embedded_etcd_defragmentation_duration_seconds",
+			Help:           "Duration of the defragmentations of the embedded etcd database.",
+			Buckets:        []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register metrics.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(dbSize)
+		legacyregistry.MustRegister(dbSizeInUse)
+		legacyregistry.MustRegister(defragmentations)
+		legacyregistry.MustRegister(defragDuration)
+	})
+}
+
+func init() {
+	Register()
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package embeddedetcd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/klog/v2"
+)
+
+type fakeBackend struct {
+	size, inUse int64
+	err         error
+	defrags     int
+}
+
+func (b *fakeBackend) Size() int64      { return b.size }
+func (b *fakeBackend) SizeInUse() int64 { return b.inUse }
+func (b *fakeBackend) Defrag() error {
+	b.defrags++
+	if b.err == nil {
+		b.size = b.inUse
+	}
+	return b.err
+}
+
+func TestMaybeDefrag(t *testing.T) {
+	config := DefragConfig{MinDBSizeBytes: 100, MinFreePercent: 50}
+	tests := map[string]struct {
+		backend fakeBackend
+		want    bool
+	}{
+		"too small":            {backend: fakeBackend{size: 99, inUse: 1}},
+		"not enough free":      {backend: fakeBackend{size: 1000, inUse: 501}},
+		"enough free":          {backend: fakeBackend{size: 1000, inUse: 500}, want: true},
+		"failing":              {backend: fakeBackend{size: 1000, inUse: 100, err: errors.New("boom")}},
+		"empty, never defrags": {backend: fakeBackend{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			backend := tt.backend
+			got := newDefragmenter(config, &backend).maybeDefrag(klog.Background())
+			require.Equal(t, tt.want, got)
+			if tt.want {
+				require.Equal(t, 1, backend.defrags)
+				require.Equal(t, backend.inUse, backend.size)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package options
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/pflag"
 	etcdtypes "go.etcd.io/etcd/client/pkg/v3/types"
@@ -36,6 +37,17 @@ type Options struct {
 	WalSizeBytes      int64
 	QuotaBackendBytes int64
 	ForceNewCluster   bool
+
+	// AutoCompactionRetention enables the periodic compaction of etcd itself, keeping the
+	// history of the given duration. Zero disables it.
+	AutoCompactionRetention time.Duration
+	// DefragInterval is the interval to check whether the database should be defragmented.
+	// Zero disables defragmentation.
+	DefragInterval time.Duration
+	// DefragMinDBSizeBytes is the database size below which it is never defragmented.
+	DefragMinDBSizeBytes int64
+	// DefragMinFreePercent is the percentage of the database size that must be free to defragment.
+	DefragMinFreePercent int
 }
 
 func NewOptions(rootDir string) *Options {
@@ -43,6 +55,9 @@ func NewOptions(rootDir string) *Options {
 		Directory:  filepath.Join(rootDir, "etcd-server"),
 		PeerPort:   "2380",
 		ClientPort: "2379",
+
+		DefragMinDBSizeBytes: 100 * 1024 * 1024,
+		DefragMinFreePercent: 50,
 	}
 }
 
@@ -54,6 +69,10 @@ func (e *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.Int64Var(&e.WalSizeBytes, "embedded-etcd-wal-size-bytes", e.WalSizeBytes, "Size of embedded etcd WAL")
 	fs.Int64Var(&e.QuotaBackendBytes, "embedded-etcd-quota-backend-bytes", e.WalSizeBytes, "Alarm threshold for embedded etcd backend bytes")
 	fs.BoolVar(&e.ForceNewCluster, "embedded-etcd-force-new-cluster", e.ForceNewCluster, "Starts a new cluster from existing data restored from a different system")
+	fs.DurationVar(&e.AutoCompactionRetention, "embedded-etcd-auto-compaction-retention", e.AutoCompactionRetention, "Periodically compact the embedded etcd, keeping the revisions of the given duration, in addition to the compaction of the apiserver storage. Zero disables it.")
+	fs.DurationVar(&e.DefragInterval, "embedded-etcd-defrag-interval", e.DefragInterval, "Interval to check whether the embedded etcd database should be defragmented. Defragmentation blocks requests to etcd while it runs. Zero disables it.")
+	fs.Int64Var(&e.DefragMinDBSizeBytes, "embedded-etcd-defrag-min-db-size-bytes", e.DefragMinDBSizeBytes, "Minimum size of the embedded etcd database to be defragmented")
+	fs.IntVar(&e.DefragMinFreePercent, "embedded-etcd-defrag-min-free-percent", e.DefragMinFreePercent, "Minimum percentage of the embedded etcd database size that must be free, i.e. reclaimable by a defragmentation")
 }
 
 type completedOptions struct {
@@ -88,6 +107,18 @@ func (e *Options) Validate() []error {
 		if e.ClientPort == "" {
 			errs = append(errs, fmt.Errorf("--embedded-etcd-client-port must be specified"))
 		}
+		if e.AutoCompactionRetention < 0 {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-retention must not be negative"))
+		}
+		if e.DefragInterval < 0 {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-defrag-interval must not be negative"))
+		}
+		if e.DefragMinDBSizeBytes < 0 {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-defrag-min-db-size-bytes must not be negative"))
+		}
+		if e.DefragMinFreePercent < 0 || e.DefragMinFreePercent > 100 {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-defrag-min-free-percent must be between 0 and 100"))
+		}
 		if len(e.ListenMetricsURLs) > 0 {
 			_, err := etcdtypes.NewURLs(e.ListenMetricsURLs)
 			if err != nil {
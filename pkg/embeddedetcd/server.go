@@ -55,6 +55,9 @@ func (s *Server) Run(ctx context.Context) error {
 
 	select {
 	case <-e.Server.ReadyNotify():
+		if s.config.Defrag.Interval > 0 {
+			go newDefragmenter(s.config.Defrag, e.Server.Backend()).run(ctx)
+		}
 		return nil
 	case <-time.After(60 * time.Second):
 		e.Server.Stop() // trigger a shutdown
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+)
+
+// Entry is a single path of the index with the logical cluster and shard it maps to.
+// Mounted workspaces only have a URL.
+type Entry struct {
+	Path    string              `json:"path"`
+	Cluster logicalcluster.Name `json:"cluster,omitempty"`
+	Shard   string              `json:"shard,omitempty"`
+	URL     string              `json:"url,omitempty"`
+}
+
+// EventType is the type of a change of an Entry.
+type EventType string
+
+const (
+	Added    EventType = "ADDED"
+	Modified EventType = "MODIFIED"
+	Deleted  EventType = "DELETED"
+)
+
+// Event is a change of an Entry between two snapshots of the index.
+type Event struct {
+	Type  EventType `json:"type"`
+	Entry Entry     `json:"entry"`
+}
+
+// Entries returns a snapshot of all paths known to the index, sorted by path. The
+// paths start at the logical clusters without a parent workspace, e.g. root.
+func (c *State) Entries() []Entry {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	children := map[logicalcluster.Name]bool{}
+	for _, clusterParents := range c.shardClusterParentCluster {
+		for cluster := range clusterParents {
+			children[cluster] = true
+		}
+	}
+
+	var entries []Entry
+	visited := map[logicalcluster.Name]bool{}
+	var walk func(path logicalcluster.Path, cluster logicalcluster.Name)
+	walk = func(path logicalcluster.Path, cluster logicalcluster.Name) {
+		if visited[cluster] {
+			return
+		}
+		visited[cluster] = true
+
+		shard := c.clusterShards[cluster]
+		entry := Entry{Path: path.String(), Cluster: cluster, Shard: shard}
+		if baseURL, found := c.shardBaseURLs[shard]; found {
+			entry.URL = strings.TrimSuffix(baseURL, "/") + cluster.Path().RequestPath()
+		}
+		entries = append(entries, entry)
+
+		for name, mountURL := range c.clusterWorkspaceMountURL[cluster] {
+			entries = append(entries, Entry{Path: path.Join(name).String(), URL: mountURL})
+		}
+		for name, child := range c.shardWorkspaceNameCluster[shard][cluster] {
+			if _, mounted := c.clusterWorkspaceMountURL[cluster][name]; mounted {
+				continue
+			}
+			if _, found := c.clusterShards[child]; found {
+				walk(path.Join(name), child)
+			}
+		}
+	}
+	for cluster := range c.clusterShards {
+		if !children[cluster] {
+			walk(cluster.Path(), cluster)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries
+}
+
+// Subscribe returns a channel that receives a value whenever the index might have
+// changed. Notifications are coalesced, i.e. a slow receiver only misses intermediate
+// notifications, never the last one. The returned function ends the subscription.
+func (c *State) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+	if c.subscribers == nil {
+		c.subscribers = map[chan struct{}]struct{}{}
+	}
+	c.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		c.subscribersLock.Lock()
+		defer c.subscribersLock.Unlock()
+		delete(c.subscribers, ch)
+	}
+}
+
+func (c *State) notify() {
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+	for ch := range c.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Diff returns the events turning the sorted entries old into the sorted entries new.
+func Diff(old, new []Entry) []Event {
+	var events []Event
+	i, j := 0, 0
+	for i < len(old) || j < len(new) {
+		switch {
+		case j == len(new) || (i < len(old) && old[i].Path < new[j].Path):
+			events = append(events, Event{Type: Deleted, Entry: old[i]})
+			i++
+		case i == len(old) || new[j].Path < old[i].Path:
+			events = append(events, Event{Type: Added, Entry: new[j]})
+			j++
+		default:
+			if old[i] != new[j] {
+				events = append(events, Event{Type: Modified, Entry: new[j]})
+			}
+			i++
+			j++
+		}
+	}
+	return events
+}
@@ -25,6 +25,7 @@ import (
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // Index implements a mapping from logical cluster to (shard) URL.
@@ -57,7 +58,7 @@ func New(rewriters []PathRewriter) *State {
 		// Experimental feature: allow mounts to be used with Workspaces
 		// structure: (clusterName, workspace name) -> string serialized mount objects
 		// This should be simplified once we promote this to workspace structure.
-		clusterWorkspaceMountAnnotation: map[logicalcluster.Name]map[string]string{},
+		clusterWorkspaceMountURL: map[logicalcluster.Name]map[string]string{},
 	}
 }
 
@@ -74,7 +75,10 @@ type State struct {
 	shardClusterParentCluster map[string]map[logicalcluster.Name]logicalcluster.Name            // (shard name, logical cluster) -> parent logical cluster
 	shardBaseURLs             map[string]string                                                 // shard name -> base URL
 	// Experimental feature: allow mounts to be used with Workspaces
-	clusterWorkspaceMountAnnotation map[logicalcluster.Name]map[string]string // (clusterName, workspace name) -> mount object string
+	clusterWorkspaceMountURL map[logicalcluster.Name]map[string]string // (clusterName, workspace name) -> mount URL
+
+	subscribersLock sync.Mutex
+	subscribers     map[chan struct{}]struct{}
 }
 
 func (c *State) UpsertWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
@@ -85,18 +89,19 @@ func (c *State) UpsertWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
 
 	c.lock.RLock()
 	cluster := c.shardWorkspaceNameCluster[shard][clusterName][ws.Name]
-	mountObjString := c.clusterWorkspaceMountAnnotation[clusterName][ws.Name] // experimental feature
+	gotMountURL := c.clusterWorkspaceMountURL[clusterName][ws.Name] // experimental feature
 	c.lock.RUnlock()
 
-	// TODO(mjudeikis): we are allowing upsert in 2 cases:
+	// we are allowing upsert in 2 cases:
 	// 1. cluster name is different
-	// 2. mount object string is different (updated, added, or removed)
-	// When we promote this to workspace structure, we should make this check smarter and better tested.
-	if (cluster.String() == ws.Spec.Cluster) && (ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey] != "" && mountObjString == ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey]) {
+	// 2. mount URL is different (updated, added, or removed)
+	mountURL := readyMountURL(ws)
+	if cluster.String() == ws.Spec.Cluster && gotMountURL == mountURL {
 		return
 	}
 
 	c.lock.Lock()
+	defer c.notify()
 	defer c.lock.Unlock()
 
 	if cluster := c.shardWorkspaceNameCluster[shard][clusterName][ws.Name]; cluster.String() != ws.Spec.Cluster {
@@ -113,12 +118,29 @@ func (c *State) UpsertWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
 		c.shardClusterParentCluster[shard][logicalcluster.Name(ws.Spec.Cluster)] = clusterName
 	}
 
-	if mountObjString := c.clusterWorkspaceMountAnnotation[clusterName][ws.Name]; mountObjString != ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey] {
-		if c.clusterWorkspaceMountAnnotation[clusterName] == nil {
-			c.clusterWorkspaceMountAnnotation[clusterName] = map[string]string{}
+	if mountURL == "" {
+		delete(c.clusterWorkspaceMountURL[clusterName], ws.Name)
+		if len(c.clusterWorkspaceMountURL[clusterName]) == 0 {
+			delete(c.clusterWorkspaceMountURL, clusterName)
+		}
+	} else {
+		if c.clusterWorkspaceMountURL[clusterName] == nil {
+			c.clusterWorkspaceMountURL[clusterName] = map[string]string{}
 		}
-		c.clusterWorkspaceMountAnnotation[clusterName][ws.Name] = ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey]
+		c.clusterWorkspaceMountURL[clusterName][ws.Name] = mountURL
+	}
+}
+
+// readyMountURL returns the URL of the mount of the given workspace, or an empty
+// string if the workspace is not mounted or the mount is not ready.
+func readyMountURL(ws *tenancyv1alpha1.Workspace) string {
+	if ws.GetMount() == nil || ws.Status.Mount == nil {
+		return ""
+	}
+	if !conditions.IsTrue(ws, tenancyv1alpha1.MountConditionReady) {
+		return ""
 	}
+	return ws.Status.Mount.URL
 }
 
 func (c *State) DeleteWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
@@ -126,7 +148,7 @@ func (c *State) DeleteWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
 
 	c.lock.RLock()
 	_, foundCluster := c.shardWorkspaceNameCluster[shard][clusterName][ws.Name]
-	_, foundMount := c.clusterWorkspaceMountAnnotation[clusterName][ws.Name]
+	_, foundMount := c.clusterWorkspaceMountURL[clusterName][ws.Name]
 	c.lock.RUnlock()
 
 	if !foundCluster && !foundMount {
@@ -134,6 +156,7 @@ func (c *State) DeleteWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
 	}
 
 	c.lock.Lock()
+	defer c.notify()
 	defer c.lock.Unlock()
 
 	if _, foundCluster = c.shardWorkspaceNameCluster[shard][clusterName][ws.Name]; foundCluster {
@@ -145,21 +168,24 @@ func (c *State) DeleteWorkspace(shard string, ws *tenancyv1alpha1.Workspace) {
 			delete(c.shardWorkspaceNameCluster, shard)
 		}
 
-		delete(c.shardWorkspaceName[shard], logicalcluster.Name(ws.Spec.Cluster))
-		if len(c.shardWorkspaceName[shard]) == 0 {
-			delete(c.shardWorkspaceName, shard)
-		}
+		// after a move, the logical cluster belongs to another workspace already.
+		if c.shardClusterParentCluster[shard][logicalcluster.Name(ws.Spec.Cluster)] == clusterName && c.shardWorkspaceName[shard][logicalcluster.Name(ws.Spec.Cluster)] == ws.Name {
+			delete(c.shardWorkspaceName[shard], logicalcluster.Name(ws.Spec.Cluster))
+			if len(c.shardWorkspaceName[shard]) == 0 {
+				delete(c.shardWorkspaceName, shard)
+			}
 
-		delete(c.shardClusterParentCluster[shard], logicalcluster.Name(ws.Spec.Cluster))
-		if len(c.shardClusterParentCluster[shard]) == 0 {
-			delete(c.shardClusterParentCluster, shard)
+			delete(c.shardClusterParentCluster[shard], logicalcluster.Name(ws.Spec.Cluster))
+			if len(c.shardClusterParentCluster[shard]) == 0 {
+				delete(c.shardClusterParentCluster, shard)
+			}
 		}
 	}
 
-	if _, foundMount = c.clusterWorkspaceMountAnnotation[clusterName][ws.Name]; foundMount {
-		delete(c.clusterWorkspaceMountAnnotation[clusterName], ws.Name)
-		if len(c.clusterWorkspaceMountAnnotation[clusterName]) == 0 {
-			delete(c.clusterWorkspaceMountAnnotation, clusterName)
+	if _, foundMount = c.clusterWorkspaceMountURL[clusterName][ws.Name]; foundMount {
+		delete(c.clusterWorkspaceMountURL[clusterName], ws.Name)
+		if len(c.clusterWorkspaceMountURL[clusterName]) == 0 {
+			delete(c.clusterWorkspaceMountURL, clusterName)
 		}
 	}
 }
@@ -172,7 +198,13 @@ func (c *State) UpsertLogicalCluster(shard string, logicalCluster *corev1alpha1.
 	c.lock.RUnlock()
 
 	if got != shard {
+		// during a migration to another shard, an unavailable copy of the logical cluster
+		// exists on both shards. Only the ready one takes over.
+		if got != "" && logicalCluster.Status.Phase == corev1alpha1.LogicalClusterPhaseUnavailable {
+			return
+		}
 		c.lock.Lock()
+		defer c.notify()
 		defer c.lock.Unlock()
 		c.clusterShards[clusterName] = shard
 	}
@@ -187,6 +219,7 @@ func (c *State) DeleteLogicalCluster(shard string, logicalCluster *corev1alpha1.
 
 	if got == shard {
 		c.lock.Lock()
+		defer c.notify()
 		defer c.lock.Unlock()
 		if got := c.clusterShards[clusterName]; got == shard {
 			delete(c.clusterShards, clusterName)
@@ -201,13 +234,31 @@ func (c *State) UpsertShard(shardName, baseURL string) {
 
 	if got != baseURL {
 		c.lock.Lock()
+		defer c.notify()
 		defer c.lock.Unlock()
 		c.shardBaseURLs[shardName] = baseURL
 	}
 }
 
+// DisableShard stops resolving paths to the logical clusters of the shard, e.g. because
+// the shard is not ready, until UpsertShard is called again. The logical clusters and
+// workspaces of the shard are kept.
+func (c *State) DisableShard(shardName string) {
+	c.lock.RLock()
+	_, found := c.shardBaseURLs[shardName]
+	c.lock.RUnlock()
+
+	if found {
+		c.lock.Lock()
+		defer c.notify()
+		defer c.lock.Unlock()
+		delete(c.shardBaseURLs, shardName)
+	}
+}
+
 func (c *State) DeleteShard(shardName string) {
 	c.lock.Lock()
+	defer c.notify()
 	defer c.lock.Unlock()
 
 	for lc, gotShardName := range c.clusterShards {
@@ -221,6 +272,18 @@ func (c *State) DeleteShard(shardName string) {
 	delete(c.shardClusterParentCluster, shardName)
 }
 
+// ShardBaseURLs returns the base URLs of all routable shards by shard name.
+func (c *State) ShardBaseURLs() map[string]string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	urls := make(map[string]string, len(c.shardBaseURLs))
+	for name, url := range c.shardBaseURLs {
+		urls[name] = strings.TrimSuffix(url, "/")
+	}
+	return urls
+}
+
 func (c *State) Lookup(path logicalcluster.Path) (Result, bool) {
 	segments := strings.Split(path.String(), ":")
 
@@ -247,17 +310,13 @@ func (c *State) Lookup(path logicalcluster.Path) (Result, bool) {
 		}
 
 		// check mounts, if found return url and true
-		val, foundMount := c.clusterWorkspaceMountAnnotation[cluster][s] // experimental feature
+		val, foundMount := c.clusterWorkspaceMountURL[cluster][s] // experimental feature
 		if foundMount {
-			mount, err := tenancyv1alpha1.ParseTenancyMountAnnotation(val)
-			if !(err != nil || mount == nil || mount.MountStatus.URL == "") {
-				url, err := url.Parse(mount.MountStatus.URL)
-				if err != nil {
-					// default to workspace itself.
-				} else {
-					return Result{URL: url.String()}, true
-				}
+			url, err := url.Parse(val)
+			if err == nil {
+				return Result{URL: url.String()}, true
 			}
+			// default to workspace itself.
 		}
 
 		var found bool
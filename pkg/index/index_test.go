@@ -19,12 +19,15 @@ package index
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 type shardStub struct {
@@ -245,9 +248,7 @@ func TestLookup(t *testing.T) {
 			},
 			initialWorkspacesToUpsert: map[string][]*tenancyv1alpha1.Workspace{
 				"root": {newWorkspace("org", "root", "one")},
-				"beta": {newWorkspaceWithAnnotation("rh", "one", "two", map[string]string{
-					"experimental.tenancy.kcp.io/mount": `{"spec":{"ref":{"kind":"KubeCluster","name":"prod-cluster","apiVersion":"proxy.kcp.dev/v1alpha1"}},"status":{"phase":"Ready","url":"https://kcp.dev.local/services/custom-url/proxy"}}`,
-				})},
+				"beta": {newMountedWorkspace("rh", "one", "two", "https://kcp.dev.local/services/custom-url/proxy")},
 			},
 			initialLogicalClustersToUpsert: map[string][]*corev1alpha1.LogicalCluster{
 				"root": {newLogicalCluster("root")},
@@ -377,6 +378,41 @@ func TestDeleteWorkspace(t *testing.T) {
 	validateLookupOutput(t, logicalcluster.NewPath("root:org"), r.Shard, r.Cluster, r.URL, found, "root", "43", "", true)
 }
 
+func TestMoveWorkspace(t *testing.T) {
+	target := New(nil)
+
+	target.UpsertShard("root", "https://root.io")
+	target.UpsertLogicalCluster("root", newLogicalCluster("root"))
+	target.UpsertWorkspace("root", newWorkspace("org1", "root", "o1"))
+	target.UpsertWorkspace("root", newWorkspace("org2", "root", "o2"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("o1"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("o2"))
+	target.UpsertWorkspace("root", newWorkspace("team", "o1", "34"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("34"))
+
+	// during the move, both paths resolve to the same logical cluster
+	target.UpsertWorkspace("root", newWorkspace("renamed", "o2", "34"))
+
+	r, found := target.Lookup(logicalcluster.NewPath("root:org1:team"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org1:team"), r.Shard, r.Cluster, r.URL, found, "root", "34", "", true)
+	r, found = target.Lookup(logicalcluster.NewPath("root:org2:renamed"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org2:renamed"), r.Shard, r.Cluster, r.URL, found, "root", "34", "", true)
+
+	// deleting the moved workspace keeps the new path
+	target.DeleteWorkspace("root", newWorkspace("team", "o1", "34"))
+
+	r, found = target.Lookup(logicalcluster.NewPath("root:org1:team"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org1:team"), r.Shard, r.Cluster, r.URL, found, "", "", "", false)
+	r, found = target.Lookup(logicalcluster.NewPath("root:org2:renamed"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org2:renamed"), r.Shard, r.Cluster, r.URL, found, "root", "34", "", true)
+	if name := target.shardWorkspaceName["root"]["34"]; name != "renamed" {
+		t.Errorf("unexpected workspace name = %v, expected = renamed", name)
+	}
+	if parent := target.shardClusterParentCluster["root"]["34"]; parent != "o2" {
+		t.Errorf("unexpected parent cluster = %v, expected = o2", parent)
+	}
+}
+
 func TestUpsertLogicalCluster(t *testing.T) {
 	target := New(nil)
 
@@ -392,6 +428,13 @@ func TestUpsertLogicalCluster(t *testing.T) {
 	target.UpsertLogicalCluster("amber", newLogicalCluster("34"))
 	r, found = target.Lookup(logicalcluster.NewPath("root:org"))
 	validateLookupOutput(t, logicalcluster.NewPath("root:org"), r.Shard, r.Cluster, r.URL, found, "amber", "34", "", true)
+
+	// an unavailable copy on another shard does not take over
+	unavailable := newLogicalCluster("34")
+	unavailable.Status.Phase = corev1alpha1.LogicalClusterPhaseUnavailable
+	target.UpsertLogicalCluster("root", unavailable)
+	r, found = target.Lookup(logicalcluster.NewPath("root:org"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org"), r.Shard, r.Cluster, r.URL, found, "amber", "34", "", true)
 }
 
 // Since LookupURL uses Lookup method the following test is just a smoke tests.
@@ -446,6 +489,37 @@ func TestUpsertShard(t *testing.T) {
 	}
 }
 
+func TestDisableShard(t *testing.T) {
+	target := New(nil)
+
+	target.UpsertShard("root", "https://root.io")
+	target.UpsertWorkspace("root", newWorkspace("org", "root", "34"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("root"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("34"))
+
+	target.UpsertShard("beta", "https://beta.io/")
+	if urls := target.ShardBaseURLs(); !cmp.Equal(urls, map[string]string{"root": "https://root.io", "beta": "https://beta.io"}) {
+		t.Fatalf("unexpected shard URLs %v", urls)
+	}
+
+	target.DisableShard("root")
+	if _, found := target.LookupURL(logicalcluster.NewPath("root:org")); found {
+		t.Fatalf("expected not to find a URL for %q path of a disabled shard", "root:org")
+	}
+	if urls := target.ShardBaseURLs(); !cmp.Equal(urls, map[string]string{"beta": "https://beta.io"}) {
+		t.Fatalf("unexpected shard URLs %v after disabling a shard", urls)
+	}
+
+	target.UpsertShard("root", "https://root.io")
+	r, found := target.LookupURL(logicalcluster.NewPath("root:org"))
+	if !found {
+		t.Fatalf("expected to find a URL for %q path", "root:org")
+	}
+	if r.URL != "https://root.io/clusters/34" {
+		t.Fatalf("unexpected url = %v returned, expected = %v for %q path", r.URL, "https://root.io/clusters/34", "root:org")
+	}
+}
+
 func TestUpsertWorkspace(t *testing.T) {
 	target := New(nil)
 
@@ -462,12 +536,61 @@ func TestUpsertWorkspace(t *testing.T) {
 	r, found = target.Lookup(logicalcluster.NewPath("root:org"))
 	validateLookupOutput(t, logicalcluster.NewPath("root:org"), r.Shard, r.Cluster, r.URL, found, "root", "44", "", true)
 
-	// Upsert workspace with changed mount annotation
-	target.UpsertWorkspace("root", newWorkspaceWithAnnotation("org", "root", "44", map[string]string{
-		"experimental.tenancy.kcp.io/mount": `{"spec":{"ref":{"kind":"KubeCluster","name":"prod-cluster","apiVersion":"proxy.kcp.dev/v1alpha1"}},"status":{"phase":"Ready","url":"https://kcp.dev.local/services/custom-url/proxy"}}`,
-	}))
+	// Upsert workspace with a ready mount
+	target.UpsertWorkspace("root", newMountedWorkspace("org", "root", "44", "https://kcp.dev.local/services/custom-url/proxy"))
 	r, found = target.Lookup(logicalcluster.NewPath("root:org"))
 	validateLookupOutput(t, logicalcluster.NewPath("root:org"), r.Shard, r.Cluster, r.URL, found, "", "", "https://kcp.dev.local/services/custom-url/proxy", true)
+
+	// Upsert workspace with a mount that is not ready anymore
+	ws := newMountedWorkspace("org", "root", "44", "https://kcp.dev.local/services/custom-url/proxy")
+	conditions.MarkFalse(ws, tenancyv1alpha1.MountConditionReady, tenancyv1alpha1.MountObjectNotReadyReason, conditionsv1alpha1.ConditionSeverityWarning, "not ready")
+	target.UpsertWorkspace("root", ws)
+	r, found = target.Lookup(logicalcluster.NewPath("root:org"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org"), r.Shard, r.Cluster, r.URL, found, "root", "44", "", true)
+}
+
+func TestEntries(t *testing.T) {
+	target := New(nil)
+	ch, cancel := target.Subscribe()
+	defer cancel()
+
+	target.UpsertShard("root", "https://root.io")
+	target.UpsertShard("beta", "https://beta.io")
+	target.UpsertLogicalCluster("root", newLogicalCluster("root"))
+	target.UpsertWorkspace("root", newWorkspace("org", "root", "34"))
+	target.UpsertLogicalCluster("beta", newLogicalCluster("34"))
+	target.UpsertWorkspace("beta", newWorkspace("team", "34", "44"))
+	target.UpsertLogicalCluster("beta", newLogicalCluster("44"))
+	target.UpsertWorkspace("root", newMountedWorkspace("mounted", "root", "", "https://mount.io"))
+	target.UpsertWorkspace("root", newWorkspace("unscheduled", "root", "55"))
+
+	select {
+	case <-ch:
+	default:
+		t.Fatal("expected a notification after changes to the index")
+	}
+
+	initial := target.Entries()
+	expected := []Entry{
+		{Path: "root", Cluster: "root", Shard: "root", URL: "https://root.io/clusters/root"},
+		{Path: "root:mounted", URL: "https://mount.io"},
+		{Path: "root:org", Cluster: "34", Shard: "beta", URL: "https://beta.io/clusters/34"},
+		{Path: "root:org:team", Cluster: "44", Shard: "beta", URL: "https://beta.io/clusters/44"},
+	}
+	if diff := cmp.Diff(expected, initial); diff != "" {
+		t.Fatalf("unexpected entries: %s", diff)
+	}
+
+	target.UpsertShard("beta", "https://new-beta.io")
+	target.DeleteWorkspace("beta", newWorkspace("team", "34", "44"))
+	target.DeleteLogicalCluster("beta", newLogicalCluster("44"))
+	expectedEvents := []Event{
+		{Type: Modified, Entry: Entry{Path: "root:org", Cluster: "34", Shard: "beta", URL: "https://new-beta.io/clusters/34"}},
+		{Type: Deleted, Entry: Entry{Path: "root:org:team", Cluster: "44", Shard: "beta", URL: "https://beta.io/clusters/44"}},
+	}
+	if diff := cmp.Diff(expectedEvents, Diff(initial, target.Entries())); diff != "" {
+		t.Fatalf("unexpected events: %s", diff)
+	}
 }
 
 func validateLookupOutput(t *testing.T, path logicalcluster.Path, shard string, cluster logicalcluster.Name, url string, found bool, expectedShard string, expectedCluster logicalcluster.Name, expectedURL string, expectToFind bool) {
@@ -495,11 +618,13 @@ func newWorkspace(name, cluster, scheduledCluster string) *tenancyv1alpha1.Works
 	}
 }
 
-func newWorkspaceWithAnnotation(name, cluster, scheduledCluster string, annotations map[string]string) *tenancyv1alpha1.Workspace {
+func newMountedWorkspace(name, cluster, scheduledCluster, mountURL string) *tenancyv1alpha1.Workspace {
 	ws := newWorkspace(name, cluster, scheduledCluster)
-	for k, v := range annotations {
-		ws.Annotations[k] = v
+	ws.Spec.Mount = &tenancyv1alpha1.Mount{
+		Reference: tenancyv1alpha1.ObjectReference{APIVersion: "proxy.kcp.dev/v1alpha1", Kind: "KubeCluster", Name: "prod-cluster"},
 	}
+	ws.Status.Mount = &tenancyv1alpha1.MountStatus{Phase: tenancyv1alpha1.MountPhaseReady, URL: mountURL}
+	conditions.MarkTrue(ws, tenancyv1alpha1.MountConditionReady)
 	return ws
 }
 
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/endpoints/responsewriter"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+)
+
+// AccessLogStdout is the access log sink writing to stdout.
+const AccessLogStdout = "stdout"
+
+// accessLogEntry is the JSON line logged per request.
+type accessLogEntry struct {
+	Time        time.Time `json:"time"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	Workspace   string    `json:"workspace,omitempty"`
+	Cluster     string    `json:"cluster,omitempty"`
+	Shard       string    `json:"shard,omitempty"`
+	User        string    `json:"user,omitempty"`
+	Verb        string    `json:"verb,omitempty"`
+	APIGroup    string    `json:"apiGroup,omitempty"`
+	Resource    string    `json:"resource,omitempty"`
+	Subresource string    `json:"subresource,omitempty"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Code        int       `json:"code"`
+	Latency     float64   `json:"latencySeconds"`
+
+	// shardURL is the URL the request was proxied to.
+	shardURL *url.URL
+}
+
+type accessLogKey int
+
+const accessLogContextKey accessLogKey = iota
+
+// accessLogger writes one JSON line per sampled request.
+type accessLogger struct {
+	index      index.Index
+	sampleRate float64
+	now        func() time.Time
+
+	lock sync.Mutex
+	out  io.Writer
+}
+
+// newAccessLogger returns an access logger writing to stdout or to the given file, logging
+// the given fraction of requests.
+func newAccessLogger(index index.Index, sink string, sampleRate float64) (*accessLogger, error) {
+	var out io.Writer = os.Stdout
+	if sink != AccessLogStdout {
+		f, err := os.OpenFile(sink, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open access log %q: %w", sink, err)
+		}
+		out = f
+	}
+	return &accessLogger{index: index, sampleRate: sampleRate, now: time.Now, out: out}, nil
+}
+
+// WithAccessLog logs the sampled requests served by the given handler. It must run after the
+// request info is set. The user and shard are filled in by withAccessLogDetails further down
+// the chain.
+func (l *accessLogger) WithAccessLog(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if l.sampleRate < 1 && rand.Float64() >= l.sampleRate {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		start := l.now()
+		entry := &accessLogEntry{
+			Time:      start,
+			Method:    req.Method,
+			Path:      req.URL.Path,
+			Workspace: clusterPathFromRequestPath(req.URL.Path),
+		}
+		if info, ok := request.RequestInfoFrom(req.Context()); ok {
+			entry.Verb = info.Verb
+			entry.APIGroup = info.APIGroup
+			entry.Resource = info.Resource
+			entry.Subresource = info.Subresource
+			entry.Namespace = info.Namespace
+			entry.Name = info.Name
+		}
+		recorder := &statusRecorder{ResponseWriter: w}
+		delegate.ServeHTTP(responsewriter.WrapForHTTP1Or2(recorder), req.WithContext(context.WithValue(req.Context(), accessLogContextKey, entry)))
+
+		entry.Code = recorder.code
+		if entry.Code == 0 {
+			entry.Code = http.StatusOK
+		}
+		entry.Latency = l.now().Sub(start).Seconds()
+		l.log(entry)
+	})
+}
+
+// withAccessLogDetails records the user and the shard URL of the request in its access log
+// entry, if it is logged.
+func withAccessLogDetails(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if entry, ok := req.Context().Value(accessLogContextKey).(*accessLogEntry); ok {
+			if user, ok := request.UserFrom(req.Context()); ok {
+				entry.User = user.GetName()
+			}
+			entry.shardURL = ShardURLFrom(req.Context())
+		}
+		delegate.ServeHTTP(w, req)
+	})
+}
+
+func (l *accessLogger) log(entry *accessLogEntry) {
+	if entry.shardURL != nil {
+		if clusterName, ok := clusterNameFromShardPath(entry.shardURL.Path); ok {
+			entry.Cluster = clusterName.String()
+		}
+		entry.Shard = entry.shardURL.Host
+		for shard, baseURL := range l.index.ShardBaseURLs() {
+			if u, err := url.Parse(baseURL); err == nil && u.Host == entry.shardURL.Host {
+				entry.Shard = shard
+				break
+			}
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	_, _ = l.out.Write(append(data, '\n'))
+}
+
+// statusRecorder records the status code of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	return r.ResponseWriter.Write(data)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestAccessLog(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := &accessLogger{
+		index:      &fakeIndex{shards: map[string]string{"alpha": "https://alpha:6443"}},
+		sampleRate: 1,
+		now: func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		},
+		out: &out,
+	}
+	handler := l.WithAccessLog(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := request.WithUser(req.Context(), &user.DefaultInfo{Name: "alice"})
+		ctx = WithShardURL(ctx, &url.URL{Scheme: "https", Host: "alpha:6443", Path: "/clusters/abc/api/v1/namespaces/default/configmaps/foo"})
+		withAccessLogDetails(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})).ServeHTTP(w, req.WithContext(ctx))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/clusters/root:org/api/v1/namespaces/default/configmaps/foo", nil)
+	req = req.WithContext(request.WithRequestInfo(req.Context(), &request.RequestInfo{
+		IsResourceRequest: true,
+		Verb:              "get",
+		Resource:          "configmaps",
+		Namespace:         "default",
+		Name:              "foo",
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	require.Equal(t, map[string]interface{}{
+		"time":           "2024-01-01T00:00:01Z",
+		"method":         "GET",
+		"path":           "/clusters/root:org/api/v1/namespaces/default/configmaps/foo",
+		"workspace":      "root:org",
+		"cluster":        "abc",
+		"shard":          "alpha",
+		"user":           "alice",
+		"verb":           "get",
+		"resource":       "configmaps",
+		"namespace":      "default",
+		"name":           "foo",
+		"code":           float64(http.StatusNotFound),
+		"latencySeconds": float64(1),
+	}, entry)
+
+	out.Reset()
+	l.sampleRate = 0
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Empty(t, out.String())
+}
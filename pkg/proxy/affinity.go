@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+)
+
+const (
+	// ShardAffinityCookie is the cookie pinning the requests of a browser session for a
+	// workspace to a shard.
+	ShardAffinityCookie = "kcp-shard-affinity"
+	// ShardAffinityHeader is the header pinning the requests of a client for a workspace to a
+	// shard, for clients without cookies. The front-proxy returns it on every response issuing
+	// a new affinity.
+	ShardAffinityHeader = "X-Kcp-Shard-Affinity"
+)
+
+// shardAffinity keeps the requests of a client for a workspace on the same shard for a while,
+// e.g. such that watches and subsequent requests are not split across the source and target
+// shard while a workspace is migrated. The affinity is a signed token naming the shard, the
+// logical cluster and the expiry time. It is ignored when it expired or the shard is no
+// longer in the index.
+type shardAffinity struct {
+	index index.Index
+	key   []byte
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// newShardAffinity returns a shardAffinity signing tokens with the key in the given file. If
+// no file is given, a random key is used, which is only known to this front-proxy replica.
+func newShardAffinity(index index.Index, ttl time.Duration, keyFile string) (*shardAffinity, error) {
+	var key []byte
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shard affinity key %q: %w", keyFile, err)
+		}
+		if key = bytes.TrimSpace(data); len(key) == 0 {
+			return nil, fmt.Errorf("shard affinity key %q is empty", keyFile)
+		}
+	} else {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate shard affinity key: %w", err)
+		}
+	}
+	return &shardAffinity{index: index, key: key, ttl: ttl, now: time.Now}, nil
+}
+
+// WithShardAffinity routes requests with a valid affinity to the shard of the affinity, and
+// issues a new affinity for the shard in the request context otherwise.
+func (a *shardAffinity) WithShardAffinity(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		shardURL := ShardURLFrom(req.Context())
+		if shardURL == nil {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+		clusterName, ok := clusterNameFromShardPath(shardURL.Path)
+		if !ok {
+			delegate.ServeHTTP(w, req) // mounts are not pinned
+			return
+		}
+		baseURLs := a.index.ShardBaseURLs()
+
+		if shard, ok := a.verify(affinityToken(req), clusterName); ok {
+			if baseURL, found := baseURLs[shard]; found {
+				if pinned, err := url.Parse(baseURL); err == nil {
+					pinned.Path = strings.TrimSuffix(pinned.Path, "/") + shardURL.Path[strings.Index(shardURL.Path, "/clusters/"):]
+					pinned.RawQuery = shardURL.RawQuery
+					delegate.ServeHTTP(w, req.WithContext(WithShardURL(req.Context(), pinned)))
+					return
+				}
+			}
+		}
+
+		for shard, baseURL := range baseURLs {
+			if u, err := url.Parse(baseURL); err != nil || u.Host != shardURL.Host {
+				continue
+			}
+			token := a.sign(clusterName, shard, a.now().Add(a.ttl))
+			w.Header().Set(ShardAffinityHeader, token)
+			http.SetCookie(w, &http.Cookie{
+				Name:     ShardAffinityCookie,
+				Value:    token,
+				Path:     "/clusters/" + clusterPathFromRequestPath(req.URL.Path),
+				MaxAge:   int(a.ttl.Seconds()),
+				Secure:   true,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+			break
+		}
+		delegate.ServeHTTP(w, req)
+	})
+}
+
+// affinityToken returns the affinity of the request, preferring the header over the cookie.
+func affinityToken(req *http.Request) string {
+	if token := req.Header.Get(ShardAffinityHeader); token != "" {
+		return token
+	}
+	if cookie, err := req.Cookie(ShardAffinityCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// sign returns a token of the form <expiry>.<signature>.<shard>.
+func (a *shardAffinity) sign(clusterName logicalcluster.Name, shard string, expiry time.Time) string {
+	expires := strconv.FormatInt(expiry.Unix(), 10)
+	return expires + "." + a.signature(clusterName, shard, expires) + "." + shard
+}
+
+// verify returns the shard of the token if it is valid for the logical cluster and did not expire.
+func (a *shardAffinity) verify(token string, clusterName logicalcluster.Name) (string, bool) {
+	expires, rest, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", false
+	}
+	signature, shard, ok := strings.Cut(rest, ".")
+	if !ok || shard == "" {
+		return "", false
+	}
+	if !hmac.Equal([]byte(signature), []byte(a.signature(clusterName, shard, expires))) {
+		return "", false
+	}
+	expiry, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !a.now().Before(time.Unix(expiry, 0)) {
+		return "", false
+	}
+	return shard, true
+}
+
+func (a *shardAffinity) signature(clusterName logicalcluster.Name, shard, expires string) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(clusterName.String() + "/" + shard + "/" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
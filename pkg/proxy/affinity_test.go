@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardAffinity(t *testing.T) {
+	idx := &fakeIndex{shards: map[string]string{"alpha": "https://alpha:6443", "beta": "https://beta:6443/prefix"}}
+	a, err := newShardAffinity(idx, time.Minute, "")
+	require.NoError(t, err)
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	var routed *url.URL
+	handler := a.WithShardAffinity(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		routed = ShardURLFrom(req.Context())
+	}))
+	serve := func(host string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/root:org/api/v1/configmaps?watch=true", nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		ctx := WithShardURL(req.Context(), &url.URL{Scheme: "https", Host: host, Path: "/clusters/abc/api/v1/configmaps", RawQuery: "watch=true"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	t.Log("A new affinity is issued for the current shard")
+	w := serve("alpha:6443", nil)
+	token := w.Header().Get(ShardAffinityHeader)
+	require.NotEmpty(t, token)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, token, cookies[0].Value)
+	require.Equal(t, "/clusters/root:org", cookies[0].Path)
+	require.Equal(t, "alpha:6443", routed.Host)
+
+	t.Log("Requests with the affinity stay on the shard after the workspace moved")
+	w = serve("beta:6443", http.Header{ShardAffinityHeader: {token}})
+	require.Empty(t, w.Header().Get(ShardAffinityHeader))
+	require.Equal(t, "https://alpha:6443/clusters/abc/api/v1/configmaps?watch=true", routed.String())
+
+	t.Log("The cookie is honored as well")
+	w = serve("beta:6443", http.Header{"Cookie": {ShardAffinityCookie + "=" + token}})
+	require.Empty(t, w.Header().Get(ShardAffinityHeader))
+	require.Equal(t, "alpha:6443", routed.Host)
+
+	t.Log("Tampered affinities are ignored")
+	forged := token[:len(token)-len("alpha")] + "beta"
+	w = serve("alpha:6443", http.Header{ShardAffinityHeader: {forged}})
+	require.NotEmpty(t, w.Header().Get(ShardAffinityHeader))
+	require.Equal(t, "alpha:6443", routed.Host)
+
+	t.Log("Expired affinities are replaced")
+	now = now.Add(2 * time.Minute)
+	w = serve("beta:6443", http.Header{ShardAffinityHeader: {token}})
+	require.NotEmpty(t, w.Header().Get(ShardAffinityHeader))
+	require.Equal(t, "beta:6443", routed.Host)
+
+	t.Log("The base URL of the shard of the affinity is used")
+	serve("alpha:6443", http.Header{ShardAffinityHeader: {w.Header().Get(ShardAffinityHeader)}})
+	require.Equal(t, "https://beta:6443/prefix/clusters/abc/api/v1/configmaps?watch=true", routed.String())
+
+	t.Log("Affinities to shards removed from the index are ignored")
+	token = a.sign("abc", "gamma", now.Add(time.Minute))
+	serve("alpha:6443", http.Header{ShardAffinityHeader: {token}})
+	require.Equal(t, "alpha:6443", routed.Host)
+}
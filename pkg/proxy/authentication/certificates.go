@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	x509request "k8s.io/apiserver/pkg/authentication/request/x509"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// CertificateMappingsReloadInterval is how often the certificate mappings file is checked for changes.
+const CertificateMappingsReloadInterval = 10 * time.Second
+
+// CertificateMappingsConfig maps client certificates to users and groups, e.g. for
+// machine-to-machine integrations.
+type CertificateMappingsConfig struct {
+	Mappings []CertificateMapping `json:"mappings"`
+}
+
+// CertificateMapping maps the client certificates matching all of its set patterns to a user.
+// Patterns use the path.Match syntax, e.g. "*.ci.example.com".
+type CertificateMapping struct {
+	// OrganizationalUnits are patterns of which one must match an organizational unit of the subject.
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+	// DNSNames are patterns of which one must match a DNS subject alternative name.
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// URIs are patterns of which one must match a URI subject alternative name.
+	URIs []string `json:"uris,omitempty"`
+
+	// User is the name of the user. Empty means the common name of the subject.
+	User string `json:"user,omitempty"`
+	// Groups are the groups of the user.
+	Groups []string `json:"groups,omitempty"`
+	// Workspaces are the workspace paths, including their descendants, the user is
+	// authenticated for. Empty means all workspaces.
+	Workspaces []string `json:"workspaces,omitempty"`
+}
+
+func (c *CertificateMappingsConfig) validate() error {
+	for i, m := range c.Mappings {
+		if len(m.OrganizationalUnits) == 0 && len(m.DNSNames) == 0 && len(m.URIs) == 0 {
+			return fmt.Errorf("mapping %d must set at least one of organizationalUnits, dnsNames and uris", i)
+		}
+		for _, patterns := range [][]string{m.OrganizationalUnits, m.DNSNames, m.URIs} {
+			for _, p := range patterns {
+				if _, err := path.Match(p, ""); err != nil {
+					return fmt.Errorf("mapping %d has invalid pattern %q: %w", i, p, err)
+				}
+			}
+		}
+		for _, ws := range m.Workspaces {
+			if !logicalcluster.NewPath(ws).IsValid() || ws == logicalcluster.Wildcard.String() {
+				return fmt.Errorf("mapping %d has invalid workspace path %q", i, ws)
+			}
+		}
+	}
+	return nil
+}
+
+// CertificateMapper authenticates verified client certificates matching one of the mappings
+// of a config file as the mapped user, only for the workspaces of the matching mappings.
+// Requests with other client certificates are passed to the wrapped authenticator. The config
+// file is reloaded when it changes.
+type CertificateMapper struct {
+	Authenticator authenticator.Request
+
+	verifyOptions x509request.VerifyOptionFunc
+	file          string
+	data          []byte
+	mappings      atomic.Pointer[[]CertificateMapping]
+}
+
+var _ authenticator.Request = &CertificateMapper{}
+
+// NewCertificateMapper returns a CertificateMapper for the given config file, verifying client
+// certificates with the given options.
+func NewCertificateMapper(delegate authenticator.Request, file string, verifyOptions x509request.VerifyOptionFunc) (*CertificateMapper, error) {
+	m := &CertificateMapper{Authenticator: delegate, verifyOptions: verifyOptions, file: file}
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Start reloads the config file every interval until the context is done. Invalid configs
+// are logged, and the previous mappings are kept.
+func (m *CertificateMapper) Start(ctx context.Context, interval time.Duration) {
+	logger := klog.FromContext(ctx)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.load(); err != nil {
+			logger.Error(err, "failed to reload certificate mappings, keeping the previous ones")
+		}
+	}, interval)
+}
+
+func (m *CertificateMapper) load() error {
+	data, err := os.ReadFile(m.file)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate mappings %q: %w", m.file, err)
+	}
+	if m.mappings.Load() != nil && bytes.Equal(data, m.data) {
+		return nil
+	}
+	var config CertificateMappingsConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return fmt.Errorf("failed to unmarshal certificate mappings %q: %w", m.file, err)
+	}
+	if err := config.validate(); err != nil {
+		return fmt.Errorf("invalid certificate mappings %q: %w", m.file, err)
+	}
+	m.mappings.Store(&config.Mappings)
+	m.data = data
+	return nil
+}
+
+func (m *CertificateMapper) AuthenticateRequest(req *http.Request) (*authenticator.Response, bool, error) {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return m.Authenticator.AuthenticateRequest(req)
+	}
+	cert := req.TLS.PeerCertificates[0]
+
+	var matching []*CertificateMapping
+	mappings := *m.mappings.Load()
+	for i := range mappings {
+		if mappings[i].matches(cert) {
+			matching = append(matching, &mappings[i])
+		}
+	}
+	if len(matching) == 0 {
+		return m.Authenticator.AuthenticateRequest(req)
+	}
+
+	opts, ok := m.verifyOptions()
+	if !ok {
+		return nil, false, nil
+	}
+	if opts.Intermediates == nil && len(req.TLS.PeerCertificates) > 1 {
+		opts.Intermediates = x509.NewCertPool()
+		for _, intermediate := range req.TLS.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(intermediate)
+		}
+	}
+	if _, err := cert.Verify(opts); err != nil {
+		return nil, false, err
+	}
+
+	for _, mapping := range matching {
+		if len(mapping.Workspaces) > 0 && !inWorkspaces(req, mapping.Workspaces) {
+			continue
+		}
+		name := mapping.User
+		if name == "" {
+			name = cert.Subject.CommonName
+		}
+		return &authenticator.Response{
+			User: &user.DefaultInfo{Name: name, Groups: append([]string(nil), mapping.Groups...)},
+		}, true, nil
+	}
+
+	// mapped certificates are never authenticated outside of their workspaces.
+	return nil, false, nil
+}
+
+func (m *CertificateMapping) matches(cert *x509.Certificate) bool {
+	uris := make([]string, 0, len(cert.URIs))
+	for _, u := range cert.URIs {
+		uris = append(uris, u.String())
+	}
+	return matchesAny(m.OrganizationalUnits, cert.Subject.OrganizationalUnit) &&
+		matchesAny(m.DNSNames, cert.DNSNames) &&
+		matchesAny(m.URIs, uris)
+}
+
+// matchesAny returns true if there are no patterns, or one of the values matches one of them.
+func matchesAny(patterns, values []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		for _, v := range values {
+			if ok, _ := path.Match(p, v); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authentication
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func TestCertificateMapper(t *testing.T) {
+	newCA := func() (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "ca"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			KeyUsage:              x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert, key
+	}
+	ca, caKey := newCA()
+	otherCA, otherKey := newCA()
+
+	newCert := func(cn string, ous, dnsNames []string, parent *x509.Certificate, signer *ecdsa.PrivateKey) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: cn, OrganizationalUnit: ous},
+			DNSNames:     dnsNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert
+	}
+
+	file := filepath.Join(t.TempDir(), "mappings.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+mappings:
+- organizationalUnits: ["ci-*"]
+  dnsNames: ["*.ci.example.com"]
+  user: ci-bot
+  groups: ["ci"]
+  workspaces: ["root:org"]
+- organizationalUnits: ["monitoring"]
+  groups: ["monitoring"]
+`), 0o600))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	delegate := authenticator.RequestFunc(func(req *http.Request) (*authenticator.Response, bool, error) {
+		return &authenticator.Response{User: &user.DefaultInfo{Name: "delegate"}}, true, nil
+	})
+	mapper, err := NewCertificateMapper(delegate, file, func() (x509.VerifyOptions, bool) {
+		return x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}, true
+	})
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		name string
+		cert *x509.Certificate
+		path string
+
+		wantUser   string
+		wantGroups []string
+		wantErr    bool
+	}{
+		{
+			name:       "mapped in workspace",
+			cert:       newCert("runner", []string{"ci-eu"}, []string{"a.ci.example.com"}, ca, caKey),
+			path:       "/clusters/root:org:team/api",
+			wantUser:   "ci-bot",
+			wantGroups: []string{"ci"},
+		},
+		{
+			name: "mapped outside of workspace",
+			cert: newCert("runner", []string{"ci-eu"}, []string{"a.ci.example.com"}, ca, caKey),
+			path: "/clusters/root:other/api",
+		},
+		{
+			name:     "partial match is not mapped",
+			cert:     newCert("runner", []string{"ci-eu"}, []string{"a.example.com"}, ca, caKey),
+			path:     "/clusters/root:org/api",
+			wantUser: "delegate",
+		},
+		{
+			name:       "common name as user",
+			cert:       newCert("prometheus", []string{"monitoring"}, nil, ca, caKey),
+			path:       "/clusters/root:other/api",
+			wantUser:   "prometheus",
+			wantGroups: []string{"monitoring"},
+		},
+		{
+			name:    "untrusted certificate",
+			cert:    newCert("prometheus", []string{"monitoring"}, nil, otherCA, otherKey),
+			path:    "/clusters/root:other/api",
+			wantErr: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+			req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{testCase.cert}}
+			resp, ok, err := mapper.AuthenticateRequest(req)
+			if testCase.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if testCase.wantUser == "" {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Equal(t, testCase.wantUser, resp.User.GetName())
+			require.Equal(t, testCase.wantGroups, resp.User.GetGroups())
+		})
+	}
+
+	t.Log("Invalid configs are rejected")
+	require.NoError(t, os.WriteFile(file, []byte(`mappings: [{user: anyone}]`), 0o600))
+	require.Error(t, mapper.load())
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apiserver/pkg/apis/apiserver"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	apiservervalidation "k8s.io/apiserver/pkg/apis/apiserver/validation"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/request/bearertoken"
+	"k8s.io/apiserver/pkg/authentication/request/union"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	"k8s.io/apiserver/plugin/pkg/authenticator/token/oidc"
+	"sigs.k8s.io/yaml"
+)
+
+// IssuersConfig configures OIDC issuers, e.g. one per organization bringing its own IdP.
+type IssuersConfig struct {
+	Issuers []Issuer `json:"issuers"`
+}
+
+// Issuer is an OIDC issuer with its claim mappings, in the format of the JWT authenticators
+// of the kube-apiserver structured authentication configuration.
+type Issuer struct {
+	apiserverv1beta1.JWTAuthenticator `json:",inline"`
+
+	// Workspaces are the workspace paths, including their descendants, users of this issuer
+	// are authenticated for. Requests to other workspaces are not authenticated with this
+	// issuer. Empty means all workspaces.
+	Workspaces []string `json:"workspaces,omitempty"`
+}
+
+// LoadIssuersConfig reads and validates the OIDC issuers config from the given file.
+func LoadIssuersConfig(path string) (*IssuersConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OIDC issuers config %q: %w", path, err)
+	}
+	var config IssuersConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OIDC issuers config %q: %w", path, err)
+	}
+	if _, err := config.jwtAuthenticators(); err != nil {
+		return nil, fmt.Errorf("invalid OIDC issuers config %q: %w", path, err)
+	}
+	return &config, nil
+}
+
+func (c *IssuersConfig) jwtAuthenticators() ([]apiserver.JWTAuthenticator, error) {
+	authConfig := &apiserver.AuthenticationConfiguration{}
+	for i := range c.Issuers {
+		var jwt apiserver.JWTAuthenticator
+		if err := apiserverv1beta1.Convert_v1beta1_JWTAuthenticator_To_apiserver_JWTAuthenticator(&c.Issuers[i].JWTAuthenticator, &jwt, nil); err != nil {
+			return nil, err
+		}
+		authConfig.JWT = append(authConfig.JWT, jwt)
+
+		for _, ws := range c.Issuers[i].Workspaces {
+			if !logicalcluster.NewPath(ws).IsValid() || ws == logicalcluster.Wildcard.String() {
+				return nil, fmt.Errorf("issuer %q has invalid workspace path %q", jwt.Issuer.URL, ws)
+			}
+		}
+	}
+	if err := apiservervalidation.ValidateAuthenticationConfiguration(authConfig, nil).ToAggregate(); err != nil {
+		return nil, err
+	}
+	return authConfig.JWT, nil
+}
+
+// NewIssuersAuthenticator returns a request authenticator for bearer tokens of all the issuers,
+// each only authenticating requests to its workspaces.
+func NewIssuersAuthenticator(ctx context.Context, config *IssuersConfig) (authenticator.Request, error) {
+	jwts, err := config.jwtAuthenticators()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticators := make([]authenticator.Request, 0, len(jwts))
+	for i, jwt := range jwts {
+		opts := oidc.Options{
+			JWTAuthenticator:     jwt,
+			SupportedSigningAlgs: oidc.AllValidSigningAlgorithms(),
+		}
+		if jwt.Issuer.CertificateAuthority != "" {
+			opts.CAContentProvider, err = dynamiccertificates.NewStaticCAContent("oidc-authenticator", []byte(jwt.Issuer.CertificateAuthority))
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate authority of issuer %q: %w", jwt.Issuer.URL, err)
+			}
+		}
+		tokenAuthenticator, err := oidc.New(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create authenticator for issuer %q: %w", jwt.Issuer.URL, err)
+		}
+
+		var auth authenticator.Request = bearertoken.New(tokenAuthenticator)
+		if workspaces := config.Issuers[i].Workspaces; len(workspaces) > 0 {
+			auth = &WorkspaceScopedAuthenticator{Authenticator: auth, Workspaces: workspaces}
+		}
+		authenticators = append(authenticators, auth)
+	}
+	return union.New(authenticators...), nil
+}
+
+// WorkspaceScopedAuthenticator only authenticates requests to the given workspaces and their
+// descendants, addressed by path.
+type WorkspaceScopedAuthenticator struct {
+	Authenticator authenticator.Request
+	Workspaces    []string
+}
+
+var _ authenticator.Request = &WorkspaceScopedAuthenticator{}
+
+func (a *WorkspaceScopedAuthenticator) AuthenticateRequest(req *http.Request) (*authenticator.Response, bool, error) {
+	if !inWorkspaces(req, a.Workspaces) {
+		return nil, false, nil
+	}
+	return a.Authenticator.AuthenticateRequest(req)
+}
+
+// inWorkspaces returns true if the request is addressed to one of the given workspaces or
+// their descendants by path.
+func inWorkspaces(req *http.Request, workspaces []string) bool {
+	cs := strings.SplitN(strings.TrimLeft(req.URL.Path, "/"), "/", 3)
+	if len(cs) < 2 || cs[0] != "clusters" {
+		return false
+	}
+	for _, ws := range workspaces {
+		if cs[1] == ws || strings.HasPrefix(cs[1], ws+":") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func TestLoadIssuersConfig(t *testing.T) {
+	for _, testCase := range []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name: "multiple issuers",
+			config: `
+issuers:
+- issuer:
+    url: https://idp.org-a.example
+    audiences: ["kcp"]
+  claimMappings:
+    username:
+      claim: email
+      prefix: "org-a:"
+    groups:
+      expression: 'claims.department == "platform" ? ["org-a:platform"] : []'
+  workspaces: ["root:org-a"]
+- issuer:
+    url: https://idp.org-b.example
+    audiences: ["kcp"]
+  claimMappings:
+    username:
+      claim: sub
+      prefix: "org-b:"
+`,
+		},
+		{
+			name: "duplicate issuers",
+			config: `
+issuers:
+- issuer: {url: "https://idp.example", audiences: ["kcp"]}
+  claimMappings: {username: {claim: sub, prefix: ""}}
+- issuer: {url: "https://idp.example", audiences: ["kcp"]}
+  claimMappings: {username: {claim: sub, prefix: ""}}
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid workspace",
+			config: `
+issuers:
+- issuer: {url: "https://idp.example", audiences: ["kcp"]}
+  claimMappings: {username: {claim: sub, prefix: ""}}
+  workspaces: ["*"]
+`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			config:  `issuers: [{foo: bar}]`,
+			wantErr: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "issuers.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(testCase.config), 0600))
+			_, err := LoadIssuersConfig(path)
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceScopedAuthenticator(t *testing.T) {
+	auth := &WorkspaceScopedAuthenticator{
+		Authenticator: authenticator.RequestFunc(func(req *http.Request) (*authenticator.Response, bool, error) {
+			return &authenticator.Response{User: &user.DefaultInfo{Name: "alice"}}, true, nil
+		}),
+		Workspaces: []string{"root:org-a"},
+	}
+	for path, want := range map[string]bool{
+		"/clusters/root:org-a/api":      true,
+		"/clusters/root:org-a:team/api": true,
+		"/clusters/root:org-ab/api":     false,
+		"/clusters/root:org-b/api":      false,
+		"/clusters/*/api":               false,
+		"/api":                          false,
+	} {
+		_, ok, err := auth.AuthenticateRequest(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		require.Equal(t, want, ok, path)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapifilters "k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/webhook"
+	"github.com/kcp-dev/kcp/pkg/server/requestinfo"
+)
+
+// WithWebhookAuthorization rejects requests with 403 Forbidden that the authorization webhook
+// denies, before they are proxied to the shards. Allowed requests and requests the webhook has
+// no opinion about are proxied, and authorized by the shards as usual.
+func WithWebhookAuthorization(delegate http.Handler, authz authorizer.Authorizer) http.Handler {
+	resolver := requestinfo.NewKCPRequestInfoResolver()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if _, ok := request.UserFrom(ctx); !ok {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+		info, err := resolver.NewRequestInfo(req)
+		if err != nil {
+			responsewriters.InternalError(w, req, err)
+			return
+		}
+		attrs, err := genericapifilters.GetAuthorizerAttributes(request.WithRequestInfo(ctx, info))
+		if err != nil {
+			responsewriters.InternalError(w, req, err)
+			return
+		}
+
+		ctx = context.WithValue(ctx, clusterPathContextKey, clusterPathFromRequestPath(req.URL.Path))
+		decision, reason, err := authz.Authorize(ctx, attrs)
+		if err != nil {
+			klog.FromContext(ctx).V(4).Info("authorization webhook failed", "error", err.Error())
+		}
+		if decision == authorizer.DecisionDeny {
+			responsewriters.Forbidden(ctx, attrs, w, req, reason, kubernetesscheme.Codecs)
+			return
+		}
+		delegate.ServeHTTP(w, req)
+	})
+}
+
+type clusterPathKey int
+
+// clusterPathContextKey holds the workspace path of the request while it is authorized.
+const clusterPathContextKey clusterPathKey = iota
+
+// proxyClusterExtra returns the logical cluster of the shard URL and the workspace path of
+// the request in the request context, or "*" for aggregated wildcard requests.
+func proxyClusterExtra(ctx context.Context) map[string]string {
+	path, _ := ctx.Value(clusterPathContextKey).(string)
+	shardURL := ShardURLFrom(ctx)
+	if shardURL == nil {
+		if path == logicalcluster.Wildcard.String() {
+			return map[string]string{webhook.ClusterNameExtraKey: path}
+		}
+		return nil
+	}
+	clusterName, ok := clusterNameFromShardPath(shardURL.Path)
+	if !ok {
+		return nil
+	}
+	extra := map[string]string{webhook.ClusterNameExtraKey: clusterName.String()}
+	if path != "" {
+		extra[webhook.ClusterPathExtraKey] = path
+	}
+	return extra
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/webhook"
+)
+
+func TestWebhookAuthorization(t *testing.T) {
+	var (
+		decision authorizer.Decision
+		authzErr error
+		got      authorizer.Attributes
+	)
+	authz := webhook.WithClusterExtra(authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+		got = attr
+		return decision, "webhook says so", authzErr
+	}), proxyClusterExtra)
+	handler := WithWebhookAuthorization(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), authz)
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/root:org/api/v1/namespaces/default/configmaps/foo", nil)
+		ctx := WithShardURL(req.Context(), &url.URL{Scheme: "https", Host: "alpha:6443", Path: "/clusters/abc/api/v1/namespaces/default/configmaps/foo"})
+		ctx = request.WithUser(ctx, &user.DefaultInfo{Name: "alice", Groups: []string{"team"}})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	t.Log("Allowed requests are proxied, with the cluster in the user extra")
+	decision = authorizer.DecisionAllow
+	require.Equal(t, http.StatusOK, serve().Code)
+	require.Equal(t, "get", got.GetVerb())
+	require.Equal(t, "configmaps", got.GetResource())
+	require.Equal(t, "default", got.GetNamespace())
+	require.Equal(t, "foo", got.GetName())
+	require.Equal(t, "alice", got.GetUser().GetName())
+	require.Equal(t, []string{"team"}, got.GetUser().GetGroups())
+	require.Equal(t, map[string][]string{
+		webhook.ClusterNameExtraKey: {"abc"},
+		webhook.ClusterPathExtraKey: {"root:org"},
+	}, got.GetUser().GetExtra())
+
+	t.Log("Requests without an opinion are left to the shards")
+	decision = authorizer.DecisionNoOpinion
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	t.Log("Denied requests are rejected")
+	decision = authorizer.DecisionDeny
+	w := serve()
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "webhook says so")
+
+	t.Log("Errors are rejected only with the Deny failure policy")
+	decision, authzErr = authorizer.DecisionNoOpinion, errors.New("unreachable")
+	require.Equal(t, http.StatusOK, serve().Code)
+	decision = authorizer.DecisionDeny
+	require.Equal(t, http.StatusForbidden, serve().Code)
+}
+
+func TestWebhookAuthorizationWildcard(t *testing.T) {
+	var got authorizer.Attributes
+	authz := webhook.WithClusterExtra(authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+		got = attr
+		return authorizer.DecisionDeny, "no wildcards", nil
+	}), proxyClusterExtra)
+	served := false
+	handler := WithWebhookAuthorization(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served = true
+	}), authz)
+
+	// aggregated wildcard requests have no shard URL
+	req := httptest.NewRequest(http.MethodGet, "/clusters/*/api/v1/configmaps?watch=true", nil)
+	ctx := request.WithUser(req.Context(), &user.DefaultInfo{Name: "alice"})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req.WithContext(ctx))
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "no wildcards")
+	require.False(t, served, "denied wildcard requests must not be aggregated")
+	require.Equal(t, "watch", got.GetVerb())
+	require.Equal(t, "configmaps", got.GetResource())
+	require.Equal(t, map[string][]string{webhook.ClusterNameExtraKey: {"*"}}, got.GetUser().GetExtra())
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/responsewriter"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+	"github.com/kcp-dev/kcp/pkg/proxy/metrics"
+)
+
+const (
+	// healthCheckInterval is how often the readiness of every shard is probed.
+	healthCheckInterval = 5 * time.Second
+	// healthCheckTimeout is the timeout of a readiness probe.
+	healthCheckTimeout = 2 * time.Second
+	// healthCheckFailureThreshold is the number of consecutive failed probes opening the breaker.
+	healthCheckFailureThreshold = 3
+
+	// errorWindow is the period in which 5xx responses are counted.
+	errorWindow = 10 * time.Second
+	// errorMinRequests is the number of requests in the error window before the breaker can open.
+	errorMinRequests = 20
+	// errorRatioThreshold is the ratio of 5xx responses in the error window opening the breaker.
+	errorRatioThreshold = 0.5
+	// openDuration is how long the breaker stays open after too many 5xx responses, before a
+	// single trial request is let through.
+	openDuration = 10 * time.Second
+)
+
+// circuitBreaker fails requests to unhealthy shards fast with 503 Service Unavailable and a
+// Retry-After header, instead of letting them hang. A shard is unhealthy while its readiness
+// probes fail, or for a while after too many of its responses were 5xx errors. After that,
+// a single trial request probes whether the shard recovered.
+type circuitBreaker struct {
+	index     index.Index
+	transport http.RoundTripper
+	now       func() time.Time
+
+	lock   sync.Mutex
+	shards map[string]*breakerState // shard host -> state
+}
+
+type breakerState struct {
+	probeFailures int
+	openUntil     time.Time // zero if closed
+	trial         bool      // a trial request of the half-open breaker is in flight
+
+	windowStart        time.Time
+	requests, failures int
+}
+
+func newCircuitBreaker(index index.Index, transport http.RoundTripper) *circuitBreaker {
+	return &circuitBreaker{
+		index:     index,
+		transport: transport,
+		now:       time.Now,
+		shards:    map[string]*breakerState{},
+	}
+}
+
+// WithCircuitBreaker rejects requests to the shard URL in the request context while its
+// breaker is open, and records the responses of the others.
+func (b *circuitBreaker) WithCircuitBreaker(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		shardURL := ShardURLFrom(req.Context())
+		if shardURL == nil {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		trial, retryAfter, ok := b.allow(shardURL.Host)
+		if !ok {
+			metrics.RecordCircuitBreakerRejected()
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			err := apierrors.NewServiceUnavailable(fmt.Sprintf("shard of workspace %q is unavailable, retry in %ds", clusterPathFromRequestPath(req.URL.Path), seconds))
+			responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		delegate.ServeHTTP(responsewriter.WrapForHTTP1Or2(recorder), req)
+		code := recorder.code
+		if code == 0 {
+			code = http.StatusOK
+		}
+		b.record(klog.FromContext(req.Context()), shardURL.Host, code, trial)
+	})
+}
+
+// allow returns whether a request to the given host is let through, and whether it is the
+// trial request of a half-open breaker. Otherwise, it returns when to retry.
+func (b *circuitBreaker) allow(host string) (trial bool, retryAfter time.Duration, ok bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s, found := b.shards[host]
+	switch {
+	case !found:
+		return false, 0, true
+	case s.probeFailures >= healthCheckFailureThreshold:
+		return false, healthCheckInterval, false
+	case s.openUntil.IsZero():
+		return false, 0, true
+	}
+	now := b.now()
+	if now.Before(s.openUntil) {
+		return false, s.openUntil.Sub(now), false
+	}
+	if s.trial {
+		return false, time.Second, false
+	}
+	s.trial = true
+	return true, 0, true
+}
+
+func (b *circuitBreaker) record(logger klog.Logger, host string, code int, trial bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s, found := b.shards[host]
+	if !found {
+		return // not a shard, e.g. a mount, or not probed yet
+	}
+	now := b.now()
+	failed := code >= http.StatusInternalServerError
+
+	if trial {
+		s.trial = false
+		if failed {
+			s.openUntil = now.Add(openDuration)
+			return
+		}
+		logger.Info("Closing circuit breaker of recovered shard", "host", host)
+		s.openUntil = time.Time{}
+		s.windowStart, s.requests, s.failures = now, 0, 0
+		return
+	}
+	if !s.openUntil.IsZero() {
+		return // in flight before the breaker opened
+	}
+
+	if now.Sub(s.windowStart) > errorWindow {
+		s.windowStart, s.requests, s.failures = now, 0, 0
+	}
+	s.requests++
+	if failed {
+		s.failures++
+	}
+	if s.requests >= errorMinRequests && float64(s.failures)/float64(s.requests) >= errorRatioThreshold {
+		logger.Info("Opening circuit breaker of shard with too many errors", "host", host, "requests", s.requests, "failures", s.failures)
+		s.openUntil = now.Add(openDuration)
+	}
+}
+
+func (b *circuitBreaker) state(host string) *breakerState {
+	s, found := b.shards[host]
+	if !found {
+		s = &breakerState{windowStart: b.now()}
+		b.shards[host] = s
+	}
+	return s
+}
+
+// Start probes the readiness of all shards every interval until the context is done.
+func (b *circuitBreaker) Start(ctx context.Context, interval time.Duration) {
+	wait.UntilWithContext(ctx, b.probe, interval)
+}
+
+func (b *circuitBreaker) probe(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	hosts := map[string]string{} // host -> base URL
+	for _, baseURL := range b.index.ShardBaseURLs() {
+		if u, err := url.Parse(baseURL); err == nil {
+			hosts[u.Host] = baseURL
+		}
+	}
+
+	b.lock.Lock()
+	for host := range b.shards {
+		if _, found := hosts[host]; !found {
+			delete(b.shards, host)
+		}
+	}
+	b.lock.Unlock()
+
+	var wg sync.WaitGroup
+	for host, baseURL := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := b.check(ctx, baseURL)
+
+			b.lock.Lock()
+			defer b.lock.Unlock()
+			s := b.state(host)
+			if err == nil {
+				if s.probeFailures >= healthCheckFailureThreshold {
+					logger.Info("Shard is ready again", "host", host)
+				}
+				s.probeFailures = 0
+				return
+			}
+			if s.probeFailures++; s.probeFailures == healthCheckFailureThreshold {
+				logger.Info("Opening circuit breaker of shard failing readiness probes", "host", host, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (b *circuitBreaker) check(ctx context.Context, baseURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/readyz", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readyz returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var ready atomic.Bool
+	ready.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	idx := &fakeIndex{shards: map[string]string{"alpha": server.URL}}
+	b := newCircuitBreaker(idx, http.DefaultTransport)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	var code atomic.Int32
+	code.Store(http.StatusOK)
+	handler := b.WithCircuitBreaker(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(int(code.Load()))
+	}))
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/root:org/api/v1/configmaps", nil)
+		ctx := WithShardURL(req.Context(), &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/clusters/abc/api/v1/configmaps"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	ctx := context.Background()
+	b.probe(ctx)
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	t.Log("Failing readiness probes open the breaker")
+	ready.Store(false)
+	for i := 0; i < healthCheckFailureThreshold; i++ {
+		b.probe(ctx)
+	}
+	w := serve()
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Equal(t, "5", w.Header().Get("Retry-After"))
+
+	t.Log("A successful probe closes it again")
+	ready.Store(true)
+	b.probe(ctx)
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	t.Log("Too many 5xx responses open the breaker")
+	code.Store(http.StatusBadGateway)
+	for i := 0; i < errorMinRequests; i++ {
+		serve()
+	}
+	w = serve()
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Equal(t, "10", w.Header().Get("Retry-After"))
+
+	t.Log("A failed trial request keeps it open")
+	now = now.Add(openDuration)
+	require.Equal(t, http.StatusBadGateway, serve().Code)
+	require.Equal(t, http.StatusServiceUnavailable, serve().Code)
+
+	t.Log("A successful trial request closes it")
+	now = now.Add(openDuration)
+	code.Store(http.StatusOK)
+	require.Equal(t, http.StatusOK, serve().Code)
+	require.Equal(t, http.StatusOK, serve().Code)
+}
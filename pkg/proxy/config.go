@@ -20,12 +20,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
 
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/component-base/tracing"
 
+	kcpserviceaccount "github.com/kcp-dev/kcp/pkg/authentication/serviceaccount"
+	cacheoptions "github.com/kcp-dev/kcp/pkg/cache/client/options"
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
 	proxyoptions "github.com/kcp-dev/kcp/pkg/proxy/options"
 	bootstrap "github.com/kcp-dev/kcp/pkg/server/bootstrap"
 )
@@ -49,10 +58,21 @@ type ExtraConfig struct {
 	ResolveIdentities func(ctx context.Context) error
 	RootShardConfig   *rest.Config
 	ShardsConfig      *rest.Config
+	// CacheConfig is the config of the cache server, or nil if Shards are watched on
+	// the root shard.
+	CacheConfig *rest.Config
+
+	// ShardIndex looks up the shards of logical clusters, e.g. to authenticate service accounts
+	// of any shard. It is bound to the index controller by NewServer.
+	ShardIndex *ShardIndex
 
 	AuthenticationInfo    genericapiserver.AuthenticationInfo
 	ServingInfo           *genericapiserver.SecureServingInfo
 	AdditionalAuthEnabled bool
+
+	// TracerProvider records the spans of proxied requests. It is a noop provider without
+	// --tracing-config-file.
+	TracerProvider tracing.TracerProvider
 }
 
 type CompletedConfig struct {
@@ -89,9 +109,6 @@ func NewConfig(ctx context.Context, opts *proxyoptions.Options) (*Config, error)
 	if err := c.Options.SecureServing.ApplyTo(&c.ServingInfo, &loopbackClientConfig); err != nil {
 		return nil, err
 	}
-	if err := c.Options.Authentication.ApplyTo(ctx, &c.AuthenticationInfo, c.ServingInfo, c.RootShardConfig); err != nil {
-		return nil, err
-	}
 
 	c.ShardsConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.Options.ShardsKubeconfig},
@@ -103,7 +120,73 @@ func NewConfig(ctx context.Context, opts *proxyoptions.Options) (*Config, error)
 	}
 	c.ShardsConfig.Wrap(kcpShardIdentityRoundTripper)
 
+	c.ShardIndex = &ShardIndex{}
+	if err := c.Options.Authentication.ApplyTo(ctx, &c.AuthenticationInfo, c.ServingInfo, c.RootShardConfig, c.ShardIndex.shardClientFunc(c.ShardsConfig)); err != nil {
+		return nil, err
+	}
+
+	if c.Options.CacheKubeconfig != "" {
+		c.CacheConfig, err = (&cacheoptions.Cache{KubeconfigFile: c.Options.CacheKubeconfig}).RestConfig(nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	c.AdditionalAuthEnabled = c.Options.Authentication.AdditionalAuthEnabled()
 
+	c.TracerProvider, err = newTracerProvider(ctx, c.Options.Tracing.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
+
+// ShardIndex resolves logical clusters to shards once it is bound to the index controller.
+type ShardIndex struct {
+	index atomic.Pointer[index.Controller]
+
+	lock    sync.Mutex
+	clients map[string]kcpkubernetesclientset.ClusterInterface
+}
+
+// Bind starts resolving logical clusters with the given index controller.
+func (i *ShardIndex) Bind(controller *index.Controller) {
+	i.index.Store(controller)
+}
+
+// LookupURL returns the base URL of the shard of the given logical cluster.
+func (i *ShardIndex) LookupURL(path logicalcluster.Path) (string, bool) {
+	controller := i.index.Load()
+	if controller == nil {
+		return "", false
+	}
+	return controller.LookupURL(path)
+}
+
+// shardClientFunc returns clients for the shard of a logical cluster, using the given shard config.
+func (i *ShardIndex) shardClientFunc(shardsConfig *rest.Config) kcpserviceaccount.ShardClientFunc {
+	return func(clusterName logicalcluster.Name) (kcpkubernetesclientset.ClusterInterface, error) {
+		shardURL, found := i.LookupURL(clusterName.Path())
+		if !found {
+			return nil, fmt.Errorf("shard of logical cluster %q not found", clusterName)
+		}
+
+		i.lock.Lock()
+		defer i.lock.Unlock()
+		if client, found := i.clients[shardURL]; found {
+			return client, nil
+		}
+		shardConfig := rest.CopyConfig(shardsConfig)
+		shardConfig.Host = shardURL
+		client, err := kcpkubernetesclientset.NewForConfig(shardConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for shard %q: %w", shardURL, err)
+		}
+		if i.clients == nil {
+			i.clients = map[string]kcpkubernetesclientset.ClusterInterface{}
+		}
+		i.clients[shardURL] = client
+		return client, nil
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+)
+
+// shardDrainer tracks the requests proxied to every shard. When a shard is removed from
+// the index, or its base URL changes, its in-flight requests are cancelled after a grace
+// period, such that clients of long-running requests like watches reconnect and are routed
+// to the current shards.
+type shardDrainer struct {
+	index   index.Index
+	timeout time.Duration
+
+	lock     sync.Mutex
+	requests map[string]map[*trackedRequest]struct{} // host -> in-flight requests
+	hosts    map[string]bool                         // shard hosts in the index
+	draining map[string]bool                         // shard hosts with a pending drain
+}
+
+type trackedRequest struct {
+	cancel context.CancelFunc
+}
+
+func newShardDrainer(index index.Index, timeout time.Duration) *shardDrainer {
+	d := &shardDrainer{
+		index:    index,
+		timeout:  timeout,
+		requests: map[string]map[*trackedRequest]struct{}{},
+		draining: map[string]bool{},
+	}
+	d.hosts = d.shardHosts()
+	return d
+}
+
+// WithTracking tracks the requests the given handler proxies to the shard URL in the
+// request context, cancelling them when the shard is drained.
+func (d *shardDrainer) WithTracking(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		shardURL := ShardURLFrom(req.Context())
+		if shardURL == nil {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		r := &trackedRequest{cancel: cancel}
+
+		d.lock.Lock()
+		if d.requests[shardURL.Host] == nil {
+			d.requests[shardURL.Host] = map[*trackedRequest]struct{}{}
+		}
+		d.requests[shardURL.Host][r] = struct{}{}
+		d.lock.Unlock()
+
+		defer func() {
+			d.lock.Lock()
+			defer d.lock.Unlock()
+			delete(d.requests[shardURL.Host], r)
+			if len(d.requests[shardURL.Host]) == 0 {
+				delete(d.requests, shardURL.Host)
+			}
+		}()
+
+		delegate.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// Start drains shards whenever they are removed from the index, until the context is done.
+func (d *shardDrainer) Start(ctx context.Context) {
+	changed, unsubscribe := d.index.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+			d.drain(ctx)
+		}
+	}
+}
+
+// drain schedules the cancellation of the requests to all shard hosts removed from the
+// index. Requests to other hosts, e.g. of mounts, are never cancelled.
+func (d *shardDrainer) drain(ctx context.Context) {
+	current := d.shardHosts()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	removed := d.hosts
+	d.hosts = current
+	for host := range removed {
+		if current[host] || d.draining[host] {
+			continue
+		}
+		d.draining[host] = true
+		klog.FromContext(ctx).V(2).Info("Draining requests to removed shard", "host", host, "requests", len(d.requests[host]), "timeout", d.timeout)
+		time.AfterFunc(d.timeout, func() {
+			d.cancel(ctx, host)
+		})
+	}
+}
+
+// cancel cancels the requests to the given shard host, unless it was added back to the index
+// in the meantime.
+func (d *shardDrainer) cancel(ctx context.Context, host string) {
+	current := d.shardHosts()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.draining, host)
+	if current[host] {
+		return // the shard is back
+	}
+	if len(d.requests[host]) > 0 {
+		klog.FromContext(ctx).Info("Cancelling requests to removed shard", "host", host, "requests", len(d.requests[host]))
+	}
+	for r := range d.requests[host] {
+		r.cancel()
+	}
+}
+
+func (d *shardDrainer) shardHosts() map[string]bool {
+	hosts := map[string]bool{}
+	for _, baseURL := range d.index.ShardBaseURLs() {
+		if u, err := url.Parse(baseURL); err == nil {
+			hosts[u.Host] = true
+		}
+	}
+	return hosts
+}
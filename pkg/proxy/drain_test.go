@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIndex struct {
+	lock   sync.Mutex
+	shards map[string]string
+	urls   map[string]string
+	ch     chan struct{}
+}
+
+func (f *fakeIndex) LookupURL(path logicalcluster.Path) (string, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	u, found := f.urls[path.String()]
+	return u, found
+}
+
+func (f *fakeIndex) ShardBaseURLs() map[string]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	urls := map[string]string{}
+	for k, v := range f.shards {
+		urls[k] = v
+	}
+	return urls
+}
+
+func (f *fakeIndex) Subscribe() (<-chan struct{}, func()) {
+	return f.ch, func() {}
+}
+
+func (f *fakeIndex) deleteShard(name string) {
+	f.lock.Lock()
+	delete(f.shards, name)
+	f.lock.Unlock()
+	f.ch <- struct{}{}
+}
+
+func TestShardDrainer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	idx := &fakeIndex{
+		shards: map[string]string{"alpha": "https://alpha:6443", "beta": "https://beta:6443"},
+		ch:     make(chan struct{}),
+	}
+	d := newShardDrainer(idx, 100*time.Millisecond)
+	go d.Start(ctx)
+
+	started := make(chan struct{}, 3)
+	cancelled := make(chan string, 3)
+	handler := d.WithTracking(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-req.Context().Done():
+			cancelled <- ShardURLFrom(req.Context()).Host
+		case <-ctx.Done():
+		}
+	}))
+	serve := func(shardURL string) {
+		u, err := url.Parse(shardURL)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/clusters/foo/api/v1/configmaps?watch=true", nil)
+		go handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(WithShardURL(req.Context(), u)))
+		<-started
+	}
+	serve("https://alpha:6443/clusters/foo")
+	serve("https://beta:6443/clusters/bar")
+	serve("https://mount:443/services/foo") // not a shard, never drained
+
+	idx.deleteShard("alpha")
+
+	select {
+	case host := <-cancelled:
+		require.Equal(t, "alpha:6443", host)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request to removed shard was not cancelled")
+	}
+	select {
+	case host := <-cancelled:
+		t.Fatalf("unexpected cancellation of request to %s", host)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -32,7 +32,9 @@ import (
 	"github.com/kcp-dev/kcp/pkg/proxy/index"
 )
 
-func shardHandler(index index.Index, proxy http.Handler) http.HandlerFunc {
+// shardHandler proxies requests to the shard of the logical cluster. Wildcard requests are
+// served by the wildcard handler if not nil, and are forbidden otherwise.
+func shardHandler(index index.Index, proxy http.Handler, wildcard http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		var cs = strings.SplitN(strings.TrimLeft(req.URL.Path, "/"), "/", 3)
 		if len(cs) < 2 || cs[0] != "clusters" {
@@ -48,6 +50,11 @@ func shardHandler(index index.Index, proxy http.Handler) http.HandlerFunc {
 			return
 		}
 
+		if cs[1] == logicalcluster.Wildcard.String() && wildcard != nil {
+			wildcard.ServeHTTP(w, req)
+			return
+		}
+
 		clusterPath := logicalcluster.NewPath(cs[1])
 		if !clusterPath.IsValid() {
 			// this includes wildcards
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/index"
+)
+
+// Path is the path the workspace index is served on.
+const Path = "/workspaceindex"
+
+// Watchable is an index that can be listed and watched.
+type Watchable interface {
+	Entries() []index.Entry
+	Subscribe() (<-chan struct{}, func())
+}
+
+// List is the response of a request without watch.
+type List struct {
+	Entries []index.Entry `json:"entries"`
+}
+
+// NewHandler returns a read-only handler serving the entries of the index to
+// authenticated users in one of the allowed groups. With "?watch=true", the
+// current entries are streamed as ADDED events, followed by the changes,
+// one JSON object per line.
+func NewHandler(idx Watchable, allowedGroups []string) http.Handler {
+	allowed := sets.New[string](allowedGroups...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		user, ok := request.UserFrom(req.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if !allowed.HasAny(user.GetGroups()...) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+
+		if req.URL.Query().Get("watch") != "true" {
+			entries := idx.Entries()
+			if entries == nil {
+				entries = []index.Entry{}
+			}
+			if err := enc.Encode(List{Entries: entries}); err != nil {
+				klog.FromContext(req.Context()).Error(err, "failed to write workspace index")
+			}
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		// subscribe before taking the first snapshot to not miss changes in between.
+		ch, cancel := idx.Subscribe()
+		defer cancel()
+
+		var last []index.Entry
+		for {
+			entries := idx.Entries()
+			for _, event := range index.Diff(last, entries) {
+				if err := enc.Encode(event); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+			last = entries
+
+			select {
+			case <-req.Context().Done():
+				return
+			case <-ch:
+			}
+		}
+	})
+}
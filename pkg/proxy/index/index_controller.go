@@ -36,6 +36,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
 	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
@@ -50,6 +52,10 @@ const (
 
 type Index interface {
 	LookupURL(path logicalcluster.Path) (url string, found bool)
+	ShardBaseURLs() map[string]string
+	// Subscribe returns a channel notified whenever the index might have changed,
+	// and a function to end the subscription.
+	Subscribe() (<-chan struct{}, func())
 }
 
 type ClusterClientGetter func(shard *corev1alpha1.Shard) (kcpclientset.ClusterInterface, error)
@@ -81,12 +87,19 @@ func NewController(
 	_, _ = shardInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			shard := obj.(*corev1alpha1.Shard)
-			c.state.UpsertShard(shard.Name, shard.Spec.BaseURL)
+			if isShardRoutable(shard) {
+				c.state.UpsertShard(shard.Name, shard.Spec.BaseURL)
+			}
 			c.enqueueShard(ctx, shard)
 		},
 		UpdateFunc: func(old, obj interface{}) {
 			shard := obj.(*corev1alpha1.Shard)
-			oldShard := obj.(*corev1alpha1.Shard)
+			if isShardRoutable(shard) {
+				c.state.UpsertShard(shard.Name, shard.Spec.BaseURL)
+			} else {
+				c.state.DisableShard(shard.Name)
+			}
+			oldShard := old.(*corev1alpha1.Shard)
 			if oldShard.Spec.BaseURL == shard.Spec.BaseURL {
 				return
 			}
@@ -286,7 +299,28 @@ func (c *Controller) stopShard(shardName string) {
 	delete(c.shardLogicalClusterInformers, shardName)
 }
 
+// isShardRoutable returns false if the shard is not ready, i.e. its Lease expired.
+func isShardRoutable(shard *corev1alpha1.Shard) bool {
+	return !conditions.IsFalse(shard, conditionsv1alpha1.ReadyCondition)
+}
+
 func (c *Controller) LookupURL(path logicalcluster.Path) (url string, found bool) {
 	r, found := c.state.LookupURL(path)
 	return r.URL, found
 }
+
+// ShardBaseURLs returns the base URLs of all routable shards by shard name.
+func (c *Controller) ShardBaseURLs() map[string]string {
+	return c.state.ShardBaseURLs()
+}
+
+// Entries returns a snapshot of all paths of the index.
+func (c *Controller) Entries() []index.Entry {
+	return c.state.Entries()
+}
+
+// Subscribe returns a channel notified whenever the index might have changed,
+// and a function to end the subscription.
+func (c *Controller) Subscribe() (<-chan struct{}, func()) {
+	return c.state.Subscribe()
+}
@@ -27,8 +27,11 @@ import (
 	"strings"
 
 	"github.com/kcp-dev/logicalcluster/v3"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-base/tracing"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
 
@@ -111,7 +114,7 @@ func (h *HttpHandler) resolveURL(r *http.Request) string {
 	return r.URL.Path
 }
 
-func NewHandler(ctx context.Context, o *proxyoptions.Options, index index.Index) (http.Handler, error) {
+func NewHandler(ctx context.Context, o *proxyoptions.Options, index index.Index, tracerProvider oteltrace.TracerProvider) (http.Handler, error) {
 	mappingData, err := os.ReadFile(o.MappingFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read mapping file %q: %w", o.MappingFile, err)
@@ -133,6 +136,40 @@ func NewHandler(ctx context.Context, o *proxyoptions.Options, index index.Index)
 		},
 	}
 
+	var limiter *rateLimiter
+	if o.RateLimitConfigFile != "" {
+		config, err := LoadRateLimitConfig(o.RateLimitConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		limiter = newRateLimiter(config, index)
+		go limiter.Start(ctx)
+	}
+
+	var mirrorConfig *MirrorConfig
+	if o.MirrorConfigFile != "" {
+		if mirrorConfig, err = LoadMirrorConfig(o.MirrorConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var affinity *shardAffinity
+	if o.ShardAffinityTTL > 0 {
+		if affinity, err = newShardAffinity(index, o.ShardAffinityTTL, o.ShardAffinityKeyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var webhookAuthorizer authorizer.Authorizer
+	if o.AuthorizationWebhook.Enabled() {
+		if webhookAuthorizer, err = o.AuthorizationWebhook.NewAuthorizer(proxyClusterExtra); err != nil {
+			return nil, err
+		}
+	}
+
+	drainer := newShardDrainer(index, o.ShardDrainTimeout)
+	go drainer.Start(ctx)
+
 	logger := klog.FromContext(ctx)
 	for _, m := range mapping {
 		logger.WithValues("mapping", m).V(2).Info("adding mapping")
@@ -142,22 +179,12 @@ func NewHandler(ctx context.Context, o *proxyoptions.Options, index index.Index)
 			return nil, fmt.Errorf("failed to create path mapping for path %q: failed to parse URL %q: %w", m.Path, m.Backend, err)
 		}
 
-		transport, err := newTransport(m.ProxyClientCert, m.ProxyClientKey, m.BackendServerCA)
+		backendTransport, err := newTransport(m.ProxyClientCert, m.ProxyClientKey, m.BackendServerCA)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create path mapping for path %q: %w", m.Path, err)
 		}
-
-		var handler http.Handler
-		if m.Path == "/clusters/" {
-			clusterProxy := newShardReverseProxy()
-			clusterProxy.Transport = transport
-			handler = shardHandler(index, clusterProxy)
-		} else {
-			// TODO: handle virtual workspace apiservers per shard
-			proxy := httputil.NewSingleHostReverseProxy(u)
-			proxy.Transport = transport
-			handler = proxy
-		}
+		// propagate the trace context of the request to the backend, as a child of the proxy span
+		transport := tracing.WrapperFor(tracerProvider)(backendTransport)
 
 		userHeader := "X-Remote-User"
 		groupHeader := "X-Remote-Group"
@@ -172,6 +199,62 @@ func NewHandler(ctx context.Context, o *proxyoptions.Options, index index.Index)
 			extraHeaderPrefix = m.ExtraHeaderPrefix
 		}
 
+		var handler http.Handler
+		if m.Path == "/clusters/" {
+			clusterProxy := newShardReverseProxy()
+			clusterProxy.Transport = transport
+			var wildcard http.Handler
+			if o.AggregateWildcardRequests {
+				wildcard = &wildcardHandler{
+					index:             index,
+					transport:         transport,
+					userHeader:        userHeader,
+					groupHeader:       groupHeader,
+					extraHeaderPrefix: extraHeaderPrefix,
+				}
+			}
+			shardProxy := drainer.WithTracking(withAccessLogDetails(clusterProxy))
+			if mirrorConfig != nil {
+				shardProxy = newMirror(mirrorConfig, index, transport).WithMirroring(shardProxy)
+			}
+			if o.ShardCircuitBreaker {
+				breaker := newCircuitBreaker(index, transport)
+				go breaker.Start(ctx, healthCheckInterval)
+				shardProxy = breaker.WithCircuitBreaker(shardProxy)
+			}
+			var streams *streamLimiter
+			if o.MaxStreamsPerWorkspace > 0 {
+				streams = newStreamLimiter(o.MaxStreamsPerWorkspace)
+			}
+			// limits and the authorization webhook apply to the requests proxied to a shard and the
+			// aggregated wildcard requests alike, the latter fanning out to all shards.
+			withLimits := func(handler http.Handler) http.Handler {
+				if streams != nil {
+					handler = streams.WithStreamLimits(handler)
+				}
+				if webhookAuthorizer != nil {
+					handler = WithWebhookAuthorization(handler, webhookAuthorizer)
+				}
+				if limiter != nil {
+					handler = limiter.WithRateLimiting(handler)
+				}
+				return handler
+			}
+			shardProxy = withLimits(shardProxy)
+			if wildcard != nil {
+				wildcard = withLimits(wildcard)
+			}
+			if affinity != nil {
+				shardProxy = affinity.WithShardAffinity(shardProxy)
+			}
+			handler = shardHandler(index, shardProxy, wildcard)
+		} else {
+			// TODO: handle virtual workspace apiservers per shard
+			proxy := httputil.NewSingleHostReverseProxy(u)
+			proxy.Transport = transport
+			handler = withAccessLogDetails(proxy)
+		}
+
 		handler = WithProxyAuthHeaders(handler, userHeader, groupHeader, extraHeaderPrefix)
 
 		logger.V(2).WithValues("path", m.Path).Info("adding handler")
@@ -32,6 +32,37 @@ func WithLatencyTracking(delegate http.Handler) http.Handler {
 	return promhttp.InstrumentHandlerDuration(requestLatencies.HistogramVec, delegate)
 }
 
+// RecordRateLimited counts a request rejected by the rate limit of the given class.
+func RecordRateLimited(class string) {
+	rateLimitedRequests.WithLabelValues(class).Inc()
+}
+
+// RecordMirrored counts a mirrored request with the given result, one of match, diverged,
+// error, skipped or dropped.
+func RecordMirrored(result string) {
+	mirroredRequests.WithLabelValues(result).Inc()
+}
+
+// RecordStreamStarted and RecordStreamFinished track the streaming requests in flight.
+func RecordStreamStarted() {
+	streamsInFlight.Inc()
+}
+
+func RecordStreamFinished() {
+	streamsInFlight.Dec()
+}
+
+// RecordStreamRejected counts a streaming request rejected by the per-workspace stream limit.
+func RecordStreamRejected() {
+	rejectedStreams.Inc()
+}
+
+// RecordCircuitBreakerRejected counts a request rejected because the circuit breaker of its
+// shard is open.
+func RecordCircuitBreakerRejected() {
+	circuitBreakerRejectedRequests.Inc()
+}
+
 // TODO(csams): enhance metrics to include shard url.
 var (
 	requestLatencies = compbasemetrics.NewHistogramVec(
@@ -44,6 +75,48 @@ var (
 		},
 		[]string{"method", "code"},
 	)
+
+	rateLimitedRequests = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "proxy_rate_limited_requests_total",
+			Help:           "Number of requests rejected by the per-workspace rate limit for each rate limit class.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"class"},
+	)
+
+	mirroredRequests = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "proxy_mirrored_requests_total",
+			Help:           "Number of read requests mirrored to a second shard, by the result of comparing the responses.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+
+	streamsInFlight = compbasemetrics.NewGauge(
+		&compbasemetrics.GaugeOpts{
+			Name:           "proxy_streams_in_flight",
+			Help:           "Number of watch, exec, attach, port-forward and proxy streams currently proxied to shards.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+
+	rejectedStreams = compbasemetrics.NewCounter(
+		&compbasemetrics.CounterOpts{
+			Name:           "proxy_rejected_streams_total",
+			Help:           "Number of streaming requests rejected because their workspace had too many streams.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+
+	circuitBreakerRejectedRequests = compbasemetrics.NewCounter(
+		&compbasemetrics.CounterOpts{
+			Name:           "proxy_circuit_breaker_rejected_requests_total",
+			Help:           "Number of requests rejected because the circuit breaker of their shard was open.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
 )
 
 var registerMetrics sync.Once
@@ -52,6 +125,11 @@ var registerMetrics sync.Once
 func Register() {
 	registerMetrics.Do(func() {
 		legacyregistry.MustRegister(requestLatencies)
+		legacyregistry.MustRegister(rateLimitedRequests)
+		legacyregistry.MustRegister(mirroredRequests)
+		legacyregistry.MustRegister(streamsInFlight)
+		legacyregistry.MustRegister(rejectedStreams)
+		legacyregistry.MustRegister(circuitBreakerRejectedRequests)
 	})
 }
 
@@ -0,0 +1,297 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+	"github.com/kcp-dev/kcp/pkg/proxy/metrics"
+)
+
+const (
+	// maxMirroredBodySize is the size of responses up to which mirrored responses are compared.
+	maxMirroredBodySize = 1 << 20
+	// maxInFlightMirrors is the number of concurrent mirrored requests. Requests beyond it are
+	// not mirrored.
+	maxInFlightMirrors = 16
+	// mirrorTimeout is the timeout of mirrored requests.
+	mirrorTimeout = 30 * time.Second
+)
+
+// MirrorConfig configures the mirroring of read requests of workspaces, e.g. to validate a
+// migration to another shard before cutover.
+type MirrorConfig struct {
+	Mirrors []Mirror `json:"mirrors"`
+}
+
+// Mirror mirrors the read requests of logical clusters to a second shard.
+type Mirror struct {
+	// Clusters are the logical cluster names or workspace paths whose requests are mirrored.
+	Clusters []string `json:"clusters"`
+	// Shard is the name of the shard the requests are mirrored to.
+	Shard string `json:"shard,omitempty"`
+	// URL is the base URL the requests are mirrored to if shard is not set, e.g. of
+	// the cache server. It must accept the client certificate of the front-proxy.
+	URL string `json:"url,omitempty"`
+}
+
+// LoadMirrorConfig reads the mirror config from the given file.
+func LoadMirrorConfig(path string) (*MirrorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirror config %q: %w", path, err)
+	}
+	var config MirrorConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mirror config %q: %w", path, err)
+	}
+	for i, m := range config.Mirrors {
+		if (m.Shard == "") == (m.URL == "") {
+			return nil, fmt.Errorf("invalid mirror config %q: mirror %d must set exactly one of shard and url", path, i)
+		}
+	}
+	return &config, nil
+}
+
+// mirror duplicates get and list requests of selected logical clusters to a second shard,
+// compares the responses with the ones of the primary shard and records the result in the
+// proxy_mirrored_requests_total metric. Mirrored requests never affect the primary response.
+type mirror struct {
+	index     index.Index
+	transport http.RoundTripper
+	mirrors   map[string]*Mirror // logical cluster name or path -> mirror
+	inFlight  chan struct{}
+}
+
+func newMirror(config *MirrorConfig, index index.Index, transport http.RoundTripper) *mirror {
+	m := &mirror{
+		index:     index,
+		transport: transport,
+		mirrors:   map[string]*Mirror{},
+		inFlight:  make(chan struct{}, maxInFlightMirrors),
+	}
+	for i := range config.Mirrors {
+		for _, cluster := range config.Mirrors[i].Clusters {
+			if _, found := m.mirrors[cluster]; !found {
+				m.mirrors[cluster] = &config.Mirrors[i]
+			}
+		}
+	}
+	return m
+}
+
+// WithMirroring mirrors the read requests the given handler proxies to the shard URL in the
+// request context.
+func (m *mirror) WithMirroring(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		target, shardPath, ok := m.target(req)
+		if !ok {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		select {
+		case m.inFlight <- struct{}{}:
+		default:
+			metrics.RecordMirrored("dropped")
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		// copy the headers before proxying, the reverse proxy modifies them. The mirrored
+		// response is decompressed by the transport.
+		header := req.Header.Clone()
+		header.Del("Accept-Encoding")
+		recorder := &recordingResponseWriter{ResponseWriter: w}
+		delegate.ServeHTTP(recorder, req)
+		if w.Header().Get("Content-Encoding") != "" || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			recorder.skipped = true // only uncompressed JSON is compared
+		}
+
+		go func() {
+			defer func() { <-m.inFlight }()
+			m.compare(klog.FromContext(req.Context()), target, shardPath, req.URL.RawQuery, header, recorder)
+		}()
+	})
+}
+
+// target returns the base URL to mirror the request to, and the path on the shard.
+func (m *mirror) target(req *http.Request) (*url.URL, string, bool) {
+	if req.Method != http.MethodGet {
+		return nil, "", false
+	}
+	if info, ok := request.RequestInfoFrom(req.Context()); !ok || !info.IsResourceRequest || (info.Verb != "get" && info.Verb != "list") {
+		return nil, "", false
+	}
+	shardURL := ShardURLFrom(req.Context())
+	if shardURL == nil {
+		return nil, "", false
+	}
+	clusterName, ok := clusterNameFromShardPath(shardURL.Path)
+	if !ok {
+		return nil, "", false
+	}
+	mirror, found := m.mirrors[clusterName.String()]
+	if !found {
+		if mirror, found = m.mirrors[clusterPathFromRequestPath(req.URL.Path)]; !found {
+			return nil, "", false
+		}
+	}
+
+	baseURL := mirror.URL
+	if mirror.Shard != "" {
+		if baseURL, found = m.index.ShardBaseURLs()[mirror.Shard]; !found {
+			metrics.RecordMirrored("error")
+			return nil, "", false
+		}
+	}
+	target, err := url.Parse(baseURL)
+	if err != nil || target.Host == shardURL.Host {
+		return nil, "", false // never mirror to the primary shard
+	}
+	return target, shardURL.Path[strings.Index(shardURL.Path, "/clusters/"):], true
+}
+
+func (m *mirror) compare(logger klog.Logger, target *url.URL, shardPath, query string, header http.Header, primary *recordingResponseWriter) {
+	if primary.skipped {
+		metrics.RecordMirrored("skipped")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mirrorTimeout)
+	defer cancel()
+	u := *target
+	u.Path = strings.TrimSuffix(u.Path, "/") + shardPath
+	u.RawQuery = query
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		metrics.RecordMirrored("error")
+		return
+	}
+	req.Header = header
+	resp, err := m.transport.RoundTrip(req)
+	if err != nil {
+		logger.V(4).Info("Mirrored request failed", "url", u.String(), "err", err)
+		metrics.RecordMirrored("error")
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMirroredBodySize+1))
+	if err != nil {
+		metrics.RecordMirrored("error")
+		return
+	}
+
+	if primary.status() != resp.StatusCode || !equivalentBodies(primary.body.Bytes(), body) {
+		logger.V(2).Info("Mirrored response diverged", "url", u.String(), "code", resp.StatusCode, "primaryCode", primary.status())
+		metrics.RecordMirrored("diverged")
+		return
+	}
+	metrics.RecordMirrored("match")
+}
+
+// equivalentBodies compares two JSON responses, ignoring resourceVersions and managedFields
+// which differ between shards.
+func equivalentBodies(a, b []byte) bool {
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalize(x), normalize(y))
+}
+
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if metadata, ok := v["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "resourceVersion")
+			delete(metadata, "managedFields")
+		}
+		for k, value := range v {
+			v[k] = normalize(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalize(value)
+		}
+	}
+	return v
+}
+
+// recordingResponseWriter records the status code and up to maxMirroredBodySize bytes of the
+// body written to the wrapped ResponseWriter.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+
+	code int
+	body bytes.Buffer
+	// skipped is true if the body is not recorded, e.g. because it is too large.
+	skipped bool
+}
+
+func (w *recordingResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingResponseWriter) Write(data []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	if !w.skipped {
+		if w.body.Len()+len(data) > maxMirroredBodySize {
+			w.skipped = true
+			w.body.Reset()
+		} else {
+			w.body.Write(data)
+		}
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *recordingResponseWriter) status() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestMirroring(t *testing.T) {
+	mirrored := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mirrored <- req
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"ConfigMapList","metadata":{"resourceVersion":"2"}}`))
+	}))
+	defer server.Close()
+
+	idx := &fakeIndex{shards: map[string]string{"alpha": "https://alpha:6443", "beta": server.URL}}
+	config := &MirrorConfig{Mirrors: []Mirror{{Clusters: []string{"root:org"}, Shard: "beta"}}}
+	handler := newMirror(config, idx, http.DefaultTransport).WithMirroring(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"ConfigMapList","metadata":{"resourceVersion":"1"}}`))
+	}))
+
+	serve := func(method, verb, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Remote-User", "alice")
+		ctx := WithShardURL(req.Context(), &url.URL{Scheme: "https", Host: "alpha:6443", Path: "/clusters/abc/api/v1/namespaces/default/configmaps"})
+		ctx = request.WithRequestInfo(ctx, &request.RequestInfo{IsResourceRequest: true, Verb: verb})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	w := serve(http.MethodGet, "list", "/clusters/root:org/api/v1/namespaces/default/configmaps?labelSelector=x")
+	require.Equal(t, http.StatusOK, w.Code)
+	select {
+	case req := <-mirrored:
+		require.Equal(t, "/clusters/abc/api/v1/namespaces/default/configmaps", req.URL.Path)
+		require.Equal(t, "labelSelector=x", req.URL.RawQuery)
+		require.Equal(t, "alice", req.Header.Get("X-Remote-User"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+
+	serve(http.MethodGet, "watch", "/clusters/root:org/api/v1/namespaces/default/configmaps?watch=true")
+	serve(http.MethodPost, "create", "/clusters/root:org/api/v1/namespaces/default/configmaps")
+	select {
+	case req := <-mirrored:
+		t.Fatalf("unexpected mirrored request %s", req.URL)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestEquivalentBodies(t *testing.T) {
+	for _, testCase := range []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "equal", a: `{"a":1}`, b: `{"a":1}`, want: true},
+		{name: "resourceVersions differ", a: `{"metadata":{"resourceVersion":"1"},"items":[{"metadata":{"name":"x","resourceVersion":"3"}}]}`, b: `{"metadata":{"resourceVersion":"2"},"items":[{"metadata":{"name":"x","resourceVersion":"4"}}]}`, want: true},
+		{name: "items differ", a: `{"items":[{"metadata":{"name":"x"}}]}`, b: `{"items":[{"metadata":{"name":"y"}}]}`, want: false},
+		{name: "not JSON", a: `foo`, b: `foo`, want: false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.want, equivalentBodies([]byte(testCase.a), []byte(testCase.b)))
+		})
+	}
+}
@@ -20,20 +20,20 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
-	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/spf13/pflag"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/request/union"
 	"k8s.io/apiserver/pkg/authentication/user"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/rest"
-	serviceaccountcontroller "k8s.io/kubernetes/pkg/controller/serviceaccount"
 	kubeoptions "k8s.io/kubernetes/pkg/kubeapiserver/options"
 
+	"github.com/kcp-dev/kcp/pkg/authentication/accesstoken"
+	kcpserviceaccount "github.com/kcp-dev/kcp/pkg/authentication/serviceaccount"
 	"github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	kcpauthentication "github.com/kcp-dev/kcp/pkg/proxy/authentication"
 )
@@ -46,6 +46,13 @@ type Authentication struct {
 	BuiltInOptions *kubeoptions.BuiltInAuthenticationOptions
 	PassOnGroups   []string
 	DropGroups     []string
+
+	// OIDCIssuersConfigFile is the file with the OIDC issuers of organizations.
+	OIDCIssuersConfigFile string
+	// CertificateMappingsFile is the file mapping client certificates to workspace-scoped users.
+	CertificateMappingsFile string
+	// WorkspaceAccessTokenKeyFiles are the files with the keys verifying workspace access tokens.
+	WorkspaceAccessTokenKeyFiles []string
 }
 
 // NewAuthentication creates a default Authentication.
@@ -68,7 +75,7 @@ func NewAuthentication() *Authentication {
 
 // When configured to enable auth other than ClientCert, this returns true.
 func (c *Authentication) AdditionalAuthEnabled() bool {
-	return c.tokenAuthEnabled() || c.serviceAccountAuthEnabled() || c.oidcAuthEnabled()
+	return c.tokenAuthEnabled() || c.serviceAccountAuthEnabled() || c.oidcAuthEnabled() || c.OIDCIssuersConfigFile != "" || len(c.WorkspaceAccessTokenKeyFiles) > 0
 }
 
 func (c *Authentication) oidcAuthEnabled() bool {
@@ -83,7 +90,9 @@ func (c *Authentication) serviceAccountAuthEnabled() bool {
 	return c.BuiltInOptions.ServiceAccounts != nil && len(c.BuiltInOptions.ServiceAccounts.KeyFiles) != 0
 }
 
-func (c *Authentication) ApplyTo(ctx context.Context, authenticationInfo *genericapiserver.AuthenticationInfo, servingInfo *genericapiserver.SecureServingInfo, rootShardConfig *rest.Config) error {
+// ApplyTo configures the authenticators. Service accounts are looked up with shardClient on the
+// shard of their logical cluster.
+func (c *Authentication) ApplyTo(ctx context.Context, authenticationInfo *genericapiserver.AuthenticationInfo, servingInfo *genericapiserver.SecureServingInfo, rootShardConfig *rest.Config, shardClient kcpserviceaccount.ShardClientFunc) error {
 	// Note BuiltInAuthenticationOptions.ApplyTo is not called, so we
 	// can reduce the dependencies pulled in from auth methods which aren't enabled
 	authenticatorConfig, err := c.BuiltInOptions.ToAuthenticationConfig()
@@ -111,13 +120,8 @@ func (c *Authentication) ApplyTo(ctx context.Context, authenticationInfo *generi
 			return fmt.Errorf("failed to create client for ServiceAccountTokenGetter: %w", err)
 		}
 
-		versionedInformers := kcpkubernetesinformers.NewSharedInformerFactory(tokenGetterClient, 10*time.Minute)
-
-		authenticatorConfig.ServiceAccountTokenGetter = serviceaccountcontroller.NewClusterGetterFromClient(
-			tokenGetterClient,
-			versionedInformers.Core().V1().Secrets().Lister(),
-			versionedInformers.Core().V1().ServiceAccounts().Lister(),
-		)
+		// service accounts are looked up on the shard of their logical cluster
+		authenticatorConfig.ServiceAccountTokenGetter = kcpserviceaccount.NewFederatedClusterGetter(shardClient)
 		authenticatorConfig.SecretsWriter = tokenGetterClient.CoreV1().Secrets()
 	}
 
@@ -127,6 +131,46 @@ func (c *Authentication) ApplyTo(ctx context.Context, authenticationInfo *generi
 		return err
 	}
 
+	if c.OIDCIssuersConfigFile != "" {
+		config, err := kcpauthentication.LoadIssuersConfig(c.OIDCIssuersConfigFile)
+		if err != nil {
+			return err
+		}
+		issuers, err := kcpauthentication.NewIssuersAuthenticator(ctx, config)
+		if err != nil {
+			return err
+		}
+		if authenticationInfo.Authenticator == nil {
+			authenticationInfo.Authenticator = issuers
+		} else {
+			authenticationInfo.Authenticator = union.New(authenticationInfo.Authenticator, issuers)
+		}
+	}
+
+	if len(c.WorkspaceAccessTokenKeyFiles) > 0 {
+		accessTokens, err := accesstoken.NewAuthenticator(c.WorkspaceAccessTokenKeyFiles)
+		if err != nil {
+			return err
+		}
+		if authenticationInfo.Authenticator == nil {
+			authenticationInfo.Authenticator = accessTokens
+		} else {
+			authenticationInfo.Authenticator = union.New(authenticationInfo.Authenticator, accessTokens)
+		}
+	}
+
+	if c.CertificateMappingsFile != "" {
+		if authenticatorConfig.ClientCAContentProvider == nil {
+			return fmt.Errorf("--authentication-certificate-mappings requires --client-ca-file")
+		}
+		mapper, err := kcpauthentication.NewCertificateMapper(authenticationInfo.Authenticator, c.CertificateMappingsFile, authenticatorConfig.ClientCAContentProvider.VerifyOptions)
+		if err != nil {
+			return err
+		}
+		go mapper.Start(ctx, kcpauthentication.CertificateMappingsReloadInterval)
+		authenticationInfo.Authenticator = mapper
+	}
+
 	// only pass on those groups to the shards we want
 	if len(c.PassOnGroups) > 0 || len(c.DropGroups) > 0 {
 		filter := &kcpauthentication.GroupFilter{
@@ -165,6 +209,16 @@ func (c *Authentication) AddFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVar(&c.DropGroups, "authentication-drop-groups", c.DropGroups,
 		"Groups that are not passed on to the shard. Empty matches none. \"prefix*\" matches "+
 			"all beginning with the given prefix. Dropping trumps over passing on.")
+	fs.StringVar(&c.OIDCIssuersConfigFile, "authentication-oidc-issuers-config", c.OIDCIssuersConfigFile,
+		"Config file with OIDC issuers, e.g. one per organization, with their claim mappings and "+
+			"the workspaces their users are authenticated for.")
+	fs.StringVar(&c.CertificateMappingsFile, "authentication-certificate-mappings", c.CertificateMappingsFile,
+		"Config file mapping client certificates by organizational unit and subject alternative name "+
+			"patterns to users and groups, authenticated only for the given workspaces. It is reloaded "+
+			"when it changes. Requires --client-ca-file.")
+	fs.StringSliceVar(&c.WorkspaceAccessTokenKeyFiles, "workspace-access-token-key-files", c.WorkspaceAccessTokenKeyFiles,
+		"Files with PEM-encoded public or private keys verifying workspace access tokens, usually the "+
+			"--virtual-workspaces-workspace-access-token-signing-key-file of the shards.")
 }
 
 func (c *Authentication) Validate() []error {
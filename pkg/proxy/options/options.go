@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/pflag"
 
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/webhook"
 )
 
 type Options struct {
@@ -33,8 +36,24 @@ type Options struct {
 	RootDirectory         string
 	RootKubeconfig        string
 	ShardsKubeconfig      string
+	CacheKubeconfig       string
 	ProfilerAddress       string
 	CorsAllowedOriginList []string
+
+	WorkspaceIndexAllowedGroups []string
+	AggregateWildcardRequests   bool
+	ShardDrainTimeout           time.Duration
+	RateLimitConfigFile         string
+	MirrorConfigFile            string
+	PathRewriteConfigFile       string
+	ShardAffinityTTL            time.Duration
+	ShardAffinityKeyFile        string
+	AccessLog                   string
+	AccessLogSampleRate         float64
+	MaxStreamsPerWorkspace      int
+	ShardCircuitBreaker         bool
+	AuthorizationWebhook        *webhook.Options
+	Tracing                     *apiserveroptions.TracingOptions
 }
 
 func NewOptions() *Options {
@@ -43,6 +62,12 @@ func NewOptions() *Options {
 		Authentication: *NewAuthentication(),
 		RootKubeconfig: "",
 		RootDirectory:  ".kcp",
+
+		WorkspaceIndexAllowedGroups: []string{"system:masters"},
+		ShardDrainTimeout:           30 * time.Second,
+		AccessLogSampleRate:         1,
+		AuthorizationWebhook:        webhook.NewOptions(),
+		Tracing:                     apiserveroptions.NewTracingOptions(),
 	}
 
 	// override all the things
@@ -59,8 +84,23 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.RootDirectory, "root-directory", o.RootDirectory, "Root directory.")
 	fs.StringVar(&o.RootKubeconfig, "root-kubeconfig", o.RootKubeconfig, "The path to the kubeconfig of the root shard.")
 	fs.StringVar(&o.ShardsKubeconfig, "shards-kubeconfig", o.ShardsKubeconfig, "The path to the kubeconfig used for communication with all shards. The server name if provided is replaced with a shard's hostname.")
+	fs.StringVar(&o.CacheKubeconfig, "cache-kubeconfig", o.CacheKubeconfig, "The path to the kubeconfig of the cache server. If set, Shards are watched through the cache server instead of the root shard.")
 	fs.StringVar(&o.ProfilerAddress, "profiler-address", "", "[Address]:port to bind the profiler to")
 	fs.StringSliceVar(&o.CorsAllowedOriginList, "cors-allowed-origins", o.CorsAllowedOriginList, "List of allowed origins for CORS, comma separated.  An allowed origin can be a regular expression to support subdomain matching. If this list is empty CORS will not be enabled.")
+	fs.StringSliceVar(&o.WorkspaceIndexAllowedGroups, "workspace-index-allowed-groups", o.WorkspaceIndexAllowedGroups, "Groups of authenticated users allowed to list and watch the workspace index on /workspaceindex. If empty, the workspace index is not served.")
+	fs.DurationVar(&o.ShardDrainTimeout, "shard-drain-timeout", o.ShardDrainTimeout, "How long requests to a shard removed from the index or with a changed base URL, e.g. watches, are kept before they are cancelled, such that clients reconnect to the current shards.")
+	fs.StringVar(&o.RateLimitConfigFile, "workspace-rate-limit-config", o.RateLimitConfigFile, "Config file with the QPS and burst classes of requests per workspace, and the users and groups exempt from them. If unset, requests are not rate limited.")
+	fs.StringVar(&o.MirrorConfigFile, "mirror-config", o.MirrorConfigFile, "Config file with the workspaces whose get and list requests are mirrored to a second shard, comparing the responses, e.g. to validate a migration before cutover. If unset, no requests are mirrored.")
+	fs.StringVar(&o.PathRewriteConfigFile, "path-rewrite-config", o.PathRewriteConfigFile, "Config file with rules rewriting external URL prefixes to workspace paths, e.g. /orgs/* to /clusters/root:*. It is reloaded when it changes.")
+	fs.DurationVar(&o.ShardAffinityTTL, "shard-affinity-ttl", o.ShardAffinityTTL, "How long requests of a client for a workspace stay on the shard of their first request, using a cookie or header issued by the front-proxy, e.g. to keep watches and subsequent requests on the same shard during a migration. If zero, requests are not pinned.")
+	fs.StringVar(&o.ShardAffinityKeyFile, "shard-affinity-key-file", o.ShardAffinityKeyFile, "File with the key signing shard affinities. It must be shared by all front-proxy replicas. If unset, a random key is used.")
+	fs.StringVar(&o.AccessLog, "access-log", o.AccessLog, "Where to write structured JSON access logs with workspace, logical cluster, shard, user, verb, resource, latency and response code of each request: \"stdout\" or a file path. If unset, no access logs are written.")
+	fs.Float64Var(&o.AccessLogSampleRate, "access-log-sample-rate", o.AccessLogSampleRate, "Fraction of requests written to the access log, between 0 and 1.")
+	fs.IntVar(&o.MaxStreamsPerWorkspace, "max-streams-per-workspace", o.MaxStreamsPerWorkspace, "Maximum number of concurrent watch, exec, attach, port-forward and proxy streams per logical cluster. Further streaming requests are rejected with 429. If zero, streams are not limited.")
+	fs.BoolVar(&o.ShardCircuitBreaker, "shard-circuit-breaker", o.ShardCircuitBreaker, "Fail requests to shards failing their readiness probes or returning mostly 5xx errors fast with 503 and Retry-After, instead of letting them hang, until they recover.")
+	o.AuthorizationWebhook.AddFlags(fs)
+	o.Tracing.AddFlags(fs)
+	fs.BoolVar(&o.AggregateWildcardRequests, "aggregate-wildcard-requests", o.AggregateWildcardRequests, "Serve wildcard list and watch requests on /clusters/* by aggregating them across all shards. The resourceVersions of aggregated requests are opaque and only valid for the front-proxy.")
 }
 
 func (o *Options) Complete() error {
@@ -92,8 +132,26 @@ func (o *Options) Validate() []error {
 		errs = append(errs, fmt.Errorf("--shards-kubeconfig is required"))
 	}
 
+	if o.ShardDrainTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--shard-drain-timeout must not be negative"))
+	}
+	if o.ShardAffinityTTL < 0 {
+		errs = append(errs, fmt.Errorf("--shard-affinity-ttl must not be negative"))
+	}
+	if o.ShardAffinityKeyFile != "" && o.ShardAffinityTTL == 0 {
+		errs = append(errs, fmt.Errorf("--shard-affinity-key-file requires --shard-affinity-ttl"))
+	}
+	if o.MaxStreamsPerWorkspace < 0 {
+		errs = append(errs, fmt.Errorf("--max-streams-per-workspace must not be negative"))
+	}
+	if o.AccessLogSampleRate < 0 || o.AccessLogSampleRate > 1 {
+		errs = append(errs, fmt.Errorf("--access-log-sample-rate must be between 0 and 1"))
+	}
+
 	errs = append(errs, o.SecureServing.Validate()...)
 	errs = append(errs, o.Authentication.Validate()...)
+	errs = append(errs, o.AuthorizationWebhook.Validate()...)
+	errs = append(errs, o.Tracing.Validate()...)
 
 	return errs
 }
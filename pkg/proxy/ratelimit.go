@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/lru"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+	"github.com/kcp-dev/kcp/pkg/proxy/metrics"
+)
+
+const (
+	// defaultRateLimitClass is the name of the class of logical clusters not in any other class.
+	defaultRateLimitClass = "default"
+	// maxRateLimiters is the number of logical clusters (or users in logical clusters) whose
+	// rate limiters are kept. Rate limiters of the least recently used ones start over.
+	maxRateLimiters = 10000
+)
+
+// RateLimitConfig configures the rate limiting of requests per logical cluster.
+type RateLimitConfig struct {
+	// PerUser limits requests per user in every logical cluster, not per logical cluster.
+	PerUser bool `json:"perUser,omitempty"`
+	// Default is the limit of logical clusters not in any of the classes.
+	Default RateLimit `json:"default"`
+	// Classes are the limits of specific logical clusters. The first matching class applies.
+	Classes []RateLimitClass `json:"classes,omitempty"`
+	// ExemptUsers and ExemptGroups are never rate limited, e.g. controllers with priority.
+	ExemptUsers  []string `json:"exemptUsers,omitempty"`
+	ExemptGroups []string `json:"exemptGroups,omitempty"`
+}
+
+// RateLimit is a token bucket rate limit. A QPS of 0 means unlimited.
+type RateLimit struct {
+	QPS   float32 `json:"qps"`
+	Burst int     `json:"burst"`
+}
+
+// RateLimitClass is the rate limit of a set of logical clusters.
+type RateLimitClass struct {
+	Name string `json:"name"`
+	RateLimit
+	// Clusters are the logical cluster names or workspace paths of the class.
+	Clusters []string `json:"clusters"`
+}
+
+// LoadRateLimitConfig reads the rate limit config from the given file.
+func LoadRateLimitConfig(path string) (*RateLimitConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit config %q: %w", path, err)
+	}
+	var config RateLimitConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rate limit config %q: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit config %q: %w", path, err)
+	}
+	return &config, nil
+}
+
+func (c *RateLimitConfig) validate() error {
+	names := sets.New[string](defaultRateLimitClass)
+	for _, class := range append([]RateLimitClass{{Name: defaultRateLimitClass, RateLimit: c.Default}}, c.Classes...) {
+		if class.QPS < 0 || class.Burst < 0 {
+			return fmt.Errorf("qps and burst of class %q must not be negative", class.Name)
+		}
+		if class.QPS > 0 && class.Burst < 1 {
+			return fmt.Errorf("burst of class %q must be at least 1", class.Name)
+		}
+		if class.Name == defaultRateLimitClass {
+			continue
+		}
+		if class.Name == "" || names.Has(class.Name) {
+			return fmt.Errorf("class names must be unique and not empty or %q, got %q", defaultRateLimitClass, class.Name)
+		}
+		names.Insert(class.Name)
+	}
+	return nil
+}
+
+// rateLimiter limits the requests proxied to shards per logical cluster, and optionally
+// per user, according to the class of the logical cluster.
+type rateLimiter struct {
+	config       *RateLimitConfig
+	index        index.Index
+	exemptUsers  sets.Set[string]
+	exemptGroups sets.Set[string]
+
+	classesLock sync.RWMutex
+	classes     map[logicalcluster.Name]*RateLimitClass
+
+	lock     sync.Mutex
+	limiters *lru.Cache // key -> flowcontrol.RateLimiter
+}
+
+func newRateLimiter(config *RateLimitConfig, index index.Index) *rateLimiter {
+	l := &rateLimiter{
+		config:       config,
+		index:        index,
+		exemptUsers:  sets.New[string](config.ExemptUsers...),
+		exemptGroups: sets.New[string](config.ExemptGroups...),
+		limiters:     lru.New(maxRateLimiters),
+	}
+	l.resolveClasses()
+	return l
+}
+
+// Start resolves the workspace paths of the classes again whenever the index changes,
+// until the context is done.
+func (l *rateLimiter) Start(ctx context.Context) {
+	changed, unsubscribe := l.index.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+			l.resolveClasses()
+		}
+	}
+}
+
+// resolveClasses maps the logical clusters of the classes to their class. Workspace paths are
+// resolved to logical cluster names through the index, such that a class applies to requests
+// to a logical cluster by path and by name alike. Paths not in the index are skipped.
+func (l *rateLimiter) resolveClasses() {
+	classes := map[logicalcluster.Name]*RateLimitClass{}
+	for i := range l.config.Classes {
+		for _, cluster := range l.config.Classes[i].Clusters {
+			clusterName := logicalcluster.Name(cluster)
+			if u, found := l.index.LookupURL(logicalcluster.NewPath(cluster)); found {
+				shardURL, err := url.Parse(u)
+				if err != nil {
+					continue
+				}
+				if clusterName, found = clusterNameFromShardPath(shardURL.Path); !found {
+					continue // e.g. mounts
+				}
+			} else if strings.Contains(cluster, ":") {
+				continue
+			}
+			if _, found := classes[clusterName]; !found {
+				classes[clusterName] = &l.config.Classes[i]
+			}
+		}
+	}
+
+	l.classesLock.Lock()
+	defer l.classesLock.Unlock()
+	l.classes = classes
+}
+
+// WithRateLimiting rejects requests with 429 Too Many Requests when the logical cluster of
+// the request exceeds its rate limit. Aggregated wildcard requests are limited as the
+// logical cluster "*".
+func (l *rateLimiter) WithRateLimiting(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		clusterName, ok := clusterNameFromRequest(req)
+		if !ok {
+			delegate.ServeHTTP(w, req) // e.g. mounts
+			return
+		}
+		clusterPath := clusterPathFromRequestPath(req.URL.Path)
+
+		user, _ := request.UserFrom(req.Context())
+		if user != nil && (l.exemptUsers.Has(user.GetName()) || l.exemptGroups.HasAny(user.GetGroups()...)) {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		name, limit := defaultRateLimitClass, l.config.Default
+		l.classesLock.RLock()
+		class, found := l.classes[clusterName]
+		l.classesLock.RUnlock()
+		if found {
+			name, limit = class.Name, class.RateLimit
+		}
+		if limit.QPS == 0 {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		// the class is part of the key, such that a logical cluster moved to another class
+		// gets a rate limiter with the limit of the new class.
+		key := name + "/" + clusterName.String()
+		if l.config.PerUser && user != nil {
+			key += "/" + user.GetName()
+		}
+		if !l.limiter(key, limit).TryAccept() {
+			metrics.RecordRateLimited(name)
+			err := apierrors.NewTooManyRequests(fmt.Sprintf("rate limit of workspace %q exceeded", clusterPath), 1)
+			responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		delegate.ServeHTTP(w, req)
+	})
+}
+
+func (l *rateLimiter) limiter(key string, limit RateLimit) flowcontrol.RateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if limiter, found := l.limiters.Get(key); found {
+		return limiter.(flowcontrol.RateLimiter)
+	}
+	limiter := flowcontrol.NewTokenBucketRateLimiter(limit.QPS, limit.Burst)
+	l.limiters.Add(key, limiter)
+	return limiter
+}
+
+// clusterNameFromShardPath returns the logical cluster name of a [<prefix>]/clusters/<name>/...
+// shard path.
+func clusterNameFromShardPath(path string) (logicalcluster.Name, bool) {
+	cs := strings.Split(path, "/")
+	for i := 0; i < len(cs)-1; i++ {
+		if cs[i] == "clusters" && cs[i+1] != "" {
+			return logicalcluster.Name(cs[i+1]), true
+		}
+	}
+	return "", false
+}
+
+// clusterNameFromRequest returns the logical cluster of a request, i.e. the one of the shard
+// URL in the request context, or "*" for wildcard requests aggregated across the shards.
+func clusterNameFromRequest(req *http.Request) (logicalcluster.Name, bool) {
+	if shardURL := ShardURLFrom(req.Context()); shardURL != nil {
+		return clusterNameFromShardPath(shardURL.Path)
+	}
+	if clusterPathFromRequestPath(req.URL.Path) == logicalcluster.Wildcard.String() {
+		return logicalcluster.Name(logicalcluster.Wildcard.String()), true
+	}
+	return "", false
+}
+
+// clusterPathFromRequestPath returns the workspace path of a /clusters/<path>/... request.
+func clusterPathFromRequestPath(path string) string {
+	cs := strings.SplitN(strings.TrimLeft(path, "/"), "/", 3)
+	if len(cs) < 2 {
+		return ""
+	}
+	return cs[1]
+}
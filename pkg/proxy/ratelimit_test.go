@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestRateLimiting(t *testing.T) {
+	config := &RateLimitConfig{
+		Default: RateLimit{QPS: 0.001, Burst: 2},
+		Classes: []RateLimitClass{
+			{Name: "large", RateLimit: RateLimit{QPS: 0.001, Burst: 4}, Clusters: []string{"root:large"}},
+			{Name: "unlimited", Clusters: []string{"unlimited"}},
+		},
+		ExemptGroups: []string{"system:masters"},
+	}
+	require.NoError(t, config.validate())
+
+	for _, testCase := range []struct {
+		name        string
+		perUser     bool
+		requestPath string
+		shardPath   string
+		users       []string
+		groups      []string
+
+		wantAccepted int
+	}{
+		{name: "default class", requestPath: "/clusters/root:org/api", shardPath: "/clusters/abc/api", users: []string{"alice"}, wantAccepted: 2},
+		{name: "class by path", requestPath: "/clusters/root:large/api", shardPath: "/clusters/def/api", users: []string{"alice"}, wantAccepted: 4},
+		{name: "class by path requested by name", requestPath: "/clusters/def/api", shardPath: "/clusters/def/api", users: []string{"alice"}, wantAccepted: 4},
+		{name: "class by name", requestPath: "/clusters/root:org/api", shardPath: "/clusters/unlimited/api", users: []string{"alice"}, wantAccepted: 10},
+		{name: "exempt group", requestPath: "/clusters/root:org/api", shardPath: "/clusters/abc/api", users: []string{"admin"}, groups: []string{"system:masters"}, wantAccepted: 10},
+		{name: "shared by users", requestPath: "/clusters/root:org/api", shardPath: "/clusters/abc/api", users: []string{"alice", "bob"}, wantAccepted: 2},
+		{name: "per user", perUser: true, requestPath: "/clusters/root:org/api", shardPath: "/clusters/abc/api", users: []string{"alice", "bob"}, wantAccepted: 4},
+		{name: "wildcard", requestPath: "/clusters/*/api/v1/configmaps", users: []string{"alice"}, wantAccepted: 2},
+		{name: "not a logical cluster", requestPath: "/clusters/root:mount/api", shardPath: "/services/mount/api", users: []string{"alice"}, wantAccepted: 10},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := *config
+			config.PerUser = testCase.perUser
+			idx := &fakeIndex{urls: map[string]string{"root:large": "https://shard/clusters/def"}}
+			handler := newRateLimiter(&config, idx).WithRateLimiting(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			accepted := 0
+			for i := 0; i < 10; i++ {
+				for _, name := range testCase.users {
+					req := httptest.NewRequest(http.MethodGet, testCase.requestPath, nil)
+					ctx := req.Context()
+					if testCase.shardPath != "" {
+						// aggregated wildcard requests have no shard URL
+						ctx = WithShardURL(ctx, &url.URL{Scheme: "https", Host: "shard", Path: testCase.shardPath})
+					}
+					ctx = request.WithUser(ctx, &user.DefaultInfo{Name: name, Groups: testCase.groups})
+					w := httptest.NewRecorder()
+					handler.ServeHTTP(w, req.WithContext(ctx))
+					switch w.Code {
+					case http.StatusOK:
+						accepted++
+					case http.StatusTooManyRequests:
+						require.Equal(t, "1", w.Header().Get("Retry-After"))
+					default:
+						t.Fatalf("unexpected status code %d", w.Code)
+					}
+				}
+			}
+			require.Equal(t, testCase.wantAccepted, accepted)
+		})
+	}
+}
+
+func TestRateLimitingFollowsIndex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := &RateLimitConfig{
+		Default: RateLimit{QPS: 0.001, Burst: 1},
+		Classes: []RateLimitClass{
+			{Name: "large", RateLimit: RateLimit{QPS: 0.001, Burst: 3}, Clusters: []string{"root:large"}},
+		},
+	}
+	idx := &fakeIndex{urls: map[string]string{}, ch: make(chan struct{})}
+	l := newRateLimiter(config, idx)
+	go l.Start(ctx)
+	handler := l.WithRateLimiting(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	accepted := func() int {
+		n := 0
+		for i := 0; i < 5; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/clusters/def/api", nil)
+			ctx := WithShardURL(req.Context(), &url.URL{Scheme: "https", Host: "shard", Path: "/clusters/def/api"})
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req.WithContext(ctx))
+			if w.Code == http.StatusOK {
+				n++
+			}
+		}
+		return n
+	}
+	require.Equal(t, 1, accepted(), "the workspace is not in the index yet")
+
+	idx.lock.Lock()
+	idx.urls["root:large"] = "https://shard/clusters/def"
+	idx.lock.Unlock()
+	idx.ch <- struct{}{}
+	require.Eventually(t, func() bool {
+		l.classesLock.RLock()
+		defer l.classesLock.RUnlock()
+		return l.classes["def"] != nil
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+	require.Equal(t, 3, accepted(), "the workspace is resolved to its logical cluster")
+}
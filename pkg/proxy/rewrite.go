@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// pathRewriteReloadInterval is how often the path rewrite config file is checked for changes.
+const pathRewriteReloadInterval = 10 * time.Second
+
+// PathRewriteConfig configures the rewriting of external URL prefixes to workspace paths.
+type PathRewriteConfig struct {
+	Rules []PathRewriteRule `json:"rules"`
+}
+
+// PathRewriteRule rewrites requests with the From prefix to the To prefix, keeping the
+// rest of the path. A "*" segment in From matches any one segment, and replaces the
+// corresponding "*" in To, e.g. /orgs/* to /clusters/root:*.
+type PathRewriteRule struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type compiledRewriteRule struct {
+	from []string
+	to   string
+}
+
+// pathRewriter rewrites request paths with the rules of a config file, reloaded when it changes.
+type pathRewriter struct {
+	file  string
+	data  []byte
+	rules atomic.Pointer[[]compiledRewriteRule]
+}
+
+func newPathRewriter(file string) (*pathRewriter, error) {
+	r := &pathRewriter{file: file}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Start reloads the config file every interval until the context is done. Invalid configs
+// are logged, and the previous rules are kept.
+func (r *pathRewriter) Start(ctx context.Context, interval time.Duration) {
+	logger := klog.FromContext(ctx)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.load(); err != nil {
+			logger.Error(err, "failed to reload path rewrite rules, keeping the previous ones")
+		}
+	}, interval)
+}
+
+func (r *pathRewriter) load() error {
+	data, err := os.ReadFile(r.file)
+	if err != nil {
+		return fmt.Errorf("failed to read path rewrite config %q: %w", r.file, err)
+	}
+	if r.rules.Load() != nil && bytes.Equal(data, r.data) {
+		return nil
+	}
+	var config PathRewriteConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return fmt.Errorf("failed to unmarshal path rewrite config %q: %w", r.file, err)
+	}
+	rules, err := compileRewriteRules(config.Rules)
+	if err != nil {
+		return fmt.Errorf("invalid path rewrite config %q: %w", r.file, err)
+	}
+	r.rules.Store(&rules)
+	r.data = data
+	return nil
+}
+
+func compileRewriteRules(rules []PathRewriteRule) ([]compiledRewriteRule, error) {
+	compiled := make([]compiledRewriteRule, 0, len(rules))
+	for _, rule := range rules {
+		if !strings.HasPrefix(rule.From, "/") {
+			return nil, fmt.Errorf("from %q must start with /", rule.From)
+		}
+		if !strings.HasPrefix(rule.To, "/clusters/") {
+			return nil, fmt.Errorf("to %q must start with /clusters/", rule.To)
+		}
+		from := strings.Split(strings.Trim(rule.From, "/"), "/")
+		wildcards := 0
+		for _, segment := range from {
+			if segment == "" {
+				return nil, fmt.Errorf("from %q must not have empty segments", rule.From)
+			}
+			if segment == "*" {
+				wildcards++
+			} else if strings.Contains(segment, "*") {
+				return nil, fmt.Errorf("from %q must only have * as whole segments", rule.From)
+			}
+		}
+		if n := strings.Count(rule.To, "*"); n != wildcards {
+			return nil, fmt.Errorf("to %q must have as many * as from %q", rule.To, rule.From)
+		}
+		compiled = append(compiled, compiledRewriteRule{from: from, to: strings.TrimSuffix(rule.To, "/")})
+	}
+	return compiled, nil
+}
+
+// rewrite returns the rewritten path for the first matching rule.
+func (r *pathRewriter) rewrite(path string) (string, bool) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for _, rule := range *r.rules.Load() {
+		if len(segments) < len(rule.from) {
+			continue
+		}
+		var matches []string
+		matched := true
+		for i, segment := range rule.from {
+			if segment == "*" && segments[i] != "" {
+				matches = append(matches, segments[i])
+			} else if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		parts := strings.Split(rule.to, "*")
+		to := parts[0]
+		for i, match := range matches {
+			to += match + parts[i+1]
+		}
+		if rest := segments[len(rule.from):]; len(rest) > 0 {
+			to += "/" + strings.Join(rest, "/")
+		}
+		return to, true
+	}
+	return "", false
+}
+
+// WithPathRewrites rewrites the paths of requests matching a rule of the rewriter.
+func WithPathRewrites(delegate http.Handler, r *pathRewriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if path, ok := r.rewrite(req.URL.Path); ok {
+			klog.FromContext(req.Context()).V(4).Info("Rewriting path", "from", req.URL.Path, "to", path)
+			req = req.Clone(req.Context())
+			req.URL.Path = path
+			req.URL.RawPath = ""
+			req.RequestURI = req.URL.RequestURI()
+		}
+		delegate.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathRewrites(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rewrites.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+rules:
+- from: /orgs/acme
+  to: /clusters/root:acme
+- from: /orgs/*/teams/*
+  to: /clusters/root:*:*
+`), 0600))
+	r, err := newPathRewriter(file)
+	require.NoError(t, err)
+
+	var got string
+	handler := WithPathRewrites(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = req.URL.Path
+	}), r)
+	serve := func(path string) string {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		return got
+	}
+
+	require.Equal(t, "/clusters/root:acme/api/v1/configmaps", serve("/orgs/acme/api/v1/configmaps"))
+	require.Equal(t, "/clusters/root:acme", serve("/orgs/acme"))
+	require.Equal(t, "/clusters/root:foo:bar/apis", serve("/orgs/foo/teams/bar/apis"))
+	require.Equal(t, "/orgs/foo/api", serve("/orgs/foo/api"), "no rule matches")
+	require.Equal(t, "/clusters/root/api", serve("/clusters/root/api"))
+
+	// reloading keeps the previous rules on errors
+	require.NoError(t, os.WriteFile(file, []byte(`rules: [{from: orgs, to: /clusters/root}]`), 0600))
+	require.Error(t, r.load())
+	require.Equal(t, "/clusters/root:acme/api", serve("/orgs/acme/api"))
+
+	require.NoError(t, os.WriteFile(file, []byte(`rules: [{from: /o/*, to: "/clusters/root:*"}]`), 0600))
+	require.NoError(t, r.load())
+	require.Equal(t, "/orgs/acme/api", serve("/orgs/acme/api"))
+	require.Equal(t, "/clusters/root:acme/api", serve("/o/acme/api"))
+}
+
+func TestCompileRewriteRules(t *testing.T) {
+	for _, rule := range []PathRewriteRule{
+		{From: "orgs", To: "/clusters/root"},
+		{From: "/orgs", To: "/root"},
+		{From: "/orgs/*", To: "/clusters/root"},
+		{From: "/orgs/a*", To: "/clusters/root:*"},
+		{From: "/orgs//x", To: "/clusters/root"},
+	} {
+		_, err := compileRewriteRules([]PathRewriteRule{rule})
+		require.Error(t, err, "%v", rule)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	genericfilters "k8s.io/apiserver/pkg/server/filters"
 	restclient "k8s.io/client-go/rest"
 	_ "k8s.io/component-base/metrics/prometheus/workqueue"
+	"k8s.io/component-base/tracing"
 	"k8s.io/klog/v2"
 
 	frontproxyfilters "github.com/kcp-dev/kcp/pkg/proxy/filters"
@@ -51,11 +52,16 @@ func NewServer(ctx context.Context, c CompletedConfig) (*Server, error) {
 	s := &Server{
 		CompletedConfig: c,
 	}
-	rootShardConfigInformerClient, err := kcpclientset.NewForConfig(s.CompletedConfig.RootShardConfig)
+	// Shards are watched through the cache server if configured, otherwise on the root shard.
+	informerConfig := s.CompletedConfig.RootShardConfig
+	if s.CompletedConfig.CacheConfig != nil {
+		informerConfig = s.CompletedConfig.CacheConfig
+	}
+	informerClient, err := kcpclientset.NewForConfig(informerConfig)
 	if err != nil {
 		return s, fmt.Errorf("failed to create client for informers: %w", err)
 	}
-	s.KcpSharedInformerFactory = kcpinformers.NewSharedScopedInformerFactoryWithOptions(rootShardConfigInformerClient.Cluster(core.RootCluster.Path()), 30*time.Minute)
+	s.KcpSharedInformerFactory = kcpinformers.NewSharedScopedInformerFactoryWithOptions(informerClient.Cluster(core.RootCluster.Path()), 30*time.Minute)
 	s.IndexController = index.NewController(
 		ctx,
 		s.KcpSharedInformerFactory.Core().V1alpha1().Shards(),
@@ -70,7 +76,9 @@ func NewServer(ctx context.Context, c CompletedConfig) (*Server, error) {
 		},
 	)
 
-	handler, err := NewHandler(ctx, s.CompletedConfig.Options, s.IndexController)
+	s.CompletedConfig.ShardIndex.Bind(s.IndexController)
+
+	handler, err := NewHandler(ctx, s.CompletedConfig.Options, s.IndexController, s.CompletedConfig.TracerProvider)
 	if err != nil {
 		return s, err
 	}
@@ -81,12 +89,28 @@ func NewServer(ctx context.Context, c CompletedConfig) (*Server, error) {
 		failedHandler,
 		s.CompletedConfig.AuthenticationInfo.Authenticator,
 		s.CompletedConfig.AdditionalAuthEnabled)
+	if c.Options.AccessLog != "" {
+		accessLogger, err := newAccessLogger(s.IndexController, c.Options.AccessLog, c.Options.AccessLogSampleRate)
+		if err != nil {
+			return s, err
+		}
+		handler = accessLogger.WithAccessLog(handler)
+	}
 
 	requestInfoFactory := requestinfo.NewFactory()
 	handler = server.WithInClusterServiceAccountRequestRewrite(handler)
 	handler = genericapifilters.WithRequestInfo(handler, requestInfoFactory)
+	if c.Options.PathRewriteConfigFile != "" {
+		rewriter, err := newPathRewriter(c.Options.PathRewriteConfigFile)
+		if err != nil {
+			return s, err
+		}
+		go rewriter.Start(ctx, pathRewriteReloadInterval)
+		handler = WithPathRewrites(handler, rewriter)
+	}
 	handler = genericfilters.WithHTTPLogging(handler)
 	handler = metrics.WithLatencyTracking(handler)
+	handler = tracing.WithTracing(handler, c.TracerProvider, "KCP Front-Proxy")
 	handler = genericfilters.WithPanicRecovery(handler, requestInfoFactory)
 	handler = genericfilters.WithCORS(handler, c.Options.CorsAllowedOriginList, nil, nil, nil, "true")
 
@@ -103,6 +127,15 @@ func NewServer(ctx context.Context, c CompletedConfig) (*Server, error) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
+	if len(c.Options.WorkspaceIndexAllowedGroups) > 0 {
+		indexHandler := frontproxyfilters.WithOptionalAuthentication(
+			index.NewHandler(s.IndexController, c.Options.WorkspaceIndexAllowedGroups),
+			failedHandler,
+			s.CompletedConfig.AuthenticationInfo.Authenticator,
+			s.CompletedConfig.AdditionalAuthEnabled)
+		mux.Handle(index.Path, genericfilters.WithPanicRecovery(indexHandler, requestInfoFactory))
+	}
+
 	mux.Handle("/", handler)
 	s.Handler = mux
 
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/kcp-dev/kcp/pkg/proxy/metrics"
+)
+
+// streamingSubresources are the subresources whose requests are long-running streams.
+var streamingSubresources = sets.New[string]("exec", "attach", "portforward", "proxy")
+
+// streamLimiter limits the number of concurrent streaming requests, i.e. watches, exec,
+// attach, port-forward and proxy requests, per logical cluster, such that single
+// tenants cannot exhaust the connection capacity of the shards.
+type streamLimiter struct {
+	max int
+
+	lock    sync.Mutex
+	streams map[logicalcluster.Name]int
+}
+
+func newStreamLimiter(max int) *streamLimiter {
+	return &streamLimiter{max: max, streams: map[logicalcluster.Name]int{}}
+}
+
+// WithStreamLimits rejects streaming requests with 429 Too Many Requests when the logical
+// cluster of the request has the maximum number of streams already. Aggregated wildcard
+// watches count as streams of the logical cluster "*".
+func (l *streamLimiter) WithStreamLimits(delegate http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !isStreamingRequest(req) {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+		clusterName, ok := clusterNameFromRequest(req)
+		if !ok {
+			delegate.ServeHTTP(w, req) // e.g. mounts
+			return
+		}
+
+		if !l.acquire(clusterName) {
+			metrics.RecordStreamRejected()
+			err := apierrors.NewTooManyRequests(fmt.Sprintf("too many concurrent streams in workspace %q", clusterPathFromRequestPath(req.URL.Path)), 1)
+			responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		metrics.RecordStreamStarted()
+		defer func() {
+			metrics.RecordStreamFinished()
+			l.release(clusterName)
+		}()
+		delegate.ServeHTTP(w, req)
+	})
+}
+
+func (l *streamLimiter) acquire(clusterName logicalcluster.Name) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.streams[clusterName] >= l.max {
+		return false
+	}
+	l.streams[clusterName]++
+	return true
+}
+
+func (l *streamLimiter) release(clusterName logicalcluster.Name) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.streams[clusterName]--; l.streams[clusterName] <= 0 {
+		delete(l.streams, clusterName)
+	}
+}
+
+// isStreamingRequest returns true for watches, connection upgrades and requests to
+// streaming subresources.
+func isStreamingRequest(req *http.Request) bool {
+	if req.Header.Get("Upgrade") != "" {
+		return true
+	}
+	info, ok := request.RequestInfoFrom(req.Context())
+	if !ok || !info.IsResourceRequest {
+		return false
+	}
+	return info.Verb == "watch" || streamingSubresources.Has(info.Subresource)
+}
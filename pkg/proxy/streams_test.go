@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestStreamLimits(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	handler := newStreamLimiter(2).WithStreamLimits(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if isStreamingRequest(req) {
+			started <- struct{}{}
+			<-release
+		}
+	}))
+
+	serve := func(cluster string, info *request.RequestInfo) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/root:org/api/v1/configmaps", nil)
+		ctx := WithShardURL(req.Context(), &url.URL{Scheme: "https", Host: "alpha:6443", Path: "/clusters/" + cluster + "/api/v1/configmaps"})
+		ctx = request.WithRequestInfo(ctx, info)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+	watch := &request.RequestInfo{IsResourceRequest: true, Verb: "watch", Resource: "configmaps"}
+	exec := &request.RequestInfo{IsResourceRequest: true, Verb: "create", Resource: "pods", Subresource: "exec"}
+
+	done := make(chan struct{})
+	for _, info := range []*request.RequestInfo{watch, exec} {
+		go func() {
+			serve("abc", info)
+			done <- struct{}{}
+		}()
+		<-started
+	}
+
+	t.Log("Further streams of the logical cluster are rejected")
+	require.Equal(t, http.StatusTooManyRequests, serve("abc", watch).Code)
+
+	t.Log("Other logical clusters and non-streaming requests are not limited")
+	require.Equal(t, http.StatusOK, serve("abc", &request.RequestInfo{IsResourceRequest: true, Verb: "list", Resource: "configmaps"}).Code)
+	go func() {
+		serve("def", watch)
+		done <- struct{}{}
+	}()
+	<-started
+
+	t.Log("Finished streams free their slot")
+	close(release)
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+	require.Equal(t, http.StatusOK, serve("abc", watch).Code)
+}
+
+func TestStreamLimitsWildcard(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := newStreamLimiter(1).WithStreamLimits(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))
+
+	// aggregated wildcard requests have no shard URL
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/*/api/v1/configmaps?watch=true", nil)
+		ctx := request.WithRequestInfo(req.Context(), &request.RequestInfo{IsResourceRequest: true, Verb: "watch", Resource: "configmaps"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	done := make(chan struct{})
+	go func() {
+		serve()
+		close(done)
+	}()
+	<-started
+
+	t.Log("Further wildcard watches are rejected")
+	require.Equal(t, http.StatusTooManyRequests, serve().Code)
+
+	close(release)
+	<-done
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	apiserveroptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/component-base/tracing"
+	tracingapi "k8s.io/component-base/tracing/api/v1"
+
+	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+)
+
+const tracingServiceName = "kcp-front-proxy"
+
+// newTracerProvider returns the provider of the spans of proxied requests, exporting them with
+// OTLP as configured in the given tracing config file, the same format as a shard's
+// --tracing-config-file. Without config file, spans are not recorded, but the trace context
+// of incoming requests is still propagated to the shards.
+func newTracerProvider(ctx context.Context, configFile string) (tracing.TracerProvider, error) {
+	if configFile == "" {
+		return tracing.NewNoopTracerProvider(), nil
+	}
+
+	config, err := apiserveroptions.ReadTracingConfiguration(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracing config: %w", err)
+	}
+	if errs := tracingapi.ValidateTracingConfiguration(config, kcpfeatures.DefaultFeatureGate, nil); len(errs) > 0 {
+		return nil, fmt.Errorf("failed to validate tracing config: %w", errs.ToAggregate())
+	}
+
+	return tracing.NewProvider(ctx, config, nil, []resource.Option{
+		resource.WithAttributes(semconv.ServiceNameKey.String(tracingServiceName)),
+	})
+}
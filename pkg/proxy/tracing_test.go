@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"k8s.io/component-base/tracing"
+)
+
+func TestTracePropagation(t *testing.T) {
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		clientSpanID = "00f067aa0ba902b7"
+	)
+
+	tests := map[string]struct {
+		tracerProvider    func(recorder *tracetest.SpanRecorder) oteltrace.TracerProvider
+		wantRecordedSpans int
+	}{
+		"spans are recorded": {
+			tracerProvider: func(recorder *tracetest.SpanRecorder) oteltrace.TracerProvider {
+				return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			},
+			wantRecordedSpans: 2,
+		},
+		"trace context is passed through without tracing config": {
+			tracerProvider: func(recorder *tracetest.SpanRecorder) oteltrace.TracerProvider {
+				tp, err := newTracerProvider(context.Background(), "")
+				require.NoError(t, err)
+				return tp
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var backendTraceParent string
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				backendTraceParent = req.Header.Get("traceparent")
+			}))
+			t.Cleanup(backend.Close)
+			backendURL, err := url.Parse(backend.URL)
+			require.NoError(t, err)
+
+			recorder := tracetest.NewSpanRecorder()
+			tp := tc.tracerProvider(recorder)
+			proxy := httputil.NewSingleHostReverseProxy(backendURL)
+			proxy.Transport = tracing.WrapperFor(tp)(http.DefaultTransport)
+			handler := tracing.WithTracing(proxy, tp, "KCP Front-Proxy")
+
+			req := httptest.NewRequest(http.MethodGet, "/clusters/root/api/v1/namespaces", nil)
+			req.Header.Set("traceparent", "00-"+traceID+"-"+clientSpanID+"-01")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Regexp(t, "^00-"+traceID+"-[0-9a-f]{16}-01$", backendTraceParent, "the trace must continue on the backend")
+			require.Len(t, recorder.Ended(), tc.wantRecordedSpans)
+			if tc.wantRecordedSpans == 0 {
+				return
+			}
+			require.NotContains(t, backendTraceParent, clientSpanID, "the backend request must be a child of the proxy spans")
+			for _, span := range recorder.Ended() {
+				require.Equal(t, traceID, span.SpanContext().TraceID().String())
+			}
+		})
+	}
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	_, err := newTracerProvider(context.Background(), write("valid.yaml", `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: TracingConfiguration
+endpoint: localhost:4317
+samplingRatePerMillion: 100
+`))
+	require.NoError(t, err)
+
+	_, err = newTracerProvider(context.Background(), write("invalid.yaml", `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: TracingConfiguration
+samplingRatePerMillion: 2000000
+`))
+	require.ErrorContains(t, err, "failed to validate tracing config")
+
+	_, err = newTracerProvider(context.Background(), filepath.Join(dir, "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read tracing config")
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/dynamic"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	kcpauthorization "github.com/kcp-dev/kcp/pkg/authorization"
+	"github.com/kcp-dev/kcp/pkg/proxy/index"
+	"github.com/kcp-dev/kcp/sdk/client/multishard"
+)
+
+// wildcardHandler serves wildcard list and watch requests, i.e. on /clusters/*, by
+// aggregating them across all shards. The requests to the shards are made as the
+// requesting user, hence the shards authorize them as usual.
+type wildcardHandler struct {
+	index     index.Index
+	transport http.RoundTripper
+
+	userHeader, groupHeader, extraHeaderPrefix string
+}
+
+func (h *wildcardHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := klog.FromContext(ctx)
+
+	attributes, err := filters.GetAuthorizerAttributes(ctx)
+	if err != nil {
+		responsewriters.InternalError(w, req, err)
+		return
+	}
+	info, ok := request.RequestInfoFrom(ctx)
+	if !ok || !info.IsResourceRequest || info.Name != "" || info.Subresource != "" || (info.Verb != "list" && info.Verb != "watch") {
+		logger.WithValues("requestPath", req.URL.Path).V(4).Info("Unsupported wildcard request")
+		responsewriters.Forbidden(ctx, attributes, w, req, kcpauthorization.WorkspaceAccessNotPermittedReason, kubernetesscheme.Codecs)
+		return
+	}
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		responsewriters.Forbidden(ctx, attributes, w, req, kcpauthorization.WorkspaceAccessNotPermittedReason, kubernetesscheme.Codecs)
+		return
+	}
+
+	// the objects of the shards are merged as unstructured objects, which are only encoded as
+	// JSON. Clients accepting only other media types, e.g. protobuf, get 406 Not Acceptable.
+	_, serializer, err := negotiation.NegotiateOutputMediaType(req, jsonNegotiatedSerializer{kubernetesscheme.Codecs}, negotiation.DefaultEndpointRestrictions)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	var options metav1.ListOptions
+	if err := metav1.ParameterCodec.DecodeParameters(req.URL.Query(), metav1.SchemeGroupVersion, &options); err != nil {
+		responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	gvr := schema.GroupVersionResource{Group: info.APIGroup, Version: info.APIVersion, Resource: info.Resource}
+	shards := multishard.Shards{}
+	for name, baseURL := range h.index.ShardBaseURLs() {
+		client, err := dynamic.NewForConfig(&rest.Config{
+			Host: baseURL + "/clusters/*",
+			Transport: &userRoundTripper{
+				delegate: h.transport,
+				header: func(header http.Header) {
+					appendClientCertAuthHeaders(header, user, h.userHeader, h.groupHeader, h.extraHeaderPrefix)
+				},
+			},
+		})
+		if err != nil {
+			responsewriters.InternalError(w, req, err)
+			return
+		}
+		if info.Namespace != "" {
+			shards[name] = client.Resource(gvr).Namespace(info.Namespace)
+		} else {
+			shards[name] = client.Resource(gvr)
+		}
+	}
+
+	if info.Verb == "list" {
+		list, err := multishard.List(ctx, shards, options)
+		if err != nil {
+			responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		bs, err := list.MarshalJSON()
+		if err != nil {
+			responsewriters.InternalError(w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", serializer.MediaType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(bs)
+		return
+	}
+
+	watcher, err := multishard.Watch(ctx, shards, options)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	defer watcher.Stop()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		responsewriters.InternalError(w, req, fmt.Errorf("unable to start watch: streaming not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", serializer.MediaType)
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for event := range watcher.ResultChan() {
+		raw, err := json.Marshal(event.Object)
+		if err != nil {
+			logger.Error(err, "failed to encode wildcard watch event")
+			return
+		}
+		if err := encoder.Encode(&metav1.WatchEvent{Type: string(event.Type), Object: runtime.RawExtension{Raw: raw}}); err != nil {
+			return // client went away
+		}
+		flusher.Flush()
+	}
+}
+
+// jsonNegotiatedSerializer restricts the media types of the delegate to JSON.
+type jsonNegotiatedSerializer struct {
+	runtime.NegotiatedSerializer
+}
+
+func (s jsonNegotiatedSerializer) SupportedMediaTypes() []runtime.SerializerInfo {
+	for _, info := range s.NegotiatedSerializer.SupportedMediaTypes() {
+		if info.MediaType == runtime.ContentTypeJSON {
+			return []runtime.SerializerInfo{info}
+		}
+	}
+	return nil
+}
+
+// userRoundTripper sets the authentication headers of the user on every request.
+type userRoundTripper struct {
+	delegate http.RoundTripper
+	header   func(header http.Header)
+}
+
+func (rt *userRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	rt.header(req.Header)
+	return rt.delegate.RoundTrip(req)
+}
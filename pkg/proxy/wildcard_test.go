@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWildcardContentNegotiation(t *testing.T) {
+	shard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"ConfigMapList","apiVersion":"v1","metadata":{"resourceVersion":"1"},"items":[]}`))
+	}))
+	t.Cleanup(shard.Close)
+
+	handler := &wildcardHandler{
+		index:     &fakeIndex{shards: map[string]string{"alpha": shard.URL}},
+		transport: http.DefaultTransport,
+
+		userHeader:        "X-Remote-User",
+		groupHeader:       "X-Remote-Group",
+		extraHeaderPrefix: "X-Remote-Extra-",
+	}
+
+	for accept, want := range map[string]int{
+		"":                                    http.StatusOK,
+		"application/json":                    http.StatusOK,
+		"application/vnd.kubernetes.protobuf": http.StatusNotAcceptable,
+		"application/vnd.kubernetes.protobuf, application/json": http.StatusOK,
+		"application/json;as=Table;v=v1;g=meta.k8s.io":          http.StatusNotAcceptable,
+	} {
+		t.Run(accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/clusters/*/api/v1/configmaps", nil)
+			if accept != "" {
+				req.Header.Set("Accept", accept)
+			}
+			ctx := request.WithRequestInfo(req.Context(), &request.RequestInfo{IsResourceRequest: true, Verb: "list", APIVersion: "v1", Resource: "configmaps"})
+			ctx = request.WithUser(ctx, &user.DefaultInfo{Name: "alice"})
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req.WithContext(ctx))
+
+			require.Equal(t, want, w.Code, w.Body.String())
+			if want == http.StatusOK {
+				require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+				require.Contains(t, w.Body.String(), `"kind":"ConfigMapList"`)
+			}
+		})
+	}
+}
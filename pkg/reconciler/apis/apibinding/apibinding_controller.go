@@ -45,6 +45,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
@@ -418,7 +420,12 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if requeue, err := c.process(ctx, key); err != nil {
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	requeue, err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
 		c.queue.AddRateLimited(key)
 		return true
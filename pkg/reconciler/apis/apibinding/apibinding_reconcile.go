@@ -408,19 +408,28 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 			continue
 		}
 
-		// Merge any current storage versions with new ones
+		// Merge any current storage versions with new ones. If the resource is already bound to
+		// this schema, the versions recorded in the binding are authoritative because a storage
+		// migration might have dropped versions that are still stored for other bindings of the
+		// same CRD. Only the current storage version of the CRD is added then.
 		storageVersions := sets.New[string]()
-		if existingCRD != nil {
-			storageVersions.Insert(existingCRD.Status.StoredVersions...)
-		}
-
+		alreadyBound := false
 		for _, b := range apiBinding.Status.BoundResources {
 			if b.Group == schema.Spec.Group && b.Resource == schema.Spec.Names.Plural {
 				storageVersions.Insert(b.StorageVersions...)
+				alreadyBound = b.Schema.UID == string(schema.UID)
 				break
 			}
 		}
 
+		if existingCRD != nil {
+			if !alreadyBound {
+				storageVersions.Insert(existingCRD.Status.StoredVersions...)
+			} else if v, err := apihelpers.GetCRDStorageVersion(existingCRD); err == nil {
+				storageVersions.Insert(v)
+			}
+		}
+
 		sortedStorageVersions := sets.List[string](storageVersions)
 		sort.Strings(sortedStorageVersions)
 
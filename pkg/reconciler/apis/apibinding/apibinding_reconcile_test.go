@@ -366,6 +366,37 @@ func TestReconcileBinding(t *testing.T) {
 			wantPhaseBound:             true,
 			wantInitialBindingComplete: true,
 		},
+		"Ensure migrated storage versions are not re-added from the CRD": {
+			apiBinding: binding.DeepCopy().
+				WithBoundResources(
+					new(boundAPIResourceBuilder).
+						WithGroupResource("kcp.io", "widgets").
+						WithSchema("today.widgets.kcp.io", "todaywidgetsuid").
+						WithStorageVersions("v1").
+						BoundAPIResource,
+				).Build(),
+			getCRDError:        nil,
+			crdExists:          true,
+			crdEstablished:     true,
+			crdStorageVersions: []string{"v0", "v1"},
+			wantAPIExportValid: true,
+			wantReady:          true,
+			wantBoundAPIExport: true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "today.widgets.kcp.io",
+						UID:          "todaywidgetsuid",
+						IdentityHash: "hash1",
+					},
+					StorageVersions: []string{"v1"},
+				},
+			},
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+		},
 	}
 
 	for testName, tc := range tests {
@@ -478,6 +509,13 @@ func TestReconcileBinding(t *testing.T) {
 							StoredVersions: tc.crdStorageVersions,
 						},
 					}
+					for i, v := range tc.crdStorageVersions {
+						crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+							Name:    v,
+							Served:  true,
+							Storage: i == len(tc.crdStorageVersions)-1,
+						})
+					}
 
 					if name == "anotherwidgetsuid" {
 						crd.Spec.Group = "kcp.io"
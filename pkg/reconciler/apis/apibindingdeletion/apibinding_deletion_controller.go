@@ -40,6 +40,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
@@ -174,7 +176,11 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
 	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
 
 	if err == nil {
 		// no error, forget this entry and return
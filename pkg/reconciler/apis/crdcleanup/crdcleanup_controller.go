@@ -39,6 +39,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
 )
@@ -189,7 +191,12 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if err := c.process(ctx, key); err != nil {
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
 		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
 		c.queue.AddRateLimited(key)
 		return true
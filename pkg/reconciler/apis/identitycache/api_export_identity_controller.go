@@ -37,6 +37,8 @@ import (
 
 	configshard "github.com/kcp-dev/kcp/config/shard"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
@@ -159,7 +161,11 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	}
 	defer c.queue.Done(key)
 
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, workKey)
+	startTime := time.Now()
 	err := c.reconcile(ctx)
+	reconcilermetrics.ObserveReconcile(ControllerName, workKey, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
 	if err == nil {
 		c.queue.Forget(key)
 		return true
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apiextensions-apiserver/pkg/apihelpers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, binding *apisv1alpha1.APIBinding) error {
+	logger := klog.FromContext(ctx)
+
+	if binding.Status.Phase != apisv1alpha1.APIBindingPhaseBound || !binding.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if binding.Spec.Reference.Export == nil {
+		return nil
+	}
+
+	clusterName := logicalcluster.From(binding)
+	path := logicalcluster.NewPath(binding.Spec.Reference.Export.Path)
+	if path.Empty() {
+		path = clusterName.Path()
+	}
+	export, err := c.getAPIExport(path, binding.Spec.Reference.Export.Name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if export.Spec.StorageMigration == nil || export.Spec.StorageMigration.Strategy != apisv1alpha1.RewriteStorageMigrationStrategy {
+		conditions.Delete(binding, apisv1alpha1.StorageMigrated)
+		return nil
+	}
+
+	for i := range binding.Status.BoundResources {
+		boundResource := &binding.Status.BoundResources[i]
+
+		crd, err := c.getCRD(apibinding.SystemBoundCRDsClusterName, boundResource.Schema.UID)
+		if apierrors.IsNotFound(err) {
+			// the APIBinding controller will recreate the CRD
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		storageVersion, err := apihelpers.GetCRDStorageVersion(crd)
+		if err != nil {
+			return err
+		}
+		if !needsMigration(boundResource.StorageVersions, storageVersion) {
+			continue
+		}
+
+		gvr := schema.GroupVersionResource{Group: boundResource.Group, Version: storageVersion, Resource: boundResource.Resource}
+		logger.V(2).Info("migrating stored objects", "gvr", gvr, "storageVersions", boundResource.StorageVersions)
+		if err := c.rewriteAll(ctx, clusterName.Path(), gvr); err != nil {
+			conditions.MarkFalse(
+				binding,
+				apisv1alpha1.StorageMigrated,
+				apisv1alpha1.StorageMigrationFailedReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"Migrating %s to storage version %s failed: %v",
+				gvr.GroupResource(), storageVersion, err,
+			)
+			return err
+		}
+
+		boundResource.StorageVersions = []string{storageVersion}
+	}
+
+	conditions.MarkTrue(binding, apisv1alpha1.StorageMigrated)
+	return nil
+}
+
+// needsMigration returns true if objects might still be stored in another version
+// than the given storage version.
+func needsMigration(storageVersions []string, storageVersion string) bool {
+	for _, v := range storageVersions {
+		if v != storageVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteAll rewrites all objects of the given resource, page by page, which makes the apiserver
+// persist them in the current storage version. Conflicts and objects deleted in the meantime are
+// ignored because these have been written by somebody else already.
+func (c *controller) rewriteAll(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource) error {
+	continueToken := ""
+	for {
+		list, err := c.listObjects(ctx, cluster, gvr, continueToken)
+		if err != nil {
+			return err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if err := c.updateObject(ctx, cluster, gvr, obj); err != nil {
+				if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+					continue
+				}
+				return fmt.Errorf("failed to rewrite %s %s/%s: %w", gvr.GroupResource(), obj.GetNamespace(), obj.GetName(), err)
+			}
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		migration       *apisv1alpha1.StorageMigration
+		storageVersions []string
+		updateErr       error
+
+		wantUpdates         int
+		wantStorageVersions []string
+		wantCondition       *bool
+		wantErr             bool
+	}{
+		"no migration strategy": {
+			storageVersions:     []string{"v1", "v2"},
+			wantStorageVersions: []string{"v1", "v2"},
+		},
+		"already migrated": {
+			migration:           &apisv1alpha1.StorageMigration{Strategy: apisv1alpha1.RewriteStorageMigrationStrategy},
+			storageVersions:     []string{"v2"},
+			wantStorageVersions: []string{"v2"},
+			wantCondition:       ptr.To(true),
+		},
+		"needs migration": {
+			migration:           &apisv1alpha1.StorageMigration{Strategy: apisv1alpha1.RewriteStorageMigrationStrategy},
+			storageVersions:     []string{"v1", "v2"},
+			wantUpdates:         2,
+			wantStorageVersions: []string{"v2"},
+			wantCondition:       ptr.To(true),
+		},
+		"conflicts are ignored": {
+			migration:           &apisv1alpha1.StorageMigration{Strategy: apisv1alpha1.RewriteStorageMigrationStrategy},
+			storageVersions:     []string{"v1", "v2"},
+			updateErr:           apierrors.NewConflict(schema.GroupResource{Group: "kcp.io", Resource: "widgets"}, "a", errors.New("conflict")),
+			wantUpdates:         2,
+			wantStorageVersions: []string{"v2"},
+			wantCondition:       ptr.To(true),
+		},
+		"update fails": {
+			migration:           &apisv1alpha1.StorageMigration{Strategy: apisv1alpha1.RewriteStorageMigrationStrategy},
+			storageVersions:     []string{"v1", "v2"},
+			updateErr:           errors.New("boom"),
+			wantUpdates:         1,
+			wantStorageVersions: []string{"v1", "v2"},
+			wantCondition:       ptr.To(false),
+			wantErr:             true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			c := &controller{
+				getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+					require.Equal(t, "root:provider", path.String())
+					return &apisv1alpha1.APIExport{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec:       apisv1alpha1.APIExportSpec{StorageMigration: tc.migration},
+					}, nil
+				},
+				getCRD: func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+					require.Equal(t, "uid1", name)
+					return &apiextensionsv1.CustomResourceDefinition{
+						Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+							Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+								{Name: "v1", Served: true},
+								{Name: "v2", Served: true, Storage: true},
+							},
+						},
+					}, nil
+				},
+				listObjects: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, continueToken string) (*unstructured.UnstructuredList, error) {
+					require.Equal(t, "root:consumer", cluster.String())
+					require.Equal(t, schema.GroupVersionResource{Group: "kcp.io", Version: "v2", Resource: "widgets"}, gvr)
+					// one object per page
+					list := &unstructured.UnstructuredList{}
+					obj := unstructured.Unstructured{}
+					switch continueToken {
+					case "":
+						obj.SetName("a")
+						list.SetContinue("b")
+					case "b":
+						obj.SetName("b")
+					default:
+						t.Fatalf("unexpected continue token %q", continueToken)
+					}
+					list.Items = append(list.Items, obj)
+					return list, nil
+				},
+				updateObject: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+					updates++
+					return tc.updateErr
+				},
+			}
+
+			binding := &apisv1alpha1.APIBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "widgets",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "root:consumer",
+					},
+				},
+				Spec: apisv1alpha1.APIBindingSpec{
+					Reference: apisv1alpha1.BindingReference{
+						Export: &apisv1alpha1.ExportBindingReference{Path: "root:provider", Name: "widgets"},
+					},
+				},
+				Status: apisv1alpha1.APIBindingStatus{
+					Phase: apisv1alpha1.APIBindingPhaseBound,
+					BoundResources: []apisv1alpha1.BoundAPIResource{
+						{
+							Group:           "kcp.io",
+							Resource:        "widgets",
+							Schema:          apisv1alpha1.BoundAPIResourceSchema{Name: "v2.widgets.kcp.io", UID: "uid1", IdentityHash: "hash"},
+							StorageVersions: tc.storageVersions,
+						},
+					},
+				},
+			}
+
+			err := c.reconcile(context.Background(), binding)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.wantUpdates, updates)
+			require.Equal(t, tc.wantStorageVersions, binding.Status.BoundResources[0].StorageVersions)
+			if tc.wantCondition == nil {
+				require.Nil(t, conditions.Get(binding, apisv1alpha1.StorageMigrated))
+			} else {
+				require.Equal(t, *tc.wantCondition, conditions.IsTrue(binding, apisv1alpha1.StorageMigrated))
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversionmigration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpapiextensionsv1informers "github.com/kcp-dev/client-go/apiextensions/informers/apiextensions/v1"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-storage-version-migration"
+
+	// batchSize is the number of objects rewritten before the progress is written to the
+	// StorageVersionMigration status.
+	batchSize = 500
+)
+
+// NewController returns a new controller which runs StorageVersionMigrations, i.e. rewrites all
+// objects of a resource in a logical cluster in the current storage version of the resource.
+//
+// getBuiltInStorageVersion returns the storage version of built-in resources, and false if the
+// resource is not a built-in resource.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	crdClusterClient kcpapiextensionsclientset.ClusterInterface,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	migrationInformer apisv1alpha1informers.StorageVersionMigrationClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
+	getBuiltInStorageVersion func(gr schema.GroupResource) (string, bool),
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: queue,
+
+		getMigration: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.StorageVersionMigration, error) {
+			return migrationInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listMigrations: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.StorageVersionMigration, error) {
+			return migrationInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		getAPIBindingByBoundResource: func(clusterName logicalcluster.Name, gr schema.GroupResource) (*apisv1alpha1.APIBinding, error) {
+			bindings, err := indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), indexers.APIBindingByBoundResources, indexers.APIBindingBoundResourceValue(clusterName, gr.Group, gr.Resource))
+			if err != nil {
+				return nil, err
+			}
+			if len(bindings) == 0 {
+				return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apibindings"), gr.String())
+			}
+			return bindings[0], nil
+		},
+		getAPIBindingsByBoundResourceUID: func(uid string) ([]*apisv1alpha1.APIBinding, error) {
+			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), indexers.APIBindingByBoundResourceUID, uid)
+		},
+		getCRD: func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+			return crdInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getBuiltInStorageVersion: getBuiltInStorageVersion,
+		listObjects: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, continueToken string) (*unstructured.UnstructuredList, error) {
+			return dynamicClusterClient.Cluster(cluster).Resource(gvr).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{Limit: batchSize, Continue: continueToken})
+		},
+		updateObject: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+			_, err := dynamicClusterClient.Cluster(cluster).Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			return err
+		},
+		updateAPIBindingStatus: func(ctx context.Context, binding *apisv1alpha1.APIBinding) error {
+			_, err := kcpClusterClient.Cluster(logicalcluster.From(binding).Path()).ApisV1alpha1().APIBindings().UpdateStatus(ctx, binding, metav1.UpdateOptions{})
+			return err
+		},
+		updateCRDStatus: func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
+			_, err := crdClusterClient.Cluster(logicalcluster.From(crd).Path()).ApiextensionsV1().CustomResourceDefinitions().UpdateStatus(ctx, crd, metav1.UpdateOptions{})
+			return err
+		},
+		commit: committer.NewCommitter[*StorageVersionMigration, Patcher, *StorageVersionMigrationSpec, *StorageVersionMigrationStatus](kcpClusterClient.ApisV1alpha1().StorageVersionMigrations()),
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+
+	_, _ = migrationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueMigration(obj, logger, "") },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueMigration(obj, logger, "") },
+	})
+
+	_, _ = apiBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueCluster(logicalcluster.From(obj.(*apisv1alpha1.APIBinding)), logger, " because of APIBinding")
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueueCluster(logicalcluster.From(obj.(*apisv1alpha1.APIBinding)), logger, " because of APIBinding")
+		},
+	})
+
+	_, _ = crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueCRD(obj, logger) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueCRD(obj, logger) },
+	})
+
+	return c, nil
+}
+
+type StorageVersionMigration = apisv1alpha1.StorageVersionMigration
+type StorageVersionMigrationSpec = apisv1alpha1.StorageVersionMigrationSpec
+type StorageVersionMigrationStatus = apisv1alpha1.StorageVersionMigrationStatus
+type Patcher = apisv1alpha1client.StorageVersionMigrationInterface
+type Resource = committer.Resource[*StorageVersionMigrationSpec, *StorageVersionMigrationStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller runs StorageVersionMigrations. It tracks the storage version and the stored versions
+// of the migrated resource per logical cluster, rewrites all objects in batches when the storage
+// version changes, and drops the old versions from the stored versions recorded in the APIBinding
+// or CustomResourceDefinition status when all objects are rewritten.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getMigration                     func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.StorageVersionMigration, error)
+	listMigrations                   func(clusterName logicalcluster.Name) ([]*apisv1alpha1.StorageVersionMigration, error)
+	getAPIBindingByBoundResource     func(clusterName logicalcluster.Name, gr schema.GroupResource) (*apisv1alpha1.APIBinding, error)
+	getAPIBindingsByBoundResourceUID func(uid string) ([]*apisv1alpha1.APIBinding, error)
+	getCRD                           func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error)
+	getBuiltInStorageVersion         func(gr schema.GroupResource) (string, bool)
+
+	listObjects            func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, continueToken string) (*unstructured.UnstructuredList, error)
+	updateObject           func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error
+	updateAPIBindingStatus func(ctx context.Context, binding *apisv1alpha1.APIBinding) error
+	updateCRDStatus        func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) error
+
+	commit CommitFunc
+}
+
+// enqueueMigration enqueues a StorageVersionMigration.
+func (c *controller) enqueueMigration(obj interface{}, logger logr.Logger, logSuffix string) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(4).Info(fmt.Sprintf("queueing StorageVersionMigration%s", logSuffix))
+	c.queue.Add(key)
+}
+
+// enqueueCluster enqueues all StorageVersionMigrations of a logical cluster.
+func (c *controller) enqueueCluster(clusterName logicalcluster.Name, logger logr.Logger, logSuffix string) {
+	migrations, err := c.listMigrations(clusterName)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, migration := range migrations {
+		c.enqueueMigration(migration, logger, logSuffix)
+	}
+}
+
+// enqueueCRD enqueues the StorageVersionMigrations that might be affected by a changed CRD: those of
+// the CRD's logical cluster, or for bound CRDs those of all logical clusters binding the CRD.
+func (c *controller) enqueueCRD(obj interface{}, logger logr.Logger) {
+	crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be a CustomResourceDefinition, but is %T", obj))
+		return
+	}
+
+	logger = logging.WithObject(logger, crd)
+	clusterName := logicalcluster.From(crd)
+	if clusterName != apibinding.SystemBoundCRDsClusterName {
+		c.enqueueCluster(clusterName, logger, " because of CRD")
+		return
+	}
+
+	bindings, err := c.getAPIBindingsByBoundResourceUID(crd.Name)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	clusters := sets.New[logicalcluster.Name]()
+	for _, binding := range bindings {
+		clusters.Insert(logicalcluster.From(binding))
+	}
+	for _, clusterName := range sets.List[logicalcluster.Name](clusters) {
+		c.enqueueCluster(clusterName, logger, " because of bound CRD")
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	requeue, err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if requeue {
+		// continue with the next batch of objects
+		c.queue.Add(key)
+	}
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) (bool, error) {
+	logger := klog.FromContext(ctx)
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		logger.Error(err, "invalid key")
+		return false, nil
+	}
+
+	migration, err := c.getMigration(clusterName, name)
+	if apierrors.IsNotFound(err) {
+		return false, nil // object deleted before we handled it
+	}
+	if err != nil {
+		return false, err
+	}
+
+	logger = logging.WithObject(logger, migration)
+	ctx = klog.NewContext(ctx, logger)
+
+	old := migration
+	migration = migration.DeepCopy()
+
+	requeue, reconcileErr := c.reconcile(ctx, migration)
+
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: migration.ObjectMeta, Spec: &migration.Spec, Status: &migration.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		return false, err
+	}
+
+	return requeue, reconcileErr
+}
+
+// InstallIndexers adds the additional indexers that this controller requires to the informers.
+func InstallIndexers(apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer) {
+	indexers.AddIfNotPresentOrDie(apiBindingInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.APIBindingByBoundResources:   indexers.IndexAPIBindingByBoundResources,
+		indexers.APIBindingByBoundResourceUID: indexers.IndexAPIBindingByBoundResourceUID,
+	})
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversionmigration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apiextensions-apiserver/pkg/apihelpers"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// target is the resource of a StorageVersionMigration as seen in its logical cluster.
+type target struct {
+	storageVersion string
+	storedVersions []string
+
+	// binding is the APIBinding the resource is bound by, if any.
+	binding *apisv1alpha1.APIBinding
+	// crd is the CustomResourceDefinition of the logical cluster defining the resource, if any.
+	crd *apiextensionsv1.CustomResourceDefinition
+}
+
+// reconcile migrates the next batch of objects. It returns true if more objects are to be migrated.
+func (c *controller) reconcile(ctx context.Context, migration *apisv1alpha1.StorageVersionMigration) (bool, error) {
+	logger := klog.FromContext(ctx)
+
+	if !migration.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	clusterName := logicalcluster.From(migration)
+	gr := schema.GroupResource{Group: migration.Spec.Resource.Group, Resource: migration.Spec.Resource.Resource}
+
+	t, err := c.resolve(clusterName, gr)
+	if err != nil {
+		return false, err
+	}
+	if t == nil {
+		conditions.MarkFalse(
+			migration,
+			apisv1alpha1.StorageVersionMigrationSucceeded,
+			apisv1alpha1.StorageVersionUnknownReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"The storage version of %s cannot be determined. The resource is neither bound, nor defined by a CustomResourceDefinition, nor built-in.",
+			gr,
+		)
+		return false, nil
+	}
+	migration.Status.StoredVersions = t.storedVersions
+
+	succeeded := conditions.IsTrue(migration, apisv1alpha1.StorageVersionMigrationSucceeded)
+	if migration.Status.StorageVersion == t.storageVersion && succeeded && !needsMigration(t.storedVersions, t.storageVersion) {
+		return false, nil
+	}
+	if migration.Status.StorageVersion != t.storageVersion || succeeded {
+		// the storage version changed, or old versions were recorded again: start over
+		logger.V(2).Info("starting storage version migration", "resource", gr, "storageVersion", t.storageVersion, "storedVersions", t.storedVersions)
+		migration.Status.StorageVersion = t.storageVersion
+		migration.Status.ContinueToken = ""
+		migration.Status.MigratedObjects = 0
+	}
+
+	gvr := gr.WithVersion(t.storageVersion)
+	list, err := c.listObjects(ctx, clusterName.Path(), gvr, migration.Status.ContinueToken)
+	if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+		// the continue token is too old, start over with a fresh list
+		migration.Status.ContinueToken = ""
+		return true, nil
+	}
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(
+			migration,
+			apisv1alpha1.StorageVersionMigrationSucceeded,
+			apisv1alpha1.StorageVersionUnknownReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"%s is not served in version %s: %v",
+			gr, t.storageVersion, err,
+		)
+		return false, nil
+	}
+	if err != nil {
+		markFailed(migration, gvr, err)
+		return false, err
+	}
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if err := c.updateObject(ctx, clusterName.Path(), gvr, obj); err != nil {
+			// conflicting and deleted objects have been written by somebody else already
+			if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+				continue
+			}
+			err = fmt.Errorf("failed to rewrite %s %s/%s: %w", gr, obj.GetNamespace(), obj.GetName(), err)
+			markFailed(migration, gvr, err)
+			return false, err
+		}
+	}
+	migration.Status.MigratedObjects += int64(len(list.Items))
+	migration.Status.ContinueToken = list.GetContinue()
+
+	if migration.Status.ContinueToken != "" {
+		conditions.MarkFalse(
+			migration,
+			apisv1alpha1.StorageVersionMigrationSucceeded,
+			apisv1alpha1.StorageVersionMigrationRunningReason,
+			conditionsv1alpha1.ConditionSeverityInfo,
+			"Migrated %d objects of %s to storage version %s",
+			migration.Status.MigratedObjects, gr, t.storageVersion,
+		)
+		return true, nil
+	}
+
+	if err := c.dropStoredVersions(ctx, gr, t); err != nil {
+		markFailed(migration, gvr, err)
+		return false, err
+	}
+	if len(t.storedVersions) > 0 {
+		migration.Status.StoredVersions = []string{t.storageVersion}
+	}
+
+	logger.V(2).Info("finished storage version migration", "resource", gr, "storageVersion", t.storageVersion, "migratedObjects", migration.Status.MigratedObjects)
+	conditions.MarkTrue(migration, apisv1alpha1.StorageVersionMigrationSucceeded)
+	return false, nil
+}
+
+// resolve finds the storage version and the stored versions of the given resource in the logical
+// cluster. Bound resources take precedence over CRDs of the logical cluster, which take precedence
+// over built-in resources. It returns nil if the storage version cannot be determined.
+func (c *controller) resolve(clusterName logicalcluster.Name, gr schema.GroupResource) (*target, error) {
+	binding, err := c.getAPIBindingByBoundResource(clusterName, gr)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		for _, boundResource := range binding.Status.BoundResources {
+			if boundResource.Group != gr.Group || boundResource.Resource != gr.Resource {
+				continue
+			}
+			crd, err := c.getCRD(apibinding.SystemBoundCRDsClusterName, boundResource.Schema.UID)
+			if apierrors.IsNotFound(err) {
+				// the APIBinding controller will recreate the CRD
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			storageVersion, err := apihelpers.GetCRDStorageVersion(crd)
+			if err != nil {
+				return nil, err
+			}
+			return &target{storageVersion: storageVersion, storedVersions: boundResource.StorageVersions, binding: binding}, nil
+		}
+	}
+
+	if gr.Group != "" {
+		crd, err := c.getCRD(clusterName, gr.Resource+"."+gr.Group)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			storageVersion, err := apihelpers.GetCRDStorageVersion(crd)
+			if err != nil {
+				return nil, err
+			}
+			return &target{storageVersion: storageVersion, storedVersions: crd.Status.StoredVersions, crd: crd}, nil
+		}
+	}
+
+	if storageVersion, ok := c.getBuiltInStorageVersion(gr); ok {
+		return &target{storageVersion: storageVersion}, nil
+	}
+
+	return nil, nil
+}
+
+// dropStoredVersions removes all versions but the storage version from the stored versions
+// recorded for the resource in the APIBinding or the CustomResourceDefinition status.
+func (c *controller) dropStoredVersions(ctx context.Context, gr schema.GroupResource, t *target) error {
+	if !needsMigration(t.storedVersions, t.storageVersion) {
+		return nil
+	}
+
+	switch {
+	case t.binding != nil:
+		binding := t.binding.DeepCopy()
+		for i := range binding.Status.BoundResources {
+			if binding.Status.BoundResources[i].Group == gr.Group && binding.Status.BoundResources[i].Resource == gr.Resource {
+				binding.Status.BoundResources[i].StorageVersions = []string{t.storageVersion}
+			}
+		}
+		return c.updateAPIBindingStatus(ctx, binding)
+	case t.crd != nil:
+		crd := t.crd.DeepCopy()
+		crd.Status.StoredVersions = []string{t.storageVersion}
+		return c.updateCRDStatus(ctx, crd)
+	}
+
+	return nil
+}
+
+func markFailed(migration *apisv1alpha1.StorageVersionMigration, gvr schema.GroupVersionResource, err error) {
+	conditions.MarkFalse(
+		migration,
+		apisv1alpha1.StorageVersionMigrationSucceeded,
+		apisv1alpha1.StorageVersionMigrationFailedReason,
+		conditionsv1alpha1.ConditionSeverityError,
+		"Migrating %s to storage version %s failed: %v",
+		gvr.GroupResource(), gvr.Version, err,
+	)
+}
+
+// needsMigration returns true if objects might still be stored in another version
+// than the given storage version.
+func needsMigration(storedVersions []string, storageVersion string) bool {
+	for _, v := range storedVersions {
+		if v != storageVersion {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversionmigration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	widgets := apisv1alpha1.GroupResource{Group: "kcp.io", Resource: "widgets"}
+	configmaps := apisv1alpha1.GroupResource{Resource: "configmaps"}
+
+	tests := map[string]struct {
+		resource       apisv1alpha1.GroupResource
+		bound          bool
+		local          bool
+		storedVersions []string
+		status         apisv1alpha1.StorageVersionMigrationStatus
+		continueToken  string
+		listErr        error
+		updateErr      error
+
+		wantRequeue         bool
+		wantErr             bool
+		wantUpdates         int
+		wantStatus          apisv1alpha1.StorageVersionMigrationStatus
+		wantReason          string
+		wantBindingVersions []string
+		wantCRDVersions     []string
+	}{
+		"bound resource is migrated": {
+			resource:            widgets,
+			bound:               true,
+			storedVersions:      []string{"v1", "v2"},
+			wantUpdates:         2,
+			wantStatus:          apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v2"}, MigratedObjects: 2},
+			wantBindingVersions: []string{"v2"},
+		},
+		"bound resource takes precedence over local CRD": {
+			resource:            widgets,
+			bound:               true,
+			local:               true,
+			storedVersions:      []string{"v1", "v2"},
+			wantUpdates:         2,
+			wantStatus:          apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v2"}, MigratedObjects: 2},
+			wantBindingVersions: []string{"v2"},
+		},
+		"local CRD is migrated": {
+			resource:        widgets,
+			local:           true,
+			storedVersions:  []string{"v1", "v2"},
+			wantUpdates:     2,
+			wantStatus:      apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v2"}, MigratedObjects: 2},
+			wantCRDVersions: []string{"v2"},
+		},
+		"built-in resource is migrated": {
+			resource:    configmaps,
+			wantUpdates: 2,
+			wantStatus:  apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v1", MigratedObjects: 2},
+		},
+		"unknown resource": {
+			resource:   apisv1alpha1.GroupResource{Group: "example.com", Resource: "unknowns"},
+			wantReason: apisv1alpha1.StorageVersionUnknownReason,
+		},
+		"built-in resource not served": {
+			resource:   configmaps,
+			listErr:    apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, ""),
+			wantStatus: apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v1"},
+			wantReason: apisv1alpha1.StorageVersionUnknownReason,
+		},
+		"first batch of many": {
+			resource:       widgets,
+			bound:          true,
+			storedVersions: []string{"v1", "v2"},
+			continueToken:  "next",
+			wantRequeue:    true,
+			wantUpdates:    2,
+			wantStatus:     apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v1", "v2"}, MigratedObjects: 2, ContinueToken: "next"},
+			wantReason:     apisv1alpha1.StorageVersionMigrationRunningReason,
+		},
+		"last batch of many": {
+			resource:            widgets,
+			bound:               true,
+			storedVersions:      []string{"v1", "v2"},
+			status:              apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", MigratedObjects: 500, ContinueToken: "next"},
+			wantUpdates:         2,
+			wantStatus:          apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v2"}, MigratedObjects: 502},
+			wantBindingVersions: []string{"v2"},
+		},
+		"storage version changed during migration": {
+			resource:            widgets,
+			bound:               true,
+			storedVersions:      []string{"v1", "v2"},
+			status:              apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v1", MigratedObjects: 500, ContinueToken: "next"},
+			wantUpdates:         2,
+			wantStatus:          apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v2"}, MigratedObjects: 2},
+			wantBindingVersions: []string{"v2"},
+		},
+		"expired continue token starts over": {
+			resource:       widgets,
+			bound:          true,
+			storedVersions: []string{"v1", "v2"},
+			status:         apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", MigratedObjects: 500, ContinueToken: "next"},
+			listErr:        apierrors.NewResourceExpired("too old"),
+			wantRequeue:    true,
+			wantStatus:     apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v1", "v2"}, MigratedObjects: 500},
+		},
+		"conflicts are ignored": {
+			resource:            widgets,
+			bound:               true,
+			storedVersions:      []string{"v1", "v2"},
+			updateErr:           apierrors.NewConflict(schema.GroupResource{Group: "kcp.io", Resource: "widgets"}, "a", errors.New("conflict")),
+			wantUpdates:         2,
+			wantStatus:          apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v2"}, MigratedObjects: 2},
+			wantBindingVersions: []string{"v2"},
+		},
+		"update fails": {
+			resource:       widgets,
+			bound:          true,
+			storedVersions: []string{"v1", "v2"},
+			updateErr:      errors.New("boom"),
+			wantErr:        true,
+			wantUpdates:    1,
+			wantStatus:     apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v2", StoredVersions: []string{"v1", "v2"}},
+			wantReason:     apisv1alpha1.StorageVersionMigrationFailedReason,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			var bindingVersions, crdVersions []string
+			crd := &apiextensionsv1.CustomResourceDefinition{
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true},
+						{Name: "v2", Served: true, Storage: true},
+					},
+				},
+				Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: tc.storedVersions},
+			}
+			c := &controller{
+				getAPIBindingByBoundResource: func(clusterName logicalcluster.Name, gr schema.GroupResource) (*apisv1alpha1.APIBinding, error) {
+					require.Equal(t, "root:consumer", clusterName.String())
+					if !tc.bound {
+						return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apibindings"), gr.String())
+					}
+					return &apisv1alpha1.APIBinding{
+						ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+						Status: apisv1alpha1.APIBindingStatus{
+							BoundResources: []apisv1alpha1.BoundAPIResource{
+								{
+									Group:           "kcp.io",
+									Resource:        "widgets",
+									Schema:          apisv1alpha1.BoundAPIResourceSchema{Name: "v2.widgets.kcp.io", UID: "uid1", IdentityHash: "hash"},
+									StorageVersions: tc.storedVersions,
+								},
+							},
+						},
+					}, nil
+				},
+				getCRD: func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+					switch {
+					case clusterName == apibinding.SystemBoundCRDsClusterName && name == "uid1":
+						return crd, nil
+					case clusterName == "root:consumer" && name == "widgets.kcp.io" && tc.local:
+						return crd, nil
+					}
+					return nil, apierrors.NewNotFound(apiextensionsv1.Resource("customresourcedefinitions"), name)
+				},
+				getBuiltInStorageVersion: func(gr schema.GroupResource) (string, bool) {
+					if gr.Group == "" {
+						return "v1", true
+					}
+					return "", false
+				},
+				listObjects: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, continueToken string) (*unstructured.UnstructuredList, error) {
+					require.Equal(t, "root:consumer", cluster.String())
+					require.Equal(t, tc.resource.Resource, gvr.Resource)
+					if tc.listErr != nil {
+						return nil, tc.listErr
+					}
+					list := &unstructured.UnstructuredList{}
+					list.SetContinue(tc.continueToken)
+					for _, name := range []string{"a", "b"} {
+						obj := unstructured.Unstructured{}
+						obj.SetName(name)
+						list.Items = append(list.Items, obj)
+					}
+					return list, nil
+				},
+				updateObject: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+					updates++
+					return tc.updateErr
+				},
+				updateAPIBindingStatus: func(ctx context.Context, binding *apisv1alpha1.APIBinding) error {
+					bindingVersions = binding.Status.BoundResources[0].StorageVersions
+					return nil
+				},
+				updateCRDStatus: func(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
+					crdVersions = crd.Status.StoredVersions
+					return nil
+				},
+			}
+
+			migration := &apisv1alpha1.StorageVersionMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "widgets",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "root:consumer",
+					},
+				},
+				Spec:   apisv1alpha1.StorageVersionMigrationSpec{Resource: tc.resource},
+				Status: tc.status,
+			}
+
+			requeue, err := c.reconcile(context.Background(), migration)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantRequeue, requeue)
+			require.Equal(t, tc.wantUpdates, updates)
+			require.Equal(t, tc.wantBindingVersions, bindingVersions)
+			require.Equal(t, tc.wantCRDVersions, crdVersions)
+
+			status := migration.Status
+			status.Conditions = nil
+			require.Equal(t, tc.wantStatus, status)
+
+			cond := conditions.Get(migration, apisv1alpha1.StorageVersionMigrationSucceeded)
+			switch {
+			case tc.wantRequeue && tc.wantReason == "":
+				require.Nil(t, cond)
+			case tc.wantReason == "":
+				require.True(t, conditions.IsTrue(migration, apisv1alpha1.StorageVersionMigrationSucceeded))
+			default:
+				require.NotNil(t, cond)
+				require.Equal(t, tc.wantReason, cond.Reason)
+			}
+		})
+	}
+}
+
+func TestReconcileAlreadySucceeded(t *testing.T) {
+	c := &controller{
+		getAPIBindingByBoundResource: func(clusterName logicalcluster.Name, gr schema.GroupResource) (*apisv1alpha1.APIBinding, error) {
+			return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apibindings"), gr.String())
+		},
+		getBuiltInStorageVersion: func(gr schema.GroupResource) (string, bool) {
+			return "v1", true
+		},
+		listObjects: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, continueToken string) (*unstructured.UnstructuredList, error) {
+			t.Fatal("unexpected list")
+			return nil, nil
+		},
+	}
+
+	migration := &apisv1alpha1.StorageVersionMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "configmaps",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:consumer"},
+		},
+		Spec:   apisv1alpha1.StorageVersionMigrationSpec{Resource: apisv1alpha1.GroupResource{Resource: "configmaps"}},
+		Status: apisv1alpha1.StorageVersionMigrationStatus{StorageVersion: "v1", MigratedObjects: 2},
+	}
+	conditions.MarkTrue(migration, apisv1alpha1.StorageVersionMigrationSucceeded)
+
+	requeue, err := c.reconcile(context.Background(), migration)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Equal(t, int64(2), migration.Status.MigratedObjects)
+}
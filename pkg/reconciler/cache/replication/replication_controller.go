@@ -40,6 +40,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
@@ -148,7 +150,11 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 
 	logger := logging.WithQueueKey(klog.FromContext(ctx), grKey.(string))
 	ctx = klog.NewContext(ctx, logger)
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, grKey.(string))
+	startTime := time.Now()
 	err := c.reconcile(ctx, grKey.(string))
+	reconcilermetrics.ObserveReconcile(ControllerName, grKey.(string), startTime, err)
+	reconcilertracing.EndReconcile(span, err)
 	if err == nil {
 		c.queue.Forget(grKey)
 		return true
@@ -240,6 +246,11 @@ func InstallIndexers(
 			Local:  localKcpInformers.Core().V1alpha1().Shards().Informer(),
 			Global: globalKcpInformers.Core().V1alpha1().Shards().Informer(),
 		},
+		corev1alpha1.SchemeGroupVersion.WithResource("shardbackups"): {
+			Kind:   "ShardBackup",
+			Local:  localKcpInformers.Core().V1alpha1().ShardBackups().Informer(),
+			Global: globalKcpInformers.Core().V1alpha1().ShardBackups().Informer(),
+		},
 		corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"): {
 			Kind: "LogicalCluster",
 			Filter: func(u *unstructured.Unstructured) bool {
@@ -253,6 +264,21 @@ func InstallIndexers(
 			Local:  localKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Informer(),
 			Global: globalKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Informer(),
 		},
+		tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacequotas"): {
+			Kind:   "WorkspaceQuota",
+			Local:  localKcpInformers.Tenancy().V1alpha1().WorkspaceQuotas().Informer(),
+			Global: globalKcpInformers.Tenancy().V1alpha1().WorkspaceQuotas().Informer(),
+		},
+		tenancyv1alpha1.SchemeGroupVersion.WithResource("auditsinks"): {
+			Kind:   "AuditSink",
+			Local:  localKcpInformers.Tenancy().V1alpha1().AuditSinks().Informer(),
+			Global: globalKcpInformers.Tenancy().V1alpha1().AuditSinks().Informer(),
+		},
+		tenancyv1alpha1.SchemeGroupVersion.WithResource("referencegrants"): {
+			Kind:   "ReferenceGrant",
+			Local:  localKcpInformers.Tenancy().V1alpha1().ReferenceGrants().Informer(),
+			Global: globalKcpInformers.Tenancy().V1alpha1().ReferenceGrants().Informer(),
+		},
 		rbacv1.SchemeGroupVersion.WithResource("clusterroles"): {
 			Kind: "ClusterRole",
 			Filter: func(u *unstructured.Unstructured) bool {
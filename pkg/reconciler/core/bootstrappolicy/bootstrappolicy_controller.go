@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrappolicy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	rbacv1client "k8s.io/client-go/kubernetes/typed/rbac/v1"
+	"k8s.io/component-helpers/auth/rbac/reconciliation"
+	"k8s.io/klog/v2"
+	controlplaneapiserver "k8s.io/kubernetes/pkg/controlplane/apiserver"
+
+	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
+	"github.com/kcp-dev/kcp/pkg/logging"
+)
+
+const ControllerName = "kcp-bootstrap-policy"
+
+// NewController returns a controller periodically re-applying the bootstrap policy, customized
+// by the given file, to the system:admin logical cluster of the shard. The file is re-read
+// every time, such that changes apply without a restart.
+func NewController(policyFile string, kubeClusterClient kcpkubernetesclientset.ClusterInterface) *Controller {
+	return &Controller{
+		load: func() (*bootstrappolicy.PolicyCustomization, error) {
+			return bootstrappolicy.LoadPolicyCustomization(policyFile)
+		},
+		client: kubeClusterClient.Cluster(controlplaneapiserver.LocalAdminCluster.Path()).RbacV1(),
+	}
+}
+
+// Controller reconciles the ClusterRoles and ClusterRoleBindings of the bootstrap policy.
+// Compiled-in objects get missing rules and subjects added, like on startup. Objects from the
+// customization are reconciled exactly, i.e. extra rules and subjects are removed.
+type Controller struct {
+	load   func() (*bootstrappolicy.PolicyCustomization, error)
+	client rbacv1client.RbacV1Interface
+}
+
+// Start reconciles the bootstrap policy every interval until the context is done.
+func (c *Controller) Start(ctx context.Context, interval time.Duration) {
+	defer runtime.HandleCrash()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.reconcile(ctx); err != nil {
+			runtime.HandleError(err)
+		}
+	}, interval)
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+
+	customization, err := c.load()
+	if err != nil {
+		return err
+	}
+	customRoles, customBindings := sets.New[string](), sets.New[string]()
+	for _, role := range customization.ClusterRoles {
+		customRoles.Insert(role.Name)
+	}
+	for _, binding := range customization.ClusterRoleBindings {
+		customBindings.Insert(binding.Name)
+	}
+
+	policy := bootstrappolicy.CustomizedPolicy(customization)
+	var errs []error
+	for i := range policy.ClusterRoles {
+		role := &policy.ClusterRoles[i]
+		result, err := (&reconciliation.ReconcileRoleOptions{
+			Role:                   reconciliation.ClusterRoleRuleOwner{ClusterRole: role},
+			Client:                 reconciliation.ClusterRoleModifier{Client: c.client.ClusterRoles()},
+			Confirm:                true,
+			RemoveExtraPermissions: customRoles.Has(role.Name),
+		}).Run()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile ClusterRole %s: %w", role.Name, err))
+			continue
+		}
+		if result.Operation != reconciliation.ReconcileNone && !result.Protected {
+			logger.V(2).Info("reconciled ClusterRole", "name", role.Name, "operation", result.Operation)
+		}
+	}
+	for i := range policy.ClusterRoleBindings {
+		binding := &policy.ClusterRoleBindings[i]
+		result, err := (&reconciliation.ReconcileRoleBindingOptions{
+			RoleBinding:         reconciliation.ClusterRoleBindingAdapter{ClusterRoleBinding: binding},
+			Client:              reconciliation.ClusterRoleBindingClientAdapter{Client: c.client.ClusterRoleBindings()},
+			Confirm:             true,
+			RemoveExtraSubjects: customBindings.Has(binding.Name),
+		}).Run()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile ClusterRoleBinding %s: %w", binding.Name, err))
+			continue
+		}
+		if result.Operation != reconciliation.ReconcileNone && !result.Protected {
+			logger.V(2).Info("reconciled ClusterRoleBinding", "name", binding.Name, "operation", result.Operation)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
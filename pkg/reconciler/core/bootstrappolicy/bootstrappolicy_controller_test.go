@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrappolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	readRule := rbacv1.PolicyRule{APIGroups: []string{"tenancy.kcp.io"}, Resources: []string{"workspaces"}, Verbs: []string{"get"}}
+	writeRule := rbacv1.PolicyRule{APIGroups: []string{"tenancy.kcp.io"}, Resources: []string{"workspaces"}, Verbs: []string{"create"}}
+
+	client := fake.NewSimpleClientset(&rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "system:kcp:tenancy:reader"},
+		Rules:      []rbacv1.PolicyRule{readRule, writeRule},
+	}).RbacV1()
+	customization := &bootstrappolicy.PolicyCustomization{
+		ClusterRoles: []rbacv1.ClusterRole{{
+			ObjectMeta: metav1.ObjectMeta{Name: "system:kcp:tenancy:reader"},
+			Rules:      []rbacv1.PolicyRule{readRule},
+		}},
+	}
+	c := &Controller{
+		load:   func() (*bootstrappolicy.PolicyCustomization, error) { return customization, nil },
+		client: client,
+	}
+
+	t.Log("Customized roles are reconciled exactly, compiled-in ones are created")
+	require.NoError(t, c.reconcile(ctx))
+	reader, err := client.ClusterRoles().Get(ctx, "system:kcp:tenancy:reader", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []rbacv1.PolicyRule{readRule}, reader.Rules)
+	_, err = client.ClusterRoles().Get(ctx, bootstrappolicy.SystemKcpWorkspaceAccessGroup, metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = client.ClusterRoleBindings().Get(ctx, bootstrappolicy.SystemKcpVirtualWorkspaceDiscovery, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	t.Log("Deleted roles are re-created on the next sync")
+	require.NoError(t, client.ClusterRoles().Delete(ctx, "system:kcp:tenancy:reader", metav1.DeleteOptions{}))
+	require.NoError(t, c.reconcile(ctx))
+	_, err = client.ClusterRoles().Get(ctx, "system:kcp:tenancy:reader", metav1.GetOptions{})
+	require.NoError(t, err)
+}
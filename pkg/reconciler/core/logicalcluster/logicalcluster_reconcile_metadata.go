@@ -27,6 +27,7 @@ import (
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/termination"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
@@ -71,6 +72,28 @@ func (r *metaDataReconciler) reconcile(ctx context.Context, logicalCluster *core
 		}
 	}
 
+	terminatorKeys := sets.New[string]()
+	for _, terminator := range logicalCluster.Status.Terminators {
+		key, value := termination.TerminatorToLabel(terminator)
+		terminatorKeys.Insert(key)
+		if got, expected := logicalCluster.Labels[key], value; got != expected {
+			if logicalCluster.Labels == nil {
+				logicalCluster.Labels = map[string]string{}
+			}
+			logicalCluster.Labels[key] = value
+			changed = true
+		}
+	}
+
+	for key := range logicalCluster.Labels {
+		if strings.HasPrefix(key, tenancyv1alpha1.WorkspaceTerminatorLabelPrefix) {
+			if !terminatorKeys.Has(key) {
+				delete(logicalCluster.Labels, key)
+				changed = true
+			}
+		}
+	}
+
 	if logicalCluster.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
 		// remove owner reference
 		if value, found := logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey]; found {
@@ -77,6 +77,28 @@ func TestReconcileMetadata(t *testing.T) {
 			},
 			wantStatus: reconcileStatusStopAndRequeue,
 		},
+		{
+			name: "adds terminator labels",
+			input: &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					DeletionTimestamp: &metav1.Time{Time: date},
+				},
+				Status: corev1alpha1.LogicalClusterStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+					Terminators: []corev1alpha1.LogicalClusterTerminator{
+						"pluto",
+					},
+				},
+			},
+			expected: metav1.ObjectMeta{
+				DeletionTimestamp: &metav1.Time{Time: date},
+				Labels: map[string]string{
+					"tenancy.kcp.io/phase": "Deleting",
+					"terminator.internal.kcp.io/2eadcbf778956517ec99fd1c1c32a9b13cba": "2eadcbf778956517ec99fd1c1c32a9b13cbae759770fc37c341c7fe8",
+				},
+			},
+			wantStatus: reconcileStatusStopAndRequeue,
+		},
 		{
 			name: "adds partially missing labels",
 			input: &corev1alpha1.LogicalCluster{
@@ -45,7 +45,12 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	corev1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/core/v1alpha1"
 	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
@@ -203,8 +208,11 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
 	startTime := time.Now()
 	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
 
 	if err == nil {
 		// no error, forget this entry and return
@@ -254,6 +262,23 @@ func (c *Controller) process(ctx context.Context, key string) error {
 
 	logicalClusterCopy := logicalCluster.DeepCopy()
 
+	// terminators clean up after the logical cluster, possibly using its content. Hence, we
+	// only start deleting the content once all of them are done. Updates of the logical cluster
+	// requeue it.
+	if terminators := logicalCluster.Status.Terminators; len(terminators) > 0 {
+		logger.V(3).Info("waiting for terminators", "terminators", terminators)
+		conditions.MarkFalse(
+			logicalClusterCopy,
+			tenancyv1alpha1.WorkspaceContentDeleted,
+			tenancyv1alpha1.WorkspaceContentDeletedTerminatorsRemaining,
+			conditionsv1alpha1.ConditionSeverityInfo,
+			"Waiting for terminators: %v", terminators,
+		)
+		oldResource := &Resource{ObjectMeta: logicalCluster.ObjectMeta, Spec: &logicalCluster.Spec, Status: &logicalCluster.Status}
+		newResource := &Resource{ObjectMeta: logicalClusterCopy.ObjectMeta, Spec: &logicalClusterCopy.Spec, Status: &logicalClusterCopy.Status}
+		return c.commit(ctx, oldResource, newResource)
+	}
+
 	logger.V(2).Info("deleting logical cluster")
 	startTime := time.Now()
 	deleteErr = c.deleter.Delete(ctx, logicalClusterCopy)
@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterusage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-logical-cluster-usage"
+
+	// rangeLimit is the number of keys read from etcd per request.
+	rangeLimit = 1000
+)
+
+// protobufPrefix is the magic prefix of objects stored as protobuf.
+var protobufPrefix = []byte{0x6b, 0x38, 0x73, 0x00}
+
+// NewController returns a controller periodically sampling the objects stored in etcd below prefix,
+// and reporting their number and size per logical cluster and resource as metrics. If reportStatus
+// is true, the usage is also written to the status of the LogicalCluster objects.
+func NewController(
+	etcdClient *clientv3.Client,
+	prefix string,
+	reportStatus bool,
+	kcpClusterClient kcpclientset.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+) (*Controller, error) {
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+
+	c := &Controller{
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().List(labels.Everything())
+		},
+		rangeObjects: func(ctx context.Context, fn func(key string, value []byte)) error {
+			return rangePrefix(ctx, etcdClient, prefix, fn)
+		},
+		now: time.Now,
+	}
+	if reportStatus {
+		c.patchStatus = func(ctx context.Context, cluster logicalcluster.Path, patch []byte) error {
+			_, err := kcpClusterClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Patch(ctx, corev1alpha1.LogicalClusterName, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+			return err
+		}
+	}
+	return c, nil
+}
+
+// Controller meters the objects stored per logical cluster of its shard. Objects of system
+// logical clusters without a LogicalCluster object are not metered.
+type Controller struct {
+	listLogicalClusters func() ([]*corev1alpha1.LogicalCluster, error)
+	// rangeObjects calls fn for every stored object with its key relative to the storage prefix.
+	rangeObjects func(ctx context.Context, fn func(key string, value []byte)) error
+	// patchStatus patches the status of the LogicalCluster of the given logical cluster. It is
+	// nil if the usage is not reported in the status.
+	patchStatus func(ctx context.Context, cluster logicalcluster.Path, patch []byte) error
+	now         func() time.Time
+}
+
+// Start samples the usage every interval until the context is done.
+func (c *Controller) Start(ctx context.Context, interval time.Duration) {
+	defer utilruntime.HandleCrash()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.update(ctx); err != nil {
+			utilruntime.HandleError(err)
+		}
+	}, interval)
+}
+
+func (c *Controller) update(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+	start := c.now()
+
+	logicalClusters, err := c.listLogicalClusters()
+	if err != nil {
+		return err
+	}
+	byName := make(map[logicalcluster.Name]*corev1alpha1.LogicalCluster, len(logicalClusters))
+	for _, lc := range logicalClusters {
+		byName[logicalcluster.From(lc)] = lc
+	}
+
+	usage, err := c.sample(ctx, func(name logicalcluster.Name) bool { _, found := byName[name]; return found })
+	if err != nil {
+		return err
+	}
+	recordUsage(usage)
+	logger.V(4).Info("sampled logical cluster usage", "logicalClusters", len(usage), "duration", c.now().Sub(start))
+
+	if c.patchStatus == nil {
+		return nil
+	}
+	var errs []error
+	for name, lc := range byName {
+		resources := usage[name]
+		if lc.Status.Usage != nil && reflect.DeepEqual(lc.Status.Usage.Resources, resources) {
+			continue
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"status": map[string]interface{}{
+				"usage": corev1alpha1.LogicalClusterUsage{
+					Resources:      resources,
+					LastUpdateTime: metav1.NewTime(start),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if err := c.patchStatus(ctx, name.Path(), patch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// sample aggregates the stored objects per logical cluster, resource and storage version. The
+// resources of every logical cluster are sorted by group, resource and version.
+func (c *Controller) sample(ctx context.Context, isLogicalCluster func(name logicalcluster.Name) bool) (map[logicalcluster.Name][]corev1alpha1.ResourceUsage, error) {
+	type usageKey struct {
+		cluster logicalcluster.Name
+		gvr     schema.GroupVersionResource
+	}
+	totals := map[usageKey]*corev1alpha1.ResourceUsage{}
+
+	if err := c.rangeObjects(ctx, func(key string, value []byte) {
+		gr, cluster, ok := parseKey(key, isLogicalCluster)
+		if !ok {
+			return
+		}
+		var version string
+		if gv, err := schema.ParseGroupVersion(storedAPIVersion(value)); err == nil && !gv.Empty() {
+			// resources stored without the group in their key, e.g. leases, get it from the object
+			gr.Group, version = gv.Group, gv.Version
+		}
+		k := usageKey{cluster: cluster, gvr: gr.WithVersion(version)}
+		u, found := totals[k]
+		if !found {
+			u = &corev1alpha1.ResourceUsage{Group: gr.Group, Resource: gr.Resource, Version: version}
+			totals[k] = u
+		}
+		u.Objects++
+		u.SizeBytes += int64(len(value))
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := map[logicalcluster.Name][]corev1alpha1.ResourceUsage{}
+	for k, u := range totals {
+		ret[k.cluster] = append(ret[k.cluster], *u)
+	}
+	for _, resources := range ret {
+		sort.Slice(resources, func(i, j int) bool {
+			if resources[i].Group != resources[j].Group {
+				return resources[i].Group < resources[j].Group
+			}
+			if resources[i].Resource != resources[j].Resource {
+				return resources[i].Resource < resources[j].Resource
+			}
+			return resources[i].Version < resources[j].Version
+		})
+	}
+	return ret, nil
+}
+
+// parseKey returns the resource and the logical cluster of a storage key relative to the
+// storage prefix. The logical cluster is the first segment after the resource that is a known
+// logical cluster, as in
+//
+//	configmaps/<cluster>/<namespace>/<name>
+//	example.com/widgets/customresources/<cluster>/<name>
+//	example.com/widgets/<identity>/<cluster>/<name> (bound resources)
+func parseKey(key string, isLogicalCluster func(name logicalcluster.Name) bool) (schema.GroupResource, logicalcluster.Name, bool) {
+	segments := strings.Split(key, "/")
+	for i := 1; i < len(segments)-1 && i <= 3; i++ {
+		name := logicalcluster.Name(segments[i])
+		if !isLogicalCluster(name) {
+			continue
+		}
+		switch i {
+		case 1:
+			return schema.GroupResource{Resource: segments[0]}, name, true
+		default:
+			// the third segment, if any, is "customresources" or the identity of a bound resource
+			return schema.GroupResource{Group: segments[0], Resource: segments[1]}, name, true
+		}
+	}
+	return schema.GroupResource{}, "", false
+}
+
+// storedAPIVersion returns the apiVersion of a stored JSON or protobuf object, or an empty
+// string if it cannot be decoded, e.g. because the object is encrypted.
+func storedAPIVersion(value []byte) string {
+	if bytes.HasPrefix(value, protobufPrefix) {
+		var unknown runtime.Unknown
+		if err := unknown.Unmarshal(value[len(protobufPrefix):]); err != nil {
+			return ""
+		}
+		return unknown.APIVersion
+	}
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(value, &typeMeta); err != nil {
+		return ""
+	}
+	return typeMeta.APIVersion
+}
+
+// rangePrefix calls fn for all keys below the prefix, reading them in batches of rangeLimit.
+func rangePrefix(ctx context.Context, client *clientv3.Client, prefix string, fn func(key string, value []byte)) error {
+	end := clientv3.GetPrefixRangeEnd(prefix)
+	from := prefix
+	for {
+		resp, err := client.Get(ctx, from, clientv3.WithRange(end), clientv3.WithLimit(rangeLimit), clientv3.WithSerializable())
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			fn(strings.TrimPrefix(string(kv.Key), prefix), kv.Value)
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		from = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterusage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestParseKey(t *testing.T) {
+	clusters := sets.New[logicalcluster.Name]("abc", "def")
+	isLogicalCluster := func(name logicalcluster.Name) bool { return clusters.Has(name) }
+
+	tests := map[string]struct {
+		key         string
+		wantGR      schema.GroupResource
+		wantCluster logicalcluster.Name
+		wantOK      bool
+	}{
+		"core resource":    {key: "configmaps/abc/default/foo", wantGR: schema.GroupResource{Resource: "configmaps"}, wantCluster: "abc", wantOK: true},
+		"cluster-scoped":   {key: "namespaces/def/default", wantGR: schema.GroupResource{Resource: "namespaces"}, wantCluster: "def", wantOK: true},
+		"group resource":   {key: "apis.kcp.io/apibindings/abc/foo", wantGR: schema.GroupResource{Group: "apis.kcp.io", Resource: "apibindings"}, wantCluster: "abc", wantOK: true},
+		"custom resource":  {key: "example.com/widgets/customresources/abc/default/foo", wantGR: schema.GroupResource{Group: "example.com", Resource: "widgets"}, wantCluster: "abc", wantOK: true},
+		"bound resource":   {key: "example.com/widgets/someidentity/def/foo", wantGR: schema.GroupResource{Group: "example.com", Resource: "widgets"}, wantCluster: "def", wantOK: true},
+		"unknown cluster":  {key: "configmaps/system:admin/default/foo"},
+		"cluster is name":  {key: "configmaps/abc"},
+		"cluster too deep": {key: "a/b/c/d/abc/foo"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gr, cluster, ok := parseKey(tt.key, isLogicalCluster)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantGR, gr)
+			require.Equal(t, tt.wantCluster, cluster)
+		})
+	}
+}
+
+func TestStoredAPIVersion(t *testing.T) {
+	unknown := runtime.Unknown{TypeMeta: runtime.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, Raw: []byte{1, 2, 3}}
+	data, err := unknown.Marshal()
+	require.NoError(t, err)
+	protobuf := append(append([]byte{}, protobufPrefix...), data...)
+
+	require.Equal(t, "v1", storedAPIVersion(protobuf))
+	require.Equal(t, "example.com/v2", storedAPIVersion([]byte(`{"apiVersion":"example.com/v2","kind":"Widget"}`)))
+	require.Equal(t, "", storedAPIVersion([]byte("k8s:enc:aescbc:v1:key:garbage")))
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newLogicalCluster := func(name string, usage *corev1alpha1.LogicalClusterUsage) *corev1alpha1.LogicalCluster {
+		return &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        corev1alpha1.LogicalClusterName,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: name},
+			},
+			Status: corev1alpha1.LogicalClusterStatus{Usage: usage},
+		}
+	}
+	objects := map[string]string{
+		"configmaps/abc/default/a":                         `{"apiVersion":"v1","kind":"ConfigMap"}`,
+		"configmaps/abc/default/b":                         `{"apiVersion":"v1","kind":"ConfigMap","data":{}}`,
+		"example.com/widgets/customresources/abc/a":        `{"apiVersion":"example.com/v1","kind":"Widget"}`,
+		"example.com/widgets/customresources/abc/b":        `{"apiVersion":"example.com/v2","kind":"Widget"}`,
+		"coordination.k8s.io/leases/abc/default/a":         `{"apiVersion":"coordination.k8s.io/v1","kind":"Lease"}`,
+		"configmaps/def/default/a":                         `{"apiVersion":"v1","kind":"ConfigMap"}`,
+		"configmaps/system:admin/default/a":                `{"apiVersion":"v1","kind":"ConfigMap"}`,
+		"example.com/widgets/customresources/unknown/a/b/": `{}`,
+	}
+
+	size := func(keys ...string) float64 {
+		var n int
+		for _, k := range keys {
+			n += len(objects[k])
+		}
+		return float64(n)
+	}
+	unchanged := []corev1alpha1.ResourceUsage{{Resource: "configmaps", Version: "v1", Objects: 1, SizeBytes: int64(size("configmaps/def/default/a"))}}
+
+	patches := map[logicalcluster.Path]map[string]interface{}{}
+	c := &Controller{
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("abc", nil),
+				newLogicalCluster("def", &corev1alpha1.LogicalClusterUsage{Resources: unchanged}),
+				newLogicalCluster("empty", nil),
+			}, nil
+		},
+		rangeObjects: func(ctx context.Context, fn func(key string, value []byte)) error {
+			for k, v := range objects {
+				fn(k, []byte(v))
+			}
+			return nil
+		},
+		patchStatus: func(ctx context.Context, cluster logicalcluster.Path, patch []byte) error {
+			var p map[string]interface{}
+			require.NoError(t, json.Unmarshal(patch, &p))
+			patches[cluster] = p
+			return nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	require.NoError(t, c.update(context.Background()))
+
+	require.Len(t, patches, 2, "def is unchanged")
+	require.Equal(t, map[string]interface{}{
+		"status": map[string]interface{}{
+			"usage": map[string]interface{}{
+				"lastUpdateTime": "2024-01-01T00:00:00Z",
+				"resources": []interface{}{
+					map[string]interface{}{"resource": "configmaps", "version": "v1", "objects": float64(2), "sizeBytes": size("configmaps/abc/default/a", "configmaps/abc/default/b")},
+					map[string]interface{}{"group": "coordination.k8s.io", "resource": "leases", "version": "v1", "objects": float64(1), "sizeBytes": size("coordination.k8s.io/leases/abc/default/a")},
+					map[string]interface{}{"group": "example.com", "resource": "widgets", "version": "v1", "objects": float64(1), "sizeBytes": size("example.com/widgets/customresources/abc/a")},
+					map[string]interface{}{"group": "example.com", "resource": "widgets", "version": "v2", "objects": float64(1), "sizeBytes": size("example.com/widgets/customresources/abc/b")},
+				},
+			},
+		},
+	}, patches[logicalcluster.NewPath("abc")])
+	require.Equal(t, map[string]interface{}{
+		"status": map[string]interface{}{
+			"usage": map[string]interface{}{
+				"lastUpdateTime": "2024-01-01T00:00:00Z",
+			},
+		},
+	}, patches[logicalcluster.NewPath("empty")], "logical clusters without objects report empty usage")
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterusage
+
+import (
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+var (
+	storedObjects = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "logical_cluster_stored_objects",
+			Help:           "Number of objects stored per logical cluster, resource and storage version, as of the last sample.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"logical_cluster", "group", "resource", "version"},
+	)
+
+	storedObjectBytes = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "logical_cluster_stored_object_size_bytes",
+			Help:           "Size of the objects stored per logical cluster, resource and storage version, as of the last sample.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"logical_cluster", "group", "resource", "version"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register metrics.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(storedObjects)
+		legacyregistry.MustRegister(storedObjectBytes)
+	})
+}
+
+func init() {
+	Register()
+}
+
+// recordUsage replaces the metrics with the given usage, dropping logical clusters and
+// resources that are gone.
+func recordUsage(usage map[logicalcluster.Name][]corev1alpha1.ResourceUsage) {
+	storedObjects.Reset()
+	storedObjectBytes.Reset()
+	for name, resources := range usage {
+		for _, u := range resources {
+			storedObjects.WithLabelValues(name.String(), u.Group, u.Resource, u.Version).Set(float64(u.Objects))
+			storedObjectBytes.WithLabelValues(name.String(), u.Group, u.Resource, u.Version).Set(float64(u.SizeBytes))
+		}
+	}
+}
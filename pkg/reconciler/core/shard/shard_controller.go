@@ -22,8 +22,10 @@ import (
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpcoordinationv1informers "github.com/kcp-dev/client-go/informers/coordination/v1"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -34,7 +36,12 @@ import (
 
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	corev1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/core/v1alpha1"
 	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
@@ -47,6 +54,7 @@ const (
 func NewController(
 	rootKcpClient kcpclientset.ClusterInterface,
 	shardInformer corev1alpha1informers.ShardClusterInformer,
+	leaseInformer kcpcoordinationv1informers.LeaseClusterInformer,
 ) (*Controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
@@ -57,6 +65,10 @@ func NewController(
 		getShard: func(clusterName logicalcluster.Name, name string) (*corev1alpha1.Shard, error) {
 			return shardInformer.Cluster(clusterName).Lister().Get(name)
 		},
+		getLease: func(name string) (*coordinationv1.Lease, error) {
+			return leaseInformer.Lister().Cluster(core.RootCluster).Leases(corev1alpha1.ShardLeaseNamespace).Get(name)
+		},
+		now: time.Now,
 	}
 
 	_, _ = shardInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -64,17 +76,34 @@ func NewController(
 		UpdateFunc: func(_, obj interface{}) { c.enqueue(obj) },
 	})
 
+	_, _ = leaseInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if final, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = final.Obj
+			}
+			lease, ok := obj.(*coordinationv1.Lease)
+			return ok && logicalcluster.From(lease) == core.RootCluster && lease.Namespace == corev1alpha1.ShardLeaseNamespace
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueLease(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueLease(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueLease(obj) },
+		},
+	})
+
 	return c, nil
 }
 
-// Controller watches WorkspaceShards and Secrets in order to make sure every Shard
-// has its URL exposed when a valid kubeconfig is connected to it.
+// Controller watches Shards and their Leases in order to mark Shards NotReady when
+// their Lease expires.
 type Controller struct {
 	queue workqueue.RateLimitingInterface
 
 	kcpClient kcpclientset.ClusterInterface
 
 	getShard func(clusterName logicalcluster.Name, name string) (*corev1alpha1.Shard, error)
+	getLease func(name string) (*coordinationv1.Lease, error)
+	now      func() time.Time
 	commit   CommitFunc
 }
 
@@ -96,6 +125,22 @@ func (c *Controller) enqueue(obj interface{}) {
 	c.queue.Add(key)
 }
 
+// enqueueLease maps a Lease to the Shard of the same name.
+func (c *Controller) enqueueLease(obj interface{}) {
+	if final, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = final.Obj
+	}
+	lease, ok := obj.(*coordinationv1.Lease)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be a Lease, but is %T", obj))
+		return
+	}
+	key := kcpcache.ToClusterAwareKey(core.RootCluster.String(), "", lease.Name)
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing Shard because of Lease")
+	c.queue.Add(key)
+}
+
 func (c *Controller) Start(ctx context.Context, numThreads int) {
 	defer runtime.HandleCrash()
 	defer c.queue.ShutDown()
@@ -133,29 +178,38 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if err := c.process(ctx, key); err != nil {
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	requeueAfter, err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
 		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
 		c.queue.AddRateLimited(key)
 		return true
 	}
 	c.queue.Forget(key)
+	if requeueAfter > 0 {
+		// leases expire without any event, hence check again when it would.
+		c.queue.AddAfter(key, requeueAfter)
+	}
 	return true
 }
 
-func (c *Controller) process(ctx context.Context, key string) error {
+func (c *Controller) process(ctx context.Context, key string) (time.Duration, error) {
 	logger := klog.FromContext(ctx)
 	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
 	if err != nil {
 		logger.Error(err, "invalid key")
-		return nil
+		return 0, nil
 	}
 
 	obj, err := c.getShard(clusterName, name)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
-			return nil // object deleted before we handled it
+			return 0, nil // object deleted before we handled it
 		}
-		return err
+		return 0, err
 	}
 
 	previous := obj
@@ -165,7 +219,8 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	ctx = klog.NewContext(ctx, logger)
 
 	var errs []error
-	if err := c.reconcile(ctx, obj); err != nil {
+	requeueAfter, err := c.reconcile(ctx, obj)
+	if err != nil {
 		errs = append(errs, err)
 	}
 
@@ -176,9 +231,33 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	}
 
 	logger.V(6).Info("processed Shard")
-	return utilerrors.NewAggregate(errs)
+	return requeueAfter, utilerrors.NewAggregate(errs)
 }
 
-func (c *Controller) reconcile(ctx context.Context, workspaceShard *corev1alpha1.Shard) error {
-	return nil
+// reconcile sets the Ready condition of the shard from its Lease. It returns after which
+// duration the Lease expires if it is not renewed.
+func (c *Controller) reconcile(ctx context.Context, shard *corev1alpha1.Shard) (time.Duration, error) {
+	lease, err := c.getLease(shard.Name)
+	if kerrors.IsNotFound(err) {
+		conditions.MarkUnknown(shard, conditionsv1alpha1.ReadyCondition, corev1alpha1.ShardLeaseNotFoundReason, "Lease %s|%s/%s not found", core.RootCluster, corev1alpha1.ShardLeaseNamespace, shard.Name)
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		conditions.MarkUnknown(shard, conditionsv1alpha1.ReadyCondition, corev1alpha1.ShardLeaseNotFoundReason, "Lease has not been renewed yet")
+		return 0, nil
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	if remaining := expiry.Sub(c.now()); remaining > 0 {
+		conditions.MarkTrue(shard, conditionsv1alpha1.ReadyCondition)
+		return remaining, nil
+	}
+
+	if conditions.IsTrue(shard, conditionsv1alpha1.ReadyCondition) {
+		klog.FromContext(ctx).Info("Shard lease expired, marking Shard NotReady", "renewTime", lease.Spec.RenewTime)
+	}
+	conditions.MarkFalse(shard, conditionsv1alpha1.ReadyCondition, corev1alpha1.ShardLeaseExpiredReason, conditionsv1alpha1.ConditionSeverityError, "Lease expired at %s", expiry.UTC().Format(time.RFC3339))
+	return 0, nil
 }
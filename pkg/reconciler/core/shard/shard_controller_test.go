@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newLease := func(renewed time.Duration) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "shard", Namespace: corev1alpha1.ShardLeaseNamespace},
+			Spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: ptr.To[int32](40),
+				RenewTime:            &metav1.MicroTime{Time: now.Add(-renewed)},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		lease *coordinationv1.Lease
+
+		wantReady        corev1.ConditionStatus
+		wantReason       string
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "lease renewed",
+			lease:            newLease(10 * time.Second),
+			wantReady:        corev1.ConditionTrue,
+			wantRequeueAfter: 30 * time.Second,
+		},
+		{
+			name:       "lease expired",
+			lease:      newLease(time.Minute),
+			wantReady:  corev1.ConditionFalse,
+			wantReason: corev1alpha1.ShardLeaseExpiredReason,
+		},
+		{
+			name:       "no lease",
+			wantReady:  corev1.ConditionUnknown,
+			wantReason: corev1alpha1.ShardLeaseNotFoundReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Controller{
+				getLease: func(name string) (*coordinationv1.Lease, error) {
+					if tc.lease == nil {
+						return nil, apierrors.NewNotFound(coordinationv1.Resource("leases"), name)
+					}
+					return tc.lease, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			shard := &corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "shard"}}
+			requeueAfter, err := c.reconcile(context.Background(), shard)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRequeueAfter, requeueAfter)
+
+			ready := conditions.Get(shard, conditionsv1alpha1.ReadyCondition)
+			require.NotNil(t, ready)
+			require.Equal(t, tc.wantReady, ready.Status)
+			require.Equal(t, tc.wantReason, ready.Reason)
+		})
+	}
+}
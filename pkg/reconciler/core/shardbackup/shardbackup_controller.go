@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardbackup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	"github.com/kcp-dev/kcp/pkg/shardbackup"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-shard-backup"
+)
+
+// NewController returns a controller taking etcd snapshots of the given shard for its
+// ShardBackups, and uploading them to the given object storage. If storage is nil, backups
+// fail as not configured.
+func NewController(
+	shardName string,
+	etcdClient *clientv3.Client,
+	storage shardbackup.ObjectStorage,
+	rootKcpClient kcpclientset.ClusterInterface,
+	globalShardBackupInformer corev1alpha1informers.ShardBackupClusterInformer,
+) (*Controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &Controller{
+		queue:     queue,
+		shardName: shardName,
+		storage:   storage,
+		getShardBackup: func(name string) (*corev1alpha1.ShardBackup, error) {
+			return globalShardBackupInformer.Cluster(core.RootCluster).Lister().Get(name)
+		},
+		patchStatus: func(ctx context.Context, name string, patch []byte) error {
+			_, err := rootKcpClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().ShardBackups().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+			return err
+		},
+		snapshot: func(ctx context.Context) (io.ReadCloser, error) {
+			return etcdClient.Snapshot(ctx)
+		},
+		now:      time.Now,
+		finished: sets.New[types.UID](),
+	}
+
+	_, _ = globalShardBackupInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			backup, ok := obj.(*corev1alpha1.ShardBackup)
+			return ok && backup.Spec.Shard == shardName
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueue(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueue(obj) },
+		},
+	})
+
+	return c, nil
+}
+
+// Controller backs up the etcd of its shard for the ShardBackups naming the shard. Every
+// ShardBackup is backed up once: it either completes or fails.
+type Controller struct {
+	queue workqueue.RateLimitingInterface
+
+	shardName string
+	storage   shardbackup.ObjectStorage
+
+	getShardBackup func(name string) (*corev1alpha1.ShardBackup, error)
+	// patchStatus patches the status of the ShardBackup in the root workspace. The ShardBackup
+	// is read from the cache server, hence its resourceVersion cannot be used as precondition.
+	patchStatus func(ctx context.Context, name string, patch []byte) error
+	snapshot    func(ctx context.Context) (io.ReadCloser, error)
+	now         func() time.Time
+
+	// finished holds the backups finished by this controller, as the cache server might still
+	// return them as running.
+	lock     sync.Mutex
+	finished sets.Set[types.UID]
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing ShardBackup")
+	c.queue.Add(key)
+}
+
+// Start starts a single worker, such that snapshots are taken one after the other.
+func (c *Controller) Start(ctx context.Context) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+
+	<-ctx.Done()
+}
+
+func (c *Controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *Controller) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+	_, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		logger.Error(err, "invalid key")
+		return nil
+	}
+
+	obj, err := c.getShardBackup(name)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+	if obj.Status.Phase == corev1alpha1.ShardBackupPhaseCompleted || obj.Status.Phase == corev1alpha1.ShardBackupPhaseFailed {
+		return nil
+	}
+	c.lock.Lock()
+	finished := c.finished.Has(obj.UID)
+	c.lock.Unlock()
+	if finished {
+		return nil
+	}
+
+	obj = obj.DeepCopy()
+	logger = logging.WithObject(logger, obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.reconcile(ctx, obj); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	c.finished.Insert(obj.UID)
+	c.lock.Unlock()
+
+	logger.V(6).Info("processed ShardBackup")
+	return nil
+}
+
+// reconcile takes the snapshot and uploads it. A backup started earlier, e.g. before the shard
+// restarted, is started from scratch. Errors are only returned if the status cannot be patched.
+func (c *Controller) reconcile(ctx context.Context, backup *corev1alpha1.ShardBackup) error {
+	logger := klog.FromContext(ctx)
+
+	if c.storage == nil {
+		c.fail(backup, corev1alpha1.ShardBackupStorageNotConfiguredReason, "shard %q has no backup location configured", c.shardName)
+		return c.updateStatus(ctx, backup)
+	}
+
+	start := metav1.NewTime(c.now())
+	backup.Status.Phase = corev1alpha1.ShardBackupPhaseRunning
+	backup.Status.StartTime = &start
+	conditions.MarkFalse(backup, corev1alpha1.ShardBackupSucceeded, string(corev1alpha1.ShardBackupPhaseRunning), conditionsv1alpha1.ConditionSeverityInfo, "Taking a snapshot of shard %q", c.shardName)
+	if err := c.updateStatus(ctx, backup); err != nil {
+		return err
+	}
+
+	logger.Info("taking etcd snapshot")
+	snapshot, err := c.snapshot(ctx)
+	if err != nil {
+		c.fail(backup, corev1alpha1.ShardBackupSnapshotFailedReason, "failed to take snapshot: %v", err)
+		return c.updateStatus(ctx, backup)
+	}
+	defer snapshot.Close()
+
+	counter := &countingReader{r: snapshot}
+	location, err := c.storage.Upload(ctx, fmt.Sprintf("%s/%s.db", c.shardName, backup.Name), counter)
+	if err != nil {
+		// a failing snapshot stream surfaces here as well, but the object storage is the usual suspect
+		c.fail(backup, corev1alpha1.ShardBackupUploadFailedReason, "failed to upload snapshot: %v", err)
+		return c.updateStatus(ctx, backup)
+	}
+
+	completion := metav1.NewTime(c.now())
+	backup.Status.Phase = corev1alpha1.ShardBackupPhaseCompleted
+	backup.Status.Location = location
+	backup.Status.SizeBytes = counter.n
+	backup.Status.CompletionTime = &completion
+	conditions.MarkTrue(backup, corev1alpha1.ShardBackupSucceeded)
+	logger.Info("uploaded etcd snapshot", "location", location, "sizeBytes", counter.n, "duration", completion.Sub(start.Time))
+	return c.updateStatus(ctx, backup)
+}
+
+func (c *Controller) fail(backup *corev1alpha1.ShardBackup, reason, messageFormat string, messageArgs ...interface{}) {
+	completion := metav1.NewTime(c.now())
+	backup.Status.Phase = corev1alpha1.ShardBackupPhaseFailed
+	backup.Status.CompletionTime = &completion
+	conditions.MarkFalse(backup, corev1alpha1.ShardBackupSucceeded, reason, conditionsv1alpha1.ConditionSeverityError, messageFormat, messageArgs...)
+}
+
+func (c *Controller) updateStatus(ctx context.Context, backup *corev1alpha1.ShardBackup) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": backup.Status,
+	})
+	if err != nil {
+		return err
+	}
+	klog.FromContext(ctx).V(2).Info("patching ShardBackup status", "patch", string(patch))
+	return c.patchStatus(ctx, backup.Name, patch)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
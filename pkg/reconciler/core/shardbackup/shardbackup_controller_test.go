@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardbackup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+type fakeStorage struct {
+	uploaded map[string]string
+	err      error
+}
+
+func (s *fakeStorage) Upload(ctx context.Context, name string, r io.Reader) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.uploaded[name] = string(data)
+	return "mem://" + name, nil
+}
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		noStorage   bool
+		snapshotErr error
+		uploadErr   error
+
+		wantPhase    corev1alpha1.ShardBackupPhaseType
+		wantReason   string
+		wantLocation string
+		wantSize     int64
+	}{
+		"completed": {
+			wantPhase:    corev1alpha1.ShardBackupPhaseCompleted,
+			wantLocation: "mem://shard-1/backup.db",
+			wantSize:     int64(len("snapshot")),
+		},
+		"no storage": {
+			noStorage:  true,
+			wantPhase:  corev1alpha1.ShardBackupPhaseFailed,
+			wantReason: corev1alpha1.ShardBackupStorageNotConfiguredReason,
+		},
+		"snapshot fails": {
+			snapshotErr: errors.New("etcd unavailable"),
+			wantPhase:   corev1alpha1.ShardBackupPhaseFailed,
+			wantReason:  corev1alpha1.ShardBackupSnapshotFailedReason,
+		},
+		"upload fails": {
+			uploadErr:  errors.New("access denied"),
+			wantPhase:  corev1alpha1.ShardBackupPhaseFailed,
+			wantReason: corev1alpha1.ShardBackupUploadFailedReason,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			storage := &fakeStorage{uploaded: map[string]string{}, err: tt.uploadErr}
+			var patches []corev1alpha1.ShardBackupStatus
+			c := &Controller{
+				shardName: "shard-1",
+				storage:   storage,
+				patchStatus: func(ctx context.Context, name string, patch []byte) error {
+					var obj corev1alpha1.ShardBackup
+					require.NoError(t, json.Unmarshal(patch, &obj))
+					patches = append(patches, obj.Status)
+					return nil
+				},
+				snapshot: func(ctx context.Context) (io.ReadCloser, error) {
+					if tt.snapshotErr != nil {
+						return nil, tt.snapshotErr
+					}
+					return io.NopCloser(strings.NewReader("snapshot")), nil
+				},
+				now:      func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) },
+				finished: sets.New[types.UID](),
+			}
+			if tt.noStorage {
+				c.storage = nil
+			}
+
+			backup := &corev1alpha1.ShardBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup"},
+				Spec:       corev1alpha1.ShardBackupSpec{Shard: "shard-1"},
+			}
+			require.NoError(t, c.reconcile(context.Background(), backup))
+
+			require.NotEmpty(t, patches)
+			if !tt.noStorage {
+				require.Equal(t, corev1alpha1.ShardBackupPhaseRunning, patches[0].Phase, "the running phase is reported first")
+			}
+			final := patches[len(patches)-1]
+			require.Equal(t, tt.wantPhase, final.Phase)
+			require.Equal(t, tt.wantLocation, final.Location)
+			require.Equal(t, tt.wantSize, final.SizeBytes)
+			require.NotNil(t, final.CompletionTime)
+
+			succeeded := conditions.Get(&corev1alpha1.ShardBackup{Status: final}, corev1alpha1.ShardBackupSucceeded)
+			require.NotNil(t, succeeded)
+			if tt.wantReason == "" {
+				require.Equal(t, "snapshot", storage.uploaded["shard-1/backup.db"])
+				require.True(t, conditions.IsTrue(&corev1alpha1.ShardBackup{Status: final}, corev1alpha1.ShardBackupSucceeded))
+			} else {
+				require.Equal(t, tt.wantReason, succeeded.Reason)
+			}
+		})
+	}
+}
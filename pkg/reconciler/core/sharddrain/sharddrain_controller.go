@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharddrain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-shard-drain"
+)
+
+// NewController returns a controller reporting the progress of draining the given shard
+// in the status of its Shard object.
+func NewController(
+	shardName string,
+	rootKcpClient kcpclientset.ClusterInterface,
+	globalShardInformer corev1alpha1informers.ShardClusterInformer,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+) (*Controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &Controller{
+		queue:     queue,
+		shardName: shardName,
+		patchStatus: func(ctx context.Context, name string, patch []byte) error {
+			_, err := rootKcpClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+			return err
+		},
+		getShard: func(name string) (*corev1alpha1.Shard, error) {
+			return globalShardInformer.Cluster(core.RootCluster).Lister().Get(name)
+		},
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().List(labels.Everything())
+		},
+	}
+
+	_, _ = globalShardInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			shard, ok := obj.(*corev1alpha1.Shard)
+			return ok && shard.Name == shardName
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueue() },
+			UpdateFunc: func(_, obj interface{}) { c.enqueue() },
+		},
+	})
+
+	_, _ = logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueue() },
+		DeleteFunc: func(obj interface{}) { c.enqueue() },
+	})
+
+	return c, nil
+}
+
+// Controller counts the logical clusters of workspaces on its shard while the Shard
+// is draining, and sets the Drained condition when none is left.
+type Controller struct {
+	queue workqueue.RateLimitingInterface
+
+	shardName string
+
+	getShard            func(name string) (*corev1alpha1.Shard, error)
+	listLogicalClusters func() ([]*corev1alpha1.LogicalCluster, error)
+
+	// patchStatus patches the status of the Shard in the root workspace. The Shard is read
+	// from the cache server, hence its resourceVersion cannot be used as precondition.
+	patchStatus func(ctx context.Context, name string, patch []byte) error
+}
+
+// enqueue queues the Shard of this controller. There is only one key, hence events are
+// coalesced by the queue.
+func (c *Controller) enqueue() {
+	key := kcpcache.ToClusterAwareKey(core.RootCluster.String(), "", c.shardName)
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing Shard")
+	c.queue.Add(key)
+}
+
+func (c *Controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *Controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *Controller) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+	_, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		logger.Error(err, "invalid key")
+		return nil
+	}
+
+	obj, err := c.getShard(name)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	previous := obj
+	obj = obj.DeepCopy()
+
+	logger = logging.WithObject(logger, obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.reconcile(ctx, obj); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(previous.Status, obj.Status) {
+		return nil
+	}
+
+	// drain is null in the merge patch when it is unset, which removes it.
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"drain":      obj.Status.Drain,
+			"conditions": obj.Status.Conditions,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	logger.V(2).Info("patching Shard status", "patch", string(patch))
+	if err := c.patchStatus(ctx, obj.Name, patch); err != nil {
+		return err
+	}
+
+	logger.V(6).Info("processed Shard")
+	return nil
+}
+
+func (c *Controller) reconcile(ctx context.Context, shard *corev1alpha1.Shard) error {
+	if !shard.Spec.Drain {
+		shard.Status.Drain = nil
+		conditions.Delete(shard, corev1alpha1.ShardDrained)
+		return nil
+	}
+
+	logicalClusters, err := c.listLogicalClusters()
+	if err != nil {
+		return err
+	}
+	var remaining int64
+	for _, logicalCluster := range logicalClusters {
+		if owner := logicalCluster.Spec.Owner; owner != nil && owner.Resource == "workspaces" {
+			remaining++
+		}
+	}
+	shard.Status.Drain = &corev1alpha1.ShardDrainStatus{RemainingLogicalClusters: remaining}
+
+	if remaining == 0 {
+		klog.FromContext(ctx).V(2).Info("shard is drained")
+		conditions.MarkTrue(shard, corev1alpha1.ShardDrained)
+		return nil
+	}
+	conditions.MarkFalse(shard, corev1alpha1.ShardDrained, corev1alpha1.ShardDrainingReason, conditionsv1alpha1.ConditionSeverityInfo, "%d logical clusters of workspaces remaining", remaining)
+	return nil
+}
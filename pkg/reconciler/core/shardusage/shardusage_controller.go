@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardusage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/component-base/metrics"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-shard-usage"
+
+	// requestsMetric counts the requests served by the shard.
+	requestsMetric = "apiserver_request_total"
+	// storageSizeMetric is the size of the storage database, if monitored.
+	storageSizeMetric = "apiserver_storage_size_bytes"
+)
+
+// NewController returns a controller periodically reporting the usage of the given shard
+// in the status of its Shard object. maxQPS is the number of requests per second the shard
+// is sized for, or 0 if unknown.
+func NewController(
+	shardName string,
+	maxQPS int64,
+	rootKcpClient kcpclientset.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	gatherer metrics.Gatherer,
+) (*Controller, error) {
+	return &Controller{
+		shardName: shardName,
+		maxQPS:    maxQPS,
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().List(labels.Everything())
+		},
+		requests: func() (float64, bool, error) {
+			return aggregateMetric(gatherer, requestsMetric, func(a, b float64) float64 { return a + b })
+		},
+		storageSize: func() (float64, bool, error) {
+			return aggregateMetric(gatherer, storageSizeMetric, func(a, b float64) float64 { return max(a, b) })
+		},
+		patchStatus: func(ctx context.Context, name string, patch []byte) error {
+			_, err := rootKcpClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+			return err
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Controller reports the number of logical clusters, the storage size and the QPS headroom
+// of its shard.
+type Controller struct {
+	shardName string
+	maxQPS    int64
+
+	listLogicalClusters func() ([]*corev1alpha1.LogicalCluster, error)
+	// requests returns the total number of requests served so far.
+	requests func() (float64, bool, error)
+	// storageSize returns the size of the storage database in bytes.
+	storageSize func() (float64, bool, error)
+	// patchStatus patches the status of the Shard in the root workspace.
+	patchStatus func(ctx context.Context, name string, patch []byte) error
+	now         func() time.Time
+
+	// lastRequests and lastTime are the request count at the previous update, to compute the QPS.
+	lastRequests float64
+	lastTime     time.Time
+}
+
+// Start reports the usage every interval until the context is done.
+func (c *Controller) Start(ctx context.Context, interval time.Duration) {
+	defer runtime.HandleCrash()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.update(ctx); err != nil {
+			runtime.HandleError(err)
+		}
+	}, interval)
+}
+
+func (c *Controller) update(ctx context.Context) error {
+	usage, err := c.usage()
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"usage": usage,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	klog.FromContext(ctx).V(4).Info("patching Shard usage", "patch", string(patch))
+	return c.patchStatus(ctx, c.shardName, patch)
+}
+
+func (c *Controller) usage() (*corev1alpha1.ShardUsage, error) {
+	now := c.now()
+
+	logicalClusters, err := c.listLogicalClusters()
+	if err != nil {
+		return nil, err
+	}
+	usage := &corev1alpha1.ShardUsage{
+		LogicalClusters: int64(len(logicalClusters)),
+		LastUpdateTime:  metav1.NewTime(now),
+	}
+
+	if size, found, err := c.storageSize(); err != nil {
+		return nil, err
+	} else if found {
+		bytes := int64(size)
+		usage.StorageSizeBytes = &bytes
+	}
+
+	requests, found, err := c.requests()
+	if err != nil {
+		return nil, err
+	}
+	if found && c.maxQPS > 0 && !c.lastTime.IsZero() && now.After(c.lastTime) {
+		qps := int64((requests - c.lastRequests) / now.Sub(c.lastTime).Seconds())
+		headroom := max(c.maxQPS-qps, 0)
+		usage.QPSHeadroom = &headroom
+	}
+	c.lastRequests, c.lastTime = requests, now
+
+	return usage, nil
+}
+
+// aggregateMetric aggregates the values of all samples of the given counter or gauge.
+func aggregateMetric(gatherer metrics.Gatherer, name string, aggregate func(a, b float64) float64) (value float64, found bool, err error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return 0, false, err
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			v := m.GetCounter().GetValue() + m.GetGauge().GetValue()
+			if !found {
+				value, found = v, true
+				continue
+			}
+			value = aggregate(value, v)
+		}
+	}
+	return value, found, nil
+}
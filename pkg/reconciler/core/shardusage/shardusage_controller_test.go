@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardusage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestUsage(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	requests := 1000.0
+	c := &Controller{
+		maxQPS: 100,
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return []*corev1alpha1.LogicalCluster{{}, {}, {}}, nil
+		},
+		requests: func() (float64, bool, error) {
+			return requests, true, nil
+		},
+		storageSize: func() (float64, bool, error) {
+			return 4096, true, nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	usage, err := c.usage()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), usage.LogicalClusters)
+	require.Equal(t, int64(4096), *usage.StorageSizeBytes)
+	require.Nil(t, usage.QPSHeadroom, "no headroom before the second update")
+
+	now = now.Add(10 * time.Second)
+	requests += 300
+	usage, err = c.usage()
+	require.NoError(t, err)
+	require.Equal(t, int64(70), *usage.QPSHeadroom)
+
+	now = now.Add(10 * time.Second)
+	requests += 2000
+	usage, err = c.usage()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), *usage.QPSHeadroom, "headroom is never negative")
+
+	c.maxQPS = 0
+	usage, err = c.usage()
+	require.NoError(t, err)
+	require.Nil(t, usage.QPSHeadroom, "no headroom without max QPS")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides the metrics shared by all kcp controllers. Importing it also registers
+// the workqueue metrics (depth, adds, latency, work duration, retries) of all named queues.
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	_ "k8s.io/component-base/metrics/prometheus/workqueue" // register workqueue metrics
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// perLogicalCluster enables the reconcile counts per logical cluster. It is off by default
+// because the cardinality grows with the number of logical clusters.
+var perLogicalCluster atomic.Bool
+
+// SetPerLogicalCluster enables or disables counting reconciliations per logical cluster.
+func SetPerLogicalCluster(enabled bool) {
+	perLogicalCluster.Store(enabled)
+}
+
+// ObserveReconcile records the duration since start and the result of one reconciliation of the
+// queue key by the named controller. A nil err counts as success, including requeues.
+func ObserveReconcile(controller, key string, start time.Time, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+
+	reconcileDuration.WithLabelValues(controller).Observe(time.Since(start).Seconds())
+	reconciles.WithLabelValues(controller, result).Inc()
+	if err != nil {
+		reconcileErrors.WithLabelValues(controller).Inc()
+	}
+
+	if !perLogicalCluster.Load() {
+		return
+	}
+	// keys of controllers for several resources are prefixed with the resource, e.g. <gvr>::<key>
+	if i := strings.LastIndex(key, "::"); i >= 0 {
+		key = key[i+len("::"):]
+	}
+	clusterName, _, _, splitErr := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if splitErr != nil || clusterName.Empty() {
+		return // not a cluster-aware key, e.g. of a controller reconciling resources
+	}
+	logicalClusterReconciles.WithLabelValues(controller, clusterName.String(), result).Inc()
+}
+
+var (
+	reconcileDuration = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "controller_reconcile_duration_seconds",
+			Help:           "Duration of the reconciliation of a queue key per controller.",
+			Buckets:        []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller"},
+	)
+
+	reconciles = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "controller_reconcile_total",
+			Help:           "Number of reconciliations of queue keys per controller and result, one of success or error.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller", "result"},
+	)
+
+	reconcileErrors = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "controller_reconcile_errors_total",
+			Help:           "Number of reconciliations of queue keys per controller that failed and were requeued with backoff.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller"},
+	)
+
+	logicalClusterReconciles = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "controller_logical_cluster_reconcile_total",
+			Help:           "Number of reconciliations per controller, logical cluster and result. Only recorded with --controller-metrics-per-logical-cluster.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller", "logical_cluster", "result"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register metrics.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(reconcileDuration)
+		legacyregistry.MustRegister(reconciles)
+		legacyregistry.MustRegister(reconcileErrors)
+		legacyregistry.MustRegister(logicalClusterReconciles)
+	})
+}
+
+func init() {
+	Register()
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	t.Cleanup(func() {
+		SetPerLogicalCluster(false)
+		reconciles.Reset()
+		reconcileErrors.Reset()
+		logicalClusterReconciles.Reset()
+	})
+
+	ObserveReconcile("test-controller", "root|foo", time.Now(), nil)
+	SetPerLogicalCluster(true)
+	ObserveReconcile("test-controller", "root:org|foo", time.Now(), nil)
+	ObserveReconcile("test-controller", "root:org|ns/bar", time.Now(), errors.New("boom"))
+	ObserveReconcile("test-controller", "apiexports.v1alpha1.apis.kcp.io::root:org|baz", time.Now(), nil)
+	ObserveReconcile("test-controller", "not-cluster-aware", time.Now(), nil)
+
+	expected := `
+# HELP controller_logical_cluster_reconcile_total [ALPHA] Number of reconciliations per controller, logical cluster and result. Only recorded with --controller-metrics-per-logical-cluster.
+# TYPE controller_logical_cluster_reconcile_total counter
+controller_logical_cluster_reconcile_total{controller="test-controller",logical_cluster="root:org",result="error"} 1
+controller_logical_cluster_reconcile_total{controller="test-controller",logical_cluster="root:org",result="success"} 2
+# HELP controller_reconcile_errors_total [ALPHA] Number of reconciliations of queue keys per controller that failed and were requeued with backoff.
+# TYPE controller_reconcile_errors_total counter
+controller_reconcile_errors_total{controller="test-controller"} 1
+# HELP controller_reconcile_total [ALPHA] Number of reconciliations of queue keys per controller and result, one of success or error.
+# TYPE controller_reconcile_total counter
+controller_reconcile_total{controller="test-controller",result="error"} 1
+controller_reconcile_total{controller="test-controller",result="success"} 4
+`
+	err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, strings.NewReader(expected),
+		"controller_reconcile_total", "controller_reconcile_errors_total", "controller_logical_cluster_reconcile_total")
+	require.NoError(t, err)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	admission "github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (t *Templater) reconcile(ctx context.Context, logicalCluster *corev1alpha1.LogicalCluster) error {
+	annotationValue, found := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterTypeAnnotationKey]
+	if !found {
+		return nil
+	}
+	wtCluster, wtName := logicalcluster.NewPath(annotationValue).Split()
+	if wtCluster.Empty() {
+		return nil
+	}
+	logger := klog.FromContext(ctx).WithValues(
+		"workspacetype.path", wtCluster.String(),
+		"workspacetype.name", wtName,
+	)
+
+	clusterName := logicalcluster.From(logicalCluster)
+	logger.V(3).Info("initializing templates for workspace")
+
+	leafWT, err := t.getWorkspaceType(wtCluster, wtName)
+	if err != nil {
+		logger.Error(err, "error getting WorkspaceType")
+
+		conditions.MarkFalse(
+			logicalCluster,
+			tenancyv1alpha1.WorkspaceTemplatesInitialized,
+			tenancyv1alpha1.WorkspaceInitializedWorkspaceTypeInvalid,
+			conditionsv1alpha1.ConditionSeverityError,
+			"error getting WorkspaceType %s|%s: %v",
+			wtCluster.String(), wtName,
+			err,
+		)
+
+		return nil
+	}
+
+	wts, err := t.transitiveTypeResolver.Resolve(leafWT)
+	if err != nil {
+		logger.Error(err, "error resolving transitive types")
+
+		conditions.MarkFalse(
+			logicalCluster,
+			tenancyv1alpha1.WorkspaceTemplatesInitialized,
+			tenancyv1alpha1.WorkspaceInitializedWorkspaceTypeInvalid,
+			conditionsv1alpha1.ConditionSeverityError,
+			"error resolving transitive set of workspace types: %v",
+			err,
+		)
+
+		return nil
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, wt := range admission.WithoutOverridden(wts, tenancyv1alpha1.WorkspaceTypeFieldTemplate) {
+		if wt.Spec.Template == nil {
+			continue
+		}
+		for i, raw := range wt.Spec.Template.Objects {
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+				logging.WithObject(logger, wt).Error(err, "invalid template object", "index", i)
+
+				conditions.MarkFalse(
+					logicalCluster,
+					tenancyv1alpha1.WorkspaceTemplatesInitialized,
+					tenancyv1alpha1.WorkspaceInitializedWorkspaceTypeInvalid,
+					conditionsv1alpha1.ConditionSeverityError,
+					"invalid object %d in template of WorkspaceType %s|%s: %v",
+					i, logicalcluster.From(wt), wt.Name,
+					err,
+				)
+
+				return nil
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	// namespaces go first as the other objects might live in them.
+	sort.SliceStable(objects, func(i, j int) bool {
+		return isNamespace(objects[i]) && !isNamespace(objects[j])
+	})
+
+	if err := t.createObjects(ctx, clusterName.Path(), objects); err != nil {
+		logger.Error(err, "error creating template objects")
+
+		conditions.MarkFalse(
+			logicalCluster,
+			tenancyv1alpha1.WorkspaceTemplatesInitialized,
+			tenancyv1alpha1.WorkspaceInitializedTemplateErrors,
+			conditionsv1alpha1.ConditionSeverityError,
+			"encountered errors: %v",
+			err,
+		)
+
+		// Retry, as e.g. the APIs of the objects might not be bound yet.
+		return err
+	}
+
+	conditions.MarkTrue(logicalCluster, tenancyv1alpha1.WorkspaceTemplatesInitialized)
+	logicalCluster.Status.Initializers = initialization.EnsureInitializerAbsent(tenancyv1alpha1.WorkspaceTemplatesInitializer, logicalCluster.Status.Initializers)
+
+	return nil
+}
+
+func isNamespace(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == "" && gvk.Kind == "Namespace"
+}
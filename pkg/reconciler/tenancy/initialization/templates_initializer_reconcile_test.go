@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+type fakeTransitiveTypeResolver []*tenancyv1alpha1.WorkspaceType
+
+func (r fakeTransitiveTypeResolver) Resolve(t *tenancyv1alpha1.WorkspaceType) ([]*tenancyv1alpha1.WorkspaceType, error) {
+	return r, nil
+}
+
+func TestTemplaterReconcile(t *testing.T) {
+	workspaceType := func(name string, objects ...string) *tenancyv1alpha1.WorkspaceType {
+		wt := &tenancyv1alpha1.WorkspaceType{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root"},
+			},
+		}
+		if len(objects) > 0 {
+			wt.Spec.Template = &tenancyv1alpha1.WorkspaceTemplate{}
+			for _, obj := range objects {
+				wt.Spec.Template.Objects = append(wt.Spec.Template.Objects, tenancyv1alpha1.WorkspaceTemplateObject{RawExtension: runtime.RawExtension{Raw: []byte(obj)}})
+			}
+		}
+		return wt
+	}
+
+	for _, testCase := range []struct {
+		name      string
+		types     []*tenancyv1alpha1.WorkspaceType
+		createErr error
+
+		wantErr         bool
+		wantCreated     []string
+		wantInitializer bool
+		wantCondition   bool
+		wantReason      string
+	}{
+		{
+			name: "creates objects of all types, namespaces first",
+			types: []*tenancyv1alpha1.WorkspaceType{
+				workspaceType("base", `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"team"}}`),
+				workspaceType("leaf",
+					`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"viewer"}}`,
+					`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"team"}}`,
+				),
+			},
+			wantCreated:   []string{"Namespace/team", "ConfigMap/settings", "ClusterRole/viewer"},
+			wantCondition: true,
+		},
+		{
+			name: "creation fails",
+			types: []*tenancyv1alpha1.WorkspaceType{
+				workspaceType("leaf", `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"team"}}`),
+			},
+			createErr:       errors.New("boom"),
+			wantErr:         true,
+			wantCreated:     []string{"Namespace/team"},
+			wantInitializer: true,
+			wantReason:      tenancyv1alpha1.WorkspaceInitializedTemplateErrors,
+		},
+		{
+			name: "invalid object",
+			types: []*tenancyv1alpha1.WorkspaceType{
+				workspaceType("leaf", `{"metadata":{"name":"team"}}`),
+			},
+			wantInitializer: true,
+			wantReason:      tenancyv1alpha1.WorkspaceInitializedWorkspaceTypeInvalid,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var created []string
+			r := &Templater{
+				getWorkspaceType: func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					require.Equal(t, "root", clusterName.String())
+					require.Equal(t, "leaf", name)
+					return testCase.types[len(testCase.types)-1], nil
+				},
+				transitiveTypeResolver: fakeTransitiveTypeResolver(testCase.types),
+				createObjects: func(ctx context.Context, cluster logicalcluster.Path, objects []*unstructured.Unstructured) error {
+					require.Equal(t, "somecluster", cluster.String())
+					for _, obj := range objects {
+						created = append(created, obj.GetKind()+"/"+obj.GetName())
+					}
+					return testCase.createErr
+				},
+			}
+
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:                    "somecluster",
+						tenancyv1alpha1.LogicalClusterTypeAnnotationKey: "root:leaf",
+					},
+				},
+				Status: corev1alpha1.LogicalClusterStatus{
+					Phase:        corev1alpha1.LogicalClusterPhaseInitializing,
+					Initializers: []corev1alpha1.LogicalClusterInitializer{tenancyv1alpha1.WorkspaceTemplatesInitializer},
+				},
+			}
+
+			err := r.reconcile(context.Background(), logicalCluster)
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.wantCreated, created)
+			require.Equal(t, testCase.wantInitializer, len(logicalCluster.Status.Initializers) > 0)
+			require.Equal(t, testCase.wantCondition, conditions.IsTrue(logicalCluster, tenancyv1alpha1.WorkspaceTemplatesInitialized))
+			require.Equal(t, testCase.wantReason, conditions.GetReason(logicalCluster, tenancyv1alpha1.WorkspaceTemplatesInitialized))
+		})
+	}
+}
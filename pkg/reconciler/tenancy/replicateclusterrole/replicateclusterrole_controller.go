@@ -24,6 +24,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/kcp-dev/kcp/pkg/authorization"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/labelclusterroles"
 	"github.com/kcp-dev/kcp/sdk/apis/tenancy"
 )
@@ -32,7 +33,8 @@ const (
 	ControllerName = "kcp-tenancy-replicate-clusterrole"
 )
 
-// NewController returns a new controller for labelling ClusterRole that should be replicated.
+// NewController returns a new controller for labelling ClusterRole that should be replicated, i.e.
+// those granting the use of WorkspaceTypes and those referenced by inherited ClusterRoleBindings.
 func NewController(
 	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	clusterRoleInformer kcprbacinformers.ClusterRoleClusterInformer,
@@ -42,7 +44,7 @@ func NewController(
 		ControllerName,
 		tenancy.GroupName,
 		HasUseRule,
-		func(clusterName logicalcluster.Name, crb *rbacv1.ClusterRoleBinding) bool { return false },
+		IsInheritedClusterRoleBinding,
 		kubeClusterClient,
 		clusterRoleInformer,
 		clusterRoleBindingInformer,
@@ -57,9 +59,15 @@ func HasUseRule(clusterName logicalcluster.Name, cr *rbacv1.ClusterRole) bool {
 		}
 		resources := sets.New[string](rule.Resources...)
 		verbs := sets.New[string](rule.Verbs...)
-		if (resources.Has("workspacetypes") || resources.Has("*")) && (verbs.Has("use") || verbs.Has("initialize") || verbs.Has("*")) {
+		if (resources.Has("workspacetypes") || resources.Has("*")) && (verbs.Has("use") || verbs.Has("initialize") || verbs.Has("terminate") || verbs.Has("*")) {
 			return true
 		}
 	}
 	return false
 }
+
+// IsInheritedClusterRoleBinding returns true if the ClusterRoleBinding is labeled for inheritance
+// by descendant workspaces, which can live on other shards.
+func IsInheritedClusterRoleBinding(clusterName logicalcluster.Name, crb *rbacv1.ClusterRoleBinding) bool {
+	return crb.Labels[authorization.InheritLabelKey] == "true"
+}
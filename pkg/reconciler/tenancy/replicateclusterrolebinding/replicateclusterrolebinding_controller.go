@@ -19,9 +19,6 @@ package replicateclusterrolebinding
 import (
 	kcprbacinformers "github.com/kcp-dev/client-go/informers/rbac/v1"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
-	"github.com/kcp-dev/logicalcluster/v3"
-
-	rbacv1 "k8s.io/api/rbac/v1"
 
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/labelclusterrolebindings"
 	replicateclusterrole "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/replicateclusterrole"
@@ -32,7 +29,8 @@ const (
 	ControllerName = "kcp-tenancy-replicate-clusterrolebinding"
 )
 
-// NewController returns a new controller for labelling ClusterRoleBinding that should be replicated.
+// NewController returns a new controller for labelling ClusterRoleBinding that should be replicated, i.e.
+// those referencing ClusterRoles granting the use of WorkspaceTypes and those labeled for inheritance.
 func NewController(
 	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	clusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
@@ -42,7 +40,7 @@ func NewController(
 		ControllerName,
 		tenancy.GroupName,
 		replicateclusterrole.HasUseRule,
-		func(clusterName logicalcluster.Name, crb *rbacv1.ClusterRoleBinding) bool { return false },
+		replicateclusterrole.IsInheritedClusterRoleBinding,
 		kubeClusterClient,
 		clusterRoleBindingInformer,
 		clusterRoleInformer,
@@ -23,8 +23,12 @@ import (
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -36,6 +40,10 @@ import (
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
@@ -51,6 +59,8 @@ const (
 
 func NewController(
 	shardName string,
+	defaultPlacementStrategy tenancyv1alpha1.WorkspacePlacementStrategy,
+	schedulingThresholds SchedulingThresholds,
 	kcpClusterClient kcpclientset.ClusterInterface,
 	kubeClusterClient kubernetes.ClusterInterface,
 	logicalClusterAdminConfig *rest.Config,
@@ -66,6 +76,8 @@ func NewController(
 		queue: queue,
 
 		shardName:                         shardName,
+		defaultPlacementStrategy:          defaultPlacementStrategy,
+		schedulingThresholds:              schedulingThresholds,
 		logicalClusterAdminConfig:         logicalClusterAdminConfig,
 		externalLogicalClusterAdminConfig: externalLogicalClusterAdminConfig,
 
@@ -92,6 +104,23 @@ func NewController(
 		UpdateFunc: func(_, obj interface{}) { c.enqueue(obj) },
 	})
 
+	_, _ = logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, obj interface{}) {
+			old, ok := oldObj.(*corev1alpha1.LogicalCluster)
+			if !ok {
+				return
+			}
+			logicalCluster, ok := obj.(*corev1alpha1.LogicalCluster)
+			if !ok {
+				return
+			}
+			if old.Annotations[core.LogicalClusterPathAnnotationKey] != logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey] ||
+				propagatedMetadataChanged(old, logicalCluster) {
+				c.enqueueChildWorkspaces(logicalCluster)
+			}
+		},
+	})
+
 	_, _ = globalShardInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    func(obj interface{}) { c.enqueueShard(obj) },
 		UpdateFunc: func(obj, _ interface{}) { c.enqueueShard(obj) },
@@ -109,6 +138,8 @@ type Controller struct {
 	queue workqueue.RateLimitingInterface
 
 	shardName                         string
+	defaultPlacementStrategy          tenancyv1alpha1.WorkspacePlacementStrategy
+	schedulingThresholds              SchedulingThresholds
 	logicalClusterAdminConfig         *rest.Config // for direct shard connections used during scheduling
 	externalLogicalClusterAdminConfig *rest.Config // for front-proxy connections used during initialization
 
@@ -143,6 +174,27 @@ func (c *Controller) enqueue(obj interface{}) {
 	c.queue.Add(key)
 }
 
+// enqueueChildWorkspaces enqueues the workspaces in the given logical cluster, e.g.
+// to update their paths after the logical cluster has been moved, or to propagate
+// labels and annotations.
+func (c *Controller) enqueueChildWorkspaces(logicalCluster *corev1alpha1.LogicalCluster) {
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	workspaces, err := c.workspaceLister.Cluster(logicalcluster.From(logicalCluster)).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, workspace := range workspaces {
+		key, err := kcpcache.MetaClusterNamespaceKeyFunc(workspace)
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		logging.WithQueueKey(logger, key).V(3).Info("queueing Workspace because of change of parent LogicalCluster")
+		c.queue.Add(key)
+	}
+}
+
 func (c *Controller) enqueueShard(obj interface{}) {
 	logger := logging.WithReconciler(klog.Background(), ControllerName)
 	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
@@ -173,6 +225,64 @@ func (c *Controller) enqueueShard(obj interface{}) {
 			c.queue.Add(key)
 		}
 	}
+
+	// workspaces on draining shards might wait for another shard to become available.
+	shards, err := c.globalShardLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, shard := range shards {
+		if !shard.Spec.Drain {
+			continue
+		}
+		workspaces, err := c.workspaceIndexer.ByIndex(byShardHash, ByBase36Sha224NameValue(shard.Name))
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		for _, workspace := range workspaces {
+			key, err := kcpcache.MetaClusterNamespaceKeyFunc(workspace)
+			if err != nil {
+				runtime.HandleError(err)
+				return
+			}
+			logging.WithQueueKey(logger, key).V(3).Info("queueing Workspace on draining shard because of shard update", "shard", shard.Name)
+			c.queue.Add(key)
+		}
+	}
+}
+
+// recordWarning emits a warning event for the given workspace in the default namespace of
+// its logical cluster. Failures are only logged.
+func (c *Controller) recordWarning(ctx context.Context, workspace *tenancyv1alpha1.Workspace, reason, message string) {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: workspace.Name + ".",
+			Namespace:    metav1.NamespaceDefault,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      tenancyv1alpha1.SchemeGroupVersion.String(),
+			Kind:            "Workspace",
+			Name:            workspace.Name,
+			UID:             workspace.UID,
+			ResourceVersion: workspace.ResourceVersion,
+		},
+		Reason:              reason,
+		Message:             message,
+		Type:                corev1.EventTypeWarning,
+		Source:              corev1.EventSource{Component: ControllerName},
+		ReportingController: ControllerName,
+		ReportingInstance:   c.shardName,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+	}
+	clusterName := logicalcluster.From(workspace)
+	if _, err := c.kubeClusterClient.Cluster(clusterName.Path()).CoreV1().Events(metav1.NamespaceDefault).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		klog.FromContext(ctx).Error(err, "failed to record event", "reason", reason)
+	}
 }
 
 func (c *Controller) Start(ctx context.Context, numThreads int) {
@@ -221,7 +331,12 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if requeue, err := c.process(ctx, key); err != nil {
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	requeue, err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
 		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
 		c.queue.AddRateLimited(key)
 		return true
@@ -274,9 +389,11 @@ func (c *Controller) process(ctx context.Context, key string) (bool, error) {
 func InstallIndexers(workspaceInformer tenancyv1alpha1informers.WorkspaceClusterInformer,
 	globalShardInformer corev1alpha1informers.ShardClusterInformer,
 	globalWorkspaceTypeInformer tenancyv1alpha1informers.WorkspaceTypeClusterInformer,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
 ) {
 	indexers.AddIfNotPresentOrDie(workspaceInformer.Informer().GetIndexer(), cache.Indexers{
 		unschedulable: indexUnschedulable,
+		byShardHash:   indexByShardHash,
 	})
 	indexers.AddIfNotPresentOrDie(globalShardInformer.Informer().GetIndexer(), cache.Indexers{
 		byBase36Sha224Name: indexByBase36Sha224Name,
@@ -284,4 +401,7 @@ func InstallIndexers(workspaceInformer tenancyv1alpha1informers.WorkspaceCluster
 	indexers.AddIfNotPresentOrDie(globalWorkspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
 	})
+	indexers.AddIfNotPresentOrDie(logicalClusterInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
 }
@@ -30,6 +30,7 @@ import (
 const (
 	byBase36Sha224Name = "byBase36Sha224Name"
 	unschedulable      = "unschedulable"
+	byShardHash        = "byShardHash"
 )
 
 func indexUnschedulable(obj interface{}) ([]string, error) {
@@ -40,6 +41,14 @@ func indexUnschedulable(obj interface{}) ([]string, error) {
 	return []string{}, nil
 }
 
+func indexByShardHash(obj interface{}) ([]string, error) {
+	workspace := obj.(*tenancyv1alpha1.Workspace)
+	if hash, found := workspace.Annotations[WorkspaceShardHashAnnotationKey]; found {
+		return []string{hash}, nil
+	}
+	return []string{}, nil
+}
+
 func indexByBase36Sha224Name(obj interface{}) ([]string, error) {
 	s := obj.(*corev1alpha1.Shard)
 	return []string{ByBase36Sha224NameValue(s.Name)}, nil
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// migrationSkippedResources are not copied during a migration. The LogicalCluster is copied
+// first and separately, and events are not worth the cost.
+var migrationSkippedResources = sets.New[schema.GroupResource](
+	corev1alpha1.Resource("logicalclusters"),
+	schema.GroupResource{Resource: "events"},
+	schema.GroupResource{Group: "events.k8s.io", Resource: "events"},
+)
+
+// migrationFirstResources are copied before all other resources, in this order, as the latter
+// depend on them to exist or to be served.
+var migrationFirstResources = []schema.GroupResource{
+	{Resource: "namespaces"},
+	{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+	{Group: "apis.kcp.io", Resource: "apibindings"},
+}
+
+type migratedResource struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+	status     bool // whether the status subresource exists
+}
+
+// shardConfig returns a client config connecting directly to the given shard with the
+// credentials in c.logicalClusterAdminConfig.
+func (c *Controller) shardConfig(shard *corev1alpha1.Shard) *restclient.Config {
+	config := restclient.CopyConfig(c.logicalClusterAdminConfig)
+	config.Host = shard.Spec.BaseURL
+	return config
+}
+
+// copyLogicalCluster copies the LogicalCluster object, in phase Unavailable, and all objects
+// of the logical cluster from one shard to another. Objects that exist already on the target
+// shard are skipped, i.e. it can be called repeatedly until it succeeds. Objects whose
+// dependencies are not copied yet, e.g. custom resources of a CRD that is not established yet,
+// fail and are copied in a later call.
+func (c *Controller) copyLogicalCluster(ctx context.Context, from, to *corev1alpha1.Shard, clusterName logicalcluster.Name) error {
+	logger := klog.FromContext(ctx).WithValues("cluster", clusterName, "from", from.Name, "to", to.Name)
+
+	fromKcpClient, err := kcpclientset.NewForConfig(c.shardConfig(from))
+	if err != nil {
+		return fmt.Errorf("failed to create shard %q kcp client: %w", from.Name, err)
+	}
+	toKcpClient, err := kcpclientset.NewForConfig(c.shardConfig(to))
+	if err != nil {
+		return fmt.Errorf("failed to create shard %q kcp client: %w", to.Name, err)
+	}
+	fromDynamicClient, err := kcpdynamic.NewForConfig(c.shardConfig(from))
+	if err != nil {
+		return fmt.Errorf("failed to create shard %q dynamic client: %w", from.Name, err)
+	}
+	toDynamicClient, err := kcpdynamic.NewForConfig(c.shardConfig(to))
+	if err != nil {
+		return fmt.Errorf("failed to create shard %q dynamic client: %w", to.Name, err)
+	}
+
+	// the LogicalCluster goes first, unavailable to users until the migration is finished.
+	logicalCluster, err := fromKcpClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if _, err := toKcpClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		logger.V(2).Info("creating LogicalCluster on target shard")
+		copied := &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        logicalCluster.Name,
+				Labels:      logicalCluster.Labels,
+				Annotations: logicalCluster.Annotations,
+				Finalizers:  logicalCluster.Finalizers,
+			},
+			Spec: logicalCluster.Spec,
+		}
+		created, err := toKcpClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Create(ctx, copied, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		created.Status = logicalCluster.Status
+		created.Status.Phase = corev1alpha1.LogicalClusterPhaseUnavailable
+		if _, err := toKcpClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	discoveryConfig := c.shardConfig(from)
+	discoveryConfig.Host += clusterName.Path().RequestPath()
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(discoveryConfig)
+	if err != nil {
+		return err
+	}
+	resourceLists, err := discoveryClient.ServerPreferredResources()
+	if err != nil {
+		return err
+	}
+
+	var resources []migratedResource
+	resourcesByKind := map[schema.GroupKind]migratedResource{}
+	subresources := sets.New[schema.GroupResource]()
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return err
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				subresources.Insert(gv.WithResource(r.Name).GroupResource())
+				continue
+			}
+			gvr := gv.WithResource(r.Name)
+			if migrationSkippedResources.Has(gvr.GroupResource()) {
+				continue
+			}
+			if verbs := sets.New[string](r.Verbs...); !verbs.HasAll("list", "create") {
+				continue
+			}
+			resourcesByKind[gv.WithKind(r.Kind).GroupKind()] = migratedResource{gvr: gvr, namespaced: r.Namespaced}
+			resources = append(resources, migratedResource{gvr: gvr, namespaced: r.Namespaced})
+		}
+	}
+	for i := range resources {
+		gr := resources[i].gvr.GroupResource()
+		resources[i].status = subresources.Has(schema.GroupResource{Group: gr.Group, Resource: gr.Resource + "/status"})
+	}
+	order := func(gr schema.GroupResource) int {
+		for i, first := range migrationFirstResources {
+			if first == gr {
+				return i
+			}
+		}
+		return len(migrationFirstResources)
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return order(resources[i].gvr.GroupResource()) < order(resources[j].gvr.GroupResource())
+	})
+
+	var errs []error
+	for _, r := range resources {
+		list, err := fromDynamicClient.Cluster(clusterName.Path()).Resource(r.gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s: %w", r.gvr, err))
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if obj.GetDeletionTimestamp() != nil {
+				continue
+			}
+			if err := copyObject(ctx, toDynamicClient.Cluster(clusterName.Path()), r, resourcesByKind, obj); err != nil {
+				errs = append(errs, fmt.Errorf("failed to copy %s %s/%s: %w", r.gvr, obj.GetNamespace(), obj.GetName(), err))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// copyObject creates the given object through the given client, including its status.
+// Owner references are rewritten to the UIDs of the owners copied before.
+func copyObject(ctx context.Context, client dynamic.Interface, resource migratedResource, resourcesByKind map[schema.GroupKind]migratedResource, obj *unstructured.Unstructured) error {
+	obj = obj.DeepCopy()
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	obj.SetGeneration(0)
+	obj.SetSelfLink("")
+
+	ownerRefs := obj.GetOwnerReferences()
+	for i, ref := range ownerRefs {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return err
+		}
+		ownerResource, found := resourcesByKind[gv.WithKind(ref.Kind).GroupKind()]
+		if !found {
+			return fmt.Errorf("unknown owner kind %s", gv.WithKind(ref.Kind))
+		}
+		ownerNamespace := ""
+		if ownerResource.namespaced {
+			ownerNamespace = obj.GetNamespace()
+		}
+		owner, err := client.Resource(ownerResource.gvr).Namespace(ownerNamespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get owner %s %s: %w", ownerResource.gvr, ref.Name, err)
+		}
+		ownerRefs[i].UID = owner.GetUID()
+	}
+	obj.SetOwnerReferences(ownerRefs)
+
+	created, err := client.Resource(resource.gvr).Namespace(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if objStatus, found := obj.Object["status"]; found && resource.status {
+		created.Object["status"] = objStatus
+		if _, err := client.Resource(resource.gvr).Namespace(obj.GetNamespace()).UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsMethodNotSupported(err) {
+			return err
+		}
+	}
+
+	return nil
+}
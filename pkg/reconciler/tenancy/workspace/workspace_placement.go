@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"fmt"
+	mathrand "math/rand"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// PlacementStrategy chooses the shard of a workspace.
+type PlacementStrategy interface {
+	// Choose returns one of the given shards, which are schedulable and match the location
+	// of the workspace. shards is never empty. siblings are the other workspaces with the
+	// same parent.
+	Choose(workspace *tenancyv1alpha1.Workspace, siblings []*tenancyv1alpha1.Workspace, shards []*corev1alpha1.Shard) *corev1alpha1.Shard
+}
+
+var placementStrategies = map[tenancyv1alpha1.WorkspacePlacementStrategy]PlacementStrategy{
+	tenancyv1alpha1.WorkspacePlacementRandom:           randomPlacement{},
+	tenancyv1alpha1.WorkspacePlacementCapacityWeighted: capacityWeightedPlacement{},
+	tenancyv1alpha1.WorkspacePlacementLabelAffinity:    labelAffinityPlacement{},
+	tenancyv1alpha1.WorkspacePlacementRegionSpread:     regionSpreadPlacement{},
+}
+
+// placer chooses shards with the placement strategy of the WorkspaceType of a workspace,
+// or with the default strategy if the type does not set one.
+type placer struct {
+	defaultStrategy tenancyv1alpha1.WorkspacePlacementStrategy
+
+	getWorkspaceType func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+	listWorkspaces   func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.Workspace, error)
+}
+
+func (p *placer) choose(workspace *tenancyv1alpha1.Workspace, shards []*corev1alpha1.Shard) (*corev1alpha1.Shard, error) {
+	name := p.defaultStrategy
+	if wt, err := p.getWorkspaceType(logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name)); err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	} else if err == nil && wt.Spec.PlacementStrategy != "" {
+		name = wt.Spec.PlacementStrategy
+	}
+	strategy, found := placementStrategies[name]
+	if !found {
+		return nil, fmt.Errorf("unknown placement strategy %q", name)
+	}
+
+	workspaces, err := p.listWorkspaces(logicalcluster.From(workspace))
+	if err != nil {
+		return nil, err
+	}
+	siblings := make([]*tenancyv1alpha1.Workspace, 0, len(workspaces))
+	for _, sibling := range workspaces {
+		if sibling.Name != workspace.Name {
+			siblings = append(siblings, sibling)
+		}
+	}
+
+	return strategy.Choose(workspace, siblings, shards), nil
+}
+
+type randomPlacement struct{}
+
+func (randomPlacement) Choose(_ *tenancyv1alpha1.Workspace, _ []*tenancyv1alpha1.Workspace, shards []*corev1alpha1.Shard) *corev1alpha1.Shard {
+	return shards[mathrand.Intn(len(shards))]
+}
+
+type capacityWeightedPlacement struct{}
+
+func (capacityWeightedPlacement) Choose(workspace *tenancyv1alpha1.Workspace, siblings []*tenancyv1alpha1.Workspace, shards []*corev1alpha1.Shard) *corev1alpha1.Shard {
+	var total int64
+	weights := make([]int64, len(shards))
+	for i, shard := range shards {
+		if capacity, found := shard.Status.Capacity[corev1alpha1.ShardCapacityLogicalClusters]; found && capacity.Value() > 0 {
+			weights[i] = capacity.Value()
+			total += weights[i]
+		}
+	}
+	if total == 0 {
+		return randomPlacement{}.Choose(workspace, siblings, shards)
+	}
+
+	n := mathrand.Int63n(total)
+	for i, weight := range weights {
+		if n < weight {
+			return shards[i]
+		}
+		n -= weight
+	}
+	return shards[len(shards)-1]
+}
+
+type labelAffinityPlacement struct{}
+
+func (labelAffinityPlacement) Choose(workspace *tenancyv1alpha1.Workspace, siblings []*tenancyv1alpha1.Workspace, shards []*corev1alpha1.Shard) *corev1alpha1.Shard {
+	var best []*corev1alpha1.Shard
+	bestScore := -1
+	for _, shard := range shards {
+		score := 0
+		for k, v := range workspace.Labels {
+			if value, found := shard.Labels[k]; found && value == v {
+				score++
+			}
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore = []*corev1alpha1.Shard{shard}, score
+		case score == bestScore:
+			best = append(best, shard)
+		}
+	}
+	return randomPlacement{}.Choose(workspace, siblings, best)
+}
+
+type regionSpreadPlacement struct{}
+
+func (regionSpreadPlacement) Choose(workspace *tenancyv1alpha1.Workspace, siblings []*tenancyv1alpha1.Workspace, shards []*corev1alpha1.Shard) *corev1alpha1.Shard {
+	// the region label of workspaces is set from their shard during scheduling.
+	counts := map[string]int{}
+	for _, sibling := range siblings {
+		if region, found := sibling.Labels["region"]; found {
+			counts[region]++
+		}
+	}
+
+	var best []*corev1alpha1.Shard
+	bestCount := -1
+	for _, shard := range shards {
+		count := counts[shard.Labels["region"]]
+		switch {
+		case bestCount < 0 || count < bestCount:
+			best, bestCount = []*corev1alpha1.Shard{shard}, count
+		case count == bestCount:
+			best = append(best, shard)
+		}
+	}
+	return randomPlacement{}.Choose(workspace, siblings, best)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestPlacement(t *testing.T) {
+	newShard := func(name string, labels map[string]string, capacity int64) *corev1alpha1.Shard {
+		s := &corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		if capacity > 0 {
+			s.Status.Capacity = corev1.ResourceList{corev1alpha1.ShardCapacityLogicalClusters: *resource.NewQuantity(capacity, resource.DecimalSI)}
+		}
+		return s
+	}
+	newWorkspace := func(name string, labels map[string]string) *tenancyv1alpha1.Workspace {
+		return &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root"},
+			},
+			Spec: tenancyv1alpha1.WorkspaceSpec{
+				Type: tenancyv1alpha1.WorkspaceTypeReference{Name: "universal", Path: "root"},
+			},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name            string
+		defaultStrategy tenancyv1alpha1.WorkspacePlacementStrategy
+		typeStrategy    tenancyv1alpha1.WorkspacePlacementStrategy
+		workspace       *tenancyv1alpha1.Workspace
+		siblings        []*tenancyv1alpha1.Workspace
+		shards          []*corev1alpha1.Shard
+
+		wantShards []string
+		wantErr    bool
+	}{
+		{
+			name:            "random",
+			defaultStrategy: tenancyv1alpha1.WorkspacePlacementRandom,
+			workspace:       newWorkspace("test", nil),
+			shards:          []*corev1alpha1.Shard{newShard("a", nil, 0), newShard("b", nil, 0)},
+			wantShards:      []string{"a", "b"},
+		},
+		{
+			name:            "capacity weighted skips shards without capacity",
+			defaultStrategy: tenancyv1alpha1.WorkspacePlacementCapacityWeighted,
+			workspace:       newWorkspace("test", nil),
+			shards:          []*corev1alpha1.Shard{newShard("a", nil, 0), newShard("b", nil, 100)},
+			wantShards:      []string{"b"},
+		},
+		{
+			name:            "capacity weighted without any capacity",
+			defaultStrategy: tenancyv1alpha1.WorkspacePlacementCapacityWeighted,
+			workspace:       newWorkspace("test", nil),
+			shards:          []*corev1alpha1.Shard{newShard("a", nil, 0), newShard("b", nil, 0)},
+			wantShards:      []string{"a", "b"},
+		},
+		{
+			name:            "label affinity",
+			defaultStrategy: tenancyv1alpha1.WorkspacePlacementLabelAffinity,
+			workspace:       newWorkspace("test", map[string]string{"tier": "gold", "team": "x"}),
+			shards: []*corev1alpha1.Shard{
+				newShard("a", map[string]string{"tier": "silver"}, 0),
+				newShard("b", map[string]string{"tier": "gold"}, 0),
+				newShard("c", map[string]string{"tier": "gold", "team": "x"}, 0),
+			},
+			wantShards: []string{"c"},
+		},
+		{
+			name:            "region spread",
+			defaultStrategy: tenancyv1alpha1.WorkspacePlacementRegionSpread,
+			workspace:       newWorkspace("test", nil),
+			siblings: []*tenancyv1alpha1.Workspace{
+				newWorkspace("one", map[string]string{"region": "eu"}),
+				newWorkspace("two", map[string]string{"region": "eu"}),
+				newWorkspace("three", map[string]string{"region": "us"}),
+			},
+			shards: []*corev1alpha1.Shard{
+				newShard("eu-1", map[string]string{"region": "eu"}, 0),
+				newShard("us-1", map[string]string{"region": "us"}, 0),
+				newShard("us-2", map[string]string{"region": "us"}, 0),
+			},
+			wantShards: []string{"us-1", "us-2"},
+		},
+		{
+			name:            "type overrides default",
+			defaultStrategy: tenancyv1alpha1.WorkspacePlacementRandom,
+			typeStrategy:    tenancyv1alpha1.WorkspacePlacementCapacityWeighted,
+			workspace:       newWorkspace("test", nil),
+			shards:          []*corev1alpha1.Shard{newShard("a", nil, 0), newShard("b", nil, 100)},
+			wantShards:      []string{"b"},
+		},
+		{
+			name:            "unknown strategy",
+			defaultStrategy: "Unknown",
+			workspace:       newWorkspace("test", nil),
+			shards:          []*corev1alpha1.Shard{newShard("a", nil, 0)},
+			wantErr:         true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			p := &placer{
+				defaultStrategy: testCase.defaultStrategy,
+				getWorkspaceType: func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					if testCase.typeStrategy == "" {
+						return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetypes"), name)
+					}
+					return &tenancyv1alpha1.WorkspaceType{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec:       tenancyv1alpha1.WorkspaceTypeSpec{PlacementStrategy: testCase.typeStrategy},
+					}, nil
+				},
+				listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.Workspace, error) {
+					return append([]*tenancyv1alpha1.Workspace{testCase.workspace}, testCase.siblings...), nil
+				},
+			}
+
+			// strategies choose randomly among equally good shards, hence try a few times.
+			for i := 0; i < 20; i++ {
+				shard, err := p.choose(testCase.workspace, testCase.shards)
+				if testCase.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Contains(t, testCase.wantShards, shard.Name)
+			}
+		})
+	}
+}
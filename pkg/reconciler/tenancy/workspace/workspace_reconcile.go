@@ -27,7 +27,10 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	restclient "k8s.io/client-go/rest"
 
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
@@ -97,6 +100,33 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 		return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), c.globalWorkspaceTypeIndexer, path, name)
 	}
 
+	placer := &placer{
+		defaultStrategy:  c.defaultPlacementStrategy,
+		getWorkspaceType: getType,
+		listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.Workspace, error) {
+			return c.workspaceLister.Cluster(clusterName).List(labels.Everything())
+		},
+	}
+
+	getShardLogicalCluster := func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		if shard.Name == c.shardName {
+			return c.logicalClusterLister.Cluster(cluster).Get(corev1alpha1.LogicalClusterName)
+		}
+		client, err := kcpDirectClientFor(shard)
+		if err != nil {
+			return nil, err
+		}
+		return client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	}
+	patchShardLogicalCluster := func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, patch []byte) error {
+		client, err := kcpDirectClientFor(shard)
+		if err != nil {
+			return err
+		}
+		_, err = client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().Patch(ctx, corev1alpha1.LogicalClusterName, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	}
+
 	reconcilers := []reconciler{
 		&metaDataReconciler{},
 		&deletionReconciler{
@@ -106,6 +136,27 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 			deleteLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) error {
 				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Delete(ctx, corev1alpha1.LogicalClusterName, metav1.DeleteOptions{})
 			},
+			retainLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, until time.Time) error {
+				patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, tenancyv1alpha1.LogicalClusterRetainedUntilAnnotationKey, until.UTC().Format(time.RFC3339))
+				_, err := c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Patch(ctx, corev1alpha1.LogicalClusterName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+				return err
+			},
+			getWorkspaceType: getType,
+			restoreWorkspace: c.restoreWorkspace,
+			now:              time.Now,
+			requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {
+				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
+			},
+		},
+		&expiryReconciler{
+			deleteWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string, uid types.UID) error {
+				return c.kcpClusterClient.Cluster(cluster).TenancyV1alpha1().Workspaces().Delete(ctx, name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+			},
+			recordWarning: c.recordWarning,
+			now:           time.Now,
+			requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {
+				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
+			},
 		},
 		&schedulingReconciler{
 			generateClusterName: randomClusterName,
@@ -114,6 +165,8 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 			},
 			getShardByHash:   getShardByName,
 			listShards:       c.globalShardLister.List,
+			placer:           placer,
+			thresholds:       c.schedulingThresholds,
 			getWorkspaceType: getType,
 			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
 				return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
@@ -126,10 +179,104 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
 				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
 			},
+			getWorkspaceType: getType,
+			recordWarning:    c.recordWarning,
+			now:              time.Now,
 			requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {
 				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
 			},
 		},
+		&moveReconciler{
+			getLogicalClusterByPath: func(path logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+				return indexers.ByPathAndName[*corev1alpha1.LogicalCluster](corev1alpha1.Resource("logicalclusters"), c.logicalClusterIndexer, path, corev1alpha1.LogicalClusterName)
+			},
+			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+				return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+			},
+			getWorkspace: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.Workspace, error) {
+				return c.workspaceLister.Cluster(clusterName).Get(name)
+			},
+			createWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1alpha1.Workspace) error {
+				_, err := c.kcpClusterClient.Cluster(cluster).TenancyV1alpha1().Workspaces().Create(ctx, workspace, metav1.CreateOptions{})
+				return err
+			},
+			deleteWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string, uid types.UID) error {
+				return c.kcpClusterClient.Cluster(cluster).TenancyV1alpha1().Workspaces().Delete(ctx, name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+			},
+			requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {
+				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
+			},
+		},
+		&pathReconciler{
+			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+				return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+			},
+			updateLogicalClusterPath: func(ctx context.Context, cluster logicalcluster.Path, path logicalcluster.Path) error {
+				patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, core.LogicalClusterPathAnnotationKey, path.String())
+				_, err := c.kcpClusterClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Patch(ctx, corev1alpha1.LogicalClusterName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+				return err
+			},
+		},
+		&propagationReconciler{
+			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+				return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+			},
+			getShardByHash:         getShardByName,
+			getShardLogicalCluster: getShardLogicalCluster,
+			patchLogicalCluster:    patchShardLogicalCluster,
+		},
+		&auditPolicyReconciler{
+			getShardByHash:         getShardByName,
+			getShardLogicalCluster: getShardLogicalCluster,
+			patchLogicalCluster:    patchShardLogicalCluster,
+		},
+		&drainReconciler{
+			getShardByHash: getShardByName,
+			listShards:     c.globalShardLister.List,
+			placer:         placer,
+			thresholds:     c.schedulingThresholds,
+		},
+		&migrationReconciler{
+			getShard: func(name string) (*corev1alpha1.Shard, error) {
+				return c.globalShardLister.Cluster(core.RootCluster).Get(name)
+			},
+			getShardByHash: getShardByName,
+			setLogicalClusterPhase: func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, phase corev1alpha1.LogicalClusterPhaseType) error {
+				client, err := kcpDirectClientFor(shard)
+				if err != nil {
+					return err
+				}
+				logicalCluster, err := client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				if logicalCluster.Status.Phase == phase {
+					return nil
+				}
+				logicalCluster.Status.Phase = phase
+				_, err = client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().UpdateStatus(ctx, logicalCluster, metav1.UpdateOptions{})
+				return err
+			},
+			copyLogicalCluster: c.copyLogicalCluster,
+			deleteLogicalCluster: func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) error {
+				client, err := kcpDirectClientFor(shard)
+				if err != nil {
+					return err
+				}
+				logicalCluster, err := client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				// the owner workspace lives on, pointing to the target shard.
+				if logicalCluster.Spec.Owner != nil {
+					logicalCluster.Spec.Owner = nil
+					if _, err := client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().Update(ctx, logicalCluster, metav1.UpdateOptions{}); err != nil {
+						return err
+					}
+				}
+				return client.Cluster(cluster.Path()).CoreV1alpha1().LogicalClusters().Delete(ctx, corev1alpha1.LogicalClusterName, metav1.DeleteOptions{})
+			},
+		},
 	}
 
 	var errs []error
@@ -150,3 +297,42 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 
 	return requeue, utilserrors.NewAggregate(errs)
 }
+
+// restoreWorkspace replaces the given deleted workspace by a new workspace of the same name
+// that adopts the retained logical cluster. As the deletion of an object cannot be undone,
+// the finalizer of the deleted workspace is removed first, and then the new workspace is created
+// from its metadata and spec. If the second step fails, the logical cluster is left retained and the
+// workspace can be restored by creating it with the same annotations manually.
+func (c *Controller) restoreWorkspace(ctx context.Context, workspace *tenancyv1alpha1.Workspace) error {
+	cluster := logicalcluster.From(workspace).Path()
+
+	annotations := make(map[string]string, len(workspace.Annotations))
+	for k, v := range workspace.Annotations {
+		annotations[k] = v
+	}
+	delete(annotations, logicalcluster.AnnotationKey)
+	delete(annotations, tenancyv1alpha1.ExperimentalWorkspaceRestoreAnnotationKey)
+	annotations[tenancyv1alpha1.ExperimentalWorkspaceRestoredFromAnnotationKey] = string(workspace.UID)
+
+	restored := &tenancyv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        workspace.Name,
+			Labels:      workspace.Labels,
+			Annotations: annotations,
+		},
+		Spec: tenancyv1alpha1.WorkspaceSpec{
+			Type:     workspace.Spec.Type,
+			Location: workspace.Spec.Location,
+			Mount:    workspace.GetMount(),
+		},
+	}
+
+	deleted := workspace.DeepCopy()
+	deleted.Finalizers = sets.List[string](sets.New[string](deleted.Finalizers...).Delete(corev1alpha1.LogicalClusterFinalizer))
+	if _, err := c.kcpClusterClient.Cluster(cluster).TenancyV1alpha1().Workspaces().Update(ctx, deleted, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	_, err := c.kcpClusterClient.Cluster(cluster).TenancyV1alpha1().Workspaces().Create(ctx, restored, metav1.CreateOptions{})
+	return err
+}
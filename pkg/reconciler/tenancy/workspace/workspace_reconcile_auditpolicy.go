@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// auditPolicyReconciler copies the audit policy of the workspace into an annotation of its
+// LogicalCluster, where the audit backend of the shard serving the logical cluster picks it up.
+type auditPolicyReconciler struct {
+	getShardByHash func(hash string) (*corev1alpha1.Shard, error)
+
+	// getShardLogicalCluster returns the logical cluster on the given shard.
+	getShardLogicalCluster func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	// patchLogicalCluster applies the given merge patch to the logical cluster on the given shard.
+	patchLogicalCluster func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, patch []byte) error
+}
+
+func (r *auditPolicyReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "auditpolicy")
+
+	switch {
+	case !workspace.DeletionTimestamp.IsZero():
+		return reconcileStatusContinue, nil
+	case workspace.Status.Phase == "" || workspace.Status.Phase == corev1alpha1.LogicalClusterPhaseScheduling:
+		return reconcileStatusContinue, nil
+	case workspace.Spec.Cluster == "":
+		return reconcileStatusContinue, nil
+	}
+
+	var want string
+	if workspace.Spec.AuditPolicy != nil {
+		bs, err := json.Marshal(workspace.Spec.AuditPolicy)
+		if err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+		want = string(bs)
+	}
+
+	shard, err := r.getShardByHash(workspace.Annotations[WorkspaceShardHashAnnotationKey])
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	clusterName := logicalcluster.Name(workspace.Spec.Cluster)
+	logicalCluster, err := r.getShardLogicalCluster(ctx, shard, clusterName)
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if owner := logicalCluster.Spec.Owner; owner == nil || owner.UID != workspace.UID {
+		return reconcileStatusContinue, nil // e.g. adopted by the target of a move
+	}
+	if logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterAuditPolicyAnnotationKey] == want {
+		return reconcileStatusContinue, nil
+	}
+
+	var value interface{}
+	if want != "" {
+		value = want
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				tenancyv1alpha1.LogicalClusterAuditPolicyAnnotationKey: value,
+			},
+		},
+	})
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	logger.V(2).Info("Updating audit policy of LogicalCluster", "cluster", clusterName, "shard", shard.Name)
+	if err := r.patchLogicalCluster(ctx, shard, clusterName, patch); err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	return reconcileStatusContinue, nil
+}
@@ -19,20 +19,33 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 type deletionReconciler struct {
 	getLogicalCluster    func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
 	deleteLogicalCluster func(ctx context.Context, cluster logicalcluster.Path) error
+	retainLogicalCluster func(ctx context.Context, cluster logicalcluster.Path, until time.Time) error
+
+	getWorkspaceType func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+
+	// restoreWorkspace replaces the deleted workspace by a new one adopting its logical cluster.
+	restoreWorkspace func(ctx context.Context, workspace *tenancyv1alpha1.Workspace) error
+
+	now          func() time.Time
+	requeueAfter func(workspace *tenancyv1alpha1.Workspace, after time.Duration)
 }
 
 func (r *deletionReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
@@ -75,7 +88,45 @@ func (r *deletionReconciler) reconcile(ctx context.Context, workspace *tenancyv1
 		return reconcileStatusContinue, nil
 	}
 
+	if owner := logicalCluster.Spec.Owner; owner != nil && owner.UID != workspace.UID {
+		// the logical cluster has been adopted by another workspace, e.g. after a move.
+		logger.Info(fmt.Sprintf("Removing finalizer %s of workspace not owning the LogicalCluster", corev1alpha1.LogicalClusterFinalizer))
+		workspace.Finalizers = sets.List[string](sets.New[string](workspace.Finalizers...).Delete(corev1alpha1.LogicalClusterFinalizer))
+		return reconcileStatusStopAndRequeue, nil // spec change
+	}
+
 	if logicalCluster.DeletionTimestamp.IsZero() {
+		retainUntil, retained, err := r.retainedUntil(workspace)
+		if err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+		if retained && r.now().Before(retainUntil) {
+			if workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceRestoreAnnotationKey] == "true" {
+				logger.Info("Restoring workspace")
+				if err := r.restoreWorkspace(ctx, workspace); err != nil {
+					return reconcileStatusStopAndRequeue, err
+				}
+				return reconcileStatusStopAndRequeue, nil
+			}
+
+			if value := retainUntil.UTC().Format(time.RFC3339); logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterRetainedUntilAnnotationKey] != value {
+				logger.Info("Retaining LogicalCluster", "until", value)
+				if err := r.retainLogicalCluster(ctx, clusterName.Path(), retainUntil); err != nil {
+					return reconcileStatusStopAndRequeue, err
+				}
+			}
+			conditions.Set(workspace, &conditionsv1alpha1.Condition{
+				Type:    tenancyv1alpha1.WorkspaceDeletionRetained,
+				Status:  corev1.ConditionTrue,
+				Message: fmt.Sprintf("Workspace can be restored until %s", retainUntil.UTC().Format(time.RFC3339)),
+			})
+			r.requeueAfter(workspace, retainUntil.Sub(r.now()))
+			return reconcileStatusContinue, nil
+		}
+		if retained {
+			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceDeletionRetained, tenancyv1alpha1.WorkspaceRetentionPeriodExpiredReason, conditionsv1alpha1.ConditionSeverityInfo, "Retention period expired")
+		}
+
 		logger.Info("Deleting LogicalCluster")
 		if err := r.deleteLogicalCluster(ctx, clusterName.Path()); err != nil {
 			return reconcileStatusStopAndRequeue, err
@@ -86,3 +137,21 @@ func (r *deletionReconciler) reconcile(ctx context.Context, workspace *tenancyv1
 
 	return reconcileStatusContinue, nil
 }
+
+// retainedUntil returns the end of the retention period of the deleted workspace, and
+// whether the WorkspaceType of the workspace has a retention policy at all.
+func (r *deletionReconciler) retainedUntil(workspace *tenancyv1alpha1.Workspace) (time.Time, bool, error) {
+	wt, err := r.getWorkspaceType(logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
+	if apierrors.IsNotFound(err) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if wt.Spec.RetentionPolicy == nil {
+		return time.Time{}, false, nil
+	}
+
+	retention := time.Duration(wt.Spec.RetentionPolicy.Days) * 24 * time.Hour
+	return workspace.DeletionTimestamp.Add(retention), true, nil
+}
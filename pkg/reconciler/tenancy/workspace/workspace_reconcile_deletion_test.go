@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileDeletionRetention(t *testing.T) {
+	deleted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		name        string
+		retention   *tenancyv1alpha1.WorkspaceRetentionPolicy
+		now         time.Time
+		annotations map[string]string
+
+		wantStatus    reconcileStatus
+		wantDeleted   bool
+		wantRetained  bool
+		wantRestored  bool
+		wantCondition *bool
+	}{
+		{
+			name:        "no retention policy deletes the logical cluster",
+			now:         deleted.Add(time.Hour),
+			wantStatus:  reconcileStatusContinue,
+			wantDeleted: true,
+		},
+		{
+			name:          "within retention period retains the logical cluster",
+			retention:     &tenancyv1alpha1.WorkspaceRetentionPolicy{Days: 2},
+			now:           deleted.Add(time.Hour),
+			wantStatus:    reconcileStatusContinue,
+			wantRetained:  true,
+			wantCondition: ptr.To(true),
+		},
+		{
+			name:         "within retention period with restore annotation restores the workspace",
+			retention:    &tenancyv1alpha1.WorkspaceRetentionPolicy{Days: 2},
+			now:          deleted.Add(time.Hour),
+			annotations:  map[string]string{tenancyv1alpha1.ExperimentalWorkspaceRestoreAnnotationKey: "true"},
+			wantStatus:   reconcileStatusStopAndRequeue,
+			wantRestored: true,
+		},
+		{
+			name:          "after retention period deletes the logical cluster",
+			retention:     &tenancyv1alpha1.WorkspaceRetentionPolicy{Days: 2},
+			now:           deleted.Add(72 * time.Hour),
+			annotations:   map[string]string{tenancyv1alpha1.ExperimentalWorkspaceRestoreAnnotationKey: "true"},
+			wantStatus:    reconcileStatusContinue,
+			wantDeleted:   true,
+			wantCondition: ptr.To(false),
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var deletedLC, retainedLC, restored bool
+			r := &deletionReconciler{
+				getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					return &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName}}, nil
+				},
+				deleteLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) error {
+					deletedLC = true
+					return nil
+				},
+				retainLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, until time.Time) error {
+					require.Equal(t, deleted.Add(48*time.Hour), until)
+					retainedLC = true
+					return nil
+				},
+				getWorkspaceType: func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					return &tenancyv1alpha1.WorkspaceType{
+						Spec: tenancyv1alpha1.WorkspaceTypeSpec{RetentionPolicy: testCase.retention},
+					}, nil
+				},
+				restoreWorkspace: func(ctx context.Context, workspace *tenancyv1alpha1.Workspace) error {
+					restored = true
+					return nil
+				},
+				now:          func() time.Time { return testCase.now },
+				requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					DeletionTimestamp: &metav1.Time{Time: deleted},
+					Finalizers:        []string{corev1alpha1.LogicalClusterFinalizer},
+					Annotations:       testCase.annotations,
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:    tenancyv1alpha1.WorkspaceTypeReference{Name: "universal", Path: "root"},
+					Cluster: "somecluster",
+				},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantDeleted, deletedLC, "deleted")
+			require.Equal(t, testCase.wantRetained, retainedLC, "retained")
+			require.Equal(t, testCase.wantRestored, restored, "restored")
+			if testCase.wantCondition == nil {
+				require.Nil(t, conditions.Get(ws, tenancyv1alpha1.WorkspaceDeletionRetained))
+			} else {
+				require.Equal(t, *testCase.wantCondition, conditions.IsTrue(ws, tenancyv1alpha1.WorkspaceDeletionRetained))
+			}
+		})
+	}
+}
+
+func TestReconcileDeletionAdoptedLogicalCluster(t *testing.T) {
+	r := &deletionReconciler{
+		getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+			return &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+				Spec: corev1alpha1.LogicalClusterSpec{
+					Owner: &corev1alpha1.LogicalClusterOwner{Name: "moved", Cluster: "other", UID: "target-uid"},
+				},
+			}, nil
+		},
+		deleteLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) error {
+			require.Fail(t, "logical cluster of a moved workspace must not be deleted")
+			return nil
+		},
+	}
+
+	ws := &tenancyv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			UID:               "source-uid",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{corev1alpha1.LogicalClusterFinalizer},
+		},
+		Spec:   tenancyv1alpha1.WorkspaceSpec{Cluster: "somecluster"},
+		Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+	}
+
+	status, err := r.reconcile(context.Background(), ws)
+	require.NoError(t, err)
+	require.Equal(t, reconcileStatusStopAndRequeue, status)
+	require.Empty(t, ws.Finalizers)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// drainReconciler moves workspaces away from draining shards. It places the workspace on another
+// schedulable shard matching its location and sets the migrate-to-shard annotation,
+// leaving the actual migration to the migrationReconciler.
+type drainReconciler struct {
+	getShardByHash func(hash string) (*corev1alpha1.Shard, error)
+	listShards     func(selector labels.Selector) ([]*corev1alpha1.Shard, error)
+	placer         *placer
+	thresholds     SchedulingThresholds
+}
+
+func (r *drainReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	switch {
+	case !workspace.DeletionTimestamp.IsZero():
+		return reconcileStatusContinue, nil
+	case workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady:
+		return reconcileStatusContinue, nil
+	case workspace.GetMount() != nil:
+		return reconcileStatusContinue, nil
+	}
+	if _, found := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey]; found {
+		return reconcileStatusContinue, nil
+	}
+	if _, migrating := workspace.Annotations[workspaceMigratingFromShardAnnotationKey]; migrating {
+		return reconcileStatusContinue, nil
+	}
+	hash, found := workspace.Annotations[WorkspaceShardHashAnnotationKey]
+	if !found {
+		return reconcileStatusContinue, nil
+	}
+
+	shard, err := r.getShardByHash(hash)
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if !shard.Spec.Drain {
+		return reconcileStatusContinue, nil
+	}
+
+	logger := klog.FromContext(ctx).WithValues("reconciler", "drain", "shard", shard.Name)
+
+	selector := labels.Everything()
+	if workspace.Spec.Location != nil && workspace.Spec.Location.Selector != nil {
+		if selector, err = metav1.LabelSelectorAsSelector(workspace.Spec.Location.Selector); err != nil {
+			logger.Error(err, "cannot drain workspace with an invalid location selector")
+			return reconcileStatusContinue, nil
+		}
+	}
+	shards, err := r.listShards(selector)
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	candidates := make([]*corev1alpha1.Shard, 0, len(shards))
+	for _, candidate := range shards {
+		if candidate.Name == shard.Name || !candidate.IsSchedulable() {
+			continue
+		}
+		if _, ok := candidate.Annotations[unschedulableAnnotationKey]; ok {
+			continue
+		}
+		if _, exceeded := r.thresholds.exceededBy(candidate); exceeded {
+			continue
+		}
+		if valid, _, _ := isValidShard(candidate); valid {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		logger.V(2).Info("No shard available to drain workspace to")
+		return reconcileStatusContinue, nil // retry is automatic when shards change
+	}
+
+	target, err := r.placer.choose(workspace, candidates)
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	logger.Info("Migrating workspace away from draining shard", "target", target.Name)
+	workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey] = target.Name
+	return reconcileStatusStopAndRequeue, nil
+}
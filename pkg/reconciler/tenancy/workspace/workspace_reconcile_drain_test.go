@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcileDrain(t *testing.T) {
+	newShard := func(name string, drain, cordoned bool, region string) *corev1alpha1.Shard {
+		return &corev1alpha1.Shard{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"region": region}},
+			Spec:       corev1alpha1.ShardSpec{Drain: drain, Cordoned: cordoned},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name        string
+		shards      []*corev1alpha1.Shard
+		annotations map[string]string
+		location    *tenancyv1alpha1.WorkspaceLocation
+
+		wantStatus reconcileStatus
+		wantTarget string
+	}{
+		{
+			name:       "shard not draining",
+			shards:     []*corev1alpha1.Shard{newShard("source", false, false, "eu"), newShard("target", false, false, "eu")},
+			wantStatus: reconcileStatusContinue,
+		},
+		{
+			name:       "draining shard",
+			shards:     []*corev1alpha1.Shard{newShard("source", true, false, "eu"), newShard("target", false, false, "eu")},
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantTarget: "target",
+		},
+		{
+			name:       "cordoned shards are skipped",
+			shards:     []*corev1alpha1.Shard{newShard("source", true, false, "eu"), newShard("cordoned", false, true, "eu"), newShard("draining", true, false, "eu")},
+			wantStatus: reconcileStatusContinue,
+		},
+		{
+			name:       "location is respected",
+			shards:     []*corev1alpha1.Shard{newShard("source", true, false, "eu"), newShard("us", false, false, "us"), newShard("eu", false, false, "eu")},
+			location:   &tenancyv1alpha1.WorkspaceLocation{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}}},
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantTarget: "eu",
+		},
+		{
+			name:   "already migrating",
+			shards: []*corev1alpha1.Shard{newShard("source", true, false, "eu"), newShard("target", false, false, "eu")},
+			annotations: map[string]string{
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "other",
+			},
+			wantStatus: reconcileStatusContinue,
+			wantTarget: "other",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &drainReconciler{
+				getShardByHash: func(hash string) (*corev1alpha1.Shard, error) {
+					for _, shard := range testCase.shards {
+						if ByBase36Sha224NameValue(shard.Name) == hash {
+							return shard, nil
+						}
+					}
+					return nil, apierrors.NewNotFound(corev1alpha1.Resource("shards"), hash)
+				},
+				listShards: func(selector labels.Selector) ([]*corev1alpha1.Shard, error) {
+					var shards []*corev1alpha1.Shard
+					for _, shard := range testCase.shards {
+						if selector.Matches(labels.Set(shard.Labels)) {
+							shards = append(shards, shard)
+						}
+					}
+					return shards, nil
+				},
+				placer: &placer{
+					defaultStrategy: tenancyv1alpha1.WorkspacePlacementRandom,
+					getWorkspaceType: func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+						return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetypes"), name)
+					},
+					listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.Workspace, error) {
+						return nil, nil
+					},
+				},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{WorkspaceShardHashAnnotationKey: ByBase36Sha224NameValue("source")},
+				},
+				Spec:   tenancyv1alpha1.WorkspaceSpec{Location: testCase.location},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+			}
+			for k, v := range testCase.annotations {
+				ws.Annotations[k] = v
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantTarget, ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey])
+		})
+	}
+}
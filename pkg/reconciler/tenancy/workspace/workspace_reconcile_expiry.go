@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// expiryWarningPeriod is how long before the expiry of a workspace a warning is emitted.
+// For short ttls, the warning is emitted after half of the ttl.
+const expiryWarningPeriod = 24 * time.Hour
+
+// expiryReconciler deletes workspaces whose spec.ttl passed, and warns about it before.
+type expiryReconciler struct {
+	deleteWorkspace func(ctx context.Context, cluster logicalcluster.Path, name string, uid types.UID) error
+
+	// recordWarning emits a warning event for the given workspace.
+	recordWarning func(ctx context.Context, workspace *tenancyv1alpha1.Workspace, reason, message string)
+
+	now          func() time.Time
+	requeueAfter func(workspace *tenancyv1alpha1.Workspace, after time.Duration)
+}
+
+func (r *expiryReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	if !workspace.DeletionTimestamp.IsZero() {
+		return reconcileStatusContinue, nil
+	}
+	if workspace.Spec.TTL == nil {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceExpiring)
+		return reconcileStatusContinue, nil
+	}
+
+	logger := klog.FromContext(ctx).WithValues("reconciler", "expiry")
+
+	ttl := workspace.Spec.TTL.Duration
+	expiry := workspace.CreationTimestamp.Add(ttl)
+	warningPeriod := expiryWarningPeriod
+	if ttl/2 < warningPeriod {
+		warningPeriod = ttl / 2
+	}
+
+	now := r.now()
+	if !now.Before(expiry) {
+		logger.Info("Workspace expired, deleting", "ttl", ttl)
+		r.recordWarning(ctx, workspace, tenancyv1alpha1.WorkspaceTTLExpiredReason, fmt.Sprintf("Workspace expired after %s and is being deleted", ttl))
+		if err := r.deleteWorkspace(ctx, logicalcluster.From(workspace).Path(), workspace.Name, workspace.UID); err != nil && !apierrors.IsNotFound(err) {
+			return reconcileStatusStopAndRequeue, err
+		}
+		return reconcileStatusContinue, nil
+	}
+
+	if warning := expiry.Add(-warningPeriod); now.Before(warning) {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceExpiring)
+		r.requeueAfter(workspace, warning.Sub(now))
+		return reconcileStatusContinue, nil
+	}
+
+	message := fmt.Sprintf("Workspace expires at %s", expiry.UTC().Format(time.RFC3339))
+	if !conditions.IsTrue(workspace, tenancyv1alpha1.WorkspaceExpiring) || conditions.GetMessage(workspace, tenancyv1alpha1.WorkspaceExpiring) != message {
+		logger.V(2).Info("Workspace expires soon", "expiry", expiry)
+		r.recordWarning(ctx, workspace, tenancyv1alpha1.WorkspaceTTLExpiringReason, message)
+	}
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:    tenancyv1alpha1.WorkspaceExpiring,
+		Status:  corev1.ConditionTrue,
+		Reason:  tenancyv1alpha1.WorkspaceTTLExpiringReason,
+		Message: message,
+	})
+	r.requeueAfter(workspace, expiry.Sub(now))
+
+	return reconcileStatusContinue, nil
+}
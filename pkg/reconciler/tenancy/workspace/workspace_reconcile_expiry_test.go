@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileExpiry(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		name     string
+		ttl      *metav1.Duration
+		now      time.Time
+		expiring bool
+
+		wantDeleted      bool
+		wantWarnings     []string
+		wantCondition    bool
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name: "no ttl",
+			now:  created.Add(time.Hour),
+		},
+		{
+			name:             "long before expiry",
+			ttl:              &metav1.Duration{Duration: 72 * time.Hour},
+			now:              created.Add(time.Hour),
+			wantRequeueAfter: 47 * time.Hour,
+		},
+		{
+			name:             "within warning period",
+			ttl:              &metav1.Duration{Duration: 72 * time.Hour},
+			now:              created.Add(60 * time.Hour),
+			wantWarnings:     []string{tenancyv1alpha1.WorkspaceTTLExpiringReason},
+			wantCondition:    true,
+			wantRequeueAfter: 12 * time.Hour,
+		},
+		{
+			name:             "within warning period after a warning",
+			ttl:              &metav1.Duration{Duration: 72 * time.Hour},
+			now:              created.Add(60 * time.Hour),
+			expiring:         true,
+			wantCondition:    true,
+			wantRequeueAfter: 12 * time.Hour,
+		},
+		{
+			name:             "short ttl warns after half of it",
+			ttl:              &metav1.Duration{Duration: 2 * time.Hour},
+			now:              created.Add(90 * time.Minute),
+			wantWarnings:     []string{tenancyv1alpha1.WorkspaceTTLExpiringReason},
+			wantCondition:    true,
+			wantRequeueAfter: 30 * time.Minute,
+		},
+		{
+			name:         "expired",
+			ttl:          &metav1.Duration{Duration: 2 * time.Hour},
+			now:          created.Add(2 * time.Hour),
+			expiring:     true,
+			wantDeleted:  true,
+			wantWarnings: []string{tenancyv1alpha1.WorkspaceTTLExpiredReason},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var deleted bool
+			var warnings []string
+			var requeueAfter time.Duration
+			r := &expiryReconciler{
+				deleteWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string, uid types.UID) error {
+					require.Equal(t, "root:org", cluster.String())
+					require.Equal(t, "test", name)
+					deleted = true
+					return nil
+				},
+				recordWarning: func(ctx context.Context, workspace *tenancyv1alpha1.Workspace, reason, message string) {
+					warnings = append(warnings, reason)
+				},
+				now: func() time.Time { return testCase.now },
+				requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {
+					requeueAfter = after
+				},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					CreationTimestamp: metav1.Time{Time: created},
+					Annotations:       map[string]string{logicalcluster.AnnotationKey: "root:org"},
+				},
+				Spec:   tenancyv1alpha1.WorkspaceSpec{TTL: testCase.ttl},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+			}
+			if testCase.expiring {
+				expiry := created.Add(testCase.ttl.Duration)
+				ws.Status.Conditions = append(ws.Status.Conditions, *conditions.TrueCondition(tenancyv1alpha1.WorkspaceExpiring))
+				ws.Status.Conditions[0].Message = "Workspace expires at " + expiry.UTC().Format(time.RFC3339)
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.wantDeleted, deleted, "deleted")
+			require.Equal(t, testCase.wantWarnings, warnings, "warnings")
+			require.Equal(t, testCase.wantRequeueAfter, requeueAfter, "requeueAfter")
+			if !testCase.wantDeleted {
+				require.Equal(t, testCase.wantCondition, conditions.IsTrue(ws, tenancyv1alpha1.WorkspaceExpiring))
+			}
+		})
+	}
+}
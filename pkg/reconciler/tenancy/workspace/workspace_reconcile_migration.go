@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+const (
+	// workspaceMigratingFromShardAnnotationKey keeps track of the shard a workspace is migrated from.
+	// The value is a base36(sha224) hash of the Shard name.
+	workspaceMigratingFromShardAnnotationKey = "internal.tenancy.kcp.io/migrating-from-shard"
+
+	// workspaceMigratingToShardAnnotationKey keeps track of the shard a workspace is migrated to.
+	// The value is a base36(sha224) hash of the Shard name.
+	workspaceMigratingToShardAnnotationKey = "internal.tenancy.kcp.io/migrating-to-shard"
+)
+
+// migrationReconciler migrates the logical cluster of a workspace to the shard in its
+// migrate-to-shard annotation. The migration is done in these steps:
+//
+//  1. the source and the target shard are recorded in annotations.
+//  2. the workspace becomes unavailable.
+//  3. the LogicalCluster on the source shard becomes unavailable, quiescing all writes of users.
+//  4. the LogicalCluster, still unavailable, and all its objects are copied to the target shard.
+//  5. the workspace is switched to the target shard.
+//  6. the LogicalCluster on the target shard becomes ready, which switches the index of the
+//     front-proxy to the target shard, the one on the source shard is deleted, and the annotations
+//     of the migration are removed.
+//  7. the workspace becomes ready again.
+//
+// The metadata and spec of the workspace, and its status, are changed in separate rounds, as they
+// cannot be updated together. All steps are idempotent, i.e. failures are retried from the last completed step. Once started,
+// a migration cannot be canceled, and changes of the migrate-to-shard annotation are ignored
+// until it is finished.
+type migrationReconciler struct {
+	getShard       func(name string) (*corev1alpha1.Shard, error)
+	getShardByHash func(hash string) (*corev1alpha1.Shard, error)
+
+	// setLogicalClusterPhase updates the phase of the logical cluster on the given shard.
+	setLogicalClusterPhase func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, phase corev1alpha1.LogicalClusterPhaseType) error
+	// copyLogicalCluster copies the unavailable logical cluster and all its objects from one shard to another.
+	copyLogicalCluster func(ctx context.Context, from, to *corev1alpha1.Shard, cluster logicalcluster.Name) error
+	// deleteLogicalCluster deletes the logical cluster with all its objects on the given shard, without
+	// deleting its owner.
+	deleteLogicalCluster func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) error
+}
+
+func (r *migrationReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "migration")
+
+	targetShardName, found := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey]
+	sourceHash, migrating := workspace.Annotations[workspaceMigratingFromShardAnnotationKey]
+	if !migrating && conditions.IsTrue(workspace, tenancyv1alpha1.WorkspaceMigrating) {
+		// step 7: the annotations are removed, make the workspace available again.
+		logger.Info("Finished migration of workspace")
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceMigrating)
+		workspace.Status.Phase = corev1alpha1.LogicalClusterPhaseReady
+		return reconcileStatusStopAndRequeue, nil
+	}
+	switch {
+	case !found && !migrating:
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceMigrating)
+		return reconcileStatusContinue, nil
+	case !workspace.DeletionTimestamp.IsZero():
+		return reconcileStatusContinue, nil
+	case workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseUnavailable:
+		return reconcileStatusContinue, nil
+	case workspace.GetMount() != nil:
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMigrating, tenancyv1alpha1.WorkspaceMigrationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "Mounted workspaces cannot be migrated")
+		return reconcileStatusContinue, nil
+	}
+	clusterName := logicalcluster.Name(workspace.Spec.Cluster)
+	currentHash := workspace.Annotations[WorkspaceShardHashAnnotationKey]
+
+	if !migrating {
+		targetShard, err := r.getShard(targetShardName)
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMigrating, tenancyv1alpha1.WorkspaceMigrationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "Shard %s not found", targetShardName)
+			return reconcileStatusContinue, nil
+		} else if err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+		targetHash := ByBase36Sha224NameValue(targetShard.Name)
+		if currentHash == targetHash {
+			logger.V(2).Info("workspace is already on the target shard", "shard", targetShard.Name)
+			// the condition, if any, is removed in the next round.
+			delete(workspace.Annotations, tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey)
+			return reconcileStatusStopAndRequeue, nil
+		}
+		if !targetShard.IsSchedulable() {
+			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMigrating, tenancyv1alpha1.WorkspaceMigrationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "Shard %s is cordoned", targetShardName)
+			return reconcileStatusContinue, nil
+		}
+		if workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+			return reconcileStatusContinue, nil
+		}
+
+		// step 1: record where we come from and where we go. The status is updated in the next round.
+		logger.Info("Starting migration of workspace", "cluster", clusterName, "shard", targetShard.Name)
+		workspace.Annotations[workspaceMigratingFromShardAnnotationKey] = currentHash
+		workspace.Annotations[workspaceMigratingToShardAnnotationKey] = targetHash
+		return reconcileStatusStopAndRequeue, nil
+	}
+
+	targetShard, err := r.getShardByHash(workspace.Annotations[workspaceMigratingToShardAnnotationKey])
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	targetHash := ByBase36Sha224NameValue(targetShard.Name)
+	logger = logger.WithValues("cluster", clusterName, "shard", targetShard.Name)
+
+	sourceShard, err := r.getShardByHash(sourceHash)
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	if currentHash != targetHash {
+		u, err := url.Parse(targetShard.Spec.ExternalURL)
+		if err != nil {
+			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMigrating, tenancyv1alpha1.WorkspaceMigrationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "Invalid connection information on target Shard: %v.", err)
+			return reconcileStatusContinue, nil
+		}
+		i := strings.LastIndex(workspace.Spec.URL, "/clusters/")
+		if i < 0 {
+			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMigrating, tenancyv1alpha1.WorkspaceMigrationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "Invalid workspace URL %q", workspace.Spec.URL)
+			return reconcileStatusContinue, nil
+		}
+		u.Path = path.Join(u.Path, workspace.Spec.URL[i:])
+
+		// step 2: make the workspace unavailable. The copying starts in the next round.
+		if workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseUnavailable || !conditions.IsTrue(workspace, tenancyv1alpha1.WorkspaceMigrating) {
+			workspace.Status.Phase = corev1alpha1.LogicalClusterPhaseUnavailable
+			setMigrating(workspace, "Copying logical cluster from shard %s to shard %s", sourceShard.Name, targetShard.Name)
+			return reconcileStatusStopAndRequeue, nil
+		}
+
+		// step 3: quiesce writes on the source shard.
+		if err := r.setLogicalClusterPhase(ctx, sourceShard, clusterName, corev1alpha1.LogicalClusterPhaseUnavailable); err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+
+		// step 4: copy everything.
+		if err := r.copyLogicalCluster(ctx, sourceShard, targetShard, clusterName); err != nil {
+			setMigrating(workspace, "Failed to copy logical cluster from shard %s to shard %s: %v", sourceShard.Name, targetShard.Name, err)
+			return reconcileStatusStopAndRequeue, err
+		}
+
+		// step 5: switch the workspace to the target shard.
+		logger.Info("Switching workspace to target shard")
+		workspace.Annotations[WorkspaceShardHashAnnotationKey] = targetHash
+		workspace.Spec.URL = u.String()
+		return reconcileStatusStopAndRequeue, nil
+	}
+
+	// step 6: activate the target, and clean up the source. The status is updated in the next round.
+	if err := r.setLogicalClusterPhase(ctx, targetShard, clusterName, corev1alpha1.LogicalClusterPhaseReady); err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	logger.Info("Deleting logical cluster on source shard", "source", sourceShard.Name)
+	if err := r.deleteLogicalCluster(ctx, sourceShard, clusterName); err != nil && !apierrors.IsNotFound(err) {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	delete(workspace.Annotations, workspaceMigratingFromShardAnnotationKey)
+	delete(workspace.Annotations, workspaceMigratingToShardAnnotationKey)
+	if workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey] == targetShard.Name {
+		delete(workspace.Annotations, tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey)
+	}
+	return reconcileStatusStopAndRequeue, nil
+}
+
+func setMigrating(workspace *tenancyv1alpha1.Workspace, messageFormat string, messageArgs ...interface{}) {
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:    tenancyv1alpha1.WorkspaceMigrating,
+		Status:  corev1.ConditionTrue,
+		Message: fmt.Sprintf(messageFormat, messageArgs...),
+	})
+}
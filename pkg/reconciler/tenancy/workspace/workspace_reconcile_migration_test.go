@@ -0,0 +1,342 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileMigration(t *testing.T) {
+	sourceHash := ByBase36Sha224NameValue("source")
+	targetHash := ByBase36Sha224NameValue("target")
+
+	for _, testCase := range []struct {
+		name        string
+		annotations map[string]string
+		phase       corev1alpha1.LogicalClusterPhaseType
+		migrating   bool
+		mounted     bool
+		copyErr     error
+
+		wantStatus      reconcileStatus
+		wantErr         bool
+		wantAnnotations map[string]string
+		wantPhase       corev1alpha1.LogicalClusterPhaseType
+		wantURL         string
+		wantPhases      map[string]corev1alpha1.LogicalClusterPhaseType
+		wantCopied      bool
+		wantDeleted     string
+		wantCondition   *bool
+		wantReason      string
+	}{
+		{
+			name:            "no migration",
+			annotations:     map[string]string{WorkspaceShardHashAnnotationKey: sourceHash},
+			wantStatus:      reconcileStatusContinue,
+			wantAnnotations: map[string]string{WorkspaceShardHashAnnotationKey: sourceHash},
+			wantPhase:       corev1alpha1.LogicalClusterPhaseReady,
+			wantURL:         "https://source.example.com/clusters/root:org:team",
+		},
+		{
+			name: "target shard not found",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "unknown",
+			},
+			wantStatus: reconcileStatusContinue,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "unknown",
+			},
+			wantPhase:     corev1alpha1.LogicalClusterPhaseReady,
+			wantURL:       "https://source.example.com/clusters/root:org:team",
+			wantCondition: ptr.To(false),
+			wantReason:    tenancyv1alpha1.WorkspaceMigrationInvalidReason,
+		},
+		{
+			name: "mounted workspace",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "target",
+			},
+			mounted:    true,
+			wantStatus: reconcileStatusContinue,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "target",
+			},
+			wantPhase:     corev1alpha1.LogicalClusterPhaseReady,
+			wantURL:       "https://source.example.com/clusters/root:org:team",
+			wantCondition: ptr.To(false),
+			wantReason:    tenancyv1alpha1.WorkspaceMigrationInvalidReason,
+		},
+		{
+			name: "already on the target shard",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "source",
+			},
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{WorkspaceShardHashAnnotationKey: sourceHash},
+			wantPhase:       corev1alpha1.LogicalClusterPhaseReady,
+			wantURL:         "https://source.example.com/clusters/root:org:team",
+		},
+		{
+			name: "starts the migration",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "target",
+			},
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  sourceHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "target",
+				workspaceMigratingFromShardAnnotationKey:                         sourceHash,
+				workspaceMigratingToShardAnnotationKey:                           targetHash,
+			},
+			wantPhase: corev1alpha1.LogicalClusterPhaseReady,
+			wantURL:   "https://source.example.com/clusters/root:org:team",
+		},
+		{
+			name: "makes the workspace unavailable",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:          sourceHash,
+				workspaceMigratingFromShardAnnotationKey: sourceHash,
+				workspaceMigratingToShardAnnotationKey:   targetHash,
+			},
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:          sourceHash,
+				workspaceMigratingFromShardAnnotationKey: sourceHash,
+				workspaceMigratingToShardAnnotationKey:   targetHash,
+			},
+			wantPhase:     corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantURL:       "https://source.example.com/clusters/root:org:team",
+			wantCondition: ptr.To(true),
+		},
+		{
+			name: "copies and switches to the target shard",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:          sourceHash,
+				workspaceMigratingFromShardAnnotationKey: sourceHash,
+				workspaceMigratingToShardAnnotationKey:   targetHash,
+			},
+			phase:      corev1alpha1.LogicalClusterPhaseUnavailable,
+			migrating:  true,
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:          targetHash,
+				workspaceMigratingFromShardAnnotationKey: sourceHash,
+				workspaceMigratingToShardAnnotationKey:   targetHash,
+			},
+			wantPhase:     corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantURL:       "https://target.example.com/clusters/root:org:team",
+			wantPhases:    map[string]corev1alpha1.LogicalClusterPhaseType{"source": corev1alpha1.LogicalClusterPhaseUnavailable},
+			wantCopied:    true,
+			wantCondition: ptr.To(true),
+		},
+		{
+			name: "copying fails",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:          sourceHash,
+				workspaceMigratingFromShardAnnotationKey: sourceHash,
+				workspaceMigratingToShardAnnotationKey:   targetHash,
+			},
+			phase:      corev1alpha1.LogicalClusterPhaseUnavailable,
+			migrating:  true,
+			copyErr:    errors.New("boom"),
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantErr:    true,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:          sourceHash,
+				workspaceMigratingFromShardAnnotationKey: sourceHash,
+				workspaceMigratingToShardAnnotationKey:   targetHash,
+			},
+			wantPhase:     corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantURL:       "https://source.example.com/clusters/root:org:team",
+			wantPhases:    map[string]corev1alpha1.LogicalClusterPhaseType{"source": corev1alpha1.LogicalClusterPhaseUnavailable},
+			wantCopied:    true,
+			wantCondition: ptr.To(true),
+		},
+		{
+			name: "activates the target and deletes the source",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  targetHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "target",
+				workspaceMigratingFromShardAnnotationKey:                         sourceHash,
+				workspaceMigratingToShardAnnotationKey:                           targetHash,
+			},
+			phase:           corev1alpha1.LogicalClusterPhaseUnavailable,
+			migrating:       true,
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{WorkspaceShardHashAnnotationKey: targetHash},
+			wantPhase:       corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantURL:         "https://source.example.com/clusters/root:org:team",
+			wantPhases:      map[string]corev1alpha1.LogicalClusterPhaseType{"target": corev1alpha1.LogicalClusterPhaseReady},
+			wantDeleted:     "source",
+			wantCondition:   ptr.To(true),
+		},
+		{
+			name: "ignores changes of the target during the migration",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  targetHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "source",
+				workspaceMigratingFromShardAnnotationKey:                         sourceHash,
+				workspaceMigratingToShardAnnotationKey:                           targetHash,
+			},
+			phase:      corev1alpha1.LogicalClusterPhaseUnavailable,
+			migrating:  true,
+			wantStatus: reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{
+				WorkspaceShardHashAnnotationKey:                                  targetHash,
+				tenancyv1alpha1.ExperimentalWorkspaceMigrateToShardAnnotationKey: "source",
+			},
+			wantPhase:     corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantURL:       "https://source.example.com/clusters/root:org:team",
+			wantPhases:    map[string]corev1alpha1.LogicalClusterPhaseType{"target": corev1alpha1.LogicalClusterPhaseReady},
+			wantDeleted:   "source",
+			wantCondition: ptr.To(true),
+		},
+		{
+			name: "makes the workspace ready again",
+			annotations: map[string]string{
+				WorkspaceShardHashAnnotationKey: targetHash,
+			},
+			phase:           corev1alpha1.LogicalClusterPhaseUnavailable,
+			migrating:       true,
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantAnnotations: map[string]string{WorkspaceShardHashAnnotationKey: targetHash},
+			wantPhase:       corev1alpha1.LogicalClusterPhaseReady,
+			wantURL:         "https://source.example.com/clusters/root:org:team",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			shards := map[string]*corev1alpha1.Shard{
+				"source": {
+					ObjectMeta: metav1.ObjectMeta{Name: "source"},
+					Spec:       corev1alpha1.ShardSpec{BaseURL: "https://source.internal", ExternalURL: "https://source.example.com"},
+				},
+				"target": {
+					ObjectMeta: metav1.ObjectMeta{Name: "target"},
+					Spec:       corev1alpha1.ShardSpec{BaseURL: "https://target.internal", ExternalURL: "https://target.example.com"},
+				},
+			}
+			getShard := func(name string) (*corev1alpha1.Shard, error) {
+				if shard, found := shards[name]; found {
+					return shard, nil
+				}
+				return nil, apierrors.NewNotFound(corev1alpha1.Resource("shards"), name)
+			}
+
+			phases := map[string]corev1alpha1.LogicalClusterPhaseType{}
+			var copied bool
+			var deleted string
+			r := &migrationReconciler{
+				getShard: getShard,
+				getShardByHash: func(hash string) (*corev1alpha1.Shard, error) {
+					for name := range shards {
+						if ByBase36Sha224NameValue(name) == hash {
+							return getShard(name)
+						}
+					}
+					return nil, apierrors.NewNotFound(corev1alpha1.Resource("shards"), hash)
+				},
+				setLogicalClusterPhase: func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, phase corev1alpha1.LogicalClusterPhaseType) error {
+					require.Equal(t, logicalcluster.Name("somecluster"), cluster)
+					phases[shard.Name] = phase
+					return nil
+				},
+				copyLogicalCluster: func(ctx context.Context, from, to *corev1alpha1.Shard, cluster logicalcluster.Name) error {
+					require.Equal(t, "source", from.Name)
+					require.Equal(t, "target", to.Name)
+					require.Equal(t, logicalcluster.Name("somecluster"), cluster)
+					copied = true
+					return testCase.copyErr
+				},
+				deleteLogicalCluster: func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) error {
+					require.Equal(t, logicalcluster.Name("somecluster"), cluster)
+					deleted = shard.Name
+					return nil
+				},
+			}
+
+			phase := testCase.phase
+			if phase == "" {
+				phase = corev1alpha1.LogicalClusterPhaseReady
+			}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team",
+					Annotations: testCase.annotations,
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Cluster: "somecluster",
+					URL:     "https://source.example.com/clusters/root:org:team",
+				},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: phase},
+			}
+			if testCase.migrating {
+				setMigrating(ws, "Copying logical cluster from shard source to shard target")
+			}
+			if testCase.mounted {
+				ws.Spec.Mount = &tenancyv1alpha1.Mount{}
+			}
+			old := ws.DeepCopy()
+
+			status, err := r.reconcile(context.Background(), ws)
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.wantStatus, status)
+			metaOrSpecChanged := !equality.Semantic.DeepEqual(old.ObjectMeta, ws.ObjectMeta) || !equality.Semantic.DeepEqual(old.Spec, ws.Spec)
+			statusChanged := !equality.Semantic.DeepEqual(old.Status, ws.Status)
+			require.False(t, metaOrSpecChanged && statusChanged, "metadata or spec and status must not change in the same round")
+			require.Equal(t, testCase.wantAnnotations, ws.Annotations)
+			require.Equal(t, testCase.wantPhase, ws.Status.Phase)
+			require.Equal(t, testCase.wantURL, ws.Spec.URL)
+			if testCase.wantPhases == nil {
+				testCase.wantPhases = map[string]corev1alpha1.LogicalClusterPhaseType{}
+			}
+			require.Equal(t, testCase.wantPhases, phases)
+			require.Equal(t, testCase.wantCopied, copied, "copied")
+			require.Equal(t, testCase.wantDeleted, deleted, "deleted")
+
+			if testCase.wantCondition == nil {
+				require.Nil(t, conditions.Get(ws, tenancyv1alpha1.WorkspaceMigrating))
+			} else {
+				require.Equal(t, *testCase.wantCondition, conditions.IsTrue(ws, tenancyv1alpha1.WorkspaceMigrating))
+				require.Equal(t, testCase.wantReason, conditions.GetReason(ws, tenancyv1alpha1.WorkspaceMigrating))
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// moveRequeueDelay is the delay after which a moving workspace is checked again
+// while waiting for the target workspace to adopt the logical cluster.
+const moveRequeueDelay = 2 * time.Second
+
+// moveReconciler moves a ready workspace to the path in its move-to annotation. This is
+// a two-phase commit: first a new workspace is created under the target parent that adopts
+// the logical cluster of the moved workspace, and only when that is ready, the moved
+// workspace is deleted, without deleting the logical cluster. In between, the logical
+// cluster is reachable through both paths.
+type moveReconciler struct {
+	getLogicalClusterByPath func(path logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	getLogicalCluster       func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+
+	getWorkspace    func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.Workspace, error)
+	createWorkspace func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1alpha1.Workspace) error
+	deleteWorkspace func(ctx context.Context, cluster logicalcluster.Path, name string, uid types.UID) error
+
+	requeueAfter func(workspace *tenancyv1alpha1.Workspace, after time.Duration)
+}
+
+func (r *moveReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "move")
+
+	moveTo, found := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey]
+	if !found {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceMoving)
+		return reconcileStatusContinue, nil
+	}
+	if !workspace.DeletionTimestamp.IsZero() || workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+		return reconcileStatusContinue, nil
+	}
+
+	targetParentPath, targetName := logicalcluster.NewPath(moveTo).Split()
+	targetParent, err := r.getLogicalClusterByPath(targetParentPath)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMoving, tenancyv1alpha1.WorkspaceMoveInvalidTargetReason, conditionsv1alpha1.ConditionSeverityError, "Parent workspace %s not found on this shard", targetParentPath)
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	targetCluster := logicalcluster.From(targetParent)
+	if targetCluster == logicalcluster.From(workspace) && targetName == workspace.Name {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMoving, tenancyv1alpha1.WorkspaceMoveInvalidTargetReason, conditionsv1alpha1.ConditionSeverityError, "Workspace is already at %s", moveTo)
+		return reconcileStatusContinue, nil
+	}
+	logger = logger.WithValues("target", moveTo)
+
+	target, err := r.getWorkspace(targetCluster, targetName)
+	if apierrors.IsNotFound(err) {
+		// first phase: create the target workspace adopting our logical cluster.
+		logger.Info("Creating target workspace")
+		err := r.createWorkspace(ctx, targetCluster.Path(), movedWorkspace(workspace, targetName))
+		if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) {
+			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMoving, tenancyv1alpha1.WorkspaceMoveFailedReason, conditionsv1alpha1.ConditionSeverityError, "Failed to create workspace %s: %v", moveTo, err)
+			return reconcileStatusContinue, nil
+		} else if err != nil && !apierrors.IsAlreadyExists(err) {
+			return reconcileStatusStopAndRequeue, err
+		}
+		markMoving(workspace, "Waiting for workspace %s to be created", moveTo)
+		r.requeueAfter(workspace, moveRequeueDelay)
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if target.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey] != string(workspace.UID) {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMoving, tenancyv1alpha1.WorkspaceMoveNameConflictReason, conditionsv1alpha1.ConditionSeverityError, "Workspace %s already exists", moveTo)
+		return reconcileStatusContinue, nil
+	}
+
+	// second phase: when the target workspace owns the logical cluster, delete ourselves.
+	if target.Status.Phase != corev1alpha1.LogicalClusterPhaseReady || target.Spec.Cluster != workspace.Spec.Cluster {
+		markMoving(workspace, "Waiting for workspace %s to become ready", moveTo)
+		r.requeueAfter(workspace, moveRequeueDelay)
+		return reconcileStatusContinue, nil
+	}
+	logicalCluster, err := r.getLogicalCluster(logicalcluster.Name(workspace.Spec.Cluster))
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if owner := logicalCluster.Spec.Owner; owner == nil || owner.UID != target.UID {
+		markMoving(workspace, "Waiting for workspace %s to adopt the logical cluster", moveTo)
+		r.requeueAfter(workspace, moveRequeueDelay)
+		return reconcileStatusContinue, nil
+	}
+
+	logger.Info("Deleting moved workspace")
+	if err := r.deleteWorkspace(ctx, logicalcluster.From(workspace).Path(), workspace.Name, workspace.UID); err != nil && !apierrors.IsNotFound(err) {
+		return reconcileStatusStopAndRequeue, err
+	}
+	markMoving(workspace, "Workspace moved to %s", moveTo)
+
+	return reconcileStatusContinue, nil
+}
+
+func markMoving(workspace *tenancyv1alpha1.Workspace, messageFormat string, messageArgs ...interface{}) {
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:    tenancyv1alpha1.WorkspaceMoving,
+		Status:  corev1.ConditionTrue,
+		Message: fmt.Sprintf(messageFormat, messageArgs...),
+	})
+}
+
+// movedWorkspace returns the workspace that replaces the given workspace under its
+// new name. It is pre-scheduled to the logical cluster of the given workspace, which
+// it adopts during scheduling.
+func movedWorkspace(workspace *tenancyv1alpha1.Workspace, name string) *tenancyv1alpha1.Workspace {
+	annotations := make(map[string]string, len(workspace.Annotations))
+	for k, v := range workspace.Annotations {
+		annotations[k] = v
+	}
+	delete(annotations, logicalcluster.AnnotationKey)
+	delete(annotations, tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey)
+	delete(annotations, tenancyv1alpha1.ExperimentalWorkspaceRestoredFromAnnotationKey)
+	annotations[tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey] = string(workspace.UID)
+	annotations[workspaceClusterAnnotationKey] = workspace.Spec.Cluster
+
+	labels := make(map[string]string, len(workspace.Labels))
+	for k, v := range workspace.Labels {
+		labels[k] = v
+	}
+	delete(labels, tenancyv1alpha1.WorkspacePhaseLabel)
+
+	return &tenancyv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: tenancyv1alpha1.WorkspaceSpec{
+			Type:     workspace.Spec.Type,
+			Location: workspace.Spec.Location,
+			Mount:    workspace.GetMount(),
+		},
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileMove(t *testing.T) {
+	movedTarget := func(phase corev1alpha1.LogicalClusterPhaseType) *tenancyv1alpha1.Workspace {
+		return &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "renamed",
+				UID:         "target-uid",
+				Annotations: map[string]string{tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey: "source-uid"},
+			},
+			Spec:   tenancyv1alpha1.WorkspaceSpec{Cluster: "somecluster"},
+			Status: tenancyv1alpha1.WorkspaceStatus{Phase: phase},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name         string
+		moveTo       string
+		target       *tenancyv1alpha1.Workspace
+		clusterOwner types.UID
+
+		wantCreated   bool
+		wantDeleted   bool
+		wantCondition *bool
+		wantReason    string
+	}{
+		{
+			name:          "parent not found on this shard",
+			moveTo:        "root:unknown:renamed",
+			wantCondition: ptr.To(false),
+			wantReason:    tenancyv1alpha1.WorkspaceMoveInvalidTargetReason,
+		},
+		{
+			name:          "creates the target workspace",
+			moveTo:        "root:org2:renamed",
+			wantCreated:   true,
+			wantCondition: ptr.To(true),
+		},
+		{
+			name:   "target name already taken",
+			moveTo: "root:org2:renamed",
+			target: &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "renamed", UID: "other-uid"},
+			},
+			wantCondition: ptr.To(false),
+			wantReason:    tenancyv1alpha1.WorkspaceMoveNameConflictReason,
+		},
+		{
+			name:          "waits for the target workspace to become ready",
+			moveTo:        "root:org2:renamed",
+			target:        movedTarget(corev1alpha1.LogicalClusterPhaseScheduling),
+			clusterOwner:  "source-uid",
+			wantCondition: ptr.To(true),
+		},
+		{
+			name:          "waits for the logical cluster to be adopted",
+			moveTo:        "root:org2:renamed",
+			target:        movedTarget(corev1alpha1.LogicalClusterPhaseReady),
+			clusterOwner:  "source-uid",
+			wantCondition: ptr.To(true),
+		},
+		{
+			name:          "deletes the moved workspace after adoption",
+			moveTo:        "root:org2:renamed",
+			target:        movedTarget(corev1alpha1.LogicalClusterPhaseReady),
+			clusterOwner:  "target-uid",
+			wantDeleted:   true,
+			wantCondition: ptr.To(true),
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var created *tenancyv1alpha1.Workspace
+			var deleted bool
+			r := &moveReconciler{
+				getLogicalClusterByPath: func(path logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					if path.String() != "root:org2" {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), path.String())
+					}
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        corev1alpha1.LogicalClusterName,
+							Annotations: map[string]string{logicalcluster.AnnotationKey: "org2"},
+						},
+					}, nil
+				},
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, logicalcluster.Name("somecluster"), clusterName)
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+						Spec:       corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{UID: testCase.clusterOwner}},
+					}, nil
+				},
+				getWorkspace: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.Workspace, error) {
+					require.Equal(t, logicalcluster.Name("org2"), clusterName)
+					if testCase.target == nil {
+						return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspaces"), name)
+					}
+					return testCase.target, nil
+				},
+				createWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1alpha1.Workspace) error {
+					require.Equal(t, "org2", cluster.String())
+					created = workspace
+					return nil
+				},
+				deleteWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string, uid types.UID) error {
+					require.Equal(t, "org1", cluster.String())
+					require.Equal(t, "team", name)
+					require.Equal(t, types.UID("source-uid"), uid)
+					deleted = true
+					return nil
+				},
+				requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "team",
+					UID:  "source-uid",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:                             "org1",
+						tenancyv1alpha1.ExperimentalWorkspaceMoveToAnnotationKey: testCase.moveTo,
+						tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey:  `{"username":"user-1"}`,
+						WorkspaceShardHashAnnotationKey:                          "shardhash",
+					},
+					Labels: map[string]string{tenancyv1alpha1.WorkspacePhaseLabel: "Ready", "team": "a"},
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type:    tenancyv1alpha1.WorkspaceTypeReference{Name: "universal", Path: "root"},
+					Cluster: "somecluster",
+					URL:     "https://kcp.example.com/clusters/root:org1:team",
+				},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.wantDeleted, deleted, "deleted")
+
+			if testCase.wantCreated {
+				require.NotNil(t, created)
+				require.Equal(t, "renamed", created.Name)
+				require.Equal(t, map[string]string{"team": "a"}, created.Labels)
+				require.Equal(t, map[string]string{
+					tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey: "source-uid",
+					tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey:     `{"username":"user-1"}`,
+					WorkspaceShardHashAnnotationKey:                             "shardhash",
+					workspaceClusterAnnotationKey:                               "somecluster",
+				}, created.Annotations)
+				require.Equal(t, ws.Spec.Type, created.Spec.Type)
+				require.Empty(t, created.Spec.Cluster)
+				require.Empty(t, created.Spec.URL)
+			} else {
+				require.Nil(t, created)
+			}
+
+			if testCase.wantCondition == nil {
+				require.Nil(t, conditions.Get(ws, tenancyv1alpha1.WorkspaceMoving))
+			} else {
+				require.Equal(t, *testCase.wantCondition, conditions.IsTrue(ws, tenancyv1alpha1.WorkspaceMoving))
+				require.Equal(t, testCase.wantReason, conditions.GetReason(ws, tenancyv1alpha1.WorkspaceMoving))
+			}
+		})
+	}
+}
+
+func TestIsAdoptable(t *testing.T) {
+	logicalCluster := func(owner types.UID, retained bool) *corev1alpha1.LogicalCluster {
+		lc := &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        corev1alpha1.LogicalClusterName,
+				Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: "root:org1:team"},
+			},
+			Spec: corev1alpha1.LogicalClusterSpec{
+				Owner: &corev1alpha1.LogicalClusterOwner{Name: "team", Cluster: "org1", UID: owner},
+			},
+		}
+		if retained {
+			lc.Annotations[tenancyv1alpha1.LogicalClusterRetainedUntilAnnotationKey] = "2024-01-01T00:00:00Z"
+		}
+		return lc
+	}
+	workspace := func(name, cluster string, annotations map[string]string) *tenancyv1alpha1.Workspace {
+		annotations[logicalcluster.AnnotationKey] = cluster
+		return &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+	}
+
+	require.True(t, isAdoptable(logicalCluster("uid", false), workspace("renamed", "org2", map[string]string{tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey: "uid"})))
+	require.False(t, isAdoptable(logicalCluster("other", false), workspace("renamed", "org2", map[string]string{tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey: "uid"})))
+	require.True(t, isAdoptable(logicalCluster("uid", true), workspace("team", "org1", map[string]string{tenancyv1alpha1.ExperimentalWorkspaceRestoredFromAnnotationKey: "uid"})))
+	require.False(t, isAdoptable(logicalCluster("uid", false), workspace("team", "org1", map[string]string{tenancyv1alpha1.ExperimentalWorkspaceRestoredFromAnnotationKey: "uid"})))
+	require.False(t, isAdoptable(logicalCluster("uid", true), workspace("team", "org1", map[string]string{})))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// pathReconciler keeps the canonical path of the logical cluster of a workspace and
+// its spec.URL in sync with the path of the parent workspace. The path of a parent
+// changes when it is moved, and this propagates the change down the tree on this shard.
+type pathReconciler struct {
+	getLogicalCluster        func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	updateLogicalClusterPath func(ctx context.Context, cluster logicalcluster.Path, path logicalcluster.Path) error
+}
+
+func (r *pathReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "path")
+
+	switch {
+	case !workspace.DeletionTimestamp.IsZero():
+		return reconcileStatusContinue, nil
+	case workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseUnavailable:
+		return reconcileStatusContinue, nil
+	case workspace.Spec.Cluster == "" || workspace.Spec.URL == "":
+		return reconcileStatusContinue, nil
+	}
+
+	parent, err := r.getLogicalCluster(logicalcluster.From(workspace))
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	parentPath, found := parent.Annotations[core.LogicalClusterPathAnnotationKey]
+	if !found {
+		return reconcileStatusContinue, nil
+	}
+	expected := logicalcluster.NewPath(parentPath).Join(workspace.Name)
+
+	logicalCluster, err := r.getLogicalCluster(logicalcluster.Name(workspace.Spec.Cluster))
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil // on another shard
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if owner := logicalCluster.Spec.Owner; owner == nil || owner.UID != workspace.UID {
+		return reconcileStatusContinue, nil // e.g. adopted by the target of a move
+	}
+
+	if got := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; got != expected.String() {
+		logger.Info("Updating path of LogicalCluster", "from", got, "to", expected)
+		if err := r.updateLogicalClusterPath(ctx, logicalcluster.NewPath(workspace.Spec.Cluster), expected); err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+	}
+
+	i := strings.LastIndex(workspace.Spec.URL, "/clusters/")
+	if i < 0 {
+		return reconcileStatusContinue, nil
+	}
+	if url := workspace.Spec.URL[:i] + expected.RequestPath(); url != workspace.Spec.URL {
+		logger.Info("Updating URL", "from", workspace.Spec.URL, "to", url)
+		workspace.Spec.URL = url
+		return reconcileStatusStopAndRequeue, nil
+	}
+
+	return reconcileStatusContinue, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcilePath(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		parentPath   string
+		clusterPath  string
+		clusterOwner types.UID
+
+		wantStatus  reconcileStatus
+		wantUpdated string
+		wantURL     string
+	}{
+		{
+			name:         "path in sync",
+			parentPath:   "root:org",
+			clusterPath:  "root:org:team",
+			clusterOwner: "uid",
+			wantStatus:   reconcileStatusContinue,
+			wantURL:      "https://kcp.example.com/clusters/root:org:team",
+		},
+		{
+			name:         "parent moved",
+			parentPath:   "root:org2:renamed",
+			clusterPath:  "root:org:team",
+			clusterOwner: "uid",
+			wantStatus:   reconcileStatusStopAndRequeue,
+			wantUpdated:  "root:org2:renamed:team",
+			wantURL:      "https://kcp.example.com/clusters/root:org2:renamed:team",
+		},
+		{
+			name:         "logical cluster adopted by another workspace",
+			parentPath:   "root:org",
+			clusterPath:  "root:org2:team",
+			clusterOwner: "other-uid",
+			wantStatus:   reconcileStatusContinue,
+			wantURL:      "https://kcp.example.com/clusters/root:org:team",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var updated string
+			r := &pathReconciler{
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					switch clusterName {
+					case "parent":
+						return &corev1alpha1.LogicalCluster{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        corev1alpha1.LogicalClusterName,
+								Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: testCase.parentPath},
+							},
+						}, nil
+					case "somecluster":
+						return &corev1alpha1.LogicalCluster{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        corev1alpha1.LogicalClusterName,
+								Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: testCase.clusterPath},
+							},
+							Spec: corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{UID: testCase.clusterOwner}},
+						}, nil
+					}
+					return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), clusterName.String())
+				},
+				updateLogicalClusterPath: func(ctx context.Context, cluster logicalcluster.Path, path logicalcluster.Path) error {
+					require.Equal(t, "somecluster", cluster.String())
+					updated = path.String()
+					return nil
+				},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team",
+					UID:         "uid",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "parent"},
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Cluster: "somecluster",
+					URL:     "https://kcp.example.com/clusters/root:org:team",
+				},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantUpdated, updated)
+			require.Equal(t, testCase.wantURL, ws.Spec.URL)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package workspace
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -33,7 +34,12 @@ import (
 
 type phaseReconciler struct {
 	getLogicalCluster func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	getWorkspaceType  func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
 
+	// recordWarning emits a warning event for the given workspace.
+	recordWarning func(ctx context.Context, workspace *tenancyv1alpha1.Workspace, reason, message string)
+
+	now          func() time.Time
 	requeueAfter func(workspace *tenancyv1alpha1.Workspace, after time.Duration)
 }
 
@@ -60,10 +66,28 @@ func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1alp
 		workspace.Status.Initializers = logicalCluster.Status.Initializers
 
 		if initializers := workspace.Status.Initializers; len(initializers) > 0 {
-			after := time.Since(logicalCluster.CreationTimestamp.Time) / 5
+			after := r.now().Sub(logicalCluster.CreationTimestamp.Time) / 5
 			if max := time.Minute * 10; after > max {
 				after = max
 			}
+
+			timeout, err := r.initializationTimeout(workspace)
+			if err != nil {
+				return reconcileStatusStopAndRequeue, err
+			}
+			if deadline := logicalCluster.CreationTimestamp.Add(timeout); timeout > 0 && !r.now().Before(deadline) {
+				message := fmt.Sprintf("Initializers %v did not complete within %s", initializers, timeout)
+				if conditions.GetReason(workspace, tenancyv1alpha1.WorkspaceInitialized) != tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut {
+					logger.Info("LogicalCluster initialization timed out", "initializers", initializers, "timeout", timeout)
+					r.recordWarning(ctx, workspace, tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut, message)
+				}
+				conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut, conditionsv1alpha1.ConditionSeverityError, "%s", message)
+				r.requeueAfter(workspace, after)
+				return reconcileStatusContinue, nil
+			} else if remaining := deadline.Sub(r.now()); timeout > 0 && remaining < after {
+				after = remaining
+			}
+
 			logger.V(3).Info("LogicalCluster still has initializers, requeueing", "initializers", initializers, "after", after)
 			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerExists, conditionsv1alpha1.ConditionSeverityInfo, "Initializers still exist: %v", workspace.Status.Initializers)
 			r.requeueAfter(workspace, after)
@@ -74,7 +98,7 @@ func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1alp
 		workspace.Status.Phase = corev1alpha1.LogicalClusterPhaseReady
 		conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceInitialized)
 
-	case corev1alpha1.LogicalClusterPhaseReady:
+	case corev1alpha1.LogicalClusterPhaseReady, corev1alpha1.LogicalClusterPhaseUnavailable:
 		if !workspace.DeletionTimestamp.IsZero() {
 			logger = logger.WithValues("cluster", workspace.Spec.Cluster)
 
@@ -84,9 +108,12 @@ func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1alp
 			} else if apierrors.IsNotFound(err) {
 				logger.Info("LogicalCluster disappeared")
 				conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceContentDeleted)
+				workspace.Status.Terminators = nil
 				return reconcileStatusContinue, nil
 			}
 
+			workspace.Status.Terminators = logicalCluster.Status.Terminators
+
 			if !conditions.IsTrue(workspace, tenancyv1alpha1.WorkspaceContentDeleted) {
 				after := time.Since(logicalCluster.CreationTimestamp.Time) / 5
 				if max := time.Minute * 10; after > max {
@@ -110,3 +137,22 @@ func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1alp
 
 	return reconcileStatusContinue, nil
 }
+
+// initializationTimeout returns the initializationTimeout of the WorkspaceType of the workspace,
+// or zero if there is none.
+func (r *phaseReconciler) initializationTimeout(workspace *tenancyv1alpha1.Workspace) (time.Duration, error) {
+	if workspace.Spec.Type.Name == "" {
+		return 0, nil
+	}
+	wt, err := r.getWorkspaceType(logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if wt.Spec.InitializationTimeout == nil {
+		return 0, nil
+	}
+	return wt.Spec.InitializationTimeout.Duration, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcilePhaseInitializationTimeout(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		name         string
+		age          time.Duration
+		timeout      *metav1.Duration
+		initializers []corev1alpha1.LogicalClusterInitializer
+		timedOut     bool
+
+		wantPhase        corev1alpha1.LogicalClusterPhaseType
+		wantReason       string
+		wantEvents       []string
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "no timeout",
+			age:              time.Hour,
+			initializers:     []corev1alpha1.LogicalClusterInitializer{"system:apibindings"},
+			wantPhase:        corev1alpha1.LogicalClusterPhaseInitializing,
+			wantReason:       tenancyv1alpha1.WorkspaceInitializedInitializerExists,
+			wantRequeueAfter: 10 * time.Minute,
+		},
+		{
+			name:             "within timeout, requeue at deadline",
+			age:              50 * time.Minute,
+			timeout:          &metav1.Duration{Duration: time.Hour},
+			initializers:     []corev1alpha1.LogicalClusterInitializer{"system:apibindings"},
+			wantPhase:        corev1alpha1.LogicalClusterPhaseInitializing,
+			wantReason:       tenancyv1alpha1.WorkspaceInitializedInitializerExists,
+			wantRequeueAfter: 10 * time.Minute,
+		},
+		{
+			name:             "within timeout, deadline before next requeue",
+			age:              55 * time.Minute,
+			timeout:          &metav1.Duration{Duration: time.Hour},
+			initializers:     []corev1alpha1.LogicalClusterInitializer{"system:apibindings"},
+			wantPhase:        corev1alpha1.LogicalClusterPhaseInitializing,
+			wantReason:       tenancyv1alpha1.WorkspaceInitializedInitializerExists,
+			wantRequeueAfter: 5 * time.Minute,
+		},
+		{
+			name:             "timed out",
+			age:              2 * time.Hour,
+			timeout:          &metav1.Duration{Duration: time.Hour},
+			initializers:     []corev1alpha1.LogicalClusterInitializer{"root:org:custom"},
+			wantPhase:        corev1alpha1.LogicalClusterPhaseInitializing,
+			wantReason:       tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut,
+			wantEvents:       []string{"InitializerTimedOut: Initializers [root:org:custom] did not complete within 1h0m0s"},
+			wantRequeueAfter: 10 * time.Minute,
+		},
+		{
+			name:             "timed out before, no new event",
+			age:              2 * time.Hour,
+			timeout:          &metav1.Duration{Duration: time.Hour},
+			initializers:     []corev1alpha1.LogicalClusterInitializer{"root:org:custom"},
+			timedOut:         true,
+			wantPhase:        corev1alpha1.LogicalClusterPhaseInitializing,
+			wantReason:       tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut,
+			wantRequeueAfter: 10 * time.Minute,
+		},
+		{
+			name:      "initialized after timeout",
+			age:       2 * time.Hour,
+			timeout:   &metav1.Duration{Duration: time.Hour},
+			timedOut:  true,
+			wantPhase: corev1alpha1.LogicalClusterPhaseReady,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var events []string
+			var requeueAfter time.Duration
+			r := &phaseReconciler{
+				getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "somecluster", cluster.String())
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              corev1alpha1.LogicalClusterName,
+							CreationTimestamp: metav1.NewTime(now.Add(-testCase.age)),
+						},
+						Status: corev1alpha1.LogicalClusterStatus{Initializers: testCase.initializers},
+					}, nil
+				},
+				getWorkspaceType: func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					require.Equal(t, "root:org", clusterName.String())
+					require.Equal(t, "custom", name)
+					return &tenancyv1alpha1.WorkspaceType{
+						Spec: tenancyv1alpha1.WorkspaceTypeSpec{InitializationTimeout: testCase.timeout},
+					}, nil
+				},
+				recordWarning: func(ctx context.Context, workspace *tenancyv1alpha1.Workspace, reason, message string) {
+					events = append(events, reason+": "+message)
+				},
+				now: func() time.Time { return now },
+				requeueAfter: func(workspace *tenancyv1alpha1.Workspace, after time.Duration) {
+					requeueAfter = after
+				},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "parent"},
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Cluster: "somecluster",
+					Type:    tenancyv1alpha1.WorkspaceTypeReference{Name: "custom", Path: "root:org"},
+				},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseInitializing},
+			}
+			if testCase.timedOut {
+				conditions.MarkFalse(ws, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut, conditionsv1alpha1.ConditionSeverityError, "timed out")
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.wantPhase, ws.Status.Phase)
+			if testCase.wantReason != "" {
+				require.Equal(t, testCase.wantReason, conditions.GetReason(ws, tenancyv1alpha1.WorkspaceInitialized))
+			} else {
+				require.True(t, conditions.IsTrue(ws, tenancyv1alpha1.WorkspaceInitialized))
+			}
+			require.Equal(t, testCase.wantEvents, events)
+			require.Equal(t, testCase.wantRequeueAfter, requeueAfter)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// workspaceInheritedMetadataAnnotationKey keeps track of the labels and annotations a workspace
+// inherited from its parent, in order to remove them when they are removed from the parent.
+const workspaceInheritedMetadataAnnotationKey = "internal.tenancy.kcp.io/inherited-metadata"
+
+type inheritedMetadata struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// propagationReconciler propagates labels and annotations with the propagation prefix
+// from the LogicalCluster of the parent to the workspace, and from the workspace to its
+// LogicalCluster. As the latter is watched by the children of the workspace, this
+// propagates the metadata transitively down the tree.
+type propagationReconciler struct {
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getShardByHash    func(hash string) (*corev1alpha1.Shard, error)
+
+	// getShardLogicalCluster returns the logical cluster on the given shard.
+	getShardLogicalCluster func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	// patchLogicalCluster applies the given merge patch to the logical cluster on the given shard.
+	patchLogicalCluster func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, patch []byte) error
+}
+
+func (r *propagationReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "propagation")
+
+	switch {
+	case !workspace.DeletionTimestamp.IsZero():
+		return reconcileStatusContinue, nil
+	case workspace.Status.Phase == "" || workspace.Status.Phase == corev1alpha1.LogicalClusterPhaseScheduling:
+		return reconcileStatusContinue, nil
+	case workspace.Spec.Cluster == "":
+		return reconcileStatusContinue, nil
+	}
+
+	// inherit from the parent
+	var parentLabels, parentAnnotations map[string]string
+	parent, err := r.getLogicalCluster(logicalcluster.From(workspace))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcileStatusStopAndRequeue, err
+	} else if err == nil {
+		parentLabels = propagatedMetadata(parent.Labels)
+		parentAnnotations = propagatedMetadata(parent.Annotations)
+	}
+
+	var inherited inheritedMetadata
+	if value, found := workspace.Annotations[workspaceInheritedMetadataAnnotationKey]; found {
+		if err := json.Unmarshal([]byte(value), &inherited); err != nil {
+			logger.Error(err, "failed to parse inherited metadata annotation, ignoring it")
+		}
+	}
+
+	labelsChanged := inheritMetadata(&workspace.Labels, inherited.Labels, parentLabels)
+	annotationsChanged := inheritMetadata(&workspace.Annotations, inherited.Annotations, parentAnnotations)
+
+	inherited = inheritedMetadata{Labels: sets.List(sets.KeySet(parentLabels)), Annotations: sets.List(sets.KeySet(parentAnnotations))}
+	if len(inherited.Labels) == 0 && len(inherited.Annotations) == 0 {
+		if _, found := workspace.Annotations[workspaceInheritedMetadataAnnotationKey]; found {
+			delete(workspace.Annotations, workspaceInheritedMetadataAnnotationKey)
+			annotationsChanged = true
+		}
+	} else {
+		bs, err := json.Marshal(inherited)
+		if err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+		if workspace.Annotations[workspaceInheritedMetadataAnnotationKey] != string(bs) {
+			if workspace.Annotations == nil {
+				workspace.Annotations = map[string]string{}
+			}
+			workspace.Annotations[workspaceInheritedMetadataAnnotationKey] = string(bs)
+			annotationsChanged = true
+		}
+	}
+
+	if labelsChanged || annotationsChanged {
+		logger.V(2).Info("Updating metadata inherited from parent")
+		return reconcileStatusStopAndRequeue, nil
+	}
+
+	// propagate to the logical cluster
+	shard, err := r.getShardByHash(workspace.Annotations[WorkspaceShardHashAnnotationKey])
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	clusterName := logicalcluster.Name(workspace.Spec.Cluster)
+	logicalCluster, err := r.getShardLogicalCluster(ctx, shard, clusterName)
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if owner := logicalCluster.Spec.Owner; owner == nil || owner.UID != workspace.UID {
+		return reconcileStatusContinue, nil // e.g. adopted by the target of a move
+	}
+
+	labelsPatch := metadataPatch(propagatedMetadata(logicalCluster.Labels), propagatedMetadata(workspace.Labels))
+	annotationsPatch := metadataPatch(propagatedMetadata(logicalCluster.Annotations), propagatedMetadata(workspace.Annotations))
+	if len(labelsPatch) == 0 && len(annotationsPatch) == 0 {
+		return reconcileStatusContinue, nil
+	}
+
+	metadata := map[string]interface{}{}
+	if len(labelsPatch) > 0 {
+		metadata["labels"] = labelsPatch
+	}
+	if len(annotationsPatch) > 0 {
+		metadata["annotations"] = annotationsPatch
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	logger.V(2).Info("Propagating metadata to LogicalCluster", "cluster", clusterName, "shard", shard.Name)
+	if err := r.patchLogicalCluster(ctx, shard, clusterName, patch); err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	return reconcileStatusContinue, nil
+}
+
+// propagatedMetadata returns the labels or annotations with the propagation prefix.
+func propagatedMetadata(m map[string]string) map[string]string {
+	var ret map[string]string
+	for k, v := range m {
+		if strings.HasPrefix(k, tenancyv1alpha1.WorkspacePropagatedKeyPrefix) {
+			if ret == nil {
+				ret = map[string]string{}
+			}
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// propagatedMetadataChanged returns true if the labels or annotations with the propagation
+// prefix differ.
+func propagatedMetadataChanged(old, new *corev1alpha1.LogicalCluster) bool {
+	return !reflect.DeepEqual(propagatedMetadata(old.Labels), propagatedMetadata(new.Labels)) ||
+		!reflect.DeepEqual(propagatedMetadata(old.Annotations), propagatedMetadata(new.Annotations))
+}
+
+// inheritMetadata sets the given values from the parent, and removes those inherited before that
+// the parent does not have anymore. It returns true if m has changed.
+func inheritMetadata(m *map[string]string, previous []string, parent map[string]string) bool {
+	changed := false
+	for _, k := range previous {
+		if _, found := parent[k]; found {
+			continue
+		}
+		if _, found := (*m)[k]; found {
+			delete(*m, k)
+			changed = true
+		}
+	}
+	for k, v := range parent {
+		if got, found := (*m)[k]; found && got == v {
+			continue
+		}
+		if *m == nil {
+			*m = map[string]string{}
+		}
+		(*m)[k] = v
+		changed = true
+	}
+	return changed
+}
+
+// metadataPatch returns the merge patch of labels or annotations to go from got to want.
+func metadataPatch(got, want map[string]string) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k := range got {
+		if _, found := want[k]; !found {
+			patch[k] = nil
+		}
+	}
+	for k, v := range want {
+		if got[k] != v {
+			patch[k] = v
+		}
+	}
+	return patch
+}
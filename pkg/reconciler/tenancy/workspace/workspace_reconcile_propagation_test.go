@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcilePropagation(t *testing.T) {
+	for _, testCase := range []struct {
+		name                   string
+		parentLabels           map[string]string
+		workspaceLabels        map[string]string
+		workspaceAnnotations   map[string]string
+		clusterLabels          map[string]string
+		clusterAnnotations     map[string]string
+		clusterOwner           types.UID
+		noParentLogicalCluster bool
+
+		wantStatus      reconcileStatus
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+		wantPatch       string
+	}{
+		{
+			name:            "nothing to propagate",
+			workspaceLabels: map[string]string{"foo": "bar"},
+			clusterOwner:    "uid",
+			wantStatus:      reconcileStatusContinue,
+			wantLabels:      map[string]string{"foo": "bar"},
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name:            "inherit from parent, overriding own value",
+			parentLabels:    map[string]string{"propagate.tenancy.kcp.io/cost-center": "42", "foo": "bar"},
+			workspaceLabels: map[string]string{"propagate.tenancy.kcp.io/cost-center": "23"},
+			clusterOwner:    "uid",
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantLabels:      map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			wantAnnotations: map[string]string{workspaceInheritedMetadataAnnotationKey: `{"labels":["propagate.tenancy.kcp.io/cost-center"]}`},
+		},
+		{
+			name:                 "removed from parent",
+			workspaceLabels:      map[string]string{"propagate.tenancy.kcp.io/cost-center": "42", "propagate.tenancy.kcp.io/own": "x"},
+			workspaceAnnotations: map[string]string{workspaceInheritedMetadataAnnotationKey: `{"labels":["propagate.tenancy.kcp.io/cost-center"]}`},
+			clusterOwner:         "uid",
+			wantStatus:           reconcileStatusStopAndRequeue,
+			wantLabels:           map[string]string{"propagate.tenancy.kcp.io/own": "x"},
+			wantAnnotations:      map[string]string{},
+		},
+		{
+			name:                   "parent logical cluster not found",
+			noParentLogicalCluster: true,
+			workspaceLabels:        map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			workspaceAnnotations:   map[string]string{workspaceInheritedMetadataAnnotationKey: `{"labels":["propagate.tenancy.kcp.io/cost-center"]}`},
+			clusterOwner:           "uid",
+			wantStatus:             reconcileStatusStopAndRequeue,
+			wantLabels:             map[string]string{},
+			wantAnnotations:        map[string]string{},
+		},
+		{
+			name:                 "propagate to logical cluster",
+			parentLabels:         map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			workspaceLabels:      map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			workspaceAnnotations: map[string]string{workspaceInheritedMetadataAnnotationKey: `{"labels":["propagate.tenancy.kcp.io/cost-center"]}`, "propagate.tenancy.kcp.io/billing": "acme"},
+			clusterLabels:        map[string]string{"propagate.tenancy.kcp.io/old": "x", "foo": "bar"},
+			clusterOwner:         "uid",
+			wantStatus:           reconcileStatusContinue,
+			wantLabels:           map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			wantAnnotations:      map[string]string{workspaceInheritedMetadataAnnotationKey: `{"labels":["propagate.tenancy.kcp.io/cost-center"]}`, "propagate.tenancy.kcp.io/billing": "acme"},
+			wantPatch:            `{"metadata":{"annotations":{"propagate.tenancy.kcp.io/billing":"acme"},"labels":{"propagate.tenancy.kcp.io/cost-center":"42","propagate.tenancy.kcp.io/old":null}}}`,
+		},
+		{
+			name:               "logical cluster in sync",
+			workspaceLabels:    map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			clusterLabels:      map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			clusterAnnotations: map[string]string{"foo": "bar"},
+			clusterOwner:       "uid",
+			wantStatus:         reconcileStatusContinue,
+			wantLabels:         map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			wantAnnotations:    map[string]string{},
+		},
+		{
+			name:            "logical cluster adopted by another workspace",
+			workspaceLabels: map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			clusterOwner:    "other-uid",
+			wantStatus:      reconcileStatusContinue,
+			wantLabels:      map[string]string{"propagate.tenancy.kcp.io/cost-center": "42"},
+			wantAnnotations: map[string]string{},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var patched string
+			r := &propagationReconciler{
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "parent", clusterName.String())
+					if testCase.noParentLogicalCluster {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), clusterName.String())
+					}
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   corev1alpha1.LogicalClusterName,
+							Labels: testCase.parentLabels,
+						},
+					}, nil
+				},
+				getShardByHash: func(hash string) (*corev1alpha1.Shard, error) {
+					require.Equal(t, "hash", hash)
+					return &corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "shard"}}, nil
+				},
+				getShardLogicalCluster: func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "shard", shard.Name)
+					require.Equal(t, "somecluster", cluster.String())
+					return &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        corev1alpha1.LogicalClusterName,
+							Labels:      testCase.clusterLabels,
+							Annotations: testCase.clusterAnnotations,
+						},
+						Spec: corev1alpha1.LogicalClusterSpec{Owner: &corev1alpha1.LogicalClusterOwner{UID: testCase.clusterOwner}},
+					}, nil
+				},
+				patchLogicalCluster: func(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Name, patch []byte) error {
+					require.Equal(t, "somecluster", cluster.String())
+					patched = string(patch)
+					return nil
+				},
+			}
+
+			annotations := map[string]string{
+				logicalcluster.AnnotationKey:    "parent",
+				WorkspaceShardHashAnnotationKey: "hash",
+			}
+			for k, v := range testCase.workspaceAnnotations {
+				annotations[k] = v
+			}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team",
+					UID:         "uid",
+					Labels:      testCase.workspaceLabels,
+					Annotations: annotations,
+				},
+				Spec:   tenancyv1alpha1.WorkspaceSpec{Cluster: "somecluster"},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantPatch, patched)
+
+			delete(ws.Annotations, logicalcluster.AnnotationKey)
+			delete(ws.Annotations, WorkspaceShardHashAnnotationKey)
+			if ws.Labels == nil {
+				ws.Labels = map[string]string{}
+			}
+			require.Equal(t, testCase.wantLabels, ws.Labels)
+			require.Equal(t, testCase.wantAnnotations, ws.Annotations)
+		})
+	}
+}
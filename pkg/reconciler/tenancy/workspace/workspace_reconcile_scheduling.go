@@ -21,7 +21,6 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"fmt"
-	mathrand "math/rand"
 	"net/url"
 	"path"
 	"strings"
@@ -44,6 +43,7 @@ import (
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/termination"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
@@ -69,6 +69,8 @@ type schedulingReconciler struct {
 	getShard       func(name string) (*corev1alpha1.Shard, error)
 	getShardByHash func(hash string) (*corev1alpha1.Shard, error)
 	listShards     func(selector labels.Selector) ([]*corev1alpha1.Shard, error)
+	placer         *placer
+	thresholds     SchedulingThresholds
 
 	getWorkspaceType func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
 
@@ -160,7 +162,8 @@ func (r *schedulingReconciler) reconcile(ctx context.Context, workspace *tenancy
 			}
 		}
 
-		if err := r.createLogicalCluster(ctx, shard, clusterName.Path(), canonicalPath, workspace); err != nil && !apierrors.IsAlreadyExists(err) {
+		phaseSet, err := r.createLogicalCluster(ctx, shard, clusterName.Path(), canonicalPath, workspace)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
 			return reconcileStatusStopAndRequeue, err
 		} else if apierrors.IsAlreadyExists(err) {
 			// we have checked in createLogicalCluster that this is a logicalcluster from another owner. Let's choose another cluster name.
@@ -168,8 +171,10 @@ func (r *schedulingReconciler) reconcile(ctx context.Context, workspace *tenancy
 			logging.WithObject(logger, shard).Info("logical cluster already exists")
 			return reconcileStatusStopAndRequeue, nil
 		}
-		if err := r.updateLogicalClusterPhase(ctx, shard, clusterName.Path(), corev1alpha1.LogicalClusterPhaseInitializing); err != nil {
-			return reconcileStatusStopAndRequeue, err
+		if !phaseSet {
+			if err := r.updateLogicalClusterPhase(ctx, shard, clusterName.Path(), corev1alpha1.LogicalClusterPhaseInitializing); err != nil {
+				return reconcileStatusStopAndRequeue, err
+			}
 		}
 
 		// now complete the second part of our two-phase commit: set location in workspace
@@ -214,6 +219,19 @@ func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, w
 			logger.V(4).Info("Skipping a shard because it is annotated as unschedulable", "shard", shard.Name, "annotation", unschedulableAnnotationKey)
 			continue
 		}
+		if !shard.IsSchedulable() {
+			logger.V(4).Info("Skipping a shard because it is cordoned", "shard", shard.Name)
+			continue
+		}
+		if message, exceeded := r.thresholds.exceededBy(shard); exceeded {
+			invalidShards[shard.Name] = struct {
+				reason, message string
+			}{
+				reason:  "CapacityExceeded",
+				message: message,
+			}
+			continue
+		}
 		if valid, reason, message := isValidShard(shard); valid {
 			validShards = append(validShards, shard)
 		} else {
@@ -234,11 +252,18 @@ func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, w
 		logger.Error(utilerrors.NewAggregate(failures), "no valid shards found for workspace, skipping")
 		return nil, "No available shards to schedule the workspace", nil // retry is automatic when new shards show up
 	}
-	targetShard := validShards[mathrand.Intn(len(validShards))]
+	targetShard, err := r.placer.choose(workspace, validShards)
+	if err != nil {
+		return nil, "", err
+	}
 	return targetShard, "", nil
 }
 
-func (r *schedulingReconciler) createLogicalCluster(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Path, canonicalPath logicalcluster.Path, workspace *tenancyv1alpha1.Workspace) error {
+// createLogicalCluster creates the LogicalCluster for the workspace. If the workspace is moved, or
+// if it is restored and the LogicalCluster of the deleted workspace is still retained, the existing
+// LogicalCluster is adopted instead.
+// phaseSet is true if the LogicalCluster has already left the Scheduling phase.
+func (r *schedulingReconciler) createLogicalCluster(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Path, canonicalPath logicalcluster.Path, workspace *tenancyv1alpha1.Workspace) (phaseSet bool, err error) {
 	logicalCluster := &corev1alpha1.LogicalCluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: corev1alpha1.LogicalClusterName,
@@ -266,15 +291,18 @@ func (r *schedulingReconciler) createLogicalCluster(ctx context.Context, shard *
 	}
 
 	// add initializers
-	var err error
 	logicalCluster.Spec.Initializers, err = LogicalClustersInitializers(r.transitiveTypeResolver, r.getWorkspaceType, logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
 	if err != nil {
-		return err
+		return false, err
+	}
+	logicalCluster.Spec.Terminators, err = LogicalClustersTerminators(r.transitiveTypeResolver, r.getWorkspaceType, logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
+	if err != nil {
+		return false, err
 	}
 
 	logicalClusterAdminClient, err := r.kcpLogicalClusterAdminClientFor(shard)
 	if err != nil {
-		return err
+		return false, err
 	}
 	logging.WithObject(klog.FromContext(ctx), logicalCluster).Info("creating LogicalCluster")
 	_, err = logicalClusterAdminClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Create(ctx, logicalCluster, metav1.CreateOptions{})
@@ -282,14 +310,50 @@ func (r *schedulingReconciler) createLogicalCluster(ctx context.Context, shard *
 	if apierrors.IsAlreadyExists(err) {
 		existing, getErr := logicalClusterAdminClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
 		if getErr != nil {
-			return getErr
+			return false, getErr
 		}
 		if equality.Semantic.DeepEqual(existing.Spec.Owner, logicalCluster.Spec.Owner) {
-			return nil
+			return existing.Status.Phase != "" && existing.Status.Phase != corev1alpha1.LogicalClusterPhaseScheduling, nil
+		}
+		if isAdoptable(existing, workspace) {
+			logging.WithObject(klog.FromContext(ctx), existing).Info("adopting LogicalCluster")
+			existing.Spec.Owner = logicalCluster.Spec.Owner
+			existing.Annotations[core.LogicalClusterPathAnnotationKey] = canonicalPath.String()
+			delete(existing.Annotations, tenancyv1alpha1.LogicalClusterRetainedUntilAnnotationKey)
+			if _, err := logicalClusterAdminClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+				return false, err
+			}
+			return true, nil
 		}
 	}
 
-	return err
+	return false, err
+}
+
+// isAdoptable returns true if the given LogicalCluster belongs to the workspace the given
+// moved workspace has been created from, or if it is retained after the deletion of the
+// workspace the given restored workspace has been created from.
+func isAdoptable(logicalCluster *corev1alpha1.LogicalCluster, workspace *tenancyv1alpha1.Workspace) bool {
+	if !logicalCluster.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if movedFrom, found := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMovedFromAnnotationKey]; found {
+		owner := logicalCluster.Spec.Owner
+		return owner != nil && string(owner.UID) == movedFrom
+	}
+
+	restoredFrom, found := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceRestoredFromAnnotationKey]
+	if !found {
+		return false
+	}
+	if _, retained := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterRetainedUntilAnnotationKey]; !retained {
+		return false
+	}
+	owner := logicalCluster.Spec.Owner
+	return owner != nil &&
+		string(owner.UID) == restoredFrom &&
+		owner.Name == workspace.Name &&
+		owner.Cluster == logicalcluster.From(workspace).String()
 }
 
 // LogicalClustersInitializers returns the initializers for a LogicalCluster of a given
@@ -310,20 +374,54 @@ func LogicalClustersInitializers(
 
 	initializers := make([]corev1alpha1.LogicalClusterInitializer, 0, len(wtAliases))
 
-	bindings := false
-	for _, alias := range wtAliases {
+	for _, alias := range workspacetypeexists.WithoutOverridden(wtAliases, tenancyv1alpha1.WorkspaceTypeFieldInitializers) {
 		if alias.Spec.Initializer {
 			initializers = append(initializers, initialization.InitializerForType(alias))
 		}
+	}
+	bindings, templates := false, false
+	for _, alias := range workspacetypeexists.WithoutOverridden(wtAliases, tenancyv1alpha1.WorkspaceTypeFieldDefaultAPIBindings) {
 		bindings = bindings || len(alias.Spec.DefaultAPIBindings) > 0
 	}
+	for _, alias := range workspacetypeexists.WithoutOverridden(wtAliases, tenancyv1alpha1.WorkspaceTypeFieldTemplate) {
+		templates = templates || (alias.Spec.Template != nil && len(alias.Spec.Template.Objects) > 0)
+	}
 	if bindings {
 		initializers = append(initializers, tenancyv1alpha1.WorkspaceAPIBindingsInitializer)
 	}
+	if templates {
+		initializers = append(initializers, tenancyv1alpha1.WorkspaceTemplatesInitializer)
+	}
 
 	return initializers, nil
 }
 
+// LogicalClustersTerminators returns the terminators for a LogicalCluster of a given
+// fully-qualified WorkspaceType reference.
+func LogicalClustersTerminators(
+	resolver workspacetypeexists.TransitiveTypeResolver,
+	getWorkspaceType func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error),
+	typePath logicalcluster.Path, typeName string,
+) ([]corev1alpha1.LogicalClusterTerminator, error) {
+	wt, err := getWorkspaceType(typePath, typeName)
+	if err != nil {
+		return nil, err
+	}
+	wtAliases, err := resolver.Resolve(wt)
+	if err != nil {
+		return nil, err
+	}
+
+	var terminators []corev1alpha1.LogicalClusterTerminator
+	for _, alias := range workspacetypeexists.WithoutOverridden(wtAliases, tenancyv1alpha1.WorkspaceTypeFieldTerminators) {
+		if alias.Spec.Terminator {
+			terminators = append(terminators, termination.TerminatorForType(alias))
+		}
+	}
+
+	return terminators, nil
+}
+
 func (r *schedulingReconciler) updateLogicalClusterPhase(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Path, phase corev1alpha1.LogicalClusterPhaseType) error {
 	logicalClusterAdminClient, err := r.kcpLogicalClusterAdminClientFor(shard)
 	if err != nil {
@@ -343,6 +441,33 @@ func isValidShard(_ *corev1alpha1.Shard) (valid bool, reason, message string) {
 	return true, "", ""
 }
 
+// SchedulingThresholds limit the usage of shards, as reported in their status, beyond which
+// no new logical clusters are placed onto them. Zero values mean no limit.
+type SchedulingThresholds struct {
+	MaxLogicalClusters  int64
+	MaxStorageSizeBytes int64
+	MinQPSHeadroom      int64
+}
+
+// exceededBy returns a message and true if the reported usage of the shard exceeds one of the
+// thresholds. Shards without reported usage never exceed them.
+func (t SchedulingThresholds) exceededBy(shard *corev1alpha1.Shard) (string, bool) {
+	usage := shard.Status.Usage
+	if usage == nil {
+		return "", false
+	}
+	if t.MaxLogicalClusters > 0 && usage.LogicalClusters >= t.MaxLogicalClusters {
+		return fmt.Sprintf("shard has %d logical clusters, the maximum is %d", usage.LogicalClusters, t.MaxLogicalClusters), true
+	}
+	if t.MaxStorageSizeBytes > 0 && usage.StorageSizeBytes != nil && *usage.StorageSizeBytes >= t.MaxStorageSizeBytes {
+		return fmt.Sprintf("shard storage has %d bytes, the maximum is %d", *usage.StorageSizeBytes, t.MaxStorageSizeBytes), true
+	}
+	if t.MinQPSHeadroom > 0 && usage.QPSHeadroom != nil && *usage.QPSHeadroom < t.MinQPSHeadroom {
+		return fmt.Sprintf("shard has a QPS headroom of %d, the minimum is %d", *usage.QPSHeadroom, t.MinQPSHeadroom), true
+	}
+	return "", false
+}
+
 func randomClusterName(path logicalcluster.Path) (logicalcluster.Name, error) {
 	token := make([]byte, 32)
 	_, err := rand.Read(token)
@@ -279,6 +279,32 @@ func TestReconcileScheduling(t *testing.T) {
 			},
 			expectedStatus: reconcileStatusContinue,
 		},
+		{
+			name: "only a cordoned shard is available, the ws is unscheduled",
+			initialShards: []*corev1alpha1.Shard{func() *corev1alpha1.Shard {
+				s := shard("amber")
+				s.Spec.Cordoned = true
+				return s
+			}()},
+			targetWorkspace:      workspace("foo"),
+			targetLogicalCluster: &corev1alpha1.LogicalCluster{},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1alpha1.Workspace) {
+				t.Helper()
+
+				clearLastTransitionTimeOnWsConditions(wsAfterReconciliation)
+				initialWS.Status.Conditions = append(initialWS.Status.Conditions, conditionsapi.Condition{
+					Type:     tenancyv1alpha1.WorkspaceScheduled,
+					Severity: conditionsapi.ConditionSeverityError,
+					Status:   corev1.ConditionFalse,
+					Reason:   tenancyv1alpha1.WorkspaceReasonUnschedulable,
+					Message:  "No available shards to schedule the workspace",
+				})
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusContinue,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -333,6 +359,13 @@ func TestReconcileScheduling(t *testing.T) {
 					}
 					return nil, kerrors.NewNotFound(tenancyv1alpha1.SchemeGroupVersion.WithResource("Shard").GroupResource(), hash)
 				},
+				placer: &placer{
+					defaultStrategy:  tenancyv1alpha1.WorkspacePlacementRandom,
+					getWorkspaceType: getType,
+					listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.Workspace, error) {
+						return nil, nil
+					},
+				},
 				getWorkspaceType: getType,
 				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
 					if clusterName != core.RootCluster {
@@ -591,3 +624,33 @@ func shardNameToBase36Sha224(name string) string {
 	base36hash := strings.ToLower(base36.EncodeBytes(hash[:]))
 	return base36hash[:8]
 }
+
+func TestSchedulingThresholds(t *testing.T) {
+	int64Ptr := func(i int64) *int64 { return &i }
+	thresholds := SchedulingThresholds{MaxLogicalClusters: 100, MaxStorageSizeBytes: 1000, MinQPSHeadroom: 10}
+
+	for _, testCase := range []struct {
+		name         string
+		usage        *corev1alpha1.ShardUsage
+		wantExceeded bool
+	}{
+		{name: "no usage reported"},
+		{name: "below thresholds", usage: &corev1alpha1.ShardUsage{LogicalClusters: 99, StorageSizeBytes: int64Ptr(999), QPSHeadroom: int64Ptr(10)}},
+		{name: "unknown storage and qps", usage: &corev1alpha1.ShardUsage{LogicalClusters: 1}},
+		{name: "too many logical clusters", usage: &corev1alpha1.ShardUsage{LogicalClusters: 100}, wantExceeded: true},
+		{name: "storage too large", usage: &corev1alpha1.ShardUsage{StorageSizeBytes: int64Ptr(1000)}, wantExceeded: true},
+		{name: "qps headroom too small", usage: &corev1alpha1.ShardUsage{QPSHeadroom: int64Ptr(9)}, wantExceeded: true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := shard("amber")
+			s.Status.Usage = testCase.usage
+			_, exceeded := thresholds.exceededBy(s)
+			if exceeded != testCase.wantExceeded {
+				t.Errorf("expected exceeded=%v, got %v", testCase.wantExceeded, exceeded)
+			}
+			if _, exceeded := (SchedulingThresholds{}).exceededBy(s); exceeded {
+				t.Errorf("expected no limits without thresholds")
+			}
+		})
+	}
+}
@@ -22,9 +22,9 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilserrors "k8s.io/apimachinery/pkg/util/errors"
 
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
@@ -45,9 +45,16 @@ type reconciler interface {
 // workspace replated operations. For now it has single reconciler that updates the status of the
 // workspace based on the mount status.
 func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspace) (bool, error) {
-	getMountObjectFunc := func(ctx context.Context, cluster logicalcluster.Path, ref *v1.ObjectReference) (*unstructured.Unstructured, error) {
+	getMountObjectFunc := func(ctx context.Context, cluster logicalcluster.Path, ref *tenancyv1alpha1.ObjectReference) (*unstructured.Unstructured, error) {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, err
+		}
 		resourceName := strings.ToLower(ref.Kind) + "s"
-		return c.dynamicClusterClient.Cluster(cluster).Resource(ref.GroupVersionKind().GroupVersion().WithResource(resourceName)).Get(ctx, ref.Name, metav1.GetOptions{})
+		if ref.Namespace != "" {
+			return c.dynamicClusterClient.Cluster(cluster).Resource(gv.WithResource(resourceName)).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		}
+		return c.dynamicClusterClient.Cluster(cluster).Resource(gv.WithResource(resourceName)).Get(ctx, ref.Name, metav1.GetOptions{})
 	}
 
 	reconcilers := []reconciler{
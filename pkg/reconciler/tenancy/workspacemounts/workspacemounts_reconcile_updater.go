@@ -21,67 +21,85 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // workspaceStatusUpdater updates the status of the workspace based on the mount status.
-// TODO(mjudeikis): For now this only updates the status of the mount annotation. In the future
-// we should add second reconciler that will update the status of the workspace so triggering
-// it to be "not ready" if the mount is not ready.
+// A mounted workspace is only Ready when its mount is ready; otherwise it is Unavailable.
 type workspaceStatusUpdater struct {
-	getMountObject func(ctx context.Context, cluster logicalcluster.Path, ref *v1.ObjectReference) (*unstructured.Unstructured, error)
+	getMountObject func(ctx context.Context, cluster logicalcluster.Path, ref *tenancyv1alpha1.ObjectReference) (*unstructured.Unstructured, error)
 }
 
 func (r *workspaceStatusUpdater) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
-	var mount *tenancyv1alpha1.Mount
-	if workspace.Annotations != nil {
-		if v, ok := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey]; ok {
-			var err error
-			mount, err = tenancyv1alpha1.ParseTenancyMountAnnotation(v)
-			if err != nil {
-				return reconcileStatusStopAndRequeue, err
-			}
-		}
+	mount := workspace.GetMount()
+	if mount == nil {
+		// not a mounted workspace, nothing to do
+		return reconcileStatusContinue, nil
+	}
+	if !workspace.DeletionTimestamp.IsZero() {
+		return reconcileStatusContinue, nil
 	}
 
-	if mount == nil {
-		// no mount annotation, nothing to do
+	ref := &mount.Reference
+	obj, err := r.getMountObject(ctx, logicalcluster.From(workspace).Path(), ref)
+	if apierrors.IsNotFound(err) {
+		workspace.Status.Mount = nil
+		conditions.MarkFalse(
+			workspace,
+			tenancyv1alpha1.MountConditionReady,
+			tenancyv1alpha1.MountObjectNotFoundReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"%s %s %q not found", ref.APIVersion, ref.Kind, ref.Name,
+		)
+		markUnavailable(workspace)
 		return reconcileStatusContinue, nil
 	}
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	// we are working on status field. As this is "loose coupling, we parse it out"
+	// Mount point implementors must expose status.{URL,phase} as a fields.
+	// We are not interested in the rest of the status.
+	statusPhase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	// url might not be there if the mount is not ready
+	statusURL, _, _ := unstructured.NestedString(obj.Object, "status", "URL")
+
+	workspace.Status.Mount = &tenancyv1alpha1.MountStatus{
+		Phase: tenancyv1alpha1.MountPhaseType(statusPhase),
+		URL:   statusURL,
+	}
 
-	switch {
-	case !workspace.DeletionTimestamp.IsZero():
+	if workspace.Status.Mount.Phase != tenancyv1alpha1.MountPhaseReady || statusURL == "" {
+		conditions.MarkFalse(
+			workspace,
+			tenancyv1alpha1.MountConditionReady,
+			tenancyv1alpha1.MountObjectNotReadyReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"%s %s %q is not ready: phase %q", ref.APIVersion, ref.Kind, ref.Name, statusPhase,
+		)
+		markUnavailable(workspace)
 		return reconcileStatusContinue, nil
-	case mount != nil && mount.MountSpec.Reference != nil:
-		obj, err := r.getMountObject(ctx, logicalcluster.From(workspace).Path(), mount.MountSpec.Reference)
-		if err != nil {
-			return reconcileStatusStopAndRequeue, err
-		}
-
-		// we are working on status field. As this is "loose coupling, we parse it out"
-		// Mount point implementors must expose status.{URL,phase} as a fields.
-		// We are not interested in the rest of the status.
-		status, ok := obj.Object["status"].(map[string]interface{})
-		if !ok {
-			return reconcileStatusStopAndRequeue, nil
-		}
-		statusPhase, ok := status["phase"].(string)
-		if !ok {
-			return reconcileStatusStopAndRequeue, nil
-		}
-		// url might not be there if the mount is not ready
-		statusURL, ok := status["URL"].(string)
-		if !ok {
-			statusURL = ""
-		}
-		mount.MountStatus.Phase = tenancyv1alpha1.MountPhaseType(statusPhase)
-		mount.MountStatus.URL = statusURL
-
-		workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey] = mount.String()
+	}
+
+	conditions.MarkTrue(workspace, tenancyv1alpha1.MountConditionReady)
+	if workspace.Status.Phase == corev1alpha1.LogicalClusterPhaseUnavailable {
+		workspace.Status.Phase = corev1alpha1.LogicalClusterPhaseReady
 	}
 
 	return reconcileStatusContinue, nil
 }
+
+// markUnavailable moves a Ready workspace to Unavailable. Workspaces that are still
+// scheduling or initializing are left alone.
+func markUnavailable(workspace *tenancyv1alpha1.Workspace) {
+	if workspace.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
+		workspace.Status.Phase = corev1alpha1.LogicalClusterPhaseUnavailable
+	}
+}
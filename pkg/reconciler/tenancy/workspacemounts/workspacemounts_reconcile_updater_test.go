@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestWorkspaceStatusUpdater(t *testing.T) {
+	tests := map[string]struct {
+		mount      *tenancyv1alpha1.Mount
+		annotation string
+		phase      corev1alpha1.LogicalClusterPhaseType
+		mountObj   *unstructured.Unstructured
+		wantPhase  corev1alpha1.LogicalClusterPhaseType
+		wantStatus *tenancyv1alpha1.MountStatus
+		wantReady  *bool
+	}{
+		"no mount": {
+			phase:     corev1alpha1.LogicalClusterPhaseReady,
+			wantPhase: corev1alpha1.LogicalClusterPhaseReady,
+		},
+		"mount object not found": {
+			mount:     &tenancyv1alpha1.Mount{Reference: tenancyv1alpha1.ObjectReference{APIVersion: "proxy.kcp.io/v1alpha1", Kind: "KubeCluster", Name: "prod"}},
+			phase:     corev1alpha1.LogicalClusterPhaseReady,
+			wantPhase: corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantReady: ptr.To(false),
+		},
+		"mount object connecting": {
+			mount:      &tenancyv1alpha1.Mount{Reference: tenancyv1alpha1.ObjectReference{APIVersion: "proxy.kcp.io/v1alpha1", Kind: "KubeCluster", Name: "prod"}},
+			phase:      corev1alpha1.LogicalClusterPhaseReady,
+			mountObj:   newMountObject("Connecting", ""),
+			wantPhase:  corev1alpha1.LogicalClusterPhaseUnavailable,
+			wantStatus: &tenancyv1alpha1.MountStatus{Phase: tenancyv1alpha1.MountPhaseConnecting},
+			wantReady:  ptr.To(false),
+		},
+		"mount object ready": {
+			mount:      &tenancyv1alpha1.Mount{Reference: tenancyv1alpha1.ObjectReference{APIVersion: "proxy.kcp.io/v1alpha1", Kind: "KubeCluster", Name: "prod"}},
+			phase:      corev1alpha1.LogicalClusterPhaseUnavailable,
+			mountObj:   newMountObject("Ready", "https://proxy.kcp.io/prod"),
+			wantPhase:  corev1alpha1.LogicalClusterPhaseReady,
+			wantStatus: &tenancyv1alpha1.MountStatus{Phase: tenancyv1alpha1.MountPhaseReady, URL: "https://proxy.kcp.io/prod"},
+			wantReady:  ptr.To(true),
+		},
+		"mount from deprecated annotation": {
+			annotation: `{"spec":{"ref":{"apiVersion":"proxy.kcp.io/v1alpha1","kind":"KubeCluster","name":"prod"}},"status":{}}`,
+			phase:      corev1alpha1.LogicalClusterPhaseUnavailable,
+			mountObj:   newMountObject("Ready", "https://proxy.kcp.io/prod"),
+			wantPhase:  corev1alpha1.LogicalClusterPhaseReady,
+			wantStatus: &tenancyv1alpha1.MountStatus{Phase: tenancyv1alpha1.MountPhaseReady, URL: "https://proxy.kcp.io/prod"},
+			wantReady:  ptr.To(true),
+		},
+		"initializing workspace stays initializing": {
+			mount:      &tenancyv1alpha1.Mount{Reference: tenancyv1alpha1.ObjectReference{APIVersion: "proxy.kcp.io/v1alpha1", Kind: "KubeCluster", Name: "prod"}},
+			phase:      corev1alpha1.LogicalClusterPhaseInitializing,
+			mountObj:   newMountObject("Connecting", ""),
+			wantPhase:  corev1alpha1.LogicalClusterPhaseInitializing,
+			wantStatus: &tenancyv1alpha1.MountStatus{Phase: tenancyv1alpha1.MountPhaseConnecting},
+			wantReady:  ptr.To(false),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &workspaceStatusUpdater{
+				getMountObject: func(ctx context.Context, cluster logicalcluster.Path, ref *tenancyv1alpha1.ObjectReference) (*unstructured.Unstructured, error) {
+					require.Equal(t, "root:org", cluster.String())
+					if tc.mountObj == nil {
+						return nil, apierrors.NewNotFound(schema.GroupResource{Group: "proxy.kcp.io", Resource: "kubeclusters"}, ref.Name)
+					}
+					require.Equal(t, "prod", ref.Name)
+					return tc.mountObj, nil
+				},
+			}
+
+			annotations := map[string]string{logicalcluster.AnnotationKey: "root:org"}
+			if tc.annotation != "" {
+				annotations[tenancyv1alpha1.ExperimentalWorkspaceMountAnnotationKey] = tc.annotation
+			}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ws",
+					Annotations: annotations,
+				},
+				Spec:   tenancyv1alpha1.WorkspaceSpec{Mount: tc.mount},
+				Status: tenancyv1alpha1.WorkspaceStatus{Phase: tc.phase},
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, tc.wantPhase, ws.Status.Phase)
+			require.Equal(t, tc.wantStatus, ws.Status.Mount)
+			if tc.wantReady == nil {
+				require.Nil(t, conditions.Get(ws, tenancyv1alpha1.MountConditionReady))
+			} else {
+				require.Equal(t, *tc.wantReady, conditions.IsTrue(ws, tenancyv1alpha1.MountConditionReady))
+			}
+		})
+	}
+}
+
+func newMountObject(phase, url string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase": phase,
+		},
+	}}
+	if url != "" {
+		_ = unstructured.SetNestedField(obj.Object, url, "status", "URL")
+	}
+	return obj
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-workspacequota"
+)
+
+// NewController returns a new controller computing the usage of WorkspaceQuotas.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	workspaceQuotaInformer tenancyv1alpha1informers.WorkspaceQuotaClusterInformer,
+	logicalClusterInformer, globalLogicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+
+		workspaceQuotaLister:  workspaceQuotaInformer.Lister(),
+		workspaceQuotaIndexer: workspaceQuotaInformer.Informer().GetIndexer(),
+
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		listLogicalClusters: func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error) {
+			local, err := indexers.ByIndex[*corev1alpha1.LogicalCluster](logicalClusterInformer.Informer().GetIndexer(), byPathAndParents, path.String())
+			if err != nil {
+				return nil, err
+			}
+			global, err := indexers.ByIndex[*corev1alpha1.LogicalCluster](globalLogicalClusterInformer.Informer().GetIndexer(), byPathAndParents, path.String())
+			if err != nil {
+				return nil, err
+			}
+			return append(local, global...), nil
+		},
+
+		commit: committer.NewCommitter[*WorkspaceQuota, Patcher, *WorkspaceQuotaSpec, *WorkspaceQuotaStatus](kcpClusterClient.TenancyV1alpha1().WorkspaceQuotas()),
+	}
+
+	indexers.AddIfNotPresentOrDie(workspaceQuotaInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+	})
+	for _, informer := range []corev1alpha1informers.LogicalClusterClusterInformer{logicalClusterInformer, globalLogicalClusterInformer} {
+		indexers.AddIfNotPresentOrDie(informer.Informer().GetIndexer(), cache.Indexers{
+			byPathAndParents: indexByPathAndParents,
+		})
+	}
+
+	_, _ = workspaceQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueWorkspaceQuota(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueWorkspaceQuota(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueWorkspaceQuota(obj) },
+	})
+
+	// the usage of the logical clusters of other shards is replicated through the cache server.
+	for _, informer := range []corev1alpha1informers.LogicalClusterClusterInformer{logicalClusterInformer, globalLogicalClusterInformer} {
+		_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { c.enqueueParentWorkspaceQuotas(obj) },
+			UpdateFunc: func(oldObj, obj interface{}) {
+				// a moved logical cluster does not count for the quotas of its old parents anymore.
+				c.enqueueParentWorkspaceQuotas(oldObj)
+				c.enqueueParentWorkspaceQuotas(obj)
+			},
+			DeleteFunc: func(obj interface{}) { c.enqueueParentWorkspaceQuotas(obj) },
+		})
+	}
+
+	return c, nil
+}
+
+type WorkspaceQuota = tenancyv1alpha1.WorkspaceQuota
+type WorkspaceQuotaSpec = tenancyv1alpha1.WorkspaceQuotaSpec
+type WorkspaceQuotaStatus = tenancyv1alpha1.WorkspaceQuotaStatus
+type Patcher = tenancyv1alpha1client.WorkspaceQuotaInterface
+type Resource = committer.Resource[*WorkspaceQuotaSpec, *WorkspaceQuotaStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller reconciles WorkspaceQuotas. It aggregates the usage recorded by the usage
+// controllers of all shards for the logical clusters below the workspace of a WorkspaceQuota
+// into its status.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	workspaceQuotaLister  tenancyv1alpha1listers.WorkspaceQuotaClusterLister
+	workspaceQuotaIndexer cache.Indexer
+
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	// listLogicalClusters returns the local and the replicated LogicalClusters at or below the
+	// given path. A LogicalCluster of this shard can be returned twice.
+	listLogicalClusters func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error)
+
+	commit CommitFunc
+}
+
+func (c *controller) enqueueWorkspaceQuota(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing WorkspaceQuota")
+	c.queue.Add(key)
+}
+
+// enqueueParentWorkspaceQuotas enqueues all WorkspaceQuotas of this shard in the workspace
+// of the given LogicalCluster and in all its parents.
+func (c *controller) enqueueParentWorkspaceQuotas(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	logicalCluster, ok := obj.(*corev1alpha1.LogicalCluster)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	for path := logicalClusterPath(logicalCluster); ; {
+		quotas, err := indexers.ByIndex[*tenancyv1alpha1.WorkspaceQuota](c.workspaceQuotaIndexer, indexers.ByLogicalClusterPath, path.String())
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		for _, quota := range quotas {
+			key, err := kcpcache.MetaClusterNamespaceKeyFunc(quota)
+			if err != nil {
+				runtime.HandleError(err)
+				continue
+			}
+			logging.WithQueueKey(logger, key).V(4).Info("queueing WorkspaceQuota because of LogicalCluster change", "cluster", logicalcluster.From(logicalCluster))
+			c.queue.Add(key)
+		}
+
+		parent, ok := path.Parent()
+		if !ok || parent.Equal(path) {
+			return
+		}
+		path = parent
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.workspaceQuotaLister.Cluster(clusterName).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.reconcile(ctx, obj); err != nil {
+		return err
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	return c.commit(ctx, oldResource, newResource)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+const (
+	// byPathAndParents indexes LogicalClusters by their path and the paths of all their parents.
+	byPathAndParents = "workspacequota-byPathAndParents"
+)
+
+// indexByPathAndParents is an index function that indexes a LogicalCluster by its path and
+// the paths of all its parents, i.e. by the workspaces whose quotas it counts for.
+func indexByPathAndParents(obj interface{}) ([]string, error) {
+	logicalCluster, ok := obj.(*corev1alpha1.LogicalCluster)
+	if !ok {
+		return []string{}, fmt.Errorf("obj is supposed to be a LogicalCluster, but is %T", obj)
+	}
+
+	var paths []string
+	for path := logicalClusterPath(logicalCluster); ; {
+		paths = append(paths, path.String())
+		parent, ok := path.Parent()
+		if !ok || parent.Equal(path) {
+			return paths, nil
+		}
+		path = parent
+	}
+}
+
+// logicalClusterPath returns the canonical path of the given LogicalCluster.
+func logicalClusterPath(logicalCluster *corev1alpha1.LogicalCluster) logicalcluster.Path {
+	if path := logicalcluster.NewPath(logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+		return path
+	}
+	return logicalcluster.From(logicalCluster).Path()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// reconcile sums up the usage recorded in the LogicalClusters at or below the workspace of the
+// quota, on this and on other shards, and records it in the status. Logical clusters whose
+// shard has not recorded their usage yet are not counted.
+func (c *controller) reconcile(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota) error {
+	logger := klog.FromContext(ctx)
+
+	path, err := quotaPath(quota, c.getLogicalCluster)
+	if err != nil {
+		return err
+	}
+	logicalClusters, err := c.listLogicalClusters(path)
+	if err != nil {
+		return err
+	}
+
+	var workspaces, apiBindings, crds int64
+	seen := sets.New[logicalcluster.Name]()
+	for _, logicalCluster := range logicalClusters {
+		clusterName := logicalcluster.From(logicalCluster)
+		if seen.Has(clusterName) {
+			continue
+		}
+		seen.Insert(clusterName)
+
+		value, found := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey]
+		if !found {
+			continue
+		}
+		var usage tenancyv1alpha1.WorkspaceQuotaResources
+		if err := json.Unmarshal([]byte(value), &usage); err != nil {
+			logger.Error(err, "failed to decode quota usage", "cluster", clusterName)
+			continue
+		}
+
+		// workspaces are counted in the workspace of the quota too, everything else only below.
+		workspaces += ptr.Deref(usage.Workspaces, 0)
+		if clusterName != logicalcluster.From(quota) {
+			apiBindings += ptr.Deref(usage.APIBindings, 0)
+			crds += ptr.Deref(usage.CustomResourceDefinitions, 0)
+		}
+	}
+
+	logger.V(4).Info("computed usage", "workspaces", workspaces, "apiBindings", apiBindings, "customResourceDefinitions", crds)
+	quota.Status.Used = tenancyv1alpha1.WorkspaceQuotaResources{
+		Workspaces:                &workspaces,
+		APIBindings:               &apiBindings,
+		CustomResourceDefinitions: &crds,
+	}
+
+	return nil
+}
+
+// quotaPath returns the path of the workspace of the given WorkspaceQuota.
+func quotaPath(quota *tenancyv1alpha1.WorkspaceQuota, getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)) (logicalcluster.Path, error) {
+	if path := logicalcluster.NewPath(quota.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+		return path, nil
+	}
+	clusterName := logicalcluster.From(quota)
+	logicalCluster, err := getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		return clusterName.Path(), nil
+	} else if err != nil {
+		return logicalcluster.Path{}, err
+	}
+	return logicalClusterPath(logicalCluster), nil
+}
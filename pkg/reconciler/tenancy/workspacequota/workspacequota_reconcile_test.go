@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcile(t *testing.T) {
+	newLogicalCluster := func(cluster, path, usage string) *corev1alpha1.LogicalCluster {
+		lc := &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: corev1alpha1.LogicalClusterName,
+				Annotations: map[string]string{
+					logicalcluster.AnnotationKey:         cluster,
+					core.LogicalClusterPathAnnotationKey: path,
+				},
+			},
+		}
+		if usage != "" {
+			lc.Annotations[tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey] = usage
+		}
+		return lc
+	}
+
+	// org is on this shard, team-b and project on another one, team-a on both
+	// (replicated from here), and pending has not recorded its usage yet.
+	logicalClusters := map[string][]*corev1alpha1.LogicalCluster{
+		"root:org": {
+			newLogicalCluster("org", "root:org", `{"workspaces":3,"apiBindings":5,"customResourceDefinitions":7}`),
+			newLogicalCluster("team-a", "root:org:team-a", `{"workspaces":1,"apiBindings":2,"customResourceDefinitions":0}`),
+			newLogicalCluster("pending", "root:org:pending", ""),
+			newLogicalCluster("team-a", "root:org:team-a", `{"workspaces":1,"apiBindings":2,"customResourceDefinitions":0}`),
+			newLogicalCluster("team-b", "root:org:team-b", `{"workspaces":1,"apiBindings":0,"customResourceDefinitions":3}`),
+			newLogicalCluster("project", "root:org:team-a:project", `{"workspaces":0,"apiBindings":1,"customResourceDefinitions":0}`),
+		},
+	}
+
+	c := &controller{
+		listLogicalClusters: func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusters[path.String()], nil
+		},
+	}
+
+	quota := &tenancyv1alpha1.WorkspaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "quota",
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey:         "org",
+				core.LogicalClusterPathAnnotationKey: "root:org",
+			},
+		},
+	}
+	require.NoError(t, c.reconcile(context.Background(), quota))
+	require.Equal(t, tenancyv1alpha1.WorkspaceQuotaResources{
+		Workspaces:                ptr.To[int64](5),
+		APIBindings:               ptr.To[int64](3),
+		CustomResourceDefinitions: ptr.To[int64](3),
+	}, quota.Status.Used)
+}
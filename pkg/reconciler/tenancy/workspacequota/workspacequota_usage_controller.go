@@ -0,0 +1,306 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpapiextensionsv1informers "github.com/kcp-dev/client-go/apiextensions/informers/apiextensions/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/cache/replication"
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/core/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	UsageControllerName = "kcp-workspacequota-usage"
+)
+
+// NewUsageController returns a new controller publishing the usage of the logical clusters
+// of this shard below a WorkspaceQuota on their LogicalCluster, and replicating them to the
+// cache server, such that the shard of the WorkspaceQuota can aggregate them.
+func NewUsageController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	workspaceQuotaInformer, globalWorkspaceQuotaInformer tenancyv1alpha1informers.WorkspaceQuotaClusterInformer,
+	workspaceInformer tenancyv1alpha1informers.WorkspaceClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
+) (*usageController, error) {
+	c := &usageController{
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), UsageControllerName),
+
+		logicalClusterLister:  logicalClusterInformer.Lister(),
+		logicalClusterIndexer: logicalClusterInformer.Informer().GetIndexer(),
+
+		hasQuota: func(path logicalcluster.Path) (bool, error) {
+			for _, indexer := range []cache.Indexer{workspaceQuotaInformer.Informer().GetIndexer(), globalWorkspaceQuotaInformer.Informer().GetIndexer()} {
+				quotas, err := indexer.ByIndex(indexers.ByLogicalClusterPath, path.String())
+				if err != nil {
+					return false, err
+				}
+				if len(quotas) > 0 {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		countWorkspaces: func(clusterName logicalcluster.Name) (int, error) {
+			workspaces, err := workspaceInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			return len(workspaces), err
+		},
+		countAPIBindings: func(clusterName logicalcluster.Name) (int, error) {
+			bindings, err := apiBindingInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			return len(bindings), err
+		},
+		countCRDs: func(clusterName logicalcluster.Name) (int, error) {
+			crds, err := crdInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			return len(crds), err
+		},
+
+		commit: committer.NewCommitter[*LogicalCluster, LogicalClusterPatcher, *LogicalClusterSpec, *LogicalClusterStatus](kcpClusterClient.CoreV1alpha1().LogicalClusters()),
+	}
+
+	indexers.AddIfNotPresentOrDie(logicalClusterInformer.Informer().GetIndexer(), cache.Indexers{
+		byPathAndParents: indexByPathAndParents,
+	})
+	for _, informer := range []tenancyv1alpha1informers.WorkspaceQuotaClusterInformer{workspaceQuotaInformer, globalWorkspaceQuotaInformer} {
+		indexers.AddIfNotPresentOrDie(informer.Informer().GetIndexer(), cache.Indexers{
+			indexers.ByLogicalClusterPath: indexers.IndexByLogicalClusterPath,
+		})
+	}
+
+	_, _ = logicalClusterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: replication.IsNoSystemClusterName,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueLogicalCluster(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueLogicalCluster(obj) },
+		},
+	})
+
+	for _, informer := range []tenancyv1alpha1informers.WorkspaceQuotaClusterInformer{workspaceQuotaInformer, globalWorkspaceQuotaInformer} {
+		_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueLogicalClustersBelow(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueLogicalClustersBelow(obj) },
+		})
+	}
+
+	for _, informer := range []cache.SharedIndexInformer{
+		workspaceInformer.Informer(),
+		apiBindingInformer.Informer(),
+		crdInformer.Informer(),
+	} {
+		_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueOwningLogicalCluster(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueOwningLogicalCluster(obj) },
+		})
+	}
+
+	return c, nil
+}
+
+type LogicalCluster = corev1alpha1.LogicalCluster
+type LogicalClusterSpec = corev1alpha1.LogicalClusterSpec
+type LogicalClusterStatus = corev1alpha1.LogicalClusterStatus
+type LogicalClusterPatcher = corev1alpha1client.LogicalClusterInterface
+type LogicalClusterResource = committer.Resource[*LogicalClusterSpec, *LogicalClusterStatus]
+type LogicalClusterCommitFunc = func(context.Context, *LogicalClusterResource, *LogicalClusterResource) error
+
+// usageController reconciles the LogicalClusters of this shard. It records the objects counted
+// by WorkspaceQuotas in each logical cluster at or below the workspace of a WorkspaceQuota.
+type usageController struct {
+	queue workqueue.RateLimitingInterface
+
+	logicalClusterLister  corev1alpha1listers.LogicalClusterClusterLister
+	logicalClusterIndexer cache.Indexer
+
+	hasQuota         func(path logicalcluster.Path) (bool, error)
+	countWorkspaces  func(clusterName logicalcluster.Name) (int, error)
+	countAPIBindings func(clusterName logicalcluster.Name) (int, error)
+	countCRDs        func(clusterName logicalcluster.Name) (int, error)
+
+	commit LogicalClusterCommitFunc
+}
+
+func (c *usageController) enqueueLogicalCluster(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), UsageControllerName), key)
+	logger.V(4).Info("queueing LogicalCluster")
+	c.queue.Add(key)
+}
+
+// enqueueOwningLogicalCluster enqueues the LogicalCluster of the logical cluster of the given object.
+func (c *usageController) enqueueOwningLogicalCluster(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logicalCluster, err := c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			runtime.HandleError(err)
+		}
+		return
+	}
+	c.enqueueLogicalCluster(logicalCluster)
+}
+
+// enqueueLogicalClustersBelow enqueues the LogicalClusters of this shard in the workspace of
+// the given WorkspaceQuota and below.
+func (c *usageController) enqueueLogicalClustersBelow(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	quota, ok := obj.(*tenancyv1alpha1.WorkspaceQuota)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return
+	}
+
+	path, err := quotaPath(quota, func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	})
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	logicalClusters, err := indexers.ByIndex[*corev1alpha1.LogicalCluster](c.logicalClusterIndexer, byPathAndParents, path.String())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, logicalCluster := range logicalClusters {
+		c.enqueueLogicalCluster(logicalCluster)
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *usageController) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), UsageControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *usageController) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *usageController) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, UsageControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(UsageControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", UsageControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *usageController) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.logicalClusterLister.Cluster(clusterName).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.reconcile(ctx, obj); err != nil {
+		return err
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &LogicalClusterResource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &LogicalClusterResource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	return c.commit(ctx, oldResource, newResource)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	kcpcorehelper "github.com/kcp-dev/kcp/sdk/apis/core/helper"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// replicateValue is the value of the replicate annotation of LogicalClusters replicated for
+// WorkspaceQuotas. It differs from the one of WorkspaceTypes to not unset theirs.
+var replicateValue = tenancyv1alpha1.Resource("workspacequotas").String()
+
+// reconcile records the usage of the logical cluster in its LogicalCluster and replicates
+// it, if the logical cluster is at or below the workspace of a WorkspaceQuota. Otherwise,
+// a previously recorded usage is removed.
+func (c *usageController) reconcile(ctx context.Context, logicalCluster *corev1alpha1.LogicalCluster) error {
+	logger := klog.FromContext(ctx)
+
+	underQuota, err := c.isUnderQuota(logicalClusterPath(logicalCluster))
+	if err != nil {
+		return err
+	}
+
+	if !underQuota {
+		if _, found := logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey]; found {
+			logger.V(3).Info("removing quota usage")
+			delete(logicalCluster.Annotations, tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey)
+		}
+		logicalCluster.Annotations, _ = kcpcorehelper.DontReplicateFor(logicalCluster.Annotations, replicateValue)
+		return nil
+	}
+
+	clusterName := logicalcluster.From(logicalCluster)
+	workspaces, err := c.countWorkspaces(clusterName)
+	if err != nil {
+		return err
+	}
+	apiBindings, err := c.countAPIBindings(clusterName)
+	if err != nil {
+		return err
+	}
+	crds, err := c.countCRDs(clusterName)
+	if err != nil {
+		return err
+	}
+	bs, err := json.Marshal(tenancyv1alpha1.WorkspaceQuotaResources{
+		Workspaces:                ptr.To(int64(workspaces)),
+		APIBindings:               ptr.To(int64(apiBindings)),
+		CustomResourceDefinitions: ptr.To(int64(crds)),
+	})
+	if err != nil {
+		return err
+	}
+
+	if logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey] != string(bs) {
+		logger.V(3).Info("recording quota usage", "usage", string(bs))
+		if logicalCluster.Annotations == nil {
+			logicalCluster.Annotations = map[string]string{}
+		}
+		logicalCluster.Annotations[tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey] = string(bs)
+	}
+	logicalCluster.Annotations, _ = kcpcorehelper.ReplicateFor(logicalCluster.Annotations, replicateValue)
+
+	return nil
+}
+
+// isUnderQuota returns whether there is a WorkspaceQuota in the workspace of the given path
+// or in any of its parents.
+func (c *usageController) isUnderQuota(path logicalcluster.Path) (bool, error) {
+	for {
+		found, err := c.hasQuota(path)
+		if err != nil || found {
+			return found, err
+		}
+
+		parent, ok := path.Parent()
+		if !ok || parent.Equal(path) {
+			return false, nil
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestUsageReconcile(t *testing.T) {
+	tests := map[string]struct {
+		path            string
+		annotations     map[string]string
+		wantAnnotations map[string]string
+	}{
+		"below a quota": {
+			path: "root:org:team-a",
+			wantAnnotations: map[string]string{
+				tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey: `{"workspaces":2,"apiBindings":1,"customResourceDefinitions":3}`,
+				core.ReplicateAnnotationKey:                           "workspacequotas.tenancy.kcp.io",
+			},
+		},
+		"workspace of a quota": {
+			path: "root:org",
+			annotations: map[string]string{
+				core.ReplicateAnnotationKey: "tenancy.kcp.io",
+			},
+			wantAnnotations: map[string]string{
+				tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey: `{"workspaces":2,"apiBindings":1,"customResourceDefinitions":3}`,
+				core.ReplicateAnnotationKey:                           "tenancy.kcp.io,workspacequotas.tenancy.kcp.io",
+			},
+		},
+		"moved away from a quota": {
+			path: "root:other:team-a",
+			annotations: map[string]string{
+				tenancyv1alpha1.LogicalClusterQuotaUsageAnnotationKey: `{"workspaces":2,"apiBindings":1,"customResourceDefinitions":3}`,
+				core.ReplicateAnnotationKey:                           "tenancy.kcp.io,workspacequotas.tenancy.kcp.io",
+			},
+			wantAnnotations: map[string]string{
+				core.ReplicateAnnotationKey: "tenancy.kcp.io",
+			},
+		},
+		"not below a quota": {
+			path:            "root:other",
+			wantAnnotations: map[string]string{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &usageController{
+				hasQuota: func(path logicalcluster.Path) (bool, error) {
+					return path.String() == "root:org", nil
+				},
+				countWorkspaces:  func(logicalcluster.Name) (int, error) { return 2, nil },
+				countAPIBindings: func(logicalcluster.Name) (int, error) { return 1, nil },
+				countCRDs:        func(logicalcluster.Name) (int, error) { return 3, nil },
+			}
+
+			annotations := map[string]string{
+				logicalcluster.AnnotationKey:         "team-a",
+				core.LogicalClusterPathAnnotationKey: tc.path,
+			}
+			for k, v := range tc.annotations {
+				annotations[k] = v
+			}
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: annotations,
+				},
+			}
+			require.NoError(t, c.reconcile(context.Background(), logicalCluster))
+
+			delete(logicalCluster.Annotations, logicalcluster.AnnotationKey)
+			delete(logicalCluster.Annotations, core.LogicalClusterPathAnnotationKey)
+			require.Equal(t, tc.wantAnnotations, logicalCluster.Annotations)
+		})
+	}
+}
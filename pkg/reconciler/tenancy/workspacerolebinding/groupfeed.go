@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Group feed types.
+const (
+	// GroupFeedFile reads the groups from a YAML or JSON file.
+	GroupFeedFile = "file"
+	// GroupFeedWebhook gets the groups as YAML or JSON from an HTTP endpoint.
+	GroupFeedWebhook = "webhook"
+	// GroupFeedSCIM lists the groups of a SCIM 2.0 service provider.
+	GroupFeedSCIM = "scim"
+)
+
+// GroupFeedTypes are the supported group feed types.
+var GroupFeedTypes = []string{GroupFeedFile, GroupFeedWebhook, GroupFeedSCIM}
+
+// scimPageSize is the number of groups requested per page from a SCIM service provider.
+const scimPageSize = 100
+
+// Groups maps the names of external groups to the user names of their members.
+type Groups map[string][]string
+
+// GroupFeed delivers the members of external groups.
+type GroupFeed interface {
+	Groups(ctx context.Context) (Groups, error)
+}
+
+// NewGroupFeed returns a group feed of the given type, reading from source, a file path for
+// file feeds and a URL otherwise. Webhook and SCIM requests send the bearer token in
+// tokenFile, if given. The token file is re-read for every request.
+func NewGroupFeed(feedType, source, tokenFile string) (GroupFeed, error) {
+	switch feedType {
+	case GroupFeedFile:
+		return &fileFeed{path: source}, nil
+	case GroupFeedWebhook, GroupFeedSCIM:
+		u, err := url.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group feed URL %q: %w", source, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("invalid group feed URL %q: scheme must be http or https", source)
+		}
+		client := &httpFeedClient{client: &http.Client{Timeout: 30 * time.Second}, tokenFile: tokenFile}
+		if feedType == GroupFeedWebhook {
+			return &webhookFeed{url: source, client: client}, nil
+		}
+		return &scimFeed{url: strings.TrimSuffix(source, "/") + "/Groups", client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown group feed type %q", feedType)
+	}
+}
+
+// groupsDocument is the format of file and webhook feeds.
+type groupsDocument struct {
+	Groups []struct {
+		Name    string   `json:"name"`
+		Members []string `json:"members"`
+	} `json:"groups"`
+}
+
+func parseGroups(data []byte) (Groups, error) {
+	var doc groupsDocument
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, err
+	}
+	groups := make(Groups, len(doc.Groups))
+	for _, g := range doc.Groups {
+		if g.Name == "" {
+			return nil, fmt.Errorf("group without name")
+		}
+		groups[g.Name] = append(groups[g.Name], g.Members...)
+	}
+	return groups, nil
+}
+
+type fileFeed struct {
+	path string
+}
+
+func (f *fileFeed) Groups(_ context.Context) (Groups, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read group feed %q: %w", f.path, err)
+	}
+	groups, err := parseGroups(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse group feed %q: %w", f.path, err)
+	}
+	return groups, nil
+}
+
+type httpFeedClient struct {
+	client    *http.Client
+	tokenFile string
+}
+
+func (c *httpFeedClient) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if c.tokenFile != "" {
+		token, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read group feed token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return body, nil
+}
+
+type webhookFeed struct {
+	url    string
+	client *httpFeedClient
+}
+
+func (f *webhookFeed) Groups(ctx context.Context) (Groups, error) {
+	body, err := f.client.get(ctx, f.url, "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group feed: %w", err)
+	}
+	groups, err := parseGroups(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse group feed from %s: %w", f.url, err)
+	}
+	return groups, nil
+}
+
+// scimListResponse is the subset of a SCIM 2.0 list response of groups used, see RFC 7644.
+type scimListResponse struct {
+	TotalResults int `json:"totalResults"`
+	Resources    []struct {
+		DisplayName string `json:"displayName"`
+		Members     []struct {
+			Value   string `json:"value"`
+			Display string `json:"display"`
+		} `json:"members"`
+	} `json:"Resources"`
+}
+
+type scimFeed struct {
+	url    string
+	client *httpFeedClient
+}
+
+// Groups lists all groups page by page. Members are identified by their display name, which
+// SCIM service providers usually set to the user name, falling back to the member id.
+func (f *scimFeed) Groups(ctx context.Context) (Groups, error) {
+	groups := Groups{}
+	for startIndex, seen := 1, 0; ; {
+		body, err := f.client.get(ctx, f.url+"?startIndex="+strconv.Itoa(startIndex)+"&count="+strconv.Itoa(scimPageSize), "application/scim+json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCIM groups: %w", err)
+		}
+		var list scimListResponse
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse SCIM groups from %s: %w", f.url, err)
+		}
+		for _, g := range list.Resources {
+			members := groups[g.DisplayName]
+			for _, m := range g.Members {
+				if m.Display != "" {
+					members = append(members, m.Display)
+				} else if m.Value != "" {
+					members = append(members, m.Value)
+				}
+			}
+			groups[g.DisplayName] = members
+		}
+		seen += len(list.Resources)
+		if len(list.Resources) == 0 || seen >= list.TotalResults {
+			return groups, nil
+		}
+		startIndex += len(list.Resources)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testGroups = `
+groups:
+- name: platform
+  members: ["alice", "bob"]
+- name: web
+  members: ["carol"]
+`
+
+func TestFileGroupFeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testGroups), 0600))
+
+	feed, err := NewGroupFeed(GroupFeedFile, path, "")
+	require.NoError(t, err)
+	groups, err := feed.Groups(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Groups{"platform": {"alice", "bob"}, "web": {"carol"}}, groups)
+
+	require.NoError(t, os.WriteFile(path, []byte("groups:\n- members: [alice]\n"), 0600))
+	_, err = feed.Groups(context.Background())
+	require.Error(t, err)
+}
+
+func TestWebhookGroupFeed(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"groups":[{"name":"platform","members":["alice"]}]}`)
+	}))
+	defer server.Close()
+
+	feed, err := NewGroupFeed(GroupFeedWebhook, server.URL, tokenFile)
+	require.NoError(t, err)
+	groups, err := feed.Groups(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Groups{"platform": {"alice"}}, groups)
+
+	feed, err = NewGroupFeed(GroupFeedWebhook, server.URL, "")
+	require.NoError(t, err)
+	_, err = feed.Groups(context.Background())
+	require.Error(t, err)
+}
+
+func TestSCIMGroupFeed(t *testing.T) {
+	// three groups, served in pages of at most two
+	pages := map[string]string{
+		"1": `{"totalResults":3,"Resources":[
+			{"displayName":"platform","members":[{"value":"1","display":"alice"},{"value":"2","display":"bob"}]},
+			{"displayName":"web","members":[{"value":"3"}]}]}`,
+		"3": `{"totalResults":3,"Resources":[{"displayName":"ops"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/scim/v2/Groups", r.URL.Path)
+		require.Equal(t, strconv.Itoa(scimPageSize), r.URL.Query().Get("count"))
+		page, found := pages[r.URL.Query().Get("startIndex")]
+		if !found {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/scim+json")
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	feed, err := NewGroupFeed(GroupFeedSCIM, server.URL+"/scim/v2/", "")
+	require.NoError(t, err)
+	groups, err := feed.Groups(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Groups{"platform": {"alice", "bob"}, "web": {"3"}, "ops": nil}, groups)
+}
+
+func TestNewGroupFeed(t *testing.T) {
+	_, err := NewGroupFeed("ldap", "ldap://example.com", "")
+	require.Error(t, err)
+	_, err = NewGroupFeed(GroupFeedSCIM, "ftp://example.com", "")
+	require.Error(t, err)
+}
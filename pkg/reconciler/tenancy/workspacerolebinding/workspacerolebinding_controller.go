@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcprbacinformers "github.com/kcp-dev/client-go/informers/rbac/v1"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-workspacerolebinding"
+)
+
+// NewController returns a new controller materializing WorkspaceRoleBindings as
+// ClusterRoleBindings of the members of their groups in the given group feed. The feed is
+// read every syncInterval. Without a feed, only the users of the bindings are bound.
+func NewController(
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	kcpClusterClient kcpclientset.ClusterInterface,
+	workspaceRoleBindingInformer tenancyv1alpha1informers.WorkspaceRoleBindingClusterInformer,
+	clusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
+	feed GroupFeed,
+	syncInterval time.Duration,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+
+		workspaceRoleBindingLister: workspaceRoleBindingInformer.Lister(),
+
+		feed:         feed,
+		syncInterval: syncInterval,
+
+		getClusterRoleBinding: func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRoleBinding, error) {
+			return clusterRoleBindingInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		createClusterRoleBinding: func(ctx context.Context, clusterName logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) error {
+			_, err := kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
+			return err
+		},
+		updateClusterRoleBinding: func(ctx context.Context, clusterName logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) error {
+			_, err := kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Update(ctx, binding, metav1.UpdateOptions{})
+			return err
+		},
+		deleteClusterRoleBinding: func(ctx context.Context, clusterName logicalcluster.Name, name string) error {
+			return kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Delete(ctx, name, metav1.DeleteOptions{})
+		},
+
+		commit: committer.NewCommitter[*WorkspaceRoleBinding, Patcher, *WorkspaceRoleBindingSpec, *WorkspaceRoleBindingStatus](kcpClusterClient.TenancyV1alpha1().WorkspaceRoleBindings()),
+	}
+
+	_, _ = workspaceRoleBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueue(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueue(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueue(obj) },
+	})
+
+	_, _ = clusterRoleBindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			binding, ok := obj.(*rbacv1.ClusterRoleBinding)
+			return ok && ownerName(binding) != ""
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueOwner(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueOwner(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueOwner(obj) },
+		},
+	})
+
+	return c, nil
+}
+
+type WorkspaceRoleBinding = tenancyv1alpha1.WorkspaceRoleBinding
+type WorkspaceRoleBindingSpec = tenancyv1alpha1.WorkspaceRoleBindingSpec
+type WorkspaceRoleBindingStatus = tenancyv1alpha1.WorkspaceRoleBindingStatus
+type Patcher = tenancyv1alpha1client.WorkspaceRoleBindingInterface
+type Resource = committer.Resource[*WorkspaceRoleBindingSpec, *WorkspaceRoleBindingStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller reconciles WorkspaceRoleBindings. It keeps a ClusterRoleBinding per
+// WorkspaceRoleBinding in sync with the members of its groups.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	workspaceRoleBindingLister tenancyv1alpha1listers.WorkspaceRoleBindingClusterLister
+
+	feed         GroupFeed
+	syncInterval time.Duration
+
+	// lock guards the last successfully read groups.
+	lock     sync.RWMutex
+	groups   Groups
+	groupErr error
+	lastSync time.Time
+
+	getClusterRoleBinding    func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRoleBinding, error)
+	createClusterRoleBinding func(ctx context.Context, clusterName logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) error
+	updateClusterRoleBinding func(ctx context.Context, clusterName logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) error
+	deleteClusterRoleBinding func(ctx context.Context, clusterName logicalcluster.Name, name string) error
+
+	commit CommitFunc
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing WorkspaceRoleBinding")
+	c.queue.Add(key)
+}
+
+// enqueueOwner enqueues the WorkspaceRoleBinding of a materialized ClusterRoleBinding.
+func (c *controller) enqueueOwner(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	binding, ok := obj.(*rbacv1.ClusterRoleBinding)
+	if !ok {
+		return
+	}
+	key := kcpcache.ToClusterAwareKey(logicalcluster.From(binding).String(), "", ownerName(binding))
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing WorkspaceRoleBinding because of ClusterRoleBinding change", "clusterRoleBinding", binding.Name)
+	c.queue.Add(key)
+}
+
+// ownerName returns the name of the WorkspaceRoleBinding controlling the ClusterRoleBinding,
+// or an empty string if there is none.
+func ownerName(binding *rbacv1.ClusterRoleBinding) string {
+	owner := metav1.GetControllerOf(binding)
+	if owner == nil || owner.Kind != "WorkspaceRoleBinding" || owner.APIVersion != tenancyv1alpha1.SchemeGroupVersion.String() {
+		return ""
+	}
+	return owner.Name
+}
+
+// syncGroups reads the group feed and enqueues all WorkspaceRoleBindings if the groups changed.
+// If the feed cannot be read, the previous groups are kept.
+func (c *controller) syncGroups(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	groups, err := c.feed.Groups(ctx)
+	c.lock.Lock()
+	if err != nil {
+		c.groupErr = err
+		c.lock.Unlock()
+		logger.Error(err, "failed to read group feed")
+		return
+	}
+	changed := c.groups == nil || !reflect.DeepEqual(c.groups, groups)
+	c.groups, c.groupErr, c.lastSync = groups, nil, time.Now()
+	c.lock.Unlock()
+
+	if !changed {
+		return
+	}
+	logger.V(2).Info("group feed changed, queueing all WorkspaceRoleBindings", "groups", len(groups))
+	bindings, err := c.workspaceRoleBindingLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, binding := range bindings {
+		c.enqueue(binding)
+	}
+}
+
+// currentGroups returns the last successfully read groups, or nil and the last error if the
+// feed has not been read successfully yet.
+func (c *controller) currentGroups() (Groups, time.Time, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.groups, c.lastSync, c.groupErr
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	if c.feed != nil {
+		go wait.UntilWithContext(ctx, c.syncGroups, c.syncInterval)
+	}
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.workspaceRoleBindingLister.Cluster(clusterName).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it, the ClusterRoleBinding is garbage collected
+		}
+		return err
+	}
+	if !obj.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.reconcile(ctx, obj); err != nil {
+		return err
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	return c.commit(ctx, oldResource, newResource)
+}
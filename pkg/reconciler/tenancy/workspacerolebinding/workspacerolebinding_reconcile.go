@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// ClusterRoleBindingName returns the name of the ClusterRoleBinding materialized for the
+// WorkspaceRoleBinding of the given name.
+func ClusterRoleBindingName(name string) string {
+	return "workspacerolebinding:" + name
+}
+
+// reconcile binds the cluster role of the WorkspaceRoleBinding to the current members of its
+// groups. If the group feed has not been read successfully yet, the ClusterRoleBinding is
+// left untouched.
+func (c *controller) reconcile(ctx context.Context, binding *tenancyv1alpha1.WorkspaceRoleBinding) error {
+	logger := klog.FromContext(ctx)
+
+	groups, lastSync, err := c.currentGroups()
+	if c.feed != nil && groups == nil {
+		message := "group feed has not been read yet"
+		if err != nil {
+			message += ": " + err.Error()
+		}
+		conditions.MarkFalse(binding, tenancyv1alpha1.WorkspaceRoleBindingSynced, tenancyv1alpha1.WorkspaceRoleBindingGroupFeedUnavailableReason, conditionsv1alpha1.ConditionSeverityWarning, "%s", message)
+		return nil
+	}
+
+	members := sets.New[string](binding.Spec.Users...)
+	var unknownGroups []string
+	for _, group := range binding.Spec.Groups {
+		groupMembers, found := groups[group]
+		if !found {
+			unknownGroups = append(unknownGroups, group)
+			continue
+		}
+		members.Insert(groupMembers...)
+	}
+
+	clusterName := logicalcluster.From(binding)
+	desired := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ClusterRoleBindingName(binding.Name),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: tenancyv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "WorkspaceRoleBinding",
+				Name:       binding.Name,
+				UID:        binding.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     binding.Spec.RoleRef.Name,
+		},
+	}
+	for _, member := range sets.List(members) {
+		desired.Subjects = append(desired.Subjects, rbacv1.Subject{
+			Kind:     rbacv1.UserKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     member,
+		})
+	}
+	if err := c.applyClusterRoleBinding(ctx, clusterName, desired); err != nil {
+		return err
+	}
+
+	logger.V(4).Info("synced members", "members", members.Len(), "unknownGroups", unknownGroups)
+	binding.Status.Members = members.Len()
+	binding.Status.UnknownGroups = unknownGroups
+	if !lastSync.IsZero() {
+		binding.Status.LastSyncTime = &metav1.Time{Time: lastSync}
+	}
+	if len(unknownGroups) > 0 {
+		conditions.MarkFalse(binding, tenancyv1alpha1.WorkspaceRoleBindingSynced, tenancyv1alpha1.WorkspaceRoleBindingUnknownGroupsReason, conditionsv1alpha1.ConditionSeverityWarning, "groups not in the group feed: %s", strings.Join(unknownGroups, ", "))
+	} else {
+		conditions.MarkTrue(binding, tenancyv1alpha1.WorkspaceRoleBindingSynced)
+	}
+
+	return nil
+}
+
+// applyClusterRoleBinding creates or updates the ClusterRoleBinding. As the role of a binding
+// is immutable, it is recreated if the role changed.
+func (c *controller) applyClusterRoleBinding(ctx context.Context, clusterName logicalcluster.Name, desired *rbacv1.ClusterRoleBinding) error {
+	existing, err := c.getClusterRoleBinding(clusterName, desired.Name)
+	if apierrors.IsNotFound(err) {
+		return c.createClusterRoleBinding(ctx, clusterName, desired)
+	} else if err != nil {
+		return err
+	}
+
+	if existing.RoleRef != desired.RoleRef {
+		if err := c.deleteClusterRoleBinding(ctx, clusterName, desired.Name); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.createClusterRoleBinding(ctx, clusterName, desired)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Subjects, desired.Subjects) &&
+		equality.Semantic.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) {
+		return nil
+	}
+	updated := existing.DeepCopy()
+	updated.Subjects = desired.Subjects
+	updated.OwnerReferences = desired.OwnerReferences
+	return c.updateClusterRoleBinding(ctx, clusterName, updated)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacerolebinding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+type fakeFeed struct{}
+
+func (fakeFeed) Groups(context.Context) (Groups, error) { return nil, errors.New("unreachable") }
+
+func TestReconcile(t *testing.T) {
+	newBinding := func(role string, groups, users []string) *tenancyv1alpha1.WorkspaceRoleBinding {
+		return &tenancyv1alpha1.WorkspaceRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "developers",
+				UID:         "uid",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "team"},
+			},
+			Spec: tenancyv1alpha1.WorkspaceRoleBindingSpec{
+				RoleRef: tenancyv1alpha1.WorkspaceRoleRef{Name: role},
+				Groups:  groups,
+				Users:   users,
+			},
+		}
+	}
+	subjects := func(names ...string) []rbacv1.Subject {
+		var s []rbacv1.Subject
+		for _, name := range names {
+			s = append(s, rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: name})
+		}
+		return s
+	}
+	existing := func(role string, users ...string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "workspacerolebinding:developers", ResourceVersion: "1"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role},
+			Subjects:   subjects(users...),
+		}
+	}
+	groups := Groups{
+		"platform": {"alice", "bob"},
+		"web":      {"bob", "carol"},
+	}
+
+	tests := map[string]struct {
+		binding  *tenancyv1alpha1.WorkspaceRoleBinding
+		groups   Groups
+		feed     GroupFeed
+		existing *rbacv1.ClusterRoleBinding
+
+		wantActions  []string
+		wantSubjects []rbacv1.Subject
+		wantMembers  int
+		wantUnknown  []string
+		wantReason   string
+	}{
+		"create binding of group members and users": {
+			binding:      newBinding("edit", []string{"platform", "web"}, []string{"dave"}),
+			groups:       groups,
+			feed:         fakeFeed{},
+			wantActions:  []string{"create"},
+			wantSubjects: subjects("alice", "bob", "carol", "dave"),
+			wantMembers:  4,
+		},
+		"update changed members": {
+			binding:      newBinding("edit", []string{"platform"}, nil),
+			groups:       groups,
+			feed:         fakeFeed{},
+			existing:     existing("edit", "alice"),
+			wantActions:  []string{"update"},
+			wantSubjects: subjects("alice", "bob"),
+			wantMembers:  2,
+		},
+		"recreate on role change": {
+			binding:      newBinding("view", []string{"web"}, nil),
+			groups:       groups,
+			feed:         fakeFeed{},
+			existing:     existing("edit", "bob", "carol"),
+			wantActions:  []string{"delete", "create"},
+			wantSubjects: subjects("bob", "carol"),
+			wantMembers:  2,
+		},
+		"unknown groups": {
+			binding:      newBinding("edit", []string{"platform", "ops"}, nil),
+			groups:       groups,
+			feed:         fakeFeed{},
+			wantActions:  []string{"create"},
+			wantSubjects: subjects("alice", "bob"),
+			wantMembers:  2,
+			wantUnknown:  []string{"ops"},
+			wantReason:   tenancyv1alpha1.WorkspaceRoleBindingUnknownGroupsReason,
+		},
+		"feed not read yet": {
+			binding:    newBinding("edit", []string{"platform"}, nil),
+			feed:       fakeFeed{},
+			existing:   existing("edit", "alice"),
+			wantReason: tenancyv1alpha1.WorkspaceRoleBindingGroupFeedUnavailableReason,
+		},
+		"no feed binds users only": {
+			binding:      newBinding("edit", []string{"platform"}, []string{"dave"}),
+			wantActions:  []string{"create"},
+			wantSubjects: subjects("dave"),
+			wantMembers:  1,
+			wantUnknown:  []string{"platform"},
+			wantReason:   tenancyv1alpha1.WorkspaceRoleBindingUnknownGroupsReason,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var actions []string
+			var written *rbacv1.ClusterRoleBinding
+			c := &controller{
+				feed:   tt.feed,
+				groups: tt.groups,
+				getClusterRoleBinding: func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRoleBinding, error) {
+					require.Equal(t, logicalcluster.Name("team"), clusterName)
+					if tt.existing == nil {
+						return nil, apierrors.NewNotFound(rbacv1.Resource("clusterrolebindings"), name)
+					}
+					return tt.existing, nil
+				},
+				createClusterRoleBinding: func(ctx context.Context, clusterName logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) error {
+					actions = append(actions, "create")
+					written = binding
+					return nil
+				},
+				updateClusterRoleBinding: func(ctx context.Context, clusterName logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) error {
+					actions = append(actions, "update")
+					written = binding
+					return nil
+				},
+				deleteClusterRoleBinding: func(ctx context.Context, clusterName logicalcluster.Name, name string) error {
+					actions = append(actions, "delete")
+					return nil
+				},
+			}
+			if tt.groups != nil {
+				c.lastSync = time.Now()
+			}
+
+			require.NoError(t, c.reconcile(context.Background(), tt.binding))
+			require.Equal(t, tt.wantActions, actions)
+			if written != nil {
+				require.Equal(t, "workspacerolebinding:developers", written.Name)
+				require.Equal(t, tt.binding.Spec.RoleRef.Name, written.RoleRef.Name)
+				require.Equal(t, tt.wantSubjects, written.Subjects)
+				require.Equal(t, "developers", ownerName(written))
+			}
+			require.Equal(t, tt.wantMembers, tt.binding.Status.Members)
+			require.Equal(t, tt.wantUnknown, tt.binding.Status.UnknownGroups)
+			if tt.wantReason == "" {
+				require.True(t, conditions.IsTrue(tt.binding, tenancyv1alpha1.WorkspaceRoleBindingSynced))
+			} else {
+				require.Equal(t, tt.wantReason, conditions.GetReason(tt.binding, tenancyv1alpha1.WorkspaceRoleBindingSynced))
+			}
+		})
+	}
+}
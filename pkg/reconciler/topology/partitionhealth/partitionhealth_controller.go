@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partitionhealth
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	topologyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/topology/v1alpha1"
+	coreinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	topologyinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/topology/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-topology-partition-health"
+)
+
+// NewController returns a new controller rolling up the health of the shards of Partitions
+// into their status.
+func NewController(
+	partitionClusterInformer topologyinformers.PartitionClusterInformer,
+	globalShardClusterInformer coreinformers.ShardClusterInformer,
+	kcpClusterClient kcpclientset.ClusterInterface,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: queue,
+		listShards: func(selector labels.Selector) ([]*corev1alpha1.Shard, error) {
+			return globalShardClusterInformer.Lister().List(selector)
+		},
+		listPartitions: func() ([]*topologyv1alpha1.Partition, error) {
+			return partitionClusterInformer.Lister().List(labels.Everything())
+		},
+		getPartition: func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.Partition, error) {
+			return partitionClusterInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		commit: committer.NewCommitter[*Partition, Patcher, *PartitionSpec, *PartitionStatus](kcpClusterClient.TopologyV1alpha1().Partitions()),
+	}
+
+	_, _ = globalShardClusterInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueueAllPartitions(obj)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// only health and URL changes impact the status of Partitions
+				if filterShardEvent(oldObj, newObj) {
+					c.enqueueAllPartitions(newObj)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				c.enqueueAllPartitions(obj)
+			},
+		},
+	)
+
+	_, _ = partitionClusterInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueuePartition(obj)
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				c.enqueuePartition(newObj)
+			},
+		},
+	)
+
+	return c, nil
+}
+
+type Partition = topologyv1alpha1.Partition
+type PartitionSpec = topologyv1alpha1.PartitionSpec
+type PartitionStatus = topologyv1alpha1.PartitionStatus
+type Patcher = topologyv1alpha1client.PartitionInterface
+type Resource = committer.Resource[*PartitionSpec, *PartitionStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller reconciles the status of Partitions with the health of the Shards they select.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	listShards     func(selector labels.Selector) ([]*corev1alpha1.Shard, error)
+	listPartitions func() ([]*topologyv1alpha1.Partition, error)
+	getPartition   func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.Partition, error)
+	commit         CommitFunc
+}
+
+// enqueuePartition enqueues a Partition.
+func (c *controller) enqueuePartition(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing Partition")
+	c.queue.Add(key)
+}
+
+// enqueueAllPartitions enqueues all Partitions.
+func (c *controller) enqueueAllPartitions(shard interface{}) {
+	list, err := c.listPartitions()
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	if s, ok := shard.(*corev1alpha1.Shard); ok {
+		logger = logging.WithObject(logger, s)
+	}
+	for i := range list {
+		key, err := kcpcache.MetaClusterNamespaceKeyFunc(list[i])
+		if err != nil {
+			runtime.HandleError(err)
+			continue
+		}
+
+		logging.WithQueueKey(logger, key).V(4).Info("queuing Partition because Shard changed")
+		c.queue.Add(key)
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.getPartition(clusterName, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	if err := c.reconcile(ctx, obj); err != nil {
+		errs = append(errs, err)
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// filterShardEvent returns true if the event passes the filter and needs to be processed false otherwise.
+func filterShardEvent(oldObj, newObj interface{}) bool {
+	oldShard, ok := oldObj.(*corev1alpha1.Shard)
+	if !ok {
+		return false
+	}
+	newShard, ok := newObj.(*corev1alpha1.Shard)
+	if !ok {
+		return false
+	}
+	return !reflect.DeepEqual(oldShard.Labels, newShard.Labels) ||
+		oldShard.Spec.VirtualWorkspaceURL != newShard.Spec.VirtualWorkspaceURL ||
+		shardHealth(oldShard) != shardHealth(newShard)
+}
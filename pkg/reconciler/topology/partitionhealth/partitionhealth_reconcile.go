@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partitionhealth
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+)
+
+// reconcile updates the shards of the partition with their health.
+func (c *controller) reconcile(ctx context.Context, partition *topologyv1alpha1.Partition) error {
+	selector := labels.Everything()
+	if partition.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(partition.Spec.Selector); err != nil {
+			// This should not happen due to OpenAPI validation
+			partition.Status.Shards = nil
+			conditions.MarkFalse(
+				partition,
+				topologyv1alpha1.PartitionShardsHealthy,
+				topologyv1alpha1.PartitionShardsDegradedReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"invalid selector: %v", err,
+			)
+			return nil
+		}
+	}
+
+	shards, err := c.listShards(selector)
+	if err != nil {
+		return err
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Name < shards[j].Name })
+
+	var degraded []string
+	statuses := make([]topologyv1alpha1.PartitionShardStatus, 0, len(shards))
+	for _, shard := range shards {
+		status := topologyv1alpha1.PartitionShardStatus{
+			Name:                shard.Name,
+			VirtualWorkspaceURL: shard.Spec.VirtualWorkspaceURL,
+			Health:              shardHealth(shard),
+		}
+		if status.Health != topologyv1alpha1.ShardHealthy {
+			degraded = append(degraded, shard.Name)
+		}
+		statuses = append(statuses, status)
+	}
+	partition.Status.Shards = statuses
+
+	if len(degraded) > 0 {
+		conditions.MarkFalse(
+			partition,
+			topologyv1alpha1.PartitionShardsHealthy,
+			topologyv1alpha1.PartitionShardsDegradedReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"shards not healthy: %s", strings.Join(degraded, ", "),
+		)
+	} else {
+		conditions.MarkTrue(partition, topologyv1alpha1.PartitionShardsHealthy)
+	}
+
+	return nil
+}
+
+// shardHealth derives the health of a shard from its Ready condition, which reflects its Lease.
+func shardHealth(shard *corev1alpha1.Shard) topologyv1alpha1.ShardHealth {
+	switch {
+	case conditions.IsTrue(shard, conditionsv1alpha1.ReadyCondition):
+		return topologyv1alpha1.ShardHealthy
+	case conditions.IsFalse(shard, conditionsv1alpha1.ReadyCondition):
+		return topologyv1alpha1.ShardDegraded
+	default:
+		return topologyv1alpha1.ShardHealthUnknown
+	}
+}
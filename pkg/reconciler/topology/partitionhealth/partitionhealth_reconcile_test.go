@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partitionhealth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+)
+
+func TestReconcile(t *testing.T) {
+	newShard := func(name, region string, ready corev1.ConditionStatus) *corev1alpha1.Shard {
+		shard := &corev1alpha1.Shard{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"region": region}},
+			Spec:       corev1alpha1.ShardSpec{VirtualWorkspaceURL: "https://" + name + ".kcp.dev/"},
+		}
+		switch ready {
+		case corev1.ConditionTrue:
+			conditions.MarkTrue(shard, conditionsv1alpha1.ReadyCondition)
+		case corev1.ConditionFalse:
+			conditions.MarkFalse(shard, conditionsv1alpha1.ReadyCondition, corev1alpha1.ShardLeaseExpiredReason, conditionsv1alpha1.ConditionSeverityError, "")
+		}
+		return shard
+	}
+
+	for _, tc := range []struct {
+		name   string
+		shards []*corev1alpha1.Shard
+
+		wantHealth  map[string]topologyv1alpha1.ShardHealth
+		wantHealthy corev1.ConditionStatus
+	}{
+		{
+			name:        "all healthy",
+			shards:      []*corev1alpha1.Shard{newShard("a", "eu", corev1.ConditionTrue), newShard("b", "eu", corev1.ConditionTrue)},
+			wantHealth:  map[string]topologyv1alpha1.ShardHealth{"a": topologyv1alpha1.ShardHealthy, "b": topologyv1alpha1.ShardHealthy},
+			wantHealthy: corev1.ConditionTrue,
+		},
+		{
+			name:        "lease expired",
+			shards:      []*corev1alpha1.Shard{newShard("a", "eu", corev1.ConditionTrue), newShard("b", "eu", corev1.ConditionFalse)},
+			wantHealth:  map[string]topologyv1alpha1.ShardHealth{"a": topologyv1alpha1.ShardHealthy, "b": topologyv1alpha1.ShardDegraded},
+			wantHealthy: corev1.ConditionFalse,
+		},
+		{
+			name:        "readiness unknown",
+			shards:      []*corev1alpha1.Shard{newShard("a", "eu", corev1.ConditionUnknown)},
+			wantHealth:  map[string]topologyv1alpha1.ShardHealth{"a": topologyv1alpha1.ShardHealthUnknown},
+			wantHealthy: corev1.ConditionFalse,
+		},
+		{
+			name:        "shards not selected are ignored",
+			shards:      []*corev1alpha1.Shard{newShard("a", "eu", corev1.ConditionTrue), newShard("b", "us", corev1.ConditionFalse)},
+			wantHealth:  map[string]topologyv1alpha1.ShardHealth{"a": topologyv1alpha1.ShardHealthy},
+			wantHealthy: corev1.ConditionTrue,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &controller{
+				listShards: func(selector labels.Selector) ([]*corev1alpha1.Shard, error) {
+					var shards []*corev1alpha1.Shard
+					for _, shard := range tc.shards {
+						if selector.Matches(labels.Set(shard.Labels)) {
+							shards = append(shards, shard)
+						}
+					}
+					return shards, nil
+				},
+			}
+
+			partition := &topologyv1alpha1.Partition{
+				ObjectMeta: metav1.ObjectMeta{Name: "eu"},
+				Spec: topologyv1alpha1.PartitionSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+				},
+			}
+			err := c.reconcile(context.Background(), partition)
+			require.NoError(t, err)
+
+			health := map[string]topologyv1alpha1.ShardHealth{}
+			for _, shard := range partition.Status.Shards {
+				health[shard.Name] = shard.Health
+				require.Equal(t, "https://"+shard.Name+".kcp.dev/", shard.VirtualWorkspaceURL)
+			}
+			require.Equal(t, tc.wantHealth, health)
+			require.Equal(t, tc.wantHealthy, conditions.Get(partition, topologyv1alpha1.PartitionShardsHealthy).Status)
+		})
+	}
+}
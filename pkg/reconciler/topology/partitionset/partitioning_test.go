@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
 )
 
 func TestPartition(t *testing.T) {
@@ -144,3 +145,93 @@ func TestGeneratePartitionName(t *testing.T) {
 	)
 	require.Equal(t, "partitionset-europe-123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890"[:validation.DNS1123SubdomainMaxLength-1], name)
 }
+
+func TestPlanPartitions(t *testing.T) {
+	newExisting := func(name string, shards ...string) existingPartition {
+		return existingPartition{partition: &topologyv1alpha1.Partition{ObjectMeta: metav1.ObjectMeta{Name: name}}, shards: shards}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		shards    []string
+		existing  []existingPartition
+		maxShards int
+		rebalance bool
+
+		wantShards     [][]string
+		wantPartitions []string
+	}{
+		{
+			name:           "no limit",
+			shards:         []string{"a", "b", "c"},
+			wantShards:     [][]string{nil},
+			wantPartitions: []string{""},
+		},
+		{
+			name:           "within limit",
+			shards:         []string{"a", "b"},
+			existing:       []existingPartition{newExisting("p1")},
+			maxShards:      2,
+			wantShards:     [][]string{nil},
+			wantPartitions: []string{"p1"},
+		},
+		{
+			name:           "split when exceeding the limit",
+			shards:         []string{"a", "b", "c", "d", "e"},
+			existing:       []existingPartition{newExisting("p1")},
+			maxShards:      2,
+			wantShards:     [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+			wantPartitions: []string{"p1", "", ""},
+		},
+		{
+			name:           "new shards are added to partitions with room",
+			shards:         []string{"a", "b", "c", "d", "e"},
+			existing:       []existingPartition{newExisting("p1", "a", "b"), newExisting("p2", "c")},
+			maxShards:      2,
+			wantShards:     [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+			wantPartitions: []string{"p1", "p2", ""},
+		},
+		{
+			name:           "removed shards keep partitions stable",
+			shards:         []string{"b", "d"},
+			existing:       []existingPartition{newExisting("p1", "a", "b"), newExisting("p2", "c", "d")},
+			maxShards:      2,
+			wantShards:     [][]string{{"b"}, {"d"}},
+			wantPartitions: []string{"p1", "p2"},
+		},
+		{
+			name:           "rebalance merges partitions",
+			shards:         []string{"b", "d"},
+			existing:       []existingPartition{newExisting("p1", "a", "b"), newExisting("p2", "c", "d")},
+			maxShards:      2,
+			rebalance:      true,
+			wantShards:     [][]string{nil},
+			wantPartitions: []string{"p1"},
+		},
+		{
+			name:           "rebalance spreads shards evenly",
+			shards:         []string{"a", "b", "c", "d", "e"},
+			existing:       []existingPartition{newExisting("p1", "a", "b", "c"), newExisting("p2", "d"), newExisting("p3", "e")},
+			maxShards:      3,
+			rebalance:      true,
+			wantShards:     [][]string{{"a", "b", "c"}, {"d", "e"}},
+			wantPartitions: []string{"p1", "p2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := planPartitions(tc.shards, tc.existing, tc.maxShards, tc.rebalance)
+			var gotShards [][]string
+			var gotPartitions []string
+			for _, c := range chunks {
+				gotShards = append(gotShards, c.shards)
+				name := ""
+				if c.partition != nil {
+					name = c.partition.Name
+				}
+				gotPartitions = append(gotPartitions, name)
+			}
+			require.Equal(t, tc.wantShards, gotShards)
+			require.Equal(t, tc.wantPartitions, gotPartitions)
+		})
+	}
+}
@@ -38,10 +38,14 @@ import (
 
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	topologyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/topology/v1alpha1"
+	apisinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
 	coreinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
 	topologyinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/topology/v1alpha1"
 )
@@ -55,6 +59,7 @@ func NewController(
 	partitionSetClusterInformer topologyinformers.PartitionSetClusterInformer,
 	partitionClusterInformer topologyinformers.PartitionClusterInformer,
 	globalShardClusterInformer coreinformers.ShardClusterInformer,
+	apiExportEndpointSliceClusterInformer apisinformers.APIExportEndpointSliceClusterInformer,
 	kcpClusterClient kcpclientset.ClusterInterface,
 ) (*controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
@@ -98,9 +103,18 @@ func NewController(
 		createPartition: func(ctx context.Context, path logicalcluster.Path, partition *topologyv1alpha1.Partition) (*topologyv1alpha1.Partition, error) {
 			return kcpClusterClient.Cluster(path).TopologyV1alpha1().Partitions().Create(ctx, partition, metav1.CreateOptions{})
 		},
+		updatePartition: func(ctx context.Context, path logicalcluster.Path, partition *topologyv1alpha1.Partition) (*topologyv1alpha1.Partition, error) {
+			return kcpClusterClient.Cluster(path).TopologyV1alpha1().Partitions().Update(ctx, partition, metav1.UpdateOptions{})
+		},
 		deletePartition: func(ctx context.Context, path logicalcluster.Path, partitionName string) error {
 			return kcpClusterClient.Cluster(path).TopologyV1alpha1().Partitions().Delete(ctx, partitionName, metav1.DeleteOptions{})
 		},
+		listAPIExportEndpointSlices: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportEndpointSlice, error) {
+			return apiExportEndpointSliceClusterInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		updateAPIExportEndpointSlice: func(ctx context.Context, path logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) (*apisv1alpha1.APIExportEndpointSlice, error) {
+			return kcpClusterClient.Cluster(path).ApisV1alpha1().APIExportEndpointSlices().Update(ctx, slice, metav1.UpdateOptions{})
+		},
 
 		commit: committer.NewCommitter[*PartitionSet, Patcher, *PartitionSetSpec, *PartitionSetStatus](kcpClusterClient.TopologyV1alpha1().PartitionSets()),
 	}
@@ -172,8 +186,13 @@ type controller struct {
 	getPartitionSet             func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.PartitionSet, error)
 	getPartitionsByPartitionSet func(ctx context.Context, partitionSet *topologyv1alpha1.PartitionSet) ([]*topologyv1alpha1.Partition, error)
 	createPartition             func(ctx context.Context, path logicalcluster.Path, partition *topologyv1alpha1.Partition) (*topologyv1alpha1.Partition, error)
+	updatePartition             func(ctx context.Context, path logicalcluster.Path, partition *topologyv1alpha1.Partition) (*topologyv1alpha1.Partition, error)
 	deletePartition             func(ctx context.Context, path logicalcluster.Path, partitionName string) error
-	commit                      CommitFunc
+
+	listAPIExportEndpointSlices  func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportEndpointSlice, error)
+	updateAPIExportEndpointSlice func(ctx context.Context, path logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) (*apisv1alpha1.APIExportEndpointSlice, error)
+
+	commit CommitFunc
 }
 
 // enqueuePartitionSet enqueues a PartitionSet.
@@ -286,7 +305,12 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if err := c.process(ctx, key); err != nil {
+	ctx, span := reconcilertracing.StartReconcile(ctx, ControllerName, key)
+	startTime := time.Now()
+	err := c.process(ctx, key)
+	reconcilermetrics.ObserveReconcile(ControllerName, key, startTime, err)
+	reconcilertracing.EndReconcile(span, err)
+	if err != nil {
 		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
 		c.queue.AddRateLimited(key)
 		return true
@@ -36,6 +36,10 @@ import (
 	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
 )
 
+// shardNameLabel is the label carrying the name of a Shard. Partitions of groups exceeding
+// maxShardsPerPartition select their shards with it.
+const shardNameLabel = "name"
+
 func (c *controller) reconcile(ctx context.Context, partitionSet *topologyv1alpha1.PartitionSet) error {
 	logger := klog.FromContext(ctx)
 	logger = logging.WithObject(logger, partitionSet)
@@ -89,110 +93,379 @@ func (c *controller) reconcile(ctx context.Context, partitionSet *topologyv1alph
 		return err
 	}
 
-	var matchLabelsMap map[string]map[string]string
 	// remove duplicates
 	dimensions := sets.List[string](sets.New[string](partitionSet.Spec.Dimensions...))
+	var shardSelectorLabels map[string]string
 	if partitionSet.Spec.ShardSelector != nil {
-		matchLabelsMap = partition(shards, dimensions, partitionSet.Spec.ShardSelector.MatchLabels)
-	} else {
-		matchLabelsMap = partition(shards, dimensions, nil)
+		shardSelectorLabels = partitionSet.Spec.ShardSelector.MatchLabels
 	}
-	partitionSet.Status.Count = uint16(len(matchLabelsMap))
-	existingMatches := map[string]struct{}{}
+	groups := groupShards(shards, dimensions, shardSelectorLabels)
 	newMatchExpressions := []metav1.LabelSelectorRequirement{}
 	if partitionSet.Spec.ShardSelector != nil {
 		newMatchExpressions = partitionSet.Spec.ShardSelector.MatchExpressions
 	}
 	// loop through existing partitions and delete old partitions owned by the PartitionSet that are no match anymore
-	// store existing matches for not to recreate existing Partitions
+	// store the existing partitions of each group for not to recreate them
+	existing := map[string][]existingPartition{}
 	for _, oldPartition := range oldPartitions {
 		pLogger := logging.WithObject(logger, oldPartition)
-		// MatchExpressions need to be the same
-		oldMatchExpressions := []metav1.LabelSelectorRequirement{}
-		if oldPartition.Spec.Selector != nil {
-			oldMatchExpressions = oldPartition.Spec.Selector.MatchExpressions
+		// MatchExpressions need to be the same, apart from the shard names of split partitions
+		shardNames, ok := partitionShardNames(oldPartition, newMatchExpressions)
+		if ok {
+			// MatchLabels need to be the same
+			oldMatchLabels := map[string]string{}
+			if oldPartition.Spec.Selector != nil {
+				oldMatchLabels = oldPartition.Spec.Selector.MatchLabels
+			}
+			partitionKey := matchLabelsKey(oldMatchLabels)
+			if _, found := groups[partitionKey]; found {
+				existing[partitionKey] = append(existing[partitionKey], existingPartition{partition: oldPartition, shards: shardNames})
+				continue
+			}
 		}
-		if !equality.Semantic.DeepEqual(oldMatchExpressions, newMatchExpressions) {
-			pLogger.V(2).Info("deleting partition")
-			if err := c.deletePartition(ctx, logicalcluster.From(oldPartition).Path(), oldPartition.Name); err != nil && !apierrors.IsNotFound(err) {
+
+		pLogger.V(2).Info("deleting partition")
+		if err := c.deletePartition(ctx, logicalcluster.From(oldPartition).Path(), oldPartition.Name); err != nil && !apierrors.IsNotFound(err) {
+			conditions.MarkFalse(
+				partitionSet,
+				topologyv1alpha1.PartitionsReady,
+				topologyv1alpha1.ErrorGeneratingPartitionsReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"old partition could not get deleted",
+			)
+			return err
+		}
+	}
+
+	rebalance := partitionSet.Spec.Rebalance != partitionSet.Status.Rebalance
+	maxShards := int(partitionSet.Spec.MaxShardsPerPartition)
+	count := 0
+	keys := sets.List[string](sets.KeySet[string](groups))
+	for _, key := range keys {
+		group := groups[key]
+		chunks := planPartitions(group.shards, existing[key], maxShards, rebalance)
+		count += len(chunks)
+
+		// Create the partitions of new chunks and update the selector of reused ones.
+		reused := sets.New[string]()
+		for i := range chunks {
+			chunk := &chunks[i]
+			matchExpressions := shardMatchExpressions(newMatchExpressions, chunk.shards)
+			if chunk.partition == nil {
+				partition := generatePartition(partitionSet.Name, matchExpressions, group.matchLabels, dimensions)
+				partition.OwnerReferences = []metav1.OwnerReference{
+					*metav1.NewControllerRef(partitionSet, topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionSet")),
+				}
+				pLogger := logging.WithObject(logger, partition)
+				pLogger.V(2).Info("creating partition")
+				created, err := c.createPartition(ctx, logicalcluster.From(partitionSet).Path(), partition)
+				if err != nil && !apierrors.IsAlreadyExists(err) {
+					conditions.MarkFalse(
+						partitionSet,
+						topologyv1alpha1.PartitionsReady,
+						topologyv1alpha1.ErrorGeneratingPartitionsReason,
+						conditionsv1alpha1.ConditionSeverityError,
+						"partition could not get created",
+					)
+					return err
+				}
+				chunk.partition = created
+				continue
+			}
+
+			reused.Insert(chunk.partition.Name)
+			selector := &metav1.LabelSelector{MatchLabels: group.matchLabels, MatchExpressions: matchExpressions}
+			if equality.Semantic.DeepEqual(chunk.partition.Spec.Selector, selector) {
+				continue
+			}
+			partition := chunk.partition.DeepCopy()
+			partition.Spec.Selector = selector
+			pLogger := logging.WithObject(logger, partition)
+			pLogger.V(2).Info("updating partition", "shards", chunk.shards)
+			if _, err := c.updatePartition(ctx, logicalcluster.From(partition).Path(), partition); err != nil {
 				conditions.MarkFalse(
 					partitionSet,
 					topologyv1alpha1.PartitionsReady,
 					topologyv1alpha1.ErrorGeneratingPartitionsReason,
 					conditionsv1alpha1.ConditionSeverityError,
-					"old partition could not get deleted",
+					"partition could not get updated",
 				)
 				return err
 			}
-			continue
-		}
-
-		// MatchLabels need to be the same
-		partitionKey := ""
-		oldMatchLabels := map[string]string{}
-		if oldPartition.Spec.Selector != nil {
-			oldMatchLabels = oldPartition.Spec.Selector.MatchLabels
 		}
 
-		// Sorting the keys of the old partition for consistent comparison
-		sortedOldKeys := make([]string, len(oldMatchLabels))
-		i := 0
-		for k := range oldMatchLabels {
-			sortedOldKeys[i] = k
-			i++
-		}
-		sort.Strings(sortedOldKeys)
-		for _, key := range sortedOldKeys {
-			partitionKey = partitionKey + "+" + key + "=" + oldMatchLabels[key]
-		}
-		if _, ok := matchLabelsMap[partitionKey]; ok {
-			existingMatches[partitionKey] = struct{}{}
-		} else {
-			pLogger.V(2).Info("deleting partition")
-			if err := c.deletePartition(ctx, logicalcluster.From(oldPartition).Path(), oldPartition.Name); err != nil && !apierrors.IsNotFound(err) {
+		// Delete the partitions not needed anymore, after pointing the APIExportEndpointSlices
+		// referencing them to the partition taking over most of their shards.
+		for _, old := range existing[key] {
+			if reused.Has(old.partition.Name) {
+				continue
+			}
+			if err := c.replacePartitionReferences(ctx, old, chunks, group.shards); err != nil {
 				conditions.MarkFalse(
 					partitionSet,
 					topologyv1alpha1.PartitionsReady,
 					topologyv1alpha1.ErrorGeneratingPartitionsReason,
 					conditionsv1alpha1.ConditionSeverityError,
-					"old partition could not get deleted",
+					"APIExportEndpointSlices could not get updated",
 				)
 				return err
 			}
-		}
-	}
-
-	// Create partitions when no existing partition for the set has the same selector.
-	for key, matchLabels := range matchLabelsMap {
-		if _, ok := existingMatches[key]; !ok {
-			partition := generatePartition(partitionSet.Name, newMatchExpressions, matchLabels, dimensions)
-			partition.OwnerReferences = []metav1.OwnerReference{
-				*metav1.NewControllerRef(partitionSet, topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionSet")),
-			}
-			pLogger := logging.WithObject(logger, partition)
-			pLogger.V(2).Info("creating partition")
-			_, err = c.createPartition(ctx, logicalcluster.From(partitionSet).Path(), partition)
-			if err != nil && !apierrors.IsAlreadyExists(err) {
+			pLogger := logging.WithObject(logger, old.partition)
+			pLogger.V(2).Info("deleting partition")
+			if err := c.deletePartition(ctx, logicalcluster.From(old.partition).Path(), old.partition.Name); err != nil && !apierrors.IsNotFound(err) {
 				conditions.MarkFalse(
 					partitionSet,
 					topologyv1alpha1.PartitionsReady,
 					topologyv1alpha1.ErrorGeneratingPartitionsReason,
 					conditionsv1alpha1.ConditionSeverityError,
-					"partition could not get created",
+					"old partition could not get deleted",
 				)
 				return err
 			}
 		}
 	}
+	partitionSet.Status.Count = uint16(count)
+	partitionSet.Status.Rebalance = partitionSet.Spec.Rebalance
 	conditions.MarkTrue(partitionSet, topologyv1alpha1.PartitionsReady)
 	return nil
 }
 
+// replacePartitionReferences updates the APIExportEndpointSlices referencing the given partition
+// to reference the chunk sharing most shards with it.
+func (c *controller) replacePartitionReferences(ctx context.Context, old existingPartition, chunks []chunk, groupShards []string) error {
+	slices, err := c.listAPIExportEndpointSlices(logicalcluster.From(old.partition))
+	if err != nil {
+		return err
+	}
+
+	var replacement *topologyv1alpha1.Partition
+	best := -1
+	for _, chunk := range chunks {
+		if chunk.partition == nil {
+			continue
+		}
+		if n := overlap(old.shardsIn(groupShards), chunk.shardsIn(groupShards)); n > best {
+			replacement, best = chunk.partition, n
+		}
+	}
+	if replacement == nil {
+		return nil
+	}
+
+	for _, slice := range slices {
+		if slice.Spec.Partition != old.partition.Name {
+			continue
+		}
+		slice = slice.DeepCopy()
+		slice.Spec.Partition = replacement.Name
+		logging.WithObject(klog.FromContext(ctx), slice).V(2).Info("updating partition of APIExportEndpointSlice", "partition", replacement.Name)
+		if _, err := c.updateAPIExportEndpointSlice(ctx, logicalcluster.From(slice).Path(), slice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shardGroup is a set of shards sharing the same dimension values.
+type shardGroup struct {
+	matchLabels map[string]string
+	// shards are the sorted names of the shards.
+	shards []string
+}
+
+// existingPartition is a Partition of a group with the names of the shards it selects.
+type existingPartition struct {
+	partition *topologyv1alpha1.Partition
+	// shards are the names of the selected shards, or nil if it selects the whole group.
+	shards []string
+}
+
+func (p existingPartition) shardsIn(groupShards []string) []string {
+	if p.shards == nil {
+		return groupShards
+	}
+	return p.shards
+}
+
+// chunk is a desired Partition of a group.
+type chunk struct {
+	// shards are the names of the selected shards, or nil if it selects the whole group.
+	shards []string
+	// partition is the Partition of the chunk, if it exists.
+	partition *topologyv1alpha1.Partition
+}
+
+func (c chunk) shardsIn(groupShards []string) []string {
+	if c.shards == nil {
+		return groupShards
+	}
+	return c.shards
+}
+
+// planPartitions splits the shards of a group into chunks of at most maxShards shards, reusing
+// the existing partitions. Without rebalancing, shards stay in their partitions and new shards
+// are added to the least filled partitions.
+func planPartitions(shards []string, existing []existingPartition, maxShards int, rebalance bool) []chunk {
+	existing = append([]existingPartition(nil), existing...)
+	sort.Slice(existing, func(i, j int) bool { return existing[i].partition.Name < existing[j].partition.Name })
+
+	fresh := rebalance || len(existing) == 0
+	for _, e := range existing {
+		if e.shards == nil {
+			fresh = true
+		}
+	}
+	if maxShards <= 0 || (len(shards) <= maxShards && fresh) {
+		return assignPartitions([][]string{nil}, shards, existing)
+	}
+	if fresh {
+		return assignPartitions(evenChunks(shards, maxShards), shards, existing)
+	}
+
+	current := sets.New[string](shards...)
+	assigned := sets.New[string]()
+	chunks := make([]chunk, 0, len(existing))
+	for _, e := range existing {
+		var kept []string
+		for _, name := range e.shards {
+			if current.Has(name) && !assigned.Has(name) && len(kept) < maxShards {
+				kept = append(kept, name)
+				assigned.Insert(name)
+			}
+		}
+		if len(kept) > 0 {
+			chunks = append(chunks, chunk{shards: kept, partition: e.partition})
+		}
+	}
+	for _, name := range shards {
+		if assigned.Has(name) {
+			continue
+		}
+		target := -1
+		for i := range chunks {
+			if len(chunks[i].shards) < maxShards && (target < 0 || len(chunks[i].shards) < len(chunks[target].shards)) {
+				target = i
+			}
+		}
+		if target < 0 {
+			chunks = append(chunks, chunk{})
+			target = len(chunks) - 1
+		}
+		chunks[target].shards = append(chunks[target].shards, name)
+	}
+	for i := range chunks {
+		sort.Strings(chunks[i].shards)
+	}
+	return chunks
+}
+
+// evenChunks spreads the shards evenly over the smallest number of chunks of at most maxShards shards.
+func evenChunks(shards []string, maxShards int) [][]string {
+	n := (len(shards) + maxShards - 1) / maxShards
+	chunks := make([][]string, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		size := len(shards) / n
+		if i < len(shards)%n {
+			size++
+		}
+		chunks = append(chunks, shards[start:start+size])
+		start += size
+	}
+	return chunks
+}
+
+// assignPartitions reuses for each desired chunk the unused existing partition sharing most shards with it.
+func assignPartitions(desired [][]string, shards []string, existing []existingPartition) []chunk {
+	used := make([]bool, len(existing))
+	chunks := make([]chunk, 0, len(desired))
+	for _, names := range desired {
+		c := chunk{shards: names}
+		best, bestOverlap := -1, -1
+		for i, e := range existing {
+			if used[i] {
+				continue
+			}
+			if n := overlap(e.shardsIn(shards), c.shardsIn(shards)); n > bestOverlap {
+				best, bestOverlap = i, n
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+			c.partition = existing[best].partition
+		}
+		chunks = append(chunks, c)
+	}
+	return chunks
+}
+
+func overlap(a, b []string) int {
+	return sets.New[string](a...).Intersection(sets.New[string](b...)).Len()
+}
+
+// partitionShardNames returns the names of the shards selected by a split partition, or nil
+// if the partition selects the whole group. It returns false if the expressions of the
+// partition do not match the given ones.
+func partitionShardNames(partition *topologyv1alpha1.Partition, matchExpressions []metav1.LabelSelectorRequirement) ([]string, bool) {
+	expressions := []metav1.LabelSelectorRequirement{}
+	if partition.Spec.Selector != nil {
+		expressions = partition.Spec.Selector.MatchExpressions
+	}
+	if equality.Semantic.DeepEqual(expressions, matchExpressions) {
+		return nil, true
+	}
+	if len(expressions) != len(matchExpressions)+1 {
+		return nil, false
+	}
+	last := expressions[len(expressions)-1]
+	if last.Key != shardNameLabel || last.Operator != metav1.LabelSelectorOpIn || len(last.Values) == 0 {
+		return nil, false
+	}
+	if !equality.Semantic.DeepEqual(expressions[:len(expressions)-1], matchExpressions) {
+		return nil, false
+	}
+	return last.Values, true
+}
+
+// shardMatchExpressions appends the selection of the given shards to the match expressions.
+func shardMatchExpressions(matchExpressions []metav1.LabelSelectorRequirement, shards []string) []metav1.LabelSelectorRequirement {
+	if shards == nil {
+		return matchExpressions
+	}
+	expressions := make([]metav1.LabelSelectorRequirement, 0, len(matchExpressions)+1)
+	expressions = append(expressions, matchExpressions...)
+	return append(expressions, metav1.LabelSelectorRequirement{
+		Key:      shardNameLabel,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   shards,
+	})
+}
+
+// matchLabelsKey returns the key of a partition with the given match labels.
+func matchLabelsKey(matchLabels map[string]string) string {
+	// Sorting the keys for consistent comparison
+	keys := sets.List[string](sets.KeySet[string](matchLabels))
+	key := ""
+	for _, k := range keys {
+		key = key + "+" + k + "=" + matchLabels[k]
+	}
+	return key
+}
+
 // partition populates shard label selectors according to dimensions.
 // It only keeps selectors that have at least one Shard matching them
 // so that Partitions not referring to any Shard would not get created.
 func partition(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorLabels map[string]string) (matchLabelsMap map[string]map[string]string) {
 	matchLabelsMap = make(map[string]map[string]string)
+	for key, group := range groupShards(shards, dimensions, shardSelectorLabels) {
+		matchLabelsMap[key] = group.matchLabels
+	}
+	return matchLabelsMap
+}
+
+// groupShards groups the shards by the values of their dimension and selector labels.
+func groupShards(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorLabels map[string]string) map[string]*shardGroup {
+	groups := make(map[string]*shardGroup)
 	labels := make([]string, len(dimensions), len(dimensions)+len(shardSelectorLabels))
 	copy(labels, dimensions)
 	for label := range shardSelectorLabels {
@@ -213,8 +486,14 @@ func partition(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorL
 			selector[label] = labelValue
 		}
 		if matchingLabels && len(key) > 0 {
-			matchLabelsMap[key] = selector
+			if _, found := groups[key]; !found {
+				groups[key] = &shardGroup{matchLabels: selector}
+			}
+			groups[key].shards = append(groups[key].shards, shard.Name)
 		}
 	}
-	return matchLabelsMap
+	for _, group := range groups {
+		sort.Strings(group.shards)
+	}
+	return groups
 }
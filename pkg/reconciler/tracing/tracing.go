@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing starts the OpenTelemetry spans of the reconciliations of kcp controllers.
+// Every reconciliation of a queue key is the root of its own trace. Requests of the controller
+// clients made with the span's context are children of it, if the client configs are wrapped
+// for tracing, e.g. as the loopback config of a shard with --tracing-config-file.
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationScope = "github.com/kcp-dev/kcp/pkg/reconciler"
+
+type provider struct {
+	trace.TracerProvider
+}
+
+// tracerProvider holds the provider of the reconcile spans. It is a noop provider until
+// SetTracerProvider is called.
+var tracerProvider atomic.Value
+
+func init() {
+	tracerProvider.Store(provider{noop.NewTracerProvider()})
+}
+
+// SetTracerProvider sets the provider of the reconcile spans. It must be called before the
+// controllers are started.
+func SetTracerProvider(tp trace.TracerProvider) {
+	tracerProvider.Store(provider{tp})
+}
+
+// StartReconcile starts the span of one reconciliation of the queue key by the named controller.
+// The returned context must be passed to the reconciliation, and the span ended with EndReconcile.
+func StartReconcile(ctx context.Context, controller, key string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("controller", controller),
+		attribute.String("key", key),
+	}
+	// keys of controllers for several resources are prefixed with the resource, e.g. <gvr>::<key>
+	clusterKey := key
+	if i := strings.LastIndex(clusterKey, "::"); i >= 0 {
+		clusterKey = clusterKey[i+len("::"):]
+	}
+	if clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(clusterKey); err == nil && !clusterName.Empty() {
+		attributes = append(attributes, attribute.String("logical_cluster", clusterName.String()))
+	}
+
+	tp := tracerProvider.Load().(provider)
+	return tp.Tracer(instrumentationScope).Start(ctx, controller,
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// EndReconcile records the error, if any, of the reconciliation on the span and ends it.
+func EndReconcile(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
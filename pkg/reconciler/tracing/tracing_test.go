@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestStartReconcile(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { SetTracerProvider(noop.NewTracerProvider()) })
+
+	ctx, span := StartReconcile(context.Background(), "test-controller", "root:org|foo")
+	_, child := span.TracerProvider().Tracer("test").Start(ctx, "request")
+	child.End()
+	EndReconcile(span, nil)
+
+	_, span = StartReconcile(ctx, "test-controller", "apiexports.v1alpha1.apis.kcp.io::root:org|ns/bar")
+	EndReconcile(span, errors.New("boom"))
+
+	_, span = StartReconcile(context.Background(), "test-controller", "not-cluster-aware")
+	EndReconcile(span, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	request, first, second, third := spans[0], spans[1], spans[2], spans[3]
+
+	require.Equal(t, "test-controller", first.Name())
+	require.False(t, first.Parent().IsValid(), "reconcile span must be a root span")
+	require.Equal(t, first.SpanContext().SpanID(), request.Parent().SpanID(), "requests must be children of the reconcile span")
+	require.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("controller", "test-controller"),
+		attribute.String("key", "root:org|foo"),
+		attribute.String("logical_cluster", "root:org"),
+	}, first.Attributes())
+	require.Equal(t, codes.Unset, first.Status().Code)
+
+	require.False(t, second.Parent().IsValid(), "reconcile span must start a new trace")
+	require.NotEqual(t, first.SpanContext().TraceID(), second.SpanContext().TraceID())
+	require.Contains(t, second.Attributes(), attribute.String("logical_cluster", "root:org"))
+	require.Equal(t, codes.Error, second.Status().Code)
+	require.Equal(t, "boom", second.Status().Description)
+
+	for _, kv := range third.Attributes() {
+		require.NotEqual(t, attribute.Key("logical_cluster"), kv.Key)
+	}
+}
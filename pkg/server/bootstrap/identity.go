@@ -53,7 +53,8 @@ var (
 
 	// KcpRootGroupResourceExportNames lists the APIExports in the root workspace for standard kcp group resources.
 	KcpRootGroupResourceExportNames = map[schema.GroupResource]string{
-		{Group: "core.kcp.io", Resource: "shards"}: "shards.core.kcp.io",
+		{Group: "core.kcp.io", Resource: "shards"}:       "shards.core.kcp.io",
+		{Group: "core.kcp.io", Resource: "shardbackups"}: "core.kcp.io",
 	}
 )
 
@@ -22,7 +22,6 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	_ "net/http/pprof"
 	"net/url"
 	"os"
@@ -40,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/admission"
+	authenticationunion "k8s.io/apiserver/pkg/authentication/request/union"
 	"k8s.io/apiserver/pkg/endpoints/filters"
 	"k8s.io/apiserver/pkg/informerfactoryhack"
 	"k8s.io/apiserver/pkg/quota/v1/generic"
@@ -57,6 +57,9 @@ import (
 	quotainstall "k8s.io/kubernetes/pkg/quota/v1/install"
 
 	kcpadmissioninitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	kcpaudit "github.com/kcp-dev/kcp/pkg/audit"
+	"github.com/kcp-dev/kcp/pkg/authentication/accesstoken"
+	kcpserviceaccount "github.com/kcp-dev/kcp/pkg/authentication/serviceaccount"
 	"github.com/kcp-dev/kcp/pkg/authorization"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	"github.com/kcp-dev/kcp/pkg/embeddedetcd"
@@ -104,6 +107,7 @@ type ExtraConfig struct {
 	ApiExtensionsClusterClient          kcpapiextensionsclientset.ClusterInterface
 	KcpClusterClient                    kcpclientset.ClusterInterface
 	RootShardKcpClusterClient           kcpclientset.ClusterInterface
+	RootShardKubeClusterClient          kcpkubernetesclientset.ClusterInterface
 	BootstrapDynamicClusterClient       kcpdynamic.ClusterInterface
 	BootstrapApiExtensionsClusterClient kcpapiextensionsclientset.ClusterInterface
 
@@ -255,6 +259,10 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		c.RootShardKubeClusterClient, err = kcpkubernetesclientset.NewForConfig(nonIdentityRootKcpShardBaseConfig)
+		if err != nil {
+			return nil, err
+		}
 
 		c.IdentityConfig = rest.CopyConfig(c.GenericConfig.LoopbackClientConfig)
 		c.IdentityConfig.Wrap(kcpShardIdentityRoundTripper)
@@ -271,6 +279,7 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 			return nil, err
 		}
 		c.RootShardKcpClusterClient = c.KcpClusterClient
+		c.RootShardKubeClusterClient = c.KubeClusterClient
 	}
 
 	informerConfig := rest.CopyConfig(c.IdentityConfig)
@@ -304,6 +313,40 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 		}
 	}
 
+	// authenticate service accounts of other shards by looking them up through the front-proxy
+	serviceAccounts := opts.GenericControlPlane.Authentication.ServiceAccounts
+	if len(c.Options.Extra.ServiceAccountTokenValidatorKubeconfig) > 0 && serviceAccounts != nil && len(serviceAccounts.KeyFiles) > 0 {
+		tokenValidatorConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.Options.Extra.ServiceAccountTokenValidatorKubeconfig}, nil).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the service account token validator kubeconfig from %q: %w", c.Options.Extra.ServiceAccountTokenValidatorKubeconfig, err)
+		}
+		tokenValidatorClient, err := kcpkubernetesclientset.NewForConfig(tokenValidatorConfig)
+		if err != nil {
+			return nil, err
+		}
+		federatedServiceAccounts, err := kcpserviceaccount.NewFederatedAuthenticator(
+			serviceAccounts.Issuers,
+			serviceAccounts.KeyFiles,
+			c.GenericConfig.Authentication.APIAudiences,
+			kcpserviceaccount.NewFederatedClusterGetter(func(logicalcluster.Name) (kcpkubernetesclientset.ClusterInterface, error) {
+				return tokenValidatorClient, nil
+			}),
+			tokenValidatorClient.CoreV1().Secrets(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		c.GenericConfig.Authentication.Authenticator = authenticationunion.New(c.GenericConfig.Authentication.Authenticator, federatedServiceAccounts)
+	}
+
+	if len(c.Options.Extra.WorkspaceAccessTokenKeyFiles) > 0 {
+		accessTokens, err := accesstoken.NewAuthenticator(c.Options.Extra.WorkspaceAccessTokenKeyFiles)
+		if err != nil {
+			return nil, err
+		}
+		c.GenericConfig.Authentication.Authenticator = authenticationunion.New(c.GenericConfig.Authentication.Authenticator, accessTokens)
+	}
+
 	// Setup apiextensions * informers
 	c.ApiExtensionsClusterClient, err = kcpapiextensionsclientset.NewForConfig(c.GenericConfig.LoopbackClientConfig)
 	if err != nil {
@@ -352,6 +395,25 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 	if err := opts.GenericControlPlane.Audit.ApplyTo(c.GenericConfig); err != nil {
 		return nil, err
 	}
+	if opts.Extra.WorkspaceAuditLogDir != "" {
+		c.GenericConfig.AuditPolicyRuleEvaluator, c.GenericConfig.AuditBackend = kcpaudit.WithWorkspacePolicies(
+			c.GenericConfig.AuditPolicyRuleEvaluator,
+			c.GenericConfig.AuditBackend,
+			opts.Extra.WorkspaceAuditLogDir,
+			c.KcpSharedInformerFactory,
+			c.CacheKcpSharedInformerFactory,
+		)
+	}
+	if opts.Extra.OrganizationAuditSinkDir != "" || opts.Extra.OrganizationAuditSinkWebhooks {
+		c.GenericConfig.AuditPolicyRuleEvaluator, c.GenericConfig.AuditBackend = kcpaudit.WithOrganizationSinks(
+			c.GenericConfig.AuditPolicyRuleEvaluator,
+			c.GenericConfig.AuditBackend,
+			opts.Extra.OrganizationAuditSinkDir,
+			opts.Extra.OrganizationAuditSinkWebhooks,
+			c.KcpSharedInformerFactory,
+			c.CacheKcpSharedInformerFactory,
+		)
+	}
 
 	var shardVirtualWorkspaceURL *url.URL
 	if !opts.Virtual.Enabled && opts.Extra.ShardVirtualWorkspaceURL != "" {
@@ -393,9 +455,10 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 	c.preHandlerChainMux = &handlerChainMuxes{}
 	c.GenericConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, genericConfig *genericapiserver.Config) (secure http.Handler) {
 		apiHandler = openapiv3.WithOpenAPIv3(apiHandler, c.openAPIv3ServiceCache) // will be initialized further down after apiextensions-apiserver
+		apiHandler = WithVirtualWorkspaceDiscovery(apiHandler, genericConfig.Authorization.Authorizer, c.KcpSharedInformerFactory, c.CacheKcpSharedInformerFactory)
 		apiHandler = WithWildcardListWatchGuard(apiHandler)
 		apiHandler = WithRequestIdentity(apiHandler)
-		apiHandler = authorization.WithSubjectAccessReviewAuditAnnotations(apiHandler)
+		apiHandler = authorization.WithSubjectAccessReviewAuditAnnotations(apiHandler, authorization.AuditDetail(opts.Authorization.AuditDetail))
 		apiHandler = authorization.WithDeepSubjectAccessReview(apiHandler)
 
 		// The following ensures that only the default main api handler chain executes authorizers which log audit messages.
@@ -404,7 +467,7 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 		// First, remember authorizer chain with audit logging disabled.
 		authorizerWithoutAudit := genericConfig.Authorization.Authorizer
 		// configure audit logging enabled authorizer chain and build the apiHandler using this configuration.
-		genericConfig.Authorization.Authorizer = authorization.WithAuditLogging("request.auth.kcp.io", genericConfig.Authorization.Authorizer)
+		genericConfig.Authorization.Authorizer = authorization.WithAuditLogging("request.auth.kcp.io", authorization.AuditDetail(opts.Authorization.AuditDetail), genericConfig.Authorization.Authorizer)
 		apiHandler = genericapiserver.DefaultBuildHandlerChainFromAuthz(apiHandler, genericConfig)
 		// reset authorizer chain with audit logging disabled.
 		genericConfig.Authorization.Authorizer = authorizerWithoutAudit
@@ -426,16 +489,9 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 
 		// Only include this when the virtual workspace server is external
 		if shardVirtualWorkspaceURL != nil && virtualWorkspaceServerProxyTransport != nil {
-			proxy := &httputil.ReverseProxy{
-				Director: func(r *http.Request) {
-					r.URL.Scheme = shardVirtualWorkspaceURL.Scheme
-					r.URL.Host = shardVirtualWorkspaceURL.Host
-					delete(r.Header, "X-Forwarded-For")
-				},
-			}
 			// Note: this has to come after DefaultBuildHandlerChainBeforeAuthz because it needs the user info, which
 			// is only available after DefaultBuildHandlerChainBeforeAuthz.
-			apiHandler = WithVirtualWorkspacesProxy(apiHandler, shardVirtualWorkspaceURL, virtualWorkspaceServerProxyTransport, proxy)
+			apiHandler = WithVirtualWorkspacesProxy(apiHandler, shardVirtualWorkspaceURL, virtualWorkspaceServerProxyTransport)
 		}
 
 		apiHandler = genericapiserver.DefaultBuildHandlerChainBeforeAuthz(apiHandler, genericConfig)
@@ -531,10 +587,14 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 		admissionPluginInitializers,
 		opts.GenericControlPlane,
 		3,
-		conversionFactory)
+		newSystemCRDConverterFactory(conversionFactory))
 	if err != nil {
 		return nil, fmt.Errorf("error configuring api extensions: %w", err)
 	}
+	c.ApiExtensions.ExtraConfig.CRDRESTOptionsGetter, err = withCustomResourceWatchCacheSizes(c.ApiExtensions.ExtraConfig.CRDRESTOptionsGetter, opts.Extra.CustomResourceWatchCacheSizes)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring custom resource watch caches: %w", err)
+	}
 
 	// make sure the informer gets started, otherwise conversions will not work!
 	_ = c.KcpSharedInformerFactory.Apis().V1alpha1().APIConversions().Informer()
@@ -576,8 +636,10 @@ func NewConfig(opts kcpserveroptions.CompletedOptions) (*Config, error) {
 			opts,
 			virtualWorkspacesConfig,
 			c.KubeSharedInformerFactory,
+			c.CacheKubeSharedInformerFactory,
 			c.KcpSharedInformerFactory,
 			c.CacheKcpSharedInformerFactory,
+			c.CacheDynamicClient,
 			c.ShardExternalURL,
 		)
 		if err != nil {
@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	_ "net/http/pprof"
@@ -29,15 +30,19 @@ import (
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	kcpmetadata "github.com/kcp-dev/client-go/metadata"
 	"github.com/kcp-dev/logicalcluster/v3"
+	etcdtransport "go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	pluginvalidatingadmissionpolicy "k8s.io/apiserver/pkg/admission/plugin/policy/validating"
 	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	serverstorage "k8s.io/apiserver/pkg/server/storage"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	k8sscheme "k8s.io/client-go/kubernetes/scheme"
@@ -45,6 +50,8 @@ import (
 	"k8s.io/client-go/restmapper"
 	certutil "k8s.io/client-go/util/cert"
 	"k8s.io/client-go/util/keyutil"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-helpers/apimachinery/lease"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/controller/certificates/rootcacertpublisher"
 	"k8s.io/kubernetes/pkg/controller/clusterroleaggregation"
@@ -53,6 +60,7 @@ import (
 	"k8s.io/kubernetes/pkg/controller/validatingadmissionpolicystatus"
 	"k8s.io/kubernetes/pkg/generated/openapi"
 	"k8s.io/kubernetes/pkg/serviceaccount"
+	"k8s.io/utils/clock"
 
 	configuniversal "github.com/kcp-dev/kcp/config/universal"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
@@ -70,14 +78,21 @@ import (
 	apisreplicateclusterrole "github.com/kcp-dev/kcp/pkg/reconciler/apis/replicateclusterrole"
 	apisreplicateclusterrolebinding "github.com/kcp-dev/kcp/pkg/reconciler/apis/replicateclusterrolebinding"
 	apisreplicatelogicalcluster "github.com/kcp-dev/kcp/pkg/reconciler/apis/replicatelogicalcluster"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/storagemigration"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/storageversionmigration"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/labelclusterrolebindings"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/labelclusterroles"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/replication"
+	bootstrappolicycontroller "github.com/kcp-dev/kcp/pkg/reconciler/core/bootstrappolicy"
 	logicalclusterctrl "github.com/kcp-dev/kcp/pkg/reconciler/core/logicalcluster"
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterusage"
 	coresreplicateclusterrole "github.com/kcp-dev/kcp/pkg/reconciler/core/replicateclusterrole"
 	corereplicateclusterrolebinding "github.com/kcp-dev/kcp/pkg/reconciler/core/replicateclusterrolebinding"
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/shard"
+	shardbackupcontroller "github.com/kcp-dev/kcp/pkg/reconciler/core/shardbackup"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/sharddrain"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/shardusage"
 	"github.com/kcp-dev/kcp/pkg/reconciler/garbagecollector"
 	"github.com/kcp-dev/kcp/pkg/reconciler/kubequota"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/bootstrap"
@@ -88,9 +103,14 @@ import (
 	tenancyreplicatelogicalcluster "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/replicatelogicalcluster"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspace"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacemounts"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacequota"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacerolebinding"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacetype"
+	"github.com/kcp-dev/kcp/pkg/reconciler/topology/partitionhealth"
 	"github.com/kcp-dev/kcp/pkg/reconciler/topology/partitionset"
+	"github.com/kcp-dev/kcp/pkg/shardbackup"
 	initializingworkspacesbuilder "github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces/builder"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
@@ -513,8 +533,22 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	externalLogicalClusterAdminConfig = rest.CopyConfig(externalLogicalClusterAdminConfig)
 	externalLogicalClusterAdminConfig = rest.AddUserAgent(externalLogicalClusterAdminConfig, workspace.ControllerName+"+"+s.Options.Extra.ShardName)
 
+	thresholds := workspace.SchedulingThresholds{
+		MaxLogicalClusters: s.Options.Extra.SchedulingMaxLogicalClusters,
+		MinQPSHeadroom:     s.Options.Extra.SchedulingMinQPSHeadroom,
+	}
+	if s.Options.Extra.SchedulingMaxStorageSize != "" {
+		size, err := resource.ParseQuantity(s.Options.Extra.SchedulingMaxStorageSize)
+		if err != nil {
+			return err
+		}
+		thresholds.MaxStorageSizeBytes = size.Value()
+	}
+
 	workspaceController, err := workspace.NewController(
 		s.Options.Extra.ShardName,
+		tenancyv1alpha1.WorkspacePlacementStrategy(s.Options.Extra.WorkspacePlacementStrategy),
+		thresholds,
 		kcpClusterClient,
 		kubeClusterClient,
 		logicalClusterAdminConfig,
@@ -557,6 +591,7 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 		workspaceShardController, err = shard.NewController(
 			kcpClusterClient,
 			s.KcpSharedInformerFactory.Core().V1alpha1().Shards(),
+			s.KubeSharedInformerFactory.Coordination().V1().Leases(),
 		)
 		if err != nil {
 			return err
@@ -567,7 +602,8 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 			Name: shard.ControllerName,
 			Wait: func(ctx context.Context, s *Server) error {
 				return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-					return s.KcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
+					return s.KcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced() &&
+						s.KubeSharedInformerFactory.Coordination().V1().Leases().Informer().HasSynced(), nil
 				})
 			},
 			Runner: func(ctx context.Context) {
@@ -650,6 +686,317 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	})
 }
 
+func (s *Server) installShardDrainController(ctx context.Context) error {
+	c, err := sharddrain.NewController(
+		s.Options.Extra.ShardName,
+		s.RootShardKcpClusterClient,
+		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: sharddrain.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, 1)
+		},
+	})
+}
+
+func (s *Server) installBootstrapPolicyController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, bootstrappolicycontroller.ControllerName)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c := bootstrappolicycontroller.NewController(s.Options.Extra.BootstrapPolicyFile, kubeClusterClient)
+
+	return s.registerController(&controllerWrapper{
+		Name: bootstrappolicycontroller.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return nil // the bootstrap policy is reconciled live, not through informers
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.Options.Extra.BootstrapPolicySyncInterval)
+		},
+	})
+}
+
+func (s *Server) installShardLeaseController(ctx context.Context) error {
+	leaseDuration := s.Options.Extra.ShardLeaseDuration
+	c := lease.NewController(
+		clock.RealClock{},
+		s.RootShardKubeClusterClient.Cluster(core.RootCluster.Path()),
+		s.Options.Extra.ShardName,
+		int32(leaseDuration.Seconds()),
+		nil,
+		leaseDuration/4,
+		s.Options.Extra.ShardName,
+		corev1alpha1.ShardLeaseNamespace,
+		nil,
+	)
+
+	return s.registerController(&controllerWrapper{
+		Name: "kcp-shard-lease",
+		Wait: func(ctx context.Context, s *Server) error {
+			return nil // heartbeats do not depend on any informer
+		},
+		Runner: func(ctx context.Context) {
+			c.Run(ctx)
+		},
+	})
+}
+
+func (s *Server) installShardUsageController(ctx context.Context) error {
+	c, err := shardusage.NewController(
+		s.Options.Extra.ShardName,
+		s.Options.Extra.ShardMaxQPS,
+		s.RootShardKcpClusterClient,
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		legacyregistry.DefaultGatherer,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: shardusage.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.Options.Extra.ShardUsageReportInterval)
+		},
+	})
+}
+
+func (s *Server) installLogicalClusterUsageController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, logicalclusterusage.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	etcdClient, err := s.newEtcdClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := logicalclusterusage.NewController(
+		etcdClient,
+		s.Options.GenericControlPlane.Etcd.StorageConfig.Prefix,
+		s.Options.Extra.LogicalClusterUsageReportStatus,
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: logicalclusterusage.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.Options.Extra.LogicalClusterUsageSampleInterval)
+		},
+	})
+}
+
+func (s *Server) installShardBackupController(ctx context.Context) error {
+	var storage shardbackup.ObjectStorage
+	if location := s.Options.Extra.ShardBackupLocation; location != "" {
+		var err error
+		if storage, err = shardbackup.NewObjectStorage(location); err != nil {
+			return err
+		}
+	}
+	etcdClient, err := s.newEtcdClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := shardbackupcontroller.NewController(
+		s.Options.Extra.ShardName,
+		etcdClient,
+		storage,
+		s.RootShardKcpClusterClient,
+		s.CacheKcpSharedInformerFactory.Core().V1alpha1().ShardBackups(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: shardbackupcontroller.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.CacheKcpSharedInformerFactory.Core().V1alpha1().ShardBackups().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx)
+		},
+	})
+}
+
+// newEtcdClient returns a client for the etcd storing the objects of this shard, embedded or not.
+func (s *Server) newEtcdClient(ctx context.Context) (*clientv3.Client, error) {
+	transport := s.Options.GenericControlPlane.Etcd.StorageConfig.Transport
+	var tlsConfig *tls.Config
+	if transport.CertFile != "" || transport.KeyFile != "" || transport.TrustedCAFile != "" {
+		tlsInfo := etcdtransport.TLSInfo{
+			CertFile:      transport.CertFile,
+			KeyFile:       transport.KeyFile,
+			TrustedCAFile: transport.TrustedCAFile,
+		}
+		var err error
+		if tlsConfig, err = tlsInfo.ClientConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return clientv3.New(clientv3.Config{
+		Endpoints:   transport.ServerList,
+		TLS:         tlsConfig,
+		DialTimeout: 20 * time.Second,
+		Context:     ctx,
+	})
+}
+
+func (s *Server) installWorkspaceQuotaController(ctx context.Context, config *rest.Config) error {
+	usageConfig := rest.CopyConfig(config)
+	usageConfig = rest.AddUserAgent(usageConfig, workspacequota.UsageControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(usageConfig)
+	if err != nil {
+		return err
+	}
+
+	usageController, err := workspacequota.NewUsageController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas(),
+		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := s.registerController(&controllerWrapper{
+		Name: workspacequota.UsageControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			usageController.Start(ctx, 2)
+		},
+	}); err != nil {
+		return err
+	}
+
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacequota.ControllerName)
+	kcpClusterClient, err = kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workspacequota.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas(),
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.CacheKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: workspacequota.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, 2)
+		},
+	})
+}
+
+func (s *Server) installWorkspaceRoleBindingController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacerolebinding.ControllerName)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	var feed workspacerolebinding.GroupFeed
+	if s.Options.Extra.WorkspaceMembershipFeed != "" {
+		feed, err = workspacerolebinding.NewGroupFeed(s.Options.Extra.WorkspaceMembershipFeedType, s.Options.Extra.WorkspaceMembershipFeed, s.Options.Extra.WorkspaceMembershipFeedTokenFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := workspacerolebinding.NewController(
+		kubeClusterClient,
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceRoleBindings(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		feed,
+		s.Options.Extra.WorkspaceMembershipSyncInterval,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: workspacerolebinding.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceRoleBindings().Informer().HasSynced() &&
+					s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, 2)
+		},
+	})
+}
+
 func (s *Server) installWorkspaceMountsScheduler(ctx context.Context, config *rest.Config) error {
 	// TODO(mjudeikis): Remove this and move to batteries.
 	if !kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.WorkspaceMounts) {
@@ -888,30 +1235,42 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 	})
 }
 
-func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Config) error {
-	// Client used to create APIBindings within the initializing workspace
+// initializingWorkspacesConfig returns a client config for the initializing workspaces virtual workspace
+// of the given initializer.
+func (s *Server) initializingWorkspacesConfig(config *rest.Config, initializer corev1alpha1.LogicalClusterInitializer) (*rest.Config, error) {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, initialization.ControllerName)
-	config.Host += initializingworkspacesbuilder.URLFor(tenancyv1alpha1.WorkspaceAPIBindingsInitializer)
+	config.Host += initializingworkspacesbuilder.URLFor(initializer)
 
 	if !s.Options.Virtual.Enabled && s.Options.Extra.ShardVirtualWorkspaceURL != "" {
 		vwURL := fmt.Sprintf("https://%s", s.GenericConfig.ExternalAddress)
 		if s.Options.Extra.ShardVirtualWorkspaceCAFile == "" {
 			// TODO move verification up
-			return fmt.Errorf("s.Options.Extra.ShardVirtualWorkspaceCAFile is required")
+			return nil, fmt.Errorf("s.Options.Extra.ShardVirtualWorkspaceCAFile is required")
 		}
 		if s.Options.Extra.ShardClientCertFile == "" {
 			// TODO move verification up
-			return fmt.Errorf("s.Options.Extra.ShardClientCertFile is required")
+			return nil, fmt.Errorf("s.Options.Extra.ShardClientCertFile is required")
 		}
 		if s.Options.Extra.ShardClientKeyFile == "" {
 			// TODO move verification up
-			return fmt.Errorf("s.Options.Extra.ShardClientKeyFile is required")
+			return nil, fmt.Errorf("s.Options.Extra.ShardClientKeyFile is required")
 		}
 		config.TLSClientConfig.CAFile = s.Options.Extra.ShardVirtualWorkspaceCAFile
 		config.TLSClientConfig.CertFile = s.Options.Extra.ShardClientCertFile
 		config.TLSClientConfig.KeyFile = s.Options.Extra.ShardClientKeyFile
-		config.Host = fmt.Sprintf("%v%v", vwURL, initializingworkspacesbuilder.URLFor(tenancyv1alpha1.WorkspaceAPIBindingsInitializer))
+		config.Host = fmt.Sprintf("%v%v", vwURL, initializingworkspacesbuilder.URLFor(initializer))
+	}
+
+	return config, nil
+}
+
+func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Config) error {
+	// Client used to create APIBindings within the initializing workspace
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, initialization.ControllerName)
+	config, err := s.initializingWorkspacesConfig(config, tenancyv1alpha1.WorkspaceAPIBindingsInitializer)
+	if err != nil {
+		return err
 	}
 
 	initializingWorkspacesKcpClusterClient, err := kcpclientset.NewForConfig(config)
@@ -963,6 +1322,59 @@ func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Co
 	})
 }
 
+func (s *Server) installTemplaterController(ctx context.Context, config *rest.Config) error {
+	// Clients used to create the template objects within the initializing workspace
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, initialization.TemplaterControllerName)
+	config, err := s.initializingWorkspacesConfig(config, tenancyv1alpha1.WorkspaceTemplatesInitializer)
+	if err != nil {
+		return err
+	}
+
+	initializingWorkspacesKcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	initializingWorkspacesDynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// This informer factory is created here because it is specifically against the initializing workspaces virtual
+	// workspace.
+	initializingWorkspacesKcpInformers := kcpinformers.NewSharedInformerFactoryWithOptions(
+		initializingWorkspacesKcpClusterClient,
+		resyncPeriod,
+	)
+
+	c, err := initialization.NewTemplater(
+		initializingWorkspacesKcpClusterClient,
+		initializingWorkspacesDynamicClusterClient,
+		initializingWorkspacesKcpInformers.Core().V1alpha1().LogicalClusters(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: initialization.TemplaterControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			initializingWorkspacesKcpInformers.Start(ctx.Done())
+			initializingWorkspacesKcpInformers.WaitForCacheSync(ctx.Done())
+
+			c.Start(ctx, 2)
+		},
+	})
+}
+
 func (s *Server) installCRDCleanupController(ctx context.Context, config *rest.Config) error {
 	config = rest.CopyConfig(config)
 	config = rest.AddUserAgent(config, crdcleanup.ControllerName)
@@ -1316,6 +1728,7 @@ func (s *Server) installPartitionSetController(ctx context.Context, config *rest
 		s.KcpSharedInformerFactory.Topology().V1alpha1().PartitionSets(),
 		s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
 		kcpClusterClient,
 	)
 	if err != nil {
@@ -1328,6 +1741,39 @@ func (s *Server) installPartitionSetController(ctx context.Context, config *rest
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KcpSharedInformerFactory.Topology().V1alpha1().PartitionSets().Informer().HasSynced() &&
 					s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, 2)
+		},
+	})
+}
+
+func (s *Server) installPartitionHealthController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, partitionhealth.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := partitionhealth.NewController(
+		s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
+		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
+		kcpClusterClient,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: partitionhealth.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
 			})
 		},
@@ -1367,6 +1813,103 @@ func (s *Server) installExtraAnnotationSyncController(ctx context.Context, confi
 	})
 }
 
+func (s *Server) installStorageMigrationController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, storagemigration.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := storagemigration.NewController(
+		kcpClusterClient,
+		dynamicClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: storagemigration.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, 2)
+		},
+	})
+}
+
+func (s *Server) installStorageVersionMigrationController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, storageversionmigration.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// built-in resources are persisted in the version chosen by the storage factory of the kube apiserver
+	storageFactory, ok := s.CompletedConfig.Apis.StorageFactory.(*serverstorage.DefaultStorageFactory)
+	if !ok {
+		return fmt.Errorf("unexpected storage factory type %T", s.CompletedConfig.Apis.StorageFactory)
+	}
+	getBuiltInStorageVersion := func(gr schema.GroupResource) (string, bool) {
+		gv, err := storageFactory.ResourceEncodingConfig.StorageEncodingFor(gr)
+		if err != nil {
+			return "", false
+		}
+		return gv.Version, true
+	}
+
+	c, err := storageversionmigration.NewController(
+		kcpClusterClient,
+		crdClusterClient,
+		dynamicClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().StorageVersionMigrations(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+		getBuiltInStorageVersion,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name: storageversionmigration.ControllerName,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Apis().V1alpha1().StorageVersionMigrations().Informer().HasSynced() &&
+					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, 2)
+		},
+	})
+}
+
 func (s *Server) installKubeQuotaController(
 	ctx context.Context,
 	config *rest.Config,
@@ -1547,7 +2090,8 @@ func (s *Server) addIndexersToInformers(ctx context.Context) map[schema.GroupVer
 	labelclusterroles.InstallIndexers(s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings())
 	workspace.InstallIndexers(s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
-		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes())
+		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters())
 
 	extraannotationsync.InstallIndexers(
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
@@ -1567,5 +2111,10 @@ func (s *Server) addIndexersToInformers(ctx context.Context) map[schema.GroupVer
 		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
 		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes())
 	crdcleanup.InstallIndexers(s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings())
+	storagemigration.InstallIndexers(
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports())
+	storageversionmigration.InstallIndexers(s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings())
 	return gvrs
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	kcpscheme "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/scheme"
+)
+
+// systemCRDConverterFactory converts objects of the multi-version system CRDs, e.g. apis.kcp.io
+// v1alpha1 and v1alpha2, in-process with the conversion functions registered in the kcp scheme.
+// All other CRDs are handled by the delegate according to their conversion strategy.
+type systemCRDConverterFactory struct {
+	delegate conversion.Factory
+}
+
+var _ conversion.Factory = &systemCRDConverterFactory{}
+
+func newSystemCRDConverterFactory(delegate conversion.Factory) *systemCRDConverterFactory {
+	return &systemCRDConverterFactory{delegate: delegate}
+}
+
+func (f *systemCRDConverterFactory) NewConverter(crd *apiextensionsv1.CustomResourceDefinition) (conversion.CRConverter, error) {
+	if logicalcluster.From(crd) != SystemCRDClusterName || len(crd.Spec.Versions) < 2 {
+		return f.delegate.NewConverter(crd)
+	}
+	return conversion.CRConverterFunc(convertSystemCRs), nil
+}
+
+// convertSystemCRs converts the given objects to the target version. Kinds without typed
+// conversion in the kcp scheme only get the new apiVersion, like with the None strategy.
+func convertSystemCRs(in *unstructured.UnstructuredList, targetGV schema.GroupVersion) (*unstructured.UnstructuredList, error) {
+	for i := range in.Items {
+		item := &in.Items[i]
+		gvk := item.GroupVersionKind()
+		targetGVK := targetGV.WithKind(gvk.Kind)
+		if gvk == targetGVK {
+			continue
+		}
+		if !kcpscheme.Scheme.Recognizes(gvk) || !kcpscheme.Scheme.Recognizes(targetGVK) {
+			item.SetAPIVersion(targetGV.String())
+			continue
+		}
+
+		obj, err := kcpscheme.Scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		if err := helpers.DecodeUnstructured(item, obj); err != nil {
+			return nil, fmt.Errorf("failed to decode %s %s: %w", gvk, item.GetName(), err)
+		}
+		item.SetGroupVersionKind(targetGVK)
+		if err := helpers.EncodeUnstructured(obj, item); err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s to %s: %w", gvk, item.GetName(), targetGV, err)
+		}
+	}
+	return in, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+)
+
+type fakeConverterFactory struct {
+	called bool
+}
+
+func (f *fakeConverterFactory) NewConverter(_ *apiextensionsv1.CustomResourceDefinition) (conversion.CRConverter, error) {
+	f.called = true
+	return conversion.NewNOPConverter(), nil
+}
+
+func TestSystemCRDConverterFactory(t *testing.T) {
+	crd := func(cluster logicalcluster.Name, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "apiexports.apis.kcp.io",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: cluster.String()},
+			},
+		}
+		for _, v := range versions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v})
+		}
+		return crd
+	}
+
+	t.Run("non-system CRDs are delegated", func(t *testing.T) {
+		delegate := &fakeConverterFactory{}
+		_, err := newSystemCRDConverterFactory(delegate).NewConverter(crd("root", "v1alpha1", "v1alpha2"))
+		require.NoError(t, err)
+		require.True(t, delegate.called)
+	})
+
+	t.Run("single version system CRDs are delegated", func(t *testing.T) {
+		delegate := &fakeConverterFactory{}
+		_, err := newSystemCRDConverterFactory(delegate).NewConverter(crd(SystemCRDClusterName, "v1alpha1"))
+		require.NoError(t, err)
+		require.True(t, delegate.called)
+	})
+
+	t.Run("multi-version system CRDs are converted with the kcp scheme", func(t *testing.T) {
+		delegate := &fakeConverterFactory{}
+		converter, err := newSystemCRDConverterFactory(delegate).NewConverter(crd(SystemCRDClusterName, "v1alpha1", "v1alpha2"))
+		require.NoError(t, err)
+		require.False(t, delegate.called)
+
+		in := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"apiVersion": "apis.kcp.io/v1alpha1",
+				"kind":       "APIExport",
+				"metadata":   map[string]interface{}{"name": "cowboys"},
+				"spec": map[string]interface{}{
+					"latestResourceSchemas": []interface{}{"today.cowboys.wildwest.dev"},
+					"permissionClaims": []interface{}{
+						map[string]interface{}{"group": "", "resource": "configmaps", "all": true},
+					},
+				},
+			}},
+			{Object: map[string]interface{}{
+				"apiVersion": "apis.kcp.io/v1alpha1",
+				"kind":       "APIResourceSchema",
+				"metadata":   map[string]interface{}{"name": "today.cowboys.wildwest.dev"},
+			}},
+		}}
+
+		out, err := converter.Convert(in, apisv1alpha2.SchemeGroupVersion)
+		require.NoError(t, err)
+
+		require.Equal(t, "apis.kcp.io/v1alpha2", out.Items[0].GetAPIVersion())
+		require.Equal(t, "APIExport", out.Items[0].GetKind())
+		resources, _, err := unstructured.NestedSlice(out.Items[0].Object, "spec", "resources")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"name": "cowboys", "group": "wildwest.dev", "schema": "today.cowboys.wildwest.dev"},
+		}, resources)
+		matchAll, _, err := unstructured.NestedFieldNoCopy(out.Items[0].Object, "spec", "permissionClaims")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"resource": "configmaps", "selector": map[string]interface{}{"matchAll": true}},
+		}, matchAll)
+
+		// kinds without a typed conversion only change their apiVersion
+		require.Equal(t, "apis.kcp.io/v1alpha2", out.Items[1].GetAPIVersion())
+
+		back, err := converter.Convert(&unstructured.UnstructuredList{Items: out.Items[:1]}, schema.GroupVersion{Group: "apis.kcp.io", Version: "v1alpha1"})
+		require.NoError(t, err)
+		schemas, _, err := unstructured.NestedStringSlice(back.Items[0].Object, "spec", "latestResourceSchemas")
+		require.NoError(t, err)
+		require.Equal(t, []string{"today.cowboys.wildwest.dev"}, schemas)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/generic"
+	genericapiserveroptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/klog/v2"
+)
+
+// watchCacheSizesRESTOptionsGetter applies watch cache sizes to the storage of custom and bound
+// resources. Upstream ignores --watch-cache-sizes for custom resources, and watch caches are sized
+// dynamically, hence only a size of 0, disabling the watch cache, has an effect.
+type watchCacheSizesRESTOptionsGetter struct {
+	delegate generic.RESTOptionsGetter
+	sizes    map[schema.GroupResource]int
+}
+
+// withCustomResourceWatchCacheSizes wraps the RESTOptionsGetter of custom resources with the given
+// sizes in the format of --watch-cache-sizes, i.e. resource[.group]#size.
+func withCustomResourceWatchCacheSizes(delegate generic.RESTOptionsGetter, watchCacheSizes []string) (generic.RESTOptionsGetter, error) {
+	if len(watchCacheSizes) == 0 {
+		return delegate, nil
+	}
+	sizes, err := genericapiserveroptions.ParseWatchCacheSizes(watchCacheSizes)
+	if err != nil {
+		return nil, err
+	}
+	for resource, size := range sizes {
+		if size > 0 {
+			klog.Background().Info("Ignoring watch cache size of custom resource, watch caches are sized dynamically", "resource", resource, "size", size)
+		}
+	}
+	return &watchCacheSizesRESTOptionsGetter{delegate: delegate, sizes: sizes}, nil
+}
+
+func (g *watchCacheSizesRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
+	ret, err := g.delegate.GetRESTOptions(resource)
+	if err != nil {
+		return ret, err
+	}
+	if size, found := g.sizes[resource]; found && size == 0 {
+		klog.Background().V(3).Info("Not using watch cache", "resource", resource)
+		ret.Decorator = generic.UndecoratedStorage
+	}
+	return ret, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/generic"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+)
+
+type fakeRESTOptionsGetter struct{}
+
+func (fakeRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
+	return generic.RESTOptions{Decorator: genericregistry.StorageWithCacher(), ResourcePrefix: resource.String()}, nil
+}
+
+func TestWithCustomResourceWatchCacheSizes(t *testing.T) {
+	getter, err := withCustomResourceWatchCacheSizes(fakeRESTOptionsGetter{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, fakeRESTOptionsGetter{}, getter, "no sizes leave the getter untouched")
+
+	_, err = withCustomResourceWatchCacheSizes(fakeRESTOptionsGetter{}, []string{"widgets.example.com"})
+	require.Error(t, err)
+
+	getter, err = withCustomResourceWatchCacheSizes(fakeRESTOptionsGetter{}, []string{"widgets.example.com#0", "gadgets.example.com#100"})
+	require.NoError(t, err)
+
+	undecorated := reflect.ValueOf(generic.UndecoratedStorage).Pointer()
+	for resource, wantCache := range map[schema.GroupResource]bool{
+		{Group: "example.com", Resource: "widgets"}: false,
+		{Group: "example.com", Resource: "gadgets"}: true,
+		{Group: "other.com", Resource: "widgets"}:   true,
+	} {
+		opts, err := getter.GetRESTOptions(resource)
+		require.NoError(t, err)
+		require.Equal(t, resource.String(), opts.ResourcePrefix)
+		require.Equal(t, wantCache, reflect.ValueOf(opts.Decorator).Pointer() != undecorated, "watch cache of %s", resource)
+	}
+}
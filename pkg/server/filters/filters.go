@@ -37,6 +37,7 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 	"k8s.io/apiserver/pkg/endpoints/request"
 
+	kcpaudit "github.com/kcp-dev/kcp/pkg/audit"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	informersv1alpha1 "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
 )
@@ -46,8 +47,6 @@ type (
 )
 
 const (
-	workspaceAnnotation = "tenancy.kcp.io/workspace"
-
 	// inactiveAnnotation is the annotation denoting a logical cluster should be
 	// deemed unreachable.
 	inactiveAnnotation = "internal.kcp.io/inactive"
@@ -79,7 +78,7 @@ func WithAuditEventClusterAnnotation(handler http.Handler) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		cluster := request.ClusterFrom(req.Context())
 		if cluster != nil {
-			kaudit.AddAuditAnnotation(req.Context(), workspaceAnnotation, cluster.Name.String())
+			kaudit.AddAuditAnnotation(req.Context(), kcpaudit.WorkspaceAnnotationKey, cluster.Name.String())
 		}
 
 		handler.ServeHTTP(w, req)
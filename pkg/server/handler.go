@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	_ "net/http/pprof"
 	"net/url"
 	"path"
@@ -40,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
+	utilproxy "k8s.io/apimachinery/pkg/util/proxy"
 	"k8s.io/apimachinery/pkg/util/sets"
 	apiserverdiscovery "k8s.io/apiserver/pkg/endpoints/discovery"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
@@ -47,6 +47,8 @@ import (
 	clientgotransport "k8s.io/client-go/transport"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/controlplane/apiserver/miniaggregator"
+
+	virtualhandler "github.com/kcp-dev/kcp/pkg/virtual/framework/handler"
 )
 
 var (
@@ -94,7 +96,8 @@ func UserAgentFrom(ctx context.Context) string {
 // not go through the front proxy) to the external virtual workspaces server. Proxying is required to avoid
 // certificate verification errors because these requests typically come from the kcp loopback client, and it is
 // impossible to use that client against any server other than kcp.
-func WithVirtualWorkspacesProxy(apiHandler http.Handler, shardVirtualWorkspaceURL *url.URL, transport http.RoundTripper, proxy *httputil.ReverseProxy) http.HandlerFunc {
+// Connection upgrades, e.g. watches over WebSocket and exec over SPDY, are passed through.
+func WithVirtualWorkspacesProxy(apiHandler http.Handler, shardVirtualWorkspaceURL *url.URL, transport http.RoundTripper) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		logger := klog.FromContext(req.Context())
 
@@ -118,16 +121,21 @@ func WithVirtualWorkspacesProxy(apiHandler http.Handler, shardVirtualWorkspaceUR
 			return
 		}
 
-		proxy.Transport = clientgotransport.NewImpersonatingRoundTripper(
-			clientgotransport.ImpersonationConfig{
-				UserName: user.GetName(),
-				UID:      user.GetUID(),
-				Groups:   user.GetGroups(),
-				Extra:    user.GetExtra(),
-			},
-			transport,
+		impersonation := clientgotransport.ImpersonationConfig{
+			UserName: user.GetName(),
+			UID:      user.GetUID(),
+			Groups:   user.GetGroups(),
+			Extra:    user.GetExtra(),
+		}
+		proxy := virtualhandler.NewProxy(
+			shardVirtualWorkspaceURL,
+			clientgotransport.NewImpersonatingRoundTripper(impersonation, transport),
+			clientgotransport.NewImpersonatingRoundTripper(impersonation, utilproxy.MirrorRequest),
 		)
 
+		req = req.Clone(req.Context())
+		req.Header.Del("X-Forwarded-For")
+
 		logger.V(4).Info("proxying virtual workspace", "target", req.URL.String())
 		proxy.ServeHTTP(w, req)
 	}
@@ -235,6 +235,11 @@ func (h *homeWorkspaceHandler) ServeHTTP(rw http.ResponseWriter, req *http.Reque
 			responsewriters.InternalError(rw, req, err)
 			return
 		}
+		logicalCluster.Spec.Terminators, err = reconcilerworkspace.LogicalClustersTerminators(h.transitiveTypeResolver, h.getWorkspaceType, core.RootCluster.Path(), "home")
+		if err != nil {
+			responsewriters.InternalError(rw, req, err)
+			return
+		}
 
 		logger.Info("Creating home LogicalCluster", "cluster", homeClusterName.String(), "user", effectiveUser.GetName())
 		logicalCluster, err = h.kcpClusterClient.Cluster(homeClusterName.Path()).CoreV1alpha1().LogicalClusters().Create(ctx, logicalCluster, metav1.CreateOptions{})
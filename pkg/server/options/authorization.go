@@ -17,6 +17,9 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+	"slices"
+
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	"github.com/spf13/pflag"
 
@@ -28,6 +31,7 @@ import (
 	genericapiserver "k8s.io/apiserver/pkg/server"
 
 	authz "github.com/kcp-dev/kcp/pkg/authorization"
+	"github.com/kcp-dev/kcp/pkg/authorization/webhook"
 	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
@@ -38,6 +42,12 @@ type Authorization struct {
 
 	// AlwaysAllowGroups are groups which are allowed to take any actions.  In kube, this is privileged system group.
 	AlwaysAllowGroups []string
+
+	// AuditDetail is the verbosity of the audit annotations recorded by the kcp authorizers.
+	AuditDetail string
+
+	// Webhook is the external authorization webhook called after the kcp authorizers.
+	Webhook *webhook.Options
 }
 
 func NewAuthorization() *Authorization {
@@ -46,6 +56,8 @@ func NewAuthorization() *Authorization {
 		// This field can be cleared by callers if they don't want this behavior.
 		AlwaysAllowPaths:  []string{"/healthz", "/readyz", "/livez"},
 		AlwaysAllowGroups: []string{user.SystemPrivilegedGroup},
+		AuditDetail:       string(authz.AuditDetailReason),
+		Webhook:           webhook.NewOptions(),
 	}
 }
 
@@ -68,6 +80,11 @@ func (s *Authorization) Validate() []error {
 
 	allErrors := []error{}
 
+	if !slices.Contains(authz.AuditDetails, authz.AuditDetail(s.AuditDetail)) {
+		allErrors = append(allErrors, fmt.Errorf("--authorization-audit-detail must be one of %v", authz.AuditDetails))
+	}
+	allErrors = append(allErrors, s.Webhook.Validate()...)
+
 	return allErrors
 }
 
@@ -79,6 +96,10 @@ func (s *Authorization) AddFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVar(&s.AlwaysAllowPaths, "authorization-always-allow-paths", s.AlwaysAllowPaths,
 		"A list of HTTP paths to skip during authorization, i.e. these are authorized without "+
 			"contacting the 'core' kubernetes server.")
+	fs.StringVar(&s.AuditDetail, "authorization-audit-detail", s.AuditDetail,
+		fmt.Sprintf("The audit annotations recorded by the kcp authorizers for their decisions. One of %v. "+
+			"Structured adds a JSON annotation per authorizer with the decision, reason, error and the authorized attributes.", authz.AuditDetails))
+	s.Webhook.AddFlags(fs)
 }
 
 func (s *Authorization) ApplyTo(config *genericapiserver.Config, kubeInformers, globalKubeInformers kcpkubernetesinformers.SharedInformerFactory, kcpInformers, globalKcpInformers kcpinformers.SharedInformerFactory) error {
@@ -115,10 +136,14 @@ func (s *Authorization) ApplyTo(config *genericapiserver.Config, kubeInformers,
 	globalAuth, _ := authz.NewGlobalAuthorizer(kubeInformers, globalKubeInformers)
 	globalAuth = authz.NewDecorator("05-global", globalAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
+	// resolves ClusterRoleBindings labeled for inheritance in the parent workspaces
+	inheritedRBAC, inheritedResolver := authz.NewInheritedAuthorizer(kubeInformers, globalKubeInformers, localLogicalClusterLister, globalLogicalClusterLister)
+	inheritedAuth := authz.NewDecorator("05-inherited", inheritedRBAC).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
+
 	// everything below - skipped for Deep SAR
 
 	// enforce maximal permission policy
-	maxPermissionPolicyAuth := authz.NewMaximalPermissionPolicyAuthorizer(kubeInformers, globalKubeInformers, kcpInformers, globalKcpInformers, union.New(bootstrapAuth, localAuth, globalAuth))
+	maxPermissionPolicyAuth := authz.NewMaximalPermissionPolicyAuthorizer(kubeInformers, globalKubeInformers, kcpInformers, globalKcpInformers, union.New(bootstrapAuth, localAuth, globalAuth, inheritedAuth))
 	maxPermissionPolicyAuth = authz.NewDecorator("04-maxpermissionpolicy", maxPermissionPolicyAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
 	// protect status updates to apiexport and apibinding
@@ -128,8 +153,8 @@ func (s *Authorization) ApplyTo(config *genericapiserver.Config, kubeInformers,
 	// content auth deteremines if users have access to the workspace itself - by default, in Kube there is a set
 	// of default permissions given even to system:authenticated (like access to discovery) - this authorizer allows
 	// kcp to make workspaces entirely invisible to users that have not been given access, by making system:authenticated
-	// mean nothing unless they also have `verb=access` on `/`
-	contentAuth := authz.NewWorkspaceContentAuthorizer(kubeInformers, globalKubeInformers, localLogicalClusterLister, globalLogicalClusterLister, systemCRDAuth)
+	// mean nothing unless they also have `verb=access` on `/`, possibly inherited from a parent workspace
+	contentAuth := authz.NewWorkspaceContentAuthorizer(kubeInformers, globalKubeInformers, localLogicalClusterLister, globalLogicalClusterLister, inheritedRBAC, systemCRDAuth)
 	contentAuth = authz.NewDecorator("02-content", contentAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
 	// workspaces are annotated to list the groups required on users wishing to access the workspace -
@@ -141,7 +166,23 @@ func (s *Authorization) ApplyTo(config *genericapiserver.Config, kubeInformers,
 
 	authorizers = append(authorizers, requiredGroupsAuth)
 
-	config.RuleResolver = union.NewRuleResolvers(bootstrapRules, localResolver)
-	config.Authorization.Authorizer = union.New(authorizers...)
+	// an external authorization webhook, e.g. a policy decision point, decides what the kcp
+	// authorizers have no opinion about, knowing the logical cluster of the request
+	if s.Webhook.Enabled() {
+		webhookAuth, err := s.Webhook.NewAuthorizer(webhook.ShardClusterExtra(localLogicalClusterLister, globalLogicalClusterLister))
+		if err != nil {
+			return err
+		}
+		webhookAuth = authz.NewDecorator("06-webhook", webhookAuth).AddAuditLogging()
+		authorizers = append(authorizers, webhookAuth)
+	}
+
+	// workspace access tokens are restricted to a workspace subtree and their rules, even for
+	// privileged groups and paths, hence this wraps all authorizers
+	workspaceAccessTokenAuth := authz.NewWorkspaceAccessTokenAuthorizer(localLogicalClusterLister, globalLogicalClusterLister, union.New(authorizers...))
+	workspaceAccessTokenAuth = authz.NewDecorator("00-workspaceaccesstoken", workspaceAccessTokenAuth).AddAuditLogging()
+
+	config.RuleResolver = union.NewRuleResolvers(bootstrapRules, localResolver, inheritedResolver)
+	config.Authorization.Authorizer = workspaceAccessTokenAuth
 	return nil
 }
@@ -39,6 +39,8 @@ type Controllers struct {
 	LeaderElectionNamespace string
 	LeaderElectionName      string
 
+	MetricsPerLogicalCluster bool
+
 	SAController kcmoptions.SAControllerOptions
 }
 
@@ -75,6 +77,8 @@ func (c *Controllers) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.LeaderElectionNamespace, "leader-election-namespace", c.LeaderElectionNamespace, "Namespace in system:admin workspace to use for leader election")
 	fs.StringVar(&c.LeaderElectionName, "leader-election-name", c.LeaderElectionName, "Name of the lease to use for leader election")
 
+	fs.BoolVar(&c.MetricsPerLogicalCluster, "controller-metrics-per-logical-cluster", c.MetricsPerLogicalCluster, "Count the reconciliations of kcp controllers per logical cluster. The number of metric series grows with the number of logical clusters.")
+
 	c.SAController.AddFlags(fs)
 }
 
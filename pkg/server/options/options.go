@@ -20,21 +20,27 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	genericapiserveroptions "k8s.io/apiserver/pkg/server/options"
 	cliflag "k8s.io/component-base/cli/flag"
 	controlplaneapiserver "k8s.io/kubernetes/pkg/controlplane/apiserver/options"
 	kubeoptions "k8s.io/kubernetes/pkg/kubeapiserver/options"
 
 	kcpadmission "github.com/kcp-dev/kcp/pkg/admission"
+	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	etcdoptions "github.com/kcp-dev/kcp/pkg/embeddedetcd/options"
 	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacerolebinding"
 	"github.com/kcp-dev/kcp/pkg/server/options/batteries"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
 type Options struct {
@@ -51,22 +57,45 @@ type Options struct {
 }
 
 type ExtraOptions struct {
-	ProfilerAddress                       string
-	ShardKubeconfigFile                   string
-	RootShardKubeconfigFile               string
-	ShardBaseURL                          string
-	ShardExternalURL                      string
-	ShardName                             string
-	ShardVirtualWorkspaceURL              string
-	ShardClientCertFile                   string
-	ShardClientKeyFile                    string
-	ShardVirtualWorkspaceCAFile           string
-	DiscoveryPollInterval                 time.Duration
-	ExperimentalBindFreePort              bool
-	LogicalClusterAdminKubeconfig         string
-	ExternalLogicalClusterAdminKubeconfig string
-	ConversionCELTransformationTimeout    time.Duration
-	BatteriesIncluded                     []string
+	ProfilerAddress                        string
+	ShardKubeconfigFile                    string
+	RootShardKubeconfigFile                string
+	ShardBaseURL                           string
+	ShardExternalURL                       string
+	ShardName                              string
+	ShardVirtualWorkspaceURL               string
+	ShardClientCertFile                    string
+	ShardClientKeyFile                     string
+	ShardVirtualWorkspaceCAFile            string
+	DiscoveryPollInterval                  time.Duration
+	ExperimentalBindFreePort               bool
+	LogicalClusterAdminKubeconfig          string
+	ExternalLogicalClusterAdminKubeconfig  string
+	ServiceAccountTokenValidatorKubeconfig string
+	ConversionCELTransformationTimeout     time.Duration
+	BatteriesIncluded                      []string
+	WorkspaceAuditLogDir                   string
+	OrganizationAuditSinkDir               string
+	OrganizationAuditSinkWebhooks          bool
+	WorkspacePlacementStrategy             string
+	ShardMaxQPS                            int64
+	ShardUsageReportInterval               time.Duration
+	LogicalClusterUsageSampleInterval      time.Duration
+	LogicalClusterUsageReportStatus        bool
+	ShardBackupLocation                    string
+	CustomResourceWatchCacheSizes          []string
+	ShardLeaseDuration                     time.Duration
+	SchedulingMaxLogicalClusters           int64
+	SchedulingMaxStorageSize               string
+	SchedulingMinQPSHeadroom               int64
+	ShardLocalWorkspaces                   []string
+	WorkspaceMembershipFeedType            string
+	WorkspaceMembershipFeed                string
+	WorkspaceMembershipFeedTokenFile       string
+	WorkspaceMembershipSyncInterval        time.Duration
+	WorkspaceAccessTokenKeyFiles           []string
+	BootstrapPolicyFile                    string
+	BootstrapPolicySyncInterval            time.Duration
 }
 
 type completedOptions struct {
@@ -107,6 +136,12 @@ func NewOptions(rootDir string) *Options {
 			DiscoveryPollInterval:              60 * time.Second,
 			ExperimentalBindFreePort:           false,
 			ConversionCELTransformationTimeout: time.Second,
+			WorkspacePlacementStrategy:         string(tenancyv1alpha1.WorkspacePlacementRandom),
+			ShardUsageReportInterval:           30 * time.Second,
+			ShardLeaseDuration:                 40 * time.Second,
+			WorkspaceMembershipFeedType:        workspacerolebinding.GroupFeedFile,
+			WorkspaceMembershipSyncInterval:    5 * time.Minute,
+			BootstrapPolicySyncInterval:        time.Minute,
 
 			BatteriesIncluded: sets.List[string](batteries.Defaults),
 		},
@@ -174,10 +209,34 @@ func (o *Options) AddFlags(fss *cliflag.NamedFlagSets) {
 	fs.StringVar(&o.Extra.ShardClientKeyFile, "shard-client-key-file", o.Extra.ShardClientKeyFile, "Path to a client certificate key file the shard uses to communicate with other system components.")
 	fs.StringVar(&o.Extra.LogicalClusterAdminKubeconfig, "logical-cluster-admin-kubeconfig", o.Extra.LogicalClusterAdminKubeconfig, "Kubeconfig holding system:kcp:logical-cluster-admin credentials for connecting to other shards. Defaults to the loopback client")
 	fs.StringVar(&o.Extra.ExternalLogicalClusterAdminKubeconfig, "external-logical-cluster-admin-kubeconfig", o.Extra.ExternalLogicalClusterAdminKubeconfig, "Kubeconfig holding system:kcp:external-logical-cluster-admin credentials for connecting to the external address (e.g. the front-proxy). Defaults to the loopback client")
+	fs.StringVar(&o.Extra.ServiceAccountTokenValidatorKubeconfig, "serviceaccount-token-validator-kubeconfig", o.Extra.ServiceAccountTokenValidatorKubeconfig, "Kubeconfig holding system:kcp:serviceaccount-token-validator credentials for looking up the service accounts of tokens issued by other shards through the external address (e.g. the front-proxy). If unset, service account tokens of other shards are not authenticated.")
 
 	fs.BoolVar(&o.Extra.ExperimentalBindFreePort, "experimental-bind-free-port", o.Extra.ExperimentalBindFreePort, "Bind to a free port. --secure-port must be 0. Use the admin.kubeconfig to extract the chosen port.")
 	fs.MarkHidden("experimental-bind-free-port") //nolint:errcheck
 
+	fs.StringVar(&o.Extra.WorkspaceAuditLogDir, "workspace-audit-log-dir", o.Extra.WorkspaceAuditLogDir, "Directory to write the audit logs of workspaces with an audit policy to, one JSON log file per logical cluster. If unset, the audit policies of workspaces and workspace types are ignored.")
+	fs.StringVar(&o.Extra.OrganizationAuditSinkDir, "organization-audit-sink-dir", o.Extra.OrganizationAuditSinkDir, "Directory below which the file AuditSinks of organizations are written, in a sub-directory per organization logical cluster. If unset, file AuditSinks are ignored.")
+	fs.BoolVar(&o.Extra.OrganizationAuditSinkWebhooks, "organization-audit-sink-webhooks", o.Extra.OrganizationAuditSinkWebhooks, "Send the audit events of organizations to their webhook AuditSinks. If false, webhook AuditSinks are ignored.")
+	fs.StringVar(&o.Extra.WorkspacePlacementStrategy, "workspace-placement-strategy", o.Extra.WorkspacePlacementStrategy, fmt.Sprintf("The strategy choosing the shard of new workspaces whose WorkspaceType does not set one. One of %v.", tenancyv1alpha1.WorkspacePlacementStrategies))
+	fs.Int64Var(&o.Extra.ShardMaxQPS, "shard-max-qps", o.Extra.ShardMaxQPS, "The number of requests per second this shard is sized for. If set, the QPS headroom of the shard is reported in the status of its Shard.")
+	fs.DurationVar(&o.Extra.ShardUsageReportInterval, "shard-usage-report-interval", o.Extra.ShardUsageReportInterval, "How often this shard reports its usage in the status of its Shard.")
+	fs.DurationVar(&o.Extra.LogicalClusterUsageSampleInterval, "logical-cluster-usage-sample-interval", o.Extra.LogicalClusterUsageSampleInterval, "How often the objects stored in etcd are counted and sized per logical cluster and resource, and exposed as metrics. Every sample reads all objects of the shard from etcd. 0 disables metering.")
+	fs.BoolVar(&o.Extra.LogicalClusterUsageReportStatus, "logical-cluster-usage-report-status", o.Extra.LogicalClusterUsageReportStatus, "Also report the sampled usage in the status of the LogicalCluster objects, e.g. for chargeback. Requires --logical-cluster-usage-sample-interval.")
+	fs.StringVar(&o.Extra.ShardBackupLocation, "shard-backup-location", o.Extra.ShardBackupLocation, "The object storage URL the etcd snapshots of ShardBackups of this shard are uploaded to, e.g. file:///var/lib/kcp/backups. Other schemes like s3:// need an object storage plugin compiled into kcp. If empty, ShardBackups of this shard fail.")
+	fs.StringSliceVar(&o.Extra.CustomResourceWatchCacheSizes, "custom-resource-watch-cache-sizes", o.Extra.CustomResourceWatchCacheSizes, "Watch cache size settings for custom and bound resources, like --watch-cache-sizes for built-in resources, as a list of resource[.group]#size, e.g. widgets.example.com#0. A size of 0 disables the watch cache of the resource, e.g. for high-churn resources whose cache takes too much memory. Other sizes are ignored as watch caches are sized dynamically.")
+	fs.DurationVar(&o.Extra.ShardLeaseDuration, "shard-lease-duration", o.Extra.ShardLeaseDuration, "The duration of the Lease this shard renews as its heartbeat in the root workspace. The shard is marked NotReady and requests are not routed to it when the Lease is not renewed within this duration. It is renewed every quarter of the duration.")
+	fs.Int64Var(&o.Extra.SchedulingMaxLogicalClusters, "workspace-scheduling-max-logical-clusters", o.Extra.SchedulingMaxLogicalClusters, "Do not schedule new workspaces onto shards with at least this number of logical clusters. 0 means no limit.")
+	fs.StringVar(&o.Extra.SchedulingMaxStorageSize, "workspace-scheduling-max-storage-size", o.Extra.SchedulingMaxStorageSize, "Do not schedule new workspaces onto shards whose storage database has at least this size, e.g. 6Gi. Empty means no limit.")
+	fs.StringSliceVar(&o.Extra.ShardLocalWorkspaces, "shard-local-workspaces", o.Extra.ShardLocalWorkspaces, "Names of system workspaces this shard creates for itself in the root workspace when it joins, scheduled onto this shard. The workspace for a name n is root:<n>-<shard-name>. Existing workspaces are left untouched.")
+	fs.Int64Var(&o.Extra.SchedulingMinQPSHeadroom, "workspace-scheduling-min-qps-headroom", o.Extra.SchedulingMinQPSHeadroom, "Do not schedule new workspaces onto shards with a QPS headroom below this value. 0 means no limit.")
+	fs.StringVar(&o.Extra.WorkspaceMembershipFeedType, "workspace-membership-feed-type", o.Extra.WorkspaceMembershipFeedType, fmt.Sprintf("The type of the --workspace-membership-feed. One of %v.", workspacerolebinding.GroupFeedTypes))
+	fs.StringVar(&o.Extra.WorkspaceMembershipFeed, "workspace-membership-feed", o.Extra.WorkspaceMembershipFeed, "The external groups bound by WorkspaceRoleBindings: a YAML file of groups and their members, a webhook URL returning the same, or the base URL of a SCIM 2.0 service provider. If unset, WorkspaceRoleBindings only bind their users.")
+	fs.StringVar(&o.Extra.WorkspaceMembershipFeedTokenFile, "workspace-membership-feed-token-file", o.Extra.WorkspaceMembershipFeedTokenFile, "File with a bearer token for requests to a webhook or SCIM --workspace-membership-feed.")
+	fs.DurationVar(&o.Extra.WorkspaceMembershipSyncInterval, "workspace-membership-sync-interval", o.Extra.WorkspaceMembershipSyncInterval, "How often the --workspace-membership-feed is read.")
+	fs.StringVar(&o.Extra.BootstrapPolicyFile, "bootstrap-policy-file", o.Extra.BootstrapPolicyFile, "File with clusterRoles and clusterRoleBindings extending the bootstrap RBAC policy of the shard. Objects named like a compiled-in one, e.g. system:kcp:tenancy:reader, replace it. It is applied on startup and re-applied every --bootstrap-policy-sync-interval.")
+	fs.DurationVar(&o.Extra.BootstrapPolicySyncInterval, "bootstrap-policy-sync-interval", o.Extra.BootstrapPolicySyncInterval, "How often the --bootstrap-policy-file is re-read and re-applied.")
+	fs.StringSliceVar(&o.Extra.WorkspaceAccessTokenKeyFiles, "workspace-access-token-key-files", o.Extra.WorkspaceAccessTokenKeyFiles, "Files with PEM-encoded public or private keys verifying workspace access tokens. If unset, workspace access tokens are not authenticated.")
+
 	fs.DurationVar(&o.Extra.ConversionCELTransformationTimeout, "conversion-cel-transformation-timeout", o.Extra.ConversionCELTransformationTimeout, "Maximum amount of time that CEL transformations may take per object conversion.")
 
 	fs.StringSliceVar(&o.Extra.BatteriesIncluded, "batteries-included", o.Extra.BatteriesIncluded, fmt.Sprintf(
@@ -243,6 +302,51 @@ func (o *CompletedOptions) Validate() []error {
 		errs = append(errs, fmt.Errorf("--shard-external-url is required if --logical-cluster-admin-kubeconfig is set"))
 	}
 
+	if !slices.Contains(tenancyv1alpha1.WorkspacePlacementStrategies, tenancyv1alpha1.WorkspacePlacementStrategy(o.Extra.WorkspacePlacementStrategy)) {
+		errs = append(errs, fmt.Errorf("--workspace-placement-strategy must be one of %v", tenancyv1alpha1.WorkspacePlacementStrategies))
+	}
+	if o.Extra.ShardUsageReportInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--shard-usage-report-interval must be positive"))
+	}
+	if _, err := genericapiserveroptions.ParseWatchCacheSizes(o.Extra.CustomResourceWatchCacheSizes); err != nil {
+		errs = append(errs, fmt.Errorf("--custom-resource-watch-cache-sizes: %w", err))
+	}
+	if o.Extra.LogicalClusterUsageSampleInterval < 0 {
+		errs = append(errs, fmt.Errorf("--logical-cluster-usage-sample-interval must not be negative"))
+	}
+	if o.Extra.LogicalClusterUsageReportStatus && o.Extra.LogicalClusterUsageSampleInterval == 0 {
+		errs = append(errs, fmt.Errorf("--logical-cluster-usage-report-status requires --logical-cluster-usage-sample-interval"))
+	}
+	if o.Extra.ShardLeaseDuration < 4*time.Second {
+		errs = append(errs, fmt.Errorf("--shard-lease-duration must be at least 4s"))
+	}
+	for _, name := range o.Extra.ShardLocalWorkspaces {
+		if msgs := validation.IsDNS1123Label(name + "-" + o.Extra.ShardName); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("--shard-local-workspaces contains invalid name %q: %s", name, strings.Join(msgs, ", ")))
+		}
+	}
+	if o.Extra.WorkspaceMembershipFeed != "" {
+		if _, err := workspacerolebinding.NewGroupFeed(o.Extra.WorkspaceMembershipFeedType, o.Extra.WorkspaceMembershipFeed, o.Extra.WorkspaceMembershipFeedTokenFile); err != nil {
+			errs = append(errs, fmt.Errorf("--workspace-membership-feed is invalid: %w", err))
+		}
+	}
+	if o.Extra.WorkspaceMembershipSyncInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--workspace-membership-sync-interval must be positive"))
+	}
+	if o.Extra.BootstrapPolicyFile != "" {
+		if _, err := bootstrappolicy.LoadPolicyCustomization(o.Extra.BootstrapPolicyFile); err != nil {
+			errs = append(errs, fmt.Errorf("--bootstrap-policy-file is invalid: %w", err))
+		}
+	}
+	if o.Extra.BootstrapPolicySyncInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--bootstrap-policy-sync-interval must be positive"))
+	}
+	if o.Extra.SchedulingMaxStorageSize != "" {
+		if _, err := resource.ParseQuantity(o.Extra.SchedulingMaxStorageSize); err != nil {
+			errs = append(errs, fmt.Errorf("--workspace-scheduling-max-storage-size is invalid: %w", err))
+		}
+	}
+
 	return errs
 }
 
@@ -288,6 +392,12 @@ func (o *Options) Complete(rootDir string) (*CompletedOptions, error) {
 			return nil, err
 		}
 	}
+	if len(o.Extra.ServiceAccountTokenValidatorKubeconfig) > 0 && !filepath.IsAbs(o.Extra.ServiceAccountTokenValidatorKubeconfig) {
+		o.Extra.ServiceAccountTokenValidatorKubeconfig, err = filepath.Abs(o.Extra.ServiceAccountTokenValidatorKubeconfig)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if o.Extra.ExperimentalBindFreePort {
 		listener, _, err := genericapiserveroptions.CreateListener("tcp", fmt.Sprintf("%s:0", o.GenericControlPlane.SecureServing.BindAddress), net.ListenConfig{})
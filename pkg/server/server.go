@@ -56,6 +56,8 @@ import (
 	metadataclient "github.com/kcp-dev/kcp/pkg/metadata"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/replication"
 	"github.com/kcp-dev/kcp/pkg/reconciler/kubequota"
+	reconcilermetrics "github.com/kcp-dev/kcp/pkg/reconciler/metrics"
+	reconcilertracing "github.com/kcp-dev/kcp/pkg/reconciler/tracing"
 	"github.com/kcp-dev/kcp/pkg/server/options/batteries"
 	virtualrootapiserver "github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
@@ -191,6 +193,9 @@ func (s *Server) uninstallControllers() {
 /* Registering all controllers and informers before starting informers. */
 func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.Config, gvrs map[schema.GroupVersionResource]replication.ReplicatedGVR) error {
 	logger := klog.FromContext(ctx).WithValues("component", "kcp")
+	reconcilermetrics.SetPerLogicalCluster(s.Options.Controllers.MetricsPerLogicalCluster)
+	reconcilertracing.SetTracerProvider(s.GenericConfig.TracerProvider)
+
 	if err := s.installKubeNamespaceController(ctx, controllerConfig); err != nil {
 		return err
 	}
@@ -234,6 +239,23 @@ func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.
 		if err := s.installWorkspaceMountsScheduler(ctx, controllerConfig); err != nil {
 			return err
 		}
+		if err := s.installShardDrainController(ctx); err != nil {
+			return err
+		}
+		if err := s.installShardLeaseController(ctx); err != nil {
+			return err
+		}
+		if err := s.installShardUsageController(ctx); err != nil {
+			return err
+		}
+		if err := s.installShardBackupController(ctx); err != nil {
+			return err
+		}
+		if s.Options.Extra.LogicalClusterUsageSampleInterval > 0 {
+			if err := s.installLogicalClusterUsageController(ctx, controllerConfig); err != nil {
+				return err
+			}
+		}
 		if err := s.installTenancyLogicalClusterController(ctx, controllerConfig); err != nil {
 			return err
 		}
@@ -255,6 +277,9 @@ func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.
 		if err := s.installExtraAnnotationSyncController(ctx, controllerConfig); err != nil {
 			return err
 		}
+		if err := s.installStorageMigrationController(ctx, controllerConfig); err != nil {
+			return err
+		}
 	}
 
 	if s.Options.Controllers.EnableAll || enabled.Has("apiexport") {
@@ -322,10 +347,19 @@ func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.
 		}
 	}
 
+	if s.Options.Controllers.EnableAll || enabled.Has("templater") {
+		if err := s.installTemplaterController(ctx, controllerConfig); err != nil {
+			return err
+		}
+	}
+
 	if s.Options.Controllers.EnableAll || enabled.Has("partition") {
 		if err := s.installPartitionSetController(ctx, controllerConfig); err != nil {
 			return err
 		}
+		if err := s.installPartitionHealthController(ctx, controllerConfig); err != nil {
+			return err
+		}
 	}
 
 	if s.Options.Controllers.EnableAll || enabled.Has("quota") {
@@ -334,19 +368,50 @@ func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.
 		}
 	}
 
+	if s.Options.Controllers.EnableAll || enabled.Has("workspacequota") {
+		if err := s.installWorkspaceQuotaController(ctx, controllerConfig); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("workspacerolebinding") {
+		if err := s.installWorkspaceRoleBindingController(ctx, controllerConfig); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("storageversionmigration") {
+		if err := s.installStorageVersionMigrationController(ctx, controllerConfig); err != nil {
+			return err
+		}
+	}
+
 	if s.Options.Controllers.EnableAll || enabled.Has("garbagecollector") {
 		if err := s.installGarbageCollectorController(ctx, controllerConfig); err != nil {
 			return err
 		}
 	}
 
+	if s.Options.Extra.BootstrapPolicyFile != "" && (s.Options.Controllers.EnableAll || enabled.Has("bootstrappolicy")) {
+		if err := s.installBootstrapPolicyController(ctx, controllerConfig); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 func (s *Server) Run(ctx context.Context) error {
 	logger := klog.FromContext(ctx).WithValues("component", "kcp")
 	ctx = klog.NewContext(ctx, logger)
 
-	if err := s.AddPostStartHook("kcp-bootstrap-policy", bootstrappolicy.Policy().EnsureRBACPolicy()); err != nil {
+	var policyCustomization *bootstrappolicy.PolicyCustomization
+	if s.Options.Extra.BootstrapPolicyFile != "" {
+		var err error
+		if policyCustomization, err = bootstrappolicy.LoadPolicyCustomization(s.Options.Extra.BootstrapPolicyFile); err != nil {
+			return err
+		}
+	}
+	if err := s.AddPostStartHook("kcp-bootstrap-policy", bootstrappolicy.CustomizedPolicy(policyCustomization).EnsureRBACPolicy()); err != nil {
 		return err
 	}
 
@@ -546,6 +611,27 @@ func (s *Server) Run(ctx context.Context) error {
 			close(s.rootPhase1FinishedCh)
 		}
 
+		if len(s.Options.Extra.ShardLocalWorkspaces) > 0 {
+			// the root workspace phase 1 (with the universal WorkspaceType) might not be
+			// bootstrapped yet if this is not the root shard, hence keep trying.
+			logger.Info("bootstrapping shard-local workspaces")
+			if err := wait.PollUntilContextCancel(hookCtx, time.Second, true, func(ctx context.Context) (bool, error) {
+				if err := configshard.BootstrapLocalWorkspaces(ctx,
+					s.RootShardKcpClusterClient.Cluster(core.RootCluster.Path()),
+					s.Options.Extra.ShardName,
+					s.Options.Extra.ShardLocalWorkspaces,
+				); err != nil {
+					logger.Error(err, "failed to bootstrap shard-local workspaces, retrying")
+					return false, nil // keep trying
+				}
+				return true, nil
+			}); err != nil {
+				logger.Error(err, "failed to bootstrap shard-local workspaces")
+				return nil // don't klog.Fatal. This only happens when context is cancelled.
+			}
+			logger.Info("finished bootstrapping shard-local workspaces")
+		}
+
 		return nil
 	}); err != nil {
 		return err
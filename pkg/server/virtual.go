@@ -19,6 +19,7 @@ package server
 import (
 	"net/http"
 
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,8 +56,9 @@ type CompletedVirtualConfig struct {
 func newVirtualConfig(
 	o kcpserveroptions.CompletedOptions,
 	config *rest.Config,
-	kubeSharedInformerFactory kcpkubernetesinformers.SharedInformerFactory,
+	kubeSharedInformerFactory, cacheKubeSharedInformerFactory kcpkubernetesinformers.SharedInformerFactory,
 	kcpSharedInformerFactory, cacheKcpSharedInformerFactory kcpinformers.SharedInformerFactory,
+	cacheDynamicClient kcpdynamic.ClusterInterface,
 	shardExternalURL func() string,
 ) (*VirtualConfig, error) {
 	scheme := runtime.NewScheme()
@@ -90,12 +92,18 @@ func newVirtualConfig(
 		virtualcommandoptions.DefaultRootPathPrefix,
 		shardExternalURL,
 		kubeSharedInformerFactory,
+		cacheKubeSharedInformerFactory,
 		kcpSharedInformerFactory,
 		cacheKcpSharedInformerFactory,
+		cacheDynamicClient,
 	)
 	if err != nil {
 		return nil, err
 	}
+	c.Extra.FlowControl, err = o.Virtual.VirtualWorkspaces.NewFlowControlConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	return (*VirtualConfig)(c), nil
 }
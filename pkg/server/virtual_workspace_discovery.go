@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	initializingworkspacesbuilder "github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces/builder"
+	"github.com/kcp-dev/kcp/pkg/virtual/replication"
+	terminatingworkspacesbuilder "github.com/kcp-dev/kcp/pkg/virtual/terminatingworkspaces/builder"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/termination"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// VirtualWorkspaceDiscoveryPath is the path of the virtual workspace URL discovery in a workspace,
+// e.g. /clusters/root:org/virtualworkspaces.
+const VirtualWorkspaceDiscoveryPath = "/virtualworkspaces"
+
+// VirtualWorkspaceDiscovery lists the virtual workspace URLs available to a user in a workspace.
+type VirtualWorkspaceDiscovery struct {
+	// Shards are the shards serving virtual workspaces, ordered by name.
+	Shards []ShardVirtualWorkspaces `json:"shards"`
+}
+
+// ShardVirtualWorkspaces lists the virtual workspace URLs of a shard.
+type ShardVirtualWorkspaces struct {
+	// Name is the name of the shard.
+	Name string `json:"name"`
+	// VirtualWorkspaces are the virtual workspaces of the shard available to the user.
+	VirtualWorkspaces []VirtualWorkspaceURL `json:"virtualWorkspaces"`
+}
+
+// VirtualWorkspaceURL is the URL of a virtual workspace.
+type VirtualWorkspaceURL struct {
+	// Type is the virtual workspace, e.g. apiexport or initializingworkspaces.
+	Type string `json:"type"`
+	// Name is the name of the APIExportEndpointSlice, or the initializer or terminator.
+	Name string `json:"name,omitempty"`
+	// URL is the URL of the virtual workspace.
+	URL string `json:"url"`
+}
+
+// WithVirtualWorkspaceDiscovery serves GET requests for VirtualWorkspaceDiscoveryPath in a workspace,
+// listing per shard
+//
+//   - the replication virtual workspace, for every user,
+//   - the initializingworkspaces and terminatingworkspaces virtual workspaces of the WorkspaceTypes of the
+//     workspace, if the user may initialize respectively terminate them,
+//   - the APIExport virtual workspaces of the APIExportEndpointSlices of the workspace, if the user may get them.
+//
+// Other requests are passed to the next handler.
+func WithVirtualWorkspaceDiscovery(apiHandler http.Handler, a authorizer.Authorizer, kcpInformers, cacheKcpInformers kcpinformers.SharedInformerFactory) http.Handler {
+	shardLister := cacheKcpInformers.Core().V1alpha1().Shards().Lister()
+	workspaceTypeLister := kcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Lister()
+	endpointSliceLister := kcpInformers.Apis().V1alpha1().APIExportEndpointSlices().Lister()
+
+	h := &virtualWorkspaceDiscoveryHandler{
+		delegate: apiHandler,
+		authz:    a,
+		listShards: func() ([]*corev1alpha1.Shard, error) {
+			return shardLister.List(labels.Everything())
+		},
+		listWorkspaceTypes: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceType, error) {
+			return workspaceTypeLister.Cluster(clusterName).List(labels.Everything())
+		},
+		listEndpointSlices: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportEndpointSlice, error) {
+			return endpointSliceLister.Cluster(clusterName).List(labels.Everything())
+		},
+	}
+	return h
+}
+
+type virtualWorkspaceDiscoveryHandler struct {
+	delegate http.Handler
+	authz    authorizer.Authorizer
+
+	listShards         func() ([]*corev1alpha1.Shard, error)
+	listWorkspaceTypes func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceType, error)
+	listEndpointSlices func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportEndpointSlice, error)
+}
+
+func (h *virtualWorkspaceDiscoveryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	cluster := request.ClusterFrom(req.Context())
+	if req.Method != http.MethodGet || req.URL.Path != VirtualWorkspaceDiscoveryPath || cluster == nil || cluster.Name.Empty() {
+		h.delegate.ServeHTTP(w, req)
+		return
+	}
+
+	discovery, err := h.discover(req.Context(), cluster.Name)
+	if err != nil {
+		responsewriters.InternalError(w, req, err)
+		return
+	}
+	responsewriters.WriteRawJSON(http.StatusOK, discovery, w)
+}
+
+func (h *virtualWorkspaceDiscoveryHandler) discover(ctx context.Context, clusterName logicalcluster.Name) (*VirtualWorkspaceDiscovery, error) {
+	shards, err := h.listShards()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Name < shards[j].Name })
+
+	// paths of the virtual workspaces served by every shard.
+	common := []VirtualWorkspaceURL{
+		{Type: replication.VirtualWorkspaceName, URL: "/services/" + replication.VirtualWorkspaceName + "/clusters/*"},
+	}
+	workspaceTypes, err := h.listWorkspaceTypes(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(workspaceTypes, func(i, j int) bool { return workspaceTypes[i].Name < workspaceTypes[j].Name })
+	for _, wt := range workspaceTypes {
+		if wt.Spec.Initializer {
+			if allowed, err := h.allowed(ctx, clusterName, "initialize", tenancyv1alpha1.SchemeGroupVersion.Group, "workspacetypes", wt.Name); err != nil {
+				return nil, err
+			} else if allowed {
+				initializer := initialization.InitializerForType(wt)
+				common = append(common, VirtualWorkspaceURL{Type: "initializingworkspaces", Name: string(initializer), URL: initializingworkspacesbuilder.URLFor(initializer)})
+			}
+		}
+		if wt.Spec.Terminator {
+			if allowed, err := h.allowed(ctx, clusterName, "terminate", tenancyv1alpha1.SchemeGroupVersion.Group, "workspacetypes", wt.Name); err != nil {
+				return nil, err
+			} else if allowed {
+				terminator := termination.TerminatorForType(wt)
+				common = append(common, VirtualWorkspaceURL{Type: "terminatingworkspaces", Name: string(terminator), URL: terminatingworkspacesbuilder.URLFor(terminator)})
+			}
+		}
+	}
+
+	slices, err := h.listEndpointSlices(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(slices, func(i, j int) bool { return slices[i].Name < slices[j].Name })
+	var exports []VirtualWorkspaceURL
+	for _, slice := range slices {
+		if allowed, err := h.allowed(ctx, clusterName, "get", apisv1alpha1.SchemeGroupVersion.Group, "apiexportendpointslices", slice.Name); err != nil {
+			return nil, err
+		} else if !allowed {
+			continue
+		}
+		for _, endpoint := range slice.Status.APIExportEndpoints {
+			exports = append(exports, VirtualWorkspaceURL{Type: "apiexport", Name: slice.Name, URL: endpoint.URL})
+		}
+	}
+
+	discovery := &VirtualWorkspaceDiscovery{Shards: []ShardVirtualWorkspaces{}}
+	for _, shard := range shards {
+		base := kcpclient.ShardVirtualWorkspaceURL(shard)
+		vws := make([]VirtualWorkspaceURL, 0, len(common))
+		for _, vw := range common {
+			vw.URL = base + vw.URL
+			vws = append(vws, vw)
+		}
+		for _, vw := range exports {
+			if strings.HasPrefix(vw.URL, base+"/") {
+				vws = append(vws, vw)
+			}
+		}
+		discovery.Shards = append(discovery.Shards, ShardVirtualWorkspaces{Name: shard.Name, VirtualWorkspaces: vws})
+	}
+
+	return discovery, nil
+}
+
+func (h *virtualWorkspaceDiscoveryHandler) allowed(ctx context.Context, clusterName logicalcluster.Name, verb, group, resource, name string) (bool, error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return false, nil
+	}
+	dec, _, err := h.authz.Authorize(request.WithCluster(ctx, request.Cluster{Name: clusterName}), authorizer.AttributesRecord{
+		User:            user,
+		Verb:            verb,
+		APIGroup:        group,
+		Resource:        resource,
+		Name:            name,
+		ResourceRequest: true,
+	})
+	if err != nil {
+		return false, err
+	}
+	return dec == authorizer.DecisionAllow, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestVirtualWorkspaceDiscovery(t *testing.T) {
+	workspaceType := func(name string, initializer, terminator bool) *tenancyv1alpha1.WorkspaceType {
+		return &tenancyv1alpha1.WorkspaceType{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{logicalcluster.AnnotationKey: "org"}},
+			Spec:       tenancyv1alpha1.WorkspaceTypeSpec{Initializer: initializer, Terminator: terminator},
+		}
+	}
+	endpointSlice := func(name string, urls ...string) *apisv1alpha1.APIExportEndpointSlice {
+		s := &apisv1alpha1.APIExportEndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, u := range urls {
+			s.Status.APIExportEndpoints = append(s.Status.APIExportEndpoints, apisv1alpha1.APIExportEndpoint{URL: u})
+		}
+		return s
+	}
+
+	h := &virtualWorkspaceDiscoveryHandler{
+		delegate: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		authz: authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			if attr.GetName() == "hidden" {
+				return authorizer.DecisionNoOpinion, "", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		}),
+		listShards: func() ([]*corev1alpha1.Shard, error) {
+			return []*corev1alpha1.Shard{
+				{ObjectMeta: metav1.ObjectMeta{Name: "beta"}, Spec: corev1alpha1.ShardSpec{BaseURL: "https://beta:6443"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "alpha"}, Spec: corev1alpha1.ShardSpec{BaseURL: "https://alpha:6443", VirtualWorkspaceURL: "https://alpha-vw:6444/"}},
+			}, nil
+		},
+		listWorkspaceTypes: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceType, error) {
+			return []*tenancyv1alpha1.WorkspaceType{
+				workspaceType("plain", false, false),
+				workspaceType("team", true, true),
+				workspaceType("hidden", true, false),
+			}, nil
+		},
+		listEndpointSlices: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportEndpointSlice, error) {
+			return []*apisv1alpha1.APIExportEndpointSlice{
+				endpointSlice("widgets", "https://alpha-vw:6444/services/apiexport/org/widgets", "https://beta:6443/services/apiexport/org/widgets"),
+				endpointSlice("hidden", "https://alpha-vw:6444/services/apiexport/org/hidden"),
+			}, nil
+		},
+	}
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		ctx := request.WithCluster(req.Context(), request.Cluster{Name: "org"})
+		ctx = request.WithUser(ctx, &user.DefaultInfo{Name: "user"})
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req.WithContext(ctx))
+		return rw
+	}
+
+	require.Equal(t, http.StatusTeapot, serve(http.MethodGet, "/api").Code)
+	require.Equal(t, http.StatusTeapot, serve(http.MethodPost, VirtualWorkspaceDiscoveryPath).Code)
+
+	rw := serve(http.MethodGet, VirtualWorkspaceDiscoveryPath)
+	require.Equal(t, http.StatusOK, rw.Code)
+	var discovery VirtualWorkspaceDiscovery
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &discovery))
+	require.Equal(t, VirtualWorkspaceDiscovery{Shards: []ShardVirtualWorkspaces{
+		{Name: "alpha", VirtualWorkspaces: []VirtualWorkspaceURL{
+			{Type: "replication", URL: "https://alpha-vw:6444/services/replication/clusters/*"},
+			{Type: "initializingworkspaces", Name: "org:team", URL: "https://alpha-vw:6444/services/initializingworkspaces/org:team"},
+			{Type: "terminatingworkspaces", Name: "org:team", URL: "https://alpha-vw:6444/services/terminatingworkspaces/org:team"},
+			{Type: "apiexport", Name: "widgets", URL: "https://alpha-vw:6444/services/apiexport/org/widgets"},
+		}},
+		{Name: "beta", VirtualWorkspaces: []VirtualWorkspaceURL{
+			{Type: "replication", URL: "https://beta:6443/services/replication/clusters/*"},
+			{Type: "initializingworkspaces", Name: "org:team", URL: "https://beta:6443/services/initializingworkspaces/org:team"},
+			{Type: "terminatingworkspaces", Name: "org:team", URL: "https://beta:6443/services/terminatingworkspaces/org:team"},
+			{Type: "apiexport", Name: "widgets", URL: "https://beta:6443/services/apiexport/org/widgets"},
+		}},
+	}}, discovery)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ObjectStorage stores the etcd snapshots of a shard.
+type ObjectStorage interface {
+	// Upload stores the data read from r as an object with the given slash-separated name, and
+	// returns the location of the object, e.g. s3://bucket/prefix/shard/name.db.
+	Upload(ctx context.Context, name string, r io.Reader) (string, error)
+}
+
+// Factory creates an ObjectStorage for a location with the URL scheme it is registered for.
+type Factory func(location *url.URL) (ObjectStorage, error)
+
+var (
+	lock      sync.RWMutex
+	factories = map[string]Factory{
+		"file": newFileStorage,
+	}
+)
+
+// Register makes an object storage available for locations with the given URL scheme, e.g.
+// "s3" or "gs". Object storage plugins call it from an init function of a package imported
+// into the kcp binary. Registering a scheme twice panics.
+func Register(scheme string, factory Factory) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, found := factories[scheme]; found {
+		panic(fmt.Sprintf("object storage for scheme %q is already registered", scheme))
+	}
+	factories[scheme] = factory
+}
+
+// NewObjectStorage returns the object storage for the given location URL, registered for the
+// scheme of the URL.
+func NewObjectStorage(location string) (ObjectStorage, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup location %q: %w", location, err)
+	}
+
+	lock.RLock()
+	factory, found := factories[u.Scheme]
+	lock.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("no object storage registered for scheme %q of backup location %q, registered are: %s", u.Scheme, location, strings.Join(schemes(), ", "))
+	}
+	return factory(u)
+}
+
+func schemes() []string {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	ret := make([]string, 0, len(factories))
+	for scheme := range factories {
+		ret = append(ret, scheme)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// fileStorage writes objects into a local directory, e.g. a mounted volume.
+type fileStorage struct {
+	dir string
+}
+
+func newFileStorage(location *url.URL) (ObjectStorage, error) {
+	if location.Host != "" || location.Path == "" {
+		return nil, fmt.Errorf("file backup location %q must be of the form file:///absolute/path", location)
+	}
+	return &fileStorage{dir: filepath.Clean(location.Path)}, nil
+}
+
+func (s *fileStorage) Upload(ctx context.Context, name string, r io.Reader) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object name %q", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+
+	// write to a temporary file first such that no partial snapshot is ever visible under the name
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close() //nolint:errcheck
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close() //nolint:errcheck
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String(), nil
+}
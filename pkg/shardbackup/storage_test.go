@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardbackup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewObjectStorage("file://" + dir)
+	require.NoError(t, err)
+
+	location, err := storage.Upload(context.Background(), "shard-1/backup.db", strings.NewReader("snapshot"))
+	require.NoError(t, err)
+	require.Equal(t, "file://"+filepath.Join(dir, "shard-1", "backup.db"), location)
+
+	data, err := os.ReadFile(filepath.Join(dir, "shard-1", "backup.db"))
+	require.NoError(t, err)
+	require.Equal(t, "snapshot", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(dir, "shard-1"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary files are left behind")
+
+	_, err = storage.Upload(context.Background(), "../escape.db", strings.NewReader("snapshot"))
+	require.Error(t, err)
+}
+
+func TestNewObjectStorage(t *testing.T) {
+	_, err := NewObjectStorage("s3://bucket/prefix")
+	require.ErrorContains(t, err, `no object storage registered for scheme "s3"`)
+
+	_, err = NewObjectStorage("file://relative/path")
+	require.Error(t, err)
+
+	require.Panics(t, func() { Register("file", newFileStorage) })
+}
@@ -25,12 +25,18 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
+// WildcardDeleteCollectionVerb is the verb required on the `apiexports/content` subresource
+// to issue DELETECOLLECTION requests against the wildcard cluster. It is not implied by
+// the "deletecollection" verb in order to keep cross-cluster deletion an explicit grant.
+const WildcardDeleteCollectionVerb = "wildcard-deletecollection"
+
 type apiExportsContentAuthorizer struct {
 	newDelegatedAuthorizer func(clusterName string) (authorizer.Authorizer, error)
 	delegate               authorizer.Authorizer
@@ -38,6 +44,7 @@ type apiExportsContentAuthorizer struct {
 
 // NewAPIExportsContentAuthorizer creates a new authorizer that checks
 // if the user has access to the `apiexports/content` subresource using the same verb as the requested resource.
+// Cross-cluster DELETECOLLECTION requests require the WildcardDeleteCollectionVerb instead.
 // The given kube cluster client is used to execute a SAR request against the cluster of the current in-flight API export.
 // If the SAR decision allows access, the given delegate authorizer is executed to proceed the authorizer chain,
 // else access is denied.
@@ -64,11 +71,16 @@ func (a *apiExportsContentAuthorizer) Authorize(ctx context.Context, attr author
 			fmt.Errorf("error creating delegated authorizer for API export %q, workspace %q: %w", apiExportName, apiExportCluster, err)
 	}
 
+	verb := attr.GetVerb()
+	if cluster := genericapirequest.ClusterFrom(ctx); verb == "deletecollection" && cluster != nil && cluster.Wildcard {
+		verb = WildcardDeleteCollectionVerb
+	}
+
 	SARAttributes := authorizer.AttributesRecord{
 		APIGroup:        apisv1alpha1.SchemeGroupVersion.Group,
 		APIVersion:      apisv1alpha1.SchemeGroupVersion.Version,
 		User:            attr.GetUser(),
-		Verb:            attr.GetVerb(),
+		Verb:            verb,
 		Name:            apiExportName,
 		Resource:        "apiexports",
 		ResourceRequest: true,
@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
 )
@@ -70,3 +71,55 @@ func TestContentAuthorizer(t *testing.T) {
 		})
 	}
 }
+
+func TestContentAuthorizerVerb(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		cluster      genericapirequest.Cluster
+		verb         string
+		expectedVerb string
+	}{
+		{
+			name:         "deletecollection in a concrete cluster",
+			cluster:      genericapirequest.Cluster{Name: "root:foo"},
+			verb:         "deletecollection",
+			expectedVerb: "deletecollection",
+		},
+		{
+			name:         "list in the wildcard cluster",
+			cluster:      genericapirequest.Cluster{Wildcard: true},
+			verb:         "list",
+			expectedVerb: "list",
+		},
+		{
+			name:         "deletecollection in the wildcard cluster",
+			cluster:      genericapirequest.Cluster{Wildcard: true},
+			verb:         "deletecollection",
+			expectedVerb: WildcardDeleteCollectionVerb,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotVerb string
+			auth := &apiExportsContentAuthorizer{
+				newDelegatedAuthorizer: func(clusterName string) (authorizer.Authorizer, error) {
+					return authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+						gotVerb = a.GetVerb()
+						return authorizer.DecisionAllow, "", nil
+					}), nil
+				},
+				delegate: authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+					return authorizer.DecisionAllow, "", nil
+				}),
+			}
+
+			ctx := dynamiccontext.WithAPIDomainKey(context.Background(), dynamiccontext.APIDomainKey("foo/bar"))
+			ctx = genericapirequest.WithCluster(ctx, tc.cluster)
+			_, _, err := auth.Authorize(ctx, &authorizer.AttributesRecord{
+				User: &user.DefaultInfo{},
+				Verb: tc.verb,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVerb, gotVerb)
+		})
+	}
+}
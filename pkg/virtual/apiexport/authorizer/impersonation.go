@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// ImpersonateVerb is the verb required on the `apiexports/content` subresource to impersonate
+// users of consumer workspaces through the APIExport virtual workspace.
+const ImpersonateVerb = "impersonate"
+
+type impersonatorKeyType int
+
+const impersonatorKey impersonatorKeyType = iota
+
+type impersonator struct {
+	user user.Info
+}
+
+// WithImpersonator returns a context in which the impersonation authorizer records the
+// user impersonating the user of the request.
+func WithImpersonator(parent context.Context) context.Context {
+	return context.WithValue(parent, impersonatorKey, &impersonator{})
+}
+
+// ImpersonatorFrom returns the user impersonating the user of the request, if any.
+func ImpersonatorFrom(ctx context.Context) (user.Info, bool) {
+	i, ok := ctx.Value(impersonatorKey).(*impersonator)
+	if !ok || i.user == nil {
+		return nil, false
+	}
+	return i.user, true
+}
+
+type impersonationAuthorizer struct {
+	newDelegatedAuthorizer func(clusterName string) (authorizer.Authorizer, error)
+	// bindsExport returns true if the consumer cluster has an APIBinding to the given APIExport.
+	bindsExport func(ctx context.Context, consumer logicalcluster.Name, exportCluster, exportName string) (bool, error)
+	delegate    authorizer.Authorizer
+}
+
+// NewImpersonationAuthorizer creates a new authorizer for impersonation requests of providers.
+// A provider may impersonate users, groups and service accounts of a concrete consumer workspace
+// if the workspace binds the APIExport of the request, and the provider has the ImpersonateVerb
+// on the `apiexports/content` subresource. System users and groups cannot be impersonated, except
+// for service accounts.
+//
+// Once impersonating, the remaining authorizer chain keeps authorizing the provider, while the
+// requests are forwarded to the consumer workspace as the impersonated user.
+func NewImpersonationAuthorizer(
+	delegate authorizer.Authorizer,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	bindsExport func(ctx context.Context, consumer logicalcluster.Name, exportCluster, exportName string) (bool, error),
+) authorizer.Authorizer {
+	return &impersonationAuthorizer{
+		newDelegatedAuthorizer: func(clusterName string) (authorizer.Authorizer, error) {
+			return delegated.NewDelegatedAuthorizer(logicalcluster.Name(clusterName), kubeClusterClient, delegated.Options{})
+		},
+		bindsExport: bindsExport,
+		delegate:    delegate,
+	}
+}
+
+func (a *impersonationAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	if attr.GetVerb() != ImpersonateVerb {
+		if provider, ok := ImpersonatorFrom(ctx); ok {
+			attr = &attributesWithUser{Attributes: attr, user: provider}
+		}
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	i, ok := ctx.Value(impersonatorKey).(*impersonator)
+	if !ok {
+		return authorizer.DecisionNoOpinion, "impersonation is not supported", nil
+	}
+	cluster := genericapirequest.ClusterFrom(ctx)
+	if cluster == nil || cluster.Wildcard || cluster.Name.Empty() {
+		return authorizer.DecisionDeny, "impersonation requires a consumer workspace", nil
+	}
+	if reason := forbiddenImpersonation(attr); reason != "" {
+		return authorizer.DecisionDeny, reason, nil
+	}
+
+	parts := strings.Split(string(dynamiccontext.APIDomainKeyFrom(ctx)), "/")
+	if len(parts) < 2 {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("invalid API domain key")
+	}
+	apiExportCluster, apiExportName := parts[0], parts[1]
+
+	binds, err := a.bindsExport(ctx, cluster.Name, apiExportCluster, apiExportName)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	if !binds {
+		return authorizer.DecisionDeny, fmt.Sprintf("workspace %q does not bind API export %q, workspace %q", cluster.Name, apiExportName, apiExportCluster), nil
+	}
+
+	authz, err := a.newDelegatedAuthorizer(apiExportCluster)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "",
+			fmt.Errorf("error creating delegated authorizer for API export %q, workspace %q: %w", apiExportName, apiExportCluster, err)
+	}
+	dec, reason, err := authz.Authorize(ctx, authorizer.AttributesRecord{
+		APIGroup:        apisv1alpha1.SchemeGroupVersion.Group,
+		APIVersion:      apisv1alpha1.SchemeGroupVersion.Version,
+		User:            attr.GetUser(),
+		Verb:            ImpersonateVerb,
+		Name:            apiExportName,
+		Resource:        "apiexports",
+		ResourceRequest: true,
+		Subresource:     "content",
+	})
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "",
+			fmt.Errorf("error authorizing RBAC in API export %q, workspace %q: %w", apiExportName, apiExportCluster, err)
+	}
+	if dec != authorizer.DecisionAllow {
+		return dec, fmt.Sprintf("API export: %q, workspace: %q RBAC decision: %v", apiExportName, apiExportCluster, reason), nil
+	}
+
+	i.user = attr.GetUser()
+	return authorizer.DecisionAllow, "", nil
+}
+
+// forbiddenImpersonation returns why the impersonation is never allowed, or an empty string.
+func forbiddenImpersonation(attr authorizer.Attributes) string {
+	switch attr.GetResource() {
+	case "users":
+		if name := attr.GetName(); strings.HasPrefix(name, "system:") && !strings.HasPrefix(name, serviceaccount.ServiceAccountUsernamePrefix) {
+			return fmt.Sprintf("system user %q cannot be impersonated", name)
+		}
+	case "groups":
+		if name := attr.GetName(); strings.HasPrefix(name, "system:") && name != user.AllAuthenticated && !strings.HasPrefix(name, serviceaccount.ServiceAccountGroupPrefix) {
+			return fmt.Sprintf("system group %q cannot be impersonated", name)
+		}
+	case "serviceaccounts", "uids":
+	default:
+		return fmt.Sprintf("impersonating %s is not supported", attr.GetResource())
+	}
+	return ""
+}
+
+type attributesWithUser struct {
+	authorizer.Attributes
+	user user.Info
+}
+
+func (a *attributesWithUser) GetUser() user.Info {
+	return a.user
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+func TestImpersonationAuthorizer(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		cluster          genericapirequest.Cluster
+		resource         string
+		impersonated     string
+		binds            bool
+		contentDecision  authorizer.Decision
+		expectedDecision authorizer.Decision
+	}{
+		{
+			name:             "user in a binding workspace",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "users",
+			impersonated:     "alice",
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:             "service account in a binding workspace",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "users",
+			impersonated:     "system:serviceaccount:default:bob",
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:             "authenticated group in a binding workspace",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "groups",
+			impersonated:     user.AllAuthenticated,
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:             "without impersonate permission on the export",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "users",
+			impersonated:     "alice",
+			binds:            true,
+			contentDecision:  authorizer.DecisionNoOpinion,
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:             "workspace not binding the export",
+			cluster:          genericapirequest.Cluster{Name: "root:other"},
+			resource:         "users",
+			impersonated:     "alice",
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionDeny,
+		},
+		{
+			name:             "wildcard cluster",
+			cluster:          genericapirequest.Cluster{Wildcard: true},
+			resource:         "users",
+			impersonated:     "alice",
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionDeny,
+		},
+		{
+			name:             "system user",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "users",
+			impersonated:     "system:admin",
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionDeny,
+		},
+		{
+			name:             "system group",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "groups",
+			impersonated:     "system:masters",
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionDeny,
+		},
+		{
+			name:             "user extras",
+			cluster:          genericapirequest.Cluster{Name: "root:consumer"},
+			resource:         "userextras",
+			impersonated:     "value",
+			binds:            true,
+			contentDecision:  authorizer.DecisionAllow,
+			expectedDecision: authorizer.DecisionDeny,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := &impersonationAuthorizer{
+				newDelegatedAuthorizer: func(clusterName string) (authorizer.Authorizer, error) {
+					require.Equal(t, "foo", clusterName)
+					return authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+						require.Equal(t, ImpersonateVerb, a.GetVerb())
+						require.Equal(t, "content", a.GetSubresource())
+						return tc.contentDecision, "", nil
+					}), nil
+				},
+				bindsExport: func(ctx context.Context, consumer logicalcluster.Name, exportCluster, exportName string) (bool, error) {
+					return tc.binds && consumer == tc.cluster.Name && exportCluster == "foo" && exportName == "bar", nil
+				},
+				delegate: authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+					return authorizer.DecisionNoOpinion, "", nil
+				}),
+			}
+
+			ctx := dynamiccontext.WithAPIDomainKey(context.Background(), dynamiccontext.APIDomainKey("foo/bar"))
+			ctx = genericapirequest.WithCluster(ctx, tc.cluster)
+			ctx = WithImpersonator(ctx)
+			provider := &user.DefaultInfo{Name: "provider"}
+			dec, _, err := auth.Authorize(ctx, &authorizer.AttributesRecord{
+				User:            provider,
+				Verb:            "impersonate",
+				Resource:        tc.resource,
+				Name:            tc.impersonated,
+				ResourceRequest: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDecision, dec)
+
+			impersonator, ok := ImpersonatorFrom(ctx)
+			require.Equal(t, tc.expectedDecision == authorizer.DecisionAllow, ok)
+			if ok {
+				require.Equal(t, provider, impersonator)
+			}
+		})
+	}
+}
+
+func TestImpersonationAuthorizerDelegatesAsProvider(t *testing.T) {
+	var gotUser string
+	auth := &impersonationAuthorizer{
+		delegate: authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+			gotUser = a.GetUser().GetName()
+			return authorizer.DecisionAllow, "", nil
+		}),
+	}
+
+	ctx := WithImpersonator(context.Background())
+	attr := &authorizer.AttributesRecord{User: &user.DefaultInfo{Name: "alice"}, Verb: "get"}
+
+	_, _, err := auth.Authorize(ctx, attr)
+	require.NoError(t, err)
+	require.Equal(t, "alice", gotUser, "without impersonation the request user is authorized")
+
+	ctx.Value(impersonatorKey).(*impersonator).user = &user.DefaultInfo{Name: "provider"}
+	_, _, err = auth.Authorize(ctx, attr)
+	require.NoError(t, err)
+	require.Equal(t, "provider", gotUser, "the impersonating provider is authorized")
+}
@@ -26,8 +26,11 @@ import (
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	genericapiserver "k8s.io/apiserver/pkg/server"
@@ -45,6 +48,7 @@ import (
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apidefinition"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwarding"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
@@ -54,6 +58,10 @@ import (
 
 const VirtualWorkspaceName string = "apiexport"
 
+// ImpersonatorExtraKey is the user extra key recording the provider that impersonated a consumer
+// user through the APIExport virtual workspace.
+const ImpersonatorExtraKey = "apis.kcp.io/impersonator"
+
 func BuildVirtualWorkspace(
 	rootPathPrefix string,
 	cfg *rest.Config,
@@ -76,6 +84,7 @@ func BuildVirtualWorkspace(
 
 			completedContext = genericapirequest.WithCluster(ctx, cluster)
 			completedContext = dynamiccontext.WithAPIDomainKey(completedContext, apiDomain)
+			completedContext = virtualapiexportauth.WithImpersonator(completedContext)
 			return true, prefixToStrip, completedContext
 		}),
 
@@ -113,6 +122,14 @@ func BuildVirtualWorkspace(
 						serviceaccount.ClusterNameKey: {cluster.Name.Path().String()},
 					},
 				}
+				if provider, ok := virtualapiexportauth.ImpersonatorFrom(ctx); ok {
+					// the provider acts on behalf of a consumer user, authorized in the consumer workspace.
+					consumer, ok := genericapirequest.UserFrom(ctx)
+					if !ok {
+						return nil, fmt.Errorf("no impersonated user found in context")
+					}
+					impersonationConfig.Impersonate = consumerImpersonationConfig(cluster.Name, consumer, provider)
+				}
 				impersonatedClient, err := kcpdynamic.NewForConfig(impersonationConfig)
 				if err != nil {
 					return nil, fmt.Errorf("error generating dynamic client: %w", err)
@@ -120,6 +137,21 @@ func BuildVirtualWorkspace(
 				return impersonatedClient, nil
 			}
 
+			wildcardDeleteCollectionEnabled := func(ctx context.Context) (bool, error) {
+				parts := strings.Split(string(dynamiccontext.APIDomainKeyFrom(ctx)), "/")
+				if len(parts) < 2 {
+					return false, fmt.Errorf("invalid API domain key")
+				}
+				apiExport, err := cachedKcpInformers.Apis().V1alpha1().APIExports().Cluster(logicalcluster.Name(parts[0])).Lister().Get(parts[1])
+				if apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				if err != nil {
+					return false, err
+				}
+				return apiExport.Annotations[apisv1alpha1.ExperimentalWildcardDeleteCollectionAnnotationKey] == "true", nil
+			}
+
 			apiReconciler, err := apireconciler.NewAPIReconciler(
 				kcpClusterClient,
 				cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas(),
@@ -127,15 +159,15 @@ func BuildVirtualWorkspace(
 				func(apiResourceSchema *apisv1alpha1.APIResourceSchema, version string, identityHash string, optionalLabelRequirements labels.Requirements) (apidefinition.APIDefinition, error) {
 					ctx, cancelFn := context.WithCancel(context.Background())
 
-					var wrapper forwardingregistry.StorageWrapper
-					if len(optionalLabelRequirements) > 0 {
-						wrapper = forwardingregistry.WithLabelSelector(func(_ context.Context) labels.Requirements {
-							return optionalLabelRequirements
-						})
+					storage := &forwarding.Storage{
+						ClientFunc:   impersonatedDynamicClientGetter,
+						IdentityHash: identityHash,
+						Verbs:        forwarding.ReadWrite,
+						Status:       true,
+						Selector:     optionalLabelRequirements,
+						Wrappers:     forwardingregistry.StorageWrappers{forwardingregistry.WithWildcardDeleteCollection(wildcardDeleteCollectionEnabled)},
 					}
-
-					storageBuilder := provideDelegatingRestStorage(ctx, impersonatedDynamicClientGetter, identityHash, wrapper)
-					def, err := apiserver.CreateServingInfoFor(mainConfig, apiResourceSchema, version, storageBuilder)
+					def, err := apiserver.CreateServingInfoFor(mainConfig, apiResourceSchema, version, storage.RestProvider(ctx))
 					if err != nil {
 						cancelFn()
 						return nil, err
@@ -184,7 +216,7 @@ func BuildVirtualWorkspace(
 
 			return apiReconciler, nil
 		},
-		Authorizer: newAuthorizer(kubeClusterClient, deepSARClient, cachedKcpInformers),
+		Authorizer: newAuthorizer(kubeClusterClient, deepSARClient, kcpClusterClient, cachedKcpInformers),
 	}
 
 	return []rootapiserver.NamedVirtualWorkspace{
@@ -257,14 +289,47 @@ func digestUrl(urlPath, rootPathPrefix string) (
 	return cluster, dynamiccontext.APIDomainKey(key), strings.TrimSuffix(urlPath, realPath), true
 }
 
-func newAuthorizer(kubeClusterClient, deepSARClient kcpkubernetesclientset.ClusterInterface, cachedKcpInformers kcpinformers.SharedInformerFactory) authorizer.Authorizer {
+func newAuthorizer(kubeClusterClient, deepSARClient kcpkubernetesclientset.ClusterInterface, kcpClusterClient kcpclientset.ClusterInterface, cachedKcpInformers kcpinformers.SharedInformerFactory) authorizer.Authorizer {
 	maximalPermissionAuth := virtualapiexportauth.NewMaximalPermissionAuthorizer(deepSARClient, cachedKcpInformers.Apis().V1alpha1().APIExports())
 	maximalPermissionAuth = authorization.NewDecorator("virtual.apiexport.maxpermissionpolicy.authorization.kcp.io", maximalPermissionAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
 	apiExportsContentAuth := virtualapiexportauth.NewAPIExportsContentAuthorizer(maximalPermissionAuth, kubeClusterClient)
 	apiExportsContentAuth = authorization.NewDecorator("virtual.apiexport.content.authorization.kcp.io", apiExportsContentAuth).AddAuditLogging().AddAnonymization()
 
-	return apiExportsContentAuth
+	impersonationAuth := virtualapiexportauth.NewImpersonationAuthorizer(apiExportsContentAuth, kubeClusterClient, func(ctx context.Context, consumer logicalcluster.Name, exportCluster, exportName string) (bool, error) {
+		bindings, err := kcpClusterClient.Cluster(consumer.Path()).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, binding := range bindings.Items {
+			if binding.Spec.Reference.Export != nil && binding.Spec.Reference.Export.Name == exportName && binding.Status.APIExportClusterName == exportCluster {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	impersonationAuth = authorization.NewDecorator("virtual.apiexport.impersonation.authorization.kcp.io", impersonationAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
+
+	return impersonationAuth
+}
+
+// consumerImpersonationConfig impersonates the given consumer user in the consumer cluster,
+// recording the impersonating provider in the user extras.
+func consumerImpersonationConfig(clusterName logicalcluster.Name, consumer, provider user.Info) rest.ImpersonationConfig {
+	extra := map[string][]string{}
+	for k, v := range consumer.GetExtra() {
+		extra[k] = v
+	}
+	extra[ImpersonatorExtraKey] = []string{provider.GetName()}
+	if _, _, err := serviceaccount.SplitUsername(consumer.GetName()); err == nil {
+		extra[serviceaccount.ClusterNameKey] = []string{clusterName.Path().String()}
+	}
+	return rest.ImpersonationConfig{
+		UserName: consumer.GetName(),
+		UID:      consumer.GetUID(),
+		Groups:   consumer.GetGroups(),
+		Extra:    extra,
+	}
 }
 
 // apiDefinitionWithCancel calls the cancelFn on tear-down.
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	virtualcontext "github.com/kcp-dev/kcp/pkg/virtual/framework/context"
+)
+
+// DefaultQueueTimeout is the time a request waits for a free seat of its priority level
+// if the level does not configure a queue timeout.
+const DefaultQueueTimeout = 5 * time.Second
+
+// Config configures the priority and fairness of requests to virtual workspaces, separately
+// from the main server. Requests are classified into priority levels by the first matching
+// flow schema. Every priority level serves a number of requests concurrently proportional to
+// its concurrency shares. Requests matching no flow schema are not limited.
+type Config struct {
+	// TotalConcurrency is the number of requests served concurrently by all priority levels.
+	// If zero, the max in-flight limits of the virtual workspace server are used.
+	TotalConcurrency int `json:"totalConcurrency,omitempty"`
+	// PriorityLevels are the priority levels requests are classified into.
+	PriorityLevels []PriorityLevel `json:"priorityLevels"`
+	// FlowSchemas classify requests into priority levels. The first matching flow schema applies.
+	FlowSchemas []FlowSchema `json:"flowSchemas"`
+}
+
+// PriorityLevel is a set of concurrently served requests.
+type PriorityLevel struct {
+	Name string `json:"name"`
+	// Exempt priority levels are never limited, e.g. for system controllers.
+	Exempt bool `json:"exempt,omitempty"`
+	// ConcurrencyShares is the share of the total concurrency of this priority level.
+	ConcurrencyShares int `json:"concurrencyShares,omitempty"`
+	// MaxConcurrencyPerUser limits the requests served concurrently for every user, such that
+	// a single user cannot occupy the whole priority level. Zero means no per-user limit.
+	MaxConcurrencyPerUser int `json:"maxConcurrencyPerUser,omitempty"`
+	// QueueTimeout is the time a request waits for a free seat before it is rejected with
+	// 429 Too Many Requests. Defaults to DefaultQueueTimeout.
+	QueueTimeout *metav1.Duration `json:"queueTimeout,omitempty"`
+}
+
+// FlowSchema matches requests to a priority level. Empty lists match everything.
+type FlowSchema struct {
+	Name          string `json:"name"`
+	PriorityLevel string `json:"priorityLevel"`
+	// VirtualWorkspaces are the names of the virtual workspaces, e.g. "apiexport".
+	VirtualWorkspaces []string `json:"virtualWorkspaces,omitempty"`
+	// Users and Groups match the requesting user. A request matches if either matches.
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// LoadConfig reads the priority and fairness config from the given file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flow control config %q: %w", path, err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal flow control config %q: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid flow control config %q: %w", path, err)
+	}
+	return &config, nil
+}
+
+func (c *Config) validate() error {
+	if c.TotalConcurrency < 0 {
+		return fmt.Errorf("totalConcurrency must not be negative")
+	}
+	levels := sets.New[string]()
+	for _, level := range c.PriorityLevels {
+		if level.Name == "" || levels.Has(level.Name) {
+			return fmt.Errorf("priority level names must be unique and not empty, got %q", level.Name)
+		}
+		levels.Insert(level.Name)
+		if level.Exempt {
+			continue
+		}
+		if level.ConcurrencyShares < 1 {
+			return fmt.Errorf("concurrencyShares of priority level %q must be at least 1", level.Name)
+		}
+		if level.MaxConcurrencyPerUser < 0 {
+			return fmt.Errorf("maxConcurrencyPerUser of priority level %q must not be negative", level.Name)
+		}
+		if level.QueueTimeout != nil && level.QueueTimeout.Duration < 0 {
+			return fmt.Errorf("queueTimeout of priority level %q must not be negative", level.Name)
+		}
+	}
+	schemas := sets.New[string]()
+	for _, fs := range c.FlowSchemas {
+		if fs.Name == "" || schemas.Has(fs.Name) {
+			return fmt.Errorf("flow schema names must be unique and not empty, got %q", fs.Name)
+		}
+		schemas.Insert(fs.Name)
+		if !levels.Has(fs.PriorityLevel) {
+			return fmt.Errorf("flow schema %q references unknown priority level %q", fs.Name, fs.PriorityLevel)
+		}
+	}
+	return nil
+}
+
+// priorityLevel serves a limited number of requests concurrently.
+type priorityLevel struct {
+	*PriorityLevel
+	seats        chan struct{}
+	queueTimeout time.Duration
+
+	lock    sync.Mutex
+	perUser map[string]int
+}
+
+// acquire returns false if the user is at its limit, or no seat frees up within the queue timeout.
+func (l *priorityLevel) acquire(req *http.Request, userName string) bool {
+	if l.MaxConcurrencyPerUser > 0 {
+		l.lock.Lock()
+		if l.perUser[userName] >= l.MaxConcurrencyPerUser {
+			l.lock.Unlock()
+			return false
+		}
+		l.perUser[userName]++
+		l.lock.Unlock()
+	}
+
+	select {
+	case l.seats <- struct{}{}:
+		return true
+	default:
+	}
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+	select {
+	case l.seats <- struct{}{}:
+		return true
+	case <-timer.C:
+	case <-req.Context().Done():
+	}
+	l.releaseUser(userName)
+	return false
+}
+
+func (l *priorityLevel) release(userName string) {
+	<-l.seats
+	l.releaseUser(userName)
+}
+
+func (l *priorityLevel) releaseUser(userName string) {
+	if l.MaxConcurrencyPerUser == 0 {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.perUser[userName]--; l.perUser[userName] <= 0 {
+		delete(l.perUser, userName)
+	}
+}
+
+type flowSchema struct {
+	*FlowSchema
+	virtualWorkspaces sets.Set[string]
+	users             sets.Set[string]
+	groups            sets.Set[string]
+	level             *priorityLevel
+}
+
+func (s *flowSchema) matches(virtualWorkspace string, u user.Info) bool {
+	if s.virtualWorkspaces.Len() > 0 && !s.virtualWorkspaces.Has(virtualWorkspace) {
+		return false
+	}
+	if s.users.Len() == 0 && s.groups.Len() == 0 {
+		return true
+	}
+	return u != nil && (s.users.Has(u.GetName()) || s.groups.HasAny(u.GetGroups()...))
+}
+
+// WithPriorityAndFairness limits the concurrency of virtual workspace requests according to
+// the given config. defaultTotalConcurrency is used if the config does not set TotalConcurrency.
+// Long-running requests like watches are not limited. The virtual workspace name, the user and
+// the request info must be in the request context.
+func WithPriorityAndFairness(delegate http.Handler, config *Config, defaultTotalConcurrency int, longRunning request.LongRunningRequestCheck) http.Handler {
+	if config == nil || len(config.FlowSchemas) == 0 {
+		return delegate
+	}
+
+	total := config.TotalConcurrency
+	if total == 0 {
+		total = defaultTotalConcurrency
+	}
+	shares := 0
+	for _, level := range config.PriorityLevels {
+		if !level.Exempt {
+			shares += level.ConcurrencyShares
+		}
+	}
+	levels := map[string]*priorityLevel{}
+	for i := range config.PriorityLevels {
+		level := &priorityLevel{PriorityLevel: &config.PriorityLevels[i], queueTimeout: DefaultQueueTimeout, perUser: map[string]int{}}
+		if !level.Exempt {
+			level.seats = make(chan struct{}, max(1, total*level.ConcurrencyShares/shares))
+		}
+		if level.QueueTimeout != nil {
+			level.queueTimeout = level.QueueTimeout.Duration
+		}
+		levels[level.Name] = level
+	}
+	schemas := make([]*flowSchema, 0, len(config.FlowSchemas))
+	for i := range config.FlowSchemas {
+		fs := &config.FlowSchemas[i]
+		schemas = append(schemas, &flowSchema{
+			FlowSchema:        fs,
+			virtualWorkspaces: sets.New[string](fs.VirtualWorkspaces...),
+			users:             sets.New[string](fs.Users...),
+			groups:            sets.New[string](fs.Groups...),
+			level:             levels[fs.PriorityLevel],
+		})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		virtualWorkspace, ok := virtualcontext.VirtualWorkspaceNameFrom(ctx)
+		if !ok {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+		if requestInfo, ok := request.RequestInfoFrom(ctx); ok && longRunning != nil && longRunning(req, requestInfo) {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		u, _ := request.UserFrom(ctx)
+		var level *priorityLevel
+		for _, fs := range schemas {
+			if fs.matches(virtualWorkspace, u) {
+				level = fs.level
+				break
+			}
+		}
+		if level == nil || level.Exempt {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		var userName string
+		if u != nil {
+			userName = u.GetName()
+		}
+		if !level.acquire(req, userName) {
+			err := apierrors.NewTooManyRequests(fmt.Sprintf("too many requests of priority level %q in virtual workspace %q", level.Name, virtualWorkspace), 1)
+			responsewriters.ErrorNegotiated(err, kubernetesscheme.Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		defer level.release(userName)
+
+		delegate.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	virtualcontext "github.com/kcp-dev/kcp/pkg/virtual/framework/context"
+)
+
+func TestLoadConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			config: `
+totalConcurrency: 100
+priorityLevels:
+- name: controllers
+  concurrencyShares: 10
+  maxConcurrencyPerUser: 2
+  queueTimeout: 1s
+- name: system
+  exempt: true
+flowSchemas:
+- name: apiexport
+  priorityLevel: controllers
+  virtualWorkspaces: ["apiexport"]
+`,
+		},
+		{
+			name: "unknown priority level",
+			config: `
+priorityLevels:
+- name: controllers
+  concurrencyShares: 10
+flowSchemas:
+- name: apiexport
+  priorityLevel: other
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing concurrency shares",
+			config: `
+priorityLevels:
+- name: controllers
+`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate priority level",
+			config: `
+priorityLevels:
+- name: controllers
+  concurrencyShares: 1
+- name: controllers
+  concurrencyShares: 1
+`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			config:  `foo: bar`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o600))
+			_, err := LoadConfig(path)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWithPriorityAndFairness(t *testing.T) {
+	config := &Config{
+		TotalConcurrency: 4,
+		PriorityLevels: []PriorityLevel{
+			{Name: "controllers", ConcurrencyShares: 1, MaxConcurrencyPerUser: 1, QueueTimeout: &metav1.Duration{}},
+			{Name: "interactive", ConcurrencyShares: 3, QueueTimeout: &metav1.Duration{}},
+			{Name: "system", Exempt: true},
+		},
+		FlowSchemas: []FlowSchema{
+			{Name: "system", PriorityLevel: "system", Groups: []string{"system:masters"}},
+			{Name: "controllers", PriorityLevel: "controllers", VirtualWorkspaces: []string{"apiexport"}},
+			{Name: "interactive", PriorityLevel: "interactive"},
+		},
+	}
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	handler := WithPriorityAndFairness(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		started <- struct{}{}
+		<-release
+	}), config, 0, func(r *http.Request, requestInfo *request.RequestInfo) bool {
+		return requestInfo.Verb == "watch"
+	})
+
+	serve := func(virtualWorkspace, verb string, u user.Info) chan int {
+		ctx := virtualcontext.WithVirtualWorkspaceName(context.Background(), virtualWorkspace)
+		ctx = request.WithUser(ctx, u)
+		ctx = request.WithRequestInfo(ctx, &request.RequestInfo{Verb: verb})
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		code := make(chan int, 1)
+		go func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			code <- w.Code
+		}()
+		return code
+	}
+	waitStarted := func() {
+		select {
+		case <-started:
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("request not served")
+		}
+	}
+
+	alice := &user.DefaultInfo{Name: "alice"}
+	bob := &user.DefaultInfo{Name: "bob"}
+	admin := &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}}
+
+	// the controllers level has a single seat taken by alice.
+	aliceCode := serve("apiexport", "list", alice)
+	waitStarted()
+	require.Equal(t, http.StatusTooManyRequests, <-serve("apiexport", "list", alice), "alice exceeds the per-user limit")
+	require.Equal(t, http.StatusTooManyRequests, <-serve("apiexport", "list", bob), "the controllers level is full")
+
+	// other levels are not starved.
+	var codes []chan int
+	for i := 0; i < 3; i++ {
+		codes = append(codes, serve("initializingworkspaces", "get", bob))
+		waitStarted()
+	}
+	require.Equal(t, http.StatusTooManyRequests, <-serve("initializingworkspaces", "get", bob), "the interactive level is full")
+
+	// exempt and long-running requests are not limited.
+	codes = append(codes, serve("apiexport", "list", admin))
+	waitStarted()
+	codes = append(codes, serve("apiexport", "watch", bob))
+	waitStarted()
+
+	close(release)
+	require.Equal(t, http.StatusOK, <-aliceCode)
+	for _, code := range codes {
+		require.Equal(t, http.StatusOK, <-code)
+	}
+
+	// seats are released.
+	require.Equal(t, http.StatusOK, <-serve("apiexport", "list", alice))
+}
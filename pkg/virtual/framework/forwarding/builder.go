@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwarding
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	virtualworkspacesdynamic "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apidefinition"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// Builder builds a dynamic virtual workspace serving a fixed set of resources by forwarding
+// requests to the underlying logical clusters. The API domain key set by the root path
+// resolver, e.g. the initializer of initializingworkspaces, selects the served resources
+// and objects.
+type Builder struct {
+	// RootPathResolver accepts the requests of the virtual workspace.
+	RootPathResolver framework.RootPathResolver
+	// Authorizer authorizes the requests of the virtual workspace.
+	Authorizer authorizer.Authorizer
+
+	// Schemas are the resources that can be served, in the given version.
+	Schemas []*apisv1alpha1.APIResourceSchema
+	Version string
+	// Resources filters the resources served for an API domain key. Nil means all.
+	Resources func(key dynamiccontext.APIDomainKey, gvr schema.GroupVersionResource) bool
+	// Storage returns the forwarding storage for an API domain key.
+	Storage func(key dynamiccontext.APIDomainKey) (*Storage, error)
+}
+
+// Build returns the virtual workspace.
+func (b *Builder) Build() *virtualworkspacesdynamic.DynamicVirtualWorkspace {
+	return &virtualworkspacesdynamic.DynamicVirtualWorkspace{
+		RootPathResolver: b.RootPathResolver,
+		Authorizer:       b.Authorizer,
+		ReadyChecker: framework.ReadyFunc(func() error {
+			return nil
+		}),
+		BootstrapAPISetManagement: func(mainConfig genericapiserver.CompletedConfig) (apidefinition.APIDefinitionSetGetter, error) {
+			return &apiDefinitionSetProvider{builder: b, config: mainConfig}, nil
+		},
+	}
+}
+
+type apiDefinitionSetProvider struct {
+	builder *Builder
+	config  genericapiserver.CompletedConfig
+}
+
+var _ apidefinition.APIDefinitionSetGetter = &apiDefinitionSetProvider{}
+
+func (p *apiDefinitionSetProvider) GetAPIDefinitionSet(ctx context.Context, key dynamiccontext.APIDomainKey) (apis apidefinition.APIDefinitionSet, apisExist bool, err error) {
+	storage, err := p.builder.Storage(key)
+	if err != nil {
+		return nil, false, err
+	}
+	restProvider := storage.RestProvider(ctx)
+
+	apis = apidefinition.APIDefinitionSet{}
+	for _, s := range p.builder.Schemas {
+		gvr := schema.GroupVersionResource{Group: s.Spec.Group, Version: p.builder.Version, Resource: s.Spec.Names.Plural}
+		if p.builder.Resources != nil && !p.builder.Resources(key, gvr) {
+			continue
+		}
+		apiDefinition, err := apiserver.CreateServingInfoFor(p.config, s, p.builder.Version, restProvider)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to create serving info: %w", err)
+		}
+		apis[gvr] = apiDefinition
+	}
+
+	return apis, len(apis) > 0, nil
+}
@@ -0,0 +1,21 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package forwarding provides builders for virtual workspaces serving resources of the
+// underlying logical clusters by forwarding requests to them, filtered by labels and
+// optionally transformed, such that new virtual workspaces only have to define which
+// resources and objects they expose and who may access them.
+package forwarding
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwarding
+
+import (
+	"context"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/transforming"
+)
+
+// Verbs selects the verbs a Storage exposes for the main resource.
+type Verbs int
+
+const (
+	// GetOnly exposes get, e.g. for virtual workspaces addressing single objects.
+	GetOnly Verbs = iota
+	// ReadOnly exposes get, list and watch.
+	ReadOnly
+	// ReadWrite exposes get, list, watch, create, update, patch, delete and deletecollection.
+	ReadWrite
+)
+
+// Storage describes a REST storage forwarding requests to a dynamic client.
+type Storage struct {
+	// ClientFunc returns the client requests are forwarded with.
+	ClientFunc registry.DynamicClusterClientFunc
+	// IdentityHash is the identity of the APIExport of the resources, if bound through one.
+	IdentityHash string
+
+	// Verbs are the verbs exposed for the main resource.
+	Verbs Verbs
+	// Status exposes get and update of the status subresource, if the resource has one.
+	Status bool
+
+	// Selector restricts the served objects to those matching the label requirements.
+	Selector labels.Requirements
+	// Transformer transforms objects before they are written and after they are read, e.g.
+	// to hide or add labels and annotations.
+	Transformer transforming.ResourceTransformer
+	// Wrappers further decorate the storage, e.g. with additional validation.
+	Wrappers registry.StorageWrappers
+}
+
+// RestProvider returns the provider of the REST storage for the resources served.
+func (s *Storage) RestProvider(ctx context.Context) apiserver.RestProviderFunc {
+	clientFunc := s.ClientFunc
+	if s.Transformer != nil {
+		clientFunc = func(ctx context.Context) (kcpdynamic.ClusterInterface, error) {
+			client, err := s.ClientFunc(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return transforming.WithResourceTransformer(client, s.Transformer), nil
+		}
+	}
+	var wrappers registry.StorageWrappers
+	if len(s.Selector) > 0 {
+		wrappers = append(wrappers, registry.WithStaticLabelSelector(s.Selector))
+	}
+	wrappers = append(wrappers, s.Wrappers...)
+
+	return func(
+		resource schema.GroupVersionResource,
+		kind schema.GroupVersionKind,
+		listKind schema.GroupVersionKind,
+		typer runtime.ObjectTyper,
+		tableConvertor rest.TableConvertor,
+		namespaceScoped bool,
+		schemaValidator validation.SchemaValidator,
+		subresourcesSchemaValidator map[string]validation.SchemaValidator,
+		structuralSchema *structuralschema.Structural,
+	) (mainStorage rest.Storage, subresourceStorages map[string]rest.Storage) {
+		statusSchemaValidate, statusEnabled := subresourcesSchemaValidator["status"]
+		statusEnabled = statusEnabled && s.Status
+
+		var statusSpec *apiextensions.CustomResourceSubresourceStatus
+		if statusEnabled {
+			statusSpec = &apiextensions.CustomResourceSubresourceStatus{}
+		}
+
+		var scaleSpec *apiextensions.CustomResourceSubresourceScale
+		// TODO(sttts): implement scale subresource
+
+		strategy := customresource.NewStrategy(
+			typer,
+			namespaceScoped,
+			kind,
+			path.ValidatePathSegmentName,
+			schemaValidator,
+			statusSchemaValidate,
+			structuralSchema,
+			statusSpec,
+			scaleSpec,
+			[]apiextensionsv1.SelectableField{},
+		)
+
+		storage, statusStorage := registry.NewStorage(
+			ctx,
+			resource,
+			s.IdentityHash,
+			kind,
+			listKind,
+			strategy,
+			nil,
+			tableConvertor,
+			nil,
+			clientFunc,
+			nil,
+			&wrappers,
+		)
+
+		// we want to expose some but not all the allowed endpoints, so filter by exposing just the funcs we need
+		subresourceStorages = make(map[string]rest.Storage)
+		if statusEnabled {
+			subresourceStorages["status"] = &struct {
+				registry.FactoryFunc
+				registry.DestroyerFunc
+
+				registry.GetterFunc
+				registry.UpdaterFunc
+				// patch is implicit as we have get + update
+
+				registry.TableConvertorFunc
+				registry.CategoriesProviderFunc
+				registry.ResetFieldsStrategyFunc
+			}{
+				FactoryFunc:   statusStorage.FactoryFunc,
+				DestroyerFunc: statusStorage.DestroyerFunc,
+
+				GetterFunc:  statusStorage.GetterFunc,
+				UpdaterFunc: statusStorage.UpdaterFunc,
+
+				TableConvertorFunc:      statusStorage.TableConvertorFunc,
+				CategoriesProviderFunc:  statusStorage.CategoriesProviderFunc,
+				ResetFieldsStrategyFunc: statusStorage.ResetFieldsStrategyFunc,
+			}
+		}
+
+		// TODO(sttts): add scale subresource
+
+		return exposeVerbs(storage, s.Verbs), subresourceStorages
+	}
+}
+
+func exposeVerbs(storage *registry.StoreFuncs, verbs Verbs) rest.Storage {
+	switch verbs {
+	case ReadWrite:
+		return &struct {
+			registry.FactoryFunc
+			registry.ListFactoryFunc
+			registry.DestroyerFunc
+
+			registry.GetterFunc
+			registry.ListerFunc
+			registry.UpdaterFunc
+			registry.WatcherFunc
+			registry.CreaterFunc
+			registry.CollectionDeleterFunc
+			registry.GracefulDeleterFunc
+
+			registry.TableConvertorFunc
+			registry.CategoriesProviderFunc
+			registry.ResetFieldsStrategyFunc
+		}{
+			FactoryFunc:     storage.FactoryFunc,
+			ListFactoryFunc: storage.ListFactoryFunc,
+			DestroyerFunc:   storage.DestroyerFunc,
+
+			GetterFunc:            storage.GetterFunc,
+			ListerFunc:            storage.ListerFunc,
+			UpdaterFunc:           storage.UpdaterFunc,
+			WatcherFunc:           storage.WatcherFunc,
+			CreaterFunc:           storage.CreaterFunc,
+			CollectionDeleterFunc: storage.CollectionDeleterFunc,
+			GracefulDeleterFunc:   storage.GracefulDeleterFunc,
+
+			TableConvertorFunc:      storage.TableConvertorFunc,
+			CategoriesProviderFunc:  storage.CategoriesProviderFunc,
+			ResetFieldsStrategyFunc: storage.ResetFieldsStrategyFunc,
+		}
+	case ReadOnly:
+		return &struct {
+			registry.FactoryFunc
+			registry.ListFactoryFunc
+			registry.DestroyerFunc
+
+			registry.GetterFunc
+			registry.ListerFunc
+			registry.WatcherFunc
+
+			registry.TableConvertorFunc
+			registry.CategoriesProviderFunc
+			registry.ResetFieldsStrategyFunc
+		}{
+			FactoryFunc:     storage.FactoryFunc,
+			ListFactoryFunc: storage.ListFactoryFunc,
+			DestroyerFunc:   storage.DestroyerFunc,
+
+			GetterFunc:  storage.GetterFunc,
+			ListerFunc:  storage.ListerFunc,
+			WatcherFunc: storage.WatcherFunc,
+
+			TableConvertorFunc:      storage.TableConvertorFunc,
+			CategoriesProviderFunc:  storage.CategoriesProviderFunc,
+			ResetFieldsStrategyFunc: storage.ResetFieldsStrategyFunc,
+		}
+	default:
+		return &struct {
+			registry.FactoryFunc
+			registry.ListFactoryFunc
+			registry.DestroyerFunc
+
+			registry.GetterFunc
+
+			registry.TableConvertorFunc
+			registry.CategoriesProviderFunc
+			registry.ResetFieldsStrategyFunc
+		}{
+			FactoryFunc:     storage.FactoryFunc,
+			ListFactoryFunc: storage.ListFactoryFunc,
+			DestroyerFunc:   storage.DestroyerFunc,
+
+			GetterFunc: storage.GetterFunc,
+
+			TableConvertorFunc:      storage.TableConvertorFunc,
+			CategoriesProviderFunc:  storage.CategoriesProviderFunc,
+			ResetFieldsStrategyFunc: storage.ResetFieldsStrategyFunc,
+		}
+	}
+}
@@ -20,13 +20,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
 )
 
 func WithStaticLabelSelector(labelSelector labels.Requirements) StorageWrapper {
@@ -76,3 +81,65 @@ func WithLabelSelector(labelSelectorFrom func(ctx context.Context) labels.Requir
 		}
 	})
 }
+
+// WithWildcardDeleteCollection adds support for DELETECOLLECTION requests against the wildcard
+// cluster. Such requests are only served if enabledFrom returns true for the request context,
+// and are rejected otherwise. Matching objects are listed across all logical clusters and deleted
+// one by one in their own logical cluster. Requests against concrete clusters are passed through.
+func WithWildcardDeleteCollection(enabledFrom func(ctx context.Context) (bool, error)) StorageWrapper {
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateLister := storage.ListerFunc
+		delegateDeleter := storage.GracefulDeleterFunc
+		delegateCollectionDeleter := storage.CollectionDeleterFunc
+		storage.CollectionDeleterFunc = func(ctx context.Context, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions, listOptions *internalversion.ListOptions) (runtime.Object, error) {
+			cluster, err := genericapirequest.ValidClusterFrom(ctx)
+			if err != nil {
+				return nil, apiErrorBadRequest(err)
+			}
+			if !cluster.Wildcard {
+				return delegateCollectionDeleter.DeleteCollection(ctx, deleteValidation, options, listOptions)
+			}
+
+			enabled, err := enabledFrom(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if !enabled {
+				return nil, errors.NewForbidden(resource, "", fmt.Errorf("cross-cluster DELETECOLLECTION is not enabled"))
+			}
+
+			obj, err := delegateLister.List(ctx, listOptions)
+			if err != nil {
+				return nil, err
+			}
+			list, ok := obj.(*unstructured.UnstructuredList)
+			if !ok {
+				return nil, fmt.Errorf("expected an UnstructuredList, got %T", obj)
+			}
+
+			deleted := &unstructured.UnstructuredList{Object: list.Object}
+			for i := range list.Items {
+				item := &list.Items[i]
+
+				clusterName := logicalcluster.From(item)
+				if clusterName.Empty() {
+					return nil, fmt.Errorf("object %s/%s has no logical cluster", item.GetNamespace(), item.GetName())
+				}
+				itemCtx := genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: clusterName})
+				if namespace := item.GetNamespace(); namespace != "" {
+					itemCtx = genericapirequest.WithNamespace(itemCtx, namespace)
+				}
+
+				if _, _, err := delegateDeleter.Delete(itemCtx, item.GetName(), deleteValidation, options); err != nil {
+					if errors.IsNotFound(err) {
+						continue
+					}
+					return nil, err
+				}
+				deleted.Items = append(deleted.Items, *item)
+			}
+
+			return deleted, nil
+		}
+	})
+}
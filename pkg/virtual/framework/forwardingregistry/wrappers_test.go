@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingregistry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+func TestWithWildcardDeleteCollection(t *testing.T) {
+	newObject := func(cluster, namespace, name string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetName(name)
+		obj.SetNamespace(namespace)
+		obj.SetAnnotations(map[string]string{logicalcluster.AnnotationKey: cluster})
+		return obj
+	}
+
+	tests := map[string]struct {
+		cluster  genericapirequest.Cluster
+		enabled  bool
+		notFound map[string]bool
+
+		wantDelegated bool
+		wantDeleted   []string
+		wantForbidden bool
+	}{
+		"concrete cluster is delegated": {
+			cluster:       genericapirequest.Cluster{Name: "root:a"},
+			wantDelegated: true,
+		},
+		"wildcard cluster is rejected when disabled": {
+			cluster:       genericapirequest.Cluster{Wildcard: true},
+			wantForbidden: true,
+		},
+		"wildcard cluster deletes in every cluster": {
+			cluster:     genericapirequest.Cluster{Wildcard: true},
+			enabled:     true,
+			wantDeleted: []string{"root:a/ns1/foo", "root:b/ns2/bar"},
+		},
+		"objects deleted in the meantime are skipped": {
+			cluster:     genericapirequest.Cluster{Wildcard: true},
+			enabled:     true,
+			notFound:    map[string]bool{"root:a/ns1/foo": true},
+			wantDeleted: []string{"root:b/ns2/bar"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			delegated := false
+			var deleted []string
+
+			storage := &StoreFuncs{
+				ListerFunc: func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+					return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+						newObject("root:a", "ns1", "foo"),
+						newObject("root:b", "ns2", "bar"),
+					}}, nil
+				},
+				GracefulDeleterFunc: func(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+					cluster := genericapirequest.ClusterFrom(ctx)
+					require.False(t, cluster.Wildcard)
+					namespace, _ := genericapirequest.NamespaceFrom(ctx)
+					key := cluster.Name.String() + "/" + namespace + "/" + name
+					if tc.notFound[key] {
+						return nil, false, apierrors.NewNotFound(schema.GroupResource{Resource: "things"}, name)
+					}
+					deleted = append(deleted, key)
+					return &unstructured.Unstructured{}, true, nil
+				},
+				CollectionDeleterFunc: func(ctx context.Context, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions, listOptions *internalversion.ListOptions) (runtime.Object, error) {
+					delegated = true
+					return &unstructured.UnstructuredList{}, nil
+				},
+			}
+			WithWildcardDeleteCollection(func(ctx context.Context) (bool, error) {
+				return tc.enabled, nil
+			}).Decorate(schema.GroupResource{Resource: "things"}, storage)
+
+			ctx := genericapirequest.WithCluster(context.Background(), tc.cluster)
+			obj, err := storage.CollectionDeleterFunc.DeleteCollection(ctx, nil, &metav1.DeleteOptions{}, &internalversion.ListOptions{})
+			if tc.wantForbidden {
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantDelegated, delegated)
+			require.Equal(t, tc.wantDeleted, deleted)
+			if !tc.wantDelegated {
+				require.Len(t, obj.(*unstructured.UnstructuredList).Items, len(tc.wantDeleted))
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/proxy"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/client-go/rest"
+)
+
+// NewProxy returns a handler proxying requests to the scheme and host of target through
+// transport. Connection upgrades, i.e. watches over WebSocket and exec, attach and
+// port-forward over SPDY or WebSocket, are passed through: they are dialed with HTTP/1.1
+// using the TLS config of transport, and upgradeRequest sets their headers, e.g. credentials
+// and impersonation. upgradeRequest must end in proxy.MirrorRequest.
+func NewProxy(target *url.URL, transport, upgradeRequest http.RoundTripper) http.Handler {
+	// a non-empty path avoids redirects of GET requests to the root path.
+	handler := proxy.NewUpgradeAwareHandler(&url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/"}, transport, false, false, errorResponder{})
+	handler.UseRequestLocation = true
+	handler.UseLocationHost = true
+	handler.UpgradeTransport = proxy.NewUpgradeRequestRoundTripper(transport, upgradeRequest)
+	return handler
+}
+
+// NewProxyForConfig returns a handler like NewProxy, proxying requests with the credentials
+// and impersonation of the given config.
+func NewProxyForConfig(target *url.URL, cfg *rest.Config) (http.Handler, error) {
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	upgradeRequest, err := rest.HTTPWrappersForConfig(cfg, proxy.MirrorRequest)
+	if err != nil {
+		return nil, err
+	}
+	return NewProxy(target, transport, upgradeRequest), nil
+}
+
+type errorResponder struct{}
+
+func (errorResponder) Error(w http.ResponseWriter, req *http.Request, err error) {
+	responsewriters.InternalError(w, req, err)
+}
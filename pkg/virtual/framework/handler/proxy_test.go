@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/proxy"
+	"k8s.io/client-go/transport"
+)
+
+func TestProxy(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "alice", req.Header.Get(transport.ImpersonateUserHeader))
+		if req.Header.Get("Upgrade") != "echo" {
+			_, _ = io.WriteString(w, req.URL.RequestURI())
+			return
+		}
+
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+		_, _ = io.WriteString(conn, "HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		line, err := buf.ReadString('\n')
+		require.NoError(t, err)
+		_, _ = io.WriteString(conn, req.URL.RequestURI()+" "+line)
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	impersonation := transport.ImpersonationConfig{UserName: "alice"}
+	frontend := httptest.NewServer(NewProxy(
+		target,
+		transport.NewImpersonatingRoundTripper(impersonation, backend.Client().Transport),
+		transport.NewImpersonatingRoundTripper(impersonation, proxy.MirrorRequest),
+	))
+	defer frontend.Close()
+
+	t.Run("plain request", func(t *testing.T) {
+		resp, err := http.Get(frontend.URL + "/clusters/root/api/v1/pods?watch=false")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "/clusters/root/api/v1/pods?watch=false", string(body))
+	})
+
+	t.Run("connection upgrade", func(t *testing.T) {
+		conn, err := net.Dial("tcp", frontend.Listener.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = io.WriteString(conn, "GET /clusters/root/api/v1/namespaces/default/pods/foo/exec?command=ls HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		require.NoError(t, err)
+		reader := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(reader, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+		_, err = io.WriteString(conn, "hello\n")
+		require.NoError(t, err)
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, "/clusters/root/api/v1/namespaces/default/pods/foo/exec?command=ls hello\n", line)
+	})
+}
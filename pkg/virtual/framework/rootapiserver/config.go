@@ -21,6 +21,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/flowcontrol"
 )
 
 type NamedVirtualWorkspace struct {
@@ -35,6 +36,9 @@ type Config struct {
 
 type ExtraConfig struct {
 	VirtualWorkspaces []NamedVirtualWorkspace
+
+	// FlowControl is the optional priority and fairness config of virtual workspace requests.
+	FlowControl *flowcontrol.Config
 }
 
 type completedConfig struct {
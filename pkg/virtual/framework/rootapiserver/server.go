@@ -33,6 +33,7 @@ import (
 	componentbaseversion "k8s.io/component-base/version"
 
 	virtualcontext "github.com/kcp-dev/kcp/pkg/virtual/framework/context"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/flowcontrol"
 )
 
 var (
@@ -80,17 +81,20 @@ func NewServer(c CompletedConfig, delegationTarget genericapiserver.DelegationTa
 
 func getRootHandlerChain(c CompletedConfig, delegateAPIServer genericapiserver.DelegationTarget) func(http.Handler, *genericapiserver.Config) http.Handler {
 	return func(apiHandler http.Handler, genericConfig *genericapiserver.Config) http.Handler {
-		delegateAfterDefaultHandlerChain := genericapiserver.DefaultBuildHandlerChain(
-			http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-				if _, virtualWorkspaceNameExists := virtualcontext.VirtualWorkspaceNameFrom(req.Context()); virtualWorkspaceNameExists {
-					delegatedHandler := delegateAPIServer.UnprotectedHandler()
-					if delegatedHandler != nil {
-						delegatedHandler.ServeHTTP(w, req)
-					}
-					return
+		handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if _, virtualWorkspaceNameExists := virtualcontext.VirtualWorkspaceNameFrom(req.Context()); virtualWorkspaceNameExists {
+				delegatedHandler := delegateAPIServer.UnprotectedHandler()
+				if delegatedHandler != nil {
+					delegatedHandler.ServeHTTP(w, req)
 				}
-				apiHandler.ServeHTTP(w, req)
-			}), c.Generic.Config)
+				return
+			}
+			apiHandler.ServeHTTP(w, req)
+		}))
+		// limit after authentication and authorization, with the user and the virtual workspace known.
+		handler = flowcontrol.WithPriorityAndFairness(handler, c.Extra.FlowControl,
+			c.Generic.MaxRequestsInFlight+c.Generic.MaxMutatingRequestsInFlight, c.Generic.LongRunningFunc)
+		delegateAfterDefaultHandlerChain := genericapiserver.DefaultBuildHandlerChain(handler, c.Generic.Config)
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			requestContext := req.Context()
 			// detect old kubectl plugins and inject warning headers
@@ -21,7 +21,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"path"
 	"strings"
@@ -33,6 +32,7 @@ import (
 	authenticationv1 "k8s.io/api/authentication/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	genericapiserver "k8s.io/apiserver/pkg/server"
@@ -46,10 +46,9 @@ import (
 	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
 	"github.com/kcp-dev/kcp/pkg/server/requestinfo"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework"
-	virtualworkspacesdynamic "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic"
-	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apidefinition"
-	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwarding"
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/handler"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 	"github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces"
@@ -87,10 +86,11 @@ func BuildVirtualWorkspace(
 		v.Schema.Raw = bs // wipe schemas. We don't want validation here.
 	}
 
+	clientFunc := func(ctx context.Context) (kcpdynamic.ClusterInterface, error) { return dynamicClusterClient, nil }
 	cachingAuthorizer := delegated.NewCachingAuthorizer(kubeClusterClient, authorizerWithCache, delegated.CachingOptions{})
 
 	wildcardLogicalClustersName := initializingworkspaces.VirtualWorkspaceName + "-wildcard-logicalclusters"
-	wildcardLogicalClusters := &virtualworkspacesdynamic.DynamicVirtualWorkspace{
+	wildcardLogicalClusters := (&forwarding.Builder{
 		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, requestContext context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
 			cluster, apiDomain, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
 			if !ok {
@@ -107,22 +107,23 @@ func BuildVirtualWorkspace(
 			return true, prefixToStrip, completedContext
 		}),
 		Authorizer: cachingAuthorizer,
-		ReadyChecker: framework.ReadyFunc(func() error {
-			return nil
-		}),
-		BootstrapAPISetManagement: func(mainConfig genericapiserver.CompletedConfig) (apidefinition.APIDefinitionSetGetter, error) {
-			return &singleResourceAPIDefinitionSetProvider{
-				config:               mainConfig,
-				dynamicClusterClient: dynamicClusterClient,
-				exposeSubresources:   false,
-				resource:             &logicalClusterResource,
-				storageProvider:      filteredLogicalClusterReadOnlyRestStorage,
+		Schemas:    []*apisv1alpha1.APIResourceSchema{&logicalClusterResource},
+		Version:    corev1alpha1.SchemeGroupVersion.Version,
+		Storage: func(key dynamiccontext.APIDomainKey) (*forwarding.Storage, error) {
+			requirements, err := initializingWorkspaceRequirements(corev1alpha1.LogicalClusterInitializer(key))
+			if err != nil {
+				return nil, err
+			}
+			return &forwarding.Storage{
+				ClientFunc: clientFunc,
+				Verbs:      forwarding.ReadOnly,
+				Selector:   requirements,
 			}, nil
 		},
-	}
+	}).Build()
 
 	LogicalClustersName := initializingworkspaces.VirtualWorkspaceName + "-logicalclusters"
-	logicalClusters := &virtualworkspacesdynamic.DynamicVirtualWorkspace{
+	logicalClusters := (&forwarding.Builder{
 		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, ctx context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
 			cluster, apiDomain, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
 			if !ok {
@@ -144,19 +145,23 @@ func BuildVirtualWorkspace(
 			return true, prefixToStrip, completedContext
 		}),
 		Authorizer: cachingAuthorizer,
-		ReadyChecker: framework.ReadyFunc(func() error {
-			return nil
-		}),
-		BootstrapAPISetManagement: func(mainConfig genericapiserver.CompletedConfig) (apidefinition.APIDefinitionSetGetter, error) {
-			return &singleResourceAPIDefinitionSetProvider{
-				config:               mainConfig,
-				dynamicClusterClient: dynamicClusterClient,
-				exposeSubresources:   true,
-				resource:             &logicalClusterResource,
-				storageProvider:      delegatingLogicalClusterReadOnlyRestStorage,
+		Schemas:    []*apisv1alpha1.APIResourceSchema{&logicalClusterResource},
+		Version:    corev1alpha1.SchemeGroupVersion.Version,
+		Storage: func(key dynamiccontext.APIDomainKey) (*forwarding.Storage, error) {
+			initializer := corev1alpha1.LogicalClusterInitializer(key)
+			requirements, err := initializingWorkspaceRequirements(initializer)
+			if err != nil {
+				return nil, err
+			}
+			return &forwarding.Storage{
+				ClientFunc: clientFunc,
+				Verbs:      forwarding.GetOnly,
+				Status:     true,
+				Selector:   requirements,
+				Wrappers:   registry.StorageWrappers{withUpdateValidation(initializer)},
 			}, nil
 		},
-	}
+	}).Build()
 
 	workspaceContentReadyCh := make(chan struct{})
 	workspaceContentName := initializingworkspaces.VirtualWorkspaceName + "-workspace-content"
@@ -252,36 +257,32 @@ func BuildVirtualWorkspace(
 				}
 
 				thisCfg := rest.CopyConfig(cfg)
-				thisCfg.Impersonate = rest.ImpersonationConfig{
-					UserName: info.Username,
-					UID:      info.UID,
-					Groups:   info.Groups,
-					Extra:    extra,
+				if !isBootstrapRequest(request) {
+					thisCfg.Impersonate = rest.ImpersonationConfig{
+						UserName: info.Username,
+						UID:      info.UID,
+						Groups:   info.Groups,
+						Extra:    extra,
+					}
 				}
-				authenticatingTransport, err := rest.TransportFor(thisCfg)
+				proxy, err := handler.NewProxyForConfig(forwardedHost, thisCfg)
 				if err != nil {
 					http.Error(writer, fmt.Sprintf("could create round-tripper: %v", err), http.StatusInternalServerError)
 					return
 				}
-				proxy := &httputil.ReverseProxy{
-					Director: func(request *http.Request) {
-						for _, header := range []string{
-							"Authorization",
-							transport.ImpersonateUserHeader,
-							transport.ImpersonateUIDHeader,
-							transport.ImpersonateGroupHeader,
-						} {
-							request.Header.Del(header)
-						}
-						for key := range request.Header {
-							if strings.HasPrefix(key, transport.ImpersonateUserExtraHeaderPrefix) {
-								request.Header.Del(key)
-							}
-						}
-						request.URL.Scheme = forwardedHost.Scheme
-						request.URL.Host = forwardedHost.Host
-					},
-					Transport: authenticatingTransport,
+				request = request.Clone(request.Context())
+				for _, header := range []string{
+					"Authorization",
+					transport.ImpersonateUserHeader,
+					transport.ImpersonateUIDHeader,
+					transport.ImpersonateGroupHeader,
+				} {
+					request.Header.Del(header)
+				}
+				for key := range request.Header {
+					if strings.HasPrefix(key, transport.ImpersonateUserExtraHeaderPrefix) {
+						request.Header.Del(key)
+					}
 				}
 				proxy.ServeHTTP(writer, request)
 			}), nil
@@ -305,6 +306,24 @@ func isLogicalClusterRequest(path string) bool {
 	return info.IsResourceRequest && info.APIGroup == corev1alpha1.SchemeGroupVersion.Group && info.Resource == "logicalclusters"
 }
 
+// bootstrapResources can be created by initializers with the privileges of the virtual workspace
+// instead of the owner of the workspace, e.g. to bootstrap credentials the owner cannot create.
+var bootstrapResources = sets.New[schema.GroupResource](
+	schema.GroupResource{Resource: "namespaces"},
+	schema.GroupResource{Resource: "secrets"},
+)
+
+var contentResolver = requestinfo.NewKCPRequestInfoResolver()
+
+func isBootstrapRequest(req *http.Request) bool {
+	info, err := contentResolver.NewRequestInfo(req)
+	if err != nil {
+		return false
+	}
+	return info.IsResourceRequest && info.Verb == "create" && info.Subresource == "" &&
+		bootstrapResources.Has(schema.GroupResource{Group: info.APIGroup, Resource: info.Resource})
+}
+
 func digestUrl(urlPath, rootPathPrefix string) (
 	cluster genericapirequest.Cluster,
 	key dynamiccontext.APIDomainKey,
@@ -368,43 +387,6 @@ func URLFor(initializerName corev1alpha1.LogicalClusterInitializer) string {
 	return path.Join("/services", initializingworkspaces.VirtualWorkspaceName, string(initializerName))
 }
 
-type singleResourceAPIDefinitionSetProvider struct {
-	config               genericapiserver.CompletedConfig
-	dynamicClusterClient kcpdynamic.ClusterInterface
-	resource             *apisv1alpha1.APIResourceSchema
-	exposeSubresources   bool
-	storageProvider      func(ctx context.Context, clusterClient kcpdynamic.ClusterInterface, initializer corev1alpha1.LogicalClusterInitializer) (apiserver.RestProviderFunc, error)
-}
-
-func (a *singleResourceAPIDefinitionSetProvider) GetAPIDefinitionSet(ctx context.Context, key dynamiccontext.APIDomainKey) (apis apidefinition.APIDefinitionSet, apisExist bool, err error) {
-	restProvider, err := a.storageProvider(ctx, a.dynamicClusterClient, corev1alpha1.LogicalClusterInitializer(key))
-	if err != nil {
-		return nil, false, err
-	}
-
-	apiDefinition, err := apiserver.CreateServingInfoFor(
-		a.config,
-		a.resource,
-		corev1alpha1.SchemeGroupVersion.Version,
-		restProvider,
-	)
-	if err != nil {
-		return nil, false, fmt.Errorf("failed to create serving info: %w", err)
-	}
-
-	apis = apidefinition.APIDefinitionSet{
-		schema.GroupVersionResource{
-			Group:    corev1alpha1.SchemeGroupVersion.Group,
-			Version:  corev1alpha1.SchemeGroupVersion.Version,
-			Resource: "logicalclusters",
-		}: apiDefinition,
-	}
-
-	return apis, len(apis) > 0, nil
-}
-
-var _ apidefinition.APIDefinitionSetGetter = &singleResourceAPIDefinitionSetProvider{}
-
 func authorizerWithCache(ctx context.Context, cache delegated.Cache, attr authorizer.Attributes) (authorizer.Decision, string, error) {
 	clusterName, name, err := initialization.TypeFrom(corev1alpha1.LogicalClusterInitializer(dynamiccontext.APIDomainKeyFrom(ctx)))
 	if err != nil {
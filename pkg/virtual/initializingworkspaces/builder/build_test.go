@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBootstrapRequest(t *testing.T) {
+	for _, testCase := range []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{method: http.MethodPost, path: "/clusters/abc/api/v1/namespaces", want: true},
+		{method: http.MethodPost, path: "/clusters/abc/api/v1/namespaces/default/secrets", want: true},
+		{method: http.MethodPut, path: "/clusters/abc/api/v1/namespaces/default/secrets/foo", want: false},
+		{method: http.MethodPost, path: "/clusters/abc/api/v1/namespaces/default/configmaps", want: false},
+		{method: http.MethodPost, path: "/clusters/abc/apis/rbac.authorization.k8s.io/v1/clusterroles", want: false},
+	} {
+		t.Run(testCase.method+" "+testCase.path, func(t *testing.T) {
+			require.Equal(t, testCase.want, isBootstrapRequest(httptest.NewRequest(testCase.method, testCase.path, nil)))
+		})
+	}
+}
@@ -20,25 +20,17 @@ import (
 	"context"
 	"fmt"
 
-	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
-
-	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
-	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
-	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/validation/path"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/klog/v2"
 
-	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
 	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
@@ -61,139 +53,9 @@ func initializingWorkspaceRequirements(initializer corev1alpha1.LogicalClusterIn
 	return requirements, nil
 }
 
-func filteredLogicalClusterReadOnlyRestStorage(
-	ctx context.Context,
-	clusterClient kcpdynamic.ClusterInterface,
-	initializer corev1alpha1.LogicalClusterInitializer,
-) (apiserver.RestProviderFunc, error) {
-	requirements, err := initializingWorkspaceRequirements(initializer)
-	if err != nil {
-		return nil, err
-	}
-
-	return registry.ProvideReadOnlyRestStorage(
-		ctx,
-		func(ctx context.Context) (kcpdynamic.ClusterInterface, error) { return clusterClient, nil },
-		registry.WithStaticLabelSelector(requirements),
-		nil,
-	)
-}
-
-func delegatingLogicalClusterReadOnlyRestStorage(
-	ctx context.Context,
-	clusterClient kcpdynamic.ClusterInterface,
-	initializer corev1alpha1.LogicalClusterInitializer,
-) (apiserver.RestProviderFunc, error) {
-	requirements, err := initializingWorkspaceRequirements(initializer)
-	if err != nil {
-		return nil, err
-	}
-
-	return func(
-		resource schema.GroupVersionResource,
-		kind schema.GroupVersionKind,
-		listKind schema.GroupVersionKind,
-		typer runtime.ObjectTyper,
-		tableConvertor rest.TableConvertor,
-		namespaceScoped bool,
-		schemaValidator validation.SchemaValidator,
-		subresourcesSchemaValidator map[string]validation.SchemaValidator,
-		structuralSchema *structuralschema.Structural,
-	) (mainStorage rest.Storage, subresourceStorages map[string]rest.Storage) {
-		statusSchemaValidate, statusEnabled := subresourcesSchemaValidator["status"]
-
-		var statusSpec *apiextensions.CustomResourceSubresourceStatus
-		if statusEnabled {
-			statusSpec = &apiextensions.CustomResourceSubresourceStatus{}
-		}
-
-		var scaleSpec *apiextensions.CustomResourceSubresourceScale
-
-		strategy := customresource.NewStrategy(
-			typer,
-			namespaceScoped,
-			kind,
-			path.ValidatePathSegmentName,
-			schemaValidator,
-			statusSchemaValidate,
-			structuralSchema,
-			statusSpec,
-			scaleSpec,
-			[]apiextensionsv1.SelectableField{},
-		)
-
-		storage, statusStorage := registry.NewStorage(
-			ctx,
-			resource,
-			"",
-			kind,
-			listKind,
-			strategy,
-			nil,
-			tableConvertor,
-			nil,
-			func(ctx context.Context) (kcpdynamic.ClusterInterface, error) { return clusterClient, nil },
-			nil,
-			&registry.StorageWrappers{
-				registry.WithStaticLabelSelector(requirements),
-				withUpdateValidation(initializer),
-			},
-		)
-
-		// we want to expose some but not all the allowed endpoints, so filter by exposing just the funcs we need
-		subresourceStorages = make(map[string]rest.Storage)
-		if statusEnabled {
-			subresourceStorages["status"] = &struct {
-				registry.FactoryFunc
-				registry.DestroyerFunc
-
-				registry.GetterFunc
-				registry.UpdaterFunc
-				// patch is implicit as we have get + update
-
-				registry.TableConvertorFunc
-				registry.CategoriesProviderFunc
-				registry.ResetFieldsStrategyFunc
-			}{
-				FactoryFunc:   statusStorage.FactoryFunc,
-				DestroyerFunc: statusStorage.DestroyerFunc,
-
-				GetterFunc:  statusStorage.GetterFunc,
-				UpdaterFunc: statusStorage.UpdaterFunc,
-
-				TableConvertorFunc:      statusStorage.TableConvertorFunc,
-				CategoriesProviderFunc:  statusStorage.CategoriesProviderFunc,
-				ResetFieldsStrategyFunc: statusStorage.ResetFieldsStrategyFunc,
-			}
-		}
-
-		// only expose GET
-		return &struct {
-			registry.FactoryFunc
-			registry.ListFactoryFunc
-			registry.DestroyerFunc
-
-			registry.GetterFunc
-
-			registry.TableConvertorFunc
-			registry.CategoriesProviderFunc
-			registry.ResetFieldsStrategyFunc
-		}{
-			FactoryFunc:     storage.FactoryFunc,
-			ListFactoryFunc: storage.ListFactoryFunc,
-			DestroyerFunc:   storage.DestroyerFunc,
-
-			GetterFunc: storage.GetterFunc,
-
-			TableConvertorFunc:      storage.TableConvertorFunc,
-			CategoriesProviderFunc:  storage.CategoriesProviderFunc,
-			ResetFieldsStrategyFunc: storage.ResetFieldsStrategyFunc,
-		}, subresourceStorages
-	}, nil
-}
-
 // withUpdateValidation adds further validation to ensure that a user of this virtual workspace can only
-// remove their own initializer from the list.
+// remove their own initializer from the list, or leave the initializers untouched, e.g. when applying
+// other status fields with server-side apply. The phase cannot be changed.
 func withUpdateValidation(initializer corev1alpha1.LogicalClusterInitializer) registry.StorageWrapper {
 	return registry.StorageWrapperFunc(func(resource schema.GroupResource, storage *registry.StoreFuncs) {
 		delegateUpdater := storage.UpdaterFunc
@@ -209,6 +71,18 @@ func withUpdateValidation(initializer corev1alpha1.LogicalClusterInitializer) re
 					logger.Error(err, "error accessing initializers from new object")
 					return errors.NewInternalError(fmt.Errorf("error accessing initializers from old object: %w", err))
 				}
+				previousPhase, _, _ := unstructured.NestedString(old.(*unstructured.Unstructured).UnstructuredContent(), "status", "phase")
+				currentPhase, _, _ := unstructured.NestedString(obj.(*unstructured.Unstructured).UnstructuredContent(), "status", "phase")
+				if previousPhase != currentPhase {
+					return errors.NewInvalid(
+						tenancyv1alpha1.Kind("Workspace"),
+						name,
+						field.ErrorList{field.Forbidden(field.NewPath("status", "phase"), "initializers cannot change the phase")},
+					)
+				}
+				if sets.New(previous...).Equal(sets.New(current...)) {
+					return updateValidation(ctx, obj, old)
+				}
 				invalidUpdateErr := errors.NewInvalid(
 					tenancyv1alpha1.Kind("Workspace"),
 					name,
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestUpdateValidation(t *testing.T) {
+	newLogicalCluster := func(phase string, initializers ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"phase":        phase,
+				"initializers": initializers,
+			},
+		}}
+	}
+
+	for _, testCase := range []struct {
+		name    string
+		old     *unstructured.Unstructured
+		new     *unstructured.Unstructured
+		wantErr bool
+	}{
+		{
+			name: "own initializer removed",
+			old:  newLogicalCluster("Initializing", "root:a", "root:b"),
+			new:  newLogicalCluster("Initializing", "root:b"),
+		},
+		{
+			name: "initializers untouched",
+			old:  newLogicalCluster("Initializing", "root:a", "root:b"),
+			new:  newLogicalCluster("Initializing", "root:b", "root:a"),
+		},
+		{
+			name:    "other initializer removed",
+			old:     newLogicalCluster("Initializing", "root:a", "root:b"),
+			new:     newLogicalCluster("Initializing", "root:a"),
+			wantErr: true,
+		},
+		{
+			name:    "initializer added",
+			old:     newLogicalCluster("Initializing", "root:a"),
+			new:     newLogicalCluster("Initializing", "root:a", "root:b"),
+			wantErr: true,
+		},
+		{
+			name:    "phase changed",
+			old:     newLogicalCluster("Initializing", "root:a"),
+			new:     newLogicalCluster("Ready", "root:a"),
+			wantErr: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			storage := &registry.StoreFuncs{}
+			storage.UpdaterFunc = func(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+				obj, err := objInfo.UpdatedObject(ctx, testCase.old)
+				if err != nil {
+					return nil, false, err
+				}
+				return obj, false, updateValidation(ctx, obj, testCase.old)
+			}
+			withUpdateValidation(corev1alpha1.LogicalClusterInitializer("root:a")).Decorate(corev1alpha1.Resource("logicalclusters"), storage)
+
+			noop := func(ctx context.Context, obj, old runtime.Object) error { return nil }
+			_, _, err := storage.Update(context.Background(), "cluster", rest.DefaultUpdatedObjectInfo(testCase.new), nil, noop, false, &metav1.UpdateOptions{})
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
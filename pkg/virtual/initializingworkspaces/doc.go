@@ -28,6 +28,14 @@ limitations under the License.
 // WATCH semantics are similar to (and implemented by) label selectors - a LogicalCluster that stops
 // matching the requirements to be served (not being in Initializing phase, not requesting initialization by
 // the controller) will be removed from the stream with a synthetic Deleted event.
+//
+// The LogicalCluster of a single workspace can be read and its status updated, patched or applied with
+// server-side apply, as long as the phase is not changed and the initializers are either untouched
+// or only the own initializer is removed.
+//
+// Other requests to a single workspace are proxied to it, impersonating the owner of the workspace.
+// Namespaces and secrets are created with the privileges of the virtual workspace, such that
+// initializers can bootstrap them without a second privileged client.
 package initializingworkspaces
 
 const VirtualWorkspaceName string = "initializingworkspaces"
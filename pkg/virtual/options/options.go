@@ -19,14 +19,20 @@ package options
 import (
 	"fmt"
 
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	"github.com/spf13/pflag"
 
 	"k8s.io/client-go/rest"
 
 	apiexportoptions "github.com/kcp-dev/kcp/pkg/virtual/apiexport/options"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/flowcontrol"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 	initializingworkspacesoptions "github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces/options"
+	replicationoptions "github.com/kcp-dev/kcp/pkg/virtual/replication/options"
+	terminatingworkspacesoptions "github.com/kcp-dev/kcp/pkg/virtual/terminatingworkspaces/options"
+	workspaceaccessreviewsoptions "github.com/kcp-dev/kcp/pkg/virtual/workspaceaccessreviews/options"
+	workspaceaccesstokensoptions "github.com/kcp-dev/kcp/pkg/virtual/workspaceaccesstokens/options"
 	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
@@ -35,12 +41,23 @@ const virtualWorkspacesFlagPrefix = "virtual-workspaces-"
 type Options struct {
 	APIExport              *apiexportoptions.APIExport
 	InitializingWorkspaces *initializingworkspacesoptions.InitializingWorkspaces
+	TerminatingWorkspaces  *terminatingworkspacesoptions.TerminatingWorkspaces
+	Replication            *replicationoptions.Replication
+	WorkspaceAccessTokens  *workspaceaccesstokensoptions.WorkspaceAccessTokens
+	WorkspaceAccessReviews *workspaceaccessreviewsoptions.WorkspaceAccessReviews
+
+	// FlowControlConfigFile is the priority and fairness config of virtual workspace requests.
+	FlowControlConfigFile string
 }
 
 func NewOptions() *Options {
 	return &Options{
 		APIExport:              apiexportoptions.New(),
 		InitializingWorkspaces: initializingworkspacesoptions.New(),
+		TerminatingWorkspaces:  terminatingworkspacesoptions.New(),
+		Replication:            replicationoptions.New(),
+		WorkspaceAccessTokens:  workspaceaccesstokensoptions.New(),
+		WorkspaceAccessReviews: workspaceaccessreviewsoptions.New(),
 	}
 }
 
@@ -49,20 +66,45 @@ func (o *Options) Validate() []error {
 
 	errs = append(errs, o.APIExport.Validate(virtualWorkspacesFlagPrefix)...)
 	errs = append(errs, o.InitializingWorkspaces.Validate(virtualWorkspacesFlagPrefix)...)
+	errs = append(errs, o.TerminatingWorkspaces.Validate(virtualWorkspacesFlagPrefix)...)
+	errs = append(errs, o.Replication.Validate(virtualWorkspacesFlagPrefix)...)
+	errs = append(errs, o.WorkspaceAccessTokens.Validate(virtualWorkspacesFlagPrefix)...)
+	errs = append(errs, o.WorkspaceAccessReviews.Validate(virtualWorkspacesFlagPrefix)...)
+	if o.FlowControlConfigFile != "" {
+		if _, err := flowcontrol.LoadConfig(o.FlowControlConfigFile); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	return errs
 }
 
 func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	o.InitializingWorkspaces.AddFlags(fs, virtualWorkspacesFlagPrefix)
+	o.TerminatingWorkspaces.AddFlags(fs, virtualWorkspacesFlagPrefix)
+	o.Replication.AddFlags(fs, virtualWorkspacesFlagPrefix)
+	o.WorkspaceAccessTokens.AddFlags(fs, virtualWorkspacesFlagPrefix)
+	o.WorkspaceAccessReviews.AddFlags(fs, virtualWorkspacesFlagPrefix)
+
+	fs.StringVar(&o.FlowControlConfigFile, virtualWorkspacesFlagPrefix+"flow-control-config", o.FlowControlConfigFile, "Config file with the priority levels and flow schemas of virtual workspace requests, separate from the main server. If unset, only the max in-flight limits apply.")
+}
+
+// NewFlowControlConfig returns the priority and fairness config of virtual workspace requests,
+// or nil if none is configured.
+func (o *Options) NewFlowControlConfig() (*flowcontrol.Config, error) {
+	if o.FlowControlConfigFile == "" {
+		return nil, nil
+	}
+	return flowcontrol.LoadConfig(o.FlowControlConfigFile)
 }
 
 func (o *Options) NewVirtualWorkspaces(
 	config *rest.Config,
 	rootPathPrefix string,
 	shardExternalURL func() string,
-	wildcardKubeInformers kcpkubernetesinformers.SharedInformerFactory,
+	wildcardKubeInformers, cachedKubeInformers kcpkubernetesinformers.SharedInformerFactory,
 	wildcardKcpInformers, cachedKcpInformers kcpinformers.SharedInformerFactory,
+	cacheDynamicClient kcpdynamic.ClusterInterface,
 ) ([]rootapiserver.NamedVirtualWorkspace, error) {
 	apiexports, err := o.APIExport.NewVirtualWorkspaces(rootPathPrefix, config, cachedKcpInformers)
 	if err != nil {
@@ -74,7 +116,27 @@ func (o *Options) NewVirtualWorkspaces(
 		return nil, err
 	}
 
-	all, err := Merge(apiexports, initializingworkspaces)
+	terminatingworkspaces, err := o.TerminatingWorkspaces.NewVirtualWorkspaces(rootPathPrefix, config)
+	if err != nil {
+		return nil, err
+	}
+
+	replication, err := o.Replication.NewVirtualWorkspaces(rootPathPrefix, cacheDynamicClient, wildcardKubeInformers, cachedKubeInformers)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceaccesstokens, err := o.WorkspaceAccessTokens.NewVirtualWorkspaces(rootPathPrefix, config, wildcardKcpInformers)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceaccessreviews, err := o.WorkspaceAccessReviews.NewVirtualWorkspaces(rootPathPrefix, config, wildcardKcpInformers)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := Merge(apiexports, initializingworkspaces, terminatingworkspaces, replication, workspaceaccesstokens, workspaceaccessreviews)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	configcrds "github.com/kcp-dev/kcp/config/crds"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwarding"
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/replication"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// replicatedCRDs are the CRDs of the replicated resources served by the virtual workspace.
+var replicatedCRDs = []string{
+	"apis.kcp.io_apiexports.yaml",
+	"apis.kcp.io_apiresourceschemas.yaml",
+	"tenancy.kcp.io_workspacetypes.yaml",
+}
+
+// BuildVirtualWorkspace returns the replication virtual workspace, serving the replicated resources
+// from the cache server. Objects are authorized with objectAuthorizer in their logical cluster.
+func BuildVirtualWorkspace(
+	rootPathPrefix string,
+	cacheDynamicClient kcpdynamic.ClusterInterface,
+	objectAuthorizer authorizer.Authorizer,
+) ([]rootapiserver.NamedVirtualWorkspace, error) {
+	if !strings.HasSuffix(rootPathPrefix, "/") {
+		rootPathPrefix += "/"
+	}
+
+	schemas := make([]*apisv1alpha1.APIResourceSchema, 0, len(replicatedCRDs))
+	for _, file := range replicatedCRDs {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := configcrds.Unmarshal(file, crd); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", file, err)
+		}
+		schema, err := apisv1alpha1.CRDToAPIResourceSchema(crd, replication.VirtualWorkspaceName)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+
+	clientFunc := func(ctx context.Context) (kcpdynamic.ClusterInterface, error) { return cacheDynamicClient, nil }
+
+	virtualWorkspace := (&forwarding.Builder{
+		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, requestContext context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
+			cluster, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
+			if !ok {
+				return false, "", requestContext
+			}
+
+			completedContext = genericapirequest.WithCluster(requestContext, cluster)
+			completedContext = dynamiccontext.WithAPIDomainKey(completedContext, dynamiccontext.APIDomainKey(replication.VirtualWorkspaceName))
+			return true, prefixToStrip, completedContext
+		}),
+		// objects are filtered by objectAuthorizer, hence every read-only request is allowed here.
+		Authorizer: authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			if !attr.IsReadOnly() {
+				return authorizer.DecisionDeny, "the replication virtual workspace is read-only", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		}),
+		Schemas: schemas,
+		Version: apisv1alpha1.SchemeGroupVersion.Version,
+		Storage: func(key dynamiccontext.APIDomainKey) (*forwarding.Storage, error) {
+			return &forwarding.Storage{
+				ClientFunc: clientFunc,
+				Verbs:      forwarding.ReadOnly,
+				Wrappers:   registry.StorageWrappers{withVisibilityFilter(objectAuthorizer)},
+			}, nil
+		},
+	}).Build()
+
+	return []rootapiserver.NamedVirtualWorkspace{
+		{Name: replication.VirtualWorkspaceName, VirtualWorkspace: virtualWorkspace},
+	}, nil
+}
+
+func digestUrl(urlPath, rootPathPrefix string) (
+	cluster genericapirequest.Cluster,
+	logicalPath string,
+	accepted bool,
+) {
+	if !strings.HasPrefix(urlPath, rootPathPrefix) {
+		return genericapirequest.Cluster{}, "", false
+	}
+	withoutRootPathPrefix := strings.TrimPrefix(urlPath, rootPathPrefix)
+
+	// Incoming requests to this virtual workspace will look like:
+	//  /services/replication/clusters/<something>/apis/apis.kcp.io/v1alpha1/apiexports
+	//                        └─────────────────────┐
+	// Where the withoutRootPathPrefix starts here: ┘
+	if !strings.HasPrefix(withoutRootPathPrefix, "clusters/") {
+		return genericapirequest.Cluster{}, "", false
+	}
+
+	withoutClustersPrefix := strings.TrimPrefix(withoutRootPathPrefix, "clusters/")
+	parts := strings.SplitN(withoutClustersPrefix, "/", 2)
+	path := logicalcluster.NewPath(parts[0])
+	realPath := "/"
+	if len(parts) > 1 {
+		realPath += parts[1]
+	}
+
+	if path == logicalcluster.Wildcard {
+		cluster.Wildcard = true
+	} else {
+		var ok bool
+		cluster.Name, ok = path.Name()
+		if !ok {
+			return genericapirequest.Cluster{}, "", false
+		}
+	}
+
+	return cluster, strings.TrimSuffix(urlPath, realPath), true
+}
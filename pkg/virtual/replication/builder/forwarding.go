@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+)
+
+// withVisibilityFilter hides the objects the user is not allowed to get in their logical cluster,
+// on get, list and watch.
+func withVisibilityFilter(objectAuthorizer authorizer.Authorizer) registry.StorageWrapper {
+	return registry.StorageWrapperFunc(func(resource schema.GroupResource, storage *registry.StoreFuncs) {
+		visible := func(ctx context.Context, obj runtime.Object) (bool, error) {
+			metaObj, err := meta.Accessor(obj)
+			if err != nil {
+				return false, err
+			}
+			user, ok := genericapirequest.UserFrom(ctx)
+			if !ok {
+				return false, nil
+			}
+			clusterName := logicalcluster.From(metaObj)
+			if clusterName.Empty() {
+				return false, nil
+			}
+
+			dec, _, err := objectAuthorizer.Authorize(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: clusterName}), authorizer.AttributesRecord{
+				User:            user,
+				Verb:            "get",
+				APIGroup:        resource.Group,
+				Resource:        resource.Resource,
+				Namespace:       metaObj.GetNamespace(),
+				Name:            metaObj.GetName(),
+				ResourceRequest: true,
+			})
+			if err != nil {
+				return false, err
+			}
+			return dec == authorizer.DecisionAllow, nil
+		}
+
+		delegateGetter := storage.GetterFunc
+		storage.GetterFunc = func(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+			obj, err := delegateGetter.Get(ctx, name, options)
+			if err != nil {
+				return obj, err
+			}
+			if ok, err := visible(ctx, obj); err != nil {
+				return nil, err
+			} else if !ok {
+				return nil, errors.NewNotFound(resource, name)
+			}
+			return obj, nil
+		}
+
+		delegateLister := storage.ListerFunc
+		storage.ListerFunc = func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+			obj, err := delegateLister.List(ctx, options)
+			if err != nil {
+				return obj, err
+			}
+			list, ok := obj.(*unstructured.UnstructuredList)
+			if !ok {
+				return nil, fmt.Errorf("expected an UnstructuredList, got %T", obj)
+			}
+
+			items := list.Items[:0]
+			for i := range list.Items {
+				if ok, err := visible(ctx, &list.Items[i]); err != nil {
+					return nil, err
+				} else if ok {
+					items = append(items, list.Items[i])
+				}
+			}
+			list.Items = items
+			return list, nil
+		}
+
+		delegateWatcher := storage.WatcherFunc
+		storage.WatcherFunc = func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			w, err := delegateWatcher.Watch(ctx, options)
+			if err != nil {
+				return w, err
+			}
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				if event.Type == watch.Bookmark || event.Type == watch.Error {
+					return event, true
+				}
+				ok, err := visible(ctx, event.Object)
+				if err != nil {
+					klog.FromContext(ctx).Error(err, "failed to authorize watch event, dropping it")
+					return event, false
+				}
+				return event, ok
+			}), nil
+		}
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestVisibilityFilter(t *testing.T) {
+	newExport := func(cluster, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetName(name)
+		u.SetAnnotations(map[string]string{logicalcluster.AnnotationKey: cluster})
+		return u
+	}
+	exports := []unstructured.Unstructured{newExport("visible", "a"), newExport("hidden", "b"), newExport("visible", "c")}
+
+	watcher := watch.NewFake()
+	storage := &registry.StoreFuncs{}
+	storage.GetterFunc = func(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+		for i := range exports {
+			if exports[i].GetName() == name {
+				return exports[i].DeepCopy(), nil
+			}
+		}
+		return nil, errors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+	}
+	storage.ListerFunc = func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+		list := &unstructured.UnstructuredList{}
+		for i := range exports {
+			list.Items = append(list.Items, *exports[i].DeepCopy())
+		}
+		return list, nil
+	}
+	storage.WatcherFunc = func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+		return watcher, nil
+	}
+
+	var users []string
+	objectAuthorizer := authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+		users = append(users, attr.GetUser().GetName())
+		require.Equal(t, "apiexports", attr.GetResource())
+		if cluster := genericapirequest.ClusterFrom(ctx); cluster.Name == "visible" {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionNoOpinion, "", nil
+	})
+	withVisibilityFilter(objectAuthorizer).Decorate(apisv1alpha1.Resource("apiexports"), storage)
+
+	ctx := genericapirequest.WithUser(context.Background(), &user.DefaultInfo{Name: "dashboard"})
+
+	_, err := storage.Get(ctx, "a", &metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = storage.Get(ctx, "b", &metav1.GetOptions{})
+	require.True(t, errors.IsNotFound(err), "expected NotFound, got %v", err)
+
+	obj, err := storage.List(ctx, &internalversion.ListOptions{})
+	require.NoError(t, err)
+	var names []string
+	for _, item := range obj.(*unstructured.UnstructuredList).Items {
+		names = append(names, item.GetName())
+	}
+	require.Equal(t, []string{"a", "c"}, names)
+
+	w, err := storage.Watch(ctx, &internalversion.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+	go func() {
+		hidden, visible := exports[1], exports[2]
+		watcher.Add(&hidden)
+		watcher.Add(&visible)
+	}()
+	event := <-w.ResultChan()
+	require.Equal(t, "c", event.Object.(*unstructured.Unstructured).GetName())
+
+	for _, u := range users {
+		require.Equal(t, "dashboard", u)
+	}
+}
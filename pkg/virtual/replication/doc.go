@@ -0,0 +1,31 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replication and its sub-packages provide the Replication Virtual Workspace.
+//
+// It serves read-only, cross-shard views of resources replicated to the cache server, i.e.
+// APIExports, APIResourceSchemas and WorkspaceTypes. A request for
+// GET /services/replication/clusters/*/apis/apis.kcp.io/v1alpha1/apiexports
+// lists the APIExports of all shards, while
+// GET /services/replication/clusters/<cluster>/apis/apis.kcp.io/v1alpha1/apiexports
+// lists those of a single logical cluster.
+//
+// Objects are served only if the user is allowed to get them in their logical cluster, either by the
+// RBAC of the local shard or by the RBAC replicated to the cache server. Objects on other shards are
+// hence only visible through replicated RBAC, e.g. the bind and content permissions of APIExports.
+package replication
+
+const VirtualWorkspaceName string = "replication"
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"path"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	"github.com/spf13/pflag"
+
+	"k8s.io/apiserver/pkg/authorization/union"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/replication"
+	"github.com/kcp-dev/kcp/pkg/virtual/replication/builder"
+)
+
+type Replication struct{}
+
+func New() *Replication {
+	return &Replication{}
+}
+
+func (o *Replication) AddFlags(flags *pflag.FlagSet, prefix string) {
+	if o == nil {
+		return
+	}
+}
+
+func (o *Replication) Validate(flagPrefix string) []error {
+	if o == nil {
+		return nil
+	}
+	errs := []error{}
+
+	return errs
+}
+
+func (o *Replication) NewVirtualWorkspaces(
+	rootPathPrefix string,
+	cacheDynamicClient kcpdynamic.ClusterInterface,
+	wildcardKubeInformers, cacheKubeInformers kcpkubernetesinformers.SharedInformerFactory,
+) (workspaces []rootapiserver.NamedVirtualWorkspace, err error) {
+	localAuthorizer, _ := authorization.NewLocalAuthorizer(wildcardKubeInformers)
+	globalAuthorizer, _ := authorization.NewGlobalAuthorizer(wildcardKubeInformers, cacheKubeInformers)
+
+	return builder.BuildVirtualWorkspace(path.Join(rootPathPrefix, replication.VirtualWorkspaceName), cacheDynamicClient, union.New(localAuthorizer, globalAuthorizer))
+}
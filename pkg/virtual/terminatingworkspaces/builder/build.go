@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	rootphase0 "github.com/kcp-dev/kcp/config/root-phase0"
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/server/requestinfo"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwarding"
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/terminatingworkspaces"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/termination"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func BuildVirtualWorkspace(
+	rootPathPrefix string,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+) ([]rootapiserver.NamedVirtualWorkspace, error) {
+	if !strings.HasSuffix(rootPathPrefix, "/") {
+		rootPathPrefix += "/"
+	}
+
+	logicalClusterResource := apisv1alpha1.APIResourceSchema{}
+	if err := rootphase0.Unmarshal("apiresourceschema-logicalclusters.core.kcp.io.yaml", &logicalClusterResource); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal logicalclusters resource: %w", err)
+	}
+	bs, err := json.Marshal(&apiextensionsv1.JSONSchemaProps{
+		Type:                   "object",
+		XPreserveUnknownFields: ptr.To(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range logicalClusterResource.Spec.Versions {
+		v := &logicalClusterResource.Spec.Versions[i]
+		v.Schema.Raw = bs // wipe schemas. We don't want validation here.
+	}
+
+	clientFunc := func(ctx context.Context) (kcpdynamic.ClusterInterface, error) { return dynamicClusterClient, nil }
+	cachingAuthorizer := delegated.NewCachingAuthorizer(kubeClusterClient, authorizerWithCache, delegated.CachingOptions{})
+
+	wildcardLogicalClustersName := terminatingworkspaces.VirtualWorkspaceName + "-wildcard-logicalclusters"
+	wildcardLogicalClusters := (&forwarding.Builder{
+		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, requestContext context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
+			cluster, apiDomain, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
+			if !ok {
+				return false, "", requestContext
+			}
+
+			if !cluster.Wildcard {
+				// this virtual workspace requires that a wildcard be provided
+				return false, "", requestContext
+			}
+
+			completedContext = genericapirequest.WithCluster(requestContext, cluster)
+			completedContext = dynamiccontext.WithAPIDomainKey(completedContext, apiDomain)
+			return true, prefixToStrip, completedContext
+		}),
+		Authorizer: cachingAuthorizer,
+		Schemas:    []*apisv1alpha1.APIResourceSchema{&logicalClusterResource},
+		Version:    corev1alpha1.SchemeGroupVersion.Version,
+		Storage: func(key dynamiccontext.APIDomainKey) (*forwarding.Storage, error) {
+			requirements, err := terminatingWorkspaceRequirements(corev1alpha1.LogicalClusterTerminator(key))
+			if err != nil {
+				return nil, err
+			}
+			return &forwarding.Storage{
+				ClientFunc: clientFunc,
+				Verbs:      forwarding.ReadOnly,
+				Selector:   requirements,
+			}, nil
+		},
+	}).Build()
+
+	logicalClustersName := terminatingworkspaces.VirtualWorkspaceName + "-logicalclusters"
+	logicalClusters := (&forwarding.Builder{
+		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, ctx context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
+			cluster, apiDomain, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
+			if !ok {
+				return false, "", ctx
+			}
+
+			if cluster.Wildcard {
+				// this virtual workspace requires that a specific cluster be provided
+				return false, "", ctx
+			}
+
+			// this delegating server only works for logicalclusters.core.kcp.io
+			if resourceURL := strings.TrimPrefix(urlPath, prefixToStrip); !isLogicalClusterRequest(resourceURL) {
+				return false, "", ctx
+			}
+
+			completedContext = genericapirequest.WithCluster(ctx, cluster)
+			completedContext = dynamiccontext.WithAPIDomainKey(completedContext, apiDomain)
+			return true, prefixToStrip, completedContext
+		}),
+		Authorizer: cachingAuthorizer,
+		Schemas:    []*apisv1alpha1.APIResourceSchema{&logicalClusterResource},
+		Version:    corev1alpha1.SchemeGroupVersion.Version,
+		Storage: func(key dynamiccontext.APIDomainKey) (*forwarding.Storage, error) {
+			terminator := corev1alpha1.LogicalClusterTerminator(key)
+			requirements, err := terminatingWorkspaceRequirements(terminator)
+			if err != nil {
+				return nil, err
+			}
+			return &forwarding.Storage{
+				ClientFunc: clientFunc,
+				Verbs:      forwarding.GetOnly,
+				Status:     true,
+				Selector:   requirements,
+				Wrappers:   registry.StorageWrappers{withUpdateValidation(terminator)},
+			}, nil
+		},
+	}).Build()
+
+	return []rootapiserver.NamedVirtualWorkspace{
+		{Name: wildcardLogicalClustersName, VirtualWorkspace: wildcardLogicalClusters},
+		{Name: logicalClustersName, VirtualWorkspace: logicalClusters},
+	}, nil
+}
+
+var resolver = requestinfo.NewFactory()
+
+func isLogicalClusterRequest(path string) bool {
+	info, err := resolver.NewRequestInfo(&http.Request{URL: &url.URL{Path: path}})
+	if err != nil {
+		return false
+	}
+	return info.IsResourceRequest && info.APIGroup == corev1alpha1.SchemeGroupVersion.Group && info.Resource == "logicalclusters"
+}
+
+func digestUrl(urlPath, rootPathPrefix string) (
+	cluster genericapirequest.Cluster,
+	key dynamiccontext.APIDomainKey,
+	logicalPath string,
+	accepted bool,
+) {
+	if !strings.HasPrefix(urlPath, rootPathPrefix) {
+		return genericapirequest.Cluster{}, dynamiccontext.APIDomainKey(""), "", false
+	}
+	withoutRootPathPrefix := strings.TrimPrefix(urlPath, rootPathPrefix)
+
+	// Incoming requests to this virtual workspace will look like:
+	//  /services/terminatingworkspaces/<terminator>/clusters/<something>/apis/core.kcp.io/v1alpha1/logicalclusters
+	//                                  └───────────┐
+	// Where the withoutRootPathPrefix starts here: ┘
+	parts := strings.SplitN(withoutRootPathPrefix, "/", 2)
+	if len(parts) < 2 {
+		return genericapirequest.Cluster{}, dynamiccontext.APIDomainKey(""), "", false
+	}
+
+	terminatorName := parts[0]
+	if terminatorName == "" {
+		return genericapirequest.Cluster{}, dynamiccontext.APIDomainKey(""), "", false
+	}
+
+	realPath := "/" + parts[1]
+
+	//  /services/terminatingworkspaces/<terminator>/clusters/<something>/apis/core.kcp.io/v1alpha1/logicalclusters
+	//                  ┌───────────────────────────┘
+	// We are now here: ┘
+	// Now, we parse out the logical cluster.
+	if !strings.HasPrefix(realPath, "/clusters/") {
+		return genericapirequest.Cluster{}, dynamiccontext.APIDomainKey(""), "", false // don't accept
+	}
+
+	withoutClustersPrefix := strings.TrimPrefix(realPath, "/clusters/")
+	parts = strings.SplitN(withoutClustersPrefix, "/", 2)
+	path := logicalcluster.NewPath(parts[0])
+	realPath = "/"
+	if len(parts) > 1 {
+		realPath += parts[1]
+	}
+
+	cluster = genericapirequest.Cluster{}
+	if path == logicalcluster.Wildcard {
+		cluster.Wildcard = true
+	} else {
+		var ok bool
+		cluster.Name, ok = path.Name()
+		if !ok {
+			return genericapirequest.Cluster{}, "", "", false
+		}
+	}
+
+	return cluster, dynamiccontext.APIDomainKey(terminatorName), strings.TrimSuffix(urlPath, realPath), true
+}
+
+// URLFor returns the absolute path for the specified terminator.
+func URLFor(terminatorName corev1alpha1.LogicalClusterTerminator) string {
+	return path.Join("/services", terminatingworkspaces.VirtualWorkspaceName, string(terminatorName))
+}
+
+func authorizerWithCache(ctx context.Context, cache delegated.Cache, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	clusterName, name, err := termination.TypeFrom(corev1alpha1.LogicalClusterTerminator(dynamiccontext.APIDomainKeyFrom(ctx)))
+	if err != nil {
+		klog.FromContext(ctx).V(2).Info(err.Error())
+		return authorizer.DecisionNoOpinion, "unable to determine terminator", fmt.Errorf("access not permitted")
+	}
+
+	authz, err := cache.Get(clusterName)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "error", err
+	}
+
+	SARAttributes := authorizer.AttributesRecord{
+		APIGroup:        tenancyv1alpha1.SchemeGroupVersion.Group,
+		APIVersion:      tenancyv1alpha1.SchemeGroupVersion.Version,
+		User:            attr.GetUser(),
+		Verb:            "terminate",
+		Name:            name,
+		Resource:        "workspacetypes",
+		ResourceRequest: true,
+	}
+
+	return authz.Authorize(ctx, SARAttributes)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+
+	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/termination"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func terminatingWorkspaceRequirements(terminator corev1alpha1.LogicalClusterTerminator) (labels.Requirements, error) {
+	labelSelector := map[string]string{
+		tenancyv1alpha1.WorkspacePhaseLabel: "Deleting",
+	}
+
+	key, value := termination.TerminatorToLabel(terminator)
+	labelSelector[key] = value
+
+	requirements, selectable := labels.SelectorFromSet(labelSelector).Requirements()
+	if !selectable {
+		return nil, fmt.Errorf("unable to create a selector from the provided labels")
+	}
+
+	return requirements, nil
+}
+
+// withUpdateValidation adds further validation to ensure that a user of this virtual workspace can only
+// remove their own terminator from the list.
+func withUpdateValidation(terminator corev1alpha1.LogicalClusterTerminator) registry.StorageWrapper {
+	return registry.StorageWrapperFunc(func(resource schema.GroupResource, storage *registry.StoreFuncs) {
+		delegateUpdater := storage.UpdaterFunc
+		storage.UpdaterFunc = func(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+			validation := rest.ValidateObjectUpdateFunc(func(ctx context.Context, obj, old runtime.Object) error {
+				logger := klog.FromContext(ctx)
+				previous, _, err := unstructured.NestedStringSlice(old.(*unstructured.Unstructured).UnstructuredContent(), "status", "terminators")
+				if err != nil {
+					return errors.NewInternalError(fmt.Errorf("error accessing terminators from old object: %w", err))
+				}
+				current, _, err := unstructured.NestedStringSlice(obj.(*unstructured.Unstructured).UnstructuredContent(), "status", "terminators")
+				if err != nil {
+					logger.Error(err, "error accessing terminators from new object")
+					return errors.NewInternalError(fmt.Errorf("error accessing terminators from old object: %w", err))
+				}
+				invalidUpdateErr := errors.NewInvalid(
+					corev1alpha1.Kind("LogicalCluster"),
+					name,
+					field.ErrorList{field.Invalid(
+						field.NewPath("status", "terminators"),
+						current,
+						fmt.Sprintf("only removing the %q terminator is supported", terminator),
+					)},
+				)
+				if len(previous)-len(current) != 1 {
+					return invalidUpdateErr
+				}
+				for _, item := range current {
+					if item == string(terminator) {
+						return invalidUpdateErr
+					}
+				}
+				return updateValidation(ctx, obj, old)
+			})
+			return delegateUpdater.Update(ctx, name, objInfo, createValidation, validation, forceAllowCreate, options)
+		}
+	})
+}
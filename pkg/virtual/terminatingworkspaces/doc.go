@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package terminatingworkspaces and its sub-packages provide the Terminating Workspace Virtual Workspace.
+//
+// It allows for two basic functions:
+// - cross-cluster LIST + WATCH of LogicalCluster which:
+//   - are being deleted
+//   - wait for termination by a specific controller
+//
+// - GET and UPDATE of the status of such a LogicalCluster, to remove the terminator of the controller
+//
+// That is, a request for
+// GET /services/terminatingworkspaces/<terminator>/clusters/*/apis/core.kcp.io/v1alpha1/logicalclusters
+// will return a list of LogicalCluster objects which are deleting and for which status.terminators contains the
+// <terminator-name>.
+// WATCH semantics are similar to (and implemented by) label selectors - a LogicalCluster that stops
+// matching the requirements to be served will be removed from the stream with a synthetic Deleted event.
+package terminatingworkspaces
+
+const VirtualWorkspaceName string = "terminatingworkspaces"
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/fixedgvs"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/workspaceaccessreviews"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+type clusterKeyType int
+
+// clusterKey holds the logical cluster of the request. The cluster in the request context is
+// replaced by the fixed group versions API server.
+const clusterKey clusterKeyType = iota
+
+// BuildVirtualWorkspace returns the workspaceaccessreviews virtual workspace. Requests are
+// authorized in the logical cluster of the request with kubeClusterClient, the workspaces of the
+// subtree are listed and reviewed with reviewKcpClient and reviewKubeClient, which must reach
+// the workspaces of all shards.
+func BuildVirtualWorkspace(
+	rootPathPrefix string,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	reviewKubeClient kcpkubernetesclientset.ClusterInterface,
+	reviewKcpClient kcpclientset.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	maxWorkspaces int,
+) ([]rootapiserver.NamedVirtualWorkspace, error) {
+	if !strings.HasSuffix(rootPathPrefix, "/") {
+		rootPathPrefix += "/"
+	}
+
+	storage := &REST{
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		listWorkspaces: func(ctx context.Context, path logicalcluster.Path) ([]tenancyv1alpha1.Workspace, error) {
+			list, err := reviewKcpClient.Cluster(path).TenancyV1alpha1().Workspaces().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		},
+		review: func(ctx context.Context, path logicalcluster.Path, sar *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReviewStatus, error) {
+			sar, err := reviewKubeClient.Cluster(path).AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &sar.Status, nil
+		},
+		maxWorkspaces: maxWorkspaces,
+	}
+
+	return []rootapiserver.NamedVirtualWorkspace{
+		{
+			Name: workspaceaccessreviews.VirtualWorkspaceName,
+			VirtualWorkspace: &fixedgvs.FixedGroupVersionsVirtualWorkspace{
+				RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, ctx context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
+					cluster, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
+					if !ok {
+						return false, "", ctx
+					}
+					completedContext = genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: cluster})
+					completedContext = context.WithValue(completedContext, clusterKey, cluster)
+					return true, prefixToStrip, completedContext
+				}),
+				Authorizer: delegated.NewCachingAuthorizer(kubeClusterClient, authorizerWithCache, delegated.CachingOptions{}),
+				ReadyChecker: framework.ReadyFunc(func() error {
+					if !logicalClusterInformer.Informer().HasSynced() {
+						return errors.New("logical cluster informer not synced")
+					}
+					return nil
+				}),
+				GroupVersionAPISets: []fixedgvs.GroupVersionAPISet{
+					{
+						GroupVersion: tenancyv1alpha1.SchemeGroupVersion,
+						AddToScheme: func(scheme *runtime.Scheme) error {
+							scheme.AddKnownTypes(tenancyv1alpha1.SchemeGroupVersion, &tenancyv1alpha1.WorkspaceAccessReview{})
+							metav1.AddToGroupVersion(scheme, tenancyv1alpha1.SchemeGroupVersion)
+							return nil
+						},
+						BootstrapRestResources: func(genericapiserver.CompletedConfig) (map[string]fixedgvs.RestStorageBuilder, error) {
+							return map[string]fixedgvs.RestStorageBuilder{
+								"workspaceaccessreviews": func(genericapiserver.CompletedConfig) (rest.Storage, error) {
+									return storage, nil
+								},
+							}, nil
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func digestUrl(urlPath, rootPathPrefix string) (cluster logicalcluster.Name, prefixToStrip string, accepted bool) {
+	if !strings.HasPrefix(urlPath, rootPathPrefix) {
+		return "", "", false
+	}
+
+	// Incoming requests to this virtual workspace will look like:
+	//  /services/workspaceaccessreviews/clusters/<logical-cluster>/apis/tenancy.kcp.io/v1alpha1/workspaceaccessreviews
+	//                                  └───────────┐
+	// Where the withoutRootPathPrefix starts here: ┘
+	withoutRootPathPrefix := strings.TrimPrefix(urlPath, rootPathPrefix)
+	if !strings.HasPrefix(withoutRootPathPrefix, "clusters/") {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(withoutRootPathPrefix, "clusters/"), "/", 2)
+	name, ok := logicalcluster.NewPath(parts[0]).Name()
+	if !ok {
+		return "", "", false // a logical cluster name is required, no wildcard or path
+	}
+	realPath := "/"
+	if len(parts) > 1 {
+		realPath += parts[1]
+	}
+
+	return name, strings.TrimSuffix(urlPath, realPath), true
+}
+
+func authorizerWithCache(ctx context.Context, cache delegated.Cache, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	cluster := genericapirequest.ClusterFrom(ctx)
+	if cluster == nil || cluster.Name.Empty() {
+		return authorizer.DecisionNoOpinion, "empty cluster name", nil
+	}
+
+	authz, err := cache.Get(cluster.Name)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "error", err
+	}
+
+	return authz.Authorize(ctx, attr)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// reviewWorkers is the number of workspaces reviewed in parallel.
+const reviewWorkers = 10
+
+// accessCheck is the check of a review without checks: whether the user can access the workspace.
+var accessCheck = tenancyv1alpha1.WorkspaceAccessCheck{Verb: "access", NonResourceURL: "/"}
+
+// REST creates WorkspaceAccessReviews without storing them.
+type REST struct {
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	// listWorkspaces returns the child workspaces of the workspace with the given path.
+	listWorkspaces func(ctx context.Context, path logicalcluster.Path) ([]tenancyv1alpha1.Workspace, error)
+	// review evaluates the SubjectAccessReview in the workspace with the given path.
+	review func(ctx context.Context, path logicalcluster.Path, sar *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReviewStatus, error)
+
+	maxWorkspaces int
+}
+
+var (
+	_ rest.Creater              = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+)
+
+func (r *REST) New() runtime.Object {
+	return &tenancyv1alpha1.WorkspaceAccessReview{}
+}
+
+// Destroy cleans up resources on shutdown.
+func (r *REST) Destroy() {
+	// Given no underlying store, we don't destroy anything
+	// here explicitly.
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) GetSingularName() string {
+	return "workspaceaccessreview"
+}
+
+func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	review, ok := obj.(*tenancyv1alpha1.WorkspaceAccessReview)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a WorkspaceAccessReview: %#v", obj))
+	}
+	clusterName, ok := ctx.Value(clusterKey).(logicalcluster.Name)
+	if !ok {
+		return nil, apierrors.NewInternalError(errors.New("no logical cluster in request context"))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	if review.Spec.User == "" && len(review.Spec.Groups) == 0 {
+		return nil, apierrors.NewBadRequest("at least one of spec.user and spec.groups must be set")
+	}
+	for i, check := range review.Spec.Checks {
+		if check.Verb == "" {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.checks[%d].verb must be set", i))
+		}
+		if (check.NonResourceURL == "") == (check.Resource == "") {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.checks[%d] must have exactly one of resource and nonResourceURL", i))
+		}
+	}
+	checks := review.Spec.Checks
+	if len(checks) == 0 {
+		checks = []tenancyv1alpha1.WorkspaceAccessCheck{accessCheck}
+	}
+
+	logicalCluster, err := r.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), clusterName.String())
+	} else if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	path := clusterName.Path()
+	if value, found := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; found {
+		path = logicalcluster.NewPath(value)
+	}
+
+	out := review.DeepCopy()
+	out.Status = r.reviewTree(ctx, path, &review.Spec, checks)
+	if err := ctx.Err(); err != nil {
+		return nil, apierrors.NewTimeoutError(fmt.Sprintf("review of %s did not finish: %v", path, err), 0)
+	}
+	return out, nil
+}
+
+// reviewTree reviews the workspaces of the subtree level by level, up to maxWorkspaces.
+func (r *REST) reviewTree(ctx context.Context, root logicalcluster.Path, spec *tenancyv1alpha1.WorkspaceAccessReviewSpec, checks []tenancyv1alpha1.WorkspaceAccessCheck) tenancyv1alpha1.WorkspaceAccessReviewStatus {
+	var status tenancyv1alpha1.WorkspaceAccessReviewStatus
+	level := []logicalcluster.Path{root}
+	for len(level) > 0 && ctx.Err() == nil {
+		if remaining := r.maxWorkspaces - len(status.Workspaces); len(level) > remaining {
+			level = level[:remaining]
+			status.Incomplete = true
+		}
+
+		results := make([]tenancyv1alpha1.WorkspaceAccessReviewResult, len(level))
+		children := make([][]logicalcluster.Path, len(level))
+		workqueue.ParallelizeUntil(ctx, reviewWorkers, len(level), func(i int) {
+			results[i], children[i] = r.reviewWorkspace(ctx, level[i], spec, checks)
+		})
+		status.Workspaces = append(status.Workspaces, results...)
+
+		level = nil
+		for _, c := range children {
+			level = append(level, c...)
+		}
+		if status.Incomplete {
+			break
+		}
+	}
+
+	sort.Slice(status.Workspaces, func(i, j int) bool {
+		return status.Workspaces[i].Path < status.Workspaces[j].Path
+	})
+	return status
+}
+
+// reviewWorkspace evaluates the checks in the workspace and returns the paths of its ready
+// children. Mounted workspaces are not reviewed.
+func (r *REST) reviewWorkspace(ctx context.Context, path logicalcluster.Path, spec *tenancyv1alpha1.WorkspaceAccessReviewSpec, checks []tenancyv1alpha1.WorkspaceAccessCheck) (tenancyv1alpha1.WorkspaceAccessReviewResult, []logicalcluster.Path) {
+	result := tenancyv1alpha1.WorkspaceAccessReviewResult{Path: path.String()}
+	for _, check := range checks {
+		status, err := r.review(ctx, path, subjectAccessReview(spec, check))
+		if err != nil {
+			result.Checks = nil
+			result.Error = err.Error()
+			break
+		}
+		result.Checks = append(result.Checks, tenancyv1alpha1.WorkspaceAccessCheckResult{
+			Allowed:         status.Allowed,
+			Reason:          status.Reason,
+			EvaluationError: status.EvaluationError,
+		})
+	}
+
+	workspaces, err := r.listWorkspaces(ctx, path)
+	if err != nil {
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("failed to list child workspaces: %v", err)
+		}
+		return result, nil
+	}
+	var children []logicalcluster.Path
+	for _, ws := range workspaces {
+		if ws.Status.Phase == corev1alpha1.LogicalClusterPhaseReady && ws.GetMount() == nil {
+			children = append(children, path.Join(ws.Name))
+		}
+	}
+	return result, children
+}
+
+func subjectAccessReview(spec *tenancyv1alpha1.WorkspaceAccessReviewSpec, check tenancyv1alpha1.WorkspaceAccessCheck) *authorizationv1.SubjectAccessReview {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   spec.User,
+			Groups: spec.Groups,
+			UID:    spec.UID,
+		},
+	}
+	if len(spec.Extra) > 0 {
+		sar.Spec.Extra = make(map[string]authorizationv1.ExtraValue, len(spec.Extra))
+		for k, v := range spec.Extra {
+			sar.Spec.Extra[k] = v
+		}
+	}
+	if check.NonResourceURL != "" {
+		sar.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{Path: check.NonResourceURL, Verb: check.Verb}
+		return sar
+	}
+	sar.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+		Namespace:   check.Namespace,
+		Verb:        check.Verb,
+		Group:       check.APIGroup,
+		Resource:    check.Resource,
+		Subresource: check.Subresource,
+		Name:        check.Name,
+	}
+	return sar
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestCreate(t *testing.T) {
+	ready := func(name string) tenancyv1alpha1.Workspace {
+		return tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     tenancyv1alpha1.WorkspaceStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+		}
+	}
+	tree := map[string][]tenancyv1alpha1.Workspace{
+		"root:org":        {ready("team-a"), ready("team-b"), {ObjectMeta: metav1.ObjectMeta{Name: "new"}}},
+		"root:org:team-a": {ready("dev")},
+	}
+	// alice may access everything but team-b, and create configmaps only in team-a.
+	allowed := func(path logicalcluster.Path, sar *authorizationv1.SubjectAccessReview) bool {
+		if sar.Spec.User != "alice" || path.String() == "root:org:team-b" {
+			return false
+		}
+		if attrs := sar.Spec.ResourceAttributes; attrs != nil {
+			return path.String() == "root:org:team-a" && attrs.Verb == "create" && attrs.Resource == "configmaps"
+		}
+		return sar.Spec.NonResourceAttributes.Verb == "access"
+	}
+
+	newREST := func(maxWorkspaces int) *REST {
+		return &REST{
+			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+				if clusterName != "org" {
+					return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), clusterName.String())
+				}
+				return &corev1alpha1.LogicalCluster{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: "root:org"}},
+				}, nil
+			},
+			listWorkspaces: func(ctx context.Context, path logicalcluster.Path) ([]tenancyv1alpha1.Workspace, error) {
+				return tree[path.String()], nil
+			},
+			review: func(ctx context.Context, path logicalcluster.Path, sar *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReviewStatus, error) {
+				if path.String() == "root:org:team-a:dev" {
+					return nil, errors.New("shard unavailable")
+				}
+				return &authorizationv1.SubjectAccessReviewStatus{Allowed: allowed(path, sar)}, nil
+			},
+			maxWorkspaces: maxWorkspaces,
+		}
+	}
+	ctx := context.WithValue(context.Background(), clusterKey, logicalcluster.Name("org"))
+
+	tests := []struct {
+		name          string
+		maxWorkspaces int
+		spec          tenancyv1alpha1.WorkspaceAccessReviewSpec
+		want          tenancyv1alpha1.WorkspaceAccessReviewStatus
+		wantErr       bool
+	}{
+		{
+			name:          "access by default",
+			maxWorkspaces: 100,
+			spec:          tenancyv1alpha1.WorkspaceAccessReviewSpec{User: "alice"},
+			want: tenancyv1alpha1.WorkspaceAccessReviewStatus{
+				Workspaces: []tenancyv1alpha1.WorkspaceAccessReviewResult{
+					{Path: "root:org", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: true}}},
+					{Path: "root:org:team-a", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: true}}},
+					{Path: "root:org:team-a:dev", Error: "shard unavailable"},
+					{Path: "root:org:team-b", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: false}}},
+				},
+			},
+		},
+		{
+			name:          "resource checks",
+			maxWorkspaces: 100,
+			spec: tenancyv1alpha1.WorkspaceAccessReviewSpec{
+				User: "alice",
+				Checks: []tenancyv1alpha1.WorkspaceAccessCheck{
+					{Verb: "create", Resource: "configmaps"},
+					{Verb: "delete", Resource: "configmaps"},
+				},
+			},
+			want: tenancyv1alpha1.WorkspaceAccessReviewStatus{
+				Workspaces: []tenancyv1alpha1.WorkspaceAccessReviewResult{
+					{Path: "root:org", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: false}, {Allowed: false}}},
+					{Path: "root:org:team-a", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: true}, {Allowed: false}}},
+					{Path: "root:org:team-a:dev", Error: "shard unavailable"},
+					{Path: "root:org:team-b", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: false}, {Allowed: false}}},
+				},
+			},
+		},
+		{
+			name:          "limited number of workspaces",
+			maxWorkspaces: 2,
+			spec:          tenancyv1alpha1.WorkspaceAccessReviewSpec{Groups: []string{"auditors"}},
+			want: tenancyv1alpha1.WorkspaceAccessReviewStatus{
+				Workspaces: []tenancyv1alpha1.WorkspaceAccessReviewResult{
+					{Path: "root:org", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: false}}},
+					{Path: "root:org:team-a", Checks: []tenancyv1alpha1.WorkspaceAccessCheckResult{{Allowed: false}}},
+				},
+				Incomplete: true,
+			},
+		},
+		{
+			name:          "no subject",
+			maxWorkspaces: 100,
+			wantErr:       true,
+		},
+		{
+			name:          "resource and non-resource URL",
+			maxWorkspaces: 100,
+			spec: tenancyv1alpha1.WorkspaceAccessReviewSpec{
+				User:   "alice",
+				Checks: []tenancyv1alpha1.WorkspaceAccessCheck{{Verb: "get", Resource: "configmaps", NonResourceURL: "/healthz"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := newREST(tt.maxWorkspaces).Create(ctx, &tenancyv1alpha1.WorkspaceAccessReview{Spec: tt.spec}, nil, &metav1.CreateOptions{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, obj.(*tenancyv1alpha1.WorkspaceAccessReview).Status)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workspaceaccessreviews and its sub-packages provide the Workspace Access Reviews Virtual Workspace.
+//
+// It allows users to check what another user can do in a workspace and all its descendants, e.g. for
+// audits. That is, a request for
+// POST /services/workspaceaccessreviews/clusters/<logical-cluster>/apis/tenancy.kcp.io/v1alpha1/workspaceaccessreviews
+// returns a WorkspaceAccessReview with a report of the checks in every workspace of the subtree, if the
+// requesting user may create workspaceaccessreviews in the logical cluster.
+package workspaceaccessreviews
+
+const VirtualWorkspaceName string = "workspaceaccessreviews"
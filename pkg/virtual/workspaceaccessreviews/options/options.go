@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"path"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/spf13/pflag"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/workspaceaccessreviews"
+	"github.com/kcp-dev/kcp/pkg/virtual/workspaceaccessreviews/builder"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+type WorkspaceAccessReviews struct {
+	// Kubeconfig reaches the workspaces of all shards, usually through the front-proxy, with
+	// permissions to list workspaces and create subjectaccessreviews everywhere. If empty, the
+	// virtual workspace is not served.
+	Kubeconfig string
+	// MaxWorkspaces is the maximum number of workspaces covered by one review.
+	MaxWorkspaces int
+}
+
+func New() *WorkspaceAccessReviews {
+	return &WorkspaceAccessReviews{
+		MaxWorkspaces: 1000,
+	}
+}
+
+func (o *WorkspaceAccessReviews) AddFlags(flags *pflag.FlagSet, prefix string) {
+	if o == nil {
+		return
+	}
+
+	flags.StringVar(&o.Kubeconfig, prefix+"workspace-access-review-kubeconfig", o.Kubeconfig, "Kubeconfig reaching the workspaces of all shards, e.g. through the front-proxy, used to list workspaces and create SubjectAccessReviews for workspace access reviews. If unset, workspace access reviews cannot be created.")
+	flags.IntVar(&o.MaxWorkspaces, prefix+"workspace-access-review-max-workspaces", o.MaxWorkspaces, "The maximum number of workspaces covered by one workspace access review.")
+}
+
+func (o *WorkspaceAccessReviews) Validate(flagPrefix string) []error {
+	if o == nil {
+		return nil
+	}
+	errs := []error{}
+
+	if o.MaxWorkspaces < 1 {
+		errs = append(errs, fmt.Errorf("--%sworkspace-access-review-max-workspaces must be at least 1", flagPrefix))
+	}
+
+	return errs
+}
+
+func (o *WorkspaceAccessReviews) NewVirtualWorkspaces(
+	rootPathPrefix string,
+	config *rest.Config,
+	wildcardKcpInformers kcpinformers.SharedInformerFactory,
+) (workspaces []rootapiserver.NamedVirtualWorkspace, err error) {
+	if o.Kubeconfig == "" {
+		return nil, nil
+	}
+
+	config = rest.AddUserAgent(rest.CopyConfig(config), "workspaceaccessreviews-virtual-workspace")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewConfig, err := clientcmd.BuildConfigFromFlags("", o.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace access review kubeconfig: %w", err)
+	}
+	reviewConfig = rest.AddUserAgent(reviewConfig, "workspaceaccessreviews-virtual-workspace")
+	reviewKubeClient, err := kcpkubernetesclientset.NewForConfig(reviewConfig)
+	if err != nil {
+		return nil, err
+	}
+	reviewKcpClient, err := kcpclientset.NewForConfig(reviewConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.BuildVirtualWorkspace(path.Join(rootPathPrefix, workspaceaccessreviews.VirtualWorkspaceName), kubeClusterClient, reviewKubeClient, reviewKcpClient, wildcardKcpInformers.Core().V1alpha1().LogicalClusters(), o.MaxWorkspaces)
+}
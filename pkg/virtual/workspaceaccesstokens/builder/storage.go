@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/kcp-dev/kcp/pkg/authentication/accesstoken"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// REST creates WorkspaceAccessTokens without storing them.
+type REST struct {
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	generator         *accesstoken.Generator
+	maxExpiration     time.Duration
+}
+
+var (
+	_ rest.Creater              = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+)
+
+func (r *REST) New() runtime.Object {
+	return &tenancyv1alpha1.WorkspaceAccessToken{}
+}
+
+// Destroy cleans up resources on shutdown.
+func (r *REST) Destroy() {
+	// Given no underlying store, we don't destroy anything
+	// here explicitly.
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) GetSingularName() string {
+	return "workspaceaccesstoken"
+}
+
+func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	token, ok := obj.(*tenancyv1alpha1.WorkspaceAccessToken)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a WorkspaceAccessToken: %#v", obj))
+	}
+	clusterName, ok := ctx.Value(clusterKey).(logicalcluster.Name)
+	if !ok {
+		return nil, apierrors.NewInternalError(errors.New("no logical cluster in request context"))
+	}
+	u, ok := genericapirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewInternalError(errors.New("no user in request context"))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(token.Spec.Rules) == 0 {
+		return nil, apierrors.NewBadRequest("spec.rules must not be empty")
+	}
+	for i, rule := range token.Spec.Rules {
+		if len(rule.Verbs) == 0 {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.rules[%d].verbs must not be empty", i))
+		}
+		if len(rule.NonResourceURLs) == 0 && len(rule.Resources) == 0 {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.rules[%d] must have resources or nonResourceURLs", i))
+		}
+	}
+	expiration := r.maxExpiration
+	if seconds := token.Spec.ExpirationSeconds; seconds != nil {
+		if *seconds < 60 {
+			return nil, apierrors.NewBadRequest("spec.expirationSeconds must be at least 60")
+		}
+		expiration = min(time.Duration(*seconds)*time.Second, r.maxExpiration)
+	}
+
+	logicalCluster, err := r.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), clusterName.String())
+	} else if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	path := clusterName.Path()
+	if value, found := logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]; found {
+		path = logicalcluster.NewPath(value)
+	}
+
+	tokenData, expiry, err := r.generator.Generate(u, path, token.Spec.Rules, expiration)
+	if err != nil {
+		return nil, apierrors.NewForbidden(tenancyv1alpha1.Resource("workspaceaccesstokens"), token.ObjectMeta.Name, err)
+	}
+
+	out := token.DeepCopy()
+	out.Spec.ExpirationSeconds = ptr.To(int64(expiration / time.Second))
+	out.Status = tenancyv1alpha1.WorkspaceAccessTokenStatus{
+		Token:               tokenData,
+		ExpirationTimestamp: metav1.NewTime(expiry),
+	}
+	return out, nil
+}
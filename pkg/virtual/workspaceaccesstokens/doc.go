@@ -0,0 +1,26 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workspaceaccesstokens and its sub-packages provide the Workspace Access Tokens Virtual Workspace.
+//
+// It allows users to create short-lived tokens restricted to a workspace, its descendants and a list
+// of rules, e.g. for CI jobs. That is, a request for
+// POST /services/workspaceaccesstokens/clusters/<logical-cluster>/apis/tenancy.kcp.io/v1alpha1/workspaceaccesstokens
+// returns a WorkspaceAccessToken with a token of the requesting user, if the user may create
+// workspaceaccesstokens in the logical cluster.
+package workspaceaccesstokens
+
+const VirtualWorkspaceName string = "workspaceaccesstokens"
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/spf13/pflag"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/authentication/accesstoken"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/workspaceaccesstokens"
+	"github.com/kcp-dev/kcp/pkg/virtual/workspaceaccesstokens/builder"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+type WorkspaceAccessTokens struct {
+	// SigningKeyFile is the private key signing the tokens. If empty, the virtual
+	// workspace is not served.
+	SigningKeyFile string
+	// MaxExpiration is the maximum lifetime of the tokens.
+	MaxExpiration time.Duration
+}
+
+func New() *WorkspaceAccessTokens {
+	return &WorkspaceAccessTokens{
+		MaxExpiration: time.Hour,
+	}
+}
+
+func (o *WorkspaceAccessTokens) AddFlags(flags *pflag.FlagSet, prefix string) {
+	if o == nil {
+		return
+	}
+
+	flags.StringVar(&o.SigningKeyFile, prefix+"workspace-access-token-signing-key-file", o.SigningKeyFile, "File with the PEM-encoded private key signing workspace access tokens. If unset, workspace access tokens cannot be created. The shards and the front-proxy verify the tokens with --workspace-access-token-key-files.")
+	flags.DurationVar(&o.MaxExpiration, prefix+"workspace-access-token-max-expiration", o.MaxExpiration, "The maximum lifetime of workspace access tokens, also used if a token does not request a lifetime.")
+}
+
+func (o *WorkspaceAccessTokens) Validate(flagPrefix string) []error {
+	if o == nil {
+		return nil
+	}
+	errs := []error{}
+
+	if o.MaxExpiration < time.Minute {
+		errs = append(errs, fmt.Errorf("--%sworkspace-access-token-max-expiration must be at least 1m", flagPrefix))
+	}
+
+	return errs
+}
+
+func (o *WorkspaceAccessTokens) NewVirtualWorkspaces(
+	rootPathPrefix string,
+	config *rest.Config,
+	wildcardKcpInformers kcpinformers.SharedInformerFactory,
+) (workspaces []rootapiserver.NamedVirtualWorkspace, err error) {
+	if o.SigningKeyFile == "" {
+		return nil, nil
+	}
+	generator, err := accesstoken.NewGenerator(o.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config = rest.AddUserAgent(rest.CopyConfig(config), "workspaceaccesstokens-virtual-workspace")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.BuildVirtualWorkspace(path.Join(rootPathPrefix, workspaceaccesstokens.VirtualWorkspaceName), kubeClusterClient, wildcardKcpInformers.Core().V1alpha1().LogicalClusters(), generator, o.MaxExpiration)
+}
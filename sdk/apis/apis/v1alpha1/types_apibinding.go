@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 const (
@@ -41,6 +42,7 @@ const (
 // +kubebuilder:resource:scope=Cluster,categories=kcp
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:storageversion
 type APIBinding struct {
 	metav1.TypeMeta `json:",inline"`
 	// +optional
@@ -64,6 +66,9 @@ func (in *APIBinding) SetConditions(conditions conditionsv1alpha1.Conditions) {
 	in.Status.Conditions = conditions
 }
 
+var _ conditions.Getter = &APIBinding{}
+var _ conditions.Setter = &APIBinding{}
+
 // APIBindingSpec records the APIs and implementations that are to be bound.
 type APIBindingSpec struct {
 	// reference uniquely identifies an API to bind to.
@@ -225,6 +230,14 @@ const (
 	// PermissionClaimsApplied is a condition for APIBinding that indicates that all the accepted permission claims
 	// have been applied.
 	PermissionClaimsApplied conditionsv1alpha1.ConditionType = "PermissionClaimsApplied"
+
+	// StorageMigrated is a condition for APIBinding that indicates that all objects of the bound resources are
+	// persisted in the current storage version, according to the storage migration strategy of the APIExport.
+	StorageMigrated conditionsv1alpha1.ConditionType = "StorageMigrated"
+
+	// StorageMigrationFailedReason is a reason for the StorageMigrated condition that rewriting objects of
+	// a bound resource failed.
+	StorageMigrationFailedReason = "StorageMigrationFailed"
 )
 
 // These are annotations for bound CRDs.
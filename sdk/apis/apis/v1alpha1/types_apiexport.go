@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // These are valid conditions of APIExport.
@@ -54,6 +55,7 @@ const (
 // +kubebuilder:resource:scope=Cluster,categories=kcp
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="VirtualWorkspaceURLsReady")].status`
+// +kubebuilder:storageversion
 type APIExport struct {
 	metav1.TypeMeta `json:",inline"`
 	// +optional
@@ -77,6 +79,12 @@ const (
 	// this APIExport. If the annotation is removed from the APIExport, it will also be removed from
 	// all APIBindings bound to this APIExport.
 	AnnotationAPIExportExtraKeyPrefix = "extra.apis.kcp.io/"
+
+	// ExperimentalWildcardDeleteCollectionAnnotationKey is the annotation key set on an APIExport
+	// to allow DELETECOLLECTION requests across all clusters, i.e. against the wildcard cluster,
+	// through the APIExport virtual workspace. Only the value "true" enables it. The caller
+	// additionally needs the "wildcard-deletecollection" verb on the apiexports/content subresource.
+	ExperimentalWildcardDeleteCollectionAnnotationKey = "experimental.apis.kcp.io/wildcard-deletecollection"
 )
 
 func (in *APIExport) GetConditions() conditionsv1alpha1.Conditions {
@@ -87,6 +95,9 @@ func (in *APIExport) SetConditions(conditions conditionsv1alpha1.Conditions) {
 	in.Status.Conditions = conditions
 }
 
+var _ conditions.Getter = &APIExport{}
+var _ conditions.Setter = &APIExport{}
+
 const (
 	// MaximalPermissionPolicyRBACUserGroupPrefix is the prefix for the user and group names
 	// when verifying the APIExport.spec.maximalPermissionPolicy.
@@ -164,6 +175,39 @@ type APIExportSpec struct {
 	// +listMapKey=group
 	// +listMapKey=resource
 	PermissionClaims []PermissionClaim `json:"permissionClaims,omitempty"`
+
+	// storageMigration configures how objects of the resources of this APIExport are migrated
+	// in all consumer workspaces when the storage version of one of the latestResourceSchemas
+	// changes.
+	//
+	// If unset, no migration takes place and all versions that were ever persisted stay listed
+	// in the storageVersions of the APIBindings.
+	//
+	// +optional
+	StorageMigration *StorageMigration `json:"storageMigration,omitempty"`
+}
+
+// StorageMigrationStrategyType is the strategy used to migrate stored objects to a new storage version.
+type StorageMigrationStrategyType string
+
+const (
+	// RewriteStorageMigrationStrategy rewrites every stored object of a bound resource through the
+	// API server such that it is persisted in the current storage version, like
+	// kube-storage-version-migrator does.
+	RewriteStorageMigrationStrategy StorageMigrationStrategyType = "Rewrite"
+)
+
+// StorageMigration configures the migration of stored objects of an APIExport's resources
+// in the consumer workspaces.
+type StorageMigration struct {
+	// strategy is the migration strategy. The only supported strategy is "Rewrite", which
+	// rewrites all stored objects in the current storage version. When all objects of a bound
+	// resource are migrated, the old versions are removed from the storageVersions of the APIBinding.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Rewrite
+	Strategy StorageMigrationStrategyType `json:"strategy"`
 }
 
 // Identity defines the identity of an APIExport, i.e. determines the etcd prefix
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // +crd
@@ -100,6 +101,9 @@ func (in *APIExportEndpointSlice) SetConditions(conditions conditionsv1alpha1.Co
 	in.Status.Conditions = conditions
 }
 
+var _ conditions.Getter = &APIExportEndpointSlice{}
+var _ conditions.Setter = &APIExportEndpointSlice{}
+
 // These are valid conditions of APIExportEndpointSlice in addition to
 // APIExportValid and related reasons defined with the APIBinding type.
 const (
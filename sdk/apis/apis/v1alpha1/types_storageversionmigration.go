@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp,path=storageversionmigrations,singular=storageversionmigration
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.resource.group"
+// +kubebuilder:printcolumn:name="Resource",type="string",JSONPath=".spec.resource.resource"
+// +kubebuilder:printcolumn:name="Storage Version",type="string",JSONPath=".status.storageVersion"
+// +kubebuilder:printcolumn:name="Migrated",type="integer",JSONPath=".status.migratedObjects"
+// +kubebuilder:printcolumn:name="Succeeded",type="string",JSONPath=`.status.conditions[?(@.type=="Succeeded")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// StorageVersionMigration rewrites all objects of a resource in the logical cluster it lives in,
+// such that they are persisted in the current storage version of the resource. The resource can
+// be bound through an APIBinding, be defined by a CustomResourceDefinition of the workspace, or be
+// a built-in resource.
+//
+// The migration is restarted whenever the storage version of the resource changes. When it has
+// succeeded, older versions are dropped from the storage versions recorded for the resource, i.e.
+// from the APIBinding status or the CustomResourceDefinition status, such that these versions can
+// be retired safely.
+type StorageVersionMigration struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec holds the desired state.
+	// +required
+	// +kubebuilder:validation:Required
+	Spec StorageVersionMigrationSpec `json:"spec"`
+
+	// status communicates the observed state.
+	// +optional
+	Status StorageVersionMigrationStatus `json:"status,omitempty"`
+}
+
+// StorageVersionMigrationSpec defines the resource to migrate.
+type StorageVersionMigrationSpec struct {
+	// resource is the resource whose objects are migrated.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="resource is immutable"
+	Resource GroupResource `json:"resource"`
+}
+
+// StorageVersionMigrationStatus communicates the progress of a StorageVersionMigration.
+type StorageVersionMigrationStatus struct {
+	// storageVersion is the version objects are migrated to. It is the current storage version
+	// of the resource when the migration was last (re)started.
+	//
+	// +optional
+	StorageVersion string `json:"storageVersion,omitempty"`
+
+	// storedVersions are the versions objects of the resource might be persisted in within this
+	// logical cluster. They are empty for built-in resources, which do not record their stored
+	// versions.
+	//
+	// +optional
+	StoredVersions []string `json:"storedVersions,omitempty"`
+
+	// migratedObjects is the number of objects rewritten since the migration was last (re)started.
+	//
+	// +optional
+	MigratedObjects int64 `json:"migratedObjects,omitempty"`
+
+	// continueToken is the list continuation token of the next batch of objects to migrate.
+	// It is empty when the migration starts or has finished.
+	//
+	// +optional
+	ContinueToken string `json:"continueToken,omitempty"`
+
+	// conditions is a list of conditions that apply to the StorageVersionMigration.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+func (in *StorageVersionMigration) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *StorageVersionMigration) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+var _ conditions.Getter = &StorageVersionMigration{}
+var _ conditions.Setter = &StorageVersionMigration{}
+
+// These are valid conditions of StorageVersionMigration.
+const (
+	// StorageVersionMigrationSucceeded is a condition for StorageVersionMigration that indicates that all
+	// objects of the resource are persisted in status.storageVersion.
+	StorageVersionMigrationSucceeded conditionsv1alpha1.ConditionType = "Succeeded"
+
+	// StorageVersionMigrationRunningReason is a reason for the Succeeded condition of StorageVersionMigration
+	// that objects are being migrated.
+	StorageVersionMigrationRunningReason = "Running"
+	// StorageVersionMigrationFailedReason is a reason for the Succeeded condition of StorageVersionMigration
+	// that rewriting objects failed. The migration is retried.
+	StorageVersionMigrationFailedReason = "Failed"
+	// StorageVersionUnknownReason is a reason for the Succeeded condition of StorageVersionMigration that the
+	// storage version of the resource cannot be determined, e.g. because the resource does not exist.
+	StorageVersionUnknownReason = "StorageVersionUnknown"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageVersionMigrationList is a list of StorageVersionMigration resources.
+type StorageVersionMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []StorageVersionMigration `json:"items"`
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+const (
+	// ResourceSchemasAnnotationKey is set on a v1alpha1 APIExport to preserve the v1alpha2
+	// spec.resources if they cannot be derived from the schema names in spec.latestResourceSchemas,
+	// i.e. if a schema name does not follow the <prefix>.<resource>.<group> convention.
+	ResourceSchemasAnnotationKey = "apis.v1alpha2.kcp.io/resources"
+
+	// VirtualWorkspacesAnnotationKey is set on a v1alpha2 APIExport to preserve the deprecated
+	// v1alpha1 status.virtualWorkspaces, which do not exist in v1alpha2.
+	VirtualWorkspacesAnnotationKey = "apis.v1alpha1.kcp.io/virtual-workspaces"
+)
+
+// RegisterConversions adds the conversion functions between v1alpha1 and v1alpha2 to the scheme.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddConversionFunc((*apisv1alpha1.APIExport)(nil), (*APIExport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_APIExport_To_v1alpha2_APIExport(a.(*apisv1alpha1.APIExport), b.(*APIExport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*APIExport)(nil), (*apisv1alpha1.APIExport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_APIExport_To_v1alpha1_APIExport(a.(*APIExport), b.(*apisv1alpha1.APIExport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*apisv1alpha1.APIExportList)(nil), (*APIExportList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_APIExportList_To_v1alpha2_APIExportList(a.(*apisv1alpha1.APIExportList), b.(*APIExportList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*APIExportList)(nil), (*apisv1alpha1.APIExportList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_APIExportList_To_v1alpha1_APIExportList(a.(*APIExportList), b.(*apisv1alpha1.APIExportList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*apisv1alpha1.APIBinding)(nil), (*APIBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_APIBinding_To_v1alpha2_APIBinding(a.(*apisv1alpha1.APIBinding), b.(*APIBinding), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*APIBinding)(nil), (*apisv1alpha1.APIBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_APIBinding_To_v1alpha1_APIBinding(a.(*APIBinding), b.(*apisv1alpha1.APIBinding), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*apisv1alpha1.APIBindingList)(nil), (*APIBindingList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_APIBindingList_To_v1alpha2_APIBindingList(a.(*apisv1alpha1.APIBindingList), b.(*APIBindingList), scope)
+	}); err != nil {
+		return err
+	}
+	return s.AddConversionFunc((*APIBindingList)(nil), (*apisv1alpha1.APIBindingList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_APIBindingList_To_v1alpha1_APIBindingList(a.(*APIBindingList), b.(*apisv1alpha1.APIBindingList), scope)
+	})
+}
+
+// ResourceSchemaFromName derives the resource of an APIResourceSchema name in the form
+// <prefix>.<resource>.<group>. It returns false if the name does not follow that form.
+func ResourceSchemaFromName(name string) (ResourceSchema, bool) {
+	parts := strings.SplitN(name, ".", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return ResourceSchema{}, false
+	}
+	return ResourceSchema{Name: parts[1], Group: parts[2], Schema: name}, true
+}
+
+func Convert_v1alpha1_APIExport_To_v1alpha2_APIExport(in *apisv1alpha1.APIExport, out *APIExport, s conversion.Scope) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	deleteAnnotation(&out.ObjectMeta, VirtualWorkspacesAnnotationKey)
+	out.Spec.Resources = nil
+	if value, found := in.Annotations[ResourceSchemasAnnotationKey]; found {
+		deleteAnnotation(&out.ObjectMeta, ResourceSchemasAnnotationKey)
+
+		var resources []ResourceSchema
+		if err := json.Unmarshal([]byte(value), &resources); err != nil {
+			return fmt.Errorf("failed to decode annotation %s: %w", ResourceSchemasAnnotationKey, err)
+		}
+		// only trust the annotation if the schemas were not changed in v1alpha1 since.
+		if schemaNames(resources) == strings.Join(in.Spec.LatestResourceSchemas, ",") {
+			out.Spec.Resources = resources
+		}
+	}
+	if out.Spec.Resources == nil {
+		for _, name := range in.Spec.LatestResourceSchemas {
+			resource, ok := ResourceSchemaFromName(name)
+			if !ok {
+				resource = ResourceSchema{Schema: name}
+			}
+			out.Spec.Resources = append(out.Spec.Resources, resource)
+		}
+	}
+
+	out.Spec.Identity = nil
+	if in.Spec.Identity != nil {
+		out.Spec.Identity = &Identity{SecretRef: in.Spec.Identity.SecretRef.DeepCopy()}
+	}
+	out.Spec.MaximalPermissionPolicy = nil
+	if in.Spec.MaximalPermissionPolicy != nil {
+		out.Spec.MaximalPermissionPolicy = &MaximalPermissionPolicy{}
+		if in.Spec.MaximalPermissionPolicy.Local != nil {
+			out.Spec.MaximalPermissionPolicy.Local = &LocalAPIExportPolicy{}
+		}
+	}
+	out.Spec.PermissionClaims = nil
+	for _, claim := range in.Spec.PermissionClaims {
+		out.Spec.PermissionClaims = append(out.Spec.PermissionClaims, convertPermissionClaimToV1alpha2(claim))
+	}
+	out.Spec.StorageMigration = nil
+	if in.Spec.StorageMigration != nil {
+		out.Spec.StorageMigration = &StorageMigration{Strategy: StorageMigrationStrategyType(in.Spec.StorageMigration.Strategy)}
+	}
+
+	out.Status.IdentityHash = in.Status.IdentityHash
+	out.Status.Conditions = in.Status.Conditions.DeepCopy()
+
+	if len(in.Status.VirtualWorkspaces) > 0 {
+		value, err := json.Marshal(in.Status.VirtualWorkspaces)
+		if err != nil {
+			return err
+		}
+		metav1.SetMetaDataAnnotation(&out.ObjectMeta, VirtualWorkspacesAnnotationKey, string(value))
+	}
+
+	return nil
+}
+
+func Convert_v1alpha2_APIExport_To_v1alpha1_APIExport(in *APIExport, out *apisv1alpha1.APIExport, s conversion.Scope) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	deleteAnnotation(&out.ObjectMeta, ResourceSchemasAnnotationKey)
+
+	out.Spec.LatestResourceSchemas = nil
+	lossless := true
+	for _, resource := range in.Spec.Resources {
+		out.Spec.LatestResourceSchemas = append(out.Spec.LatestResourceSchemas, resource.Schema)
+		if derived, ok := ResourceSchemaFromName(resource.Schema); !ok || derived != resource {
+			lossless = false
+		}
+	}
+	if !lossless {
+		value, err := json.Marshal(in.Spec.Resources)
+		if err != nil {
+			return err
+		}
+		metav1.SetMetaDataAnnotation(&out.ObjectMeta, ResourceSchemasAnnotationKey, string(value))
+	}
+
+	out.Spec.Identity = nil
+	if in.Spec.Identity != nil {
+		out.Spec.Identity = &apisv1alpha1.Identity{SecretRef: in.Spec.Identity.SecretRef.DeepCopy()}
+	}
+	out.Spec.MaximalPermissionPolicy = nil
+	if in.Spec.MaximalPermissionPolicy != nil {
+		out.Spec.MaximalPermissionPolicy = &apisv1alpha1.MaximalPermissionPolicy{}
+		if in.Spec.MaximalPermissionPolicy.Local != nil {
+			out.Spec.MaximalPermissionPolicy.Local = &apisv1alpha1.LocalAPIExportPolicy{}
+		}
+	}
+	out.Spec.PermissionClaims = nil
+	for _, claim := range in.Spec.PermissionClaims {
+		out.Spec.PermissionClaims = append(out.Spec.PermissionClaims, convertPermissionClaimToV1alpha1(claim))
+	}
+	out.Spec.StorageMigration = nil
+	if in.Spec.StorageMigration != nil {
+		out.Spec.StorageMigration = &apisv1alpha1.StorageMigration{Strategy: apisv1alpha1.StorageMigrationStrategyType(in.Spec.StorageMigration.Strategy)}
+	}
+
+	out.Status.IdentityHash = in.Status.IdentityHash
+	out.Status.Conditions = in.Status.Conditions.DeepCopy()
+
+	out.Status.VirtualWorkspaces = nil
+	if value, found := in.Annotations[VirtualWorkspacesAnnotationKey]; found {
+		deleteAnnotation(&out.ObjectMeta, VirtualWorkspacesAnnotationKey)
+		if err := json.Unmarshal([]byte(value), &out.Status.VirtualWorkspaces); err != nil {
+			return fmt.Errorf("failed to decode annotation %s: %w", VirtualWorkspacesAnnotationKey, err)
+		}
+	}
+
+	return nil
+}
+
+func Convert_v1alpha1_APIExportList_To_v1alpha2_APIExportList(in *apisv1alpha1.APIExportList, out *APIExportList, s conversion.Scope) error {
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	out.Items = make([]APIExport, len(in.Items))
+	for i := range in.Items {
+		if err := Convert_v1alpha1_APIExport_To_v1alpha2_APIExport(&in.Items[i], &out.Items[i], s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Convert_v1alpha2_APIExportList_To_v1alpha1_APIExportList(in *APIExportList, out *apisv1alpha1.APIExportList, s conversion.Scope) error {
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	out.Items = make([]apisv1alpha1.APIExport, len(in.Items))
+	for i := range in.Items {
+		if err := Convert_v1alpha2_APIExport_To_v1alpha1_APIExport(&in.Items[i], &out.Items[i], s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Convert_v1alpha1_APIBinding_To_v1alpha2_APIBinding(in *apisv1alpha1.APIBinding, out *APIBinding, s conversion.Scope) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	out.Spec.Reference = BindingReference{}
+	if in.Spec.Reference.Export != nil {
+		out.Spec.Reference.Export = &ExportBindingReference{
+			Path: in.Spec.Reference.Export.Path,
+			Name: in.Spec.Reference.Export.Name,
+		}
+	}
+	out.Spec.PermissionClaims = nil
+	for _, claim := range in.Spec.PermissionClaims {
+		out.Spec.PermissionClaims = append(out.Spec.PermissionClaims, AcceptablePermissionClaim{
+			PermissionClaim: convertPermissionClaimToV1alpha2(claim.PermissionClaim),
+			State:           AcceptablePermissionClaimState(claim.State),
+		})
+	}
+
+	out.Status.APIExportClusterName = in.Status.APIExportClusterName
+	out.Status.BoundResources = nil
+	for _, resource := range in.Status.BoundResources {
+		out.Status.BoundResources = append(out.Status.BoundResources, BoundAPIResource{
+			Group:    resource.Group,
+			Resource: resource.Resource,
+			Schema: BoundAPIResourceSchema{
+				Name:         resource.Schema.Name,
+				UID:          resource.Schema.UID,
+				IdentityHash: resource.Schema.IdentityHash,
+			},
+			StorageVersions: append([]string(nil), resource.StorageVersions...),
+		})
+	}
+	out.Status.Phase = APIBindingPhaseType(in.Status.Phase)
+	out.Status.Conditions = in.Status.Conditions.DeepCopy()
+	out.Status.AppliedPermissionClaims = nil
+	for _, claim := range in.Status.AppliedPermissionClaims {
+		out.Status.AppliedPermissionClaims = append(out.Status.AppliedPermissionClaims, convertPermissionClaimToV1alpha2(claim))
+	}
+	out.Status.ExportPermissionClaims = nil
+	for _, claim := range in.Status.ExportPermissionClaims {
+		out.Status.ExportPermissionClaims = append(out.Status.ExportPermissionClaims, convertPermissionClaimToV1alpha2(claim))
+	}
+
+	return nil
+}
+
+func Convert_v1alpha2_APIBinding_To_v1alpha1_APIBinding(in *APIBinding, out *apisv1alpha1.APIBinding, s conversion.Scope) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	out.Spec.Reference = apisv1alpha1.BindingReference{}
+	if in.Spec.Reference.Export != nil {
+		out.Spec.Reference.Export = &apisv1alpha1.ExportBindingReference{
+			Path: in.Spec.Reference.Export.Path,
+			Name: in.Spec.Reference.Export.Name,
+		}
+	}
+	out.Spec.PermissionClaims = nil
+	for _, claim := range in.Spec.PermissionClaims {
+		out.Spec.PermissionClaims = append(out.Spec.PermissionClaims, apisv1alpha1.AcceptablePermissionClaim{
+			PermissionClaim: convertPermissionClaimToV1alpha1(claim.PermissionClaim),
+			State:           apisv1alpha1.AcceptablePermissionClaimState(claim.State),
+		})
+	}
+
+	out.Status.APIExportClusterName = in.Status.APIExportClusterName
+	out.Status.BoundResources = nil
+	for _, resource := range in.Status.BoundResources {
+		out.Status.BoundResources = append(out.Status.BoundResources, apisv1alpha1.BoundAPIResource{
+			Group:    resource.Group,
+			Resource: resource.Resource,
+			Schema: apisv1alpha1.BoundAPIResourceSchema{
+				Name:         resource.Schema.Name,
+				UID:          resource.Schema.UID,
+				IdentityHash: resource.Schema.IdentityHash,
+			},
+			StorageVersions: append([]string(nil), resource.StorageVersions...),
+		})
+	}
+	out.Status.Phase = apisv1alpha1.APIBindingPhaseType(in.Status.Phase)
+	out.Status.Conditions = in.Status.Conditions.DeepCopy()
+	out.Status.AppliedPermissionClaims = nil
+	for _, claim := range in.Status.AppliedPermissionClaims {
+		out.Status.AppliedPermissionClaims = append(out.Status.AppliedPermissionClaims, convertPermissionClaimToV1alpha1(claim))
+	}
+	out.Status.ExportPermissionClaims = nil
+	for _, claim := range in.Status.ExportPermissionClaims {
+		out.Status.ExportPermissionClaims = append(out.Status.ExportPermissionClaims, convertPermissionClaimToV1alpha1(claim))
+	}
+
+	return nil
+}
+
+func Convert_v1alpha1_APIBindingList_To_v1alpha2_APIBindingList(in *apisv1alpha1.APIBindingList, out *APIBindingList, s conversion.Scope) error {
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	out.Items = make([]APIBinding, len(in.Items))
+	for i := range in.Items {
+		if err := Convert_v1alpha1_APIBinding_To_v1alpha2_APIBinding(&in.Items[i], &out.Items[i], s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Convert_v1alpha2_APIBindingList_To_v1alpha1_APIBindingList(in *APIBindingList, out *apisv1alpha1.APIBindingList, s conversion.Scope) error {
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	out.Items = make([]apisv1alpha1.APIBinding, len(in.Items))
+	for i := range in.Items {
+		if err := Convert_v1alpha2_APIBinding_To_v1alpha1_APIBinding(&in.Items[i], &out.Items[i], s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertPermissionClaimToV1alpha2(in apisv1alpha1.PermissionClaim) PermissionClaim {
+	out := PermissionClaim{
+		GroupResource: GroupResource{Group: in.Group, Resource: in.Resource},
+		Selector:      PermissionClaimSelector{MatchAll: in.All},
+		IdentityHash:  in.IdentityHash,
+	}
+	for _, selector := range in.ResourceSelector {
+		out.Selector.MatchResources = append(out.Selector.MatchResources, ResourceSelector{Name: selector.Name, Namespace: selector.Namespace})
+	}
+	return out
+}
+
+func convertPermissionClaimToV1alpha1(in PermissionClaim) apisv1alpha1.PermissionClaim {
+	out := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Group: in.Group, Resource: in.Resource},
+		All:           in.Selector.MatchAll,
+		IdentityHash:  in.IdentityHash,
+	}
+	for _, selector := range in.Selector.MatchResources {
+		out.ResourceSelector = append(out.ResourceSelector, apisv1alpha1.ResourceSelector{Name: selector.Name, Namespace: selector.Namespace})
+	}
+	return out
+}
+
+// deleteAnnotation removes a conversion annotation, dropping the annotations
+// altogether if it was the only one.
+func deleteAnnotation(meta *metav1.ObjectMeta, key string) {
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
+
+func schemaNames(resources []ResourceSchema) string {
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.Schema)
+	}
+	return strings.Join(names, ",")
+}
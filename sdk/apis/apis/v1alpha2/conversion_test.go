@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+func TestAPIExportRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		v1alpha1 *apisv1alpha1.APIExport
+		v1alpha2 *APIExport
+	}{
+		"full": {
+			v1alpha1: &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "cowboys", Labels: map[string]string{"a": "b"}},
+				Spec: apisv1alpha1.APIExportSpec{
+					LatestResourceSchemas: []string{"today.cowboys.wildwest.dev", "v1.sheriffs.wildwest.dev"},
+					Identity:              &apisv1alpha1.Identity{SecretRef: &corev1.SecretReference{Namespace: "kcp-system", Name: "cowboys"}},
+					MaximalPermissionPolicy: &apisv1alpha1.MaximalPermissionPolicy{
+						Local: &apisv1alpha1.LocalAPIExportPolicy{},
+					},
+					PermissionClaims: []apisv1alpha1.PermissionClaim{
+						{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+						{
+							GroupResource:    apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "horses"},
+							ResourceSelector: []apisv1alpha1.ResourceSelector{{Namespace: "stable"}},
+							IdentityHash:     "abc",
+						},
+					},
+					StorageMigration: &apisv1alpha1.StorageMigration{Strategy: apisv1alpha1.RewriteStorageMigrationStrategy},
+				},
+				Status: apisv1alpha1.APIExportStatus{
+					IdentityHash: "def",
+					Conditions:   conditionsv1alpha1.Conditions{{Type: apisv1alpha1.APIExportIdentityValid, Status: corev1.ConditionTrue}},
+				},
+			},
+			v1alpha2: &APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "cowboys", Labels: map[string]string{"a": "b"}},
+				Spec: APIExportSpec{
+					Resources: []ResourceSchema{
+						{Name: "cowboys", Group: "wildwest.dev", Schema: "today.cowboys.wildwest.dev"},
+						{Name: "sheriffs", Group: "wildwest.dev", Schema: "v1.sheriffs.wildwest.dev"},
+					},
+					Identity: &Identity{SecretRef: &corev1.SecretReference{Namespace: "kcp-system", Name: "cowboys"}},
+					MaximalPermissionPolicy: &MaximalPermissionPolicy{
+						Local: &LocalAPIExportPolicy{},
+					},
+					PermissionClaims: []PermissionClaim{
+						{GroupResource: GroupResource{Resource: "configmaps"}, Selector: PermissionClaimSelector{MatchAll: true}},
+						{
+							GroupResource: GroupResource{Group: "wildwest.dev", Resource: "horses"},
+							Selector:      PermissionClaimSelector{MatchResources: []ResourceSelector{{Namespace: "stable"}}},
+							IdentityHash:  "abc",
+						},
+					},
+					StorageMigration: &StorageMigration{Strategy: RewriteStorageMigrationStrategy},
+				},
+				Status: APIExportStatus{
+					IdentityHash: "def",
+					Conditions:   conditionsv1alpha1.Conditions{{Type: apisv1alpha1.APIExportIdentityValid, Status: corev1.ConditionTrue}},
+				},
+			},
+		},
+		"schema names not derivable": {
+			v1alpha1: &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cowboys",
+					Annotations: map[string]string{ResourceSchemasAnnotationKey: `[{"name":"cowboys","group":"wildwest.dev","schema":"cowboys"}]`},
+				},
+				Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"cowboys"}},
+			},
+			v1alpha2: &APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "cowboys"},
+				Spec: APIExportSpec{
+					Resources: []ResourceSchema{{Name: "cowboys", Group: "wildwest.dev", Schema: "cowboys"}},
+				},
+			},
+		},
+		"virtual workspaces": {
+			v1alpha1: &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "cowboys"},
+				Status: apisv1alpha1.APIExportStatus{
+					VirtualWorkspaces: []apisv1alpha1.VirtualWorkspace{{URL: "https://shard/services/apiexport/root/cowboys"}},
+				},
+			},
+			v1alpha2: &APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cowboys",
+					Annotations: map[string]string{VirtualWorkspacesAnnotationKey: `[{"url":"https://shard/services/apiexport/root/cowboys"}]`},
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v1alpha2 := &APIExport{}
+			err := Convert_v1alpha1_APIExport_To_v1alpha2_APIExport(tt.v1alpha1, v1alpha2, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.v1alpha2, v1alpha2)
+
+			v1alpha1 := &apisv1alpha1.APIExport{}
+			err = Convert_v1alpha2_APIExport_To_v1alpha1_APIExport(v1alpha2, v1alpha1, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.v1alpha1, v1alpha1)
+		})
+	}
+}
+
+func TestAPIExportStaleResourceSchemasAnnotation(t *testing.T) {
+	in := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cowboys",
+			Annotations: map[string]string{ResourceSchemasAnnotationKey: `[{"name":"cowboys","group":"wildwest.dev","schema":"cowboys"}]`},
+		},
+		Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"today.sheriffs.wildwest.dev"}},
+	}
+
+	out := &APIExport{}
+	err := Convert_v1alpha1_APIExport_To_v1alpha2_APIExport(in, out, nil)
+	require.NoError(t, err)
+	require.Empty(t, out.Annotations)
+	require.Equal(t, []ResourceSchema{{Name: "sheriffs", Group: "wildwest.dev", Schema: "today.sheriffs.wildwest.dev"}}, out.Spec.Resources)
+}
+
+func TestAPIBindingRoundTrip(t *testing.T) {
+	in := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "cowboys", Annotations: map[string]string{"a": "b"}},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{Path: "root:org", Name: "cowboys"},
+			},
+			PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+				{
+					PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+					State:           apisv1alpha1.ClaimAccepted,
+				},
+				{
+					PermissionClaim: apisv1alpha1.PermissionClaim{
+						GroupResource:    apisv1alpha1.GroupResource{Resource: "secrets"},
+						ResourceSelector: []apisv1alpha1.ResourceSelector{{Name: "creds", Namespace: "default"}},
+					},
+					State: apisv1alpha1.ClaimRejected,
+				},
+			},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			APIExportClusterName: "1234",
+			BoundResources: []apisv1alpha1.BoundAPIResource{{
+				Group:           "wildwest.dev",
+				Resource:        "cowboys",
+				Schema:          apisv1alpha1.BoundAPIResourceSchema{Name: "today.cowboys.wildwest.dev", UID: "uid", IdentityHash: "abc"},
+				StorageVersions: []string{"v1"},
+			}},
+			Phase:                   apisv1alpha1.APIBindingPhaseBound,
+			Conditions:              conditionsv1alpha1.Conditions{{Type: apisv1alpha1.APIExportValid, Status: corev1.ConditionTrue}},
+			AppliedPermissionClaims: []apisv1alpha1.PermissionClaim{{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}},
+			ExportPermissionClaims:  []apisv1alpha1.PermissionClaim{{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}},
+		},
+	}
+
+	v1alpha2 := &APIBinding{}
+	err := Convert_v1alpha1_APIBinding_To_v1alpha2_APIBinding(in, v1alpha2, nil)
+	require.NoError(t, err)
+	require.Equal(t, PermissionClaimSelector{MatchResources: []ResourceSelector{{Name: "creds", Namespace: "default"}}}, v1alpha2.Spec.PermissionClaims[1].Selector)
+	require.Equal(t, ClaimRejected, v1alpha2.Spec.PermissionClaims[1].State)
+
+	out := &apisv1alpha1.APIBinding{}
+	err = Convert_v1alpha2_APIBinding_To_v1alpha1_APIBinding(v1alpha2, out, nil)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha2 contains the v1alpha2 API of APIExport and APIBinding. v1alpha1 stays
+// the storage version, objects are converted from and to it with the functions in conversion.go.
+//
+// +k8s:deepcopy-gen=package,register
+// +groupName=apis.kcp.io
+// +k8s:openapi-gen=true
+package v1alpha2
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apitest "github.com/kcp-dev/kcp/sdk/apis/test"
+)
+
+func TestPermissionClaimSelectorCELValidation(t *testing.T) {
+	testCases := []struct {
+		name         string
+		current, old map[string]interface{}
+		wantErrs     []string
+	}{
+		{
+			name:    "nothing is set",
+			current: map[string]interface{}{},
+			wantErrs: []string{
+				"openAPIV3Schema.properties.spec.properties.permissionClaims.items.properties.selector: Invalid value: \"object\": either \"matchAll\" or \"matchResources\" must be set",
+			},
+		},
+		{
+			name: "matchAll is true",
+			current: map[string]interface{}{
+				"matchAll": true,
+			},
+		},
+		{
+			name: "matchAll is true and matchResources is set",
+			current: map[string]interface{}{
+				"matchAll": true,
+				"matchResources": []interface{}{
+					map[string]interface{}{"namespace": "foo"},
+				},
+			},
+			wantErrs: []string{
+				"openAPIV3Schema.properties.spec.properties.permissionClaims.items.properties.selector: Invalid value: \"object\": either \"matchAll\" or \"matchResources\" must be set",
+			},
+		},
+		{
+			name: "matchResources is set",
+			current: map[string]interface{}{
+				"matchResources": []interface{}{
+					map[string]interface{}{"namespace": "foo"},
+				},
+			},
+		},
+		{
+			name: "matchAll is false and matchResources is empty",
+			current: map[string]interface{}{
+				"matchAll":       false,
+				"matchResources": []interface{}{},
+			},
+			wantErrs: []string{
+				"openAPIV3Schema.properties.spec.properties.permissionClaims.items.properties.selector: Invalid value: \"object\": either \"matchAll\" or \"matchResources\" must be set",
+			},
+		},
+	}
+
+	validators := apitest.FieldValidatorsFromFile(t, "../../../../config/crds/apis.kcp.io_apiexports.yaml")
+
+	for _, tc := range testCases {
+		pth := "openAPIV3Schema.properties.spec.properties.permissionClaims.items.properties.selector"
+		validator, found := validators["v1alpha2"][pth]
+		require.True(t, found, "failed to find validator for %s", pth)
+
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validator(tc.current, tc.old)
+			t.Log(errs)
+
+			if got := len(errs); got != len(tc.wantErrs) {
+				t.Errorf("expected errors %v, got %v", len(tc.wantErrs), len(errs))
+				return
+			}
+
+			for i := range tc.wantErrs {
+				got := errs[i].Error()
+				if got != tc.wantErrs[i] {
+					t.Errorf("want error %q, got %q", tc.wantErrs[i], got)
+				}
+			}
+		})
+	}
+}
@@ -59,13 +59,16 @@ const (
 
 // LogicalClusterPhaseType is the type of the current phase of the logical cluster.
 //
-// +kubebuilder:validation:Enum=Scheduling;Initializing;Ready
+// +kubebuilder:validation:Enum=Scheduling;Initializing;Ready;Unavailable
 type LogicalClusterPhaseType string
 
 const (
 	LogicalClusterPhaseScheduling   LogicalClusterPhaseType = "Scheduling"
 	LogicalClusterPhaseInitializing LogicalClusterPhaseType = "Initializing"
 	LogicalClusterPhaseReady        LogicalClusterPhaseType = "Ready"
+	// LogicalClusterPhaseUnavailable phase is used to indicate that the logical cluster is unavailable to be used.
+	// It is currently only set on mounted workspaces whose mount is not ready.
+	LogicalClusterPhaseUnavailable LogicalClusterPhaseType = "Unavailable"
 )
 
 // LogicalClusterInitializer is a unique string corresponding to a logical cluster
@@ -74,6 +77,12 @@ const (
 // +kubebuilder:validation:Pattern:="^([a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*(:[a-z0-9][a-z0-9]([-a-z0-9]*[a-z0-9])?))|(system:.+)$"
 type LogicalClusterInitializer string
 
+// LogicalClusterTerminator is a unique string corresponding to a logical cluster
+// termination controller.
+//
+// +kubebuilder:validation:Pattern:="^([a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*(:[a-z0-9][a-z0-9]([-a-z0-9]*[a-z0-9])?))|(system:.+)$"
+type LogicalClusterTerminator string
+
 // LogicalClusterSpec is the specification of the LogicalCluster resource.
 type LogicalClusterSpec struct {
 	// DirectlyDeletable indicates that this logical cluster can be directly deleted by the user
@@ -98,6 +107,12 @@ type LogicalClusterSpec struct {
 	//
 	// +optional
 	Initializers []LogicalClusterInitializer `json:"initializers,omitempty"`
+
+	// terminators are set on creation by the system and copied to status when
+	// initialization starts.
+	//
+	// +optional
+	Terminators []LogicalClusterTerminator `json:"terminators,omitempty"`
 }
 
 // LogicalClusterOwner is a reference to a resource controlling the life-cycle of a LogicalCluster.
@@ -166,6 +181,53 @@ type LogicalClusterStatus struct {
 	//
 	// +optional
 	Initializers []LogicalClusterInitializer `json:"initializers,omitempty"`
+
+	// terminators must be cleared by a controller before the logical cluster is deleted.
+	// They can only be removed once the deletion of the logical cluster has started, and
+	// the content of the logical cluster is not deleted before all terminators are cleared.
+	//
+	// +optional
+	Terminators []LogicalClusterTerminator `json:"terminators,omitempty"`
+
+	// usage reports the number and the size of the objects stored in the logical cluster,
+	// e.g. for chargeback. It is only set if the shard is configured to report it.
+	//
+	// +optional
+	Usage *LogicalClusterUsage `json:"usage,omitempty"`
+}
+
+// LogicalClusterUsage reports the objects stored in a logical cluster.
+type LogicalClusterUsage struct {
+	// resources lists the stored objects per resource and storage version.
+	//
+	// +optional
+	Resources []ResourceUsage `json:"resources,omitempty"`
+
+	// lastUpdateTime is the time the usage was last updated.
+	LastUpdateTime v1.Time `json:"lastUpdateTime"`
+}
+
+// ResourceUsage reports the objects of a resource stored in one version.
+type ResourceUsage struct {
+	// group is the API group of the resource. It is empty for the core group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// resource is the name of the resource.
+	Resource string `json:"resource"`
+
+	// version is the version the objects are stored in. It is empty if unknown,
+	// e.g. for objects encrypted at rest.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// objects is the number of stored objects.
+	Objects int64 `json:"objects"`
+
+	// sizeBytes is the size of the stored objects in bytes.
+	SizeBytes int64 `json:"sizeBytes"`
 }
 
 func (in *LogicalCluster) SetConditions(c conditionsv1alpha1.Conditions) {
@@ -49,6 +49,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&LogicalClusterList{},
 		&Shard{},
 		&ShardList{},
+		&ShardBackup{},
+		&ShardBackupList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -38,6 +38,8 @@ var RootShard = "root"
 // +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.metadata.labels['region']`,description="The region this workspace is in"
 // +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.baseURL`,description="Type URL to directly connect to the shard"
 // +kubebuilder:printcolumn:name="External URL",type=string,JSONPath=`.spec.externalURL`,description="The URL exposed in logical clusters created on that shard"
+// +kubebuilder:printcolumn:name="Cordoned",type=boolean,JSONPath=`.spec.cordoned`,description="Whether new logical clusters are not scheduled to the shard",priority=1
+// +kubebuilder:printcolumn:name="Drain",type=boolean,JSONPath=`.spec.drain`,description="Whether logical clusters are migrated away from the shard",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Shard struct {
 	v1.TypeMeta `json:",inline"`
@@ -102,8 +104,31 @@ type ShardSpec struct {
 	// +kubebuilder:validation:Format=uri
 	// +kubebuilder:validation:MinLength=1
 	VirtualWorkspaceURL string `json:"virtualWorkspaceURL,omitempty"`
+
+	// cordoned marks the shard as unschedulable, i.e. no new logical clusters are scheduled
+	// to it, and it is not chosen as a target of migrations. Existing logical clusters are
+	// not affected.
+	//
+	// +optional
+	Cordoned bool `json:"cordoned,omitempty"`
+
+	// drain migrates all logical clusters of workspaces away from the shard, to other shards
+	// matching the location selectors of the workspaces. A draining shard is cordoned.
+	// The progress is reported in status.drain and the Drained condition.
+	//
+	// +optional
+	Drain bool `json:"drain,omitempty"`
+}
+
+// IsSchedulable returns true if new logical clusters can be scheduled to the shard.
+func (in *Shard) IsSchedulable() bool {
+	return !in.Spec.Cordoned && !in.Spec.Drain
 }
 
+// ShardCapacityLogicalClusters is the capacity of a shard in number of logical clusters,
+// weighting the shard in the CapacityWeighted placement strategy of workspaces.
+const ShardCapacityLogicalClusters corev1.ResourceName = "logicalclusters"
+
 // ShardStatus communicates the observed state of the Shard.
 type ShardStatus struct {
 	// Set of integer resources that logical clusters can be scheduled into
@@ -113,8 +138,71 @@ type ShardStatus struct {
 	// Current processing state of the Shard.
 	// +optional
 	Conditions v1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// drain reports the progress of draining the shard. It is only set while spec.drain is true.
+	//
+	// +optional
+	Drain *ShardDrainStatus `json:"drain,omitempty"`
+
+	// usage reports the load of the shard. It is updated periodically by the shard itself.
+	// Workspace scheduling does not place new logical clusters onto shards whose usage
+	// exceeds the thresholds configured on the scheduling shard.
+	//
+	// +optional
+	Usage *ShardUsage `json:"usage,omitempty"`
 }
 
+// ShardUsage reports the load of a shard.
+type ShardUsage struct {
+	// logicalClusters is the number of logical clusters on the shard.
+	LogicalClusters int64 `json:"logicalClusters"`
+
+	// storageSizeBytes is the size of the storage database of the shard, e.g. of etcd,
+	// if known.
+	//
+	// +optional
+	StorageSizeBytes *int64 `json:"storageSizeBytes,omitempty"`
+
+	// qpsHeadroom is the number of requests per second the shard can serve in addition to
+	// its current load, if the shard is configured with its maximum QPS.
+	//
+	// +optional
+	QPSHeadroom *int64 `json:"qpsHeadroom,omitempty"`
+
+	// lastUpdateTime is the time the usage was last updated.
+	LastUpdateTime v1.Time `json:"lastUpdateTime"`
+}
+
+// ShardDrainStatus reports the progress of draining a shard.
+type ShardDrainStatus struct {
+	// remainingLogicalClusters is the number of logical clusters of workspaces still on the shard.
+	RemainingLogicalClusters int64 `json:"remainingLogicalClusters"`
+}
+
+const (
+	// ShardDrained represents status of draining a shard. It is true when no logical cluster
+	// of a workspace is left on the shard, and only set while spec.drain is true.
+	ShardDrained v1alpha1.ConditionType = "Drained"
+
+	// ShardDrainingReason is the reason for the ShardDrained condition while logical clusters
+	// are migrated away.
+	ShardDrainingReason = "Draining"
+)
+
+// ShardLeaseNamespace is the namespace in the root workspace holding the coordination.k8s.io
+// Lease of every shard. The Lease is named like the shard and renewed by the shard as its
+// heartbeat. The Ready condition of a Shard is false when its Lease expired.
+const ShardLeaseNamespace = "kcp-shard-lease"
+
+const (
+	// ShardLeaseExpiredReason is the reason for the Ready condition of a shard whose Lease
+	// was not renewed within its lease duration.
+	ShardLeaseExpiredReason = "LeaseExpired"
+
+	// ShardLeaseNotFoundReason is the reason for the Ready condition of a shard without a Lease.
+	ShardLeaseNotFoundReason = "LeaseNotFound"
+)
+
 // ShardList is a list of shard instances
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// ShardBackup requests an etcd snapshot of a shard, uploaded to the object storage configured
+// on that shard. ShardBackups live in the root workspace next to the Shards.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Shard",type=string,JSONPath=`.spec.shard`,description="The shard to back up"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The phase of the backup"
+// +kubebuilder:printcolumn:name="Location",type=string,JSONPath=`.status.location`,description="Where the snapshot was uploaded to",priority=1
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.status.sizeBytes`,description="The size of the snapshot in bytes",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ShardBackup struct {
+	v1.TypeMeta `json:",inline"`
+	// +optional
+	v1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Spec ShardBackupSpec `json:"spec"`
+
+	// +optional
+	Status ShardBackupStatus `json:"status,omitempty"`
+}
+
+func (in *ShardBackup) SetConditions(c v1alpha1.Conditions) {
+	in.Status.Conditions = c
+}
+
+func (in *ShardBackup) GetConditions() v1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+var _ conditions.Getter = &ShardBackup{}
+var _ conditions.Setter = &ShardBackup{}
+
+// ShardBackupSpec holds the desired state of the ShardBackup.
+type ShardBackupSpec struct {
+	// shard is the name of the Shard to back up.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="shard is immutable"
+	Shard string `json:"shard"`
+}
+
+// ShardBackupPhaseType is the phase of a ShardBackup.
+//
+// +kubebuilder:validation:Enum=Running;Completed;Failed
+type ShardBackupPhaseType string
+
+const (
+	// ShardBackupPhaseRunning means the snapshot is being taken or uploaded.
+	ShardBackupPhaseRunning ShardBackupPhaseType = "Running"
+	// ShardBackupPhaseCompleted means the snapshot was uploaded to status.location.
+	ShardBackupPhaseCompleted ShardBackupPhaseType = "Completed"
+	// ShardBackupPhaseFailed means the backup failed and will not be retried.
+	ShardBackupPhaseFailed ShardBackupPhaseType = "Failed"
+)
+
+// ShardBackupStatus reports a backup taken by the shard.
+type ShardBackupStatus struct {
+	// phase is the current phase of the backup. The backup is finished when it is Completed
+	// or Failed.
+	//
+	// +optional
+	Phase ShardBackupPhaseType `json:"phase,omitempty"`
+
+	// location is where the snapshot was uploaded to, e.g. s3://bucket/prefix/name.db.
+	//
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// sizeBytes is the size of the snapshot.
+	//
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// startTime is when the shard started taking the snapshot.
+	//
+	// +optional
+	StartTime *v1.Time `json:"startTime,omitempty"`
+
+	// completionTime is when the backup completed or failed.
+	//
+	// +optional
+	CompletionTime *v1.Time `json:"completionTime,omitempty"`
+
+	// Current processing state of the ShardBackup.
+	// +optional
+	Conditions v1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+const (
+	// ShardBackupSucceeded is true when the snapshot was uploaded, and false with a reason when
+	// the backup failed.
+	ShardBackupSucceeded v1alpha1.ConditionType = "Succeeded"
+
+	// ShardBackupSnapshotFailedReason is the reason for the ShardBackupSucceeded condition when
+	// the etcd snapshot could not be taken.
+	ShardBackupSnapshotFailedReason = "SnapshotFailed"
+	// ShardBackupUploadFailedReason is the reason for the ShardBackupSucceeded condition when
+	// the snapshot could not be uploaded to the object storage.
+	ShardBackupUploadFailedReason = "UploadFailed"
+	// ShardBackupStorageNotConfiguredReason is the reason for the ShardBackupSucceeded condition
+	// when the shard has no object storage for backups.
+	ShardBackupStorageNotConfiguredReason = "StorageNotConfigured"
+)
+
+// ShardBackupList is a list of ShardBackup resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ShardBackupList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata"`
+
+	Items []ShardBackup `json:"items"`
+}
@@ -118,6 +118,11 @@ func (in *LogicalClusterSpec) DeepCopyInto(out *LogicalClusterSpec) {
 		*out = make([]LogicalClusterInitializer, len(*in))
 		copy(*out, *in)
 	}
+	if in.Terminators != nil {
+		in, out := &in.Terminators, &out.Terminators
+		*out = make([]LogicalClusterTerminator, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -146,6 +151,16 @@ func (in *LogicalClusterStatus) DeepCopyInto(out *LogicalClusterStatus) {
 		*out = make([]LogicalClusterInitializer, len(*in))
 		copy(*out, *in)
 	}
+	if in.Terminators != nil {
+		in, out := &in.Terminators, &out.Terminators
+		*out = make([]LogicalClusterTerminator, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(LogicalClusterUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -159,6 +174,44 @@ func (in *LogicalClusterStatus) DeepCopy() *LogicalClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalClusterUsage) DeepCopyInto(out *LogicalClusterUsage) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceUsage, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalClusterUsage.
+func (in *LogicalClusterUsage) DeepCopy() *LogicalClusterUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalClusterUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsage) DeepCopyInto(out *ResourceUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsage.
+func (in *ResourceUsage) DeepCopy() *ResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Shard) DeepCopyInto(out *Shard) {
 	*out = *in
@@ -187,6 +240,130 @@ func (in *Shard) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardBackup) DeepCopyInto(out *ShardBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardBackup.
+func (in *ShardBackup) DeepCopy() *ShardBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardBackupList) DeepCopyInto(out *ShardBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShardBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardBackupList.
+func (in *ShardBackupList) DeepCopy() *ShardBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardBackupSpec) DeepCopyInto(out *ShardBackupSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardBackupSpec.
+func (in *ShardBackupSpec) DeepCopy() *ShardBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardBackupStatus) DeepCopyInto(out *ShardBackupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(conditionsv1alpha1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardBackupStatus.
+func (in *ShardBackupStatus) DeepCopy() *ShardBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardDrainStatus) DeepCopyInto(out *ShardDrainStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardDrainStatus.
+func (in *ShardDrainStatus) DeepCopy() *ShardDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardList) DeepCopyInto(out *ShardList) {
 	*out = *in
@@ -253,6 +430,16 @@ func (in *ShardStatus) DeepCopyInto(out *ShardStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(ShardDrainStatus)
+		**out = **in
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ShardUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -265,3 +452,30 @@ func (in *ShardStatus) DeepCopy() *ShardStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardUsage) DeepCopyInto(out *ShardUsage) {
+	*out = *in
+	if in.StorageSizeBytes != nil {
+		in, out := &in.StorageSizeBytes, &out.StorageSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.QPSHeadroom != nil {
+		in, out := &in.QPSHeadroom, &out.QPSHeadroom
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardUsage.
+func (in *ShardUsage) DeepCopy() *ShardUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardUsage)
+	in.DeepCopyInto(out)
+	return out
+}
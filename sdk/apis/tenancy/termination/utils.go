@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package termination
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TerminatorPresent(terminator corev1alpha1.LogicalClusterTerminator, terminators []corev1alpha1.LogicalClusterTerminator) bool {
+	for i := range terminators {
+		if terminators[i] == terminator {
+			return true
+		}
+	}
+	return false
+}
+
+func EnsureTerminatorAbsent(terminator corev1alpha1.LogicalClusterTerminator, terminators []corev1alpha1.LogicalClusterTerminator) []corev1alpha1.LogicalClusterTerminator {
+	removeAt := -1
+	for i := range terminators {
+		if terminators[i] == terminator {
+			removeAt = i
+			break
+		}
+	}
+	if removeAt != -1 {
+		terminators = append(terminators[:removeAt], terminators[removeAt+1:]...)
+	}
+	return terminators
+}
+
+func TerminatorForType(wt *tenancyv1alpha1.WorkspaceType) corev1alpha1.LogicalClusterTerminator {
+	return corev1alpha1.LogicalClusterTerminator(logicalcluster.From(wt).Path().Join(wt.Name).String())
+}
+
+func TypeFrom(terminator corev1alpha1.LogicalClusterTerminator) (logicalcluster.Name, string, error) {
+	separatorIndex := strings.LastIndex(string(terminator), ":")
+	switch separatorIndex {
+	case -1:
+		return "", "", fmt.Errorf("expected workspace terminator in form workspace:name, not %q", terminator)
+	default:
+		return logicalcluster.Name(terminator[:separatorIndex]), tenancyv1alpha1.ObjectName(tenancyv1alpha1.WorkspaceTypeName(terminator[separatorIndex+1:])), nil
+	}
+}
+
+func TerminatorToLabel(terminator corev1alpha1.LogicalClusterTerminator) (string, string) {
+	hash := fmt.Sprintf("%x", sha256.Sum224([]byte(terminator)))
+	labelKeyHashLength := validation.LabelValueMaxLength - len(tenancyv1alpha1.WorkspaceTerminatorLabelPrefix)
+	return tenancyv1alpha1.WorkspaceTerminatorLabelPrefix + hash[0:labelKeyHashLength], hash
+}
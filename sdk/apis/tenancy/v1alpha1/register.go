@@ -49,6 +49,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&WorkspaceList{},
 		&WorkspaceType{},
 		&WorkspaceTypeList{},
+		&WorkspaceQuota{},
+		&WorkspaceQuotaList{},
+		&AuditSink{},
+		&AuditSinkList{},
+		&ReferenceGrant{},
+		&ReferenceGrantList{},
+		&WorkspaceRoleBinding{},
+		&WorkspaceRoleBindingList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// AuditPolicy is an audit policy of a workspace. It follows the audit.k8s.io/v1 Policy
+// format, restricted to the fields that make sense for a single workspace.
+type AuditPolicy struct {
+	// rules are evaluated in order. The first rule matching a request sets its audit level.
+	// Requests not matching any rule are not logged to the audit log of the workspace.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Rules []AuditPolicyRule `json:"rules"`
+}
+
+// AuditLevel defines the amount of information logged during auditing.
+//
+// +kubebuilder:validation:Enum=None;Metadata;Request;RequestResponse
+type AuditLevel string
+
+const (
+	// AuditLevelNone disables auditing.
+	AuditLevelNone AuditLevel = "None"
+	// AuditLevelMetadata provides the basic level of auditing.
+	AuditLevelMetadata AuditLevel = "Metadata"
+	// AuditLevelRequest provides Metadata level of auditing, and additionally
+	// logs the request object (does not apply for non-resource requests).
+	AuditLevelRequest AuditLevel = "Request"
+	// AuditLevelRequestResponse provides Request level of auditing, and additionally
+	// logs the response object (does not apply for non-resource requests).
+	AuditLevelRequestResponse AuditLevel = "RequestResponse"
+)
+
+// AuditPolicyRule maps requests based off metadata to an audit level.
+// Requests must match the rules of every field (an intersection of rules).
+type AuditPolicyRule struct {
+	// level that requests matching this rule are recorded at.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Level AuditLevel `json:"level"`
+
+	// users (by authenticated user name) this rule applies to.
+	// An empty list implies every user.
+	//
+	// +optional
+	// +listType=atomic
+	Users []string `json:"users,omitempty"`
+
+	// userGroups this rule applies to. A user is considered matching
+	// if it is a member of any of the userGroups.
+	// An empty list implies every user group.
+	//
+	// +optional
+	// +listType=atomic
+	UserGroups []string `json:"userGroups,omitempty"`
+
+	// verbs included in this rule, e.g. create, update or delete.
+	// An empty list implies every verb.
+	//
+	// +optional
+	// +listType=atomic
+	Verbs []string `json:"verbs,omitempty"`
+
+	// resources this rule matches. An empty list implies all kinds in all API groups.
+	//
+	// +optional
+	// +listType=atomic
+	Resources []AuditGroupResources `json:"resources,omitempty"`
+
+	// namespaces this rule matches.
+	// The empty string "" matches non-namespaced resources.
+	// An empty list implies every namespace.
+	//
+	// +optional
+	// +listType=atomic
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// nonResourceURLs is a set of URL paths that should be audited.
+	// "*"s are allowed, but only as the full, final step in the path.
+	//
+	// +optional
+	// +listType=atomic
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// AuditGroupResources represents resource kinds in an API group.
+type AuditGroupResources struct {
+	// group is the name of the API group that contains the resources.
+	// The empty string represents the core API group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// resources is a list of resources this rule applies to, e.g. "pods" or "pods/log".
+	// An empty list implies all resources and subresources in this API group.
+	//
+	// +optional
+	// +listType=atomic
+	Resources []string `json:"resources,omitempty"`
+
+	// resourceNames is a list of resource instance names that the policy matches.
+	// An empty list implies that every instance of the resource is matched.
+	//
+	// +optional
+	// +listType=atomic
+	ResourceNames []string `json:"resourceNames,omitempty"`
+}
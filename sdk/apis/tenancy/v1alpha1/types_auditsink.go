@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditSink routes the audit events of an organization to an external sink, i.e. the events
+// of requests to the top-level organization workspace it is created in and to all workspaces
+// below it. AuditSinks in other workspaces than top-level organizations are ignored.
+//
+// The shards write the events of an organization only to its own sinks, in the
+// audit.k8s.io/v1 format, independently of the audit log configured for kcp itself.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="File",type=string,JSONPath=`.spec.file.path`,description="Path of the audit log file"
+// +kubebuilder:printcolumn:name="Webhook",type=string,JSONPath=`.spec.webhook.url`,description="URL of the audit webhook"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type AuditSink struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec AuditSinkSpec `json:"spec,omitempty"`
+}
+
+// AuditSinkSpec defines the events of the sink and where they are sent to. Exactly one of
+// file and webhook must be set.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.file) != has(self.webhook)",message="exactly one of file or webhook must be set"
+type AuditSinkSpec struct {
+	// policy selects the events recorded in the sink, and at which level. If unset, all
+	// requests are recorded at the Metadata level.
+	//
+	// +optional
+	Policy *AuditPolicy `json:"policy,omitempty"`
+
+	// file writes the events as JSON lines to a file on the shards.
+	//
+	// +optional
+	File *AuditSinkFile `json:"file,omitempty"`
+
+	// webhook sends the events in batches to an HTTPS endpoint.
+	//
+	// +optional
+	Webhook *AuditSinkWebhook `json:"webhook,omitempty"`
+}
+
+// AuditSinkFile is an audit log file on the shards.
+type AuditSinkFile struct {
+	// path of the log file, relative to the organization directory below the audit sink
+	// directory of the shards. It must not leave the organization directory.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.split('/').exists(s, s == '..')",message="path must be relative and must not contain '..'"
+	Path string `json:"path"`
+}
+
+// AuditSinkWebhook is an HTTPS endpoint receiving audit.k8s.io/v1 EventLists.
+type AuditSinkWebhook struct {
+	// url of the endpoint the events are posted to.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// caBundle is a PEM encoded CA bundle to verify the serving certificate of the endpoint.
+	// If unset, the system trust roots are used.
+	//
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// AuditSinkList is a list of audit sinks
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AuditSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AuditSink `json:"items"`
+}
@@ -20,8 +20,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	corev1 "k8s.io/api/core/v1"
-
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
@@ -41,52 +39,98 @@ const (
 	MountPhaseUnknown MountPhaseType = "Unknown"
 )
 
+// These are valid conditions of a mounted workspace.
+const (
+	// MountConditionReady represents the readiness of the mount object referenced by a workspace.
+	MountConditionReady conditionsv1alpha1.ConditionType = "WorkspaceMountReady"
+	// MountObjectNotFoundReason reason in WorkspaceMountReady condition means that the referenced
+	// mount object does not exist.
+	MountObjectNotFoundReason = "MountObjectNotFound"
+	// MountObjectNotReadyReason reason in WorkspaceMountReady condition means that the referenced
+	// mount object is not ready yet, or does not expose a URL.
+	MountObjectNotReadyReason = "MountObjectNotReady"
+)
+
 // Mount is a workspace mount that can be used to mount a workspace into another workspace or resource.
 // Mounting itself is done at front proxy level.
 type Mount struct {
-	// MountSpec is the spec of the mount.
-	MountSpec MountSpec `json:"spec,omitempty"`
-	// MountStatus is the status of the mount.
-	MountStatus MountStatus `json:"status,omitempty"`
+	// ref is a reference to the object implementing the mount. The object must expose
+	// status.phase (Initializing, Connecting, Ready, Unknown) and status.URL fields, and
+	// is expected to live in the same logical cluster as the workspace.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Reference ObjectReference `json:"ref"`
 }
 
-type MountSpec struct {
-	// Reference is an ObjectReference to the object that is mounted.
-	Reference *corev1.ObjectReference `json:"ref,omitempty"`
+// ObjectReference contains enough information to let you locate the referenced object.
+type ObjectReference struct {
+	// apiVersion is the API group and version of the referenced object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// kind is the kind of the referenced object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// name is the name of the referenced object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// namespace is the namespace of the referenced object, if it is namespaced.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
-// MountStatus is the status of a mount. It is used to indicate the status of a mount,
-// potentially managed outside of the core API.
+// MountStatus is the status of a mount as reported by the mount object referenced by
+// a workspace.
 type MountStatus struct {
-	// Phase of the mount (Initializing, Connecting, Ready, Unknown).
+	// phase of the mount (Initializing, Connecting, Ready, Unknown).
 	//
-	// +kubebuilder:default=Initializing
-	Phase MountPhaseType `json:"phase,omitempty"`
-	// Conditions is a list of conditions and their status.
-	// Current processing state of the Mount.
 	// +optional
-	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+	Phase MountPhaseType `json:"phase,omitempty"`
 
-	// URL is the URL of the mount. Mount is considered mountable when URL is set.
+	// URL is the URL the front proxy sends requests for the workspace to.
+	// Requests are only proxied when the mount is ready.
+	//
 	// +optional
 	URL string `json:"url,omitempty"`
 }
 
+// legacyMountAnnotation is the value of the deprecated ExperimentalWorkspaceMountAnnotationKey
+// annotation. Its status is ignored, it is reported in the workspace status now.
+//
+// +k8s:deepcopy-gen=false
+type legacyMountAnnotation struct {
+	Spec struct {
+		Reference *ObjectReference `json:"ref,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
 // ParseTenancyMountAnnotation parses the value of the annotation into a Mount.
+//
+// Deprecated: use spec.mount. This is only kept to read workspaces mounted via the
+// ExperimentalWorkspaceMountAnnotationKey annotation until the next release.
 func ParseTenancyMountAnnotation(value string) (*Mount, error) {
 	if value == "" {
 		return nil, fmt.Errorf("mount annotation is empty")
 	}
-	var mount Mount
-	err := json.Unmarshal([]byte(value), &mount)
-	return &mount, err
-}
-
-// String returns the string representation of the mount.
-func (m *Mount) String() string {
-	b, err := json.Marshal(m)
-	if err != nil {
-		panic(err) // :'( but will go away once it graduates from annotation to spec
+	var legacy legacyMountAnnotation
+	if err := json.Unmarshal([]byte(value), &legacy); err != nil {
+		return nil, err
+	}
+	if legacy.Spec.Reference == nil {
+		return nil, fmt.Errorf("mount annotation has no spec.ref")
 	}
-	return string(b)
+	return &Mount{Reference: *legacy.Spec.Reference}, nil
 }
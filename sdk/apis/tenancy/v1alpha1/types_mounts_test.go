@@ -21,27 +21,67 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
-	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const legacyAnnotationValue = "{\"spec\":{\"ref\":{\"apiVersion\":\"proxy.faros.sh/v1alpha1\",\"kind\":\"KubeCluster\",\"name\":\"dev-cluster\"}},\"status\":{}}"
+
 func TestParseTenancyMountAnnotation(t *testing.T) {
-	input := "{\"spec\":{\"ref\":{\"apiVersion\":\"proxy.faros.sh/v1alpha1\",\"kind\":\"KubeCluster\",\"name\":\"dev-cluster\"}},\"status\":{}}"
 	expected := &Mount{
-		MountSpec: MountSpec{
-			Reference: &v1.ObjectReference{
-				APIVersion: "proxy.faros.sh/v1alpha1",
-				Kind:       "KubeCluster",
-				Name:       "dev-cluster",
-			},
+		Reference: ObjectReference{
+			APIVersion: "proxy.faros.sh/v1alpha1",
+			Kind:       "KubeCluster",
+			Name:       "dev-cluster",
 		},
-		MountStatus: MountStatus{},
 	}
 
-	v, err := ParseTenancyMountAnnotation(input)
+	v, err := ParseTenancyMountAnnotation(legacyAnnotationValue)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if cmp.Diff(v, expected) != "" {
-		t.Fatalf("unexpected diff: %s", cmp.Diff(v, &expected))
+	if diff := cmp.Diff(expected, v); diff != "" {
+		t.Fatalf("unexpected diff: %s", diff)
+	}
+
+	if _, err := ParseTenancyMountAnnotation("{\"spec\":{}}"); err == nil {
+		t.Fatal("expected an error for an annotation without spec.ref")
+	}
+}
+
+func TestWorkspaceGetMount(t *testing.T) {
+	specMount := &Mount{Reference: ObjectReference{APIVersion: "proxy.kcp.io/v1alpha1", Kind: "Proxy", Name: "spec"}}
+
+	tests := map[string]struct {
+		workspace *Workspace
+		want      *Mount
+	}{
+		"not mounted": {
+			workspace: &Workspace{},
+		},
+		"spec.mount": {
+			workspace: &Workspace{Spec: WorkspaceSpec{Mount: specMount}},
+			want:      specMount,
+		},
+		"deprecated annotation": {
+			workspace: &Workspace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ExperimentalWorkspaceMountAnnotationKey: legacyAnnotationValue}}},
+			want:      &Mount{Reference: ObjectReference{APIVersion: "proxy.faros.sh/v1alpha1", Kind: "KubeCluster", Name: "dev-cluster"}},
+		},
+		"spec.mount wins over the deprecated annotation": {
+			workspace: &Workspace{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ExperimentalWorkspaceMountAnnotationKey: legacyAnnotationValue}},
+				Spec:       WorkspaceSpec{Mount: specMount},
+			},
+			want: specMount,
+		},
+		"invalid annotation": {
+			workspace: &Workspace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ExperimentalWorkspaceMountAnnotationKey: "{"}}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.workspace.GetMount()); diff != "" {
+				t.Fatalf("unexpected diff: %s", diff)
+			}
+		})
 	}
 }
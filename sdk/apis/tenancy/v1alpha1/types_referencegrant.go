@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExperimentalReferencesAnnotationKey is the annotation on any object declaring the objects
+// in other workspaces it references, as a JSON list of WorkspaceObjectReferences. Creating
+// or updating an object with references is only admitted if each referenced workspace has a
+// ReferenceGrant allowing the reference.
+const ExperimentalReferencesAnnotationKey = "experimental.tenancy.kcp.io/references"
+
+// ReferenceGrant allows objects in other workspaces to reference objects in the workspace
+// it is created in. A reference is allowed if one ReferenceGrant matches both the
+// referencing object in its from list and the referenced object in its to list.
+//
+// Grants are checked when the referencing object is created or its references change.
+// Deleting a ReferenceGrant does not affect existing references.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type ReferenceGrant struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ReferenceGrantSpec `json:"spec,omitempty"`
+}
+
+// ReferenceGrantSpec defines which references are allowed.
+type ReferenceGrantSpec struct {
+	// from are the referencing objects, by workspace and resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	From []ReferenceGrantFrom `json:"from"`
+
+	// to are the objects in this workspace that may be referenced.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	To []ReferenceGrantTo `json:"to"`
+}
+
+// ReferenceGrantFrom describes referencing objects.
+type ReferenceGrantFrom struct {
+	// path is the workspace of the referencing objects, e.g. root:org:team.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	Path string `json:"path"`
+
+	GroupResource `json:",inline"`
+}
+
+// ReferenceGrantTo describes referenced objects.
+type ReferenceGrantTo struct {
+	GroupResource `json:",inline"`
+
+	// name restricts the referenced objects to those of the given name.
+	// If empty, all objects of the resource can be referenced.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// WorkspaceObjectReference references an object in another workspace.
+type WorkspaceObjectReference struct {
+	// path is the workspace of the referenced object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	GroupResource `json:",inline"`
+
+	// namespace is the namespace of the referenced object, if it is namespaced.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the referenced object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// ReferenceGrantList is a list of reference grants
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ReferenceGrant `json:"items"`
+}
@@ -23,6 +23,7 @@ import (
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // WorkspaceTypeReference is a globally unique, fully qualified reference to a workspace type.
@@ -56,11 +57,39 @@ const (
 	// ExperimentalWorkspaceOwnerAnnotationKey is the annotation key used to indicate the owner of the workspace.
 	ExperimentalWorkspaceOwnerAnnotationKey string = "experimental.tenancy.kcp.io/owner"
 	// ExperimentalWorkspaceMountAnnotationKey is the annotation key used to indicate the mounts of the workspace.
+	//
+	// Deprecated: use spec.mount. The annotation is still read for workspaces without spec.mount
+	// until the next release.
 	ExperimentalWorkspaceMountAnnotationKey string = "experimental.tenancy.kcp.io/mount"
 	// ExperimentalIsMountAnnotationKey is the annotation key used to indicate that object is a mount.
 	ExperimentalIsMountAnnotationKey string = "experimental.tenancy.kcp.io/is-mount"
 	// ExperimentalMountWorkspaceAnnotationKey is the annotation key used to indicate the owner workspace of the mount.
 	ExperimentalMountWorkspaceAnnotationKey string = "experimental.tenancy.kcp.io/owning-workspace"
+	// ExperimentalWorkspaceRestoreAnnotationKey is the annotation key set to "true" by a system
+	// administrator on a workspace retained after deletion in order to restore it.
+	ExperimentalWorkspaceRestoreAnnotationKey string = "experimental.tenancy.kcp.io/restore"
+	// ExperimentalWorkspaceRestoredFromAnnotationKey is the annotation key set by the system on
+	// a restored workspace. Its value is the UID of the deleted workspace whose logical cluster
+	// is adopted by the restored workspace.
+	ExperimentalWorkspaceRestoredFromAnnotationKey string = "experimental.tenancy.kcp.io/restored-from"
+	// ExperimentalWorkspaceMoveToAnnotationKey is the annotation key set by a system administrator
+	// on a ready workspace in order to move it. Its value is the new canonical path of the workspace,
+	// e.g. "root:org2:team". The new parent workspace must be scheduled on the same shard.
+	ExperimentalWorkspaceMoveToAnnotationKey string = "experimental.tenancy.kcp.io/move-to"
+	// ExperimentalWorkspaceMovedFromAnnotationKey is the annotation key set by the system on
+	// the workspace created by a move. Its value is the UID of the moved workspace whose logical
+	// cluster is adopted.
+	ExperimentalWorkspaceMovedFromAnnotationKey string = "experimental.tenancy.kcp.io/moved-from"
+	// ExperimentalWorkspaceMigrateToShardAnnotationKey is the annotation key set by a system administrator
+	// on a ready workspace in order to migrate its logical cluster with all objects to another shard.
+	// Its value is the name of the target shard.
+	ExperimentalWorkspaceMigrateToShardAnnotationKey string = "experimental.tenancy.kcp.io/migrate-to-shard"
+
+	// WorkspacePropagatedKeyPrefix is the prefix of label and annotation keys of a Workspace that are
+	// propagated to its LogicalCluster, and transitively to all child Workspaces and their LogicalClusters,
+	// e.g. "propagate.tenancy.kcp.io/cost-center". Values inherited from the parent override those set
+	// on the child.
+	WorkspacePropagatedKeyPrefix string = "propagate.tenancy.kcp.io/"
 )
 
 // These are valid conditions of workspace.
@@ -76,6 +105,9 @@ const (
 
 	// WorkspaceContentDeleted represents the status that all resources in the workspace are deleted.
 	WorkspaceContentDeleted conditionsv1alpha1.ConditionType = "WorkspaceContentDeleted"
+	// WorkspaceContentDeletedTerminatorsRemaining reason in WorkspaceContentDeleted condition means that
+	// the content is not deleted yet because at least one terminator is left.
+	WorkspaceContentDeletedTerminatorsRemaining = "TerminatorsRemaining"
 
 	// WorkspaceInitialized represents the status that initialization has finished.
 	WorkspaceInitialized conditionsv1alpha1.ConditionType = "WorkspaceInitialized"
@@ -85,6 +117,9 @@ const (
 	// WorkspaceInitializedWorkspaceDisappeared reason in WorkspaceInitialized condition means that the LogicalCluster
 	// object has disappeared.
 	WorkspaceInitializedWorkspaceDisappeared = "WorkspaceDisappeared"
+	// WorkspaceInitializedInitializerTimedOut reason in WorkspaceInitialized condition means that at least one
+	// initializer was not removed within the initializationTimeout of the WorkspaceType.
+	WorkspaceInitializedInitializerTimedOut = "InitializerTimedOut"
 
 	// WorkspaceAPIBindingsInitialized represents the status of the initial APIBindings for the workspace.
 	WorkspaceAPIBindingsInitialized conditionsv1alpha1.ConditionType = "APIBindingsInitialized"
@@ -98,12 +133,66 @@ const (
 	// WorkspaceInitializedAPIBindingErrors is a reason for the APIBindingsInitialized condition that indicates there
 	// were errors trying to initialize APIBindings for the workspace.
 	WorkspaceInitializedAPIBindingErrors = "APIBindingErrors"
+
+	// WorkspaceTemplatesInitialized represents the status of the objects created from the templates
+	// of the WorkspaceTypes of the workspace.
+	WorkspaceTemplatesInitialized conditionsv1alpha1.ConditionType = "TemplatesInitialized"
+	// WorkspaceInitializedTemplateErrors is a reason for the TemplatesInitialized condition that indicates there
+	// were errors trying to create the objects of the templates.
+	WorkspaceInitializedTemplateErrors = "TemplateErrors"
+
+	// WorkspaceDeletionRetained represents the status of a deleted workspace that is retained
+	// according to the retention policy of its WorkspaceType. It is true while the workspace
+	// can still be restored.
+	WorkspaceDeletionRetained conditionsv1alpha1.ConditionType = "WorkspaceDeletionRetained"
+	// WorkspaceRetentionPeriodExpiredReason reason in WorkspaceDeletionRetained condition means that
+	// the retention period is over and the logical cluster is being purged.
+	WorkspaceRetentionPeriodExpiredReason = "RetentionPeriodExpired"
+
+	// WorkspaceExpiring represents the status of a workspace with a ttl that expires soon.
+	// It is true from the expiry warning until the workspace is deleted.
+	WorkspaceExpiring conditionsv1alpha1.ConditionType = "WorkspaceExpiring"
+	// WorkspaceTTLExpiringReason reason in WorkspaceExpiring condition and events means that
+	// the ttl of the workspace expires soon.
+	WorkspaceTTLExpiringReason = "TTLExpiring"
+	// WorkspaceTTLExpiredReason reason in events means that the ttl of the workspace expired
+	// and the workspace is being deleted.
+	WorkspaceTTLExpiredReason = "TTLExpired"
+
+	// WorkspaceMoving represents the status of a workspace move requested through the
+	// move-to annotation. It is true while the workspace is being moved.
+	WorkspaceMoving conditionsv1alpha1.ConditionType = "WorkspaceMoving"
+	// WorkspaceMoveInvalidTargetReason reason in WorkspaceMoving condition means that the
+	// target path is invalid, or the target parent workspace is not found on this shard.
+	WorkspaceMoveInvalidTargetReason = "InvalidTarget"
+	// WorkspaceMoveNameConflictReason reason in WorkspaceMoving condition means that a
+	// different workspace with the target name already exists.
+	WorkspaceMoveNameConflictReason = "NameConflict"
+	// WorkspaceMoveFailedReason reason in WorkspaceMoving condition means that the
+	// target workspace could not be created.
+	WorkspaceMoveFailedReason = "MoveFailed"
+
+	// WorkspaceMigrating represents the status of a migration to another shard requested through
+	// the migrate-to-shard annotation. It is true while the logical cluster is being migrated, and
+	// the workspace is unavailable.
+	WorkspaceMigrating conditionsv1alpha1.ConditionType = "WorkspaceMigrating"
+	// WorkspaceMigrationInvalidReason reason in WorkspaceMigrating condition means that the
+	// target shard does not exist or that the workspace cannot be migrated.
+	WorkspaceMigrationInvalidReason = "Invalid"
 )
 
+// LogicalClusterRetainedUntilAnnotationKey is the annotation key set on the LogicalCluster of a
+// deleted workspace that is retained. Its value is the RFC3339 time after which the logical cluster is purged.
+const LogicalClusterRetainedUntilAnnotationKey = "internal.tenancy.kcp.io/retained-until"
+
 // LogicalClusterTypeAnnotationKey is the annotation key used to indicate
 // the type of the workspace on the corresponding LogicalCluster object. Its format is "root:ws:name".
 const LogicalClusterTypeAnnotationKey = "internal.tenancy.kcp.io/type"
 
+// LogicalClusterAuditPolicyAnnotationKey is the annotation key set on the LogicalCluster of a
+// workspace with an audit policy. Its value is the JSON encoded audit policy of the workspace.
+const LogicalClusterAuditPolicyAnnotationKey = "internal.tenancy.kcp.io/audit-policy"
+
 // Workspace defines a generic Kubernetes-cluster-like endpoint, with standard Kubernetes
 // discovery APIs, OpenAPI and resource API endpoints.
 //
@@ -138,6 +227,7 @@ type Workspace struct {
 // WorkspaceSpec holds the desired state of the Workspace.
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.URL) || has(self.URL)",message="URL cannot be unset"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.cluster) || has(self.cluster)",message="cluster cannot be unset"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.mount) == has(self.mount)",message="mount cannot be added or removed after creation"
 type WorkspaceSpec struct {
 	// type defines properties of the workspace both on creation (e.g. initial
 	// resources and initially installed APIs) and during runtime (e.g. permissions).
@@ -178,6 +268,31 @@ type WorkspaceSpec struct {
 	//
 	// +kubebuilder:format:uri
 	URL string `json:"URL,omitempty"`
+
+	// mount is a reference to an object implementing the mount of this workspace, e.g.
+	// a proxy to an external Kubernetes cluster. If set, requests to the workspace path
+	// are proxied by the front proxy to the URL published by the mount object, instead
+	// of being served by kcp. The mount is immutable after creation.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="mount is immutable"
+	Mount *Mount `json:"mount,omitempty"`
+
+	// auditPolicy supplements the global audit policy of the server for requests to this
+	// workspace. Matching requests are logged to the audit log of the workspace, if the
+	// server is configured with a workspace audit log directory.
+	//
+	// +optional
+	AuditPolicy *AuditPolicy `json:"auditPolicy,omitempty"`
+
+	// ttl is the time after the creation of the workspace after which it is deleted
+	// automatically, e.g. for ephemeral development or test workspaces. A warning event
+	// is emitted before. The ttl can be changed or removed until the workspace expires.
+	// With a retention policy on the WorkspaceType, the expired workspace is retained
+	// like any other deleted workspace.
+	//
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
 }
 
 type WorkspaceLocation struct {
@@ -190,7 +305,7 @@ type WorkspaceLocation struct {
 
 // WorkspaceStatus communicates the observed state of the Workspace.
 type WorkspaceStatus struct {
-	// Phase of the workspace (Scheduling, Initializing, Ready).
+	// Phase of the workspace (Scheduling, Initializing, Ready, Unavailable).
 	//
 	// +kubebuilder:default=Scheduling
 	Phase corev1alpha1.LogicalClusterPhaseType `json:"phase,omitempty"`
@@ -204,16 +319,48 @@ type WorkspaceStatus struct {
 	//
 	// +optional
 	Initializers []corev1alpha1.LogicalClusterInitializer `json:"initializers,omitempty"`
+
+	// terminators must be cleared by a controller before the workspace is deleted.
+	// They are shown while the workspace is deleting.
+	//
+	// +optional
+	Terminators []corev1alpha1.LogicalClusterTerminator `json:"terminators,omitempty"`
+
+	// mount is the last observed status of the object referenced by spec.mount.
+	//
+	// +optional
+	Mount *MountStatus `json:"mount,omitempty"`
 }
 
 func (in *Workspace) SetConditions(c conditionsv1alpha1.Conditions) {
 	in.Status.Conditions = c
 }
 
+var _ conditions.Getter = &Workspace{}
+var _ conditions.Setter = &Workspace{}
+
 func (in *Workspace) GetConditions() conditionsv1alpha1.Conditions {
 	return in.Status.Conditions
 }
 
+// GetMount returns spec.mount or, for workspaces mounted before spec.mount existed, the mount
+// of the deprecated ExperimentalWorkspaceMountAnnotationKey annotation. It returns nil if the
+// workspace is not mounted or the annotation cannot be parsed.
+func (in *Workspace) GetMount() *Mount {
+	if in.Spec.Mount != nil {
+		return in.Spec.Mount
+	}
+	value, found := in.Annotations[ExperimentalWorkspaceMountAnnotationKey]
+	if !found {
+		return nil
+	}
+	mount, err := ParseTenancyMountAnnotation(value)
+	if err != nil {
+		return nil
+	}
+	return mount
+}
+
 // WorkspaceList is a list of Workspaces
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
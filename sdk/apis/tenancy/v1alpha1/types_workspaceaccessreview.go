@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceAccessReview checks what a user can do in the workspace it is created in and in all
+// workspaces below it, e.g. for audits or to verify the onboarding of a team. Every check is
+// evaluated like a SubjectAccessReview in every workspace of the subtree.
+//
+// WorkspaceAccessReviews are not stored. They are created through the workspaceaccessreviews
+// virtual workspace and return the report in the status. The object meta is not embedded,
+// such that no CustomResourceDefinition is generated.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceAccessReview struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	ObjectMeta metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspaceAccessReviewSpec `json:"spec"`
+
+	// +optional
+	Status WorkspaceAccessReviewStatus `json:"status,omitempty"`
+}
+
+// WorkspaceAccessReviewSpec defines the user and the checks of the review. At least one of
+// user and groups must be set.
+type WorkspaceAccessReviewSpec struct {
+	// user is the name of the user to review.
+	//
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// groups are the groups of the user.
+	//
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// uid of the user.
+	//
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// extra is the extra information of the user.
+	//
+	// +optional
+	Extra map[string][]string `json:"extra,omitempty"`
+
+	// checks are the requests to check in every workspace. If empty, it is checked whether
+	// the user can access the workspaces at all.
+	//
+	// +optional
+	Checks []WorkspaceAccessCheck `json:"checks,omitempty"`
+}
+
+// WorkspaceAccessCheck is a request, either to a resource or to a non-resource URL.
+type WorkspaceAccessCheck struct {
+	// verb of the request, e.g. get, create or access.
+	Verb string `json:"verb"`
+
+	// apiGroup of the resource. "" is the core API group.
+	//
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// resource of the request, e.g. "configmaps".
+	//
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// subresource of the request, e.g. "status".
+	//
+	// +optional
+	Subresource string `json:"subresource,omitempty"`
+
+	// namespace of the request. Empty means all namespaces for namespaced resources.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object. Empty means all objects.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// nonResourceURL is the path of a non-resource request, e.g. "/healthz". Mutually
+	// exclusive with resource.
+	//
+	// +optional
+	NonResourceURL string `json:"nonResourceURL,omitempty"`
+}
+
+// WorkspaceAccessReviewStatus is the report of the review.
+type WorkspaceAccessReviewStatus struct {
+	// workspaces are the results per workspace, sorted by path. Workspaces that are not ready
+	// are not reviewed.
+	//
+	// +optional
+	Workspaces []WorkspaceAccessReviewResult `json:"workspaces,omitempty"`
+
+	// incomplete is true if the subtree has more workspaces than a review covers. The
+	// workspaces closest to the workspace of the review are reviewed first.
+	//
+	// +optional
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// WorkspaceAccessReviewResult are the results of the checks in one workspace.
+type WorkspaceAccessReviewResult struct {
+	// path of the workspace.
+	Path string `json:"path"`
+
+	// checks are the results of the checks of the spec, in the same order.
+	//
+	// +optional
+	Checks []WorkspaceAccessCheckResult `json:"checks,omitempty"`
+
+	// error is set if the workspace could not be reviewed.
+	//
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// WorkspaceAccessCheckResult is the result of one check.
+type WorkspaceAccessCheckResult struct {
+	// allowed is true if the request would be allowed.
+	Allowed bool `json:"allowed"`
+
+	// reason is the reason of the decision, if the authorizers report one.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// evaluationError is set if an error occurred while authorizing the request.
+	//
+	// +optional
+	EvaluationError string `json:"evaluationError,omitempty"`
+}
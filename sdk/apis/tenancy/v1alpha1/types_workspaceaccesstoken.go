@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceAccessToken is a request for a short-lived token of the requesting user, restricted
+// to the workspace it is created in, its descendants, and the given rules. A request with the
+// token is only allowed if both the rules of the token and the permissions of the user allow it.
+//
+// WorkspaceAccessTokens are not stored. They are created through the workspaceaccesstokens
+// virtual workspace and return the token in the status. The object meta is not embedded,
+// such that no CustomResourceDefinition is generated.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceAccessToken struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	ObjectMeta metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspaceAccessTokenSpec `json:"spec"`
+
+	// +optional
+	Status WorkspaceAccessTokenStatus `json:"status,omitempty"`
+}
+
+// WorkspaceAccessTokenSpec defines the restrictions of the token.
+type WorkspaceAccessTokenSpec struct {
+	// rules are the requests allowed with the token. Requests matching no rule are denied.
+	//
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Rules []WorkspaceAccessTokenRule `json:"rules"`
+
+	// expirationSeconds is the requested lifetime of the token. It is capped by the
+	// maximum lifetime configured for the virtual workspace.
+	//
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// WorkspaceAccessTokenRule allows requests like a rule of a ClusterRole.
+type WorkspaceAccessTokenRule struct {
+	// verbs are the allowed verbs, or "*" for all.
+	Verbs []string `json:"verbs"`
+
+	// apiGroups are the API groups of the allowed resources, or "*" for all.
+	//
+	// +optional
+	APIGroups []string `json:"apiGroups,omitempty"`
+
+	// resources are the allowed resources, e.g. "configmaps" or "deployments/scale", or "*" for all.
+	//
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+
+	// resourceNames restricts the rule to the named objects.
+	//
+	// +optional
+	ResourceNames []string `json:"resourceNames,omitempty"`
+
+	// nonResourceURLs are the allowed non-resource URLs, e.g. "/api" or "/apis/*" for discovery.
+	//
+	// +optional
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// WorkspaceAccessTokenStatus contains the minted token.
+type WorkspaceAccessTokenStatus struct {
+	// token is the bearer token.
+	Token string `json:"token"`
+
+	// expirationTimestamp is the time the token expires.
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LogicalClusterQuotaUsageAnnotationKey is the annotation key set on the LogicalCluster of a
+// workspace at or below the workspace of a WorkspaceQuota. Its value is the JSON encoded
+// WorkspaceQuotaResources counted in the logical cluster itself.
+const LogicalClusterQuotaUsageAnnotationKey = "internal.tenancy.kcp.io/quota-usage"
+
+// WorkspaceQuota sets aggregate limits on the workspaces below the workspace
+// it is created in, i.e. on all child workspaces and their children, transitively.
+//
+// Each shard records the usage of its logical clusters below a WorkspaceQuota on their
+// LogicalCluster objects, which are replicated through the cache server. The shard of the
+// WorkspaceQuota sums them up across all shards and publishes the total in the status. Creation of objects in child workspaces
+// is rejected by admission when it would exceed one of the limits of a WorkspaceQuota
+// in any of the parent workspaces. As the usage is updated asynchronously, concurrent
+// creations can exceed a limit briefly.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Workspaces",type=integer,JSONPath=`.status.used.workspaces`,description="Number of workspaces in the subtree"
+// +kubebuilder:printcolumn:name="APIBindings",type=integer,JSONPath=`.status.used.apiBindings`,description="Number of APIBindings in the subtree"
+// +kubebuilder:printcolumn:name="CRDs",type=integer,JSONPath=`.status.used.customResourceDefinitions`,description="Number of CustomResourceDefinitions in the subtree"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type WorkspaceQuota struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkspaceQuotaSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status WorkspaceQuotaStatus `json:"status,omitempty"`
+}
+
+// WorkspaceQuotaSpec defines the desired limits.
+type WorkspaceQuotaSpec struct {
+	// hard is the set of limits enforced across all child workspaces.
+	//
+	// +optional
+	Hard WorkspaceQuotaResources `json:"hard,omitempty"`
+}
+
+// WorkspaceQuotaResources counts the objects limited by a WorkspaceQuota.
+// An unset field means no limit, or no usage respectively.
+type WorkspaceQuotaResources struct {
+	// workspaces is the number of workspaces below the workspace of the quota.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Workspaces *int64 `json:"workspaces,omitempty"`
+
+	// apiBindings is the number of APIBindings in all child workspaces.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	APIBindings *int64 `json:"apiBindings,omitempty"`
+
+	// customResourceDefinitions is the number of CustomResourceDefinitions in all child workspaces.
+	// Only the definitions are counted, not the custom resource objects of their types.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CustomResourceDefinitions *int64 `json:"customResourceDefinitions,omitempty"`
+}
+
+// WorkspaceQuotaStatus defines the observed usage.
+type WorkspaceQuotaStatus struct {
+	// used is the current aggregated usage of all child workspaces.
+	//
+	// +optional
+	Used WorkspaceQuotaResources `json:"used,omitempty"`
+}
+
+// WorkspaceQuotaList is a list of workspace quotas
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceQuota `json:"items"`
+}
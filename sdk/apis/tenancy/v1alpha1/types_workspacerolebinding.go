@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// WorkspaceRoleBinding binds a cluster role in the workspace it is created in to the members
+// of external groups, e.g. the teams of an organization in a SCIM directory. It is
+// materialized as a ClusterRoleBinding of the current members of the groups, as delivered by
+// the group feed of the shard, and kept up-to-date when the membership changes.
+//
+// Creating or changing a WorkspaceRoleBinding requires the bind verb on the cluster role.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=`.spec.roleRef.name`
+// +kubebuilder:printcolumn:name="Members",type="integer",JSONPath=`.status.members`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type WorkspaceRoleBinding struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkspaceRoleBindingSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status WorkspaceRoleBindingStatus `json:"status,omitempty"`
+}
+
+// WorkspaceRoleBindingSpec defines the role and its members.
+type WorkspaceRoleBindingSpec struct {
+	// roleRef is the cluster role in this workspace granted to the members.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	RoleRef WorkspaceRoleRef `json:"roleRef"`
+
+	// groups are the names of the external groups whose members are bound.
+	//
+	// +optional
+	// +listType=set
+	Groups []string `json:"groups,omitempty"`
+
+	// users are bound in addition to the members of the groups.
+	//
+	// +optional
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+}
+
+// WorkspaceRoleRef references a cluster role.
+type WorkspaceRoleRef struct {
+	// name is the name of the cluster role.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// WorkspaceRoleBindingStatus communicates the observed state of the WorkspaceRoleBinding.
+type WorkspaceRoleBindingStatus struct {
+	// members is the number of users currently bound.
+	//
+	// +optional
+	Members int `json:"members,omitempty"`
+
+	// unknownGroups are the groups that are not part of the group feed.
+	//
+	// +optional
+	// +listType=set
+	UnknownGroups []string `json:"unknownGroups,omitempty"`
+
+	// lastSyncTime is the time the members were last synchronized from the group feed.
+	//
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Current processing state of the WorkspaceRoleBinding.
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+// These are valid conditions of WorkspaceRoleBinding.
+const (
+	// WorkspaceRoleBindingSynced means the ClusterRoleBinding reflects the current members of the groups.
+	WorkspaceRoleBindingSynced conditionsv1alpha1.ConditionType = "Synced"
+
+	// WorkspaceRoleBindingGroupFeedUnavailableReason is a reason for the Synced condition that
+	// the group feed has not been read successfully yet.
+	WorkspaceRoleBindingGroupFeedUnavailableReason = "GroupFeedUnavailable"
+	// WorkspaceRoleBindingUnknownGroupsReason is a reason for the Synced condition that some
+	// of the groups are not part of the group feed.
+	WorkspaceRoleBindingUnknownGroupsReason = "UnknownGroups"
+)
+
+func (in *WorkspaceRoleBinding) SetConditions(c conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = c
+}
+
+var _ conditions.Getter = &WorkspaceRoleBinding{}
+var _ conditions.Setter = &WorkspaceRoleBinding{}
+
+func (in *WorkspaceRoleBinding) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+// WorkspaceRoleBindingList is a list of workspace role bindings
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceRoleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceRoleBinding `json:"items"`
+}
@@ -18,9 +18,11 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // WorkspaceTypeReservedNames defines the set of names that may not be
@@ -69,10 +71,23 @@ type WorkspaceTypeSpec struct {
 	// +optional
 	Initializer bool `json:"initializer,omitempty"`
 
+	// terminator determines if this WorkspaceType has an associated terminating
+	// controller. These controllers are used to clean up after a Workspace, e.g.
+	// external resources; the LogicalCluster of the Workspace is not deleted before
+	// all terminating controllers have finished their work.
+	//
+	// One terminating controller is supported per WorkspaceType; the identifier
+	// for this terminator is built like the one of the initializer, e.g.
+	// `root:org:example` for a WorkspaceType `example` in the `root:org` workspace.
+	//
+	// +optional
+	Terminator bool `json:"terminator,omitempty"`
+
 	// extend is a list of other WorkspaceTypes whose initializers and limitAllowedChildren
 	// and limitAllowedParents this WorkspaceType is inheriting. By (transitively) extending
 	// another WorkspaceType, this WorkspaceType will be considered as that
 	// other type in evaluation of limitAllowedChildren and limitAllowedParents constraints.
+	// Fields listed in extend.overrides are not inherited.
 	//
 	// A dependency cycle stop this WorkspaceType from being admitted as the type
 	// of a Workspace.
@@ -111,11 +126,169 @@ type WorkspaceTypeSpec struct {
 	// +optional
 	LimitAllowedParents *WorkspaceTypeSelector `json:"limitAllowedParents,omitempty"`
 
+	// childNamePolicy constrains the names of sub-workspaces created in workspaces of
+	// this type. These are in addition to the name policies of types this one extends.
+	//
+	// +optional
+	ChildNamePolicy *WorkspaceNamePolicy `json:"childNamePolicy,omitempty"`
+
 	// defaultAPIBindings are the APIs to bind during initialization of workspaces created from this type.
 	// The APIBinding names will be generated dynamically.
 	//
 	// +optional
 	DefaultAPIBindings []APIExportReference `json:"defaultAPIBindings,omitempty"`
+
+	// retentionPolicy configures soft-deletion of workspaces of this type. When set,
+	// deleted workspaces are kept in the Terminating phase together with their logical
+	// cluster and all its objects for the given retention period, during which a system
+	// administrator can restore them. After the retention period the logical cluster is purged.
+	// Extending another WorkspaceType does not inherit its retentionPolicy.
+	//
+	// +optional
+	RetentionPolicy *WorkspaceRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// initializationTimeout is the maximum time initializers may take to initialize workspaces
+	// of this type. If initializers remain after it, the WorkspaceInitialized condition of the
+	// workspace is set to false with reason InitializerTimedOut naming them, and a warning event
+	// is emitted. The workspace stays Initializing until the initializers are removed.
+	// Extending another WorkspaceType does not inherit its initializationTimeout.
+	//
+	// +optional
+	InitializationTimeout *metav1.Duration `json:"initializationTimeout,omitempty"`
+
+	// template holds objects that are created in every new workspace of this type during
+	// initialization, e.g. namespaces, RBAC or settings. The templates of extended WorkspaceTypes
+	// are applied too. Objects that exist already are not updated.
+	//
+	// +optional
+	Template *WorkspaceTemplate `json:"template,omitempty"`
+
+	// limits restricts the APIs that can be used in workspaces of this type. The limits of
+	// extended WorkspaceTypes apply too.
+	//
+	// +optional
+	Limits *WorkspaceTypeLimits `json:"limits,omitempty"`
+
+	// auditPolicy supplements the global audit policy of the server for requests to
+	// workspaces of this type, in addition to the audit policy of the workspace itself.
+	// Extending another WorkspaceType does not inherit its auditPolicy.
+	//
+	// +optional
+	AuditPolicy *AuditPolicy `json:"auditPolicy,omitempty"`
+
+	// placementStrategy chooses the shard of new workspaces of this type, overriding the
+	// default strategy of the server. Extending another WorkspaceType does not inherit
+	// its placementStrategy.
+	//
+	// +optional
+	PlacementStrategy WorkspacePlacementStrategy `json:"placementStrategy,omitempty"`
+}
+
+// WorkspacePlacementStrategy is a strategy to choose the shard of a new workspace among
+// the schedulable shards matching its location.
+//
+// +kubebuilder:validation:Enum=Random;CapacityWeighted;LabelAffinity;RegionSpread
+type WorkspacePlacementStrategy string
+
+const (
+	// WorkspacePlacementRandom chooses a random shard.
+	WorkspacePlacementRandom WorkspacePlacementStrategy = "Random"
+	// WorkspacePlacementCapacityWeighted chooses a random shard, weighted by the
+	// logicalclusters capacity in the status of the shards.
+	WorkspacePlacementCapacityWeighted WorkspacePlacementStrategy = "CapacityWeighted"
+	// WorkspacePlacementLabelAffinity chooses a shard with the most labels equal to
+	// the labels of the workspace.
+	WorkspacePlacementLabelAffinity WorkspacePlacementStrategy = "LabelAffinity"
+	// WorkspacePlacementRegionSpread chooses a shard in the region with the fewest
+	// sibling workspaces, spreading the children of a workspace across regions.
+	WorkspacePlacementRegionSpread WorkspacePlacementStrategy = "RegionSpread"
+)
+
+// WorkspacePlacementStrategies are all known placement strategies.
+var WorkspacePlacementStrategies = []WorkspacePlacementStrategy{
+	WorkspacePlacementRandom,
+	WorkspacePlacementCapacityWeighted,
+	WorkspacePlacementLabelAffinity,
+	WorkspacePlacementRegionSpread,
+}
+
+// WorkspaceTypeLimits restricts the APIs that can be used in workspaces of a type.
+type WorkspaceTypeLimits struct {
+	// disabledResources are built-in resources, e.g. deployments.apps or services, that
+	// nothing acts on in workspaces of this type. Creating objects of these resources is
+	// refused at admission. Existing objects can still be read, updated and deleted.
+	//
+	// +optional
+	// +listType=atomic
+	DisabledResources []GroupResource `json:"disabledResources,omitempty"`
+}
+
+// GroupResource identifies a resource.
+type GroupResource struct {
+	// group is the name of an API group.
+	// For core groups this is the empty string '""'.
+	//
+	// +kubebuilder:validation:Pattern=`^(|[a-z0-9]([-a-z0-9]*[a-z0-9](\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*)?)$`
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// resource is the name of the resource.
+	//
+	// +kubebuilder:validation:Pattern=`^[a-z][-a-z0-9]*[a-z0-9]$`
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+}
+
+// String returns the resource in the form resource.group.
+func (r GroupResource) String() string {
+	if r.Group == "" {
+		return r.Resource
+	}
+	return r.Resource + "." + r.Group
+}
+
+// WorkspaceNamePolicy constrains the names of workspaces.
+type WorkspaceNamePolicy struct {
+	// pattern is a regular expression in RE2 syntax that names must match completely,
+	// e.g. "team-[a-z]+".
+	//
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// reservedPrefixes are prefixes that names must not start with.
+	//
+	// +optional
+	// +listType=set
+	ReservedPrefixes []string `json:"reservedPrefixes,omitempty"`
+}
+
+// WorkspaceTemplate describes the initial objects of new workspaces.
+type WorkspaceTemplate struct {
+	// objects are the manifests of the objects to create. Namespaces are created first,
+	// all other objects in the given order.
+	//
+	// +optional
+	// +listType=atomic
+	Objects []WorkspaceTemplateObject `json:"objects,omitempty"`
+}
+
+// WorkspaceTemplateObject is the manifest of an object created in new workspaces.
+//
+// +kubebuilder:pruning:PreserveUnknownFields
+// +kubebuilder:validation:Type=object
+type WorkspaceTemplateObject struct {
+	runtime.RawExtension `json:",inline"`
+}
+
+// WorkspaceRetentionPolicy describes how long deleted workspaces are retained before being purged.
+type WorkspaceRetentionPolicy struct {
+	// days is the number of days a deleted workspace is retained.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Days int32 `json:"days"`
 }
 
 // APIExportReference provides the fields necessary to resolve an APIExport.
@@ -163,8 +336,43 @@ type WorkspaceTypeExtension struct {
 	//
 	// +optional
 	With []WorkspaceTypeReference `json:"with,omitempty"`
+
+	// overrides lists the fields of this WorkspaceType that replace the values of the
+	// WorkspaceTypes it (transitively) extends, instead of being combined with them. E.g.
+	// with "defaultAPIBindings", only the defaultAPIBindings of this type are bound, even
+	// if they are empty. Types extending this type in turn combine with this type's values
+	// only. Overrides do not change aliasing in the evaluation of limitAllowedChildren and
+	// limitAllowedParents.
+	//
+	// +optional
+	// +listType=set
+	Overrides []WorkspaceTypeField `json:"overrides,omitempty"`
 }
 
+// WorkspaceTypeField is a field of a WorkspaceType that is inherited from extended types.
+//
+// +kubebuilder:validation:Enum=initializers;terminators;defaultAPIBindings;limitAllowedChildren;limitAllowedParents;childNamePolicy;template;limits
+type WorkspaceTypeField string
+
+const (
+	// WorkspaceTypeFieldInitializers refers to the initializers of extended types.
+	WorkspaceTypeFieldInitializers WorkspaceTypeField = "initializers"
+	// WorkspaceTypeFieldTerminators refers to the terminators of extended types.
+	WorkspaceTypeFieldTerminators WorkspaceTypeField = "terminators"
+	// WorkspaceTypeFieldDefaultAPIBindings refers to spec.defaultAPIBindings.
+	WorkspaceTypeFieldDefaultAPIBindings WorkspaceTypeField = "defaultAPIBindings"
+	// WorkspaceTypeFieldLimitAllowedChildren refers to spec.limitAllowedChildren.
+	WorkspaceTypeFieldLimitAllowedChildren WorkspaceTypeField = "limitAllowedChildren"
+	// WorkspaceTypeFieldLimitAllowedParents refers to spec.limitAllowedParents.
+	WorkspaceTypeFieldLimitAllowedParents WorkspaceTypeField = "limitAllowedParents"
+	// WorkspaceTypeFieldChildNamePolicy refers to spec.childNamePolicy.
+	WorkspaceTypeFieldChildNamePolicy WorkspaceTypeField = "childNamePolicy"
+	// WorkspaceTypeFieldTemplate refers to spec.template.
+	WorkspaceTypeFieldTemplate WorkspaceTypeField = "template"
+	// WorkspaceTypeFieldLimits refers to spec.limits.
+	WorkspaceTypeFieldLimits WorkspaceTypeField = "limits"
+)
+
 // These are valid conditions of WorkspaceType.
 const (
 	WorkspaceTypeVirtualWorkspaceURLsReady conditionsv1alpha1.ConditionType = "VirtualWorkspaceURLsReady"
@@ -201,6 +409,9 @@ func (in *WorkspaceType) SetConditions(conditions conditionsv1alpha1.Conditions)
 	in.Status.Conditions = conditions
 }
 
+var _ conditions.Getter = &WorkspaceType{}
+var _ conditions.Setter = &WorkspaceType{}
+
 // WorkspaceTypeList is a list of workspace types
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -215,6 +426,10 @@ type WorkspaceTypeList struct {
 // on a WorkspaceType to be created.
 const WorkspaceAPIBindingsInitializer corev1alpha1.LogicalClusterInitializer = "system:apibindings"
 
+// WorkspaceTemplatesInitializer is a special-case initializer that waits for the objects of the
+// templates defined on a WorkspaceType to be created.
+const WorkspaceTemplatesInitializer corev1alpha1.LogicalClusterInitializer = "system:templates"
+
 const (
 	// WorkspacePhaseLabel holds the Workspace.Status.Phase value, and is enforced to match
 	// by a mutating admission webhook.
@@ -223,6 +438,8 @@ const (
 	// and the set of labels with this prefix is enforced to match the set of initializers by a mutating admission
 	// webhook.
 	WorkspaceInitializerLabelPrefix = "initializer.internal.kcp.io/"
+	// WorkspaceTerminatorLabelPrefix is the prefix for labels which match LogicalCluster.Status.Terminators.
+	WorkspaceTerminatorLabelPrefix = "terminator.internal.kcp.io/"
 )
 
 const (
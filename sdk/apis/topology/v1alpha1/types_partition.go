@@ -18,14 +18,19 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // +crd
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories=kcp
 // +kubebuilder:printcolumn:name="Owner",type="string",JSONPath=".metadata.ownerReferences[*].name"
+// +kubebuilder:printcolumn:name="Healthy",type="string",JSONPath=".status.conditions[?(@.type==\"ShardsHealthy\")].status"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Partition defines the selection of a set of shards along multiple dimensions.
@@ -42,6 +47,11 @@ type Partition struct {
 
 	// spec holds the desired state.
 	Spec PartitionSpec `json:"spec,omitempty"`
+
+	// +optional
+
+	// status holds information about the health of the shards of the partition.
+	Status PartitionStatus `json:"status,omitempty"`
 }
 
 // PartitionSpec records the values defining the partition along multiple dimensions.
@@ -52,6 +62,72 @@ type PartitionSpec struct {
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
+// PartitionStatus records the health of the shards selected by the Partition.
+type PartitionStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// shards are the shards selected by the partition with their health.
+	Shards []PartitionShardStatus `json:"shards,omitempty"`
+
+	// +optional
+
+	// conditions is a list of conditions that apply to the Partition.
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+// PartitionShardStatus records the health of a shard of a Partition.
+type PartitionShardStatus struct {
+	// name is the name of the Shard.
+	Name string `json:"name"`
+
+	// +optional
+
+	// virtualWorkspaceURL is the URL of the virtual workspaces of the shard, which the
+	// endpoints of APIExportEndpointSlices are based on.
+	VirtualWorkspaceURL string `json:"virtualWorkspaceURL,omitempty"`
+
+	// health is the health of the shard, derived from its Ready condition, i.e. from
+	// its Lease.
+	Health ShardHealth `json:"health"`
+}
+
+// ShardHealth is the health of a shard.
+//
+// +kubebuilder:validation:Enum=Healthy;Degraded;Unknown
+type ShardHealth string
+
+const (
+	// ShardHealthy is the health of a ready shard.
+	ShardHealthy ShardHealth = "Healthy"
+	// ShardDegraded is the health of a shard whose Lease expired.
+	ShardDegraded ShardHealth = "Degraded"
+	// ShardHealthUnknown is the health of a shard whose readiness is not known yet.
+	ShardHealthUnknown ShardHealth = "Unknown"
+)
+
+func (in *Partition) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *Partition) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+var _ conditions.Getter = &Partition{}
+var _ conditions.Setter = &Partition{}
+
+// These are valid conditions of Partition.
+const (
+	// PartitionShardsHealthy reflects whether all shards selected by the Partition are healthy.
+	PartitionShardsHealthy conditionsv1alpha1.ConditionType = "ShardsHealthy"
+
+	// PartitionShardsDegradedReason indicates that some shards of the Partition are degraded
+	// or their health is unknown.
+	PartitionShardsDegradedReason = "ShardsDegraded"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // PartitionList is a list of Partition resources.
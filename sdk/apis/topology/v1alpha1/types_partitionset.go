@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // +crd
@@ -62,6 +63,23 @@ type PartitionSetSpec struct {
 
 	// shardSelector (optional) specifies filtering for shard targets.
 	ShardSelector *metav1.LabelSelector `json:"shardSelector,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+
+	// maxShardsPerPartition (optional) limits the number of shards in a partition. The shards of
+	// a dimension group exceeding it are split over multiple partitions, which select their shards
+	// by the "name" label. New shards are added to the partitions with room left, existing
+	// partitions are kept stable until a rebalance is triggered.
+	MaxShardsPerPartition int32 `json:"maxShardsPerPartition,omitempty"`
+
+	// +optional
+
+	// rebalance (optional) triggers a rebalancing of the partitions when changed to a new value.
+	// The shards of each dimension group are then evenly spread over the smallest number of
+	// partitions, merging partitions that became too small. APIExportEndpointSlices referencing
+	// a removed partition are updated to reference the partition taking over most of its shards.
+	Rebalance string `json:"rebalance,omitempty"`
 }
 
 // PartitionSetStatus records the status of the PartitionSet.
@@ -71,6 +89,11 @@ type PartitionSetStatus struct {
 
 	// +optional
 
+	// rebalance is the value of spec.rebalance the partitions were last rebalanced for.
+	Rebalance string `json:"rebalance,omitempty"`
+
+	// +optional
+
 	// conditions is a list of conditions that apply to the APIExportEndpointSlice.
 	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
 }
@@ -83,6 +106,9 @@ func (in *PartitionSet) SetConditions(conditions conditionsv1alpha1.Conditions)
 	in.Status.Conditions = conditions
 }
 
+var _ conditions.Getter = &PartitionSet{}
+var _ conditions.Setter = &PartitionSet{}
+
 // These are valid conditions of PartitionSet.
 const (
 	// PartitionSetValid reflects the validity of the PartitionSet spec.
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates and defaults objects of the kcp APIs against the OpenAPI
+// schemas of their CRDs, including CEL rules and list types, the same way the kcp server
+// does. It needs no server and can be used by admission plugins, by clients for pre-flight
+// checks and by tooling to validate manifests offline.
+package validation
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"sync"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/listtype"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+)
+
+// crds are copied from config/crds by hack/update-codegen-crds.sh.
+//
+//go:embed crds/*.yaml
+var crds embed.FS
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(apisv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(apisv1alpha2.AddToScheme(scheme))
+	utilruntime.Must(corev1alpha1.AddToScheme(scheme))
+	utilruntime.Must(tenancyv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(topologyv1alpha1.AddToScheme(scheme))
+}
+
+type versionSchema struct {
+	structural *structuralschema.Structural
+	validator  apiservervalidation.SchemaValidator
+	cel        *cel.Validator
+}
+
+var (
+	loadOnce sync.Once
+	schemas  map[schema.GroupVersionKind]*versionSchema
+	loadErr  error
+)
+
+// Default sets the defaults of the OpenAPI schema of the object's kcp CRD on obj, like
+// the kcp server does on create. obj can be a typed object or an *unstructured.Unstructured.
+func Default(obj runtime.Object) error {
+	u, gvk, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	s, err := schemaFor(gvk)
+	if err != nil {
+		return err
+	}
+
+	structuraldefaulting.Default(u, s.structural)
+
+	return fromUnstructured(u, obj)
+}
+
+// Validate validates obj against the OpenAPI schema, including the CEL rules, of its
+// kcp CRD. obj can be a typed object or an *unstructured.Unstructured.
+func Validate(obj runtime.Object) field.ErrorList {
+	return ValidateUpdate(obj, nil)
+}
+
+// ValidateUpdate validates the update of old to obj against the OpenAPI schema, including
+// the CEL transition rules, of their kcp CRD. If old is nil, obj is validated as created.
+func ValidateUpdate(obj, old runtime.Object) field.ErrorList {
+	u, gvk, err := toUnstructured(obj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(nil, err)}
+	}
+	s, err := schemaFor(gvk)
+	if err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("kind"), gvk.String(), err.Error())}
+	}
+
+	var oldU map[string]interface{}
+	var errs field.ErrorList
+	if old == nil {
+		errs = apiservervalidation.ValidateCustomResource(nil, u, s.validator)
+	} else {
+		if oldU, _, err = toUnstructured(old); err != nil {
+			return field.ErrorList{field.InternalError(nil, err)}
+		}
+		errs = apiservervalidation.ValidateCustomResourceUpdate(nil, u, oldU, s.validator)
+	}
+	errs = append(errs, listtype.ValidateListSetsAndMaps(nil, s.structural, u)...)
+
+	var oldObj interface{}
+	if oldU != nil {
+		oldObj = oldU
+	}
+	celErrs, _ := s.cel.Validate(context.TODO(), nil, s.structural, u, oldObj, celconfig.RuntimeCELCostBudget)
+	return append(errs, celErrs...)
+}
+
+func schemaFor(gvk schema.GroupVersionKind) (*versionSchema, error) {
+	loadOnce.Do(func() {
+		schemas, loadErr = loadSchemas()
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	s, found := schemas[gvk]
+	if !found {
+		return nil, fmt.Errorf("%s is not a kcp API", gvk)
+	}
+	return s, nil
+}
+
+func loadSchemas() (map[schema.GroupVersionKind]*versionSchema, error) {
+	files, err := crds.ReadDir("crds")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[schema.GroupVersionKind]*versionSchema{}
+	for _, f := range files {
+		raw, err := crds.ReadFile("crds/" + f.Name())
+		if err != nil {
+			return nil, err
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(raw, crd); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", f.Name(), err)
+		}
+
+		for _, v := range crd.Spec.Versions {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			var internalSchema apiextensions.JSONSchemaProps
+			if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(v.Schema.OpenAPIV3Schema, &internalSchema, nil); err != nil {
+				return nil, fmt.Errorf("failed to convert schema of %s version %s: %w", crd.Name, v.Name, err)
+			}
+			structural, err := structuralschema.NewStructural(&internalSchema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create structural schema of %s version %s: %w", crd.Name, v.Name, err)
+			}
+			validator, _, err := apiservervalidation.NewSchemaValidator(&internalSchema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create validator of %s version %s: %w", crd.Name, v.Name, err)
+			}
+
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}
+			ret[gvk] = &versionSchema{
+				structural: structural,
+				validator:  validator,
+				cel:        cel.NewValidator(structural, true, celconfig.PerCallLimit),
+			}
+		}
+	}
+	return ret, nil
+}
+
+func toUnstructured(obj runtime.Object) (map[string]interface{}, schema.GroupVersionKind, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.Object, u.GroupVersionKind(), nil
+	}
+
+	kinds, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil, schema.GroupVersionKind{}, err
+	}
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, schema.GroupVersionKind{}, err
+	}
+	raw["apiVersion"] = kinds[0].GroupVersion().String()
+	raw["kind"] = kinds[0].Kind
+	return raw, kinds[0], nil
+}
+
+func fromUnstructured(u map[string]interface{}, obj runtime.Object) error {
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		// defaulted in-place
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj)
+}
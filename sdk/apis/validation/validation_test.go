@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	valid := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "cowboys"},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+			},
+		},
+	}
+	require.Empty(t, Validate(valid))
+
+	invalid := valid.DeepCopy()
+	invalid.Spec.PermissionClaims[0].All = false
+	errs := Validate(invalid)
+	require.Len(t, errs, 1)
+	require.Equal(t, "spec.permissionClaims[0]", errs[0].Field)
+	require.Contains(t, errs[0].Detail, `either "all" or "resourceSelector" must be set`)
+
+	invalid = valid.DeepCopy()
+	invalid.Spec.PermissionClaims[0].Resource = "Config_Maps"
+	errs = Validate(invalid)
+	require.Len(t, errs, 1)
+	require.Equal(t, "spec.permissionClaims[0].resource", errs[0].Field)
+
+	invalid = valid.DeepCopy()
+	invalid.Spec.LatestResourceSchemas = append(invalid.Spec.LatestResourceSchemas, "today.cowboys.wildwest.dev")
+	errs = Validate(invalid)
+	require.Len(t, errs, 1)
+	require.Equal(t, "spec.latestResourceSchemas[1]", errs[0].Field)
+}
+
+func TestValidateUnstructured(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apis.kcp.io/v1alpha2",
+		"kind":       "APIExport",
+		"metadata":   map[string]interface{}{"name": "cowboys"},
+		"spec": map[string]interface{}{
+			"permissionClaims": []interface{}{
+				map[string]interface{}{"group": "", "resource": "configmaps", "selector": map[string]interface{}{}},
+			},
+		},
+	}}
+	errs := Validate(u)
+	require.Len(t, errs, 1)
+	require.Equal(t, "spec.permissionClaims[0].selector", errs[0].Field)
+
+	u.SetAPIVersion("example.com/v1")
+	errs = Validate(u)
+	require.Len(t, errs, 1)
+	require.Equal(t, "kind", errs[0].Field)
+}
+
+func TestValidateUpdate(t *testing.T) {
+	old := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "cowboys"},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{Export: &apisv1alpha1.ExportBindingReference{Path: "root:org", Name: "cowboys"}},
+		},
+	}
+	require.Empty(t, ValidateUpdate(old.DeepCopy(), old))
+
+	updated := old.DeepCopy()
+	updated.Spec.Reference.Export.Name = "sheriffs"
+	errs := ValidateUpdate(updated, old)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Detail, "APIExport reference must not be changed")
+	require.Empty(t, Validate(updated))
+}
+
+func TestDefault(t *testing.T) {
+	schema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: "today.cowboys.wildwest.dev"},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "wildwest.dev",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "cowboys", Singular: "cowboy", Kind: "Cowboy", ListKind: "CowboyList"},
+			Scope: "Namespaced",
+		},
+	}
+	require.NoError(t, Default(schema))
+	require.Equal(t, "DNS1123Subdomain", schema.Spec.NameValidation)
+
+	ws := &tenancyv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: "ws"}}
+	require.NoError(t, Default(ws))
+	require.Equal(t, corev1alpha1.LogicalClusterPhaseScheduling, ws.Status.Phase)
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "core.kcp.io/v1alpha1",
+		"kind":       "LogicalCluster",
+		"metadata":   map[string]interface{}{"name": "cluster"},
+		"status":     map[string]interface{}{},
+	}}
+	require.NoError(t, Default(u))
+	phase, _, err := unstructured.NestedString(u.Object, "status", "phase")
+	require.NoError(t, err)
+	require.Equal(t, "Scheduling", phase)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/client-go/rest"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// APIExportEndpointSliceRESTConfigs returns a copy of config for every endpoint of slice, by
+// endpoint URL, pointing to the wildcard cluster of the APIExport virtual workspace on its shard.
+// If shards are given, only the endpoints served by these shards are returned. An error is
+// returned while the endpoint URLs of slice are not ready.
+func APIExportEndpointSliceRESTConfigs(slice *apisv1alpha1.APIExportEndpointSlice, config *rest.Config, shards ...*corev1alpha1.Shard) (map[string]*rest.Config, error) {
+	if !conditions.IsTrue(slice, apisv1alpha1.APIExportEndpointSliceURLsReady) {
+		return nil, fmt.Errorf("endpoint URLs of APIExportEndpointSlice %s|%s are not ready", logicalcluster.From(slice), slice.Name)
+	}
+
+	configs := make(map[string]*rest.Config, len(slice.Status.APIExportEndpoints))
+	for _, endpoint := range slice.Status.APIExportEndpoints {
+		if len(shards) > 0 && ShardOfAPIExportEndpoint(endpoint.URL, shards) == nil {
+			continue
+		}
+		endpointConfig := rest.CopyConfig(config)
+		endpointConfig.Host = strings.TrimSuffix(endpoint.URL, "/") + logicalcluster.Wildcard.RequestPath()
+		configs[endpoint.URL] = endpointConfig
+	}
+	return configs, nil
+}
+
+// ShardOfAPIExportEndpoint returns the shard whose virtual workspaces serve the given endpoint
+// URL of an APIExportEndpointSlice, or nil if none of the shards does.
+func ShardOfAPIExportEndpoint(url string, shards []*corev1alpha1.Shard) *corev1alpha1.Shard {
+	for _, shard := range shards {
+		if strings.HasPrefix(url, ShardVirtualWorkspaceURL(shard)+"/") {
+			return shard
+		}
+	}
+	return nil
+}
+
+// ShardVirtualWorkspaceURL returns the base URL of the virtual workspaces of shard, without
+// trailing slash. It defaults to the base URL of the shard.
+func ShardVirtualWorkspaceURL(shard *corev1alpha1.Shard) string {
+	if url := strings.TrimSuffix(shard.Spec.VirtualWorkspaceURL, "/"); url != "" {
+		return url
+	}
+	return strings.TrimSuffix(shard.Spec.BaseURL, "/")
+}
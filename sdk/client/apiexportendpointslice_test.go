@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+func TestAPIExportEndpointSliceRESTConfigs(t *testing.T) {
+	slice := &apisv1alpha1.APIExportEndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+		Status: apisv1alpha1.APIExportEndpointSliceStatus{
+			APIExportEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{URL: "https://root.kcp:6444/services/apiexport/root/widgets"},
+				{URL: "https://one.kcp:6444/services/apiexport/root/widgets/"},
+			},
+		},
+	}
+	config := &rest.Config{Host: "https://kcp:6443", BearerToken: "token"}
+
+	_, err := APIExportEndpointSliceRESTConfigs(slice, config)
+	require.Error(t, err, "endpoint URLs are not ready yet")
+
+	slice.Status.Conditions = conditionsv1alpha1.Conditions{{Type: apisv1alpha1.APIExportEndpointSliceURLsReady, Status: "True"}}
+	configs, err := APIExportEndpointSliceRESTConfigs(slice, config)
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	require.Equal(t, "https://root.kcp:6444/services/apiexport/root/widgets/clusters/*", configs["https://root.kcp:6444/services/apiexport/root/widgets"].Host)
+	require.Equal(t, "https://one.kcp:6444/services/apiexport/root/widgets/clusters/*", configs["https://one.kcp:6444/services/apiexport/root/widgets/"].Host)
+	require.Equal(t, "token", configs["https://one.kcp:6444/services/apiexport/root/widgets/"].BearerToken)
+	require.Equal(t, "https://kcp:6443", config.Host, "config must not be changed")
+
+	shards := []*corev1alpha1.Shard{
+		{ObjectMeta: metav1.ObjectMeta{Name: "one"}, Spec: corev1alpha1.ShardSpec{BaseURL: "https://one.kcp:6443", VirtualWorkspaceURL: "https://one.kcp:6444/"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "two"}, Spec: corev1alpha1.ShardSpec{BaseURL: "https://two.kcp:6443"}},
+	}
+	configs, err = APIExportEndpointSliceRESTConfigs(slice, config, shards...)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	require.Contains(t, configs, "https://one.kcp:6444/services/apiexport/root/widgets/")
+
+	require.Equal(t, "one", ShardOfAPIExportEndpoint("https://one.kcp:6444/services/apiexport/root/widgets", shards).Name)
+	require.Equal(t, "two", ShardOfAPIExportEndpoint("https://two.kcp:6443/services/apiexport/root/widgets", shards).Name)
+	require.Nil(t, ShardOfAPIExportEndpoint("https://one.kcp:64445/services/apiexport/root/widgets", shards))
+}
@@ -25,6 +25,7 @@ type APIExportSpecApplyConfiguration struct {
 	Identity                *IdentityApplyConfiguration                `json:"identity,omitempty"`
 	MaximalPermissionPolicy *MaximalPermissionPolicyApplyConfiguration `json:"maximalPermissionPolicy,omitempty"`
 	PermissionClaims        []PermissionClaimApplyConfiguration        `json:"permissionClaims,omitempty"`
+	StorageMigration        *StorageMigrationApplyConfiguration        `json:"storageMigration,omitempty"`
 }
 
 // APIExportSpecApplyConfiguration constructs an declarative configuration of the APIExportSpec type for use with
@@ -71,3 +72,11 @@ func (b *APIExportSpecApplyConfiguration) WithPermissionClaims(values ...*Permis
 	}
 	return b
 }
+
+// WithStorageMigration sets the StorageMigration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageMigration field is set to the value of the last call.
+func (b *APIExportSpecApplyConfiguration) WithStorageMigration(value *StorageMigrationApplyConfiguration) *APIExportSpecApplyConfiguration {
+	b.StorageMigration = value
+	return b
+}
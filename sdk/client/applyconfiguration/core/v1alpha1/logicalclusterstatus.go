@@ -26,10 +26,12 @@ import (
 // LogicalClusterStatusApplyConfiguration represents an declarative configuration of the LogicalClusterStatus type for use
 // with apply.
 type LogicalClusterStatusApplyConfiguration struct {
-	URL          *string                              `json:"URL,omitempty"`
-	Phase        *v1alpha1.LogicalClusterPhaseType    `json:"phase,omitempty"`
-	Conditions   *conditionsv1alpha1.Conditions       `json:"conditions,omitempty"`
-	Initializers []v1alpha1.LogicalClusterInitializer `json:"initializers,omitempty"`
+	URL          *string                                `json:"URL,omitempty"`
+	Phase        *v1alpha1.LogicalClusterPhaseType      `json:"phase,omitempty"`
+	Conditions   *conditionsv1alpha1.Conditions         `json:"conditions,omitempty"`
+	Initializers []v1alpha1.LogicalClusterInitializer   `json:"initializers,omitempty"`
+	Terminators  []v1alpha1.LogicalClusterTerminator    `json:"terminators,omitempty"`
+	Usage        *LogicalClusterUsageApplyConfiguration `json:"usage,omitempty"`
 }
 
 // LogicalClusterStatusApplyConfiguration constructs an declarative configuration of the LogicalClusterStatus type for use with
@@ -71,3 +73,21 @@ func (b *LogicalClusterStatusApplyConfiguration) WithInitializers(values ...v1al
 	}
 	return b
 }
+
+// WithTerminators adds the given value to the Terminators field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Terminators field.
+func (b *LogicalClusterStatusApplyConfiguration) WithTerminators(values ...v1alpha1.LogicalClusterTerminator) *LogicalClusterStatusApplyConfiguration {
+	for i := range values {
+		b.Terminators = append(b.Terminators, values[i])
+	}
+	return b
+}
+
+// WithUsage sets the Usage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Usage field is set to the value of the last call.
+func (b *LogicalClusterStatusApplyConfiguration) WithUsage(value *LogicalClusterUsageApplyConfiguration) *LogicalClusterStatusApplyConfiguration {
+	b.Usage = value
+	return b
+}
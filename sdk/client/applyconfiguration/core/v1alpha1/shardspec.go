@@ -24,6 +24,8 @@ type ShardSpecApplyConfiguration struct {
 	BaseURL             *string `json:"baseURL,omitempty"`
 	ExternalURL         *string `json:"externalURL,omitempty"`
 	VirtualWorkspaceURL *string `json:"virtualWorkspaceURL,omitempty"`
+	Cordoned            *bool   `json:"cordoned,omitempty"`
+	Drain               *bool   `json:"drain,omitempty"`
 }
 
 // ShardSpecApplyConfiguration constructs an declarative configuration of the ShardSpec type for use with
@@ -55,3 +57,19 @@ func (b *ShardSpecApplyConfiguration) WithVirtualWorkspaceURL(value string) *Sha
 	b.VirtualWorkspaceURL = &value
 	return b
 }
+
+// WithCordoned sets the Cordoned field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cordoned field is set to the value of the last call.
+func (b *ShardSpecApplyConfiguration) WithCordoned(value bool) *ShardSpecApplyConfiguration {
+	b.Cordoned = &value
+	return b
+}
+
+// WithDrain sets the Drain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Drain field is set to the value of the last call.
+func (b *ShardSpecApplyConfiguration) WithDrain(value bool) *ShardSpecApplyConfiguration {
+	b.Drain = &value
+	return b
+}
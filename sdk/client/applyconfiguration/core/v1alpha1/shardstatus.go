@@ -27,8 +27,10 @@ import (
 // ShardStatusApplyConfiguration represents an declarative configuration of the ShardStatus type for use
 // with apply.
 type ShardStatusApplyConfiguration struct {
-	Capacity   *v1.ResourceList     `json:"capacity,omitempty"`
-	Conditions *v1alpha1.Conditions `json:"conditions,omitempty"`
+	Capacity   *v1.ResourceList                    `json:"capacity,omitempty"`
+	Conditions *v1alpha1.Conditions                `json:"conditions,omitempty"`
+	Drain      *ShardDrainStatusApplyConfiguration `json:"drain,omitempty"`
+	Usage      *ShardUsageApplyConfiguration       `json:"usage,omitempty"`
 }
 
 // ShardStatusApplyConfiguration constructs an declarative configuration of the ShardStatus type for use with
@@ -52,3 +54,19 @@ func (b *ShardStatusApplyConfiguration) WithConditions(value v1alpha1.Conditions
 	b.Conditions = &value
 	return b
 }
+
+// WithDrain sets the Drain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Drain field is set to the value of the last call.
+func (b *ShardStatusApplyConfiguration) WithDrain(value *ShardDrainStatusApplyConfiguration) *ShardStatusApplyConfiguration {
+	b.Drain = value
+	return b
+}
+
+// WithUsage sets the Usage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Usage field is set to the value of the last call.
+func (b *ShardStatusApplyConfiguration) WithUsage(value *ShardUsageApplyConfiguration) *ShardStatusApplyConfiguration {
+	b.Usage = value
+	return b
+}
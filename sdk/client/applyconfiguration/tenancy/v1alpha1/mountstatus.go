@@ -19,16 +19,14 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
 // MountStatusApplyConfiguration represents an declarative configuration of the MountStatus type for use
 // with apply.
 type MountStatusApplyConfiguration struct {
-	Phase      *v1alpha1.LogicalClusterPhaseType `json:"phase,omitempty"`
-	Conditions *conditionsv1alpha1.Conditions    `json:"conditions,omitempty"`
-	URL        *string                           `json:"url,omitempty"`
+	Phase *v1alpha1.MountPhaseType `json:"phase,omitempty"`
+	URL   *string                  `json:"url,omitempty"`
 }
 
 // MountStatusApplyConfiguration constructs an declarative configuration of the MountStatus type for use with
@@ -40,19 +38,11 @@ func MountStatus() *MountStatusApplyConfiguration {
 // WithPhase sets the Phase field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Phase field is set to the value of the last call.
-func (b *MountStatusApplyConfiguration) WithPhase(value v1alpha1.LogicalClusterPhaseType) *MountStatusApplyConfiguration {
+func (b *MountStatusApplyConfiguration) WithPhase(value v1alpha1.MountPhaseType) *MountStatusApplyConfiguration {
 	b.Phase = &value
 	return b
 }
 
-// WithConditions sets the Conditions field in the declarative configuration to the given value
-// and returns the receiver, so that objects can be built by chaining "With" function invocations.
-// If called multiple times, the Conditions field is set to the value of the last call.
-func (b *MountStatusApplyConfiguration) WithConditions(value conditionsv1alpha1.Conditions) *MountStatusApplyConfiguration {
-	b.Conditions = &value
-	return b
-}
-
 // WithURL sets the URL field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the URL field is set to the value of the last call.
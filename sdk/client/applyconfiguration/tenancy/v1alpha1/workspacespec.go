@@ -18,13 +18,20 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // WorkspaceSpecApplyConfiguration represents an declarative configuration of the WorkspaceSpec type for use
 // with apply.
 type WorkspaceSpecApplyConfiguration struct {
-	Type     *WorkspaceTypeReferenceApplyConfiguration `json:"type,omitempty"`
-	Location *WorkspaceLocationApplyConfiguration      `json:"location,omitempty"`
-	Cluster  *string                                   `json:"cluster,omitempty"`
-	URL      *string                                   `json:"URL,omitempty"`
+	Type        *WorkspaceTypeReferenceApplyConfiguration `json:"type,omitempty"`
+	Location    *WorkspaceLocationApplyConfiguration      `json:"location,omitempty"`
+	Cluster     *string                                   `json:"cluster,omitempty"`
+	URL         *string                                   `json:"URL,omitempty"`
+	Mount       *MountApplyConfiguration                  `json:"mount,omitempty"`
+	AuditPolicy *AuditPolicyApplyConfiguration            `json:"auditPolicy,omitempty"`
+	TTL         *v1.Duration                              `json:"ttl,omitempty"`
 }
 
 // WorkspaceSpecApplyConfiguration constructs an declarative configuration of the WorkspaceSpec type for use with
@@ -64,3 +71,27 @@ func (b *WorkspaceSpecApplyConfiguration) WithURL(value string) *WorkspaceSpecAp
 	b.URL = &value
 	return b
 }
+
+// WithMount sets the Mount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mount field is set to the value of the last call.
+func (b *WorkspaceSpecApplyConfiguration) WithMount(value *MountApplyConfiguration) *WorkspaceSpecApplyConfiguration {
+	b.Mount = value
+	return b
+}
+
+// WithAuditPolicy sets the AuditPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AuditPolicy field is set to the value of the last call.
+func (b *WorkspaceSpecApplyConfiguration) WithAuditPolicy(value *AuditPolicyApplyConfiguration) *WorkspaceSpecApplyConfiguration {
+	b.AuditPolicy = value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *WorkspaceSpecApplyConfiguration) WithTTL(value v1.Duration) *WorkspaceSpecApplyConfiguration {
+	b.TTL = &value
+	return b
+}
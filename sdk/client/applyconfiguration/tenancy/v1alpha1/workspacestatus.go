@@ -29,6 +29,8 @@ type WorkspaceStatusApplyConfiguration struct {
 	Phase        *v1alpha1.LogicalClusterPhaseType    `json:"phase,omitempty"`
 	Conditions   *conditionsv1alpha1.Conditions       `json:"conditions,omitempty"`
 	Initializers []v1alpha1.LogicalClusterInitializer `json:"initializers,omitempty"`
+	Terminators  []v1alpha1.LogicalClusterTerminator  `json:"terminators,omitempty"`
+	Mount        *MountStatusApplyConfiguration       `json:"mount,omitempty"`
 }
 
 // WorkspaceStatusApplyConfiguration constructs an declarative configuration of the WorkspaceStatus type for use with
@@ -62,3 +64,21 @@ func (b *WorkspaceStatusApplyConfiguration) WithInitializers(values ...v1alpha1.
 	}
 	return b
 }
+
+// WithTerminators adds the given value to the Terminators field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Terminators field.
+func (b *WorkspaceStatusApplyConfiguration) WithTerminators(values ...v1alpha1.LogicalClusterTerminator) *WorkspaceStatusApplyConfiguration {
+	for i := range values {
+		b.Terminators = append(b.Terminators, values[i])
+	}
+	return b
+}
+
+// WithMount sets the Mount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mount field is set to the value of the last call.
+func (b *WorkspaceStatusApplyConfiguration) WithMount(value *MountStatusApplyConfiguration) *WorkspaceStatusApplyConfiguration {
+	b.Mount = value
+	return b
+}
@@ -18,10 +18,15 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
 // WorkspaceTypeExtensionApplyConfiguration represents an declarative configuration of the WorkspaceTypeExtension type for use
 // with apply.
 type WorkspaceTypeExtensionApplyConfiguration struct {
-	With []WorkspaceTypeReferenceApplyConfiguration `json:"with,omitempty"`
+	With      []WorkspaceTypeReferenceApplyConfiguration `json:"with,omitempty"`
+	Overrides []tenancyv1alpha1.WorkspaceTypeField       `json:"overrides,omitempty"`
 }
 
 // WorkspaceTypeExtensionApplyConfiguration constructs an declarative configuration of the WorkspaceTypeExtension type for use with
@@ -42,3 +47,13 @@ func (b *WorkspaceTypeExtensionApplyConfiguration) WithWith(values ...*Workspace
 	}
 	return b
 }
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *WorkspaceTypeExtensionApplyConfiguration) WithOverrides(values ...tenancyv1alpha1.WorkspaceTypeField) *WorkspaceTypeExtensionApplyConfiguration {
+	for i := range values {
+		b.Overrides = append(b.Overrides, values[i])
+	}
+	return b
+}
@@ -18,16 +18,30 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
 // WorkspaceTypeSpecApplyConfiguration represents an declarative configuration of the WorkspaceTypeSpec type for use
 // with apply.
 type WorkspaceTypeSpecApplyConfiguration struct {
-	Initializer               *bool                                     `json:"initializer,omitempty"`
-	Extend                    *WorkspaceTypeExtensionApplyConfiguration `json:"extend,omitempty"`
-	AdditionalWorkspaceLabels map[string]string                         `json:"additionalWorkspaceLabels,omitempty"`
-	DefaultChildWorkspaceType *WorkspaceTypeReferenceApplyConfiguration `json:"defaultChildWorkspaceType,omitempty"`
-	LimitAllowedChildren      *WorkspaceTypeSelectorApplyConfiguration  `json:"limitAllowedChildren,omitempty"`
-	LimitAllowedParents       *WorkspaceTypeSelectorApplyConfiguration  `json:"limitAllowedParents,omitempty"`
-	DefaultAPIBindings        []APIExportReferenceApplyConfiguration    `json:"defaultAPIBindings,omitempty"`
+	Initializer               *bool                                       `json:"initializer,omitempty"`
+	Terminator                *bool                                       `json:"terminator,omitempty"`
+	Extend                    *WorkspaceTypeExtensionApplyConfiguration   `json:"extend,omitempty"`
+	AdditionalWorkspaceLabels map[string]string                           `json:"additionalWorkspaceLabels,omitempty"`
+	DefaultChildWorkspaceType *WorkspaceTypeReferenceApplyConfiguration   `json:"defaultChildWorkspaceType,omitempty"`
+	LimitAllowedChildren      *WorkspaceTypeSelectorApplyConfiguration    `json:"limitAllowedChildren,omitempty"`
+	LimitAllowedParents       *WorkspaceTypeSelectorApplyConfiguration    `json:"limitAllowedParents,omitempty"`
+	ChildNamePolicy           *WorkspaceNamePolicyApplyConfiguration      `json:"childNamePolicy,omitempty"`
+	DefaultAPIBindings        []APIExportReferenceApplyConfiguration      `json:"defaultAPIBindings,omitempty"`
+	RetentionPolicy           *WorkspaceRetentionPolicyApplyConfiguration `json:"retentionPolicy,omitempty"`
+	InitializationTimeout     *v1.Duration                                `json:"initializationTimeout,omitempty"`
+	Template                  *WorkspaceTemplateApplyConfiguration        `json:"template,omitempty"`
+	Limits                    *WorkspaceTypeLimitsApplyConfiguration      `json:"limits,omitempty"`
+	AuditPolicy               *AuditPolicyApplyConfiguration              `json:"auditPolicy,omitempty"`
+	PlacementStrategy         *tenancyv1alpha1.WorkspacePlacementStrategy `json:"placementStrategy,omitempty"`
 }
 
 // WorkspaceTypeSpecApplyConfiguration constructs an declarative configuration of the WorkspaceTypeSpec type for use with
@@ -44,6 +58,14 @@ func (b *WorkspaceTypeSpecApplyConfiguration) WithInitializer(value bool) *Works
 	return b
 }
 
+// WithTerminator sets the Terminator field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Terminator field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithTerminator(value bool) *WorkspaceTypeSpecApplyConfiguration {
+	b.Terminator = &value
+	return b
+}
+
 // WithExtend sets the Extend field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Extend field is set to the value of the last call.
@@ -90,6 +112,14 @@ func (b *WorkspaceTypeSpecApplyConfiguration) WithLimitAllowedParents(value *Wor
 	return b
 }
 
+// WithChildNamePolicy sets the ChildNamePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChildNamePolicy field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithChildNamePolicy(value *WorkspaceNamePolicyApplyConfiguration) *WorkspaceTypeSpecApplyConfiguration {
+	b.ChildNamePolicy = value
+	return b
+}
+
 // WithDefaultAPIBindings adds the given value to the DefaultAPIBindings field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the DefaultAPIBindings field.
@@ -102,3 +132,51 @@ func (b *WorkspaceTypeSpecApplyConfiguration) WithDefaultAPIBindings(values ...*
 	}
 	return b
 }
+
+// WithRetentionPolicy sets the RetentionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetentionPolicy field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithRetentionPolicy(value *WorkspaceRetentionPolicyApplyConfiguration) *WorkspaceTypeSpecApplyConfiguration {
+	b.RetentionPolicy = value
+	return b
+}
+
+// WithInitializationTimeout sets the InitializationTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InitializationTimeout field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithInitializationTimeout(value v1.Duration) *WorkspaceTypeSpecApplyConfiguration {
+	b.InitializationTimeout = &value
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithTemplate(value *WorkspaceTemplateApplyConfiguration) *WorkspaceTypeSpecApplyConfiguration {
+	b.Template = value
+	return b
+}
+
+// WithLimits sets the Limits field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Limits field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithLimits(value *WorkspaceTypeLimitsApplyConfiguration) *WorkspaceTypeSpecApplyConfiguration {
+	b.Limits = value
+	return b
+}
+
+// WithAuditPolicy sets the AuditPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AuditPolicy field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithAuditPolicy(value *AuditPolicyApplyConfiguration) *WorkspaceTypeSpecApplyConfiguration {
+	b.AuditPolicy = value
+	return b
+}
+
+// WithPlacementStrategy sets the PlacementStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PlacementStrategy field is set to the value of the last call.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithPlacementStrategy(value tenancyv1alpha1.WorkspacePlacementStrategy) *WorkspaceTypeSpecApplyConfiguration {
+	b.PlacementStrategy = &value
+	return b
+}
@@ -30,7 +30,8 @@ import (
 type PartitionApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *PartitionSpecApplyConfiguration `json:"spec,omitempty"`
+	Spec                             *PartitionSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *PartitionStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // Partition constructs an declarative configuration of the Partition type for use with
@@ -208,3 +209,11 @@ func (b *PartitionApplyConfiguration) WithSpec(value *PartitionSpecApplyConfigur
 	b.Spec = value
 	return b
 }
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *PartitionApplyConfiguration) WithStatus(value *PartitionStatusApplyConfiguration) *PartitionApplyConfiguration {
+	b.Status = value
+	return b
+}
@@ -25,8 +25,10 @@ import (
 // PartitionSetSpecApplyConfiguration represents an declarative configuration of the PartitionSetSpec type for use
 // with apply.
 type PartitionSetSpecApplyConfiguration struct {
-	Dimensions    []string                            `json:"dimensions,omitempty"`
-	ShardSelector *v1.LabelSelectorApplyConfiguration `json:"shardSelector,omitempty"`
+	Dimensions            []string                            `json:"dimensions,omitempty"`
+	ShardSelector         *v1.LabelSelectorApplyConfiguration `json:"shardSelector,omitempty"`
+	MaxShardsPerPartition *int32                              `json:"maxShardsPerPartition,omitempty"`
+	Rebalance             *string                             `json:"rebalance,omitempty"`
 }
 
 // PartitionSetSpecApplyConfiguration constructs an declarative configuration of the PartitionSetSpec type for use with
@@ -52,3 +54,19 @@ func (b *PartitionSetSpecApplyConfiguration) WithShardSelector(value *v1.LabelSe
 	b.ShardSelector = value
 	return b
 }
+
+// WithMaxShardsPerPartition sets the MaxShardsPerPartition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxShardsPerPartition field is set to the value of the last call.
+func (b *PartitionSetSpecApplyConfiguration) WithMaxShardsPerPartition(value int32) *PartitionSetSpecApplyConfiguration {
+	b.MaxShardsPerPartition = &value
+	return b
+}
+
+// WithRebalance sets the Rebalance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rebalance field is set to the value of the last call.
+func (b *PartitionSetSpecApplyConfiguration) WithRebalance(value string) *PartitionSetSpecApplyConfiguration {
+	b.Rebalance = &value
+	return b
+}
@@ -26,6 +26,7 @@ import (
 // with apply.
 type PartitionSetStatusApplyConfiguration struct {
 	Count      *uint16              `json:"count,omitempty"`
+	Rebalance  *string              `json:"rebalance,omitempty"`
 	Conditions *v1alpha1.Conditions `json:"conditions,omitempty"`
 }
 
@@ -43,6 +44,14 @@ func (b *PartitionSetStatusApplyConfiguration) WithCount(value uint16) *Partitio
 	return b
 }
 
+// WithRebalance sets the Rebalance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rebalance field is set to the value of the last call.
+func (b *PartitionSetStatusApplyConfiguration) WithRebalance(value string) *PartitionSetStatusApplyConfiguration {
+	b.Rebalance = &value
+	return b
+}
+
 // WithConditions sets the Conditions field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Conditions field is set to the value of the last call.
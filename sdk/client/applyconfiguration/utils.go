@@ -24,11 +24,13 @@ import (
 	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 
 	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	v1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/apis/v1alpha2"
 	applyconfigurationconditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/conditions/v1alpha1"
 	applyconfigurationcorev1alpha1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/core/v1alpha1"
 	applyconfigurationmetav1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
@@ -97,6 +99,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apisv1alpha1.PermissionClaimApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceSelector"):
 		return &apisv1alpha1.ResourceSelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StorageMigration"):
+		return &apisv1alpha1.StorageMigrationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StorageVersionMigration"):
+		return &apisv1alpha1.StorageVersionMigrationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StorageVersionMigrationSpec"):
+		return &apisv1alpha1.StorageVersionMigrationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StorageVersionMigrationStatus"):
+		return &apisv1alpha1.StorageVersionMigrationStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("VirtualWorkspace"):
 		return &apisv1alpha1.VirtualWorkspaceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("WebhookClientConfig"):
@@ -104,6 +114,46 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	case v1alpha1.SchemeGroupVersion.WithKind("WebhookConversion"):
 		return &apisv1alpha1.WebhookConversionApplyConfiguration{}
 
+		// Group=apis.kcp.io, Version=v1alpha2
+	case v1alpha2.SchemeGroupVersion.WithKind("AcceptablePermissionClaim"):
+		return &apisv1alpha2.AcceptablePermissionClaimApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("APIBinding"):
+		return &apisv1alpha2.APIBindingApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("APIBindingSpec"):
+		return &apisv1alpha2.APIBindingSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("APIBindingStatus"):
+		return &apisv1alpha2.APIBindingStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("APIExport"):
+		return &apisv1alpha2.APIExportApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("APIExportSpec"):
+		return &apisv1alpha2.APIExportSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("APIExportStatus"):
+		return &apisv1alpha2.APIExportStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("BindingReference"):
+		return &apisv1alpha2.BindingReferenceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("BoundAPIResource"):
+		return &apisv1alpha2.BoundAPIResourceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("BoundAPIResourceSchema"):
+		return &apisv1alpha2.BoundAPIResourceSchemaApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ExportBindingReference"):
+		return &apisv1alpha2.ExportBindingReferenceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("GroupResource"):
+		return &apisv1alpha2.GroupResourceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("Identity"):
+		return &apisv1alpha2.IdentityApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("MaximalPermissionPolicy"):
+		return &apisv1alpha2.MaximalPermissionPolicyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PermissionClaim"):
+		return &apisv1alpha2.PermissionClaimApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PermissionClaimSelector"):
+		return &apisv1alpha2.PermissionClaimSelectorApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ResourceSchema"):
+		return &apisv1alpha2.ResourceSchemaApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ResourceSelector"):
+		return &apisv1alpha2.ResourceSelectorApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("StorageMigration"):
+		return &apisv1alpha2.StorageMigrationApplyConfiguration{}
+
 		// Group=conditions, Version=v1alpha1
 	case conditionsv1alpha1.SchemeGroupVersion.WithKind("Condition"):
 		return &applyconfigurationconditionsv1alpha1.ConditionApplyConfiguration{}
@@ -117,12 +167,26 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationcorev1alpha1.LogicalClusterSpecApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("LogicalClusterStatus"):
 		return &applyconfigurationcorev1alpha1.LogicalClusterStatusApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("LogicalClusterUsage"):
+		return &applyconfigurationcorev1alpha1.LogicalClusterUsageApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ResourceUsage"):
+		return &applyconfigurationcorev1alpha1.ResourceUsageApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("Shard"):
 		return &applyconfigurationcorev1alpha1.ShardApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ShardBackup"):
+		return &applyconfigurationcorev1alpha1.ShardBackupApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ShardBackupSpec"):
+		return &applyconfigurationcorev1alpha1.ShardBackupSpecApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ShardBackupStatus"):
+		return &applyconfigurationcorev1alpha1.ShardBackupStatusApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ShardDrainStatus"):
+		return &applyconfigurationcorev1alpha1.ShardDrainStatusApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ShardSpec"):
 		return &applyconfigurationcorev1alpha1.ShardSpecApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ShardStatus"):
 		return &applyconfigurationcorev1alpha1.ShardStatusApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ShardUsage"):
+		return &applyconfigurationcorev1alpha1.ShardUsageApplyConfiguration{}
 
 		// Group=meta.k8s.io, Version=v1
 	case v1.SchemeGroupVersion.WithKind("Condition"):
@@ -145,20 +209,76 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		// Group=tenancy.kcp.io, Version=v1alpha1
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("APIExportReference"):
 		return &applyconfigurationtenancyv1alpha1.APIExportReferenceApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditGroupResources"):
+		return &applyconfigurationtenancyv1alpha1.AuditGroupResourcesApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditPolicy"):
+		return &applyconfigurationtenancyv1alpha1.AuditPolicyApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditPolicyRule"):
+		return &applyconfigurationtenancyv1alpha1.AuditPolicyRuleApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditSink"):
+		return &applyconfigurationtenancyv1alpha1.AuditSinkApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditSinkFile"):
+		return &applyconfigurationtenancyv1alpha1.AuditSinkFileApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditSinkSpec"):
+		return &applyconfigurationtenancyv1alpha1.AuditSinkSpecApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("AuditSinkWebhook"):
+		return &applyconfigurationtenancyv1alpha1.AuditSinkWebhookApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("GroupResource"):
+		return &applyconfigurationtenancyv1alpha1.GroupResourceApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("Mount"):
+		return &applyconfigurationtenancyv1alpha1.MountApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("MountStatus"):
+		return &applyconfigurationtenancyv1alpha1.MountStatusApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("ObjectReference"):
+		return &applyconfigurationtenancyv1alpha1.ObjectReferenceApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("ReferenceGrant"):
+		return &applyconfigurationtenancyv1alpha1.ReferenceGrantApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("ReferenceGrantFrom"):
+		return &applyconfigurationtenancyv1alpha1.ReferenceGrantFromApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("ReferenceGrantSpec"):
+		return &applyconfigurationtenancyv1alpha1.ReferenceGrantSpecApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("ReferenceGrantTo"):
+		return &applyconfigurationtenancyv1alpha1.ReferenceGrantToApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("VirtualWorkspace"):
 		return &applyconfigurationtenancyv1alpha1.VirtualWorkspaceApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("Workspace"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceLocation"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceLocationApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceNamePolicy"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceNamePolicyApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceQuota"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceQuotaApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceQuotaResources"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceQuotaResourcesApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceQuotaSpec"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceQuotaSpecApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceQuotaStatus"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceQuotaStatusApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceRetentionPolicy"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceRetentionPolicyApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceRoleBinding"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceRoleBindingApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceRoleBindingSpec"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceRoleBindingSpecApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceRoleBindingStatus"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceRoleBindingStatusApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceRoleRef"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceRoleRefApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceSpec"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceSpecApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceStatus"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceStatusApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceTemplate"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceTemplateApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceTemplateObject"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceTemplateObjectApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceType"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceTypeApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceTypeExtension"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceTypeExtensionApplyConfiguration{}
+	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceTypeLimits"):
+		return &applyconfigurationtenancyv1alpha1.WorkspaceTypeLimitsApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceTypeReference"):
 		return &applyconfigurationtenancyv1alpha1.WorkspaceTypeReferenceApplyConfiguration{}
 	case tenancyv1alpha1.SchemeGroupVersion.WithKind("WorkspaceTypeSelector"):
@@ -177,8 +297,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationtopologyv1alpha1.PartitionSetSpecApplyConfiguration{}
 	case topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionSetStatus"):
 		return &applyconfigurationtopologyv1alpha1.PartitionSetStatusApplyConfiguration{}
+	case topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionShardStatus"):
+		return &applyconfigurationtopologyv1alpha1.PartitionShardStatusApplyConfiguration{}
 	case topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionSpec"):
 		return &applyconfigurationtopologyv1alpha1.PartitionSpecApplyConfiguration{}
+	case topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionStatus"):
+		return &applyconfigurationtopologyv1alpha1.PartitionStatusApplyConfiguration{}
 
 	}
 	return nil
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// NewClusterAwareClientset returns a clientset like NewSimpleClientset for tests spanning many
+// logical clusters. The objects are tracked per logical cluster of their kcp.io/cluster annotation,
+// and lists and watches of the cluster clients span all logical clusters. Objects without logical
+// cluster, and requests of clients scoped to no logical cluster, are errors instead of silently
+// ending up in a logical cluster no real client could reach.
+func NewClusterAwareClientset(objects ...runtime.Object) (*ClusterClientset, error) {
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if logicalcluster.From(accessor).Empty() {
+			return nil, fmt.Errorf("%T %s must have the %s annotation", obj, accessor.GetName(), logicalcluster.AnnotationKey)
+		}
+	}
+
+	cs := NewSimpleClientset(objects...)
+	cs.PrependReactor("*", "*", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		if action.GetCluster().Empty() {
+			return true, nil, missingClusterError(action)
+		}
+		return false, nil, nil
+	})
+	cs.PrependWatchReactor("*", func(action kcptesting.Action) (bool, watch.Interface, error) {
+		if action.GetCluster().Empty() {
+			return true, nil, missingClusterError(action)
+		}
+		return false, nil, nil
+	})
+	return cs, nil
+}
+
+func missingClusterError(action kcptesting.Action) error {
+	return fmt.Errorf("%s of %s without logical cluster, the client must be scoped with Cluster()", action.GetVerb(), action.GetResource().GroupResource())
+}
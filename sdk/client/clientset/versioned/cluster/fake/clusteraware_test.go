@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func newSchema(cluster, name string) *apisv1alpha1.APIResourceSchema {
+	schema := &apisv1alpha1.APIResourceSchema{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if cluster != "" {
+		schema.Annotations = map[string]string{logicalcluster.AnnotationKey: cluster}
+	}
+	return schema
+}
+
+func TestClusterAwareClientset(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewClusterAwareClientset(newSchema("", "widgets"))
+	require.Error(t, err, "objects without logical cluster should be rejected")
+
+	cs, err := NewClusterAwareClientset(newSchema("one", "widgets"), newSchema("two", "widgets"))
+	require.NoError(t, err)
+	schemas := cs.ApisV1alpha1().APIResourceSchemas()
+
+	one, err := schemas.Cluster(logicalcluster.NewPath("one")).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, one.Items, 1)
+	all, err := schemas.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, all.Items, 2, "wildcard lists should span all logical clusters")
+
+	w, err := schemas.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+	_, err = schemas.Cluster(logicalcluster.NewPath("three")).Create(ctx, newSchema("", "gadgets"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	select {
+	case event := <-w.ResultChan():
+		require.Equal(t, watch.Added, event.Type)
+		require.Equal(t, "gadgets", event.Object.(*apisv1alpha1.APIResourceSchema).Name)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("wildcard watches should see objects of any logical cluster")
+	}
+
+	_, err = schemas.Cluster(logicalcluster.NewPath("three")).Get(ctx, "widgets", metav1.GetOptions{})
+	require.Error(t, err, "objects of other logical clusters should not be found")
+
+	_, err = schemas.Cluster(logicalcluster.Path{}).Get(ctx, "widgets", metav1.GetOptions{})
+	require.ErrorContains(t, err, "without logical cluster")
+	_, err = schemas.Cluster(logicalcluster.Path{}).Watch(ctx, metav1.ListOptions{})
+	require.ErrorContains(t, err, "without logical cluster")
+}
@@ -64,6 +64,10 @@ func (c *ApisV1alpha1ClusterClient) APIConversions() kcpapisv1alpha1.APIConversi
 	return &aPIConversionsClusterClient{Fake: c.Fake}
 }
 
+func (c *ApisV1alpha1ClusterClient) StorageVersionMigrations() kcpapisv1alpha1.StorageVersionMigrationClusterInterface {
+	return &storageVersionMigrationsClusterClient{Fake: c.Fake}
+}
+
 var _ apisv1alpha1.ApisV1alpha1Interface = (*ApisV1alpha1Client)(nil)
 
 type ApisV1alpha1Client struct {
@@ -95,3 +99,7 @@ func (c *ApisV1alpha1Client) APIResourceSchemas() apisv1alpha1.APIResourceSchema
 func (c *ApisV1alpha1Client) APIConversions() apisv1alpha1.APIConversionInterface {
 	return &aPIConversionsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
+
+func (c *ApisV1alpha1Client) StorageVersionMigrations() apisv1alpha1.StorageVersionMigrationInterface {
+	return &storageVersionMigrationsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
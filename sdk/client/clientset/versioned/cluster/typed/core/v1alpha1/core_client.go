@@ -36,6 +36,7 @@ type CoreV1alpha1ClusterInterface interface {
 	CoreV1alpha1ClusterScoper
 	LogicalClustersClusterGetter
 	ShardsClusterGetter
+	ShardBackupsClusterGetter
 }
 
 type CoreV1alpha1ClusterScoper interface {
@@ -61,6 +62,10 @@ func (c *CoreV1alpha1ClusterClient) Shards() ShardClusterInterface {
 	return &shardsClusterInterface{clientCache: c.clientCache}
 }
 
+func (c *CoreV1alpha1ClusterClient) ShardBackups() ShardBackupClusterInterface {
+	return &shardBackupsClusterInterface{clientCache: c.clientCache}
+}
+
 // NewForConfig creates a new CoreV1alpha1ClusterClient for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -52,6 +52,10 @@ func (c *CoreV1alpha1ClusterClient) Shards() kcpcorev1alpha1.ShardClusterInterfa
 	return &shardsClusterClient{Fake: c.Fake}
 }
 
+func (c *CoreV1alpha1ClusterClient) ShardBackups() kcpcorev1alpha1.ShardBackupClusterInterface {
+	return &shardBackupsClusterClient{Fake: c.Fake}
+}
+
 var _ corev1alpha1.CoreV1alpha1Interface = (*CoreV1alpha1Client)(nil)
 
 type CoreV1alpha1Client struct {
@@ -71,3 +75,7 @@ func (c *CoreV1alpha1Client) LogicalClusters() corev1alpha1.LogicalClusterInterf
 func (c *CoreV1alpha1Client) Shards() corev1alpha1.ShardInterface {
 	return &shardsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
+
+func (c *CoreV1alpha1Client) ShardBackups() corev1alpha1.ShardBackupInterface {
+	return &shardBackupsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
@@ -44,10 +44,26 @@ func (c *TenancyV1alpha1ClusterClient) Cluster(clusterPath logicalcluster.Path)
 	return &TenancyV1alpha1Client{Fake: c.Fake, ClusterPath: clusterPath}
 }
 
+func (c *TenancyV1alpha1ClusterClient) AuditSinks() kcptenancyv1alpha1.AuditSinkClusterInterface {
+	return &auditSinksClusterClient{Fake: c.Fake}
+}
+
+func (c *TenancyV1alpha1ClusterClient) ReferenceGrants() kcptenancyv1alpha1.ReferenceGrantClusterInterface {
+	return &referenceGrantsClusterClient{Fake: c.Fake}
+}
+
 func (c *TenancyV1alpha1ClusterClient) Workspaces() kcptenancyv1alpha1.WorkspaceClusterInterface {
 	return &workspacesClusterClient{Fake: c.Fake}
 }
 
+func (c *TenancyV1alpha1ClusterClient) WorkspaceQuotas() kcptenancyv1alpha1.WorkspaceQuotaClusterInterface {
+	return &workspaceQuotasClusterClient{Fake: c.Fake}
+}
+
+func (c *TenancyV1alpha1ClusterClient) WorkspaceRoleBindings() kcptenancyv1alpha1.WorkspaceRoleBindingClusterInterface {
+	return &workspaceRoleBindingsClusterClient{Fake: c.Fake}
+}
+
 func (c *TenancyV1alpha1ClusterClient) WorkspaceTypes() kcptenancyv1alpha1.WorkspaceTypeClusterInterface {
 	return &workspaceTypesClusterClient{Fake: c.Fake}
 }
@@ -64,10 +80,26 @@ func (c *TenancyV1alpha1Client) RESTClient() rest.Interface {
 	return ret
 }
 
+func (c *TenancyV1alpha1Client) AuditSinks() tenancyv1alpha1.AuditSinkInterface {
+	return &auditSinksClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
+func (c *TenancyV1alpha1Client) ReferenceGrants() tenancyv1alpha1.ReferenceGrantInterface {
+	return &referenceGrantsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
 func (c *TenancyV1alpha1Client) Workspaces() tenancyv1alpha1.WorkspaceInterface {
 	return &workspacesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
 
+func (c *TenancyV1alpha1Client) WorkspaceQuotas() tenancyv1alpha1.WorkspaceQuotaInterface {
+	return &workspaceQuotasClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
+func (c *TenancyV1alpha1Client) WorkspaceRoleBindings() tenancyv1alpha1.WorkspaceRoleBindingInterface {
+	return &workspaceRoleBindingsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
 func (c *TenancyV1alpha1Client) WorkspaceTypes() tenancyv1alpha1.WorkspaceTypeInterface {
 	return &workspaceTypesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
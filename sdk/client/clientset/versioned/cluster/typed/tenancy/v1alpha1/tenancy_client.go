@@ -34,7 +34,11 @@ import (
 
 type TenancyV1alpha1ClusterInterface interface {
 	TenancyV1alpha1ClusterScoper
+	AuditSinksClusterGetter
+	ReferenceGrantsClusterGetter
 	WorkspacesClusterGetter
+	WorkspaceQuotasClusterGetter
+	WorkspaceRoleBindingsClusterGetter
 	WorkspaceTypesClusterGetter
 }
 
@@ -53,10 +57,26 @@ func (c *TenancyV1alpha1ClusterClient) Cluster(clusterPath logicalcluster.Path)
 	return c.clientCache.ClusterOrDie(clusterPath)
 }
 
+func (c *TenancyV1alpha1ClusterClient) AuditSinks() AuditSinkClusterInterface {
+	return &auditSinksClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) ReferenceGrants() ReferenceGrantClusterInterface {
+	return &referenceGrantsClusterInterface{clientCache: c.clientCache}
+}
+
 func (c *TenancyV1alpha1ClusterClient) Workspaces() WorkspaceClusterInterface {
 	return &workspacesClusterInterface{clientCache: c.clientCache}
 }
 
+func (c *TenancyV1alpha1ClusterClient) WorkspaceQuotas() WorkspaceQuotaClusterInterface {
+	return &workspaceQuotasClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) WorkspaceRoleBindings() WorkspaceRoleBindingClusterInterface {
+	return &workspaceRoleBindingsClusterInterface{clientCache: c.clientCache}
+}
+
 func (c *TenancyV1alpha1ClusterClient) WorkspaceTypes() WorkspaceTypeClusterInterface {
 	return &workspaceTypesClusterInterface{clientCache: c.clientCache}
 }
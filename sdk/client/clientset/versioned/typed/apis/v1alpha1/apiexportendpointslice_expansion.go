@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client"
+)
+
+type APIExportEndpointSliceExpansion interface {
+	// RESTConfigs gets the named APIExportEndpointSlice and returns a copy of config for the
+	// wildcard cluster of the virtual workspace of every endpoint, by endpoint URL. If shards
+	// are given, only their endpoints are returned.
+	RESTConfigs(ctx context.Context, name string, config *rest.Config, shards ...*corev1alpha1.Shard) (map[string]*rest.Config, error)
+}
+
+func (c *aPIExportEndpointSlices) RESTConfigs(ctx context.Context, name string, config *rest.Config, shards ...*corev1alpha1.Shard) (map[string]*rest.Config, error) {
+	slice, err := c.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return kcpclient.APIExportEndpointSliceRESTConfigs(slice, config, shards...)
+}
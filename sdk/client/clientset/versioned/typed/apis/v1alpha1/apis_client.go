@@ -34,6 +34,7 @@ type ApisV1alpha1Interface interface {
 	APIExportsGetter
 	APIExportEndpointSlicesGetter
 	APIResourceSchemasGetter
+	StorageVersionMigrationsGetter
 }
 
 // ApisV1alpha1Client is used to interact with features provided by the apis.kcp.io group.
@@ -61,6 +62,10 @@ func (c *ApisV1alpha1Client) APIResourceSchemas() APIResourceSchemaInterface {
 	return newAPIResourceSchemas(c)
 }
 
+func (c *ApisV1alpha1Client) StorageVersionMigrations() StorageVersionMigrationInterface {
+	return newStorageVersionMigrations(c)
+}
+
 // NewForConfig creates a new ApisV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -24,6 +24,6 @@ type APIConversionExpansion interface{}
 
 type APIExportExpansion interface{}
 
-type APIExportEndpointSliceExpansion interface{}
-
 type APIResourceSchemaExpansion interface{}
+
+type StorageVersionMigrationExpansion interface{}
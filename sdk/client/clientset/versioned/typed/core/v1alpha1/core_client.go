@@ -31,6 +31,7 @@ type CoreV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	LogicalClustersGetter
 	ShardsGetter
+	ShardBackupsGetter
 }
 
 // CoreV1alpha1Client is used to interact with features provided by the core.kcp.io group.
@@ -46,6 +47,10 @@ func (c *CoreV1alpha1Client) Shards() ShardInterface {
 	return newShards(c)
 }
 
+func (c *CoreV1alpha1Client) ShardBackups() ShardBackupInterface {
+	return newShardBackups(c)
+}
+
 // NewForConfig creates a new CoreV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -37,6 +37,10 @@ func (c *FakeCoreV1alpha1) Shards() v1alpha1.ShardInterface {
 	return &FakeShards{c}
 }
 
+func (c *FakeCoreV1alpha1) ShardBackups() v1alpha1.ShardBackupInterface {
+	return &FakeShardBackups{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCoreV1alpha1) RESTClient() rest.Interface {
@@ -21,3 +21,5 @@ package v1alpha1
 type LogicalClusterExpansion interface{}
 
 type ShardExpansion interface{}
+
+type ShardBackupExpansion interface{}
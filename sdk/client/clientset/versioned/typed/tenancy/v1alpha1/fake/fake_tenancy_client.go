@@ -29,10 +29,26 @@ type FakeTenancyV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTenancyV1alpha1) AuditSinks() v1alpha1.AuditSinkInterface {
+	return &FakeAuditSinks{c}
+}
+
+func (c *FakeTenancyV1alpha1) ReferenceGrants() v1alpha1.ReferenceGrantInterface {
+	return &FakeReferenceGrants{c}
+}
+
 func (c *FakeTenancyV1alpha1) Workspaces() v1alpha1.WorkspaceInterface {
 	return &FakeWorkspaces{c}
 }
 
+func (c *FakeTenancyV1alpha1) WorkspaceQuotas() v1alpha1.WorkspaceQuotaInterface {
+	return &FakeWorkspaceQuotas{c}
+}
+
+func (c *FakeTenancyV1alpha1) WorkspaceRoleBindings() v1alpha1.WorkspaceRoleBindingInterface {
+	return &FakeWorkspaceRoleBindings{c}
+}
+
 func (c *FakeTenancyV1alpha1) WorkspaceTypes() v1alpha1.WorkspaceTypeInterface {
 	return &FakeWorkspaceTypes{c}
 }
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+type AuditSinkExpansion interface{}
+
+type ReferenceGrantExpansion interface{}
+
 type WorkspaceExpansion interface{}
 
+type WorkspaceQuotaExpansion interface{}
+
+type WorkspaceRoleBindingExpansion interface{}
+
 type WorkspaceTypeExpansion interface{}
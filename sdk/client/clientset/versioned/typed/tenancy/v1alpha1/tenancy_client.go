@@ -29,7 +29,11 @@ import (
 
 type TenancyV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AuditSinksGetter
+	ReferenceGrantsGetter
 	WorkspacesGetter
+	WorkspaceQuotasGetter
+	WorkspaceRoleBindingsGetter
 	WorkspaceTypesGetter
 }
 
@@ -38,10 +42,26 @@ type TenancyV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TenancyV1alpha1Client) AuditSinks() AuditSinkInterface {
+	return newAuditSinks(c)
+}
+
+func (c *TenancyV1alpha1Client) ReferenceGrants() ReferenceGrantInterface {
+	return newReferenceGrants(c)
+}
+
 func (c *TenancyV1alpha1Client) Workspaces() WorkspaceInterface {
 	return newWorkspaces(c)
 }
 
+func (c *TenancyV1alpha1Client) WorkspaceQuotas() WorkspaceQuotaInterface {
+	return newWorkspaceQuotas(c)
+}
+
+func (c *TenancyV1alpha1Client) WorkspaceRoleBindings() WorkspaceRoleBindingInterface {
+	return newWorkspaceRoleBindings(c)
+}
+
 func (c *TenancyV1alpha1Client) WorkspaceTypes() WorkspaceTypeInterface {
 	return newWorkspaceTypes(c)
 }
@@ -99,6 +99,17 @@ func (c *FakePartitions) Update(ctx context.Context, partition *v1alpha1.Partiti
 	return obj.(*v1alpha1.Partition), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePartitions) UpdateStatus(ctx context.Context, partition *v1alpha1.Partition, opts v1.UpdateOptions) (*v1alpha1.Partition, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(partitionsResource, "status", partition), &v1alpha1.Partition{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Partition), err
+}
+
 // Delete takes name of the partition and deletes it. Returns an error if one occurs.
 func (c *FakePartitions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -144,3 +155,25 @@ func (c *FakePartitions) Apply(ctx context.Context, partition *topologyv1alpha1.
 	}
 	return obj.(*v1alpha1.Partition), err
 }
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakePartitions) ApplyStatus(ctx context.Context, partition *topologyv1alpha1.PartitionApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Partition, err error) {
+	if partition == nil {
+		return nil, fmt.Errorf("partition provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(partition)
+	if err != nil {
+		return nil, err
+	}
+	name := partition.Name
+	if name == nil {
+		return nil, fmt.Errorf("partition.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(partitionsResource, *name, types.ApplyPatchType, data, "status"), &v1alpha1.Partition{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Partition), err
+}
@@ -44,6 +44,7 @@ type PartitionsGetter interface {
 type PartitionInterface interface {
 	Create(ctx context.Context, partition *v1alpha1.Partition, opts v1.CreateOptions) (*v1alpha1.Partition, error)
 	Update(ctx context.Context, partition *v1alpha1.Partition, opts v1.UpdateOptions) (*v1alpha1.Partition, error)
+	UpdateStatus(ctx context.Context, partition *v1alpha1.Partition, opts v1.UpdateOptions) (*v1alpha1.Partition, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Partition, error)
@@ -51,6 +52,7 @@ type PartitionInterface interface {
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Partition, err error)
 	Apply(ctx context.Context, partition *topologyv1alpha1.PartitionApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Partition, err error)
+	ApplyStatus(ctx context.Context, partition *topologyv1alpha1.PartitionApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Partition, err error)
 	PartitionExpansion
 }
 
@@ -133,6 +135,21 @@ func (c *partitions) Update(ctx context.Context, partition *v1alpha1.Partition,
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *partitions) UpdateStatus(ctx context.Context, partition *v1alpha1.Partition, opts v1.UpdateOptions) (result *v1alpha1.Partition, err error) {
+	result = &v1alpha1.Partition{}
+	err = c.client.Put().
+		Resource("partitions").
+		Name(partition.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(partition).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the partition and deletes it. Returns an error if one occurs.
 func (c *partitions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -196,3 +213,32 @@ func (c *partitions) Apply(ctx context.Context, partition *topologyv1alpha1.Part
 		Into(result)
 	return
 }
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *partitions) ApplyStatus(ctx context.Context, partition *topologyv1alpha1.PartitionApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Partition, err error) {
+	if partition == nil {
+		return nil, fmt.Errorf("partition provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(partition)
+	if err != nil {
+		return nil, err
+	}
+
+	name := partition.Name
+	if name == nil {
+		return nil, fmt.Errorf("partition.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.Partition{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("partitions").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// WaitForCondition gets the object with get every interval until its condition of the given type
+// is true, and returns it. Errors of get are retried until ctx is done, e.g. while the object does
+// not exist yet. get is usually the Get of a cluster client scoped to a logical cluster:
+//
+//	binding, err := client.WaitForCondition(ctx, time.Second, func(ctx context.Context) (*apisv1alpha1.APIBinding, error) {
+//		return kcpClusterClient.Cluster(path).ApisV1alpha1().APIBindings().Get(ctx, name, metav1.GetOptions{})
+//	}, apisv1alpha1.InitialBindingCompleted)
+func WaitForCondition[T conditions.Getter](ctx context.Context, interval time.Duration, get func(ctx context.Context) (T, error), conditionType conditionsv1alpha1.ConditionType) (T, error) {
+	var obj T
+	var found bool
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		latest, err := get(ctx)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		obj, found, lastErr = latest, true, nil
+		return conditions.IsTrue(obj, conditionType), nil
+	})
+	if err == nil {
+		return obj, nil
+	}
+
+	if lastErr != nil {
+		return obj, fmt.Errorf("failed waiting for condition %s: %w", conditionType, lastErr)
+	}
+	if found {
+		if c := conditions.Get(obj, conditionType); c != nil {
+			return obj, fmt.Errorf("condition %s of %s is %s: %s: %s", conditionType, obj.GetName(), c.Status, c.Reason, c.Message)
+		}
+	}
+	return obj, fmt.Errorf("failed waiting for condition %s: %w", conditionType, err)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+func TestWaitForCondition(t *testing.T) {
+	gets := 0
+	get := func(ctx context.Context) (*apisv1alpha1.APIBinding, error) {
+		gets++
+		binding := &apisv1alpha1.APIBinding{ObjectMeta: metav1.ObjectMeta{Name: "widgets"}}
+		switch {
+		case gets == 1:
+			return nil, errors.New("not found")
+		case gets == 2:
+			binding.Status.Conditions = conditionsv1alpha1.Conditions{{Type: apisv1alpha1.InitialBindingCompleted, Status: corev1.ConditionFalse}}
+		default:
+			binding.Status.Conditions = conditionsv1alpha1.Conditions{{Type: apisv1alpha1.InitialBindingCompleted, Status: corev1.ConditionTrue}}
+		}
+		return binding, nil
+	}
+
+	binding, err := WaitForCondition(context.Background(), time.Millisecond, get, apisv1alpha1.InitialBindingCompleted)
+	require.NoError(t, err)
+	require.Equal(t, "widgets", binding.Name)
+	require.Equal(t, 3, gets)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = WaitForCondition(ctx, time.Millisecond, func(ctx context.Context) (*apisv1alpha1.APIBinding, error) {
+		binding := &apisv1alpha1.APIBinding{ObjectMeta: metav1.ObjectMeta{Name: "widgets"}}
+		binding.Status.Conditions = conditionsv1alpha1.Conditions{{Type: apisv1alpha1.InitialBindingCompleted, Status: corev1.ConditionFalse, Reason: "Waiting", Message: "waiting for the export"}}
+		return binding, nil
+	}, apisv1alpha1.InitialBindingCompleted)
+	require.EqualError(t, err, "condition InitialBindingCompleted of widgets is False: Waiting: waiting for the export")
+}
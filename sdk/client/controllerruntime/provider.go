@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerruntime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kcp-dev/kcp/sdk/client/kubeconfig"
+)
+
+// ConfigForCluster returns a copy of config for the given logical cluster or workspace path.
+// A logical cluster in the host of config, e.g. the "/clusters/*" of the URL of an APIExport
+// virtual workspace, is replaced.
+func ConfigForCluster(config *rest.Config, cluster logicalcluster.Path) *rest.Config {
+	return kubeconfig.ForWorkspace(config, cluster)
+}
+
+// Provider engages a cluster.Cluster for every logical cluster of the objects in an informer, e.g.
+// the LogicalClusters of the wildcard endpoint or the APIBindings of an APIExport virtual
+// workspace, and disengages it when the last object of the logical cluster is gone. The watches of
+// controllers are started in every engaged cluster.
+//
+// Provider is a manager.Runnable, and engages clusters once added to a manager and started.
+type Provider struct {
+	config   *rest.Config
+	informer cache.SharedIndexInformer
+	options  []cluster.Option
+
+	newCluster func(config *rest.Config, options ...cluster.Option) (cluster.Cluster, error)
+
+	lock     sync.RWMutex
+	ctx      context.Context
+	objects  map[logicalcluster.Name]sets.Set[string]
+	clusters map[logicalcluster.Name]*engagedCluster
+	watches  []providerWatch
+}
+
+var _ manager.Runnable = &Provider{}
+
+type engagedCluster struct {
+	cluster.Cluster
+	cancel context.CancelFunc
+}
+
+type providerWatch struct {
+	ctrl       controller.Controller
+	obj        client.Object
+	predicates []predicate.Predicate
+}
+
+// NewProvider returns a Provider for the logical clusters of the objects in the given informer.
+// The clusters are created with ConfigForCluster of config and the given options.
+func NewProvider(config *rest.Config, informer cache.SharedIndexInformer, options ...cluster.Option) *Provider {
+	return &Provider{
+		config:     config,
+		informer:   informer,
+		options:    options,
+		newCluster: cluster.New,
+		objects:    map[logicalcluster.Name]sets.Set[string]{},
+		clusters:   map[logicalcluster.Name]*engagedCluster{},
+	}
+}
+
+// Get returns the engaged cluster of the given logical cluster, e.g. for the client of a Request.
+func (p *Provider) Get(name logicalcluster.Name) (cluster.Cluster, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	cl, found := p.clusters[name]
+	if !found {
+		return nil, fmt.Errorf("logical cluster %q is not engaged", name)
+	}
+	return cl.Cluster, nil
+}
+
+// Watch watches objects like obj in every engaged cluster, and in the ones engaged later, and
+// enqueues a Request with their logical cluster into ctrl. ctrl must reconcile with a Reconciler
+// returned by NewReconciler.
+func (p *Provider) Watch(ctrl controller.Controller, obj client.Object, predicates ...predicate.Predicate) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	w := providerWatch{ctrl: ctrl, obj: obj, predicates: predicates}
+	for name, cl := range p.clusters {
+		if err := w.start(cl); err != nil {
+			return fmt.Errorf("failed to watch %T in logical cluster %q: %w", obj, name, err)
+		}
+	}
+	p.watches = append(p.watches, w)
+	return nil
+}
+
+func (w providerWatch) start(cl cluster.Cluster) error {
+	return w.ctrl.Watch(source.Kind(cl.GetCache(), w.obj, EnqueueRequestForObject(), w.predicates...))
+}
+
+// Start engages the clusters of the objects in the informer until ctx is done. The informer must
+// be started separately.
+func (p *Provider) Start(ctx context.Context) error {
+	p.lock.Lock()
+	p.ctx = ctx
+	p.lock.Unlock()
+
+	handle, err := p.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			p.add(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			p.add(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			p.remove(obj)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = p.informer.RemoveEventHandler(handle)
+	}()
+
+	<-ctx.Done()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for name, cl := range p.clusters {
+		cl.cancel()
+		delete(p.clusters, name)
+	}
+	return nil
+}
+
+func (p *Provider) add(obj interface{}) {
+	name, key, ok := clusterAndKey(obj)
+	if !ok {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.objects[name] == nil {
+		p.objects[name] = sets.New[string]()
+	}
+	p.objects[name].Insert(key)
+	if _, found := p.clusters[name]; found || p.ctx.Err() != nil {
+		return
+	}
+
+	logger := klog.FromContext(p.ctx).WithValues("cluster", name)
+	cl, err := p.newCluster(ConfigForCluster(p.config, name.Path()), p.options...)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to create cluster for logical cluster %q: %w", name, err))
+		return
+	}
+	ctx, cancel := context.WithCancel(p.ctx)
+	go func() {
+		if err := cl.Start(ctx); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to run cluster of logical cluster %q: %w", name, err))
+		}
+	}()
+	for _, w := range p.watches {
+		if err := w.start(cl); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to watch %T in logical cluster %q: %w", w.obj, name, err))
+		}
+	}
+	p.clusters[name] = &engagedCluster{Cluster: cl, cancel: cancel}
+	logger.V(2).Info("engaged logical cluster")
+}
+
+func (p *Provider) remove(obj interface{}) {
+	name, key, ok := clusterAndKey(obj)
+	if !ok {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.objects[name].Delete(key)
+	if p.objects[name].Len() > 0 {
+		return
+	}
+	delete(p.objects, name)
+	if cl, found := p.clusters[name]; found {
+		cl.cancel()
+		delete(p.clusters, name)
+		klog.FromContext(p.ctx).WithValues("cluster", name).V(2).Info("disengaged logical cluster")
+	}
+}
+
+func clusterAndKey(obj interface{}) (logicalcluster.Name, string, bool) {
+	o, ok := obj.(client.Object)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return "", "", false
+	}
+	name := logicalcluster.From(o)
+	if name.Empty() {
+		return "", "", false
+	}
+	return name, o.GetNamespace() + "/" + o.GetName(), true
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerruntime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func TestConfigForCluster(t *testing.T) {
+	tests := map[string]string{
+		"https://kcp:6443":               "https://kcp:6443/clusters/root:org",
+		"https://kcp:6443/":              "https://kcp:6443/clusters/root:org",
+		"https://kcp:6443/clusters/root": "https://kcp:6443/clusters/root:org",
+		"https://kcp:6443/services/apiexport/root/widgets/clusters/*": "https://kcp:6443/services/apiexport/root/widgets/clusters/root:org",
+	}
+	for host, expected := range tests {
+		t.Run(host, func(t *testing.T) {
+			config := &rest.Config{Host: host}
+			require.Equal(t, expected, ConfigForCluster(config, logicalcluster.NewPath("root:org")).Host)
+			require.Equal(t, host, config.Host, "config must not be changed")
+		})
+	}
+}
+
+type fakeCluster struct {
+	cluster.Cluster
+	config *rest.Config
+	done   chan struct{}
+}
+
+func (c *fakeCluster) GetCache() cache.Cache {
+	return nil
+}
+
+func (c *fakeCluster) Start(ctx context.Context) error {
+	<-ctx.Done()
+	close(c.done)
+	return nil
+}
+
+type fakeController struct {
+	controller.Controller
+	sources []source.Source
+}
+
+func (c *fakeController) Watch(src source.Source) error {
+	c.sources = append(c.sources, src)
+	return nil
+}
+
+func TestProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewProvider(&rest.Config{Host: "https://kcp:6443/clusters/*"}, nil)
+	p.ctx = ctx
+	clusters := map[string]*fakeCluster{}
+	p.newCluster = func(config *rest.Config, _ ...cluster.Option) (cluster.Cluster, error) {
+		cl := &fakeCluster{config: config, done: make(chan struct{})}
+		clusters[config.Host] = cl
+		return cl, nil
+	}
+	ctrl := &fakeController{}
+	newObject := func(cluster, name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{logicalcluster.AnnotationKey: cluster}}}
+	}
+
+	p.add(newObject("one", "a"))
+	require.NoError(t, p.Watch(ctrl, &corev1.ConfigMap{}))
+	require.Len(t, ctrl.sources, 1, "the watch should be started in the engaged cluster")
+
+	p.add(newObject("one", "b"))
+	p.add(newObject("two", "a"))
+	require.Len(t, clusters, 2)
+	require.Len(t, ctrl.sources, 2, "the watch should be started in the newly engaged cluster")
+	two, err := p.Get("two")
+	require.NoError(t, err)
+	require.Equal(t, "https://kcp:6443/clusters/two", two.(*fakeCluster).config.Host)
+
+	p.remove(newObject("one", "a"))
+	_, err = p.Get("one")
+	require.NoError(t, err, "cluster one should be engaged while it has objects")
+
+	p.remove(newObject("one", "b"))
+	_, err = p.Get("one")
+	require.Error(t, err)
+	<-clusters["https://kcp:6443/clusters/one"].done
+}
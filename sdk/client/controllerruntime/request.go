@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controllerruntime adapts kcp to controller-runtime, for controllers reconciling objects
+// of many logical clusters.
+//
+// Requests carry the logical cluster of the object. A Provider engages a controller-runtime
+// cluster.Cluster for every logical cluster a controller has to serve, e.g. every workspace
+// binding an APIExport, and starts the watches of the controller in it:
+//
+//	provider := controllerruntime.NewProvider(vwConfig, apiBindingInformer)
+//	ctrl, err := controller.New("widgets", mgr, controller.Options{
+//		Reconciler: controllerruntime.NewReconciler(reconciler),
+//	})
+//	err = provider.Watch(ctrl, &Widget{})
+//	err = mgr.Add(provider)
+//
+// The reconciler gets the client of the logical cluster of a request from the provider.
+package controllerruntime
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kcpclient "github.com/kcp-dev/kcp/sdk/client"
+)
+
+// Request is a reconcile.Request for an object of a logical cluster.
+type Request struct {
+	ClusterName logicalcluster.Name
+	types.NamespacedName
+}
+
+func (r Request) String() string {
+	return kcpclient.ToClusterAwareKey(r.ClusterName.Path(), r.NamespacedName.String())
+}
+
+// Reconciler reconciles objects of many logical clusters.
+type Reconciler interface {
+	Reconcile(ctx context.Context, req Request) (reconcile.Result, error)
+}
+
+// ReconcilerFunc is a function implementing Reconciler.
+type ReconcilerFunc func(ctx context.Context, req Request) (reconcile.Result, error)
+
+func (f ReconcilerFunc) Reconcile(ctx context.Context, req Request) (reconcile.Result, error) {
+	return f(ctx, req)
+}
+
+// NewReconciler returns a reconcile.Reconciler for controller-runtime controllers, passing the
+// requests enqueued by the handlers of this package with their logical cluster to r.
+func NewReconciler(r Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return r.Reconcile(ctx, FromReconcileRequest(req))
+	})
+}
+
+// ToReconcileRequest encodes the logical cluster of req into the name of a reconcile.Request,
+// which controller-runtime queues as is.
+func ToReconcileRequest(req Request) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: req.Namespace,
+		Name:      kcpclient.ToClusterAwareKey(req.ClusterName.Path(), req.Name),
+	}}
+}
+
+// FromReconcileRequest decodes a reconcile.Request encoded by ToReconcileRequest. The logical
+// cluster of other requests is empty.
+func FromReconcileRequest(req reconcile.Request) Request {
+	cluster, name, found := strings.Cut(req.Name, "|")
+	if !found {
+		return Request{NamespacedName: req.NamespacedName}
+	}
+	return Request{
+		ClusterName:    logicalcluster.Name(cluster),
+		NamespacedName: types.NamespacedName{Namespace: req.Namespace, Name: name},
+	}
+}
+
+// EnqueueRequestForObject returns a handler enqueueing a Request for the object of an event,
+// in the logical cluster of its kcp.io/cluster annotation.
+func EnqueueRequestForObject() handler.EventHandler {
+	return EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []Request {
+		return []Request{{
+			ClusterName:    logicalcluster.From(obj),
+			NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
+		}}
+	})
+}
+
+// EnqueueRequestsFromMapFunc returns a handler enqueueing the Requests fn maps the object of an
+// event to, e.g. to reconcile an owner in the same logical cluster.
+func EnqueueRequestsFromMapFunc(fn func(ctx context.Context, obj client.Object) []Request) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		reqs := fn(ctx, obj)
+		ret := make([]reconcile.Request, 0, len(reqs))
+		for _, req := range reqs {
+			ret = append(ret, ToReconcileRequest(req))
+		}
+		return ret
+	})
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerruntime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileRequest(t *testing.T) {
+	req := Request{ClusterName: "abc", NamespacedName: types.NamespacedName{Namespace: "default", Name: "foo"}}
+	require.Equal(t, "abc|default/foo", req.String())
+	require.Equal(t, req, FromReconcileRequest(ToReconcileRequest(req)))
+
+	plain := reconcile.Request{NamespacedName: types.NamespacedName{Name: "foo"}}
+	require.Equal(t, Request{NamespacedName: plain.NamespacedName}, FromReconcileRequest(plain))
+}
+
+func TestEnqueueRequestForObject(t *testing.T) {
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "foo",
+		Annotations: map[string]string{logicalcluster.AnnotationKey: "abc"},
+	}}
+	EnqueueRequestForObject().Create(context.Background(), event.CreateEvent{Object: obj}, queue)
+	require.Equal(t, 1, queue.Len())
+
+	item, _ := queue.Get()
+	var got Request
+	_, err := NewReconciler(ReconcilerFunc(func(_ context.Context, req Request) (reconcile.Result, error) {
+		got = req
+		return reconcile.Result{}, nil
+	})).Reconcile(context.Background(), item.(reconcile.Request))
+	require.NoError(t, err)
+	require.Equal(t, Request{ClusterName: "abc", NamespacedName: types.NamespacedName{Namespace: "default", Name: "foo"}}, got)
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicinformer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// DiscoveryForCluster returns a discovery client for the given logical cluster.
+type DiscoveryForCluster func(cluster logicalcluster.Name) (discovery.DiscoveryInterface, error)
+
+const discoveryRetryDelay = 10 * time.Second
+
+// NewAPIBindingGVRSource returns a GVRSource for the resources bound by the APIBindings in the
+// given informer, e.g. of a single workspace or of the wildcard endpoint. The kind, scope and
+// served versions of a bound resource are discovered once, through discoveryFor of a logical
+// cluster binding it, and discovered again when the binding changes. Failed discoveries are
+// retried.
+func NewAPIBindingGVRSource(informer cache.SharedIndexInformer, discoveryFor DiscoveryForCluster) GVRSource {
+	return &apiBindingGVRSource{
+		informer:     informer,
+		discoveryFor: discoveryFor,
+		retryDelay:   discoveryRetryDelay,
+		discovered:   map[schema.GroupResource]map[schema.GroupVersionResource]GVRPartialMetadata{},
+	}
+}
+
+type apiBindingGVRSource struct {
+	informer     cache.SharedIndexInformer
+	discoveryFor DiscoveryForCluster
+	retryDelay   time.Duration
+
+	lock        sync.Mutex
+	discovered  map[schema.GroupResource]map[schema.GroupVersionResource]GVRPartialMetadata
+	subscribers []chan struct{}
+}
+
+func (s *apiBindingGVRSource) GVRs() map[schema.GroupVersionResource]GVRPartialMetadata {
+	bound := map[schema.GroupResource]logicalcluster.Name{}
+	for _, obj := range s.informer.GetStore().List() {
+		binding, ok := obj.(*apisv1alpha1.APIBinding)
+		if !ok || binding.Status.Phase != apisv1alpha1.APIBindingPhaseBound {
+			continue
+		}
+		for _, r := range binding.Status.BoundResources {
+			gr := schema.GroupResource{Group: r.Group, Resource: r.Resource}
+			if _, found := bound[gr]; !found {
+				bound[gr] = logicalcluster.From(binding)
+			}
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for gr := range s.discovered {
+		if _, found := bound[gr]; !found {
+			delete(s.discovered, gr)
+		}
+	}
+
+	result := map[schema.GroupVersionResource]GVRPartialMetadata{}
+	failed := false
+	for gr, cluster := range bound {
+		gvrs, found := s.discovered[gr]
+		if !found {
+			var err error
+			if gvrs, err = s.discover(cluster, gr); err != nil {
+				utilruntime.HandleError(fmt.Errorf("failed to discover %s in logical cluster %s: %w", gr, cluster, err))
+				failed = true
+				continue
+			}
+			s.discovered[gr] = gvrs
+		}
+		for gvr, metadata := range gvrs {
+			result[gvr] = metadata
+		}
+	}
+	if failed {
+		time.AfterFunc(s.retryDelay, s.notifyChange)
+	}
+
+	return result
+}
+
+// discover returns the served versions of gr with their metadata.
+func (s *apiBindingGVRSource) discover(cluster logicalcluster.Name, gr schema.GroupResource) (map[schema.GroupVersionResource]GVRPartialMetadata, error) {
+	client, err := s.discoveryFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[schema.GroupVersionResource]GVRPartialMetadata{}
+	for _, group := range groups.Groups {
+		if group.Name != gr.Group {
+			continue
+		}
+		for _, version := range group.Versions {
+			resources, err := client.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources.APIResources {
+				if r.Name != gr.Resource {
+					continue
+				}
+				scope := apiextensionsv1.ClusterScoped
+				if r.Namespaced {
+					scope = apiextensionsv1.NamespaceScoped
+				}
+				ret[gr.WithVersion(version.Version)] = GVRPartialMetadata{
+					Scope: scope,
+					Names: apiextensionsv1.CustomResourceDefinitionNames{
+						Kind:     r.Kind,
+						Singular: r.SingularName,
+					},
+				}
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("%s is not served yet", gr)
+	}
+
+	return ret, nil
+}
+
+func (s *apiBindingGVRSource) Ready() bool {
+	return s.informer.HasSynced()
+}
+
+func (s *apiBindingGVRSource) Subscribe() <-chan struct{} {
+	changes := make(chan struct{}, 1)
+
+	s.lock.Lock()
+	s.subscribers = append(s.subscribers, changes)
+	s.lock.Unlock()
+
+	// When the bound resources change, send a notification that we might need to add/remove informers.
+	_, _ = s.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if binding, ok := obj.(*apisv1alpha1.APIBinding); ok && binding.Status.Phase == apisv1alpha1.APIBindingPhaseBound {
+				s.notifyChange()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBinding, ok := oldObj.(*apisv1alpha1.APIBinding)
+			if !ok {
+				return
+			}
+			newBinding, ok := newObj.(*apisv1alpha1.APIBinding)
+			if !ok {
+				return
+			}
+			if oldBinding.Status.Phase == newBinding.Status.Phase && equality.Semantic.DeepEqual(oldBinding.Status.BoundResources, newBinding.Status.BoundResources) {
+				return
+			}
+			// Discover the resources of both again, their schemas might have changed.
+			s.lock.Lock()
+			for _, binding := range []*apisv1alpha1.APIBinding{oldBinding, newBinding} {
+				for _, r := range binding.Status.BoundResources {
+					delete(s.discovered, schema.GroupResource{Group: r.Group, Resource: r.Resource})
+				}
+			}
+			s.lock.Unlock()
+			s.notifyChange()
+		},
+		DeleteFunc: func(obj interface{}) {
+			s.notifyChange()
+		},
+	})
+
+	return changes
+}
+
+func (s *apiBindingGVRSource) notifyChange() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, changes := range s.subscribers {
+		select {
+		case changes <- struct{}{}:
+			klog.Background().V(4).Info("enqueued APIBinding change notification")
+		default:
+			klog.Background().V(5).Info("dropping APIBinding change notification because a notification is already pending")
+		}
+	}
+}
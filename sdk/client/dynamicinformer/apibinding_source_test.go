@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicinformer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func newBinding(cluster, name string, phase apisv1alpha1.APIBindingPhaseType, resources ...string) *apisv1alpha1.APIBinding {
+	binding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+		},
+		Status: apisv1alpha1.APIBindingStatus{Phase: phase},
+	}
+	for _, r := range resources {
+		binding.Status.BoundResources = append(binding.Status.BoundResources, apisv1alpha1.BoundAPIResource{Group: "example.com", Resource: r})
+	}
+	return binding
+}
+
+func TestAPIBindingGVRSource(t *testing.T) {
+	fake := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", SingularName: "widget", Namespaced: true, Kind: "Widget"},
+				{Name: "widgets/status", Namespaced: true, Kind: "Widget"},
+				{Name: "gadgets", SingularName: "gadget", Kind: "Gadget"},
+			},
+		},
+		{
+			GroupVersion: "example.com/v2",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", SingularName: "widget", Namespaced: true, Kind: "Widget"},
+			},
+		},
+	}}}
+	var discovered []logicalcluster.Name
+	var discoveryErr error
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &apisv1alpha1.APIBinding{}, 0, cache.Indexers{})
+	source := NewAPIBindingGVRSource(informer, func(cluster logicalcluster.Name) (discovery.DiscoveryInterface, error) {
+		discovered = append(discovered, cluster)
+		return fake, discoveryErr
+	}).(*apiBindingGVRSource)
+	source.retryDelay = 10 * time.Millisecond
+	changes := source.Subscribe()
+
+	require.NoError(t, informer.GetStore().Add(newBinding("one", "widgets", apisv1alpha1.APIBindingPhaseBound, "widgets")))
+	require.NoError(t, informer.GetStore().Add(newBinding("two", "widgets", apisv1alpha1.APIBindingPhaseBound, "widgets")))
+	require.NoError(t, informer.GetStore().Add(newBinding("two", "gadgets", apisv1alpha1.APIBindingPhaseBinding, "gadgets")))
+
+	widgets := GVRPartialMetadata{Scope: apiextensionsv1.NamespaceScoped, Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Singular: "widget"}}
+	require.Equal(t, map[schema.GroupVersionResource]GVRPartialMetadata{
+		{Group: "example.com", Version: "v1", Resource: "widgets"}: widgets,
+		{Group: "example.com", Version: "v2", Resource: "widgets"}: widgets,
+	}, source.GVRs())
+	require.Len(t, discovered, 1, "widgets should be discovered once for both bindings")
+
+	source.GVRs()
+	require.Len(t, discovered, 1, "widgets should be discovered from the cache")
+
+	gadgets := newBinding("two", "gadgets", apisv1alpha1.APIBindingPhaseBound, "gadgets")
+	require.NoError(t, informer.GetStore().Update(gadgets))
+	discoveryErr = errors.New("unavailable")
+	require.Len(t, source.GVRs(), 2, "gadgets should be left out while discovery fails")
+	select {
+	case <-changes:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("expected a retry notification after the failed discovery")
+	}
+
+	discoveryErr = nil
+	require.Equal(t, GVRPartialMetadata{Scope: apiextensionsv1.ClusterScoped, Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Gadget", Singular: "gadget"}},
+		source.GVRs()[schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "gadgets"}])
+
+	require.NoError(t, informer.GetStore().Delete(gadgets))
+	require.Len(t, source.GVRs(), 2, "gadgets should be removed with their binding")
+	require.NotContains(t, source.discovered, schema.GroupResource{Group: "example.com", Resource: "gadgets"})
+}
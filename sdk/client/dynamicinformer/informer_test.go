@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicinformer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/restmapper"
+)
+
+func TestGVRsToDiscoveryData(t *testing.T) {
+	input := map[schema.GroupVersionResource]GVRPartialMetadata{
+		{Group: "g1", Version: "v1", Resource: "g1-v1-r1"}: {
+			Scope: apiextensionsv1.ClusterScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     "G1v1r1",
+				Singular: "g1v1r1",
+			},
+		},
+		{Group: "g2", Version: "v1", Resource: "g2-v1-r1"}: {
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     "G2v1r1",
+				Singular: "g2v1r1",
+			},
+		},
+		{Group: "g1", Version: "v1", Resource: "g1-v1-r2"}: {
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     "G1v1r2",
+				Singular: "g1v1r2",
+			},
+		},
+		{Group: "g3", Version: "v3", Resource: "g3-v3-r1"}: {
+			Scope: apiextensionsv1.ClusterScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     "G3v3r1",
+				Singular: "g3v3r1",
+			},
+		},
+	}
+
+	expected := discoveryData{
+		apiGroupResources: []*restmapper.APIGroupResources{
+			{
+				Group: metav1.APIGroup{
+					Name: "g1",
+					Versions: []metav1.GroupVersionForDiscovery{
+						{
+							GroupVersion: "g1",
+							Version:      "v1",
+						},
+					},
+				},
+				VersionedResources: map[string][]metav1.APIResource{
+					"v1": {
+						{
+							Name:         "g1-v1-r1",
+							SingularName: "g1v1r1",
+							Group:        "g1",
+							Version:      "v1",
+							Kind:         "G1v1r1",
+							Verbs:        []string{"create", "list", "watch", "delete"},
+						},
+						{
+							Name:         "g1-v1-r2",
+							SingularName: "g1v1r2",
+							Namespaced:   true,
+							Group:        "g1",
+							Version:      "v1",
+							Kind:         "G1v1r2",
+							Verbs:        []string{"create", "list", "watch", "delete"},
+						},
+					},
+				},
+			},
+			{
+				Group: metav1.APIGroup{
+					Name: "g2",
+					Versions: []metav1.GroupVersionForDiscovery{
+						{
+							GroupVersion: "g2",
+							Version:      "v1",
+						},
+					},
+				},
+				VersionedResources: map[string][]metav1.APIResource{
+					"v1": {
+						{
+							Name:         "g2-v1-r1",
+							SingularName: "g2v1r1",
+							Namespaced:   true,
+							Group:        "g2",
+							Version:      "v1",
+							Kind:         "G2v1r1",
+							Verbs:        []string{"create", "list", "watch", "delete"},
+						},
+					},
+				},
+			},
+			{
+				Group: metav1.APIGroup{
+					Name: "g3",
+					Versions: []metav1.GroupVersionForDiscovery{
+						{
+							GroupVersion: "g3",
+							Version:      "v3",
+						},
+					},
+				},
+				VersionedResources: map[string][]metav1.APIResource{
+					"v3": {
+						{
+							Name:         "g3-v3-r1",
+							Group:        "g3",
+							Version:      "v3",
+							Namespaced:   false,
+							Kind:         "G3v3r1",
+							SingularName: "g3v3r1",
+							Verbs:        []string{"create", "list", "watch", "delete"},
+						},
+					},
+				},
+			},
+		},
+		apiResourceList: []*metav1.APIResourceList{
+			{
+				GroupVersion: "g1/v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name:         "g1-v1-r1",
+						Group:        "g1",
+						Version:      "v1",
+						Namespaced:   false,
+						Kind:         "G1v1r1",
+						SingularName: "g1v1r1",
+						Verbs:        []string{"create", "list", "watch", "delete"},
+					},
+					{
+						Name:         "g1-v1-r2",
+						Group:        "g1",
+						Version:      "v1",
+						Namespaced:   true,
+						Kind:         "G1v1r2",
+						SingularName: "g1v1r2",
+						Verbs:        []string{"create", "list", "watch", "delete"},
+					},
+				},
+			},
+			{
+				GroupVersion: "g2/v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name:         "g2-v1-r1",
+						Group:        "g2",
+						Version:      "v1",
+						Namespaced:   true,
+						Kind:         "G2v1r1",
+						SingularName: "g2v1r1",
+						Verbs:        []string{"create", "list", "watch", "delete"},
+					},
+				},
+			},
+			{
+				GroupVersion: "g3/v3",
+				APIResources: []metav1.APIResource{
+					{
+						Name:         "g3-v3-r1",
+						Group:        "g3",
+						Version:      "v3",
+						Namespaced:   false,
+						Kind:         "G3v3r1",
+						SingularName: "g3v3r1",
+						Verbs:        []string{"create", "list", "watch", "delete"},
+					},
+				},
+			},
+		},
+	}
+
+	actual := gvrsToDiscoveryData(input)
+
+	require.Empty(t, cmp.Diff(expected, actual, cmp.AllowUnexported(discoveryData{})))
+}
@@ -36,6 +36,8 @@ type ClusterInterface interface {
 	APIResourceSchemas() APIResourceSchemaClusterInformer
 	// APIConversions returns a APIConversionClusterInformer
 	APIConversions() APIConversionClusterInformer
+	// StorageVersionMigrations returns a StorageVersionMigrationClusterInformer
+	StorageVersionMigrations() StorageVersionMigrationClusterInformer
 }
 
 type version struct {
@@ -73,6 +75,11 @@ func (v *version) APIConversions() APIConversionClusterInformer {
 	return &aPIConversionClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// StorageVersionMigrations returns a StorageVersionMigrationClusterInformer
+func (v *version) StorageVersionMigrations() StorageVersionMigrationClusterInformer {
+	return &storageVersionMigrationClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 type Interface interface {
 	// APIBindings returns a APIBindingInformer
 	APIBindings() APIBindingInformer
@@ -84,6 +91,8 @@ type Interface interface {
 	APIResourceSchemas() APIResourceSchemaInformer
 	// APIConversions returns a APIConversionInformer
 	APIConversions() APIConversionInformer
+	// StorageVersionMigrations returns a StorageVersionMigrationInformer
+	StorageVersionMigrations() StorageVersionMigrationInformer
 }
 
 type scopedVersion struct {
@@ -121,3 +130,8 @@ func (v *scopedVersion) APIResourceSchemas() APIResourceSchemaInformer {
 func (v *scopedVersion) APIConversions() APIConversionInformer {
 	return &aPIConversionScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// StorageVersionMigrations returns a StorageVersionMigrationInformer
+func (v *scopedVersion) StorageVersionMigrations() StorageVersionMigrationInformer {
+	return &storageVersionMigrationScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -30,6 +30,8 @@ type ClusterInterface interface {
 	LogicalClusters() LogicalClusterClusterInformer
 	// Shards returns a ShardClusterInformer
 	Shards() ShardClusterInformer
+	// ShardBackups returns a ShardBackupClusterInformer
+	ShardBackups() ShardBackupClusterInformer
 }
 
 type version struct {
@@ -52,11 +54,18 @@ func (v *version) Shards() ShardClusterInformer {
 	return &shardClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ShardBackups returns a ShardBackupClusterInformer
+func (v *version) ShardBackups() ShardBackupClusterInformer {
+	return &shardBackupClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 type Interface interface {
 	// LogicalClusters returns a LogicalClusterInformer
 	LogicalClusters() LogicalClusterInformer
 	// Shards returns a ShardInformer
 	Shards() ShardInformer
+	// ShardBackups returns a ShardBackupInformer
+	ShardBackups() ShardBackupInformer
 }
 
 type scopedVersion struct {
@@ -79,3 +88,8 @@ func (v *scopedVersion) LogicalClusters() LogicalClusterInformer {
 func (v *scopedVersion) Shards() ShardInformer {
 	return &shardScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// ShardBackups returns a ShardBackupInformer
+func (v *scopedVersion) ShardBackups() ShardBackupInformer {
+	return &shardBackupScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
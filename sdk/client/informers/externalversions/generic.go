@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
@@ -100,14 +101,31 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().APIResourceSchemas().Informer()}, nil
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiconversions"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().APIConversions().Informer()}, nil
+	case apisv1alpha1.SchemeGroupVersion.WithResource("storageversionmigrations"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().StorageVersionMigrations().Informer()}, nil
+	// Group=apis.kcp.io, Version=V1alpha2
+	case apisv1alpha2.SchemeGroupVersion.WithResource("apibindings"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha2().APIBindings().Informer()}, nil
+	case apisv1alpha2.SchemeGroupVersion.WithResource("apiexports"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha2().APIExports().Informer()}, nil
 	// Group=core.kcp.io, Version=V1alpha1
 	case corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().LogicalClusters().Informer()}, nil
 	case corev1alpha1.SchemeGroupVersion.WithResource("shards"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().Shards().Informer()}, nil
+	case corev1alpha1.SchemeGroupVersion.WithResource("shardbackups"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().ShardBackups().Informer()}, nil
 	// Group=tenancy.kcp.io, Version=V1alpha1
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("auditsinks"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().AuditSinks().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("referencegrants"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().ReferenceGrants().Informer()}, nil
 	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspaces"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().Workspaces().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacequotas"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceQuotas().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacerolebindings"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceRoleBindings().Informer()}, nil
 	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetypes"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceTypes().Informer()}, nil
 	// Group=topology.kcp.io, Version=V1alpha1
@@ -140,6 +158,16 @@ func (f *sharedScopedInformerFactory) ForResource(resource schema.GroupVersionRe
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiconversions"):
 		informer := f.Apis().V1alpha1().APIConversions().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case apisv1alpha1.SchemeGroupVersion.WithResource("storageversionmigrations"):
+		informer := f.Apis().V1alpha1().StorageVersionMigrations().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	// Group=apis.kcp.io, Version=V1alpha2
+	case apisv1alpha2.SchemeGroupVersion.WithResource("apibindings"):
+		informer := f.Apis().V1alpha2().APIBindings().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case apisv1alpha2.SchemeGroupVersion.WithResource("apiexports"):
+		informer := f.Apis().V1alpha2().APIExports().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	// Group=core.kcp.io, Version=V1alpha1
 	case corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"):
 		informer := f.Core().V1alpha1().LogicalClusters().Informer()
@@ -147,10 +175,25 @@ func (f *sharedScopedInformerFactory) ForResource(resource schema.GroupVersionRe
 	case corev1alpha1.SchemeGroupVersion.WithResource("shards"):
 		informer := f.Core().V1alpha1().Shards().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case corev1alpha1.SchemeGroupVersion.WithResource("shardbackups"):
+		informer := f.Core().V1alpha1().ShardBackups().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	// Group=tenancy.kcp.io, Version=V1alpha1
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("auditsinks"):
+		informer := f.Tenancy().V1alpha1().AuditSinks().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("referencegrants"):
+		informer := f.Tenancy().V1alpha1().ReferenceGrants().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspaces"):
 		informer := f.Tenancy().V1alpha1().Workspaces().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacequotas"):
+		informer := f.Tenancy().V1alpha1().WorkspaceQuotas().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacerolebindings"):
+		informer := f.Tenancy().V1alpha1().WorkspaceRoleBindings().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetypes"):
 		informer := f.Tenancy().V1alpha1().WorkspaceTypes().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
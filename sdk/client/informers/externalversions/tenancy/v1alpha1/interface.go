@@ -26,8 +26,16 @@ import (
 )
 
 type ClusterInterface interface {
+	// AuditSinks returns a AuditSinkClusterInformer
+	AuditSinks() AuditSinkClusterInformer
+	// ReferenceGrants returns a ReferenceGrantClusterInformer
+	ReferenceGrants() ReferenceGrantClusterInformer
 	// Workspaces returns a WorkspaceClusterInformer
 	Workspaces() WorkspaceClusterInformer
+	// WorkspaceQuotas returns a WorkspaceQuotaClusterInformer
+	WorkspaceQuotas() WorkspaceQuotaClusterInformer
+	// WorkspaceRoleBindings returns a WorkspaceRoleBindingClusterInformer
+	WorkspaceRoleBindings() WorkspaceRoleBindingClusterInformer
 	// WorkspaceTypes returns a WorkspaceTypeClusterInformer
 	WorkspaceTypes() WorkspaceTypeClusterInformer
 }
@@ -42,19 +50,47 @@ func New(f internalinterfaces.SharedInformerFactory, tweakListOptions internalin
 	return &version{factory: f, tweakListOptions: tweakListOptions}
 }
 
+// AuditSinks returns a AuditSinkClusterInformer
+func (v *version) AuditSinks() AuditSinkClusterInformer {
+	return &auditSinkClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ReferenceGrants returns a ReferenceGrantClusterInformer
+func (v *version) ReferenceGrants() ReferenceGrantClusterInformer {
+	return &referenceGrantClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Workspaces returns a WorkspaceClusterInformer
 func (v *version) Workspaces() WorkspaceClusterInformer {
 	return &workspaceClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// WorkspaceQuotas returns a WorkspaceQuotaClusterInformer
+func (v *version) WorkspaceQuotas() WorkspaceQuotaClusterInformer {
+	return &workspaceQuotaClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkspaceRoleBindings returns a WorkspaceRoleBindingClusterInformer
+func (v *version) WorkspaceRoleBindings() WorkspaceRoleBindingClusterInformer {
+	return &workspaceRoleBindingClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // WorkspaceTypes returns a WorkspaceTypeClusterInformer
 func (v *version) WorkspaceTypes() WorkspaceTypeClusterInformer {
 	return &workspaceTypeClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
 type Interface interface {
+	// AuditSinks returns a AuditSinkInformer
+	AuditSinks() AuditSinkInformer
+	// ReferenceGrants returns a ReferenceGrantInformer
+	ReferenceGrants() ReferenceGrantInformer
 	// Workspaces returns a WorkspaceInformer
 	Workspaces() WorkspaceInformer
+	// WorkspaceQuotas returns a WorkspaceQuotaInformer
+	WorkspaceQuotas() WorkspaceQuotaInformer
+	// WorkspaceRoleBindings returns a WorkspaceRoleBindingInformer
+	WorkspaceRoleBindings() WorkspaceRoleBindingInformer
 	// WorkspaceTypes returns a WorkspaceTypeInformer
 	WorkspaceTypes() WorkspaceTypeInformer
 }
@@ -70,11 +106,31 @@ func NewScoped(f internalinterfaces.SharedScopedInformerFactory, namespace strin
 	return &scopedVersion{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AuditSinks returns a AuditSinkInformer
+func (v *scopedVersion) AuditSinks() AuditSinkInformer {
+	return &auditSinkScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ReferenceGrants returns a ReferenceGrantInformer
+func (v *scopedVersion) ReferenceGrants() ReferenceGrantInformer {
+	return &referenceGrantScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Workspaces returns a WorkspaceInformer
 func (v *scopedVersion) Workspaces() WorkspaceInformer {
 	return &workspaceScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// WorkspaceQuotas returns a WorkspaceQuotaInformer
+func (v *scopedVersion) WorkspaceQuotas() WorkspaceQuotaInformer {
+	return &workspaceQuotaScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkspaceRoleBindings returns a WorkspaceRoleBindingInformer
+func (v *scopedVersion) WorkspaceRoleBindings() WorkspaceRoleBindingInformer {
+	return &workspaceRoleBindingScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // WorkspaceTypes returns a WorkspaceTypeInformer
 func (v *scopedVersion) WorkspaceTypes() WorkspaceTypeInformer {
 	return &workspaceTypeScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeconfig builds rest.Configs and kubeconfigs for kcp workspaces, reached through the
+// front-proxy by workspace path or directly on a shard.
+package kubeconfig
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+const clustersPrefix = "/clusters/"
+
+// SplitHost splits a host URL into its base URL, without trailing slash, and the logical cluster
+// or workspace path of its "/clusters/<path>" segment. The path is empty if host does not point
+// to a workspace. Everything following the path is dropped.
+func SplitHost(host string) (string, logicalcluster.Path, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", logicalcluster.Path{}, err
+	}
+	i := strings.Index(u.Path, clustersPrefix)
+	if i < 0 {
+		return strings.TrimSuffix(host, "/"), logicalcluster.Path{}, nil
+	}
+	path := logicalcluster.NewPath(strings.SplitN(u.Path[i+len(clustersPrefix):], "/", 2)[0])
+	if path != logicalcluster.Wildcard && !path.IsValid() {
+		return "", logicalcluster.Path{}, fmt.Errorf("invalid workspace path %q in %s", path, host)
+	}
+	u.Path = strings.TrimSuffix(u.Path[:i], "/")
+	u.RawPath = ""
+	return u.String(), path, nil
+}
+
+// ForWorkspace returns a copy of config pointing to the given workspace path or logical cluster.
+// The host of config is the front-proxy, or a shard for logical clusters and paths it can resolve.
+// A "/clusters/<path>" already in the host of config is replaced.
+func ForWorkspace(config *rest.Config, path logicalcluster.Path) *rest.Config {
+	config = rest.CopyConfig(config)
+	host := config.Host
+	if i := strings.Index(host, clustersPrefix); i >= 0 {
+		host = host[:i]
+	}
+	config.Host = strings.TrimSuffix(host, "/") + path.RequestPath()
+	return config
+}
+
+// ForShard returns a copy of config pointing to the given logical cluster directly on shard,
+// bypassing the front-proxy. The credentials of config must be accepted by the shard.
+func ForShard(config *rest.Config, shard *corev1alpha1.Shard, cluster logicalcluster.Name) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Host = strings.TrimSuffix(shard.Spec.BaseURL, "/") + cluster.Path().RequestPath()
+	return config
+}
+
+// WithBearerToken returns a copy of config authenticating with token only.
+func WithBearerToken(config *rest.Config, token string) *rest.Config {
+	config = withoutCredentials(config)
+	config.BearerToken = token
+	return config
+}
+
+// WithClientCertificate returns a copy of config authenticating with the given PEM encoded client
+// certificate and key only.
+func WithClientCertificate(config *rest.Config, certData, keyData []byte) *rest.Config {
+	config = withoutCredentials(config)
+	config.CertData = certData
+	config.KeyData = keyData
+	return config
+}
+
+func withoutCredentials(config *rest.Config) *rest.Config {
+	config = rest.AnonymousClientConfig(config)
+	config.Impersonate = rest.ImpersonationConfig{}
+	return config
+}
+
+// ToKubeconfig returns a kubeconfig with a single cluster, user and context, all named name and
+// selected as current context, with the server, TLS settings and credentials of config. A proxy
+// of config is not part of the kubeconfig.
+func ToKubeconfig(config *rest.Config, name string) *clientcmdapi.Config {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   config.Host,
+		TLSServerName:            config.ServerName,
+		InsecureSkipTLSVerify:    config.Insecure,
+		CertificateAuthority:     config.CAFile,
+		CertificateAuthorityData: config.CAData,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{
+		ClientCertificate:     config.CertFile,
+		ClientCertificateData: config.CertData,
+		ClientKey:             config.KeyFile,
+		ClientKeyData:         config.KeyData,
+		Token:                 config.BearerToken,
+		TokenFile:             config.BearerTokenFile,
+		Username:              config.Username,
+		Password:              config.Password,
+		Impersonate:           config.Impersonate.UserName,
+		ImpersonateUID:        config.Impersonate.UID,
+		ImpersonateGroups:     config.Impersonate.Groups,
+		ImpersonateUserExtra:  config.Impersonate.Extra,
+		Exec:                  config.ExecProvider,
+		AuthProvider:          config.AuthProvider,
+	}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{
+		Cluster:  name,
+		AuthInfo: name,
+	}
+	kubeconfig.CurrentContext = name
+	return kubeconfig
+}
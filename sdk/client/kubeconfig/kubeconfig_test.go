@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestSplitHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		wantBase string
+		wantPath logicalcluster.Path
+		wantErr  bool
+	}{
+		{host: "https://kcp:6443", wantBase: "https://kcp:6443"},
+		{host: "https://kcp:6443/", wantBase: "https://kcp:6443"},
+		{host: "https://kcp:6443/clusters/root:org", wantBase: "https://kcp:6443", wantPath: logicalcluster.NewPath("root:org")},
+		{host: "https://kcp:6443/clusters/root:org/api/v1", wantBase: "https://kcp:6443", wantPath: logicalcluster.NewPath("root:org")},
+		{host: "https://kcp:6444/services/apiexport/root/widgets/clusters/*", wantBase: "https://kcp:6444/services/apiexport/root/widgets", wantPath: logicalcluster.Wildcard},
+		{host: "https://kcp:6443/clusters/Root", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			base, path, err := SplitHost(tt.host)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBase, base)
+			require.Equal(t, tt.wantPath, path)
+		})
+	}
+}
+
+func TestForWorkspace(t *testing.T) {
+	config := &rest.Config{Host: "https://kcp:6443/clusters/root", BearerToken: "token"}
+
+	got := ForWorkspace(config, logicalcluster.NewPath("root:org:team"))
+	require.Equal(t, "https://kcp:6443/clusters/root:org:team", got.Host)
+	require.Equal(t, "token", got.BearerToken)
+	require.Equal(t, "https://kcp:6443/clusters/root", config.Host, "config must not be changed")
+
+	got = ForWorkspace(&rest.Config{Host: "https://kcp:6443/"}, logicalcluster.NewPath("root"))
+	require.Equal(t, "https://kcp:6443/clusters/root", got.Host)
+}
+
+func TestForShard(t *testing.T) {
+	shard := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{Name: "one"},
+		Spec:       corev1alpha1.ShardSpec{BaseURL: "https://one.kcp:6443/", ExternalURL: "https://kcp:6443"},
+	}
+	got := ForShard(&rest.Config{Host: "https://kcp:6443/clusters/root:org"}, shard, "2x8vvtz6lo6ooypq")
+	require.Equal(t, "https://one.kcp:6443/clusters/2x8vvtz6lo6ooypq", got.Host)
+}
+
+func TestCredentials(t *testing.T) {
+	config := &rest.Config{
+		Host:            "https://kcp:6443",
+		Username:        "user",
+		Password:        "password",
+		BearerToken:     "old",
+		Impersonate:     rest.ImpersonationConfig{UserName: "other"},
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca"), CertData: []byte("old-cert"), KeyData: []byte("old-key")},
+	}
+
+	got := WithBearerToken(config, "token")
+	require.Equal(t, "token", got.BearerToken)
+	require.Empty(t, got.Username)
+	require.Empty(t, got.Password)
+	require.Empty(t, got.CertData)
+	require.Empty(t, got.Impersonate.UserName)
+	require.Equal(t, []byte("ca"), got.CAData)
+	require.Equal(t, "old", config.BearerToken, "config must not be changed")
+
+	got = WithClientCertificate(config, []byte("cert"), []byte("key"))
+	require.Empty(t, got.BearerToken)
+	require.Equal(t, []byte("cert"), got.CertData)
+	require.Equal(t, []byte("key"), got.KeyData)
+	require.Equal(t, []byte("ca"), got.CAData)
+}
+
+func TestToKubeconfig(t *testing.T) {
+	config := WithBearerToken(&rest.Config{
+		Host:            "https://kcp:6443",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca"), ServerName: "kcp"},
+	}, "token")
+
+	got := ToKubeconfig(ForWorkspace(config, logicalcluster.NewPath("root:org")), "org")
+	require.Equal(t, "org", got.CurrentContext)
+	require.Equal(t, "https://kcp:6443/clusters/root:org", got.Clusters["org"].Server)
+	require.Equal(t, []byte("ca"), got.Clusters["org"].CertificateAuthorityData)
+	require.Equal(t, "kcp", got.Clusters["org"].TLSServerName)
+	require.Equal(t, "token", got.AuthInfos["org"].Token)
+	require.Equal(t, "org", got.Contexts["org"].Cluster)
+	require.Equal(t, "org", got.Contexts["org"].AuthInfo)
+}
@@ -1,8 +1,5 @@
-//go:build !ignore_autogenerated
-// +build !ignore_autogenerated
-
 /*
-Copyright The KCP Authors.
+Copyright 2024 The KCP Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -17,12 +14,38 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Code generated by kcp code-generator. DO NOT EDIT.
-
 package v1alpha1
 
+import (
+	"k8s.io/client-go/rest"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client"
+)
+
 // APIExportEndpointSliceClusterListerExpansion allows custom methods to be added to APIExportEndpointSliceClusterLister.
 type APIExportEndpointSliceClusterListerExpansion interface{}
 
 // APIExportEndpointSliceListerExpansion allows custom methods to be added to APIExportEndpointSliceLister.
-type APIExportEndpointSliceListerExpansion interface{}
+type APIExportEndpointSliceListerExpansion interface {
+	// RESTConfigs returns a copy of config for the wildcard cluster of the virtual workspace of
+	// every endpoint of the named APIExportEndpointSlice, by endpoint URL. If shards are given,
+	// only their endpoints are returned.
+	RESTConfigs(name string, config *rest.Config, shards ...*corev1alpha1.Shard) (map[string]*rest.Config, error)
+}
+
+func (s *aPIExportEndpointSliceLister) RESTConfigs(name string, config *rest.Config, shards ...*corev1alpha1.Shard) (map[string]*rest.Config, error) {
+	slice, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return kcpclient.APIExportEndpointSliceRESTConfigs(slice, config, shards...)
+}
+
+func (s *aPIExportEndpointSliceScopedLister) RESTConfigs(name string, config *rest.Config, shards ...*corev1alpha1.Shard) (map[string]*rest.Config, error) {
+	slice, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return kcpclient.APIExportEndpointSliceRESTConfigs(slice, config, shards...)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package multishard aggregates wildcard lists and watches of a resource across all shards
+// into a single list and watch stream.
+//
+// The resourceVersion of an aggregated list, and of the objects of an aggregated watch, is a
+// ResourceVersion recording the resourceVersion of every shard. The shard of every object
+// and its original resourceVersion are recorded in the ShardAnnotationKey and
+// ShardResourceVersionAnnotationKey annotations. Objects with an aggregated resourceVersion
+// cannot be updated directly, use OriginalResourceVersion to restore it first.
+package multishard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// ShardAnnotationKey is the annotation recording the shard an aggregated object comes from.
+	// It is the same annotation the cache server uses for replicated objects.
+	ShardAnnotationKey = "kcp.io/shard"
+	// ShardResourceVersionAnnotationKey is the annotation recording the resourceVersion of an
+	// aggregated object on its shard.
+	ShardResourceVersionAnnotationKey = "multishard.kcp.io/resource-version"
+)
+
+// Shards are the clients for the wildcard requests of a resource to every shard, by shard name.
+type Shards map[string]dynamic.ResourceInterface
+
+// NewListerWatcher returns a ListerWatcher aggregating the shards, e.g. for an informer.
+func NewListerWatcher(ctx context.Context, shards Shards) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return List(ctx, shards, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return Watch(ctx, shards, options)
+		},
+	}
+}
+
+// List lists the resource on all shards and merges the items. The resourceVersion of the
+// options, if set, must be an aggregated resourceVersion; shards not part of it are listed
+// from the most recent resourceVersion. Pagination is not supported.
+func List(ctx context.Context, shards Shards, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	rv, err := ParseResourceVersion(options.ResourceVersion)
+	if err != nil && options.ResourceVersion != "0" {
+		return nil, err
+	}
+
+	result := &unstructured.UnstructuredList{}
+	listRV := ResourceVersion{}
+	for _, name := range shardNames(shards) {
+		shardOptions := options
+		shardOptions.ResourceVersion = rv[name]
+		if options.ResourceVersion == "0" {
+			shardOptions.ResourceVersion = "0"
+		}
+		shardOptions.ResourceVersionMatch = ""
+		shardOptions.Limit, shardOptions.Continue = 0, ""
+
+		list, err := shards[name].List(ctx, shardOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list on shard %q: %w", name, err)
+		}
+		if result.Object == nil {
+			result.Object = list.Object
+		}
+		for i := range list.Items {
+			annotate(&list.Items[i], name)
+			result.Items = append(result.Items, list.Items[i])
+		}
+		listRV[name] = list.GetResourceVersion()
+	}
+	if result.Object == nil {
+		result.Object = map[string]interface{}{}
+	}
+	result.SetResourceVersion(listRV.String())
+	result.SetContinue("")
+	return result, nil
+}
+
+// Watch watches the resource on all shards and merges the events into a single stream.
+// The resourceVersion of the options, if set, must be an aggregated resourceVersion; shards
+// not part of it are watched from the most recent resourceVersion. The stream ends when the
+// watch of any shard ends, and the objects of its events carry the aggregated resourceVersion
+// to resume from.
+func Watch(ctx context.Context, shards Shards, options metav1.ListOptions) (watch.Interface, error) {
+	rv, err := ParseResourceVersion(options.ResourceVersion)
+	if err != nil {
+		if options.ResourceVersion != "0" {
+			return nil, err
+		}
+		rv = ResourceVersion{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &aggregatedWatch{
+		result: make(chan watch.Event),
+		cancel: cancel,
+		rv:     rv.DeepCopy(),
+	}
+
+	watches := make(map[string]watch.Interface, len(shards))
+	for _, name := range shardNames(shards) {
+		shardOptions := options
+		shardOptions.ResourceVersion = rv[name]
+		if options.ResourceVersion == "0" {
+			shardOptions.ResourceVersion = "0"
+		}
+		shardOptions.Watch = true
+		sw, err := shards[name].Watch(ctx, shardOptions)
+		if err != nil {
+			cancel()
+			for _, sw := range watches {
+				sw.Stop()
+			}
+			return nil, fmt.Errorf("failed to watch on shard %q: %w", name, err)
+		}
+		watches[name] = sw
+	}
+
+	var wg sync.WaitGroup
+	for name, sw := range watches {
+		wg.Add(1)
+		go func(name string, sw watch.Interface) {
+			defer wg.Done()
+			defer cancel() // the stream ends with the first shard watch ending
+			defer sw.Stop()
+			w.forward(ctx, name, sw)
+		}(name, sw)
+	}
+	go func() {
+		wg.Wait()
+		close(w.result)
+	}()
+	if len(watches) == 0 {
+		cancel()
+	}
+
+	return w, nil
+}
+
+type aggregatedWatch struct {
+	result chan watch.Event
+	cancel context.CancelFunc
+
+	lock sync.Mutex
+	rv   ResourceVersion
+}
+
+var _ watch.Interface = &aggregatedWatch{}
+
+func (w *aggregatedWatch) Stop() {
+	w.cancel()
+}
+
+func (w *aggregatedWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// forward sends the events of the watch of a shard until it ends or the context is done.
+func (w *aggregatedWatch) forward(ctx context.Context, shard string, sw watch.Interface) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sw.ResultChan():
+			if !ok {
+				return
+			}
+			if event.Type == watch.Error {
+				w.send(ctx, event)
+				return
+			}
+			u, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				w.send(ctx, watch.Event{Type: watch.Error, Object: &metav1.Status{
+					TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+					Status:   metav1.StatusFailure,
+					Message:  fmt.Sprintf("unexpected object %T on shard %q", event.Object, shard),
+					Reason:   metav1.StatusReasonInternalError,
+				}})
+				return
+			}
+			u = u.DeepCopy()
+
+			// The events are sent while holding the lock, such that the aggregated
+			// resourceVersions of the stream never go back in time.
+			w.lock.Lock()
+			w.rv[shard] = u.GetResourceVersion()
+			if event.Type != watch.Bookmark {
+				annotate(u, shard)
+			}
+			u.SetResourceVersion(w.rv.String())
+			sent := w.send(ctx, watch.Event{Type: event.Type, Object: u})
+			w.lock.Unlock()
+			if !sent {
+				return
+			}
+		}
+	}
+}
+
+func (w *aggregatedWatch) send(ctx context.Context, event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// OriginalResourceVersion restores the resourceVersion of an aggregated object on its shard,
+// and returns the shard. It returns false if the object is not aggregated.
+func OriginalResourceVersion(obj metav1.Object) (shard string, ok bool) {
+	annotations := obj.GetAnnotations()
+	shard, found := annotations[ShardAnnotationKey]
+	if !found {
+		return "", false
+	}
+	if rv, found := annotations[ShardResourceVersionAnnotationKey]; found {
+		obj.SetResourceVersion(rv)
+		delete(annotations, ShardResourceVersionAnnotationKey)
+		obj.SetAnnotations(annotations)
+	}
+	return shard, true
+}
+
+func annotate(u *unstructured.Unstructured, shard string) {
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ShardAnnotationKey] = shard
+	annotations[ShardResourceVersionAnnotationKey] = u.GetResourceVersion()
+	u.SetAnnotations(annotations)
+}
+
+func shardNames(shards Shards) []string {
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
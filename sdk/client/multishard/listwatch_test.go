@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multishard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var gvr = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func newConfigMap(name, rv string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace("default")
+	u.SetName(name)
+	u.SetResourceVersion(rv)
+	return u
+}
+
+func newShards(objects map[string][]runtime.Object) (Shards, map[string]*dynamicfake.FakeDynamicClient) {
+	shards := Shards{}
+	clients := map[string]*dynamicfake.FakeDynamicClient{}
+	for name, objs := range objects {
+		client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: "ConfigMapList"}, objs...)
+		clients[name] = client
+		shards[name] = client.Resource(gvr)
+	}
+	return shards, clients
+}
+
+func TestResourceVersion(t *testing.T) {
+	rv := ResourceVersion{"beta": "7", "alpha": "42"}
+	parsed, err := ParseResourceVersion(rv.String())
+	require.NoError(t, err)
+	require.Equal(t, rv, parsed)
+
+	parsed, err = ParseResourceVersion("")
+	require.NoError(t, err)
+	require.Empty(t, parsed)
+
+	_, err = ParseResourceVersion("42")
+	require.Error(t, err)
+}
+
+func TestList(t *testing.T) {
+	shards, _ := newShards(map[string][]runtime.Object{
+		"alpha": {newConfigMap("a", "1")},
+		"beta":  {newConfigMap("b", "2"), newConfigMap("c", "3")},
+	})
+
+	list, err := List(context.Background(), shards, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 3)
+	_, err = ParseResourceVersion(list.GetResourceVersion())
+	require.NoError(t, err)
+
+	for _, item := range list.Items {
+		want := map[string]string{"a": "alpha", "b": "beta", "c": "beta"}[item.GetName()]
+		require.Equal(t, want, item.GetAnnotations()[ShardAnnotationKey])
+	}
+
+	cm := list.Items[0].DeepCopy()
+	shard, ok := OriginalResourceVersion(cm)
+	require.True(t, ok)
+	require.Equal(t, "alpha", shard)
+	require.Equal(t, "1", cm.GetResourceVersion())
+	require.NotContains(t, cm.GetAnnotations(), ShardResourceVersionAnnotationKey)
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shards, clients := newShards(map[string][]runtime.Object{"alpha": nil, "beta": nil})
+
+	w, err := Watch(ctx, shards, metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	_, err = clients["alpha"].Resource(gvr).Namespace("default").Create(ctx, newConfigMap("a", "10"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	event := next(t, w)
+	require.Equal(t, watch.Added, event.Type)
+	u := event.Object.(*unstructured.Unstructured)
+	require.Equal(t, "alpha", u.GetAnnotations()[ShardAnnotationKey])
+	rv, err := ParseResourceVersion(u.GetResourceVersion())
+	require.NoError(t, err)
+	require.Equal(t, ResourceVersion{"alpha": "10"}, rv)
+
+	_, err = clients["beta"].Resource(gvr).Namespace("default").Create(ctx, newConfigMap("b", "20"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	event = next(t, w)
+	require.Equal(t, watch.Added, event.Type)
+	rv, err = ParseResourceVersion(event.Object.(*unstructured.Unstructured).GetResourceVersion())
+	require.NoError(t, err)
+	require.Equal(t, ResourceVersion{"alpha": "10", "beta": "20"}, rv)
+
+	w.Stop()
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-w.ResultChan():
+			return !ok
+		default:
+			return false
+		}
+	}, wait, 10*time.Millisecond)
+}
+
+const wait = 5 * time.Second
+
+func next(t *testing.T, w watch.Interface) watch.Event {
+	t.Helper()
+	select {
+	case event, ok := <-w.ResultChan():
+		require.True(t, ok, "watch ended unexpectedly")
+		return event
+	case <-time.After(wait):
+		t.Fatal("timed out waiting for event")
+	}
+	return watch.Event{}
+}
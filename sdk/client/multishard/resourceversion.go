@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multishard
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// ResourceVersion is the resourceVersion of an aggregated list or watch. It records the
+// resourceVersion of every shard, such that a watch can be resumed on every shard where
+// it ended.
+type ResourceVersion map[string]string
+
+// ParseResourceVersion parses an aggregated resourceVersion. An empty string results in an
+// empty ResourceVersion.
+func ParseResourceVersion(s string) (ResourceVersion, error) {
+	rv := ResourceVersion{}
+	if s == "" {
+		return rv, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregated resourceVersion %q: %w", s, err)
+	}
+	if err := json.Unmarshal(bs, &rv); err != nil {
+		return nil, fmt.Errorf("invalid aggregated resourceVersion %q: %w", s, err)
+	}
+	return rv, nil
+}
+
+// String encodes the resourceVersion. It is opaque to clients.
+func (rv ResourceVersion) String() string {
+	// json.Marshal sorts the keys of maps, hence the encoding is stable.
+	bs, err := json.Marshal(map[string]string(rv))
+	if err != nil {
+		// cannot happen for a map of strings
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+// DeepCopy returns a copy of the resourceVersion.
+func (rv ResourceVersion) DeepCopy() ResourceVersion {
+	cp := make(ResourceVersion, len(rv))
+	for k, v := range rv {
+		cp[k] = v
+	}
+	return cp
+}
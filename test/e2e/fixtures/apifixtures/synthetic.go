@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apifixtures
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// SyntheticAPI describes a generated, namespaced API whose schema size, versions and subresources
+// are configurable, for scale and replication tests that need more realistic payloads than sheriffs.
+//
+// The spec of an object has Fields string properties field0...fieldN of FieldSize characters each,
+// and a replicas property. With the status subresource, the status mirrors the spec fields.
+type SyntheticAPI struct {
+	Group    string
+	Plural   string
+	Kind     string
+	Versions []string
+
+	Fields    int
+	FieldSize int
+
+	StatusSubresource bool
+	ScaleSubresource  bool
+}
+
+// SyntheticAPIOption configures a SyntheticAPI.
+type SyntheticAPIOption func(api *SyntheticAPI)
+
+// WithSyntheticFields sets the number of string fields in the spec, and the number of characters
+// of their values in generated objects.
+func WithSyntheticFields(fields, fieldSize int) SyntheticAPIOption {
+	return func(api *SyntheticAPI) {
+		api.Fields = fields
+		api.FieldSize = fieldSize
+	}
+}
+
+// WithSyntheticVersions serves the API in the versions v1...vN, with the last one being the storage version.
+func WithSyntheticVersions(n int) SyntheticAPIOption {
+	return func(api *SyntheticAPI) {
+		api.Versions = nil
+		for i := 1; i <= n; i++ {
+			api.Versions = append(api.Versions, fmt.Sprintf("v%d", i))
+		}
+	}
+}
+
+// WithSyntheticStatusSubresource enables the status subresource.
+func WithSyntheticStatusSubresource() SyntheticAPIOption {
+	return func(api *SyntheticAPI) {
+		api.StatusSubresource = true
+	}
+}
+
+// WithSyntheticScaleSubresource enables the scale subresource on spec.replicas. It implies the status subresource.
+func WithSyntheticScaleSubresource() SyntheticAPIOption {
+	return func(api *SyntheticAPI) {
+		api.StatusSubresource = true
+		api.ScaleSubresource = true
+	}
+}
+
+// NewSyntheticAPI returns a SyntheticAPI for the given group and kind, by default with a single
+// version v1, ten fields of 64 characters and no subresources.
+func NewSyntheticAPI(group, kind string, opts ...SyntheticAPIOption) *SyntheticAPI {
+	api := &SyntheticAPI{
+		Group:     group,
+		Plural:    strings.ToLower(kind) + "s",
+		Kind:      kind,
+		Versions:  []string{"v1"},
+		Fields:    10,
+		FieldSize: 64,
+	}
+	for _, opt := range opts {
+		opt(api)
+	}
+	return api
+}
+
+// StorageVersion returns the version the API is stored in.
+func (api *SyntheticAPI) StorageVersion() string {
+	return api.Versions[len(api.Versions)-1]
+}
+
+// GVR returns the resource of the API in the storage version.
+func (api *SyntheticAPI) GVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: api.Group, Version: api.StorageVersion(), Resource: api.Plural}
+}
+
+// CRD returns a CustomResourceDefinition for the API.
+func (api *SyntheticAPI) CRD() *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s.%s", api.Plural, api.Group),
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: api.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   api.Plural,
+				Singular: strings.ToLower(api.Kind),
+				Kind:     api.Kind,
+				ListKind: api.Kind + "List",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			// all versions share the same schema
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.NoneConverter,
+			},
+		},
+	}
+
+	for _, version := range api.Versions {
+		v := apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:    version,
+			Served:  true,
+			Storage: version == api.StorageVersion(),
+			Schema: &apiextensionsv1.CustomResourceValidation{
+				OpenAPIV3Schema: api.openAPISchema(version),
+			},
+		}
+		if api.StatusSubresource {
+			v.Subresources = &apiextensionsv1.CustomResourceSubresources{
+				Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+			}
+		}
+		if api.ScaleSubresource {
+			v.Subresources.Scale = &apiextensionsv1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+			}
+		}
+		crd.Spec.Versions = append(crd.Spec.Versions, v)
+	}
+
+	return crd
+}
+
+func (api *SyntheticAPI) openAPISchema(version string) *apiextensionsv1.JSONSchemaProps {
+	fields := map[string]apiextensionsv1.JSONSchemaProps{
+		"replicas": {Type: "integer", Format: "int32", Minimum: ptr.To[float64](0)},
+	}
+	for i := 0; i < api.Fields; i++ {
+		fields[fmt.Sprintf("field%d", i)] = apiextensionsv1.JSONSchemaProps{
+			Type:        "string",
+			Description: fmt.Sprintf("field%d of the synthetic %s %s API", i, api.Kind, version),
+		}
+	}
+
+	props := map[string]apiextensionsv1.JSONSchemaProps{
+		"spec": {Type: "object", Properties: fields},
+	}
+	if api.StatusSubresource {
+		props["status"] = apiextensionsv1.JSONSchemaProps{Type: "object", Properties: fields}
+	}
+
+	return &apiextensionsv1.JSONSchemaProps{
+		Type:        "object",
+		Description: fmt.Sprintf("synthetic %s %s", api.Kind, version),
+		Properties:  props,
+	}
+}
+
+// APIResourceSchema returns an APIResourceSchema for the API with the given name prefix.
+func (api *SyntheticAPI) APIResourceSchema(prefix string) (*apisv1alpha1.APIResourceSchema, error) {
+	return apisv1alpha1.CRDToAPIResourceSchema(api.CRD(), prefix)
+}
+
+// Object returns an object of the API in the storage version, with all spec fields filled.
+// The values are derived from name, so objects generated twice are equal.
+func (api *SyntheticAPI) Object(namespace, name string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"replicas": int64(1),
+	}
+	for i := 0; i < api.Fields; i++ {
+		spec[fmt.Sprintf("field%d", i)] = syntheticValue(fmt.Sprintf("%s-%d-", name, i), api.FieldSize)
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": api.Group + "/" + api.StorageVersion(),
+			"kind":       api.Kind,
+			"metadata": map[string]interface{}{
+				"namespace": namespace,
+				"name":      name,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// syntheticValue returns seed repeated up to size characters.
+func syntheticValue(seed string, size int) string {
+	if size <= 0 {
+		return ""
+	}
+	return strings.Repeat(seed, size/len(seed)+1)[:size]
+}
+
+// CreateSyntheticSchemaAndExport creates an apisv1alpha1.APIResourceSchema for the synthetic API and then creates an
+// apisv1alpha1.APIExport named after the group of the API to export it.
+func CreateSyntheticSchemaAndExport(
+	ctx context.Context,
+	t *testing.T,
+	path logicalcluster.Path,
+	clusterClient kcpclientset.ClusterInterface,
+	api *SyntheticAPI,
+) {
+	t.Helper()
+
+	schema, err := api.APIResourceSchema("today")
+	require.NoError(t, err, "error converting synthetic API %s.%s", api.Plural, api.Group)
+
+	t.Logf("Creating APIResourceSchema %s|%s", path, schema.Name)
+	_, err = clusterClient.Cluster(path).ApisV1alpha1().APIResourceSchemas().Create(ctx, schema, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating APIResourceSchema %s|%s", path, schema.Name)
+
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: api.Group,
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{schema.Name},
+		},
+	}
+
+	t.Logf("Creating APIExport %s|%s", path, export.Name)
+	_, err = clusterClient.Cluster(path).ApisV1alpha1().APIExports().Create(ctx, export, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating APIExport %s|%s", path, export.Name)
+}
+
+// CreateSyntheticObjects creates count objects of the synthetic API named <prefix>-0...<prefix>-N in the namespace
+// of the logical cluster identified by clusterName, and returns their names. The API must be served already, or
+// become served within wait.ForeverTestTimeout.
+func CreateSyntheticObjects(
+	ctx context.Context,
+	t *testing.T,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	clusterName logicalcluster.Path,
+	api *SyntheticAPI,
+	namespace, prefix string,
+	count int,
+) []string {
+	t.Helper()
+
+	t.Logf("Creating %d %s %s|%s/%s-*", count, api.GVR(), clusterName, namespace, prefix)
+
+	client := dynamicClusterClient.Cluster(clusterName).Resource(api.GVR()).Namespace(namespace)
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		obj := api.Object(namespace, fmt.Sprintf("%s-%d", prefix, i))
+		if i == 0 {
+			// CRDs are asynchronously served because they are informer based.
+			framework.Eventually(t, func() (bool, string) {
+				if _, err := client.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+					return false, fmt.Sprintf("failed to create %s %s|%s: %v", api.Kind, clusterName, obj.GetName(), err)
+				}
+				return true, ""
+			}, wait.ForeverTestTimeout, time.Millisecond*100, "error creating %s %s|%s", api.Kind, clusterName, obj.GetName())
+		} else {
+			_, err := client.Create(ctx, obj, metav1.CreateOptions{})
+			require.NoError(t, err, "error creating %s %s|%s", api.Kind, clusterName, obj.GetName())
+		}
+		names = append(names, obj.GetName())
+	}
+	return names
+}
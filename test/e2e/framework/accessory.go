@@ -48,11 +48,16 @@ type Accessory struct {
 	artifactDir string
 	name        string
 	cmd         []string
+
+	runOpts []RunOption
+	// stop terminates the current run and waits for it to finish, nil while not running.
+	stop func()
 }
 
 func (a *Accessory) Run(t *testing.T, opts ...RunOption) error {
 	t.Helper()
 
+	a.runOpts = opts
 	runOpts := runOptions{}
 	for _, opt := range opts {
 		opt(&runOpts)
@@ -72,7 +77,8 @@ func (a *Accessory) Run(t *testing.T, opts ...RunOption) error {
 	cmd := exec.CommandContext(ctx, a.cmd[0], a.cmd[1:]...)
 
 	a.t.Logf("running: %v", strings.Join(cmd.Args, " "))
-	logFile, err := os.Create(filepath.Join(a.artifactDir, fmt.Sprintf("%s.log", a.name)))
+	// Append to the log file, so the logs of earlier runs survive a restart.
+	logFile, err := os.OpenFile(filepath.Join(a.artifactDir, fmt.Sprintf("%s.log", a.name)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		cleanupCancel()
 		return fmt.Errorf("could not create log file: %w", err)
@@ -90,8 +96,15 @@ func (a *Accessory) Run(t *testing.T, opts ...RunOption) error {
 		cleanupCancel()
 		return err
 	}
+	done := make(chan struct{})
+	a.stop = func() {
+		cleanupCancel()
+		<-done
+	}
 	go func() {
+		defer close(done)
 		defer cleanupCancel()
+		defer logFile.Close()
 		err := cmd.Wait()
 		if err != nil && ctx.Err() == nil {
 			a.t.Errorf("`%s` failed: %v output: %s", a.name, err, log.String())
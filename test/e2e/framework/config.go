@@ -47,6 +47,9 @@ type testConfig struct {
 	shardKubeconfigs    map[string]string
 	useDefaultKCPServer bool
 	suites              string
+	detectLeaks         bool
+	scenarios           string
+	scenarioShard       string
 }
 
 var TestConfig *testConfig
@@ -86,6 +89,9 @@ func registerFlags(c *testConfig) {
 	flag.Var(cliflag.NewMapStringString(&c.shardKubeconfigs), "shard-kubeconfigs", "Paths to the kubeconfigs for a kcp shard server in the format <shard-name>=<kubeconfig-path>. If unset, kcp-kubeconfig is used.")
 	flag.BoolVar(&c.useDefaultKCPServer, "use-default-kcp-server", false, "Whether to use server configuration from .kcp/admin.kubeconfig.")
 	flag.StringVar(&c.suites, "suites", "control-plane", "A comma-delimited list of suites to run.")
+	flag.StringVar(&c.scenarios, "scenarios", "", "A regular expression selecting the scenarios of scenario suites to run by name.")
+	flag.StringVar(&c.scenarioShard, "scenario-shard", "", "Run only the share of the scenarios of scenario suites given as <index>/<total>, e.g. 0/3.")
+	flag.BoolVar(&c.detectLeaks, "detect-leaks", false, "Whether to fail tests whose fixtures leave workspaces behind after cleanup.")
 }
 
 // WriteLogicalClusterConfig creates a logical cluster config for the given config and
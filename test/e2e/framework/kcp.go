@@ -447,6 +447,14 @@ func WithCustomArguments(args ...string) KcpConfigOption {
 	}
 }
 
+// WithRunInProcess runs the kcp server in the test process instead of a separate process.
+func WithRunInProcess() KcpConfigOption {
+	return func(cfg *kcpConfig) *kcpConfig {
+		cfg.RunInProcess = true
+		return cfg
+	}
+}
+
 // kcpConfig qualify a kcp server to start
 //
 // Deprecated for use outside this package. Prefer PrivateKcpServer().
@@ -478,6 +486,10 @@ type kcpServer struct {
 	cfg            clientcmd.ClientConfig
 	kubeconfigPath string
 
+	runOpts []RunOption
+	// stop terminates the current run and waits for it to finish, nil while not running.
+	stop func()
+
 	t *testing.T
 }
 
@@ -596,6 +608,7 @@ func Command(executableName, identity string) []string {
 // Run runs the kcp server while the parent context is active. This call is not blocking,
 // callers should ensure that the server is Ready() before using it.
 func (c *kcpServer) Run(opts ...RunOption) error {
+	c.runOpts = opts
 	runOpts := runOptions{}
 	for _, opt := range opts {
 		opt(&runOpts)
@@ -623,6 +636,10 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 		c.t.Log("cleanup: received shutdownComplete")
 	})
 	c.ctx = ctx
+	c.stop = func() {
+		cancel()
+		<-shutdownComplete
+	}
 
 	commandLine := append(StartKcpCommand("KCP"), c.args...)
 	c.t.Logf("running: %v", strings.Join(commandLine, " "))
@@ -702,7 +719,8 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 	// the idea!
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	logFile, err := os.Create(filepath.Join(c.artifactDir, "kcp.log"))
+	// Append to the log file, so the logs of earlier runs survive a restart.
+	logFile, err := os.OpenFile(filepath.Join(c.artifactDir, "kcp.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		cleanup()
 		return fmt.Errorf("could not create log file: %w", err)
@@ -733,13 +751,20 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 		return err
 	}
 
-	c.t.Cleanup(func() {
-		// Ensure child process is killed on cleanup - send the negative of the pid, which is the process group id.
+	terminate := func() {
+		// Ensure child process is killed - send the negative of the pid, which is the process group id.
 		// See https://medium.com/@felixge/killing-a-child-process-and-all-of-its-children-in-go-54079af94773 for details.
-		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil {
+		// The process group is gone already if the server was stopped before.
+		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
 			c.t.Errorf("Saw an error trying to kill `kcp`: %v", err)
 		}
-	})
+	}
+	c.t.Cleanup(terminate)
+	c.stop = func() {
+		cancel()
+		terminate()
+		<-shutdownComplete
+	}
 
 	go func() {
 		defer cleanup()
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// leakTimeout is how long objects created by fixtures may take to disappear after cleanup,
+// e.g. while the finalizers of a deleted workspace run.
+const leakTimeout = 60 * time.Second
+
+func detectLeaks() bool {
+	return TestConfig.detectLeaks && !preserveTestResources()
+}
+
+// ExpectGoneAfterCleanup registers a cleanup failing the test if the object is still there after
+// the cleanups registered later, e.g. the deletion of the object by the fixture creating it, have
+// run. The object is gone when get returns a NotFound or Forbidden error, the latter because the
+// logical cluster of the object might be gone as well.
+//
+// This is a no-op unless leak detection is enabled with --detect-leaks.
+func ExpectGoneAfterCleanup(t *testing.T, description string, get func(ctx context.Context) error) {
+	t.Helper()
+
+	if !detectLeaks() {
+		return
+	}
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), leakTimeout)
+		defer cancel()
+
+		var lastErr error
+		err := wait.PollUntilContextCancel(ctx, 100*time.Millisecond, true, func(ctx context.Context) (bool, error) {
+			lastErr = get(ctx)
+			return apierrors.IsNotFound(lastErr) || apierrors.IsForbidden(lastErr), nil
+		})
+		if err == nil {
+			return
+		}
+		if lastErr != nil {
+			t.Errorf("failed to check for leaked %s after cleanup: %v", description, lastErr)
+			return
+		}
+		t.Errorf("leaked %s: still present %s after cleanup", description, leakTimeout)
+	})
+}
+
+// DumpOnFailure registers a cleanup writing the YAML-formatted objects returned by producer to
+// the artifact directory of the test if the test has failed. The cleanup runs before the cleanups
+// registered earlier, i.e. it must be registered after the cleanup deleting the objects.
+//
+// The logs of test-managed servers, including their controllers, are written to the artifact
+// directory of the server independently of the outcome of the test.
+func DumpOnFailure(t *testing.T, producer func(ctx context.Context) ([]runtime.Object, error)) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+		defer cancel()
+
+		objs, err := producer(ctx)
+		if err != nil {
+			// Don't fail the test further if we couldn't collect the objects
+			t.Logf("error collecting artifacts of failed test: %v", err)
+		}
+		if len(objs) == 0 {
+			return
+		}
+
+		artifactDir, err := CreateTempDirForTest(t, filepath.Join("artifacts", "failure"))
+		if err != nil {
+			t.Logf("error creating artifact dir: %v", err)
+			return
+		}
+		for _, obj := range objs {
+			if err := writeArtifact(artifactDir, obj); err != nil {
+				t.Logf("error writing artifact: %v", err)
+			}
+		}
+		t.Logf("Dumped %d objects involved in the failed test to %q", len(objs), artifactDir)
+	})
+}
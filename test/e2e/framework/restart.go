@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RestartableServer is a RunningServer whose process is managed by the test, and that can be
+// stopped and started again during the test, e.g. to check the resilience of controllers or the
+// replication to the cache server. The data directory, including the embedded etcd and the
+// embedded cache server, is preserved across restarts.
+type RestartableServer interface {
+	RunningServer
+
+	// Stop terminates the server and waits for it to shut down.
+	Stop(t *testing.T)
+	// Start starts the stopped server again with the same arguments and waits for it to become ready.
+	Start(t *testing.T)
+}
+
+// RestartServer stops the given server and starts it again, waiting for it to become ready. The test
+// fails if the server is not managed by the test, e.g. a persistent shared server, or runs in-process.
+func RestartServer(t *testing.T, server RunningServer) {
+	t.Helper()
+
+	s, ok := server.(RestartableServer)
+	if !ok {
+		t.Fatalf("kcp server %s is not managed by the test and cannot be restarted", server.Name())
+	}
+	s.Stop(t)
+	s.Start(t)
+}
+
+// Stop terminates the kcp server process and waits for it to shut down.
+func (c *kcpServer) Stop(t *testing.T) {
+	t.Helper()
+
+	require.NoError(t, c.restartable(), "cannot stop kcp server %s", c.name)
+	require.NotNil(t, c.stop, "kcp server %s is not running", c.name)
+
+	t.Logf("Stopping kcp server %s", c.name)
+	c.stop()
+	c.stop = nil
+}
+
+// Start runs the stopped kcp server again with the same arguments and data directory, and waits
+// for it to become ready.
+func (c *kcpServer) Start(t *testing.T) {
+	t.Helper()
+
+	require.NoError(t, c.restartable(), "cannot start kcp server %s", c.name)
+	require.Nil(t, c.stop, "kcp server %s is still running", c.name)
+
+	t.Logf("Starting kcp server %s", c.name)
+	require.NoError(t, c.Run(c.runOpts...))
+	require.NoError(t, c.loadCfg(), "error loading config")
+	require.NoError(t, WaitForReady(c.ctx, t, c.RootShardSystemMasterBaseConfig(t), true), "kcp server %s never became ready", c.name)
+}
+
+// restartable returns an error if the server cannot be stopped and started again. The global
+// state of an in-process server, e.g. registered metrics, does not survive a second run.
+func (c *kcpServer) restartable() error {
+	runOpts := runOptions{}
+	for _, opt := range c.runOpts {
+		opt(&runOpts)
+	}
+	if runOpts.runInProcess {
+		return fmt.Errorf("kcp server %s runs in-process", c.name)
+	}
+	return nil
+}
+
+// Stop terminates the accessory process, e.g. a standalone cache server or front-proxy, and waits
+// for it to exit.
+func (a *Accessory) Stop(t *testing.T) {
+	t.Helper()
+
+	require.NotNil(t, a.stop, "accessory %s is not running", a.name)
+
+	t.Logf("Stopping accessory %s", a.name)
+	a.stop()
+	a.stop = nil
+}
+
+// Start runs the stopped accessory again with the same command line. Callers are responsible for
+// waiting for the accessory to become ready.
+func (a *Accessory) Start(t *testing.T) {
+	t.Helper()
+
+	require.Nil(t, a.stop, "accessory %s is still running", a.name)
+
+	t.Logf("Starting accessory %s", a.name)
+	require.NoError(t, a.Run(t, a.runOpts...))
+}
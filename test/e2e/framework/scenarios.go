@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Scenario is a named test case run by a ScenarioSuite against the environment E
+// prepared for a server topology.
+type Scenario[E any] struct {
+	Name string
+	Work func(ctx context.Context, t *testing.T, env E)
+}
+
+// Topology provides the kcp server the scenarios of a suite run against.
+type Topology struct {
+	Name string
+	// Private makes every scenario run against a server of its own, e.g. for scenarios
+	// disrupting the server, instead of a server shared by all scenarios.
+	Private bool
+	Server  func(t *testing.T) RunningServer
+}
+
+// SharedServerTopology runs all scenarios against the server returned by SharedKcpServer,
+// i.e. a persistent server with standalone components if one is configured.
+func SharedServerTopology() Topology {
+	return Topology{
+		Name:   "shared",
+		Server: SharedKcpServer,
+	}
+}
+
+// PrivateServerTopology runs every scenario against a test-managed kcp process of its own.
+func PrivateServerTopology(options ...KcpConfigOption) Topology {
+	return Topology{
+		Name:    "private",
+		Private: true,
+		Server: func(t *testing.T) RunningServer {
+			t.Helper()
+			return PrivateKcpServer(t, options...)
+		},
+	}
+}
+
+// InProcessServerTopology runs all scenarios against a single kcp server running in the
+// test process, for easier debugging. Because of the global state of kcp, e.g. registered
+// metrics, it should not be combined with other in-process servers in the same test binary.
+func InProcessServerTopology(options ...KcpConfigOption) Topology {
+	return Topology{
+		Name: "in-process",
+		Server: func(t *testing.T) RunningServer {
+			t.Helper()
+			return PrivateKcpServer(t, append(options, WithRunInProcess())...)
+		},
+	}
+}
+
+// ScenarioSuite runs the same scenarios against each of the given server topologies, as
+// subtests named <topology>/<scenario>. The scenarios to run can be narrowed down with the
+// --scenarios and --scenario-shard flags.
+type ScenarioSuite[E any] struct {
+	Scenarios  []Scenario[E]
+	Topologies []Topology
+	// Environment prepares the clients and fixtures for the scenarios from a server. It is
+	// called once per topology, or once per scenario for private topologies.
+	Environment func(ctx context.Context, t *testing.T, server RunningServer) E
+	// Serial runs the topologies and scenarios one after the other instead of in parallel.
+	Serial bool
+}
+
+// Run runs the selected scenarios of the suite against all topologies.
+func (s ScenarioSuite[E]) Run(t *testing.T) {
+	t.Helper()
+
+	selected, err := TestConfig.selectScenarios(scenarioNames(s.Scenarios))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, topology := range s.Topologies {
+		topology := topology
+
+		t.Run(topology.Name, func(t *testing.T) {
+			if !s.Serial {
+				t.Parallel()
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			var env E
+			if !topology.Private {
+				env = s.Environment(ctx, t, topology.Server(t))
+			}
+
+			for i, scenario := range s.Scenarios {
+				if !selected[i] {
+					continue
+				}
+				scenario := scenario
+
+				t.Run(scenario.Name, func(t *testing.T) {
+					if !s.Serial {
+						t.Parallel()
+					}
+
+					env := env
+					if topology.Private {
+						env = s.Environment(ctx, t, topology.Server(t))
+					}
+					scenario.Work(ctx, t, env)
+				})
+			}
+		})
+	}
+}
+
+func scenarioNames[E any](scenarios []Scenario[E]) []string {
+	names := make([]string, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		names = append(names, scenario.Name)
+	}
+	return names
+}
+
+// selectScenarios returns which of the named scenarios match --scenarios and belong to the
+// shard given by --scenario-shard. Scenarios are assigned to shards round-robin in the order
+// of the suite, so every shard of a CI job runs a stable subset.
+func (c *testConfig) selectScenarios(names []string) ([]bool, error) {
+	filter := regexp.MustCompile("")
+	if c.scenarios != "" {
+		var err error
+		if filter, err = regexp.Compile(c.scenarios); err != nil {
+			return nil, fmt.Errorf("invalid --scenarios %q: %w", c.scenarios, err)
+		}
+	}
+
+	index, total := 0, 1
+	if c.scenarioShard != "" {
+		var err error
+		if index, total, err = parseScenarioShard(c.scenarioShard); err != nil {
+			return nil, err
+		}
+	}
+
+	selected := make([]bool, len(names))
+	for i, name := range names {
+		selected[i] = i%total == index && filter.MatchString(name)
+	}
+	return selected, nil
+}
+
+func parseScenarioShard(s string) (int, int, error) {
+	indexStr, totalStr, found := strings.Cut(s, "/")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid --scenario-shard %q: expected <index>/<total>", s)
+	}
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --scenario-shard %q: %w", s, err)
+	}
+	total, err := strconv.Atoi(totalStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --scenario-shard %q: %w", s, err)
+	}
+	if total < 1 || index < 0 || index >= total {
+		return 0, 0, fmt.Errorf("invalid --scenario-shard %q: expected 0 <= index < total", s)
+	}
+	return index, total, nil
+}
@@ -192,43 +192,59 @@ func artifact(t *testing.T, server RunningServer, producer func() (runtime.Objec
 	t.Cleanup(func() {
 		data, err := producer()
 		require.NoError(t, err, "error fetching artifact")
+		require.NoError(t, writeArtifact(artifactDir, data))
+	})
+}
 
-		accessor, ok := data.(metav1.Object)
-		require.True(t, ok, "artifact has no object meta: %#v", data)
+// writeArtifact writes the YAML-formatted object to a file below artifactDir, in
+// a directory per logical cluster and namespace.
+func writeArtifact(artifactDir string, data runtime.Object) error {
+	accessor, ok := data.(metav1.Object)
+	if !ok {
+		return fmt.Errorf("artifact has no object meta: %#v", data)
+	}
 
-		dir := path.Join(artifactDir, logicalcluster.From(accessor).String())
-		dir = strings.ReplaceAll(dir, ":", "_") // github actions don't like colon because NTFS is unhappy with it in path names
-		if accessor.GetNamespace() != "" {
-			dir = path.Join(dir, accessor.GetNamespace())
-		}
-		err = os.MkdirAll(dir, 0755)
-		require.NoError(t, err, "could not create dir")
+	dir := path.Join(artifactDir, logicalcluster.From(accessor).String())
+	dir = strings.ReplaceAll(dir, ":", "_") // github actions don't like colon because NTFS is unhappy with it in path names
+	if accessor.GetNamespace() != "" {
+		dir = path.Join(dir, accessor.GetNamespace())
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("could not create dir: %w", err)
+	}
 
-		gvks, _, err := kubernetesscheme.Scheme.ObjectKinds(data)
-		if err != nil {
-			gvks, _, err = kcpscheme.Scheme.ObjectKinds(data)
-		}
-		require.NoError(t, err, "error finding gvk for artifact")
-		require.NotEmpty(t, gvks, "found no gvk for artifact: %T", data)
-		gvk := gvks[0]
-		data.GetObjectKind().SetGroupVersionKind(gvk)
-
-		group := gvk.Group
-		if group == "" {
-			group = "core"
-		}
+	gvks, _, err := kubernetesscheme.Scheme.ObjectKinds(data)
+	if err != nil {
+		gvks, _, err = kcpscheme.Scheme.ObjectKinds(data)
+	}
+	if err != nil {
+		return fmt.Errorf("error finding gvk for artifact: %w", err)
+	}
+	if len(gvks) == 0 {
+		return fmt.Errorf("found no gvk for artifact: %T", data)
+	}
+	gvk := gvks[0]
+	data.GetObjectKind().SetGroupVersionKind(gvk)
+
+	group := gvk.Group
+	if group == "" {
+		group = "core"
+	}
 
-		gvkForFilename := fmt.Sprintf("%s_%s", group, gvk.Kind)
+	gvkForFilename := fmt.Sprintf("%s_%s", group, gvk.Kind)
 
-		file := path.Join(dir, fmt.Sprintf("%s-%s.yaml", gvkForFilename, accessor.GetName()))
-		file = strings.ReplaceAll(file, ":", "_") // github actions don't like colon because NTFS is unhappy with it in path names
+	file := path.Join(dir, fmt.Sprintf("%s-%s.yaml", gvkForFilename, accessor.GetName()))
+	file = strings.ReplaceAll(file, ":", "_") // github actions don't like colon because NTFS is unhappy with it in path names
 
-		bs, err := yaml.Marshal(data)
-		require.NoError(t, err, "error marshalling artifact")
+	bs, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshalling artifact: %w", err)
+	}
 
-		err = os.WriteFile(file, bs, 0644)
-		require.NoError(t, err, "error writing artifact")
-	})
+	if err := os.WriteFile(file, bs, 0644); err != nil {
+		return fmt.Errorf("error writing artifact: %w", err)
+	}
+	return nil
 }
 
 // GetFreePort asks the kernel for a free open port that is ready to use.
@@ -28,6 +28,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/authentication/user"
 
@@ -157,6 +158,18 @@ func newWorkspaceFixture[O WorkspaceOption](t *testing.T, createClusterClient, c
 	}, wait.ForeverTestTimeout, time.Millisecond*100, "failed to create %s workspace under %s", tmpl.Spec.Type.Name, parent)
 
 	wsName := ws.Name
+	var clusterName logicalcluster.Name // set once the workspace is scheduled
+	ExpectGoneAfterCleanup(t, fmt.Sprintf("LogicalCluster of workspace %s", parent.Join(wsName)), func(ctx context.Context) error {
+		if clusterName.Empty() {
+			return apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+		}
+		_, err := clusterClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+		return err
+	})
+	ExpectGoneAfterCleanup(t, fmt.Sprintf("workspace %s", parent.Join(wsName)), func(ctx context.Context) error {
+		_, err := clusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, wsName, metav1.GetOptions{})
+		return err
+	})
 	t.Cleanup(func() {
 		if preserveTestResources() {
 			return
@@ -171,12 +184,36 @@ func newWorkspaceFixture[O WorkspaceOption](t *testing.T, createClusterClient, c
 		}
 		require.NoErrorf(t, err, "failed to delete workspace %s", wsName)
 	})
+	DumpOnFailure(t, func(ctx context.Context) ([]runtime.Object, error) {
+		ws, err := clusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, wsName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		objs := []runtime.Object{ws}
+		if clusterName.Empty() {
+			return objs, nil
+		}
+		lc, err := clusterClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+		if err != nil {
+			return objs, err
+		}
+		objs = append(objs, lc)
+		bindings, err := clusterClient.Cluster(clusterName.Path()).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return objs, err
+		}
+		for i := range bindings.Items {
+			objs = append(objs, &bindings.Items[i])
+		}
+		return objs, nil
+	})
 
 	Eventually(t, func() (bool, string) {
 		var err error
 		ws, err = clusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, ws.Name, metav1.GetOptions{})
 		require.Falsef(t, apierrors.IsNotFound(err), "workspace %s was deleted", parent.Join(ws.Name))
 		require.NoError(t, err, "failed to get workspace %s", parent.Join(ws.Name))
+		clusterName = logicalcluster.Name(ws.Spec.Cluster)
 		if actual, expected := ws.Status.Phase, corev1alpha1.LogicalClusterPhaseReady; actual != expected {
 			return false, fmt.Sprintf("workspace phase is %s, not %s", actual, expected)
 		}
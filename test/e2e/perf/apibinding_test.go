@@ -0,0 +1,134 @@
+//go:build perf
+
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package perf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/apifixtures"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestAPIBindingLatency measures the time from creating an APIBinding until the initial binding
+// has completed, i.e. the bound API is served in the consumer workspace.
+func TestAPIBindingLatency(t *testing.T) {
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	providerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	t.Logf("Creating %d APIExports in %s", count, providerPath)
+	groups := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		api := apifixtures.NewSyntheticAPI(fmt.Sprintf("perf%d.example.io", i), "Widget")
+		apifixtures.CreateSyntheticSchemaAndExport(ctx, t, providerPath, kcpClusterClient, api)
+		groups = append(groups, api.Group)
+	}
+	for _, group := range groups {
+		framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+			return kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Get(ctx, group, metav1.GetOptions{})
+		}, framework.Is(apisv1alpha1.APIExportIdentityValid))
+	}
+
+	bindings := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings()
+
+	var lock sync.Mutex
+	created := map[string]time.Time{}
+	done := map[string]chan time.Time{}
+	for _, group := range groups {
+		done[group] = make(chan time.Time, 1)
+	}
+
+	watcher, err := bindings.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to watch APIBindings in %s", consumerPath)
+	t.Cleanup(watcher.Stop)
+	go func() {
+		completed := map[string]bool{}
+		for event := range watcher.ResultChan() {
+			binding, ok := event.Object.(*apisv1alpha1.APIBinding)
+			if !ok || completed[binding.Name] || !conditions.IsTrue(binding, apisv1alpha1.InitialBindingCompleted) {
+				continue
+			}
+			if ch, found := done[binding.Name]; found {
+				completed[binding.Name] = true
+				ch <- time.Now()
+			}
+		}
+	}()
+
+	ready := newLatencies("APIBindingReady")
+
+	t.Logf("Binding %d APIExports in %s with concurrency %d", count, consumerPath, concurrency)
+	runConcurrently(concurrency, count, func(i int) {
+		group := groups[i]
+		lock.Lock()
+		created[group] = time.Now()
+		lock.Unlock()
+
+		_, err := bindings.Create(ctx, &apisv1alpha1.APIBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: group},
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{
+					Export: &apisv1alpha1.ExportBindingReference{
+						Path: providerPath.String(),
+						Name: group,
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			t.Logf("Failed to create APIBinding %s|%s: %v", consumerPath, group, err)
+			ready.fail()
+			return
+		}
+
+		select {
+		case completedAt := <-done[group]:
+			lock.Lock()
+			defer lock.Unlock()
+			ready.observe(completedAt.Sub(created[group]))
+		case <-time.After(timeout):
+			t.Logf("APIBinding %s|%s did not complete within %s", consumerPath, group, timeout)
+			ready.fail()
+		}
+	})
+
+	writeResults(t, ready.result(concurrency))
+	if r := ready.result(concurrency); r.Errors > 0 {
+		t.Errorf("%d of %d APIBindings failed to complete", r.Errors, count)
+	}
+}
@@ -0,0 +1,168 @@
+//go:build perf
+
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package perf measures end-to-end latencies of kcp operations. The tests are opt-in with the
+// perf build tag, and write their results as JSON for regression tracking:
+//
+//	go test -tags perf ./test/e2e/perf -args --perf-concurrency=20 --perf-count=200 --perf-results-dir=/tmp/perf
+package perf
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+var (
+	concurrency int
+	count       int
+	timeout     time.Duration
+	resultsDir  string
+)
+
+func init() {
+	flag.IntVar(&concurrency, "perf-concurrency", 10, "Number of operations run in parallel.")
+	flag.IntVar(&count, "perf-count", 50, "Number of operations measured per test.")
+	flag.DurationVar(&timeout, "perf-timeout", 2*time.Minute, "Time after which an operation counts as failed.")
+	flag.StringVar(&resultsDir, "perf-results-dir", "", "Directory to write the JSON results to. Defaults to the artifact directory of the test.")
+}
+
+// Result summarizes the latencies of one measured step.
+type Result struct {
+	Name        string  `json:"name"`
+	Concurrency int     `json:"concurrency"`
+	Samples     int     `json:"samples"`
+	Errors      int     `json:"errors"`
+	MinMillis   float64 `json:"minMillis"`
+	P50Millis   float64 `json:"p50Millis"`
+	P90Millis   float64 `json:"p90Millis"`
+	P99Millis   float64 `json:"p99Millis"`
+	MaxMillis   float64 `json:"maxMillis"`
+}
+
+// latencies collects the latencies of a measured step, concurrently.
+type latencies struct {
+	name string
+
+	lock    sync.Mutex
+	samples []time.Duration
+	errors  int
+}
+
+func newLatencies(name string) *latencies {
+	return &latencies{name: name}
+}
+
+func (l *latencies) observe(d time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.samples = append(l.samples, d)
+}
+
+func (l *latencies) fail() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.errors++
+}
+
+func (l *latencies) result(concurrency int) Result {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	samples := append([]time.Duration(nil), l.samples...)
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	r := Result{Name: l.name, Concurrency: concurrency, Samples: len(samples), Errors: l.errors}
+	if len(samples) == 0 {
+		return r
+	}
+	percentile := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(samples)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return millis(samples[i])
+	}
+	r.MinMillis = millis(samples[0])
+	r.P50Millis = percentile(0.5)
+	r.P90Millis = percentile(0.9)
+	r.P99Millis = percentile(0.99)
+	r.MaxMillis = millis(samples[len(samples)-1])
+	return r
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// runConcurrently calls work for the indexes 0...count-1 from the given number of workers.
+func runConcurrently(concurrency, count int, work func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// writeResults logs the results and writes them as JSON to <results-dir>/<test>.json, with the
+// artifact directory of the test as the default results directory.
+func writeResults(t *testing.T, results ...Result) {
+	t.Helper()
+
+	for _, r := range results {
+		t.Logf("%s: concurrency=%d samples=%d errors=%d p50=%.0fms p90=%.0fms p99=%.0fms max=%.0fms",
+			r.Name, r.Concurrency, r.Samples, r.Errors, r.P50Millis, r.P90Millis, r.P99Millis, r.MaxMillis)
+	}
+
+	dir := resultsDir
+	if dir == "" {
+		var err error
+		dir, err = framework.CreateTempDirForTest(t, "perf")
+		require.NoError(t, err, "failed to create results dir")
+	}
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	bs, err := json.MarshalIndent(results, "", "  ")
+	require.NoError(t, err)
+	file := filepath.Join(dir, fmt.Sprintf("%s.json", strings.ReplaceAll(t.Name(), "/", "_")))
+	require.NoError(t, os.WriteFile(file, bs, 0644))
+	t.Logf("Wrote results to %q", file)
+}
@@ -0,0 +1,152 @@
+//go:build perf
+
+/*
+Copyright 2024 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package perf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// workspaceSample records when a workspace created by the test reached its phases.
+type workspaceSample struct {
+	created      time.Time
+	initializing time.Time
+	ready        time.Time
+	done         chan struct{}
+}
+
+// TestWorkspaceCreationLatency measures the time from creating a workspace until it is scheduled
+// to a shard, and until its initializers have completed and it is ready.
+func TestWorkspaceCreationLatency(t *testing.T) {
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+	workspaces := kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces()
+
+	var lock sync.Mutex
+	samples := map[string]*workspaceSample{}
+
+	watcher, err := workspaces.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to watch workspaces in %s", orgPath)
+	t.Cleanup(watcher.Stop)
+	go func() {
+		for event := range watcher.ResultChan() {
+			ws, ok := event.Object.(*tenancyv1alpha1.Workspace)
+			if !ok {
+				continue
+			}
+			now := time.Now()
+
+			lock.Lock()
+			s, found := samples[ws.Name]
+			if found {
+				switch ws.Status.Phase {
+				case corev1alpha1.LogicalClusterPhaseInitializing:
+					if s.initializing.IsZero() {
+						s.initializing = now
+					}
+				case corev1alpha1.LogicalClusterPhaseReady:
+					if s.initializing.IsZero() {
+						s.initializing = now
+					}
+					if s.ready.IsZero() {
+						s.ready = now
+						close(s.done)
+					}
+				}
+			}
+			lock.Unlock()
+		}
+	}()
+
+	scheduled := newLatencies("WorkspaceScheduled")
+	initialized := newLatencies("WorkspaceInitialized")
+	ready := newLatencies("WorkspaceReady")
+
+	t.Logf("Creating %d workspaces in %s with concurrency %d", count, orgPath, concurrency)
+	runConcurrently(concurrency, count, func(i int) {
+		name := fmt.Sprintf("perf-%d", i)
+		s := &workspaceSample{done: make(chan struct{})}
+		lock.Lock()
+		samples[name] = s
+		s.created = time.Now()
+		lock.Unlock()
+
+		_, err := workspaces.Create(ctx, &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: tenancyv1alpha1.WorkspaceSpec{
+				Type: tenancyv1alpha1.WorkspaceTypeReference{
+					Name: "universal",
+					Path: "root",
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			t.Logf("Failed to create workspace %s: %v", orgPath.Join(name), err)
+			ready.fail()
+			return
+		}
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+			defer cancel()
+			if err := workspaces.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				t.Logf("Failed to delete workspace %s: %v", orgPath.Join(name), err)
+			}
+		})
+
+		select {
+		case <-s.done:
+		case <-time.After(timeout):
+			t.Logf("Workspace %s did not become ready within %s", orgPath.Join(name), timeout)
+			ready.fail()
+			return
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		scheduled.observe(s.initializing.Sub(s.created))
+		initialized.observe(s.ready.Sub(s.initializing))
+		ready.observe(s.ready.Sub(s.created))
+	})
+
+	writeResults(t, scheduled.result(concurrency), initialized.result(concurrency), ready.result(concurrency))
+	if r := ready.result(concurrency); r.Errors > 0 {
+		t.Errorf("%d of %d workspaces failed to become ready", r.Errors, count)
+	}
+}
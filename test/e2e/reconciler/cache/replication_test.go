@@ -52,26 +52,60 @@ import (
 	"github.com/kcp-dev/kcp/test/e2e/framework"
 )
 
-type testScenario struct {
-	name string
-	work func(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface)
+// replicationEnvironment holds the clients shared by the scenarios run against a server.
+type replicationEnvironment struct {
+	server                       framework.RunningServer
+	kcpShardClusterDynamicClient kcpdynamic.ClusterInterface
+	cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface
+}
+
+type testScenario = framework.Scenario[replicationEnvironment]
+
+func scenario(name string, work func(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface)) testScenario {
+	return testScenario{
+		Name: name,
+		Work: func(ctx context.Context, t *testing.T, env replicationEnvironment) {
+			t.Helper()
+			work(ctx, t, env.server, env.kcpShardClusterDynamicClient, env.cacheKcpClusterDynamicClient)
+		},
+	}
+}
+
+// newReplicationEnvironment creates the clients for the root shard of the server and its cache server.
+func newReplicationEnvironment(ctx context.Context, t *testing.T, server framework.RunningServer) replicationEnvironment {
+	t.Helper()
+
+	kcpRootShardConfig := server.RootShardSystemMasterBaseConfig(t)
+	kcpShardDynamicClient, err := kcpdynamic.NewForConfig(kcpRootShardConfig)
+	require.NoError(t, err)
+	cacheClientConfig := createCacheClientConfigForEnvironment(ctx, t, kcpRootShardConfig)
+	cacheClientRT := ClientRoundTrippersFor(cacheClientConfig)
+	cacheKcpClusterDynamicClient, err := kcpdynamic.NewForConfig(cacheClientRT)
+	require.NoError(t, err)
+
+	return replicationEnvironment{
+		server:                       server,
+		kcpShardClusterDynamicClient: kcpShardDynamicClient,
+		cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient,
+	}
 }
 
 // scenarios all test scenarios that will be run against an environment provided by the test binary.
 var scenarios = []testScenario{
-	{"TestReplicateAPIExport", replicateAPIExportScenario},
-	{"TestReplicateAPIExportNegative", replicateAPIExportNegativeScenario},
-	{"TestReplicateAPIResourceSchema", replicateAPIResourceSchemaScenario},
-	{"TestReplicateAPIResourceSchemaNegative", replicateAPIResourceSchemaNegativeScenario},
-	{"TestReplicateWorkspaceType", replicateWorkspaceTypeScenario},
-	{"TestReplicateWorkspaceTypeNegative", replicateWorkspaceTypeNegativeScenario},
+	scenario("TestReplicateAPIExport", replicateAPIExportScenario),
+	scenario("TestReplicateAPIExportNegative", replicateAPIExportNegativeScenario),
+	scenario("TestReplicateAPIResourceSchema", replicateAPIResourceSchemaScenario),
+	scenario("TestReplicateAPIResourceSchemaNegative", replicateAPIResourceSchemaNegativeScenario),
+	scenario("TestReplicateWorkspaceType", replicateWorkspaceTypeScenario),
+	scenario("TestReplicateWorkspaceTypeNegative", replicateWorkspaceTypeNegativeScenario),
 }
 
 // disruptiveScenarios contains a list of scenarios that will be run in a private environment
 // so that they don't disrupt other tests.
 var disruptiveScenarios = []testScenario{
-	{"TestReplicateShard", replicateShardScenario},
-	{"TestReplicateShardNegative", replicateShardNegativeScenario},
+	scenario("TestReplicateShard", replicateShardScenario),
+	scenario("TestReplicateShardNegative", replicateShardNegativeScenario),
+	scenario("TestReplicateAfterRestart", replicateAfterRestartScenario),
 }
 
 // replicateAPIResourceSchemaScenario tests if an APIResourceSchema is propagated to the cache server.
@@ -241,6 +275,33 @@ func replicateShardScenario(ctx context.Context, t *testing.T, server framework.
 	)
 }
 
+// replicateAfterRestartScenario checks that cached objects survive a restart of kcp with its embedded
+// cache server, and that the replication catches up with changes made after the restart.
+func replicateAfterRestartScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
+	t.Helper()
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	_, ws := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithRootShard())
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+	resourceName := withPseudoRandomSuffix("wild.wild.west")
+	scenario := &replicateResourceScenario{resourceName: resourceName, kind: "APIExport", gvr: apisv1alpha1.SchemeGroupVersion.WithResource("apiexports"), cluster: clusterName, server: server, kcpShardClusterDynamicClient: kcpShardClusterDynamicClient, cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient}
+
+	t.Logf("Create source APIExport %s/%s on the root shard for replication", clusterName, resourceName)
+	scenario.CreateSourceResource(ctx, t, &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: resourceName}})
+	scenario.VerifyReplication(ctx, t)
+
+	t.Logf("Restart kcp and verify that the cached APIExport %s/%s is still there", clusterName, resourceName)
+	framework.RestartServer(t, server)
+	scenario.VerifyReplication(ctx, t)
+
+	t.Logf("Change the spec on source APIExport %s/%s and verify that the update is propagated after the restart", clusterName, resourceName)
+	scenario.UpdateSpecSourceResource(ctx, t, &apisv1alpha1.APIExport{Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"foo.bar"}}})
+	scenario.VerifyReplication(ctx, t)
+
+	t.Logf("Verify that deleting source APIExport %s/%s leads to removal of the cached object", clusterName, resourceName)
+	scenario.DeleteSourceResourceAndVerify(ctx, t)
+}
+
 // replicateShardNegativeScenario checks if modified or even deleted cached Shard will be reconciled to match the original object.
 func replicateShardNegativeScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
 	t.Helper()
@@ -424,25 +485,11 @@ func TestReplication(t *testing.T) {
 	t.Parallel()
 	framework.Suite(t, "control-plane")
 
-	server := framework.SharedKcpServer(t)
-	ctx, cancel := context.WithCancel(context.Background())
-	t.Cleanup(cancel)
-
-	kcpRootShardConfig := server.RootShardSystemMasterBaseConfig(t)
-	kcpShardDynamicClient, err := kcpdynamic.NewForConfig(kcpRootShardConfig)
-	require.NoError(t, err)
-	cacheClientConfig := createCacheClientConfigForEnvironment(ctx, t, kcpRootShardConfig)
-	cacheClientRT := ClientRoundTrippersFor(cacheClientConfig)
-	cacheKcpClusterDynamicClient, err := kcpdynamic.NewForConfig(cacheClientRT)
-	require.NoError(t, err)
-
-	for _, scenario := range scenarios {
-		scenario := scenario
-		t.Run(scenario.name, func(t *testing.T) {
-			t.Parallel()
-			scenario.work(ctx, t, server, kcpShardDynamicClient, cacheKcpClusterDynamicClient)
-		})
-	}
+	framework.ScenarioSuite[replicationEnvironment]{
+		Scenarios:   scenarios,
+		Topologies:  []framework.Topology{framework.SharedServerTopology()},
+		Environment: newReplicationEnvironment,
+	}.Run(t)
 }
 
 // TestReplicationDisruptive runs each disruptive test in its own private environment.
@@ -450,28 +497,20 @@ func TestReplicationDisruptive(t *testing.T) {
 	t.Parallel()
 	framework.Suite(t, "control-plane")
 
-	for _, scenario := range disruptiveScenarios {
-		scenario := scenario
-
-		t.Run(scenario.name, func(t *testing.T) {
-			t.Parallel()
-
-			tokenAuthFile := framework.WriteTokenAuthFile(t)
-			server := framework.PrivateKcpServer(t,
-				framework.WithCustomArguments(framework.TestServerArgsWithTokenAuthFile(tokenAuthFile)...))
-			ctx, cancel := context.WithCancel(context.Background())
-			t.Cleanup(cancel)
-
-			kcpRootShardConfig := server.RootShardSystemMasterBaseConfig(t)
-			kcpRootShardDynamicClient, err := kcpdynamic.NewForConfig(kcpRootShardConfig)
-			require.NoError(t, err)
-			cacheClientRT := ClientRoundTrippersFor(kcpRootShardConfig)
-			cacheKcpClusterDynamicClient, err := kcpdynamic.NewForConfig(cacheClientRT)
-			require.NoError(t, err)
-
-			scenario.work(ctx, t, server, kcpRootShardDynamicClient, cacheKcpClusterDynamicClient)
-		})
-	}
+	framework.ScenarioSuite[replicationEnvironment]{
+		Scenarios: disruptiveScenarios,
+		Topologies: []framework.Topology{{
+			Name:    "private",
+			Private: true,
+			Server: func(t *testing.T) framework.RunningServer {
+				t.Helper()
+				tokenAuthFile := framework.WriteTokenAuthFile(t)
+				return framework.PrivateKcpServer(t,
+					framework.WithCustomArguments(framework.TestServerArgsWithTokenAuthFile(tokenAuthFile)...))
+			},
+		}},
+		Environment: newReplicationEnvironment,
+	}.Run(t)
 }
 
 // replicateResourceScenario an auxiliary struct that is used by all test scenarios defined in this pkg.